@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"maps"
 	"os"
 	"strconv"
 	"strings"
@@ -19,6 +20,11 @@ var version = "dev"
 const (
 	defaultWoltHTTPMinInterval = 220 * time.Millisecond
 	woltHTTPMinIntervalEnv     = "WOLT_HTTP_MIN_INTERVAL_MS"
+	woltHTTPFamilyIntervalsEnv = "WOLT_HTTP_FAMILY_MIN_INTERVAL_MS"
+	woltRecordDirEnv           = "WOLT_RECORD_DIR"
+	woltClientHeadersEnv       = "WOLT_CLIENT_HEADERS"
+	woltMemoryCacheSizeEnv     = "WOLT_MEMORY_CACHE_SIZE"
+	woltMemoryCacheTTLsEnv     = "WOLT_MEMORY_CACHE_TTL_MS"
 )
 
 func main() {
@@ -27,10 +33,29 @@ func main() {
 		_, _ = os.Stderr.WriteString(err.Error() + "\n")
 		os.Exit(1)
 	}
+	clientHeaders, err := woltgateway.ParseClientHeaders(os.Getenv(woltClientHeadersEnv))
+	if err != nil {
+		_, _ = os.Stderr.WriteString(woltClientHeadersEnv + ": " + err.Error() + "\n")
+		os.Exit(1)
+	}
+	familyIntervals, err := resolveWoltFamilyMinIntervals(store)
+	if err != nil {
+		_, _ = os.Stderr.WriteString(woltHTTPFamilyIntervalsEnv + ": " + err.Error() + "\n")
+		os.Exit(1)
+	}
+	cacheTTLs, err := woltgateway.ParseFamilyCacheTTLs(os.Getenv(woltMemoryCacheTTLsEnv))
+	if err != nil {
+		_, _ = os.Stderr.WriteString(woltMemoryCacheTTLsEnv + ": " + err.Error() + "\n")
+		os.Exit(1)
+	}
 
 	deps := cli.Dependencies{
 		Wolt: woltgateway.NewClient(
 			woltgateway.WithRequestMinInterval(resolveWoltRequestMinInterval()),
+			woltgateway.WithFamilyMinIntervals(familyIntervals),
+			woltgateway.WithRecordDir(os.Getenv(woltRecordDirEnv)),
+			woltgateway.WithClientHeaders(clientHeaders),
+			woltgateway.WithResponseCache(resolveWoltMemoryCacheSize(), cacheTTLs),
 		),
 		Profiles: profile.NewResolver(store),
 		Location: locationgateway.NewClient(),
@@ -53,3 +78,31 @@ func resolveWoltRequestMinInterval() time.Duration {
 	}
 	return time.Duration(ms) * time.Millisecond
 }
+
+// resolveWoltMemoryCacheSize reads WOLT_MEMORY_CACHE_SIZE, the number of
+// responses the in-process cache keeps; unset or invalid leaves it off.
+func resolveWoltMemoryCacheSize() int {
+	size, err := strconv.Atoi(strings.TrimSpace(os.Getenv(woltMemoryCacheSizeEnv)))
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
+
+// resolveWoltFamilyMinIntervals reads per-family pacing from the config file's
+// http.min_interval_ms; WOLT_HTTP_FAMILY_MIN_INTERVAL_MS overrides it family
+// by family. An unreadable config leaves the error to the command.
+func resolveWoltFamilyMinIntervals(store *config.Store) (map[string]time.Duration, error) {
+	intervals := map[string]time.Duration{}
+	if cfg, err := store.Load(context.Background()); err == nil && cfg.HTTP != nil {
+		for family, ms := range cfg.HTTP.MinIntervalMS {
+			intervals[family] = time.Duration(ms) * time.Millisecond
+		}
+	}
+	overrides, err := woltgateway.ParseFamilyMinIntervals(os.Getenv(woltHTTPFamilyIntervalsEnv))
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(intervals, overrides)
+	return intervals, nil
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/approval"
+	"github.com/mekedron/wolt-cli/internal/service/money"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const approvalDirEnv = "WOLT_APPROVAL_DIR"
+
+func addApproveTokenFlag(cmd *cobra.Command, token *string) {
+	cmd.Flags().StringVar(token, "approve-token", "", "Single-use token from wolt approve for a total above the profile's approval threshold.")
+}
+
+func openApprovalStore(deps Dependencies) (*approval.Store, error) {
+	dir, err := stateDir(deps, approvalDirEnv, "approvals")
+	if err != nil {
+		return nil, err
+	}
+	return approval.NewStore(dir), nil
+}
+
+// parseApprovalThreshold reads "AMOUNT CURRENCY" (or "AMOUNTCURRENCY", such
+// as 50EUR); empty clears the threshold.
+func parseApprovalThreshold(value string) (*domain.ApprovalPolicy, error) {
+	value = strings.ToUpper(strings.Join(strings.Fields(value), ""))
+	if value == "" {
+		return nil, nil
+	}
+	if len(value) > 3 {
+		currency := value[len(value)-3:]
+		if currencyCodePattern.MatchString(currency) {
+			if amount, err := money.ParseMajor(value[:len(value)-3], currency); err == nil && amount > 0 {
+				return &domain.ApprovalPolicy{Threshold: amount, Currency: currency}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("--approval-threshold must be an amount and currency such as \"50 EUR\"")
+}
+
+// basketLinesTotal is the total of upsert lines: count times the unit price
+// plus selected option values.
+func basketLinesTotal(items []any) int {
+	total := 0
+	for _, value := range items {
+		line := asMap(value)
+		unit := asInt(line["price"])
+		for _, option := range asSlice(line["options"]) {
+			for _, choice := range asSlice(asMap(option)["values"]) {
+				choiceMap := asMap(choice)
+				unit += max(asInt(choiceMap["count"]), 1) * asInt(choiceMap["price"])
+			}
+		}
+		total += max(asInt(line["count"]), 1) * unit
+	}
+	return total
+}
+
+// guardApproval refuses a cart or checkout command whose total is above the
+// profile's approval threshold unless it is approved: by a token from wolt
+// approve, or at a y/N prompt when stdin is a terminal outside --machine
+// mode. --force does not lift it. It returns a warning describing how the
+// command was approved.
+func guardApproval(
+	cmd *cobra.Command,
+	deps Dependencies,
+	profileFlag string,
+	token string,
+	total int,
+	currency string,
+	format output.Format,
+	profileName string,
+	locale string,
+	outputPath string,
+) (string, error) {
+	if deps.Profiles == nil {
+		return "", nil
+	}
+	profile, err := deps.Profiles.Find(cmd.Context(), profileFlag)
+	if err != nil || profile.Approval == nil || profile.Approval.Threshold <= 0 {
+		return "", nil
+	}
+	policy := *profile.Approval
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	// A total in another currency cannot be compared, so it needs approval too.
+	if strings.EqualFold(currency, policy.Currency) && total <= policy.Threshold {
+		return "", nil
+	}
+	totalText := fallbackString(formatMinorAmount(total, currency), fmt.Sprintf("%d", total))
+	thresholdText := formatMinorAmount(policy.Threshold, policy.Currency)
+	owner := journalProfile(cmd.Context(), deps, profileFlag)
+
+	if token = strings.TrimSpace(token); token != "" {
+		store, err := openApprovalStore(deps)
+		if err == nil {
+			_, err = store.Redeem(token, owner, total, currency, deps.now())
+		}
+		if err != nil {
+			code := "WOLT_APPROVAL_REQUIRED"
+			if !errors.Is(err, approval.ErrUnknownToken) && !errors.Is(err, approval.ErrExpired) && !errors.Is(err, approval.ErrNotCovered) {
+				code = "WOLT_APPROVAL_STORE_ERROR"
+			}
+			return "", emitError(cmd, format, profileName, locale, outputPath, code, fmt.Sprintf("--approve-token rejected for %s: %v", totalText, err))
+		}
+		return fmt.Sprintf("total %s is above the approval threshold %s; approved by token", totalText, thresholdText), nil
+	}
+
+	if !machineMode(cmd) && isInteractiveInput(cmd.InOrStdin()) {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Total %s is above the approval threshold %s of profile %s. Continue? [y/N] ", totalText, thresholdText, owner)
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return fmt.Sprintf("total %s is above the approval threshold %s; approved at the prompt", totalText, thresholdText), nil
+		}
+		return "", emitError(cmd, format, profileName, locale, outputPath, "WOLT_APPROVAL_REQUIRED", fmt.Sprintf("total %s was not approved at the prompt", totalText))
+	}
+	return "", emitError(cmd, format, profileName, locale, outputPath, "WOLT_APPROVAL_REQUIRED",
+		fmt.Sprintf("total %s is above the approval threshold %s; run `wolt approve %s %s --profile %s` and pass the token with --approve-token",
+			totalText, thresholdText, money.Decimal(total, currency), currency, owner))
+}
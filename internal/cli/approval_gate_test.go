@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func TestCartAddAboveApprovalThresholdNeedsToken(t *testing.T) {
+	previousInteractive := isInteractiveInput
+	defer func() { isInteractiveInput = previousInteractive }()
+	isInteractiveInput = func(io.Reader) bool { return false }
+	t.Setenv(approvalDirEnv, t.TempDir())
+
+	deps := Dependencies{
+		Wolt: &testWoltAPI{},
+		Profiles: &testProfiles{profile: domain.Profile{
+			Name:     "default",
+			WToken:   "token",
+			Location: domain.Location{Lat: 60.17, Lon: 24.94},
+			Approval: &domain.ApprovalPolicy{Threshold: 5000, Currency: "EUR"},
+		}},
+		Config:  &testConfigManager{path: filepath.Join(t.TempDir(), "config.json")},
+		Version: "1.1.1",
+	}
+	run := func(args ...string) (int, map[string]any) {
+		var stdout, stderr bytes.Buffer
+		code := Execute(context.Background(), append(append([]string{}, args...), "--format", "json"), deps, &stdout, &stderr)
+		var envelope map[string]any
+		if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+			t.Fatalf("decode envelope: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+		}
+		return code, envelope
+	}
+	add := []string{"cart", "add", "venue-1", "item-1", "--lat", "60.17", "--lon", "24.94", "--price", "3000", "--currency", "EUR", "--count", "2"}
+
+	if code, envelope := run(add[:len(add)-2]...); code != 0 {
+		t.Fatalf("expected a total below the threshold to pass, got %v", envelope["error"])
+	}
+	code, envelope := run(add...)
+	if message := asString(asMap(envelope["error"])["message"]); code != 1 || !strings.Contains(message, "wolt approve 60.00 EUR") {
+		t.Fatalf("expected WOLT_APPROVAL_REQUIRED with the approve command, got %v", envelope["error"])
+	}
+
+	_, small := run("approve", "55", "EUR")
+	if code, envelope := run(append(add, "--approve-token", asString(asMap(small["data"])["token"]))...); code != 1 || !strings.Contains(asString(asMap(envelope["error"])["message"]), "does not cover") {
+		t.Fatalf("expected a 55 EUR token to be refused for 60 EUR, got %v", envelope["error"])
+	}
+
+	_, issued := run("approve", "60", "EUR")
+	token := asString(asMap(issued["data"])["token"])
+	code, envelope = run(append(add, "--approve-token", token)...)
+	if code != 0 || !strings.Contains(fmt.Sprint(envelope["warnings"]), "approved by token") {
+		t.Fatalf("expected the token to approve the add, got error %v warnings %v", envelope["error"], envelope["warnings"])
+	}
+	if code, envelope := run(append(add, "--approve-token", token)...); code != 1 || asString(asMap(envelope["error"])["code"]) != "WOLT_APPROVAL_REQUIRED" {
+		t.Fatalf("expected a used token to be refused, got %v", envelope["error"])
+	}
+}
+
+func TestParseApprovalThreshold(t *testing.T) {
+	for _, value := range []string{"50 EUR", "50eur", " 50.00 EUR "} {
+		policy, err := parseApprovalThreshold(value)
+		if err != nil || policy == nil || policy.Threshold != 5000 || policy.Currency != "EUR" {
+			t.Fatalf("expected 5000 EUR from %q, got %+v err=%v", value, policy, err)
+		}
+	}
+	if policy, err := parseApprovalThreshold(""); err != nil || policy != nil {
+		t.Fatalf("expected empty to clear, got %+v err=%v", policy, err)
+	}
+	if _, err := parseApprovalThreshold("EUR"); err == nil {
+		t.Fatalf("expected a missing amount to be rejected")
+	}
+}
@@ -15,6 +15,14 @@ const assortmentCategoryConcurrency = 8
 
 func collectAssortmentCategorySlugs(assortmentPayload map[string]any) []string {
 	slugs := []string{}
+	walkAssortmentCrawlCategories(assortmentPayload, func(slug string, _ map[string]any) {
+		slugs = append(slugs, slug)
+	})
+	return slugs
+}
+
+// walkAssortmentCrawlCategories visits each distinct category the full-catalog crawl requests.
+func walkAssortmentCrawlCategories(assortmentPayload map[string]any, visit func(slug string, category map[string]any)) {
 	seen := map[string]struct{}{}
 
 	var walkCategory func(category map[string]any)
@@ -28,7 +36,7 @@ func collectAssortmentCategorySlugs(assortmentPayload map[string]any) []string {
 		if shouldIncludeSlug && slug != "" {
 			if _, exists := seen[slug]; !exists {
 				seen[slug] = struct{}{}
-				slugs = append(slugs, slug)
+				visit(slug, category)
 			}
 		}
 		for _, rawSubcategory := range subcategories {
@@ -42,7 +50,6 @@ func collectAssortmentCategorySlugs(assortmentPayload map[string]any) []string {
 	for _, rawSubcategory := range asSlice(assortmentPayload["subcategories"]) {
 		walkCategory(asMap(rawSubcategory))
 	}
-	return slugs
 }
 
 func loadAssortmentCategoryPayloads(
@@ -89,6 +96,7 @@ func loadAssortmentCategoryPayloadsSequential(
 	for _, categorySlug := range slugs {
 		categoryPayload, err := requestAssortmentCategoryPayload(ctx, deps, venueSlug, categorySlug, language, auth)
 		if err != nil {
+			recordPartialFailure(ctx, "full catalog crawl", err)
 			continue
 		}
 		if len(categoryPayload) == 0 {
@@ -153,6 +161,9 @@ func loadAssortmentCategoryPayloadsParallel(
 					language,
 					auth,
 				)
+				if err != nil {
+					recordPartialFailure(ctx, "full catalog crawl", err)
+				}
 				if err != nil || len(categoryPayload) == 0 {
 					results <- assortmentCategoryLoadResult{index: idx}
 					continue
@@ -235,6 +246,7 @@ func hydrateAssortmentCategoryItems(
 	for _, batch := range batchStrings(itemIDs, assortmentItemsBatchSize) {
 		itemsPayload, err := requestAssortmentItemsPayload(ctx, deps, venueSlug, batch, auth)
 		if err != nil {
+			recordPartialFailure(ctx, "category item hydration", err)
 			continue
 		}
 		collectedItems = append(collectedItems, asSlice(itemsPayload["items"])...)
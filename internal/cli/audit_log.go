@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/audit"
+	"github.com/spf13/cobra"
+)
+
+const (
+	auditDirEnv      = "WOLT_AUDIT_DIR"
+	auditLogFileName = "audit.jsonl"
+)
+
+// expenseFlags carries the --expense-code and --cost-center values that are
+// recorded in the local audit log.
+type expenseFlags struct {
+	expenseCode string
+	costCenter  string
+}
+
+func addExpenseFlags(cmd *cobra.Command, flags *expenseFlags) {
+	cmd.Flags().StringVar(&flags.expenseCode, "expense-code", "", "Expense code recorded in the local audit log for expense reconciliation.")
+	cmd.Flags().StringVar(&flags.costCenter, "cost-center", "", "Cost center recorded in the local audit log for expense reconciliation.")
+}
+
+func (f expenseFlags) set() bool {
+	return strings.TrimSpace(f.expenseCode) != "" || strings.TrimSpace(f.costCenter) != ""
+}
+
+// entry builds the audit entry for command with the trimmed expense values.
+func (f expenseFlags) entry(command string, now time.Time) audit.Entry {
+	return audit.Entry{
+		RecordedAt:  now.UTC().Truncate(time.Second),
+		Command:     command,
+		ExpenseCode: strings.TrimSpace(f.expenseCode),
+		CostCenter:  strings.TrimSpace(f.costCenter),
+	}
+}
+
+func expenseTagsData(tags audit.ExpenseTags) map[string]any {
+	return map[string]any{
+		"expense_code": emptyToNil(tags.ExpenseCode),
+		"cost_center":  emptyToNil(tags.CostCenter),
+	}
+}
+
+// openAuditLog returns the audit log in $WOLT_AUDIT_DIR, or an audit directory
+// next to the config file.
+func openAuditLog(deps Dependencies) (*audit.Log, error) {
+	dir, err := stateDir(deps, auditDirEnv, "audit")
+	if err != nil {
+		return nil, err
+	}
+	return audit.NewLog(filepath.Join(dir, auditLogFileName)), nil
+}
+
+// appendAuditEntry writes entry to the audit log.
+func appendAuditEntry(deps Dependencies, entry audit.Entry) error {
+	log, err := openAuditLog(deps)
+	if err != nil {
+		return err
+	}
+	return log.Append(entry)
+}
+
+// loadPurchaseExpenseTags reads the expense tags recorded per purchase ID.
+func loadPurchaseExpenseTags(deps Dependencies) (map[string]audit.ExpenseTags, error) {
+	log, err := openAuditLog(deps)
+	if err != nil {
+		return nil, err
+	}
+	return log.PurchaseTags()
+}
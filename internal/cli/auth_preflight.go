@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const authRequiredAnnotation = "wolt_cli_auth_required"
+
+// authPreflightLeeway matches the margin automatic refresh uses before the
+// exp claim.
+const authPreflightLeeway = 30 * time.Second
+
+// markAuthRequired marks cmd, and the commands below it, as always needing
+// credentials; --auth-preflight checks them before the command runs.
+func markAuthRequired(cmd *cobra.Command, required bool) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[authRequiredAnnotation] = fmt.Sprint(required)
+}
+
+// authRequired reports the nearest marking of cmd or its parents.
+func authRequired(cmd *cobra.Command) bool {
+	for current := cmd; current != nil; current = current.Parent() {
+		if value, ok := current.Annotations[authRequiredAnnotation]; ok {
+			return value == "true"
+		}
+	}
+	return false
+}
+
+// applyAuthPreflight fails an --auth-preflight run of a command that needs
+// credentials with WOLT_AUTH_REQUIRED when there are none or the access
+// token's exp claim has passed. It reads the token only and makes no request;
+// tokens without an exp claim and cookie-only sessions pass.
+func applyAuthPreflight(cmd *cobra.Command, deps Dependencies) error {
+	enabled, _ := cmd.Flags().GetBool("auth-preflight")
+	if !enabled || !authRequired(cmd) {
+		return nil
+	}
+	flags := globalFlags{}
+	flags.Profile, _ = cmd.Flags().GetString("profile")
+	flags.WToken, _ = cmd.Flags().GetString("wtoken")
+	flags.WRefreshToken, _ = cmd.Flags().GetString("wrtoken")
+	flags.Cookies, _ = cmd.Flags().GetStringArray("cookie")
+	auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+
+	message := ""
+	if !auth.HasCredentials() {
+		message = "Authentication is required. Provide --wtoken or at least one --cookie."
+	} else if tokenExpired(auth.WToken, deps.now().UTC(), authPreflightLeeway) {
+		expiry, _ := tokenExpiry(auth.WToken)
+		message = fmt.Sprintf("Access token expired at %s. Run `wolt auth refresh` to rotate it, then retry.", expiry.Format(time.RFC3339))
+		if strings.TrimSpace(auth.RefreshToken) == "" {
+			message = fmt.Sprintf("Access token expired at %s and no refresh token is configured. Pass a new --wtoken, or store a refresh token with `wolt configure --wrtoken` and run `wolt auth refresh`.", expiry.Format(time.RFC3339))
+		}
+	}
+	if message == "" {
+		return nil
+	}
+	formatFlag, _ := cmd.Flags().GetString("format")
+	format, err := parseOutputFormat(formatFlag)
+	if err != nil {
+		format = output.FormatTable
+	}
+	locale, _ := cmd.Flags().GetString("locale")
+	outputPath, _ := cmd.Flags().GetString("output")
+	return emitError(cmd, format, defaultProfileName(flags.Profile), locale, outputPath, "WOLT_AUTH_REQUIRED", message)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+// annotateBasketRows adds a basket object to venue rows for which the
+// authenticated user has an open basket: its subtotal, the order minimum when
+// the basket payload states one, and whether that minimum is met. Without
+// credentials nothing is fetched; a failed basket lookup only warns.
+func annotateBasketRows(ctx context.Context, deps Dependencies, location domain.Location, auth woltgateway.AuthContext, rows []any) []string {
+	if !auth.HasCredentials() || len(rows) == 0 {
+		return nil
+	}
+	page, err := deps.Wolt.BasketsPage(ctx, location, auth)
+	if err != nil {
+		return []string{"open baskets could not be loaded; rows carry no basket totals"}
+	}
+	byVenue := map[string]map[string]any{}
+	for _, value := range asSlice(page["baskets"]) {
+		basket := asMap(value)
+		if basket == nil || len(asSlice(basket["items"])) == 0 {
+			continue
+		}
+		summary := basketRowSummary(basket)
+		venue := asMap(basket["venue"])
+		for _, key := range []any{venue["id"], venue["slug"], venue["venue_slug"], venue["public_slug"], venue["url_slug"]} {
+			if key := strings.ToLower(strings.TrimSpace(asString(key))); key != "" {
+				byVenue[key] = summary
+			}
+		}
+	}
+	if len(byVenue) == 0 {
+		return nil
+	}
+	for _, value := range rows {
+		row := asMap(value)
+		for _, key := range []string{asString(row["venue_id"]), asString(row["slug"])} {
+			if summary, ok := byVenue[strings.ToLower(strings.TrimSpace(key))]; ok && key != "" {
+				row["basket"] = summary
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func basketRowSummary(basket map[string]any) map[string]any {
+	currency := inferCurrency(asString(basket["total"]))
+	subtotal := basketSubtotal(basket)
+	items := 0
+	for _, value := range asSlice(basket["items"]) {
+		count := asInt(asMap(value)["count"])
+		if count <= 0 {
+			count = 1
+		}
+		items += count
+	}
+	summary := map[string]any{
+		"basket_id":   asString(basket["id"]),
+		"total_items": items,
+		"subtotal": map[string]any{
+			"amount":           subtotal,
+			"formatted_amount": emptyToNil(formatMinorAmount(subtotal, currency)),
+		},
+		"order_minimum":     nil,
+		"minimum_met":       nil,
+		"amount_to_minimum": nil,
+	}
+	minimum, ok := basketOrderMinimum(basket)
+	if !ok {
+		return summary
+	}
+	remaining := max(minimum-subtotal, 0)
+	summary["order_minimum"] = map[string]any{
+		"amount":           minimum,
+		"formatted_amount": emptyToNil(formatMinorAmount(minimum, currency)),
+	}
+	summary["minimum_met"] = remaining == 0
+	summary["amount_to_minimum"] = map[string]any{
+		"amount":           remaining,
+		"formatted_amount": emptyToNil(formatMinorAmount(remaining, currency)),
+	}
+	return summary
+}
+
+// basketOrderMinimum reads the minimum order value from the basket or its
+// venue; it is given either in minor units or as an {amount} object.
+func basketOrderMinimum(basket map[string]any) (int, bool) {
+	venue := asMap(basket["venue"])
+	value := coalesceAny(
+		basket["order_minimum"],
+		basket["minimum_order_value"],
+		venue["order_minimum"],
+		venue["minimum_order_value"],
+	)
+	if object := asMap(value); object != nil {
+		value = object["amount"]
+	}
+	if value == nil {
+		return 0, false
+	}
+	minimum := asInt(value)
+	return minimum, minimum > 0
+}
+
+// basketRowLabel is the table suffix for a row with an open basket, such as
+// " (basket €12.00, €3.00 to minimum)".
+func basketRowLabel(row map[string]any) string {
+	basket := asMap(row["basket"])
+	if basket == nil {
+		return ""
+	}
+	label := fallbackString(asString(asMap(basket["subtotal"])["formatted_amount"]), asString(asMap(basket["subtotal"])["amount"]))
+	switch met, known := basket["minimum_met"].(bool); {
+	case known && met:
+		label += ", minimum met"
+	case known:
+		label += ", " + fallbackString(asString(asMap(basket["amount_to_minimum"])["formatted_amount"]), asString(asMap(basket["amount_to_minimum"])["amount"])) + " to minimum"
+	}
+	return " (basket " + label + ")"
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const beancountAnnotation = "wolt_cli_beancount"
+
+// enableBeancount marks cmd as able to render --format beancount itself.
+func enableBeancount(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[beancountAnnotation] = "true"
+}
+
+// applyBeancountFormat rejects --format beancount on commands without ledger entries.
+func applyBeancountFormat(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil || !strings.EqualFold(strings.TrimSpace(flag.Value.String()), string(output.FormatBeancount)) {
+		return nil
+	}
+	if cmd.Annotations[beancountAnnotation] != "true" {
+		return fmt.Errorf("--format beancount is supported by profile orders export")
+	}
+	return nil
+}
+
+// writeBeancountEnvelope renders envelopes that reach the generic machine
+// writer in beancount mode. Only errors do; they become ledger comments so a
+// redirected file stays valid, and the command still exits non-zero.
+func writeBeancountEnvelope(cmd *cobra.Command, env output.Envelope, outputPath string) error {
+	if env.Error == nil {
+		return fmt.Errorf("--format beancount is not supported by %s", cmd.CommandPath())
+	}
+	comment := fmt.Sprintf("error %s: %s", asString(env.Error["code"]), asString(env.Error["message"]))
+	return output.WriteOutput(cmd.OutOrStdout(), output.RenderBeancount(nil, []string{comment}), outputPath)
+}
@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/mekedron/wolt-cli/internal/service/cache"
+)
+
+const cacheDirEnv = "WOLT_CACHE_DIR"
+
+// openCLICache opens the named cache file in $WOLT_CACHE_DIR, or a cache
+// directory next to the config file. A corrupt file is returned empty together
+// with the error, so callers can warn and continue.
+func openCLICache(deps Dependencies, name string) (*cache.File, error) {
+	dir, err := stateDir(deps, cacheDirEnv, "cache")
+	if err != nil {
+		return nil, err
+	}
+	return cache.Open(filepath.Join(dir, name))
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/spf13/cobra"
+)
+
+const (
+	capabilitiesCacheFile = "capabilities.json"
+	// capabilityTTL is how long a 410 keeps an endpoint family gated before
+	// it is tried again; wolt status re-probes immediately.
+	capabilityTTL = 7 * 24 * time.Hour
+)
+
+// gatewayCapabilityGate is implemented by gateways that can refuse endpoint
+// families upfront.
+type gatewayCapabilityGate interface {
+	SetUnsupportedFamilies(families []string)
+	SetCapabilityHandler(handler woltgateway.CapabilityHandler)
+}
+
+// capabilitiesMu serializes writes of the capabilities cache from concurrent requests.
+var capabilitiesMu sync.Mutex
+
+// attachCapabilityGate loads the endpoint families the profile's region does
+// not serve, and records new 410 answers for the next run.
+func attachCapabilityGate(cmd *cobra.Command, deps Dependencies) {
+	gate, ok := deps.Wolt.(gatewayCapabilityGate)
+	if !ok {
+		return
+	}
+	profileFlag, _ := cmd.Flags().GetString("profile")
+	profileName := defaultProfileName(profileFlag)
+	gate.SetUnsupportedFamilies(loadUnsupportedFamilies(deps, profileName))
+	gate.SetCapabilityHandler(func(ctx context.Context, family string, detected bool) {
+		capabilityNoticesFromContext(ctx).add(family)
+		if detected {
+			rememberUnsupportedFamily(deps, profileName, family)
+		}
+	})
+}
+
+// loadUnsupportedFamilies returns the families marked unsupported for profile
+// within capabilityTTL. The cache is advisory, so read errors mean none.
+func loadUnsupportedFamilies(deps Dependencies, profileName string) []string {
+	file, _ := openCLICache(deps, capabilitiesCacheFile)
+	if file == nil {
+		return nil
+	}
+	detected := map[string]time.Time{}
+	file.Get(profileName, 0, deps.now(), &detected)
+	families := make([]string, 0, len(detected))
+	for family, at := range detected {
+		if deps.now().Sub(at) <= capabilityTTL {
+			families = append(families, family)
+		}
+	}
+	sort.Strings(families)
+	return families
+}
+
+func rememberUnsupportedFamily(deps Dependencies, profileName string, family string) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	file, _ := openCLICache(deps, capabilitiesCacheFile)
+	if file == nil {
+		return
+	}
+	detected := map[string]time.Time{}
+	file.Get(profileName, 0, deps.now(), &detected)
+	detected[family] = deps.now().UTC()
+	if file.Put(profileName, detected, deps.now()) == nil {
+		_ = file.Save()
+	}
+}
+
+// forgetUnsupportedFamilies clears the profile's gated families so the next
+// requests probe them again.
+func forgetUnsupportedFamilies(deps Dependencies, profileName string) {
+	if gate, ok := deps.Wolt.(gatewayCapabilityGate); ok {
+		gate.SetUnsupportedFamilies(nil)
+	}
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	file, _ := openCLICache(deps, capabilitiesCacheFile)
+	if file == nil {
+		return
+	}
+	var detected map[string]time.Time
+	if !file.Get(profileName, 0, deps.now(), &detected) || len(detected) == 0 {
+		return
+	}
+	if file.Put(profileName, map[string]time.Time{}, deps.now()) == nil {
+		_ = file.Save()
+	}
+}
+
+type capabilityNoticesKey struct{}
+
+// capabilityNotices collects the endpoint families a run skipped or found
+// unsupported, in first-seen order.
+type capabilityNotices struct {
+	mu       sync.Mutex
+	families []string
+}
+
+func withCapabilityNotices(ctx context.Context) (context.Context, *capabilityNotices) {
+	notices := &capabilityNotices{}
+	return context.WithValue(ctx, capabilityNoticesKey{}, notices), notices
+}
+
+func capabilityNoticesFromContext(ctx context.Context) *capabilityNotices {
+	if ctx == nil {
+		return nil
+	}
+	notices, _ := ctx.Value(capabilityNoticesKey{}).(*capabilityNotices)
+	return notices
+}
+
+func (n *capabilityNotices) add(family string) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, seen := range n.families {
+		if seen == family {
+			return
+		}
+	}
+	n.families = append(n.families, family)
+}
+
+func (n *capabilityNotices) warnings() []string {
+	if n == nil {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	out := make([]string, 0, len(n.families))
+	for _, family := range n.families {
+		out = append(out, fmt.Sprintf("unsupported_in_region: %s endpoint is not available in this region and was skipped (re-check with wolt status)", family))
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+type goneHTTPClient struct {
+	calls int
+}
+
+func (c *goneHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return &http.Response{
+		StatusCode: http.StatusGone,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"gone"}`)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestCapabilityGateSkipsGoneEndpointOnNextRun(t *testing.T) {
+	t.Setenv(cacheDirEnv, t.TempDir())
+	httpClient := &goneHTTPClient{}
+	run := func() map[string]any {
+		deps := Dependencies{
+			Wolt:     woltgateway.NewClient(woltgateway.WithHTTPClient(httpClient)),
+			Profiles: &testProfiles{profile: domain.Profile{Name: "default", WToken: "token"}},
+			Config:   &testConfigManager{},
+			Version:  "1.1.1",
+		}
+		var stdout, stderr bytes.Buffer
+		if code := Execute(context.Background(), []string{"cart", "count", "--format", "json"}, deps, &stdout, &stderr); code != 1 {
+			t.Fatalf("expected exit 1, got %d\nstderr:\n%s", code, stderr.String())
+		}
+		var envelope map[string]any
+		if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+			t.Fatalf("decode envelope: %v\n%s", err, stdout.String())
+		}
+		return envelope
+	}
+
+	first := run()
+	if code := asString(asMap(first["error"])["code"]); code != "WOLT_UPSTREAM_ERROR" {
+		t.Fatalf("expected the live 410 to be an upstream error, got %q", code)
+	}
+	second := run()
+	if code := asString(asMap(second["error"])["code"]); code != "WOLT_UNSUPPORTED_IN_REGION" {
+		t.Fatalf("expected WOLT_UNSUPPORTED_IN_REGION, got %q", code)
+	}
+	if httpClient.calls != 1 {
+		t.Fatalf("expected the second run to skip the request, got %d calls", httpClient.calls)
+	}
+	warnings := asSlice(second["warnings"])
+	if len(warnings) != 1 || !strings.HasPrefix(asString(warnings[0]), "unsupported_in_region: basket_count") {
+		t.Fatalf("expected an unsupported_in_region warning, got %v", warnings)
+	}
+}
@@ -18,6 +18,12 @@ func TestCurrencyAndAmountHelpers(t *testing.T) {
 	if got := formatMinorAmount(0, "EUR"); got != "€0.00" {
 		t.Fatalf("expected €0.00, got %q", got)
 	}
+	if got := formatMinorAmount(1500, "JPY"); got != "JPY 1500" {
+		t.Fatalf("expected JPY 1500, got %q", got)
+	}
+	if got := formatMinorAmount(1250, "KWD"); got != "KWD 1.250" {
+		t.Fatalf("expected KWD 1.250, got %q", got)
+	}
 }
 
 func TestDedupeStrings(t *testing.T) {
@@ -2,9 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
 )
 
 type optionSelection struct {
@@ -179,8 +183,8 @@ func visitOptionGroupCandidates(payload map[string]any, visit func(map[string]an
 					}
 				}
 			}
-			for _, nested := range typed {
-				walk(nested)
+			for _, key := range slices.Sorted(maps.Keys(typed)) {
+				walk(typed[key])
 			}
 		case []any:
 			for _, nested := range typed {
@@ -327,13 +331,16 @@ func formatMinorAmount(amount int, currency string) string {
 	if currency == "" {
 		return ""
 	}
+	if locale := money.DefaultLocale(); locale != "" {
+		return money.Format(amount, currency, locale)
+	}
 	switch currency {
 	case "EUR":
-		return fmt.Sprintf("€%.2f", float64(amount)/100)
+		return "€" + money.Decimal(amount, currency)
 	case "USD":
-		return fmt.Sprintf("$%.2f", float64(amount)/100)
+		return "$" + money.Decimal(amount, currency)
 	default:
-		return fmt.Sprintf("%s %.2f", currency, float64(amount)/100)
+		return currency + " " + money.Decimal(amount, currency)
 	}
 }
 
@@ -345,8 +352,8 @@ func resolveOptionGroupToken(token string, specs map[string]optionGroupSpec) str
 	if _, ok := specs[token]; ok {
 		return token
 	}
-	for groupID, spec := range specs {
-		if strings.EqualFold(groupID, token) || strings.EqualFold(spec.Name, token) {
+	for _, groupID := range slices.Sorted(maps.Keys(specs)) {
+		if strings.EqualFold(groupID, token) || strings.EqualFold(specs[groupID].Name, token) {
 			return groupID
 		}
 	}
@@ -361,8 +368,8 @@ func resolveOptionValueToken(token string, group optionGroupSpec) string {
 	if _, ok := group.Values[token]; ok {
 		return token
 	}
-	for valueID, valueSpec := range group.Values {
-		if strings.EqualFold(valueID, token) || strings.EqualFold(valueSpec.Name, token) {
+	for _, valueID := range slices.Sorted(maps.Keys(group.Values)) {
+		if strings.EqualFold(valueID, token) || strings.EqualFold(group.Values[valueID].Name, token) {
 			return valueID
 		}
 	}
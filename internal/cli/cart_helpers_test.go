@@ -177,3 +177,45 @@ func TestNeedsVenueContentFallback(t *testing.T) {
 		t.Fatalf("did not expect full assortment to require venue-content fallback")
 	}
 }
+
+func TestMergeJSONInputForAddedBasketLine(t *testing.T) {
+	request, line, err := splitBasketLineInput(map[string]any{
+		"currency": "SEK",
+		"items":    []any{map[string]any{"options": []any{map[string]any{"id": "group-1"}}, "substitution_settings": map[string]any{"comment": "any brand"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := map[string]any{"venue_id": "venue-1", "currency": "EUR"}
+	fields, err := mergeJSONInput(payload, request, "venue_id")
+	if err != nil || payload["currency"] != "SEK" || len(fields) != 1 {
+		t.Fatalf("expected currency override, got %v (%v, %v)", payload, fields, err)
+	}
+	added := map[string]any{
+		"id":                    "item-1",
+		"count":                 2,
+		"options":               []any{},
+		"substitution_settings": map[string]any{"is_allowed": true},
+	}
+	fields, err = mergeJSONInput(added, line, "id", "count")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	settings := asMap(added["substitution_settings"])
+	if settings["is_allowed"] != true || settings["comment"] != "any brand" || len(asSlice(added["options"])) != 1 {
+		t.Fatalf("expected nested merge into the added line, got %v", added)
+	}
+	if len(fields) != 2 || fields[0] != "options" || fields[1] != "substitution_settings.comment" {
+		t.Fatalf("unexpected merged fields %v", fields)
+	}
+
+	if _, err := mergeJSONInput(added, map[string]any{"count": 5}, "id", "count"); err == nil {
+		t.Fatalf("expected pinned count to be rejected")
+	}
+	if _, err := mergeJSONInput(added, map[string]any{"options": "none"}); err == nil {
+		t.Fatalf("expected type mismatch to be rejected")
+	}
+	if _, _, err := splitBasketLineInput(map[string]any{"items": []any{map[string]any{}, map[string]any{}}}); err == nil {
+		t.Fatalf("expected more than one line to be rejected")
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cartPriceCacheFile = "cart-prices.json"
+	cartPriceCacheKey  = "added_prices"
+	// cartPriceLimit caps the history; the oldest additions go first.
+	cartPriceLimit = 1000
+)
+
+// addedPrice is the menu price of a basket line when it was added.
+type addedPrice struct {
+	Amount   int       `json:"amount"`
+	Currency string    `json:"currency,omitempty"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+// cartPriceMu serializes read-modify-write cycles of the added price file.
+var cartPriceMu sync.Mutex
+
+func addedPriceKey(venueID string, itemID string) string {
+	return strings.ToLower(strings.TrimSpace(venueID)) + "|" + strings.ToLower(strings.TrimSpace(itemID))
+}
+
+// recordAddedPrices remembers the unit price of each added line so checkout
+// preview can tell when it changed since. Lines without a price are skipped,
+// and cache failures are ignored: the history is an aid.
+func recordAddedPrices(deps Dependencies, venueID string, currency string, lines []map[string]any) {
+	cartPriceMu.Lock()
+	defer cartPriceMu.Unlock()
+	file, _ := openCLICache(deps, cartPriceCacheFile)
+	if file == nil || strings.TrimSpace(venueID) == "" {
+		return
+	}
+	history := map[string]addedPrice{}
+	file.Get(cartPriceCacheKey, 0, deps.now(), &history)
+	now := deps.now().UTC()
+	for _, line := range lines {
+		itemID := asString(line["id"])
+		if price := asInt(line["price"]); strings.TrimSpace(itemID) != "" && price > 0 {
+			history[addedPriceKey(venueID, itemID)] = addedPrice{Amount: price, Currency: strings.TrimSpace(currency), AddedAt: now}
+		}
+	}
+	if len(history) > cartPriceLimit {
+		keys := make([]string, 0, len(history))
+		for key := range history {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return history[keys[i]].AddedAt.Before(history[keys[j]].AddedAt)
+		})
+		for _, key := range keys[:len(history)-cartPriceLimit] {
+			delete(history, key)
+		}
+	}
+	if file.Put(cartPriceCacheKey, history, deps.now()) == nil {
+		_ = file.Save()
+	}
+}
+
+// loadAddedPrices returns the recorded prices of a venue's lines by item id.
+func loadAddedPrices(deps Dependencies, venueID string) map[string]addedPrice {
+	cartPriceMu.Lock()
+	defer cartPriceMu.Unlock()
+	prices := map[string]addedPrice{}
+	file, _ := openCLICache(deps, cartPriceCacheFile)
+	if file == nil {
+		return prices
+	}
+	history := map[string]addedPrice{}
+	file.Get(cartPriceCacheKey, 0, deps.now(), &history)
+	prefix := addedPriceKey(venueID, "")
+	for key, price := range history {
+		if itemID, ok := strings.CutPrefix(key, prefix); ok {
+			prices[itemID] = price
+		}
+	}
+	return prices
+}
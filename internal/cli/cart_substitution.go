@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// substitutionFlags sets a cart line's substitution_settings. Unset flags
+// leave the line's current settings alone.
+type substitutionFlags struct {
+	allow   bool
+	deny    bool
+	note    string
+	noteSet bool
+}
+
+func addSubstitutionFlags(cmd *cobra.Command, flags *substitutionFlags) {
+	cmd.Flags().BoolVar(&flags.allow, "allow-substitution", false, "Let the venue substitute this item when it is unavailable.")
+	cmd.Flags().BoolVar(&flags.deny, "no-substitution", false, "Refund this item instead of substituting it when it is unavailable.")
+	cmd.Flags().StringVar(&flags.note, "substitution-note", "", "Note for the picker about acceptable substitutes; an empty value clears it.")
+}
+
+// read records which flags were given; call it before validate.
+func (s *substitutionFlags) read(cmd *cobra.Command) {
+	s.noteSet = cmd.Flags().Changed("substitution-note")
+}
+
+func (s substitutionFlags) set() bool {
+	return s.allow || s.deny || s.noteSet
+}
+
+func (s substitutionFlags) validate() error {
+	if s.allow && s.deny {
+		return fmt.Errorf("--allow-substitution and --no-substitution cannot be combined")
+	}
+	return nil
+}
+
+// apply returns current with the requested changes. A note alone keeps the
+// current allow/deny choice.
+func (s substitutionFlags) apply(current map[string]any) map[string]any {
+	settings := substitutionSettings(current)
+	switch {
+	case s.allow:
+		settings["is_allowed"] = true
+	case s.deny:
+		settings["is_allowed"] = false
+	}
+	if s.noteSet {
+		if note := strings.TrimSpace(s.note); note != "" {
+			settings["note"] = note
+		} else {
+			delete(settings, "note")
+		}
+	}
+	return settings
+}
+
+// substitutionSettings copies the fields of a line's substitution_settings
+// that mutations send back, so re-sending a line keeps the app's choice.
+func substitutionSettings(current map[string]any) map[string]any {
+	settings := map[string]any{"is_allowed": asBool(current["is_allowed"])}
+	if note := strings.TrimSpace(asString(current["note"])); note != "" {
+		settings["note"] = note
+	}
+	return settings
+}
+
+// cartLineSubstitution is the cart output view of substitution_settings.
+func cartLineSubstitution(line map[string]any) map[string]any {
+	settings := asMap(line["substitution_settings"])
+	return map[string]any{
+		"allowed": asBool(settings["is_allowed"]),
+		"note":    emptyToNil(strings.TrimSpace(asString(settings["note"]))),
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const chainSeparator = "--then"
+
+type chainSelectionKey struct{}
+
+// chainSelection carries identifiers picked by one chain stage into the next.
+type chainSelection struct {
+	IDs    []string
+	Picked bool
+}
+
+func withChainSelection(ctx context.Context) (context.Context, *chainSelection) {
+	selection := &chainSelection{}
+	return context.WithValue(ctx, chainSelectionKey{}, selection), selection
+}
+
+func chainSelectionFromContext(ctx context.Context) *chainSelection {
+	if ctx == nil {
+		return nil
+	}
+	selection, _ := ctx.Value(chainSelectionKey{}).(*chainSelection)
+	return selection
+}
+
+// splitChainArgs splits raw CLI args into stages separated by --then. A
+// --then that is the value of the flag before it, or that follows --, stays
+// an argument of its stage; root resolves which flags take values.
+func splitChainArgs(root *cobra.Command, args []string) [][]string {
+	stages := [][]string{{}}
+	literal := false
+	for _, arg := range args {
+		stage := stages[len(stages)-1]
+		if arg == chainSeparator && !literal && (len(stage) == 0 || !chainFlagTakesValue(root, stage, stage[len(stage)-1])) {
+			stages = append(stages, []string{})
+			continue
+		}
+		if arg == "--" {
+			literal = true
+		}
+		stages[len(stages)-1] = append(stage, arg)
+	}
+	return stages
+}
+
+// chainFlagTakesValue reports whether token is a flag of the command stage
+// names that consumes the next argument as its value.
+func chainFlagTakesValue(root *cobra.Command, stage []string, token string) bool {
+	if root == nil || !strings.HasPrefix(token, "-") || token == "-" || strings.Contains(token, "=") {
+		return false
+	}
+	cmd, _, err := root.Find(stage)
+	if err != nil || cmd == nil {
+		cmd = root
+	}
+	flags := cmd.Flags()
+	flags.AddFlagSet(cmd.InheritedFlags())
+	var name string
+	if strings.HasPrefix(token, "--") {
+		name = strings.TrimPrefix(token, "--")
+	} else if len(token) == 2 {
+		if flag := flags.ShorthandLookup(token[1:]); flag != nil {
+			name = flag.Name
+		}
+	}
+	flag := flags.Lookup(name)
+	return flag != nil && flag.NoOptDefVal == ""
+}
+
+func chainStageError(stage int, message string) error {
+	return fmt.Errorf("--then stage %d: %s", stage, message)
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitChainArgs(t *testing.T) {
+	root := NewRootCommand(Dependencies{})
+	stages := splitChainArgs(root, []string{"venue", "search", "shop", "--query", "cola", "--pick-first", "--then", "cart", "add", "--count", "2"})
+	expected := [][]string{
+		{"venue", "search", "shop", "--query", "cola", "--pick-first"},
+		{"cart", "add", "--count", "2"},
+	}
+	if !reflect.DeepEqual(stages, expected) {
+		t.Fatalf("unexpected stages: %#v", stages)
+	}
+	if single := splitChainArgs(root, []string{"cart", "show"}); len(single) != 1 {
+		t.Fatalf("expected one stage without --then, got %#v", single)
+	}
+}
+
+func TestSplitChainArgsKeepsThenAsFlagValue(t *testing.T) {
+	root := NewRootCommand(Dependencies{})
+	stages := splitChainArgs(root, []string{"venue", "search", "shop", "--query", "--then", "--pick-first", "--then", "cart", "show"})
+	expected := [][]string{
+		{"venue", "search", "shop", "--query", "--then", "--pick-first"},
+		{"cart", "show"},
+	}
+	if !reflect.DeepEqual(stages, expected) {
+		t.Fatalf("unexpected stages: %#v", stages)
+	}
+}
+
+func TestSplitChainArgsStopsAfterDoubleDash(t *testing.T) {
+	root := NewRootCommand(Dependencies{})
+	stages := splitChainArgs(root, []string{"venue", "search", "shop", "--", "--then", "cart", "show"})
+	expected := [][]string{{"venue", "search", "shop", "--", "--then", "cart", "show"}}
+	if !reflect.DeepEqual(stages, expected) {
+		t.Fatalf("unexpected stages: %#v", stages)
+	}
+}
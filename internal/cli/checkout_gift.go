@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// giftMessageLimit caps --gift-message, in characters.
+const giftMessageLimit = 250
+
+// giftPhonePattern matches an E.164 number once spaces, dashes, dots, and
+// parentheses are removed.
+var giftPhonePattern = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+
+// giftCountryRule is what a venue country asks of a gift recipient.
+type giftCountryRule struct {
+	callingCode  string
+	requirePhone bool
+}
+
+// giftOrderCountries maps the venue countries that offer gift orders to their
+// recipient rules. The courier calls the recipient, so a phone number on the
+// local network is required except where name-labelled doorbells make the
+// name enough to deliver.
+var giftOrderCountries = map[string]giftCountryRule{
+	"AUT": {callingCode: "+43"},
+	"CZE": {callingCode: "+420", requirePhone: true},
+	"DEU": {callingCode: "+49"},
+	"DNK": {callingCode: "+45", requirePhone: true},
+	"EST": {callingCode: "+372", requirePhone: true},
+	"FIN": {callingCode: "+358", requirePhone: true},
+	"HRV": {callingCode: "+385", requirePhone: true},
+	"HUN": {callingCode: "+36", requirePhone: true},
+	"LTU": {callingCode: "+370", requirePhone: true},
+	"LVA": {callingCode: "+371", requirePhone: true},
+	"NOR": {callingCode: "+47", requirePhone: true},
+	"POL": {callingCode: "+48", requirePhone: true},
+	"SVK": {callingCode: "+421", requirePhone: true},
+	"SWE": {callingCode: "+46", requirePhone: true},
+}
+
+// giftOrder is the recipient of a --gift checkout.
+type giftOrder struct {
+	enabled bool
+	name    string
+	phone   string
+	message string
+}
+
+// set reports whether any recipient flag was given.
+func (g giftOrder) set() bool {
+	return g.enabled || strings.TrimSpace(g.name) != "" || strings.TrimSpace(g.phone) != "" || strings.TrimSpace(g.message) != ""
+}
+
+// parseGiftOrder checks the flag combination before any request is made:
+// recipient flags need --gift, and the message has a length limit.
+func parseGiftOrder(gift giftOrder) (giftOrder, error) {
+	gift.name = strings.Join(strings.Fields(gift.name), " ")
+	gift.phone = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimSpace(gift.phone))
+	gift.message = strings.TrimSpace(gift.message)
+	if !gift.enabled {
+		if gift.set() {
+			return gift, fmt.Errorf("--recipient-name, --recipient-phone, and --gift-message require --gift")
+		}
+		return gift, nil
+	}
+	if strings.HasPrefix(gift.phone, "00") {
+		gift.phone = "+" + strings.TrimPrefix(gift.phone, "00")
+	}
+	if gift.phone != "" && !giftPhonePattern.MatchString(gift.phone) {
+		return gift, fmt.Errorf("--recipient-phone %q must be an international number such as +358401234567", gift.phone)
+	}
+	if utf8.RuneCountInString(gift.message) > giftMessageLimit {
+		return gift, fmt.Errorf("--gift-message is %d characters; the limit is %d", utf8.RuneCountInString(gift.message), giftMessageLimit)
+	}
+	return gift, nil
+}
+
+// checkGiftCountry applies the venue country's recipient rules: the country
+// has to offer gift orders, the name is always required, and the phone where
+// the rule asks for it, on the country's calling code.
+func checkGiftCountry(gift giftOrder, country string) error {
+	if !gift.enabled {
+		return nil
+	}
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		return fmt.Errorf("venue country is unknown, so the gift recipient cannot be checked")
+	}
+	rule, ok := giftOrderCountries[country]
+	if !ok {
+		return fmt.Errorf("gift orders are not offered for venues in %s", country)
+	}
+	if gift.name == "" {
+		return fmt.Errorf("gift orders in %s need --recipient-name", country)
+	}
+	if gift.phone == "" {
+		if rule.requirePhone {
+			return fmt.Errorf("gift orders in %s need --recipient-phone", country)
+		}
+		return nil
+	}
+	if !strings.HasPrefix(gift.phone, rule.callingCode) {
+		return fmt.Errorf("--recipient-phone %s is not a %s number; gift couriers in %s call %s numbers", gift.phone, country, country, rule.callingCode)
+	}
+	return nil
+}
+
+// applyGiftOrder adds the recipient to the checkout request.
+func applyGiftOrder(checkoutPayload map[string]any, gift giftOrder) {
+	if !gift.enabled {
+		return
+	}
+	asMap(checkoutPayload["purchase_plan"])["gift"] = map[string]any{
+		"is_gift":                true,
+		"recipient_name":         gift.name,
+		"recipient_phone_number": emptyToNil(gift.phone),
+		"message":                emptyToNil(gift.message),
+	}
+}
+
+func giftOrderData(gift giftOrder, country string) map[string]any {
+	return map[string]any{
+		"recipient_name":  gift.name,
+		"recipient_phone": emptyToNil(gift.phone),
+		"message":         emptyToNil(gift.message),
+		"country":         emptyToNil(strings.ToUpper(strings.TrimSpace(country))),
+	}
+}
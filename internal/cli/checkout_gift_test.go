@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGiftOrder(t *testing.T) {
+	if _, err := parseGiftOrder(giftOrder{name: "Aino"}); err == nil || !strings.Contains(err.Error(), "require --gift") {
+		t.Fatalf("expected recipient flags without --gift to fail, got %v", err)
+	}
+	gift, err := parseGiftOrder(giftOrder{enabled: true, name: "  Aino   Virtanen ", phone: "0035840 123-4567"})
+	if err != nil || gift.name != "Aino Virtanen" || gift.phone != "+358401234567" {
+		t.Fatalf("expected normalized recipient, got %+v err=%v", gift, err)
+	}
+	if _, err := parseGiftOrder(giftOrder{enabled: true, name: "Aino", phone: "040 123 4567"}); err == nil {
+		t.Fatalf("expected a national number to be rejected")
+	}
+	if _, err := parseGiftOrder(giftOrder{enabled: true, name: "Aino", message: strings.Repeat("ä", giftMessageLimit+1)}); err == nil {
+		t.Fatalf("expected an overlong message to be rejected")
+	}
+}
+
+func TestCheckGiftCountry(t *testing.T) {
+	cases := []struct {
+		name    string
+		gift    giftOrder
+		country string
+		wantErr string
+	}{
+		{"finland with phone", giftOrder{enabled: true, name: "Aino", phone: "+358401234567"}, "FIN", ""},
+		{"finland without phone", giftOrder{enabled: true, name: "Aino"}, "FIN", "need --recipient-phone"},
+		{"germany without phone", giftOrder{enabled: true, name: "Anna"}, "DEU", ""},
+		{"missing name", giftOrder{enabled: true, phone: "+46701234567"}, "SWE", "need --recipient-name"},
+		{"foreign phone", giftOrder{enabled: true, name: "Aino", phone: "+46701234567"}, "FIN", "not a FIN number"},
+		{"country without gifting", giftOrder{enabled: true, name: "Dana"}, "ISR", "not offered"},
+		{"unknown country", giftOrder{enabled: true, name: "Aino"}, "", "unknown"},
+		{"not a gift", giftOrder{}, "", ""},
+	}
+	for _, tc := range cases {
+		err := checkGiftCountry(tc.gift, tc.country)
+		if tc.wantErr == "" && err != nil {
+			t.Fatalf("%s: expected no error, got %v", tc.name, err)
+		}
+		if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+			t.Fatalf("%s: expected %q, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
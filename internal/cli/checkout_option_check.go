@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkCheckoutOptions compares the option values selected on a basket line
+// with the item's current option spec, given as valuePrices. A value the spec
+// no longer lists is reported as unavailable, and a value whose basket price
+// differs from the spec as price_changed. Lines are not checked when the spec
+// could not be loaded (valuePrices is empty), so a missing item page is never
+// mistaken for removed options. source says whether valuePrices came from the
+// line cache or the live item page; issues carry it as spec_source, and
+// warnings from a cached spec say so.
+func checkCheckoutOptions(item map[string]any, itemID string, valuePrices map[string]int, currency string, source string) ([]any, []string) {
+	if len(valuePrices) == 0 {
+		return nil, nil
+	}
+	itemName := fallbackString(strings.TrimSpace(asString(item["name"])), itemID)
+	issues := []any{}
+	warnings := []string{}
+	suffix := ""
+	if source == checkoutResolutionCache {
+		suffix = " (per the cached item spec; --refresh checks the live one)"
+	}
+	for _, optionValue := range asSlice(item["options"]) {
+		option := asMap(optionValue)
+		for _, selectedValue := range asSlice(option["values"]) {
+			value := asMap(selectedValue)
+			valueID := strings.TrimSpace(asString(value["id"]))
+			if valueID == "" {
+				continue
+			}
+			valueName := fallbackString(strings.TrimSpace(asString(value["name"])), valueID)
+			issue := map[string]any{
+				"item_id":       itemID,
+				"option_id":     strings.TrimSpace(asString(option["id"])),
+				"value_id":      valueID,
+				"value_name":    emptyToNil(strings.TrimSpace(asString(value["name"]))),
+				"basket_price":  nil,
+				"current_price": nil,
+				"spec_source":   source,
+			}
+			if value["price"] != nil {
+				issue["basket_price"] = asInt(value["price"])
+			}
+			current, ok := valuePrices[valueID]
+			switch {
+			case !ok:
+				issue["issue"] = "unavailable"
+				warnings = append(warnings, fmt.Sprintf("option_unavailable: %s option %s is no longer offered%s", itemName, valueName, suffix))
+			case value["price"] != nil && asInt(value["price"]) != current:
+				issue["issue"] = "price_changed"
+				issue["current_price"] = current
+				warnings = append(warnings, fmt.Sprintf(
+					"option_price_changed: %s option %s was %s in the basket and is %s now%s",
+					itemName,
+					valueName,
+					formatMinorAmount(asInt(value["price"]), currency),
+					formatMinorAmount(current, currency),
+					suffix,
+				))
+			default:
+				continue
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues, warnings
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+)
+
+// checkoutRowKinds classifies checkout amount rows by label keyword, first
+// match wins. Items come last so labels such as "Item discount" count as the
+// adjustment they name. Rows that match none are "other".
+var checkoutRowKinds = []struct {
+	kind     string
+	keywords []string
+}{
+	{"discount", []string{"discount", "offer", "promo", "campaign", "credit", "alennus", "rabatt"}},
+	{"tip", []string{"tip", "dricks", "tippi"}},
+	{"fee", []string{"fee", "delivery", "service", "small order", "bag", "maksu", "toimitus"}},
+	{"items", []string{"subtotal", "item", "välisumma", "tuotteet"}},
+}
+
+// checkoutRowAmount reads the signed minor amount of a checkout amount row,
+// from its amount or else its formatted amount.
+func checkoutRowAmount(row map[string]any) (int, bool) {
+	amount := asMap(row["amount"])
+	if amount == nil {
+		return 0, false
+	}
+	if amount["amount"] != nil {
+		return asInt(amount["amount"]), true
+	}
+	formatted := strings.TrimSpace(asString(amount["formatted_amount"]))
+	negative := strings.HasPrefix(formatted, "-") || strings.HasPrefix(formatted, "−")
+	formatted = strings.TrimLeft(formatted, "-− ")
+	value, ok := orderTotalMinor(formatted, inferCurrency(formatted))
+	if negative {
+		value = -value
+	}
+	return value, ok
+}
+
+func checkoutRowKind(label string) string {
+	label = strings.ToLower(label)
+	for _, entry := range checkoutRowKinds {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(label, keyword) {
+				return entry.kind
+			}
+		}
+	}
+	return "other"
+}
+
+// buildCheckoutReconciliation compares the basket with the checkout preview:
+// each line's unit price now against the menu price recorded when it was
+// added, and the basket line total plus every fee, discount, and tip row
+// against the payable amount. What remains is reported as rounding. Lines
+// whose price changed since they were added come back as price_changed
+// warnings.
+func buildCheckoutReconciliation(basket map[string]any, payload map[string]any, added map[string]addedPrice, tip int, currency string) (map[string]any, []string) {
+	money := func(amount int) map[string]any {
+		return map[string]any{"amount": amount, "formatted_amount": formatMinorAmount(amount, currency)}
+	}
+	checkoutPrices := map[string]int{}
+	for _, value := range asSlice(payload["items"]) {
+		item := asMap(value)
+		if id := strings.ToLower(strings.TrimSpace(asString(item["id"]))); id != "" && item["price"] != nil {
+			checkoutPrices[id] = asInt(item["price"])
+		}
+	}
+
+	warnings := []string{}
+	lines := []any{}
+	itemsTotal, changed := 0, 0
+	for _, value := range asSlice(basket["items"]) {
+		item := asMap(value)
+		itemID := strings.TrimSpace(asString(item["id"]))
+		count := max(asInt(item["count"]), 1)
+		unitPrice := asInt(item["price"])
+		if price, ok := checkoutPrices[strings.ToLower(itemID)]; ok {
+			unitPrice = price
+		}
+		itemsTotal += count * unitPrice
+		line := map[string]any{
+			"item_id":          itemID,
+			"name":             emptyToNil(asString(item["name"])),
+			"count":            count,
+			"unit_price":       money(unitPrice),
+			"line_total":       money(count * unitPrice),
+			"added_unit_price": nil,
+			"added_at":         nil,
+			"price_changed":    false,
+		}
+		if price, ok := added[strings.ToLower(itemID)]; ok {
+			line["added_unit_price"] = money(price.Amount)
+			line["added_at"] = price.AddedAt.Format("2006-01-02T15:04:05Z07:00")
+			if price.Amount != unitPrice {
+				changed++
+				line["price_changed"] = true
+				warnings = append(warnings, fmt.Sprintf(
+					"price_changed: %s was %s when added and is %s now",
+					fallbackString(asString(item["name"]), itemID),
+					formatMinorAmount(price.Amount, currency),
+					formatMinorAmount(unitPrice, currency),
+				))
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	adjustments := []any{}
+	adjusted := 0
+	hasTip := false
+	var checkoutItemsTotal any
+	for _, value := range asSlice(payload["checkout_rows"]) {
+		row := asMap(value)
+		if asString(row["template"]) != "amount_row" {
+			continue
+		}
+		amount, ok := checkoutRowAmount(row)
+		if !ok {
+			continue
+		}
+		kind := checkoutRowKind(asString(row["label"]))
+		if kind == "items" {
+			checkoutItemsTotal = money(amount)
+			continue
+		}
+		if kind == "discount" && amount > 0 {
+			amount = -amount
+		}
+		hasTip = hasTip || kind == "tip"
+		adjusted += amount
+		adjustments = append(adjustments, map[string]any{"label": asString(row["label"]), "kind": kind, "amount": money(amount)})
+	}
+	if tip > 0 && !hasTip {
+		adjusted += tip
+		adjustments = append(adjustments, map[string]any{"label": "Courier tip", "kind": "tip", "amount": money(tip)})
+	}
+
+	payable := asInt(payload["payable_amount"])
+	expected := itemsTotal + adjusted
+	return map[string]any{
+		"lines":                lines,
+		"changed_lines":        changed,
+		"items_total":          money(itemsTotal),
+		"checkout_items_total": checkoutItemsTotal,
+		"adjustments":          adjustments,
+		"expected_total":       money(expected),
+		"payable_total":        money(payable),
+		"rounding":             money(payable - expected),
+	}, warnings
+}
+
+func buildCheckoutReconciliationTable(reconciliation map[string]any) string {
+	amount := func(value any) string {
+		object := asMap(value)
+		if object == nil {
+			return "-"
+		}
+		return fallbackString(asString(object["formatted_amount"]), asString(object["amount"]))
+	}
+	rows := [][]string{}
+	for _, value := range asSlice(reconciliation["lines"]) {
+		line := asMap(value)
+		changed := "-"
+		if asBool(line["price_changed"]) {
+			changed = "changed"
+		}
+		rows = append(rows, []string{
+			fallbackString(asString(line["name"]), asString(line["item_id"])),
+			asString(line["count"]),
+			amount(line["added_unit_price"]),
+			amount(line["unit_price"]),
+			amount(line["line_total"]),
+			changed,
+		})
+	}
+	rows = append(rows, []string{"Items", "", "", "", amount(reconciliation["items_total"]), ""})
+	for _, value := range asSlice(reconciliation["adjustments"]) {
+		adjustment := asMap(value)
+		rows = append(rows, []string{asString(adjustment["label"]), "", "", "", amount(adjustment["amount"]), asString(adjustment["kind"])})
+	}
+	rows = append(rows,
+		[]string{"Expected", "", "", "", amount(reconciliation["expected_total"]), ""},
+		[]string{"Payable", "", "", "", amount(reconciliation["payable_total"]), ""},
+		[]string{"Rounding", "", "", "", amount(reconciliation["rounding"]), ""},
+	)
+	return output.RenderTable("Reconciliation", []string{"Line", "Qty", "Added at", "Now", "Total", "Note"}, rows)
+}
@@ -0,0 +1,34 @@
+package cli
+
+import "testing"
+
+func TestCheckoutReconciliationSubtractsItemDiscountRows(t *testing.T) {
+	amountRow := func(label string, amount int) any {
+		return map[string]any{"template": "amount_row", "label": label, "amount": map[string]any{"amount": amount}}
+	}
+	basket := map[string]any{"items": []any{map[string]any{"id": "item-1", "name": "Burger", "count": 1, "price": 1000}}}
+	payload := map[string]any{
+		"payable_amount": 840,
+		"checkout_rows": []any{
+			amountRow("Item subtotal", 1000),
+			amountRow("Item discount", 200),
+			amountRow("Items offer", 150),
+			amountRow("Delivery fee", 190),
+		},
+	}
+
+	reconciliation, _ := buildCheckoutReconciliation(basket, payload, nil, 0, "EUR")
+	if got := asInt(asMap(reconciliation["checkout_items_total"])["amount"]); got != 1000 {
+		t.Fatalf("expected the subtotal row as the items total, got %d", got)
+	}
+	kinds := []string{}
+	for _, value := range asSlice(reconciliation["adjustments"]) {
+		kinds = append(kinds, asString(asMap(value)["kind"]))
+	}
+	if len(kinds) != 3 || kinds[0] != "discount" || kinds[1] != "discount" || kinds[2] != "fee" {
+		t.Fatalf("expected two discounts and a fee, got %v", kinds)
+	}
+	if rounding := asInt(asMap(reconciliation["rounding"])["amount"]); rounding != 0 {
+		t.Fatalf("expected no rounding once item discounts are subtracted, got %d", rounding)
+	}
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+)
+
+// paySplit is one --pay-with entry: a fixed amount in minor units, or the
+// remainder of the payable total when rest is set.
+type paySplit struct {
+	method string
+	amount int
+	rest   bool
+}
+
+// benefitPaymentMethods lists the stored-value lunch and benefit providers
+// whose use is limited per country; any other method settles the remainder.
+var benefitPaymentMethods = []string{
+	"cibus",
+	"edenred",
+	"epassi",
+	"meal_benefit",
+	"pluxee",
+	"smartum",
+	"szep_kh",
+	"szep_mkb",
+	"szep_otp",
+	"updejeuner",
+}
+
+// splitPaymentCountries maps a venue country to the benefit methods that may
+// share an order with another payment method there.
+var splitPaymentCountries = map[string][]string{
+	"FIN": {"edenred", "epassi", "meal_benefit", "pluxee", "smartum"},
+	"HUN": {"szep_kh", "szep_mkb", "szep_otp"},
+	"ISR": {"cibus"},
+}
+
+// parsePayWith reads --pay-with METHOD:AMOUNT|rest values.
+func parsePayWith(values []string) ([]paySplit, error) {
+	splits := make([]paySplit, 0, len(values))
+	seen := map[string]struct{}{}
+	hasRest := false
+	for _, value := range values {
+		method, amount, ok := strings.Cut(strings.TrimSpace(value), ":")
+		method = strings.ToLower(strings.TrimSpace(method))
+		amount = strings.ToLower(strings.TrimSpace(amount))
+		if !ok || method == "" || amount == "" {
+			return nil, fmt.Errorf("--pay-with %q must be METHOD:AMOUNT or METHOD:rest", value)
+		}
+		if _, dup := seen[method]; dup {
+			return nil, fmt.Errorf("--pay-with names %s more than once", method)
+		}
+		seen[method] = struct{}{}
+		split := paySplit{method: method}
+		if amount == "rest" {
+			if hasRest {
+				return nil, fmt.Errorf("--pay-with accepts only one rest entry")
+			}
+			hasRest = true
+			split.rest = true
+		} else {
+			parsed, err := strconv.Atoi(amount)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("--pay-with %q amount must be a positive number of minor units", value)
+			}
+			split.amount = parsed
+		}
+		splits = append(splits, split)
+	}
+	return splits, nil
+}
+
+// checkSplitCountry rejects splits the venue's country does not allow: a
+// benefit method outside the country's list, or more than one benefit method
+// on the same order.
+func checkSplitCountry(splits []paySplit, country string) error {
+	if len(splits) < 2 {
+		return nil
+	}
+	country = strings.ToUpper(strings.TrimSpace(country))
+	benefits := []string{}
+	for _, split := range splits {
+		if slices.Contains(benefitPaymentMethods, split.method) {
+			benefits = append(benefits, split.method)
+		}
+	}
+	if len(benefits) == 0 {
+		return nil
+	}
+	if country == "" {
+		return fmt.Errorf("venue country is unknown, so a split with %s cannot be checked", benefits[0])
+	}
+	if len(benefits) > 1 {
+		return fmt.Errorf("only one benefit method can be combined per order, got %s", strings.Join(benefits, " and "))
+	}
+	if !slices.Contains(splitPaymentCountries[country], benefits[0]) {
+		return fmt.Errorf("%s cannot be split with another payment method for venues in %s", benefits[0], country)
+	}
+	return nil
+}
+
+// allocatePaySplit spreads payable over the splits in flag order. Fixed
+// amounts act as limits and are capped at what is still owed; the rest entry
+// takes whatever remains.
+func allocatePaySplit(splits []paySplit, payable int, currency string) ([]any, []string, error) {
+	remaining := payable
+	warnings := []string{}
+	amounts := make([]int, len(splits))
+	for i, split := range splits {
+		if split.rest {
+			continue
+		}
+		amounts[i] = min(split.amount, remaining)
+		if amounts[i] < split.amount {
+			warnings = append(warnings, fmt.Sprintf("--pay-with %s:%d exceeds what is owed; capped at %d", split.method, split.amount, amounts[i]))
+		}
+		remaining -= amounts[i]
+	}
+	restIndex := slices.IndexFunc(splits, func(split paySplit) bool { return split.rest })
+	if restIndex >= 0 {
+		amounts[restIndex] = remaining
+		remaining = 0
+	}
+	if remaining > 0 {
+		return nil, nil, fmt.Errorf(
+			"--pay-with leaves %s of %s unpaid; add a METHOD:rest entry",
+			fallbackString(formatMinorAmount(remaining, currency), strconv.Itoa(remaining)),
+			fallbackString(formatMinorAmount(payable, currency), strconv.Itoa(payable)),
+		)
+	}
+	rows := make([]any, 0, len(splits))
+	for i, split := range splits {
+		var requested any = split.amount
+		if split.rest {
+			requested = "rest"
+		}
+		rows = append(rows, map[string]any{
+			"method":    split.method,
+			"requested": requested,
+			"amount": map[string]any{
+				"amount":           amounts[i],
+				"formatted_amount": emptyToNil(formatMinorAmount(amounts[i], currency)),
+			},
+		})
+	}
+	return rows, warnings, nil
+}
+
+func buildPaymentSplitTable(split map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(split["methods"]) {
+		entry := asMap(value)
+		amount := asMap(entry["amount"])
+		rows = append(rows, []string{
+			asString(entry["method"]),
+			asString(entry["requested"]),
+			fallbackString(asString(amount["formatted_amount"]), asString(amount["amount"])),
+		})
+	}
+	return output.RenderTable("Payment split", []string{"Method", "Requested", "Amount"}, rows)
+}
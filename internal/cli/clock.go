@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Clock reports the current time. Opening-hours checks, token expiry, and
+// cache ages read it, so a fixed clock makes them deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+// Random draws the seed for random choices made without --seed.
+type Random interface {
+	Seed() int64
+}
+
+// ClockFunc adapts a function to Clock.
+type ClockFunc func() time.Time
+
+// Now calls f.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SeedFunc adapts a function to Random.
+type SeedFunc func() int64
+
+// Seed calls f.
+func (f SeedFunc) Seed() int64 {
+	return f()
+}
+
+// now is the time from deps.Clock, or the system clock when none is set.
+func (deps Dependencies) now() time.Time {
+	if deps.Clock == nil {
+		return time.Now()
+	}
+	return deps.Clock.Now()
+}
+
+type clockKey struct{}
+
+// withClock records deps.Clock for code that only sees the command context.
+func withClock(ctx context.Context, deps Dependencies) context.Context {
+	return context.WithValue(ctx, clockKey{}, deps.Clock)
+}
+
+// clockNow is deps.now() for the stage that ctx belongs to.
+func clockNow(ctx context.Context) time.Time {
+	clock, _ := ctx.Value(clockKey{}).(Clock)
+	return Dependencies{Clock: clock}.now()
+}
+
+// seed draws from deps.Random. Without one, seeds come from the system random
+// source unless a Clock is injected; then they follow the clock, so a pinned
+// clock also pins the pick.
+func (deps Dependencies) seed() int64 {
+	switch {
+	case deps.Random != nil:
+		return deps.Random.Seed()
+	case deps.Clock != nil:
+		return deps.Clock.Now().UnixNano()
+	}
+	return rand.Int64()
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/approval"
+	"github.com/mekedron/wolt-cli/internal/service/money"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// approvalMaxTTL caps how long an approval token stays valid.
+const approvalMaxTTL = 7 * 24 * time.Hour
+
+func newApproveCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var ttl time.Duration
+	var note string
+
+	cmd := &cobra.Command{
+		Use:   "approve <amount> <currency>",
+		Short: "Issue a single-use token that lets one command go above the approval threshold.",
+		Long: "Issue a single-use token that lets one command go above the approval threshold.\n\n" +
+			"Profiles with an approval threshold (configure --approval-threshold) refuse cart add, cart update,\n" +
+			"venue shop --apply, and checkout preview when the basket total is above it, unless the command is\n" +
+			"confirmed at a prompt or passed --approve-token with a token from this command. The token covers\n" +
+			"one command of this profile with a total up to <amount> in <currency>, until it expires.",
+		Example: "wolt approve 120 EUR --ttl 1h --note \"team lunch\"",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			currency := strings.ToUpper(strings.TrimSpace(args[1]))
+			if !currencyCodePattern.MatchString(currency) {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("%q is not a currency code such as EUR", args[1]))
+			}
+			amount, err := money.ParseMajor(args[0], currency)
+			if err != nil || amount <= 0 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("approval amount must be a positive number, got %q", args[0]))
+			}
+			if ttl <= 0 || ttl > approvalMaxTTL {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--ttl must be between 1s and 168h")
+			}
+
+			store, err := openApprovalStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_APPROVAL_STORE_ERROR", err.Error())
+			}
+			owner := journalProfile(cmd.Context(), deps, flags.Profile)
+			now := deps.now()
+			issued := approval.Approval{
+				Profile:   owner,
+				Amount:    amount,
+				Currency:  currency,
+				Note:      strings.TrimSpace(note),
+				IssuedAt:  now,
+				ExpiresAt: now.Add(ttl),
+			}
+			token, err := store.Issue(issued)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_APPROVAL_STORE_ERROR", err.Error())
+			}
+
+			data := map[string]any{
+				"token":   token,
+				"profile": owner,
+				"max_total": map[string]any{
+					"amount":           amount,
+					"currency":         currency,
+					"formatted_amount": formatMinorAmount(amount, currency),
+				},
+				"expires_at": issued.ExpiresAt.UTC().Format(time.RFC3339),
+				"note":       emptyToNil(issued.Note),
+			}
+			if format == output.FormatTable {
+				rows := [][]string{
+					{"Token", token},
+					{"Profile", owner},
+					{"Up to", formatMinorAmount(amount, currency)},
+					{"Expires", issued.ExpiresAt.Local().Format("2006-01-02 15:04")},
+				}
+				if issued.Note != "" {
+					rows = append(rows, []string{"Note", issued.Note})
+				}
+				return writeTable(cmd, output.RenderTable("Approval", []string{"Field", "Value"}, rows), flags.Output)
+			}
+			env := output.BuildEnvelope(owner, flags.Locale, data, []string{}, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", 30*time.Minute, "How long the token stays valid (at most 168h).")
+	cmd.Flags().StringVar(&note, "note", "", "Reason recorded with the approval.")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ func newAuthCommand(deps Dependencies) *cobra.Command {
 		Short: "Inspect authentication state for authenticated commands.",
 	}
 	auth.AddCommand(newAuthStatusCommand(deps))
+	auth.AddCommand(newAuthRefreshCommand(deps))
 	return auth
 }
 
@@ -76,7 +78,10 @@ func newAuthStatusCommand(deps Dependencies) *cobra.Command {
 				"wolt_plus_subscriber": woltPlusSubscriber,
 			}
 			if flags.Verbose {
-				data["token_preview"] = tokenPreview(auth.WToken)
+				data["token_preview"] = woltgateway.Redacted
+				if flags.RevealSecrets {
+					data["token_preview"] = tokenPreview(auth.WToken)
+				}
 				data["cookie_count"] = len(auth.Cookies)
 			}
 
@@ -92,6 +97,75 @@ func newAuthStatusCommand(deps Dependencies) *cobra.Command {
 	return cmd
 }
 
+func newAuthRefreshCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Rotate the access token with the refresh token and save both to the profile.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			refreshToken := strings.TrimSpace(auth.RefreshToken)
+			if refreshToken == "" {
+				return emitError(
+					cmd,
+					format,
+					profileName,
+					flags.Locale,
+					flags.Output,
+					"WOLT_AUTH_REQUIRED",
+					"No refresh token. Pass --wrtoken or store one with `wolt configure --wrtoken`.",
+				)
+			}
+			result, err := deps.Wolt.RefreshAccessToken(cmd.Context(), refreshToken, auth)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			accessToken := normalizeWToken(result.AccessToken)
+			if accessToken == "" {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, fmt.Errorf("%w: refresh response did not include access token", woltgateway.ErrUpstream))
+			}
+			rotatedRefresh := fallbackString(normalizeRefreshToken(result.RefreshToken), refreshToken)
+			woltgateway.RememberAuthSecrets(woltgateway.AuthContext{WToken: accessToken, RefreshToken: rotatedRefresh})
+
+			warnings := []string{}
+			saved := false
+			if credentialsInjected(cmd.Context()) {
+				warnings = append(warnings, "credentials came from the environment or stdin; the rotated tokens were not saved")
+			} else if err := upsertProfileTokens(cmd.Context(), deps, flags.Profile, accessToken, rotatedRefresh); err != nil {
+				warnings = append(warnings, fmt.Sprintf("failed to persist rotated tokens in profile config: %v", err))
+			} else {
+				saved = true
+			}
+			data := map[string]any{
+				"refreshed":          true,
+				"session_expires_at": emptyToNil(tokenExpiryRFC3339(accessToken)),
+				"saved_to_profile":   saved,
+			}
+
+			if format == output.FormatTable {
+				rows := [][]string{
+					{"Refreshed", boolToYesNo(true)},
+					{"Session expires", fallbackString(asString(data["session_expires_at"]), "-")},
+					{"Saved to profile", boolToYesNo(saved)},
+				}
+				return writeTable(cmd, output.RenderTable("Auth refresh", []string{"Field", "Value"}, rows), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
 func buildAuthStatusTable(data map[string]any) string {
 	headers := []string{"Field", "Value"}
 	rows := [][]string{
@@ -0,0 +1,391 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/money"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	budgetPeriodMonth = "month"
+	budgetPeriodWeek  = "week"
+	// budgetWarnPercent is the share of the budget that adds a warning.
+	budgetWarnPercent = 80
+	// budgetHistoryMaxPages bounds the order history walk for one period.
+	budgetHistoryMaxPages = 5
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func newBudgetCommand(deps Dependencies) *cobra.Command {
+	budget := &cobra.Command{
+		Use:   "budget",
+		Short: "Set a spend budget that checkout preview enforces.",
+		Long: "Set a spend budget that checkout preview enforces.\n\n" +
+			"Spend is summed from order history for the current calendar month or week (local time). " +
+			"checkout preview reports the remaining budget, warns once the basket brings spend to 80%, and " +
+			"fails with WOLT_BUDGET_EXCEEDED above the budget unless --force is passed.",
+	}
+	budget.AddCommand(newBudgetSetCommand(deps))
+	budget.AddCommand(newBudgetShowCommand(deps))
+	budget.AddCommand(newBudgetClearCommand(deps))
+	return budget
+}
+
+func newBudgetSetCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var period string
+
+	cmd := &cobra.Command{
+		Use:     "set <amount> <currency>",
+		Short:   "Store a spend budget on the profile.",
+		Example: "wolt budget set 200 EUR --period month",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			currency := strings.ToUpper(strings.TrimSpace(args[1]))
+			if !currencyCodePattern.MatchString(currency) {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("%q is not a currency code such as EUR", args[1]))
+			}
+			amount, err := money.ParseMajor(args[0], currency)
+			if err != nil || amount <= 0 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("budget amount must be a positive number, got %q", args[0]))
+			}
+			period = strings.ToLower(strings.TrimSpace(period))
+			if period != budgetPeriodMonth && period != budgetPeriodWeek {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--period must be month or week")
+			}
+
+			budget := &domain.Budget{Amount: amount, Currency: currency, Period: period}
+			name, err := saveProfileBudget(cmd, deps, flags.Profile, budget)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_PROFILE_ERROR", err.Error())
+			}
+			data := map[string]any{"budget": budgetSettingsData(budget)}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildBudgetTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(name, flags.Locale, data, []string{}, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	cmd.Flags().StringVar(&period, "period", budgetPeriodMonth, "Budget period: month or week (calendar, local time)")
+	return cmd
+}
+
+func newBudgetShowCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the budget and spend so far this period.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			if err == nil && strings.TrimSpace(profile.Name) != "" {
+				profileName = profile.Name
+			}
+			if profile.Budget == nil {
+				data := map[string]any{"budget": nil}
+				warnings := []string{"no budget set; use wolt budget set"}
+				switch format {
+				case output.FormatTable:
+					return writeTable(cmd, buildBudgetTable(data), flags.Output)
+				case output.FormatMarkdown:
+					return writeMarkdownReport(cmd, "Wolt budget", buildBudgetTable(data), warnings, flags.Output)
+				}
+				env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+				return writeMachinePayload(cmd, env, format, flags.Output)
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+			spend, warnings, err := loadBudgetSpend(cmd, deps, flags, &auth, *profile.Budget, deps.now())
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			budgetData, budgetWarnings := buildBudgetUsage(*profile.Budget, spend, 0)
+			data := map[string]any{"budget": budgetData}
+			warnings = append(warnings, budgetWarnings...)
+			switch format {
+			case output.FormatTable:
+				return writeTable(cmd, buildBudgetTable(data), flags.Output)
+			case output.FormatMarkdown:
+				return writeMarkdownReport(cmd, "Wolt budget", buildBudgetTable(data), warnings, flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	enableMarkdown(cmd)
+	return cmd
+}
+
+func newBudgetClearCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the budget from the profile.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			name, err := saveProfileBudget(cmd, deps, flags.Profile, nil)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_PROFILE_ERROR", err.Error())
+			}
+			data := map[string]any{"budget": nil}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildBudgetTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(name, flags.Locale, data, []string{}, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// saveProfileBudget stores budget (nil clears it) on the selected profile and
+// returns the profile name.
+func saveProfileBudget(cmd *cobra.Command, deps Dependencies, profileFlag string, budget *domain.Budget) (string, error) {
+	if deps.Config == nil {
+		return "", fmt.Errorf("config store is not available")
+	}
+	cfg, err := deps.Config.Load(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	index := findProfileIndex(cfg, profileFlag)
+	if index < 0 {
+		return "", fmt.Errorf("profile %q not found", defaultProfileName(profileFlag))
+	}
+	cfg.Profiles[index].Budget = budget
+	if err := deps.Config.Save(cmd.Context(), cfg); err != nil {
+		return "", err
+	}
+	return cfg.Profiles[index].Name, nil
+}
+
+// budgetSpend is the order history total for the current budget period.
+type budgetSpend struct {
+	Start     time.Time
+	Spent     int
+	Orders    int
+	Truncated bool
+}
+
+// budgetPeriodStart is the local start of the calendar month or ISO week
+// (Monday) containing now.
+func budgetPeriodStart(period string, now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if period == budgetPeriodWeek {
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day.AddDate(0, 0, 1-day.Day())
+}
+
+// loadBudgetSpend sums the totals of orders paid since the period start,
+// walking order history newest first. Rejected and cancelled orders are not
+// spend.
+func loadBudgetSpend(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	budget domain.Budget,
+	now time.Time,
+) (budgetSpend, []string, error) {
+	spend := budgetSpend{Start: budgetPeriodStart(budget.Period, now)}
+	warnings := []string{}
+	pageToken := ""
+	for pages := 1; ; pages++ {
+		payload, authWarnings, err := invokeWithAuthAutoRefresh(
+			cmd.Context(),
+			deps,
+			flags,
+			auth,
+			func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+				return deps.Wolt.OrderHistory(
+					cmd.Context(),
+					authCtx,
+					woltgateway.OrderHistoryOptions{Limit: profileOrdersMaxLimit, PageToken: pageToken},
+				)
+			},
+		)
+		warnings = append(warnings, authWarnings...)
+		if err != nil {
+			return spend, warnings, err
+		}
+		reachedStart := false
+		for _, value := range extractOrderHistoryOrders(payload, "") {
+			row := asMap(value)
+			paidAt := time.UnixMilli(int64(asInt(row["payment_time_ts"])))
+			if paidAt.Before(spend.Start) {
+				reachedStart = true
+				continue
+			}
+			switch strings.ToLower(asString(row["status"])) {
+			case "rejected", "cancelled", "canceled", "refunded":
+				continue
+			}
+			amount, ok := orderTotalMinor(asString(row["total_amount"]), budget.Currency)
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("order %s has no readable total and is left out of the budget", asString(row["purchase_id"])))
+				continue
+			}
+			spend.Spent += amount
+			spend.Orders++
+		}
+		pageToken = strings.TrimSpace(asString(payload["next_page_token"]))
+		if pageToken == "" || reachedStart {
+			return spend, warnings, nil
+		}
+		if pages >= budgetHistoryMaxPages {
+			spend.Truncated = true
+			warnings = append(warnings, fmt.Sprintf("budget spend counts only the newest %d order history pages", pages))
+			return spend, warnings, nil
+		}
+	}
+}
+
+// orderTotalMinor reads an order history total such as "€15.38" or
+// "15,38 €" as minor units of currency.
+func orderTotalMinor(formatted string, currency string) (int, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			return r
+		}
+		return -1
+	}, formatted)
+	if digits == "" {
+		return 0, false
+	}
+	amount, err := money.ParseMajor(digits, currency)
+	return amount, err == nil
+}
+
+// buildBudgetUsage reports spend against budget with pending, the amount of
+// the order being previewed, on top. The warnings flag spend at or above 80%.
+func buildBudgetUsage(budget domain.Budget, spend budgetSpend, pending int) (map[string]any, []string) {
+	after := spend.Spent + pending
+	usedPercent := 0
+	if budget.Amount > 0 {
+		usedPercent = after * 100 / budget.Amount
+	}
+	data := budgetSettingsData(&budget)
+	data["period_start"] = spend.Start.Format(time.RFC3339)
+	data["orders"] = spend.Orders
+	data["spent"] = budgetAmountData(spend.Spent, budget.Currency)
+	data["remaining"] = budgetAmountData(budget.Amount-spend.Spent, budget.Currency)
+	data["used_percent"] = usedPercent
+	data["truncated"] = spend.Truncated
+
+	warnings := []string{}
+	if usedPercent >= budgetWarnPercent && after <= budget.Amount {
+		warnings = append(warnings, fmt.Sprintf("%s spend is at %d%% of the %s budget", budget.Period, usedPercent, formatMinorAmount(budget.Amount, budget.Currency)))
+	}
+	return data, warnings
+}
+
+func budgetSettingsData(budget *domain.Budget) map[string]any {
+	return map[string]any{
+		"amount":   budgetAmountData(budget.Amount, budget.Currency),
+		"currency": budget.Currency,
+		"period":   budget.Period,
+	}
+}
+
+func budgetAmountData(amount int, currency string) map[string]any {
+	return map[string]any{
+		"amount":           amount,
+		"formatted_amount": emptyToNil(formatMinorAmount(amount, currency)),
+	}
+}
+
+func buildBudgetTable(data map[string]any) string {
+	budget := asMap(data["budget"])
+	if budget == nil {
+		return output.RenderTable("Budget", []string{"Field", "Value"}, [][]string{{"Budget", "-"}})
+	}
+	rows := [][]string{
+		{"Budget", asString(asMap(budget["amount"])["formatted_amount"]) + " per " + asString(budget["period"])},
+	}
+	if spent := asMap(budget["spent"]); spent != nil {
+		rows = append(rows,
+			[]string{"Since", asString(budget["period_start"])},
+			[]string{"Spent", fmt.Sprintf("%s (%d orders, %d%%)", asString(spent["formatted_amount"]), asInt(budget["orders"]), asInt(budget["used_percent"]))},
+			[]string{"Remaining", asString(asMap(budget["remaining"])["formatted_amount"])},
+		)
+	}
+	return output.RenderTable("Budget", []string{"Field", "Value"}, rows)
+}
+
+// checkCheckoutBudget compares the period spend plus a previewed payable
+// amount with the profile budget. It returns the data.budget block, or an
+// emitted WOLT_BUDGET_EXCEEDED error when the order would go over budget and
+// force is not set. Order history failures only warn, so a flaky history
+// endpoint does not block previews.
+func checkCheckoutBudget(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	budget domain.Budget,
+	payable int,
+	currency string,
+	force bool,
+	format output.Format,
+	profileName string,
+) (map[string]any, []string, error) {
+	if currency != "" && !strings.EqualFold(currency, budget.Currency) {
+		return nil, []string{fmt.Sprintf("budget is in %s but this checkout is in %s; budget not checked", budget.Currency, currency)}, nil
+	}
+	spend, warnings, err := loadBudgetSpend(cmd, deps, flags, auth, budget, deps.now())
+	if err != nil {
+		return nil, append(warnings, fmt.Sprintf("budget not checked: order history unavailable: %v", err)), nil
+	}
+	data, usageWarnings := buildBudgetUsage(budget, spend, payable)
+	data["remaining_after_checkout"] = budgetAmountData(budget.Amount-spend.Spent-payable, budget.Currency)
+	warnings = append(warnings, usageWarnings...)
+	if over := spend.Spent + payable - budget.Amount; over > 0 {
+		message := fmt.Sprintf(
+			"this order brings %s spend to %s, %s over the %s budget",
+			budget.Period,
+			formatMinorAmount(spend.Spent+payable, budget.Currency),
+			formatMinorAmount(over, budget.Currency),
+			formatMinorAmount(budget.Amount, budget.Currency),
+		)
+		if !force {
+			return nil, warnings, emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_BUDGET_EXCEEDED", message+"; pass --force to preview anyway")
+		}
+		warnings = append(warnings, message)
+	}
+	return data, warnings, nil
+}
@@ -18,8 +18,12 @@ func newCartCommand(deps Dependencies) *cobra.Command {
 	cart.AddCommand(newCartShowCommand(deps))
 	cart.AddCommand(newCartAddCommand(deps))
 	cart.AddCommand(newCartRemoveCommand(deps))
+	cart.AddCommand(newCartUpdateCommand(deps))
 	cart.AddCommand(newCartClearCommand(deps))
 	cart.AddCommand(newCartCountCommand(deps))
+	cart.AddCommand(newCartExportCommand(deps))
+	cart.AddCommand(newCartImportCommand(deps))
+	markAuthRequired(cart, true)
 	return cart
 }
 
@@ -100,6 +104,7 @@ func newCartShowCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for cart endpoints. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for cart endpoints. Provide together with --lat.")
 	addGlobalFlags(cmd, &flags)
+	enableHASensor(cmd, "cart")
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		latSet = cmd.Flags().Changed("lat")
 		lonSet = cmd.Flags().Changed("lon")
@@ -109,13 +114,17 @@ func newCartShowCommand(deps Dependencies) *cobra.Command {
 
 func newCartAddCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
+	var noLock bool
+	var force bool
+	var approveToken string
 	var count int
 	var optionFlags []string
-	var allowSubstitutions bool
+	var substitution substitutionFlags
 	var nameOverride string
 	var priceOverride int
 	var currencyOverride string
 	var venueSlug string
+	var fromJSON string
 	var lat float64
 	var lon float64
 	var latSet bool
@@ -124,7 +133,11 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add <venue-id> <item-id>",
 		Short: "Add an item to basket.",
-		Args:  cobra.ExactArgs(2),
+		Long: "Add an item to basket.\n\n" +
+			"--from-json takes a partial add-to-basket payload for fields the flags do not cover. Top-level fields\n" +
+			"merge into the request and the single object in items merges into the added line; the venue, item id,\n" +
+			"and count stay with the arguments and --count.",
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			format, err := parseOutputFormat(flags.Format)
 			if err != nil {
@@ -134,10 +147,32 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 				return fmt.Errorf("%s", requiredArg("--count must be greater than 0"))
 			}
 			profileName := defaultProfileName(flags.Profile)
+			substitution.read(cmd)
+			if err := substitution.validate(); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			var requestInput, lineInput map[string]any
+			if strings.TrimSpace(fromJSON) != "" {
+				input, err := readJSONInput(cmd, "from-json", fromJSON)
+				if err == nil {
+					requestInput, lineInput, err = splitBasketLineInput(input)
+				}
+				if err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+			}
 			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
 			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
 				return err
 			}
+			if err := guardLatestOrderTime(cmd, deps, flags.Profile, force, format, profileName, flags.Locale, flags.Output); err != nil {
+				return err
+			}
+			release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			defer release()
 
 			venueID := strings.TrimSpace(args[0])
 			itemID := strings.TrimSpace(args[1])
@@ -197,6 +232,7 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 					}
 					if fallback := buildItemPayloadFromAssortment(assortmentPayload, itemID); fallback != nil {
 						itemPayload = mergeItemPayloadFallback(itemPayload, fallback)
+						recordFallback(cmd.Context(), "item_assortment")
 						break
 					}
 					if !needsVenueContentFallback(assortmentPayload, venueID) {
@@ -206,6 +242,7 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 					warnings = append(warnings, fallbackWarnings...)
 					if fallback := buildItemPayloadFromMenuPayloads(venueContentPayloads, venueID, itemID); fallback != nil {
 						itemPayload = mergeItemPayloadFallback(itemPayload, fallback)
+						recordFallback(cmd.Context(), "item_venue_content")
 						warnings = append(warnings, "used venue content fallback metadata for cart item")
 						break
 					}
@@ -256,18 +293,17 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 			}
 			options := buildBasketOptions(itemPayload, selectedOptions)
 			newLineItem := map[string]any{
-				"id":      itemID,
-				"count":   count,
-				"name":    name,
-				"price":   price,
-				"options": options,
-				"substitution_settings": map[string]any{
-					"is_allowed": allowSubstitutions,
-				},
+				"id":                    itemID,
+				"count":                 count,
+				"name":                  name,
+				"price":                 price,
+				"options":               options,
+				"substitution_settings": substitution.apply(nil),
 			}
 
 			mergedItems := []any{newLineItem}
 			venueMutationID := venueID
+			var existingBasket map[string]any
 			existingPage, preAddAuthWarnings, preAddErr := invokeWithAuthAutoRefresh(
 				cmd.Context(),
 				deps,
@@ -280,34 +316,18 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 			warnings = append(warnings, preAddAuthWarnings...)
 			if preAddErr == nil {
 				selectedBasket, _, _ := selectBasketWithMeta(existingPage, venueID)
+				existingBasket = selectedBasket
 				if selectedBasket != nil {
 					resolvedVenue := asMap(selectedBasket["venue"])
 					if resolvedVenueID := strings.TrimSpace(asString(resolvedVenue["id"])); resolvedVenueID != "" {
 						venueMutationID = resolvedVenueID
 					}
-					existingItems := asSlice(selectedBasket["items"])
-					if len(existingItems) > 0 {
-						mergedItems = make([]any, 0, len(existingItems)+1)
-						mergedCurrentLine := false
-						for _, rawValue := range existingItems {
-							line := asMap(rawValue)
-							if line == nil {
-								continue
-							}
-							lineID := strings.TrimSpace(asString(line["id"]))
-							lineCount := asInt(line["count"])
-							if lineCount <= 0 {
-								lineCount = 1
+					mergedItems = mergeBasketAddLines(asSlice(selectedBasket["items"]), []map[string]any{newLineItem})
+					if substitution.set() {
+						for _, value := range mergedItems {
+							if line := asMap(value); strings.EqualFold(strings.TrimSpace(asString(line["id"])), itemID) {
+								line["substitution_settings"] = substitution.apply(asMap(line["substitution_settings"]))
 							}
-							if lineID != "" && strings.EqualFold(lineID, itemID) {
-								mergedItems = append(mergedItems, buildBasketUpsertItem(line, lineCount+count))
-								mergedCurrentLine = true
-								continue
-							}
-							mergedItems = append(mergedItems, buildBasketUpsertItem(line, lineCount))
-						}
-						if !mergedCurrentLine {
-							mergedItems = append(mergedItems, newLineItem)
 						}
 					}
 				}
@@ -320,6 +340,35 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 				"venue_id": venueMutationID,
 				"currency": currency,
 			}
+			var inputFields []string
+			if requestInput != nil {
+				inputFields, err = mergeJSONInput(addPayload, requestInput, "venue_id")
+				if err == nil && lineInput != nil {
+					var lineFields []string
+					for _, value := range mergedItems {
+						if line := asMap(value); strings.EqualFold(strings.TrimSpace(asString(line["id"])), itemID) {
+							lineFields, err = mergeJSONInput(line, lineInput, "id", "count")
+							break
+						}
+					}
+					for _, field := range lineFields {
+						inputFields = append(inputFields, "items."+field)
+					}
+				}
+				if err != nil {
+					return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--from-json: "+err.Error())
+				}
+			}
+			if err := guardAllowedCountry(cmd, deps, flags.Profile, existingBasket, venueMutationID, format, profile, flags.Locale, flags.Output); err != nil {
+				return err
+			}
+			approvalWarning, err := guardApproval(cmd, deps, flags.Profile, approveToken, basketLinesTotal(asSlice(addPayload["items"])), currency, format, profile, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			if approvalWarning != "" {
+				warnings = append(warnings, approvalWarning)
+			}
 			resultPayload, authWarnings, err := invokeWithAuthAutoRefresh(
 				cmd.Context(),
 				deps,
@@ -332,6 +381,14 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
 			}
+			menuPrice := asInt(asMap(itemPayload["price"])["amount"])
+			if menuPrice <= 0 {
+				menuPrice = asInt(itemPayload["price"])
+			}
+			if menuPrice <= 0 {
+				menuPrice = price
+			}
+			recordAddedPrices(deps, venueMutationID, currency, []map[string]any{{"id": itemID, "price": menuPrice}})
 
 			total := map[string]any{
 				"amount":           count * price,
@@ -376,6 +433,15 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 				"item_name":     name,
 				"item_price":    price,
 				"item_currency": currency,
+				"substitution":  cartLineSubstitution(newLineItem),
+			}
+			for _, value := range mergedItems {
+				if line := asMap(value); strings.EqualFold(strings.TrimSpace(asString(line["id"])), itemID) {
+					data["substitution"] = cartLineSubstitution(line)
+				}
+			}
+			if requestInput != nil {
+				data["json_input"] = jsonInputData(fromJSON, inputFields)
 			}
 
 			if format == output.FormatTable {
@@ -390,13 +456,19 @@ func newCartAddCommand(deps Dependencies) *cobra.Command {
 
 	cmd.Flags().IntVar(&count, "count", 1, "Quantity to add.")
 	cmd.Flags().StringArrayVar(&optionFlags, "option", nil, "Option selection in group-id=value-id or group-id=value-id:count form (IDs or names; repeatable).")
-	cmd.Flags().BoolVar(&allowSubstitutions, "allow-substitutions", false, "Allow substitutions for unavailable items.")
+	addSubstitutionFlags(cmd, &substitution)
+	cmd.Flags().BoolVar(&substitution.allow, "allow-substitutions", false, "Alias of --allow-substitution.")
+	_ = cmd.Flags().MarkHidden("allow-substitutions")
 	cmd.Flags().StringVar(&nameOverride, "name", "", "Override item display name.")
 	cmd.Flags().IntVar(&priceOverride, "price", 0, "Override item price in minor units.")
 	cmd.Flags().StringVar(&currencyOverride, "currency", "", "Override basket currency, for example EUR.")
 	cmd.Flags().StringVar(&venueSlug, "venue-slug", "", "Venue slug used to enrich item metadata/options when needed.")
+	cmd.Flags().StringVar(&fromJSON, "from-json", "", "Partial add-to-basket payload (JSON file, or - for stdin) merged into the request.")
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for cart totals refresh. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for cart totals refresh. Provide together with --lat.")
+	addForceFlag(cmd, &force)
+	addApproveTokenFlag(cmd, &approveToken)
+	addNoLockFlag(cmd, &noLock)
 	addGlobalFlags(cmd, &flags)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		latSet = cmd.Flags().Changed("lat")
@@ -450,6 +522,7 @@ func newCartCountCommand(deps Dependencies) *cobra.Command {
 
 func newCartRemoveCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
+	var noLock bool
 	var venueID string
 	var count int
 	var all bool
@@ -475,6 +548,11 @@ func newCartRemoveCommand(deps Dependencies) *cobra.Command {
 			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
 				return err
 			}
+			release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			defer release()
 
 			var latPtr *float64
 			var lonPtr *float64
@@ -652,6 +730,7 @@ func newCartRemoveCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "Remove all quantity for this item.")
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for cart endpoints. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for cart endpoints. Provide together with --lat.")
+	addNoLockFlag(cmd, &noLock)
 	addGlobalFlags(cmd, &flags)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		latSet = cmd.Flags().Changed("lat")
@@ -662,6 +741,7 @@ func newCartRemoveCommand(deps Dependencies) *cobra.Command {
 
 func newCartClearCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
+	var noLock bool
 	var venueID string
 	var all bool
 	var lat float64
@@ -682,6 +762,11 @@ func newCartClearCommand(deps Dependencies) *cobra.Command {
 			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
 				return err
 			}
+			release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			defer release()
 
 			var latPtr *float64
 			var lonPtr *float64
@@ -789,6 +874,7 @@ func newCartClearCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "Clear all baskets for the authenticated user.")
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for cart endpoints. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for cart endpoints. Provide together with --lat.")
+	addNoLockFlag(cmd, &noLock)
 	addGlobalFlags(cmd, &flags)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		latSet = cmd.Flags().Changed("lat")
@@ -905,6 +991,7 @@ func buildCartState(page map[string]any, venueID string) (map[string]any, []stri
 				"amount":           lineAmount,
 				"formatted_amount": formatMinorAmount(lineAmount, currency),
 			},
+			"substitution": cartLineSubstitution(item),
 		})
 	}
 
@@ -1101,6 +1188,42 @@ func buildBasketMutationItem(line map[string]any, count int) map[string]any {
 	return item
 }
 
+// mergeBasketAddLines returns the full item list for an add mutation: every
+// existing line re-sent so upstream does not drop it, with the count of an
+// added item folded into its existing line and other additions appended.
+func mergeBasketAddLines(existingItems []any, additions []map[string]any) []any {
+	added := map[string]int{}
+	for _, line := range additions {
+		added[strings.ToLower(strings.TrimSpace(asString(line["id"])))] += asInt(line["count"])
+	}
+	merged := make([]any, 0, len(existingItems)+len(additions))
+	mergedIDs := map[string]struct{}{}
+	for _, rawValue := range existingItems {
+		line := asMap(rawValue)
+		if line == nil {
+			continue
+		}
+		lineID := strings.ToLower(strings.TrimSpace(asString(line["id"])))
+		lineCount := asInt(line["count"])
+		if lineCount <= 0 {
+			lineCount = 1
+		}
+		if count, ok := added[lineID]; ok && lineID != "" {
+			if _, done := mergedIDs[lineID]; !done {
+				lineCount += count
+				mergedIDs[lineID] = struct{}{}
+			}
+		}
+		merged = append(merged, buildBasketUpsertItem(line, lineCount))
+	}
+	for _, line := range additions {
+		if _, done := mergedIDs[strings.ToLower(strings.TrimSpace(asString(line["id"])))]; !done {
+			merged = append(merged, line)
+		}
+	}
+	return merged
+}
+
 func buildBasketUpsertItem(line map[string]any, count int) map[string]any {
 	if count <= 0 {
 		count = 1
@@ -1134,14 +1257,12 @@ func buildBasketUpsertItem(line map[string]any, count int) map[string]any {
 		})
 	}
 	return map[string]any{
-		"id":      asString(line["id"]),
-		"count":   count,
-		"name":    asString(line["name"]),
-		"price":   price,
-		"options": lineOptions,
-		"substitution_settings": map[string]any{
-			"is_allowed": asBool(asMap(line["substitution_settings"])["is_allowed"]),
-		},
+		"id":                    asString(line["id"]),
+		"count":                 count,
+		"name":                  asString(line["name"]),
+		"price":                 price,
+		"options":               lineOptions,
+		"substitution_settings": substitutionSettings(asMap(line["substitution_settings"])),
 	}
 }
 
@@ -0,0 +1,538 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// cartOrderFileVersion is the version written by cart export; cart import
+// refuses files from a newer one.
+const cartOrderFileVersion = 1
+
+// cartOrderFile is the portable basket description shared between people:
+// the venue and each line with its options by name, so it stays readable
+// and can be edited before import.
+type cartOrderFile struct {
+	Version int             `json:"version" yaml:"version"`
+	Venue   cartOrderVenue  `json:"venue" yaml:"venue"`
+	Items   []cartOrderItem `json:"items" yaml:"items"`
+}
+
+type cartOrderVenue struct {
+	Slug string `json:"slug,omitempty" yaml:"slug,omitempty"`
+	ID   string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// cartOrderItem options use the --option syntax of cart add:
+// "Group=Value" or "Group=Value:count".
+type cartOrderItem struct {
+	Name    string   `json:"name" yaml:"name"`
+	ItemID  string   `json:"item_id,omitempty" yaml:"item_id,omitempty"`
+	Count   int      `json:"count" yaml:"count"`
+	Options []string `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+func (f cartOrderFile) data() map[string]any {
+	items := make([]any, 0, len(f.Items))
+	for _, item := range f.Items {
+		items = append(items, map[string]any{
+			"name":    item.Name,
+			"item_id": item.ItemID,
+			"count":   item.Count,
+			"options": append([]string{}, item.Options...),
+		})
+	}
+	return map[string]any{
+		"version": f.Version,
+		"venue": map[string]any{
+			"slug": f.Venue.Slug,
+			"id":   f.Venue.ID,
+			"name": f.Venue.Name,
+		},
+		"items": items,
+	}
+}
+
+func newCartExportCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var venueID string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Describe the basket as an order file another profile can import.",
+		Long: "Describe the basket as an order file another profile can import.\n\n" +
+			"The file names the venue and every line with its count and options by name. Table output prints it as\n" +
+			"YAML, so --output order.yaml writes a file ready for `wolt cart import`; json and yaml print it as the\n" +
+			"envelope data, which cart import reads as well.",
+		Example: "wolt cart export --output order.yaml\n" +
+			"wolt cart export --venue-id <venue-id> --format json",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+			page, warnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.BasketsPage(cmd.Context(), location, authCtx)
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			state, _ := buildCartState(page, venueID)
+			if len(asSlice(state["lines"])) == 0 {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_NOT_FOUND", "the cart is empty; there is nothing to export")
+			}
+
+			file := cartOrderFile{
+				Version: cartOrderFileVersion,
+				Venue: cartOrderVenue{
+					Slug: strings.TrimSpace(asString(state["venue_slug"])),
+					ID:   strings.TrimSpace(asString(state["venue_id"])),
+					Name: strings.TrimSpace(asString(state["venue_name"])),
+				},
+			}
+			if file.Venue.Slug == "" && file.Venue.ID != "" {
+				if slug, err := resolveVenueSlug(cmd.Context(), deps, file.Venue.ID); err == nil {
+					file.Venue.Slug = slug
+				} else {
+					warnings = append(warnings, "venue slug unavailable; cart import will look the venue up by id")
+				}
+			}
+			for _, value := range asSlice(state["lines"]) {
+				line := asMap(value)
+				item := cartOrderItem{
+					Name:   strings.TrimSpace(asString(line["name"])),
+					ItemID: strings.TrimSpace(asString(line["item_id"])),
+					Count:  max(asInt(line["count"]), 1),
+				}
+				if options := asSlice(line["options"]); len(options) > 0 {
+					itemPayload, _ := deps.Wolt.VenueItemPage(cmd.Context(), file.Venue.ID, item.ItemID)
+					var byID bool
+					item.Options, byID = cartOrderOptions(options, extractOptionSpecs(itemPayload))
+					if byID {
+						warnings = append(warnings, fmt.Sprintf("some options of %s are written as ids; their names were unavailable", item.Name))
+					}
+				}
+				file.Items = append(file.Items, item)
+			}
+
+			if format == output.FormatTable {
+				encoded, err := yaml.Marshal(file)
+				if err != nil {
+					return err
+				}
+				return output.WriteOutput(cmd.OutOrStdout(), strings.TrimRight(string(encoded), "\n"), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, file.data(), dedupeStrings(warnings), nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&venueID, "venue-id", "", "Export this venue's basket when several are open.")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// cartOrderOptions writes basket line options as Group=Value[:count] by
+// name. A group or value whose name is unknown, or would not parse back, is
+// written by id; the second result reports that.
+func cartOrderOptions(options []any, specs map[string]optionGroupSpec) ([]string, bool) {
+	byID := false
+	label := func(name string, id string) string {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsAny(name, "=:") {
+			byID = true
+			return id
+		}
+		return name
+	}
+	selections := []string{}
+	for _, value := range options {
+		option := asMap(value)
+		groupID := strings.TrimSpace(asString(option["id"]))
+		if groupID == "" {
+			continue
+		}
+		spec := specs[groupID]
+		group := label(spec.Name, groupID)
+		for _, rawValue := range asSlice(option["values"]) {
+			selected := asMap(rawValue)
+			valueID := strings.TrimSpace(asString(selected["id"]))
+			if valueID == "" {
+				continue
+			}
+			selection := group + "=" + label(spec.Values[valueID].Name, valueID)
+			if count := asInt(selected["count"]); count > 1 {
+				selection += ":" + strconv.Itoa(count)
+			}
+			selections = append(selections, selection)
+		}
+	}
+	return selections, byID
+}
+
+func newCartImportCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var dryRun bool
+	var minConfidence float64
+	var noLock bool
+	var force bool
+	var approveToken string
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Add the lines of an order file from cart export to the basket.",
+		Long: "Add the lines of an order file from cart export to the basket.\n\n" +
+			"Every line is looked up at the file's venue by item id, or else by the closest name, and its options are\n" +
+			"matched by name. Lines that are gone, sold out, or whose options no longer exist are reported and left\n" +
+			"out; the rest are added in one request next to what the basket already holds. Pass - to read stdin.",
+		Example: "wolt cart import order.yaml --dry-run\n" +
+			"wolt cart import order.yaml",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if minConfidence < 0 || minConfidence > 1 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--min-confidence must be between 0 and 1")
+			}
+			file, err := readCartOrderFile(cmd, args[0])
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if !dryRun {
+				if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+					return err
+				}
+				if err := guardLatestOrderTime(cmd, deps, flags.Profile, force, format, profileName, flags.Locale, flags.Output); err != nil {
+					return err
+				}
+				release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+				if err != nil {
+					return err
+				}
+				defer release()
+			}
+
+			warnings := []string{}
+			slug := file.Venue.Slug
+			venueID := file.Venue.ID
+			staticPayload := map[string]any{}
+			if slug == "" {
+				if slug, err = resolveVenueSlug(cmd.Context(), deps, venueID); err != nil {
+					slug = ""
+					warnings = append(warnings, "venue slug unavailable; lines are matched by item id only")
+				}
+			}
+			if slug != "" {
+				if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
+					staticPayload = payload
+					if resolvedID := strings.TrimSpace(venueIDFromPayload(payload)); resolvedID != "" {
+						venueID = resolvedID
+					}
+				} else {
+					warnings = append(warnings, "venue static page endpoint unavailable")
+				}
+			}
+			if venueID == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_NOT_FOUND",
+					fmt.Sprintf("venue %q was not found", slug))
+			}
+
+			language := resolveAssortmentLanguage(flags.Locale)
+			rows := make([]any, 0, len(file.Items))
+			missing := []string{}
+			additions := []map[string]any{}
+			currency := ""
+			for _, item := range file.Items {
+				row := map[string]any{
+					"name":    item.Name,
+					"item_id": item.ItemID,
+					"count":   item.Count,
+					"options": append([]string{}, item.Options...),
+					"status":  "missing",
+					"match":   nil,
+				}
+				rows = append(rows, row)
+				selections, err := parseOptionSelections(item.Options)
+				if err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+						fmt.Sprintf("options of %s: %v", item.Name, err))
+				}
+
+				itemID := item.ItemID
+				itemPayload := loadCartOrderItemPayload(cmd.Context(), deps, venueID, slug, itemID)
+				price, lineCurrency := cartOrderItemPrice(itemPayload)
+				name := fallbackString(strings.TrimSpace(asString(itemPayload["name"])), item.Name)
+				status := "matched"
+				if price <= 0 {
+					if slug == "" {
+						missing = append(missing, item.Name)
+						continue
+					}
+					searchPayload, err := requestAssortmentItemsSearchPayload(cmd.Context(), deps, slug, item.Name, language, auth)
+					if err != nil {
+						warnings = append(warnings, fmt.Sprintf("search for %q failed: %v", item.Name, err))
+						missing = append(missing, item.Name)
+						continue
+					}
+					searchCurrency := resolveVenueSearchFallbackCurrency(staticPayload, searchPayload)
+					searchData, _ := buildVenueItemSearchData(venueID, slug, item.Name, "", searchPayload, searchCurrency, false, nil)
+					match, confidence, _ := matchReorderItem(asSlice(searchData["items"]), item.ItemID, item.Name)
+					if match == nil || confidence < minConfidence {
+						missing = append(missing, item.Name)
+						continue
+					}
+					if asBool(match["is_sold_out"]) {
+						row["status"] = "sold_out"
+						missing = append(missing, item.Name)
+						continue
+					}
+					itemID = asString(match["item_id"])
+					name = asString(match["name"])
+					price = asInt(asMap(match["base_price"])["amount"])
+					lineCurrency = strings.TrimSpace(asString(asMap(match["base_price"])["currency"]))
+					status = "matched_by_name"
+					if len(selections) > 0 {
+						itemPayload = loadCartOrderItemPayload(cmd.Context(), deps, venueID, slug, itemID)
+					}
+				}
+				row["match"] = map[string]any{
+					"item_id": itemID,
+					"name":    name,
+					"price": map[string]any{
+						"amount":           price,
+						"formatted_amount": formatMinorAmount(price, lineCurrency),
+					},
+				}
+				if unresolved := unresolvedOptionSelections(selections, extractOptionSpecs(itemPayload)); len(unresolved) > 0 {
+					row["status"] = "option_missing"
+					row["unresolved_options"] = unresolved
+					missing = append(missing, item.Name)
+					continue
+				}
+				row["status"] = status
+				if currency == "" {
+					currency = lineCurrency
+				}
+				additions = append(additions, map[string]any{
+					"id":      itemID,
+					"count":   item.Count,
+					"name":    name,
+					"price":   price,
+					"options": buildBasketOptions(itemPayload, selections),
+					"substitution_settings": map[string]any{
+						"is_allowed": false,
+					},
+				})
+			}
+
+			data := map[string]any{
+				"source":     strings.TrimSpace(args[0]),
+				"venue_id":   venueID,
+				"venue_slug": slug,
+				"venue_name": file.Venue.Name,
+				"lines":      rows,
+				"matched":    len(additions),
+				"missing":    missing,
+				"dry_run":    dryRun,
+				"added":      false,
+				"cart":       nil,
+			}
+			profile := profileName
+			switch {
+			case dryRun:
+			case len(additions) == 0:
+				warnings = append(warnings, "no line of the order file matched; nothing was added to the cart")
+			default:
+				cart, resolvedProfile, addWarnings, err := addLinesToBasket(cmd, deps, flags, &auth, format, venueID, currency, additions, approveToken)
+				warnings = append(warnings, addWarnings...)
+				if err != nil {
+					return err
+				}
+				profile = resolvedProfile
+				data["added"] = true
+				data["cart"] = cart
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildCartImportTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, dedupeStrings(warnings), nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Match the lines and report them without changing the cart.")
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0.5, "Lowest name match confidence (0-1) for lines whose item id is gone.")
+	addNoLockFlag(cmd, &noLock)
+	addForceFlag(cmd, &force)
+	addApproveTokenFlag(cmd, &approveToken)
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// readCartOrderFile reads an order file as written by cart export: YAML, or
+// JSON, either bare or as the data of a cart export envelope.
+func readCartOrderFile(cmd *cobra.Command, path string) (cartOrderFile, error) {
+	path = strings.TrimSpace(path)
+	var raw []byte
+	var err error
+	if path == "-" {
+		raw, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return cartOrderFile{}, fmt.Errorf("read order file: %w", err)
+	}
+	var file cartOrderFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return cartOrderFile{}, fmt.Errorf("order file is not valid YAML or JSON: %v", err)
+	}
+	if len(file.Items) == 0 {
+		var envelope struct {
+			Data cartOrderFile `yaml:"data"`
+		}
+		if err := yaml.Unmarshal(raw, &envelope); err == nil && len(envelope.Data.Items) > 0 {
+			file = envelope.Data
+		}
+	}
+	if file.Version > cartOrderFileVersion {
+		return cartOrderFile{}, fmt.Errorf("order file version %d is newer than this wolt supports (%d)", file.Version, cartOrderFileVersion)
+	}
+	file.Venue.Slug = strings.TrimSpace(file.Venue.Slug)
+	file.Venue.ID = strings.TrimSpace(file.Venue.ID)
+	if file.Venue.Slug == "" && file.Venue.ID == "" {
+		return cartOrderFile{}, fmt.Errorf("order file names no venue; set venue.slug or venue.id")
+	}
+	if len(file.Items) == 0 {
+		return cartOrderFile{}, fmt.Errorf("order file has no items")
+	}
+	for idx := range file.Items {
+		item := &file.Items[idx]
+		item.Name = strings.TrimSpace(item.Name)
+		item.ItemID = strings.TrimSpace(item.ItemID)
+		if item.Name == "" && item.ItemID == "" {
+			return cartOrderFile{}, fmt.Errorf("item %d of the order file has neither a name nor an item_id", idx+1)
+		}
+		if item.Count < 0 {
+			return cartOrderFile{}, fmt.Errorf("item %d of the order file has a negative count", idx+1)
+		}
+		item.Count = max(item.Count, 1)
+		item.Name = fallbackString(item.Name, item.ItemID)
+	}
+	return file, nil
+}
+
+// loadCartOrderItemPayload reads an item's menu entry for its price and
+// option names, from the item page or else the venue assortment. It returns
+// an empty payload when neither knows the item.
+func loadCartOrderItemPayload(ctx context.Context, deps Dependencies, venueID string, slug string, itemID string) map[string]any {
+	if itemID == "" {
+		return map[string]any{}
+	}
+	itemPayload := map[string]any{}
+	if payload, err := deps.Wolt.VenueItemPage(ctx, venueID, itemID); err == nil && payload != nil {
+		itemPayload = payload
+	}
+	if needsAssortmentFallback(itemPayload) && slug != "" {
+		if assortment, err := deps.Wolt.AssortmentByVenueSlug(ctx, slug); err == nil {
+			if fallback := buildItemPayloadFromAssortment(assortment, itemID); fallback != nil {
+				itemPayload = mergeItemPayloadFallback(itemPayload, fallback)
+				recordFallback(ctx, "item_assortment")
+			}
+		}
+	}
+	return itemPayload
+}
+
+func cartOrderItemPrice(itemPayload map[string]any) (int, string) {
+	price := asMap(itemPayload["price"])
+	if amount := asInt(price["amount"]); amount > 0 {
+		return amount, strings.TrimSpace(asString(price["currency"]))
+	}
+	return asInt(itemPayload["price"]), strings.TrimSpace(asString(itemPayload["currency"]))
+}
+
+// unresolvedOptionSelections lists the selections whose group or value
+// matches no option of the item, in Group=Value form.
+func unresolvedOptionSelections(selections map[string][]optionSelection, specs map[string]optionGroupSpec) []string {
+	unresolved := []string{}
+	for groupToken, choices := range selections {
+		groupID := resolveOptionGroupToken(groupToken, specs)
+		for _, choice := range choices {
+			if groupID == "" || resolveOptionValueToken(choice.ValueID, specs[groupID]) == "" {
+				unresolved = append(unresolved, groupToken+"="+choice.ValueID)
+			}
+		}
+	}
+	sort.Strings(unresolved)
+	return unresolved
+}
+
+func buildCartImportTable(data map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(data["lines"]) {
+		line := asMap(value)
+		match := asMap(line["match"])
+		rows = append(rows, []string{
+			asString(line["name"]),
+			strconv.Itoa(asInt(line["count"])),
+			fallbackString(strings.Join(toStringSlice(asSlice(line["options"])), ", "), "-"),
+			fallbackString(asString(match["name"]), "-"),
+			fallbackString(asString(asMap(match["price"])["formatted_amount"]), "-"),
+			asString(line["status"]),
+		})
+	}
+	title := fmt.Sprintf("Order file: %s (%d matched, %d missing)", fallbackString(asString(data["venue_slug"]), asString(data["venue_id"])), asInt(data["matched"]), len(asSlice(data["missing"])))
+	text := output.RenderTable(title, []string{"Item", "Qty", "Options", "Match", "Price", "Status"}, rows)
+	if cart := asMap(data["cart"]); cart != nil {
+		text += "\n\n" + output.RenderTable("Cart", []string{"Field", "Value"}, [][]string{
+			{"Basket ID", fallbackString(asString(cart["basket_id"]), "-")},
+			{"Lines added", asString(cart["added_lines"])},
+			{"Total items", fallbackString(asString(cart["total_items"]), "-")},
+			{"Total", fallbackString(asString(asMap(cart["total"])["formatted_amount"]), "-")},
+		})
+	}
+	return text
+}
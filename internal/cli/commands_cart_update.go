@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newCartUpdateCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var noLock bool
+	var force bool
+	var approveToken string
+	var venueID string
+	var count int
+	var substitution substitutionFlags
+	var lat float64
+	var lon float64
+	var latSet bool
+	var lonSet bool
+
+	cmd := &cobra.Command{
+		Use:   "update <item-id>",
+		Short: "Change the count or substitution settings of a basket line.",
+		Long: "Change the count or substitution settings of a basket line.\n\n" +
+			"Every other line is sent back unchanged, including the substitution settings chosen in the app.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			substitution.read(cmd)
+			countSet := cmd.Flags().Changed("count")
+			switch {
+			case countSet && count <= 0:
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--count must be greater than 0; use cart remove to drop a line")
+			case !countSet && !substitution.set():
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "pass --count, --allow-substitution, --no-substitution, or --substitution-note")
+			}
+			if err := substitution.validate(); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+			if err := guardLatestOrderTime(cmd, deps, flags.Profile, force, format, profileName, flags.Locale, flags.Output); err != nil {
+				return err
+			}
+			release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			var latPtr *float64
+			var lonPtr *float64
+			if latSet {
+				latPtr = &lat
+			}
+			if lonSet {
+				lonPtr = &lon
+			}
+			location, profile, err := resolveLocation(
+				cmd.Context(),
+				deps,
+				latPtr,
+				lonPtr,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+
+			page, authWarnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.BasketsPage(cmd.Context(), location, authCtx)
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			selected, _, selectionWarnings := selectBasketWithMeta(page, venueID)
+			if selected == nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_EMPTY_CART", "No basket found for selected venue.")
+			}
+			itemID := strings.TrimSpace(args[0])
+			if line, _ := findBasketLineByID(selected, itemID); line == nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_ITEM_NOT_FOUND", fmt.Sprintf("Item %q not found in selected basket.", itemID))
+			}
+
+			var updated map[string]any
+			items := make([]any, 0, len(asSlice(selected["items"])))
+			for _, value := range asSlice(selected["items"]) {
+				line := asMap(value)
+				if line == nil {
+					continue
+				}
+				lineCount := asInt(line["count"])
+				isTarget := updated == nil && strings.TrimSpace(asString(line["id"])) == itemID
+				if isTarget && countSet {
+					lineCount = count
+				}
+				item := buildBasketUpsertItem(line, lineCount)
+				if isTarget {
+					if substitution.set() {
+						item["substitution_settings"] = substitution.apply(asMap(line["substitution_settings"]))
+					}
+					updated = item
+				}
+				items = append(items, item)
+			}
+			venue := asMap(selected["venue"])
+			currency := inferCurrency(asString(selected["total"]))
+			if currency == "" {
+				currency = "EUR"
+			}
+			if err := guardAllowedCountry(cmd, deps, flags.Profile, selected, asString(venue["id"]), format, profile, flags.Locale, flags.Output); err != nil {
+				return err
+			}
+			approvalWarning, err := guardApproval(cmd, deps, flags.Profile, approveToken, basketLinesTotal(items), currency, format, profile, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			result, mutationWarnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.AddToBasket(cmd.Context(), map[string]any{
+						"items":    items,
+						"venue_id": asString(venue["id"]),
+						"currency": currency,
+					}, authCtx)
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			data := map[string]any{
+				"basket_id":    fallbackString(asString(result["id"]), asString(selected["id"])),
+				"venue_id":     asString(venue["id"]),
+				"mutation":     "update",
+				"line_id":      itemID,
+				"count":        asInt(updated["count"]),
+				"substitution": cartLineSubstitution(updated),
+				"total_items":  asInt(selected["total_items"]),
+				"total":        nil,
+			}
+			if refreshed, _, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.BasketsPage(cmd.Context(), location, authCtx)
+				},
+			); err == nil {
+				state, _ := buildCartState(refreshed, asString(venue["id"]))
+				data["total_items"] = state["total_items"]
+				data["total"] = state["total"]
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildCartMutationTable(data), flags.Output)
+			}
+			warnings := append(selectionWarnings, authWarnings...)
+			warnings = append(warnings, mutationWarnings...)
+			if approvalWarning != "" {
+				warnings = append(warnings, approvalWarning)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&venueID, "venue-id", "", "Restrict mutation to one venue basket.")
+	cmd.Flags().IntVar(&count, "count", 0, "New quantity for the line.")
+	addSubstitutionFlags(cmd, &substitution)
+	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for cart endpoints. Provide together with --lon.")
+	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for cart endpoints. Provide together with --lat.")
+	addNoLockFlag(cmd, &noLock)
+	addForceFlag(cmd, &force)
+	addApproveTokenFlag(cmd, &approveToken)
+	addGlobalFlags(cmd, &flags)
+	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
+		latSet = cmd.Flags().Changed("lat")
+		lonSet = cmd.Flags().Changed("lon")
+	}
+	return cmd
+}
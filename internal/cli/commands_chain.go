@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newChainCommand(deps Dependencies) *cobra.Command {
+	chain := &cobra.Command{
+		Use:   "chain",
+		Short: "Inspect venue chains: one brand with several branches.",
+	}
+	chain.AddCommand(newChainBranchesCommand(deps))
+	return chain
+}
+
+func newChainBranchesCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var pick rowPick
+
+	cmd := &cobra.Command{
+		Use:   "branches <brand>",
+		Short: "List the branches of a chain near the profile location, nearest first.",
+		Long: "List the branches of a chain near the profile location, nearest first.\n\n" +
+			"A venue is a branch when its name is the brand or starts with it, as in \"Burger King Kamppi\" or\n" +
+			"\"Burger King | Itis\" for the brand \"Burger King\". Matching ignores case.",
+		Example: "wolt chain branches \"Burger King\"",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			brand := strings.TrimSpace(args[0])
+			if brand == "" {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "brand is required")
+			}
+			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&locationAuth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+			items, err := deps.Wolt.Items(cmd.Context(), location)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			rememberItemVenues(deps, items, "")
+			result, warnings := observability.BuildVenueSearchResult(items, brand, observability.VenueSortRecommended, nil, "", false, false, nil, 0)
+
+			branches := make([]any, 0)
+			for _, value := range asSlice(result["items"]) {
+				if row := asMap(value); row != nil && venueMatchesBrand(asString(row["name"]), brand) {
+					branches = append(branches, row)
+				}
+			}
+			branches, _ = annotateVenueDistances(branches, location, 0)
+			sort.SliceStable(branches, func(i, j int) bool {
+				left, leftOK := asFloat(asMap(branches[i])["distance_km"])
+				right, rightOK := asFloat(asMap(branches[j])["distance_km"])
+				if leftOK != rightOK {
+					return leftOK
+				}
+				return left < right
+			})
+			if len(branches) == 0 {
+				warnings = append(warnings, fmt.Sprintf("no venue named %q or starting with it delivers here", brand))
+			}
+
+			data := map[string]any{
+				"brand":    brand,
+				"branches": branches,
+				"count":    len(branches),
+			}
+			if pick.enabled() {
+				return emitPickedRow(cmd, format, profile, flags.Locale, flags.Output, pick, branches, venueRowPicker())
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildChainBranchesTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addRowPickFlags(cmd, &pick, "venue slug")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func buildChainBranchesTable(data map[string]any) string {
+	headers := []string{"Venue", "Slug", "Address", "Distance", "Delivery", "Fee", "Rating"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["branches"]) {
+		branch := asMap(value)
+		rows = append(rows, []string{
+			asString(branch["name"]),
+			fallbackString(asString(branch["slug"]), "-"),
+			fallbackString(asString(branch["address"]), "-"),
+			formatDistanceForTable(branch),
+			fallbackString(asString(branch["delivery_estimate"]), "-"),
+			fallbackString(asString(asMap(branch["delivery_fee"])["formatted_amount"]), "-"),
+			fallbackString(asString(branch["rating"]), "-"),
+		})
+	}
+	return output.RenderTable("Chain branches: "+asString(data["brand"]), headers, rows)
+}
@@ -3,11 +3,16 @@ package cli
 import (
 	"context"
 	"fmt"
+	"math"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
 	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/audit"
+	"github.com/mekedron/wolt-cli/internal/service/cache"
 	"github.com/mekedron/wolt-cli/internal/service/output"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +25,7 @@ func newCheckoutCommand(deps Dependencies) *cobra.Command {
 		Short: "Inspect checkout pricing projections (preview only).",
 	}
 	checkout.AddCommand(newCheckoutPreviewCommand(deps))
+	markAuthRequired(checkout, true)
 	return checkout
 }
 
@@ -29,27 +35,57 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 	var tip int
 	var promoCode string
 	var venueID string
+	var refresh bool
+	var expense expenseFlags
 	var lat float64
 	var lon float64
 	var latSet bool
 	var lonSet bool
+	var payWith []string
+	var planJSON string
+	var force bool
+	var approveToken string
+	var strict bool
+	var gift giftOrder
+	var work bool
 
 	cmd := &cobra.Command{
 		Use:   "preview",
 		Short: "Preview checkout rows and payable total (no order placement).",
 		Long: "Preview-only checkout estimation.\n\n" +
-			"This command does not place orders. Location overrides affect the quote preview only; actual order placement in Wolt uses the delivery address selected in your Wolt account.",
+			"This command does not place orders. Location overrides affect the quote preview only; actual order placement in Wolt uses the delivery address selected in your Wolt account.\n\n" +
+			"--plan-json takes a partial purchase_plan (or a request wrapping one) whose fields replace the resolved\n" +
+			"ones; the venue id and menu_items always come from the basket.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			format, err := parseOutputFormat(flags.Format)
 			if err != nil {
 				return err
 			}
 			profileName := defaultProfileName(flags.Profile)
+			splits, err := parsePayWith(payWith)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			gift, err := parseGiftOrder(gift)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			var planInput map[string]any
+			if strings.TrimSpace(planJSON) != "" {
+				input, err := readJSONInput(cmd, "plan-json", planJSON)
+				if err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+				planInput = checkoutPlanInput(input)
+			}
 
 			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
 			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
 				return err
 			}
+			if err := guardLatestOrderTime(cmd, deps, flags.Profile, force, format, profileName, flags.Locale, flags.Output); err != nil {
+				return err
+			}
 
 			var latPtr *float64
 			var lonPtr *float64
@@ -100,8 +136,45 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 					"No basket found for checkout preview.",
 				)
 			}
+			if err := guardAllowedCountry(cmd, deps, flags.Profile, basket, asString(asMap(basket["venue"])["id"]), format, profile, flags.Locale, flags.Output); err != nil {
+				return err
+			}
+			venueCountry := strings.ToUpper(strings.TrimSpace(asString(asMap(basket["venue"])["country"])))
+			if err := checkSplitCountry(splits, venueCountry); err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_SPLIT_NOT_ALLOWED", err.Error())
+			}
+			if err := checkGiftCountry(gift, venueCountry); err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_GIFT_NOT_ALLOWED", err.Error())
+			}
+			var workMethod map[string]any
+			if work {
+				payments, paymentsAuthWarnings, err := invokeWithAuthAutoRefresh(
+					cmd.Context(),
+					deps,
+					flags,
+					&auth,
+					func(authCtx woltgateway.AuthContext) (profilePaymentsPayload, error) {
+						return fetchProfilePaymentsPayload(cmd.Context(), deps, authCtx)
+					},
+				)
+				if err != nil {
+					return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+				}
+				authWarnings = append(authWarnings, paymentsAuthWarnings...)
+				authWarnings = append(authWarnings, payments.Warnings...)
+				workMethod = workPaymentMethod(extractPaymentMethods(payments.Payload, false))
+				if workMethod == nil {
+					return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_NOT_FOUND",
+						"No Wolt at Work payment method is available on this account; run wolt profile payments to see the linked methods.")
+				}
+			}
 
-			checkoutPayload, checkoutWarnings, err := buildCheckoutPayload(
+			settings, _ := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			tip, appliedTip := resolveCheckoutTip(cmd.Flags().Changed("tip"), tip, settings.DefaultTipPercent, basket)
+
+			// --strict checks options against the live item spec, not a cached one.
+			lineCache, cacheWarnings := openCheckoutLineCache(deps, refresh || strict)
+			checkoutPayload, resolutions, optionIssues, checkoutWarnings, err := buildCheckoutPayload(
 				cmd.Context(),
 				deps,
 				basket,
@@ -109,7 +182,9 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 				deliveryMode,
 				tip,
 				promoCode,
+				lineCache,
 			)
+			checkoutWarnings = append(cacheWarnings, checkoutWarnings...)
 			if err != nil {
 				return emitError(
 					cmd,
@@ -121,6 +196,37 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 					err.Error(),
 				)
 			}
+			if strict && len(optionIssues) > 0 {
+				first := asMap(optionIssues[0])
+				return emitError(
+					cmd,
+					format,
+					profile,
+					flags.Locale,
+					flags.Output,
+					"WOLT_OPTION_UNAVAILABLE",
+					fmt.Sprintf(
+						"%d selected option value(s) are unavailable or changed price (first: %s on item %s, %s); update the basket or preview without --strict.",
+						len(optionIssues),
+						fallbackString(asString(first["value_name"]), asString(first["value_id"])),
+						asString(first["item_id"]),
+						strings.ReplaceAll(asString(first["issue"]), "_", " "),
+					),
+				)
+			}
+			applyGiftOrder(checkoutPayload, gift)
+			if workMethod != nil {
+				asMap(checkoutPayload["purchase_plan"])["payment_methods"] = []any{
+					map[string]any{"id": workMethod["method_id"], "type": workMethod["type"]},
+				}
+			}
+			var planFields []string
+			if planInput != nil {
+				planFields, err = mergeJSONInput(checkoutPayload, planInput, "purchase_plan.venue.id", "purchase_plan.menu_items")
+				if err != nil {
+					return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--plan-json: "+err.Error())
+				}
+			}
 			payload, checkoutAuthWarnings, err := invokeWithAuthAutoRefresh(
 				cmd.Context(),
 				deps,
@@ -133,6 +239,36 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
 			}
+			appliedPromo := map[string]any{"id": nil, "source": "none"}
+			if code := strings.TrimSpace(promoCode); code != "" {
+				appliedPromo = map[string]any{"id": code, "source": "flag"}
+			} else if settings.AutoApplyBestPromo && !slices.Contains(planFields, "purchase_plan.use_promo_discount_ids") {
+				if offer, ok := bestCheckoutOffer(payload); ok {
+					asMap(checkoutPayload["purchase_plan"])["use_promo_discount_ids"] = []any{offer["id"]}
+					promoPayload, promoAuthWarnings, err := invokeWithAuthAutoRefresh(
+						cmd.Context(),
+						deps,
+						flags,
+						&auth,
+						func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+							return deps.Wolt.CheckoutPreview(cmd.Context(), checkoutPayload, authCtx)
+						},
+					)
+					checkoutAuthWarnings = append(checkoutAuthWarnings, promoAuthWarnings...)
+					if err != nil {
+						checkoutWarnings = append(checkoutWarnings, fmt.Sprintf("auto-applying offer %s failed; preview is without it: %v", offer["id"], err))
+					} else {
+						payload = promoPayload
+						offer["source"] = "profile"
+						appliedPromo = offer
+					}
+				}
+			}
+			if lineCache != nil {
+				if err := lineCache.file.Save(); err != nil {
+					checkoutWarnings = append(checkoutWarnings, fmt.Sprintf("checkout line cache not saved: %v", err))
+				}
+			}
 
 			payableAmount := asInt(payload["payable_amount"])
 			payableFormatted := asString(asMap(asMap(payload["payment_breakdown"])["total"])["formatted_amount"])
@@ -163,7 +299,103 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 				"delivery_configs": coalesceAny(payload["delivery_configs"], []any{}),
 				"offers":           coalesceAny(payload["offers"], map[string]any{"selectable": []any{}, "applied": []any{}}),
 				"tip_config":       coalesceAny(payload["tip_config"], map[string]any{}),
+				"applied_tip":      appliedTip,
+				"applied_promo":    appliedPromo,
+				"tax_breakdown": buildTaxBreakdown(
+					payload,
+					asSlice(coalesceAny(payload["items"], basket["items"])),
+					fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"]))),
+				),
 			}
+			reconciliation, reconciliationWarnings := buildCheckoutReconciliation(
+				basket,
+				payload,
+				loadAddedPrices(deps, asString(data["venue_id"])),
+				tip,
+				fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"]))),
+			)
+			data["reconciliation"] = reconciliation
+			if len(optionIssues) > 0 {
+				data["option_issues"] = optionIssues
+			}
+			if gift.enabled {
+				data["gift"] = giftOrderData(gift, venueCountry)
+			}
+			checkoutWarnings = append(checkoutWarnings, reconciliationWarnings...)
+			if flags.Verbose {
+				data["line_resolution"] = resolutions
+			}
+			if planInput != nil {
+				data["json_input"] = jsonInputData(planJSON, planFields)
+			}
+			if len(splits) > 0 {
+				methods, splitWarnings, err := allocatePaySplit(splits, payableAmount, fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"]))))
+				if err != nil {
+					return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+				checkoutWarnings = append(checkoutWarnings, splitWarnings...)
+				data["payment_split"] = map[string]any{
+					"country": emptyToNil(venueCountry),
+					"methods": methods,
+				}
+			}
+			if workMethod != nil {
+				workData, violations := checkWorkPolicy(workMethod, payableAmount, fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"]))), deps.now())
+				if len(violations) > 0 && !force {
+					return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_WORK_POLICY_VIOLATION",
+						"Wolt at Work policy: "+strings.Join(violations, "; ")+"; pass --force to preview anyway.")
+				}
+				for _, violation := range violations {
+					checkoutWarnings = append(checkoutWarnings, "work_policy: "+violation)
+				}
+				data["work"] = workData
+			}
+			// A --work order is paid by the company, so the personal budget does not apply.
+			if settings.Budget != nil && workMethod == nil {
+				budgetData, budgetWarnings, err := checkCheckoutBudget(
+					cmd,
+					deps,
+					flags,
+					&auth,
+					*settings.Budget,
+					payableAmount,
+					fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"]))),
+					force,
+					format,
+					profile,
+				)
+				if err != nil {
+					return err
+				}
+				checkoutWarnings = append(checkoutWarnings, budgetWarnings...)
+				if budgetData != nil {
+					data["budget"] = budgetData
+				}
+			}
+			approvalWarning, err := guardApproval(cmd, deps, flags.Profile, approveToken, payableAmount, fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"]))), format, profile, flags.Locale, flags.Output)
+			if err != nil {
+				return err
+			}
+			if approvalWarning != "" {
+				checkoutWarnings = append(checkoutWarnings, approvalWarning)
+			}
+			if expense.set() {
+				entry := expense.entry("checkout preview", deps.now())
+				entry.BasketID = asString(data["basket_id"])
+				entry.VenueID = asString(data["venue_id"])
+				if err := appendAuditEntry(deps, entry); err != nil {
+					return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_AUDIT_LOG_ERROR", err.Error())
+				}
+				data["expense"] = expenseTagsData(audit.ExpenseTags{ExpenseCode: entry.ExpenseCode, CostCenter: entry.CostCenter})
+			}
+
+			checkoutWarnings = append(checkoutWarnings, woltPlusBenefitWarnings(
+				cmd,
+				deps,
+				flags,
+				&auth,
+				checkoutWoltPlusOrder(basket, payload, fallbackString(inferCurrency(payableFormatted), inferCurrency(asString(basket["total"])))),
+			)...)
 
 			if format == output.FormatTable {
 				return writeTable(cmd, buildCheckoutPreviewTable(data), flags.Output)
@@ -180,6 +412,18 @@ func newCheckoutPreviewCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().IntVar(&tip, "tip", 0, "Tip amount in minor units.")
 	cmd.Flags().StringVar(&promoCode, "promo-code", "", "Promo code identifier to forward into checkout discount IDs.")
 	cmd.Flags().StringVar(&venueID, "venue-id", "", "Restrict preview to one venue basket.")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Resolve basket line categories and option prices live instead of from the local cache.")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail with WOLT_OPTION_UNAVAILABLE when a selected option value is gone or changed price (checks the live item spec).")
+	cmd.Flags().StringArrayVar(&payWith, "pay-with", nil, "Split the payable total as METHOD:AMOUNT (minor units, a limit) or METHOD:rest; repeatable.")
+	cmd.Flags().BoolVar(&gift.enabled, "gift", false, "Send the order as a gift to the recipient below.")
+	cmd.Flags().StringVar(&gift.name, "recipient-name", "", "Gift recipient's name (with --gift).")
+	cmd.Flags().StringVar(&gift.phone, "recipient-phone", "", "Gift recipient's phone in international format, such as +358401234567 (with --gift).")
+	cmd.Flags().StringVar(&gift.message, "gift-message", "", "Message delivered with the gift, up to 250 characters (with --gift).")
+	cmd.Flags().BoolVar(&work, "work", false, "Pay with the account's Wolt at Work method and check the company policy (budget, allowed times).")
+	cmd.Flags().StringVar(&planJSON, "plan-json", "", "Partial purchase_plan (JSON file, or - for stdin) merged into the checkout request.")
+	addForceFlag(cmd, &force)
+	addApproveTokenFlag(cmd, &approveToken)
+	addExpenseFlags(cmd, &expense)
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for checkout preview. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for checkout preview. Provide together with --lat.")
 	addGlobalFlags(cmd, &flags)
@@ -198,13 +442,14 @@ func buildCheckoutPayload(
 	deliveryMode string,
 	tip int,
 	promoCode string,
-) (map[string]any, []string, error) {
+	lineCache *checkoutLineCache,
+) (map[string]any, []any, []any, []string, error) {
 	deliveryMode = strings.ToLower(strings.TrimSpace(deliveryMode))
 	if deliveryMode == "" {
 		deliveryMode = "standard"
 	}
 	if deliveryMode != "standard" && deliveryMode != "priority" && deliveryMode != "schedule" {
-		return nil, nil, fmt.Errorf("unsupported --delivery-mode %q", deliveryMode)
+		return nil, nil, nil, nil, fmt.Errorf("unsupported --delivery-mode %q", deliveryMode)
 	}
 
 	venue := asMap(basket["venue"])
@@ -219,8 +464,14 @@ func buildCheckoutPayload(
 	categoryIDsByItemID := map[string]string{}
 	assortmentPayload := map[string]any{}
 
+	// Venue-level payloads are only needed for lines the cache cannot answer.
 	venueSlug := resolveBasketVenueSlug(venue)
-	if venueSlug != "" && deps.Wolt != nil {
+	venueIndexesLoaded := false
+	loadVenueIndexes := func() {
+		if venueIndexesLoaded || venueSlug == "" || deps.Wolt == nil {
+			return
+		}
+		venueIndexesLoaded = true
 		if payload, err := deps.Wolt.AssortmentByVenueSlug(ctx, venueSlug); err == nil {
 			assortmentPayload = payload
 			mergeCheckoutCategoryIndexes(categoryIDsByItemID, buildCheckoutCategoryIDIndex(payload))
@@ -233,6 +484,13 @@ func buildCheckoutPayload(
 	}
 
 	menuItems := make([]any, 0, len(asSlice(basket["items"])))
+	resolutions := make([]any, 0, len(asSlice(basket["items"])))
+	optionIssues := []any{}
+	checkOptions := func(item map[string]any, itemID string, valuePrices map[string]int, source string) {
+		issues, issueWarnings := checkCheckoutOptions(item, itemID, valuePrices, currency, source)
+		optionIssues = append(optionIssues, issues...)
+		warnings = append(warnings, issueWarnings...)
+	}
 	for _, value := range asSlice(basket["items"]) {
 		item := asMap(value)
 		itemID := strings.TrimSpace(asString(item["id"]))
@@ -242,8 +500,17 @@ func buildCheckoutPayload(
 		}
 		price := asInt(item["price"])
 		if price <= 0 {
-			return nil, warnings, fmt.Errorf("unable to resolve base_price for basket item %q", itemID)
+			return nil, nil, nil, warnings, fmt.Errorf("unable to resolve base_price for basket item %q", itemID)
+		}
+
+		if cached, ok := lineCache.lookup(venueID, itemID); ok {
+			categoryID := resolveCheckoutCategoryID(item, map[string]any{}, itemID, map[string]string{itemID: cached.CategoryID})
+			checkOptions(item, itemID, cached.OptionPrices, checkoutResolutionCache)
+			menuItems = append(menuItems, buildCheckoutMenuItem(item, itemID, venueID, count, price, categoryID, cached.OptionPrices))
+			resolutions = append(resolutions, checkoutLineResolution(itemID, categoryID, checkoutResolutionCache))
+			continue
 		}
+		loadVenueIndexes()
 
 		detail := map[string]any{}
 		if itemID != "" && deps.Wolt != nil {
@@ -262,35 +529,22 @@ func buildCheckoutPayload(
 		}
 
 		categoryID := resolveCheckoutCategoryID(item, detail, itemID, categoryIDsByItemID)
+		resolved := categoryID != ""
 		if categoryID == "" {
 			if looksLikeObjectID(itemID) {
 				categoryID = itemID
 				warnings = append(warnings, fmt.Sprintf("unable to resolve category_id for item %s; falling back to item id", itemID))
 			} else {
-				return nil, warnings, fmt.Errorf("unable to resolve category_id for basket item %q", itemID)
+				return nil, nil, nil, warnings, fmt.Errorf("unable to resolve category_id for basket item %q", itemID)
 			}
 		}
-		categoryIDs := resolveCheckoutCategoryIDs(item, categoryID)
 		valuePrices := buildOptionValuePriceIndex(detail)
-		options := buildCheckoutOptions(item["options"], valuePrices)
-
-		menuItems = append(menuItems, map[string]any{
-			"id":                                itemID,
-			"venue_id":                          venueID,
-			"count":                             count,
-			"base_price":                        price,
-			"end_amount":                        count * price,
-			"is_weighted_item":                  false,
-			"category_id":                       categoryID,
-			"category_ids":                      categoryIDs,
-			"alcohol_permille":                  asInt(coalesceAny(item["alcohol_permille"], 0)),
-			"exclude_from_credits":              asBool(coalesceAny(item["exclude_from_credits"], false)),
-			"exclude_from_discounts":            asBool(coalesceAny(item["exclude_from_discounts"], false)),
-			"exclude_from_discounts_min_basket": asBool(coalesceAny(item["exclude_from_discounts_min_basket"], false)),
-			"restrictions":                      coalesceAny(item["restrictions"], []any{}),
-			"age_limit":                         coalesceAny(item["age_limit"], nil),
-			"options":                           options,
-		})
+		checkOptions(item, itemID, valuePrices, checkoutResolutionLive)
+		if resolved {
+			lineCache.store(venueID, itemID, checkoutLineMetadata{CategoryID: categoryID, OptionPrices: valuePrices})
+		}
+		menuItems = append(menuItems, buildCheckoutMenuItem(item, itemID, venueID, count, price, categoryID, valuePrices))
+		resolutions = append(resolutions, checkoutLineResolution(itemID, categoryID, checkoutResolutionLive))
 	}
 
 	promoDiscountIDs := []any{}
@@ -322,7 +576,112 @@ func buildCheckoutPayload(
 				},
 			},
 		},
-	}, warnings, nil
+	}, resolutions, optionIssues, warnings, nil
+}
+
+// resolveCheckoutTip returns the courier tip and how it was chosen: an explicit
+// --tip wins, then the profile's default_tip_percent of the basket subtotal.
+func resolveCheckoutTip(tipSet bool, tip int, percent float64, basket map[string]any) (int, map[string]any) {
+	source := "none"
+	switch {
+	case tipSet:
+		source = "flag"
+	case percent > 0:
+		source = "profile"
+		tip = int(math.Round(float64(basketSubtotal(basket)) * percent / 100))
+	}
+	applied := map[string]any{
+		"amount":           tip,
+		"formatted_amount": emptyToNil(formatMinorAmount(tip, inferCurrency(asString(basket["total"])))),
+		"source":           source,
+	}
+	if source == "profile" {
+		applied["percent"] = percent
+	}
+	return tip, applied
+}
+
+// basketSubtotal sums line prices the same way checkout menu items do.
+func basketSubtotal(basket map[string]any) int {
+	total := 0
+	for _, value := range asSlice(basket["items"]) {
+		item := asMap(value)
+		count := asInt(item["count"])
+		if count <= 0 {
+			count = 1
+		}
+		total += count * asInt(item["price"])
+	}
+	return total
+}
+
+// bestCheckoutOffer picks the selectable offer with the largest saving from a
+// checkout preview. It reports false when an offer is already applied or none
+// can be selected. The first offer wins ties, so one with no stated saving is
+// chosen only when no offer states one.
+func bestCheckoutOffer(payload map[string]any) (map[string]any, bool) {
+	offers := asMap(payload["offers"])
+	if len(asSlice(offers["applied"])) > 0 {
+		return nil, false
+	}
+	var best map[string]any
+	for _, value := range asSlice(offers["selectable"]) {
+		offer := asMap(value)
+		id := strings.TrimSpace(asString(coalesceAny(offer["id"], offer["discount_id"], offer["promo_id"])))
+		if id == "" || asBool(offer["is_selected"]) {
+			continue
+		}
+		savings := checkoutOfferSavings(offer)
+		if best == nil || savings > asInt(best["savings"]) {
+			best = map[string]any{
+				"id":      id,
+				"title":   emptyToNil(asString(coalesceAny(offer["title"], offer["name"]))),
+				"savings": savings,
+			}
+		}
+	}
+	return best, best != nil
+}
+
+func checkoutOfferSavings(offer map[string]any) int {
+	for _, key := range []string{"discount_amount", "savings", "amount", "value"} {
+		value := offer[key]
+		if nested := asMap(value); nested != nil {
+			value = nested["amount"]
+		}
+		if amount := asInt(value); amount > 0 {
+			return amount
+		}
+	}
+	return 0
+}
+
+func buildCheckoutMenuItem(
+	item map[string]any,
+	itemID string,
+	venueID string,
+	count int,
+	price int,
+	categoryID string,
+	valuePrices map[string]int,
+) map[string]any {
+	return map[string]any{
+		"id":                                itemID,
+		"venue_id":                          venueID,
+		"count":                             count,
+		"base_price":                        price,
+		"end_amount":                        count * price,
+		"is_weighted_item":                  false,
+		"category_id":                       categoryID,
+		"category_ids":                      resolveCheckoutCategoryIDs(item, categoryID),
+		"alcohol_permille":                  asInt(coalesceAny(item["alcohol_permille"], 0)),
+		"exclude_from_credits":              asBool(coalesceAny(item["exclude_from_credits"], false)),
+		"exclude_from_discounts":            asBool(coalesceAny(item["exclude_from_discounts"], false)),
+		"exclude_from_discounts_min_basket": asBool(coalesceAny(item["exclude_from_discounts_min_basket"], false)),
+		"restrictions":                      coalesceAny(item["restrictions"], []any{}),
+		"age_limit":                         coalesceAny(item["age_limit"], nil),
+		"options":                           buildCheckoutOptions(item["options"], valuePrices),
+	}
 }
 
 func resolveCheckoutCategoryID(item map[string]any, detail map[string]any, itemID string, fallback map[string]string) string {
@@ -577,6 +936,24 @@ func buildCheckoutPreviewTable(data map[string]any) string {
 		{"Venue slug", fallbackString(asString(data["venue_slug"]), "-")},
 		{"Payable total", fallbackString(asString(asMap(data["payable_amount"])["formatted_amount"]), "-")},
 	}
+	if tip := asMap(data["applied_tip"]); tip != nil {
+		summaryRows = append(summaryRows, []string{"Tip", fmt.Sprintf("%s (%s)", fallbackString(asString(tip["formatted_amount"]), asString(tip["amount"])), asString(tip["source"]))})
+	}
+	if expense := asMap(data["expense"]); expense != nil {
+		summaryRows = append(summaryRows,
+			[]string{"Expense code", fallbackString(asString(expense["expense_code"]), "-")},
+			[]string{"Cost center", fallbackString(asString(expense["cost_center"]), "-")},
+		)
+	}
+	if work := asMap(data["work"]); work != nil {
+		summaryRows = append(summaryRows, []string{"Work account", fmt.Sprintf("%s (%s)", fallbackString(asString(work["company_name"]), "-"), asString(work["label"]))})
+	}
+	if gift := asMap(data["gift"]); gift != nil {
+		summaryRows = append(summaryRows, []string{"Gift for", fmt.Sprintf("%s (%s)", asString(gift["recipient_name"]), fallbackString(asString(gift["recipient_phone"]), "no phone"))})
+	}
+	if promo := asMap(data["applied_promo"]); promo != nil {
+		summaryRows = append(summaryRows, []string{"Promo", fmt.Sprintf("%s (%s)", fallbackString(asString(promo["id"]), "-"), asString(promo["source"]))})
+	}
 	if selection := asMap(data["selection"]); selection != nil {
 		summaryRows = append(summaryRows, []string{"Selection mode", fallbackString(asString(selection["selection_mode"]), "-")})
 		summaryRows = append(summaryRows, []string{"Baskets available", asString(selection["basket_count"])})
@@ -605,5 +982,107 @@ func buildCheckoutPreviewTable(data map[string]any) string {
 		rows = append(rows, []string{"Total", fallbackString(asString(asMap(data["payable_amount"])["formatted_amount"]), "-")})
 	}
 	rowsTable := output.RenderTable("Checkout rows", headers, rows)
-	return summary + "\n\n" + rowsTable
+	text := summary + "\n\n" + rowsTable
+	if resolutions := asSlice(data["line_resolution"]); len(resolutions) > 0 {
+		resolutionRows := [][]string{}
+		for _, value := range resolutions {
+			line := asMap(value)
+			resolutionRows = append(resolutionRows, []string{
+				fallbackString(asString(line["item_id"]), "-"),
+				fallbackString(asString(line["category_id"]), "-"),
+				asString(line["resolution_source"]),
+			})
+		}
+		text += "\n\n" + output.RenderTable("Line resolution", []string{"Item ID", "Category ID", "Source"}, resolutionRows)
+	}
+	if issues := asSlice(data["option_issues"]); len(issues) > 0 {
+		issueRows := [][]string{}
+		for _, value := range issues {
+			issue := asMap(value)
+			issueRows = append(issueRows, []string{
+				asString(issue["item_id"]),
+				fallbackString(asString(issue["value_name"]), asString(issue["value_id"])),
+				asString(issue["issue"]),
+				fallbackString(asString(issue["basket_price"]), "-"),
+				fallbackString(asString(issue["current_price"]), "-"),
+			})
+		}
+		text += "\n\n" + output.RenderTable("Option issues", []string{"Item ID", "Option value", "Issue", "Basket price", "Current price"}, issueRows)
+	}
+	if reconciliation := asMap(data["reconciliation"]); reconciliation != nil {
+		text += "\n\n" + buildCheckoutReconciliationTable(reconciliation)
+	}
+	if breakdown := asMap(data["tax_breakdown"]); breakdown != nil {
+		text += "\n\n" + buildTaxBreakdownTable(breakdown)
+	}
+	if split := asMap(data["payment_split"]); split != nil {
+		text += "\n\n" + buildPaymentSplitTable(split)
+	}
+	return text
+}
+
+const (
+	checkoutLineCacheFile = "checkout-lines.json"
+	// checkoutLineCacheTTL bounds how long resolved category and option prices are
+	// reused; menus change rarely, but option prices do move.
+	checkoutLineCacheTTL = 24 * time.Hour
+
+	checkoutResolutionCache = "cache"
+	checkoutResolutionLive  = "live"
+)
+
+// checkoutLineMetadata is what checkout preview resolves per basket line from
+// venue and item payloads.
+type checkoutLineMetadata struct {
+	CategoryID   string         `json:"category_id"`
+	OptionPrices map[string]int `json:"option_prices"`
+}
+
+// checkoutLineCache reuses checkoutLineMetadata keyed by venue and item. A nil
+// cache never hits and drops stores.
+type checkoutLineCache struct {
+	file    *cache.File
+	refresh bool
+	now     func() time.Time
+}
+
+func (c *checkoutLineCache) lookup(venueID string, itemID string) (checkoutLineMetadata, bool) {
+	var metadata checkoutLineMetadata
+	if c == nil || c.refresh || venueID == "" || itemID == "" {
+		return metadata, false
+	}
+	if !c.file.Get(venueID+"/"+itemID, checkoutLineCacheTTL, c.now(), &metadata) || metadata.CategoryID == "" {
+		return metadata, false
+	}
+	return metadata, true
+}
+
+func (c *checkoutLineCache) store(venueID string, itemID string, metadata checkoutLineMetadata) {
+	if c == nil || venueID == "" || itemID == "" {
+		return
+	}
+	// Values are plain strings and ints, so encoding cannot fail.
+	_ = c.file.Put(venueID+"/"+itemID, metadata, c.now())
+}
+
+// openCheckoutLineCache returns nil with a warning when the cache cannot be
+// used; checkout preview then resolves every line live.
+func openCheckoutLineCache(deps Dependencies, refresh bool) (*checkoutLineCache, []string) {
+	file, err := openCLICache(deps, checkoutLineCacheFile)
+	if file == nil {
+		return nil, []string{fmt.Sprintf("checkout line cache unavailable: %v", err)}
+	}
+	warnings := []string{}
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("checkout line cache reset: %v", err))
+	}
+	return &checkoutLineCache{file: file, refresh: refresh, now: deps.now}, warnings
+}
+
+func checkoutLineResolution(itemID string, categoryID string, source string) map[string]any {
+	return map[string]any{
+		"item_id":           itemID,
+		"category_id":       categoryID,
+		"resolution_source": source,
+	}
 }
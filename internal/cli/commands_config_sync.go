@@ -0,0 +1,496 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/configsync"
+	"github.com/mekedron/wolt-cli/internal/service/journal"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	syncDirEnv        = "WOLT_SYNC_DIR"
+	syncSharedFile    = "wolt-shared.json"
+	syncKindPath      = "path"
+	syncKindGit       = "git"
+	syncProfilePrefix = "profile/"
+	syncNotePrefix    = "note/"
+	syncCommitText    = "wolt config sync"
+)
+
+// syncLocalOnlyProfileKeys are profile fields that never leave this machine:
+// credentials, the account-specific address id, plugin trust, the read-only
+// switch and approval threshold, the default marker, and temporary travel state.
+var syncLocalOnlyProfileKeys = []string{
+	"is_default",
+	"wtoken",
+	"wrefresh_token",
+	"cookies",
+	"wolt_address_id",
+	"plugin_auth",
+	"read_only",
+	"approval",
+	"travel",
+}
+
+func newConfigCommand(deps Dependencies) *cobra.Command {
+	config := &cobra.Command{
+		Use:   "config",
+		Short: "Share configuration between machines and household members.",
+	}
+	config.AddCommand(newConfigSyncCommand(deps))
+	return config
+}
+
+func newConfigSyncCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var remote string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Merge profile settings and venue notes with a shared file or git repository.",
+		Long: "Merge profile settings and venue notes with a shared file or git repository.\n\n" +
+			"--remote is a file path, a directory (the file is wolt-shared.json inside it), or a git URL. " +
+			"Git remotes are cloned next to the config file and pushed with the git command on PATH. " +
+			"Profiles are shared without tokens, cookies, wolt_address_id, plugin_auth, read_only, or the default marker, " +
+			"and travel profiles are not shared. Changes made on one side since the last sync win; when both sides " +
+			"changed an entry, the local value is kept and the key is listed under conflicts.",
+		Example: "wolt config sync --remote ~/Dropbox/wolt\n" +
+			"wolt config sync --remote git@github.com:me/wolt-household.git --dry-run",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			remote = strings.TrimSpace(remote)
+			if remote == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--remote is required")
+			}
+			if deps.Config == nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_SYNC_ERROR", "config store is not available")
+			}
+
+			data, warnings, err := runConfigSync(cmd.Context(), deps, remote, dryRun)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_SYNC_ERROR", err.Error())
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildConfigSyncTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	cmd.Flags().StringVar(&remote, "remote", "", "Shared file, directory, or git URL to sync with")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be pulled and pushed without writing anything")
+	return cmd
+}
+
+// syncTarget is where the shared file lives for one --remote value.
+type syncTarget struct {
+	Kind     string
+	File     string
+	Checkout string
+	BaseFile string
+}
+
+func runConfigSync(ctx context.Context, deps Dependencies, remote string, dryRun bool) (map[string]any, []string, error) {
+	cfg, err := deps.Config.Load(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load config: %w", err)
+	}
+	store, err := openJournalStore(deps)
+	if err != nil {
+		return nil, nil, err
+	}
+	notes, err := store.AllNotes()
+	if err != nil {
+		return nil, nil, err
+	}
+	local, err := buildSyncSnapshot(cfg, notes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := resolveSyncTarget(deps, remote)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.Kind == syncKindGit {
+		if err := syncGitFetch(ctx, remote, target.Checkout); err != nil {
+			return nil, nil, err
+		}
+	}
+	shared, err := configsync.Read(target.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	base, err := configsync.Read(target.BaseFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := configsync.Merge(base, local, shared)
+	warnings := []string{}
+	for _, key := range result.Conflicts {
+		warnings = append(warnings, fmt.Sprintf("%s changed on both sides since the last sync; kept the local value", key))
+	}
+	kept, keepWarnings := keepLocalProfilesOnRemoval(cfg, local, result.Merged)
+	warnings = append(warnings, keepWarnings...)
+	pushed := result.Pushed
+	for _, key := range kept {
+		result.Pulled = slices.DeleteFunc(result.Pulled, func(pulled string) bool { return pulled == key })
+		if !slices.Contains(pushed, key) {
+			pushed = append(pushed, key)
+		}
+	}
+	slices.Sort(pushed)
+
+	if !dryRun {
+		if _, err := os.Stat(target.File); len(pushed) > 0 || errors.Is(err, os.ErrNotExist) {
+			if err := configsync.Write(target.File, result.Merged, deps.now()); err != nil {
+				return nil, nil, err
+			}
+			if target.Kind == syncKindGit {
+				if err := syncGitPush(ctx, target.Checkout); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		if err := applySyncSnapshot(ctx, deps, store, cfg, notes, result.Merged, result.Pulled); err != nil {
+			return nil, nil, err
+		}
+		if err := configsync.Write(target.BaseFile, result.Merged, deps.now()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return map[string]any{
+		"remote":    remote,
+		"kind":      target.Kind,
+		"file":      target.File,
+		"dry_run":   dryRun,
+		"pulled":    result.Pulled,
+		"pushed":    pushed,
+		"conflicts": result.Conflicts,
+		"entries":   len(result.Merged),
+	}, warnings, nil
+}
+
+// buildSyncSnapshot collects the shareable entries of cfg and notes.
+func buildSyncSnapshot(cfg domain.Config, notes map[string]map[string]journal.Note) (configsync.Snapshot, error) {
+	snapshot := configsync.Snapshot{}
+	for _, profile := range cfg.Profiles {
+		if profile.Travel != nil || strings.TrimSpace(profile.Name) == "" {
+			continue
+		}
+		value, err := syncProfileValue(profile)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[syncProfilePrefix+profile.Name] = value
+	}
+	for profile, entries := range notes {
+		for slug, note := range entries {
+			value, err := json.Marshal(note)
+			if err != nil {
+				return nil, fmt.Errorf("encode note %s: %w", slug, err)
+			}
+			snapshot[syncNotePrefix+profile+"/"+slug] = value
+		}
+	}
+	return snapshot, nil
+}
+
+func syncProfileValue(profile domain.Profile) (json.RawMessage, error) {
+	encoded, err := json.Marshal(profile)
+	if err != nil {
+		return nil, fmt.Errorf("encode profile %s: %w", profile.Name, err)
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("encode profile %s: %w", profile.Name, err)
+	}
+	for _, key := range syncLocalOnlyProfileKeys {
+		delete(fields, key)
+	}
+	return json.Marshal(fields)
+}
+
+// keepLocalProfilesOnRemoval restores profiles the shared side removed when
+// they are the default or still hold a login here, so a sync never signs the
+// machine out. It returns the restored keys.
+func keepLocalProfilesOnRemoval(cfg domain.Config, local configsync.Snapshot, merged configsync.Snapshot) ([]string, []string) {
+	kept := []string{}
+	warnings := []string{}
+	for _, profile := range cfg.Profiles {
+		key := syncProfilePrefix + profile.Name
+		if _, ok := merged[key]; ok || local[key] == nil {
+			continue
+		}
+		if !profile.IsDefault && strings.TrimSpace(profile.WToken) == "" {
+			continue
+		}
+		merged[key] = local[key]
+		kept = append(kept, key)
+		warnings = append(warnings, fmt.Sprintf("profile %q was removed from the shared file but is signed in or default here; kept it", profile.Name))
+	}
+	return kept, warnings
+}
+
+// applySyncSnapshot writes pulled entries into the config and journal. Local
+// credentials and markers of existing profiles are kept.
+func applySyncSnapshot(
+	ctx context.Context,
+	deps Dependencies,
+	store *journal.Store,
+	cfg domain.Config,
+	notes map[string]map[string]journal.Note,
+	merged configsync.Snapshot,
+	pulled []string,
+) error {
+	profilesChanged := false
+	notesChanged := false
+	for _, key := range pulled {
+		if name, ok := strings.CutPrefix(key, syncProfilePrefix); ok {
+			updated, err := applySyncProfile(cfg, name, merged[key])
+			if err != nil {
+				return err
+			}
+			cfg = updated
+			profilesChanged = true
+			continue
+		}
+		if rest, ok := strings.CutPrefix(key, syncNotePrefix); ok {
+			split := strings.LastIndex(rest, "/")
+			if split <= 0 {
+				continue
+			}
+			profile, slug := rest[:split], rest[split+1:]
+			if notes[profile] == nil {
+				notes[profile] = map[string]journal.Note{}
+			}
+			if value, present := merged[key]; present {
+				var note journal.Note
+				if err := json.Unmarshal(value, &note); err != nil {
+					return fmt.Errorf("decode shared %s: %w", key, err)
+				}
+				notes[profile][slug] = note
+			} else {
+				delete(notes[profile], slug)
+			}
+			notesChanged = true
+		}
+	}
+	if profilesChanged {
+		if err := deps.Config.Save(ctx, cfg); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+	}
+	if notesChanged {
+		if err := store.ReplaceNotes(notes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applySyncProfile(cfg domain.Config, name string, value json.RawMessage) (domain.Config, error) {
+	index := -1
+	for i, profile := range cfg.Profiles {
+		if profile.Name == name {
+			index = i
+			break
+		}
+	}
+	if value == nil {
+		if index >= 0 {
+			cfg.Profiles = append(cfg.Profiles[:index:index], cfg.Profiles[index+1:]...)
+		}
+		return cfg, nil
+	}
+	var shared domain.Profile
+	if err := json.Unmarshal(value, &shared); err != nil {
+		return cfg, fmt.Errorf("decode shared profile %s: %w", name, err)
+	}
+	shared.Name = name
+	if index < 0 {
+		cfg.Profiles = append(cfg.Profiles, shared)
+		return cfg, nil
+	}
+	current := cfg.Profiles[index]
+	shared.IsDefault = current.IsDefault
+	shared.WToken = current.WToken
+	shared.WRefreshToken = current.WRefreshToken
+	shared.Cookies = current.Cookies
+	shared.WoltAddressID = current.WoltAddressID
+	shared.PluginAuth = current.PluginAuth
+	shared.ReadOnly = current.ReadOnly
+	shared.Approval = current.Approval
+	shared.Travel = current.Travel
+	profiles := append([]domain.Profile{}, cfg.Profiles...)
+	profiles[index] = shared
+	cfg.Profiles = profiles
+	return cfg, nil
+}
+
+// resolveSyncTarget maps --remote to the shared file and the base snapshot
+// of the previous sync with it.
+func resolveSyncTarget(deps Dependencies, remote string) (syncTarget, error) {
+	dir, err := stateDir(deps, syncDirEnv, "sync")
+	if err != nil {
+		return syncTarget{}, err
+	}
+	if isGitRemote(remote) {
+		id := syncRemoteID(remote)
+		checkout := filepath.Join(dir, id)
+		return syncTarget{
+			Kind:     syncKindGit,
+			File:     filepath.Join(checkout, syncSharedFile),
+			Checkout: checkout,
+			BaseFile: filepath.Join(dir, id+".base.json"),
+		}, nil
+	}
+	path, err := filepath.Abs(expandHome(remote))
+	if err != nil {
+		return syncTarget{}, fmt.Errorf("resolve --remote %s: %w", remote, err)
+	}
+	if info, err := os.Stat(path); (err == nil && info.IsDir()) || strings.HasSuffix(remote, string(filepath.Separator)) {
+		path = filepath.Join(path, syncSharedFile)
+	}
+	return syncTarget{
+		Kind:     syncKindPath,
+		File:     path,
+		BaseFile: filepath.Join(dir, syncRemoteID(path)+".base.json"),
+	}, nil
+}
+
+func isGitRemote(remote string) bool {
+	for _, prefix := range []string{"git@", "ssh://", "git://", "http://", "https://", "file://"} {
+		if strings.HasPrefix(remote, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(remote, ".git")
+}
+
+func syncRemoteID(remote string) string {
+	sum := sha256.Sum256([]byte(remote))
+	return hex.EncodeToString(sum[:6])
+}
+
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + rest
+}
+
+// syncGitFetch clones remote into checkout, or resets an existing clone to the
+// remote branch. The clone is only a cache of the shared file, so local
+// commits a failed push left behind are dropped.
+func syncGitFetch(ctx context.Context, remote string, checkout string) error {
+	if _, err := os.Stat(filepath.Join(checkout, ".git")); err != nil {
+		if err := os.MkdirAll(filepath.Dir(checkout), 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", filepath.Dir(checkout), err)
+		}
+		_, err := runGit(ctx, "", "clone", "--quiet", remote, checkout)
+		return err
+	}
+	if _, err := runGit(ctx, checkout, "fetch", "--quiet", "origin"); err != nil {
+		return err
+	}
+	if _, err := runGit(ctx, checkout, "rev-parse", "--verify", "--quiet", "@{u}"); err != nil {
+		// An empty remote has no branch to follow yet.
+		return nil
+	}
+	_, err := runGit(ctx, checkout, "reset", "--quiet", "--hard", "@{u}")
+	return err
+}
+
+// syncGitPush commits the shared file when it changed and pushes it.
+func syncGitPush(ctx context.Context, checkout string) error {
+	if _, err := runGit(ctx, checkout, "add", syncSharedFile); err != nil {
+		return err
+	}
+	if _, err := runGit(ctx, checkout, "diff", "--cached", "--quiet"); err == nil {
+		return nil
+	}
+	if _, err := runGit(ctx, checkout, "commit", "--quiet", "-m", syncCommitText); err != nil {
+		return err
+	}
+	if _, err := runGit(ctx, checkout, "push", "--quiet", "origin", "HEAD"); err != nil {
+		return fmt.Errorf("%w; run wolt config sync again to merge the newer shared file", err)
+	}
+	return nil
+}
+
+// runGit runs git in dir; tests replace it.
+var runGit = func(ctx context.Context, dir string, args ...string) (string, error) {
+	binary, err := exec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("git remotes need the git command on PATH")
+	}
+	subcommand := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, binary, args...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", subcommand, message)
+		}
+		return "", fmt.Errorf("git: %w", err)
+	}
+	return stdout.String(), nil
+}
+
+func buildConfigSyncTable(data map[string]any) string {
+	mode := "applied"
+	if asBool(data["dry_run"]) {
+		mode = "dry run"
+	}
+	return output.RenderTable("Config sync", []string{"Field", "Value"}, [][]string{
+		{"Remote", asString(data["remote"])},
+		{"Kind", asString(data["kind"])},
+		{"Mode", mode},
+		{"Pulled", syncKeyList(data["pulled"])},
+		{"Pushed", syncKeyList(data["pushed"])},
+		{"Conflicts", syncKeyList(data["conflicts"])},
+		{"Entries", fmt.Sprintf("%d", asInt(data["entries"]))},
+	})
+}
+
+func syncKeyList(value any) string {
+	keys, _ := value.([]string)
+	if len(keys) == 0 {
+		return "-"
+	}
+	return strings.Join(keys, ", ")
+}
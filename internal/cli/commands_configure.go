@@ -2,23 +2,151 @@ package cli
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/output"
 	"github.com/spf13/cobra"
 )
 
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
 func newConfigureCommand(deps Dependencies) *cobra.Command {
 	var profileName string
 	var wtoken string
 	var wrefreshToken string
 	var cookies []string
 	var overwrite bool
+	var machine bool
+	var tipPercent float64
+	var autoApplyPromo bool
+	var locale string
+	var latestOrderTime string
+	var readOnly bool
+	var approvalThreshold string
+	var allowedCountryValues []string
+	var mealPresetValues []string
+	var pluginAuth []string
+	var enrichmentMode string
 
 	cmd := &cobra.Command{
 		Use:   "configure",
 		Short: "Create and manage local profile auth configuration.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			tipPercentSet := cmd.Flags().Changed("default-tip-percent")
+			autoApplyPromoSet := cmd.Flags().Changed("auto-apply-best-promo")
+			localeSet := cmd.Flags().Changed("locale")
+			latestOrderTimeSet := cmd.Flags().Changed("latest-order-time")
+			readOnlySet := cmd.Flags().Changed("read-only")
+			approvalThresholdSet := cmd.Flags().Changed("approval-threshold")
+			if tipPercentSet && (tipPercent < 0 || tipPercent > 100) {
+				return fmt.Errorf("--default-tip-percent must be between 0 and 100")
+			}
+			locale = strings.TrimSpace(locale)
+			if localeSet && locale != "" && !localePattern.MatchString(locale) {
+				return fmt.Errorf("--locale must be a BCP-47 tag such as fi-FI")
+			}
+			if latestOrderTimeSet {
+				parsed, err := parseLatestOrderTime(latestOrderTime)
+				if err != nil {
+					return err
+				}
+				latestOrderTime = parsed
+			}
+			var approvalPolicy *domain.ApprovalPolicy
+			if approvalThresholdSet {
+				parsed, err := parseApprovalThreshold(approvalThreshold)
+				if err != nil {
+					return err
+				}
+				approvalPolicy = parsed
+			}
+			allowedCountriesSet := cmd.Flags().Changed("allowed-country")
+			allowedCountries, err := parseAllowedCountries(allowedCountryValues)
+			if err != nil {
+				return err
+			}
+			enrichmentModeSet := cmd.Flags().Changed("enrichment-mode")
+			if enrichmentModeSet && strings.TrimSpace(enrichmentMode) != "" {
+				parsed, err := parseFeedEnrichment(enrichmentMode)
+				if err != nil {
+					return fmt.Errorf("--enrichment-mode must be all, none, fast, full, wolt-plus-only, or top:N with N of at least 1, got %q", strings.TrimSpace(enrichmentMode))
+				}
+				enrichmentMode = parsed.String()
+			} else {
+				enrichmentMode = ""
+			}
+			mealPresetSet := len(mealPresetValues) > 0
+			pluginAuthSet := cmd.Flags().Changed("plugin-auth")
+			pluginNames := []string{}
+			for _, name := range pluginAuth {
+				name = strings.TrimPrefix(strings.TrimSpace(name), pluginPrefix)
+				if name == "" {
+					continue
+				}
+				if !pluginNamePattern.MatchString(name) {
+					return fmt.Errorf("--plugin-auth %q is not a valid plugin name", name)
+				}
+				pluginNames = append(pluginNames, name)
+			}
+			mealOverrides := map[string]*domain.MealPreset{}
+			for _, value := range mealPresetValues {
+				name, preset, err := parseMealPresetFlag(value)
+				if err != nil {
+					return err
+				}
+				mealOverrides[name] = preset
+			}
+			applySettings := func(profile *domain.Profile) {
+				if tipPercentSet {
+					profile.DefaultTipPercent = tipPercent
+				}
+				if autoApplyPromoSet {
+					profile.AutoApplyBestPromo = autoApplyPromo
+				}
+				if localeSet {
+					profile.Locale = locale
+				}
+				if latestOrderTimeSet {
+					profile.LatestOrderTime = latestOrderTime
+				}
+				if readOnlySet {
+					profile.ReadOnly = readOnly
+				}
+				if approvalThresholdSet {
+					profile.Approval = approvalPolicy
+				}
+				if allowedCountriesSet {
+					profile.AllowedCountries = nil
+					if len(allowedCountries) > 0 {
+						profile.AllowedCountries = allowedCountries
+					}
+				}
+				for name, preset := range mealOverrides {
+					if preset == nil {
+						delete(profile.MealPresets, name)
+						continue
+					}
+					if profile.MealPresets == nil {
+						profile.MealPresets = map[string]domain.MealPreset{}
+					}
+					profile.MealPresets[name] = *preset
+				}
+				if len(profile.MealPresets) == 0 {
+					profile.MealPresets = nil
+				}
+				if enrichmentModeSet {
+					profile.EnrichmentMode = enrichmentMode
+				}
+				if pluginAuthSet {
+					profile.PluginAuth = nil
+					if len(pluginNames) > 0 {
+						profile.PluginAuth = dedupeStrings(pluginNames)
+					}
+				}
+			}
+
 			cookieInputs := normalizeCookieInputs(cookies)
 			refreshCandidate := extractRefreshToken(wrefreshToken)
 			if refreshCandidate == "" {
@@ -34,8 +162,9 @@ func newConfigureCommand(deps Dependencies) *cobra.Command {
 			existingCfg, loadErr := deps.Config.Load(cmd.Context())
 			hasExisting := loadErr == nil
 			if hasExisting && !overwrite {
-				if strings.TrimSpace(wtoken) == "" && strings.TrimSpace(refreshCandidate) == "" && len(cookieInputs) == 0 {
-					return fmt.Errorf("provide --wtoken, --wrtoken, or --cookie to update auth fields")
+				authChanged := strings.TrimSpace(wtoken) != "" || strings.TrimSpace(refreshCandidate) != "" || len(cookieInputs) > 0
+				if !authChanged && !tipPercentSet && !autoApplyPromoSet && !localeSet && !latestOrderTimeSet && !readOnlySet && !approvalThresholdSet && !allowedCountriesSet && !mealPresetSet && !pluginAuthSet && !enrichmentModeSet {
+					return fmt.Errorf("provide --wtoken, --wrtoken, or --cookie to update auth fields, or --default-tip-percent, --auto-apply-best-promo, --locale, --latest-order-time, --read-only, --approval-threshold, --allowed-country, --meal-preset, --plugin-auth, or --enrichment-mode to update settings")
 				}
 				index := findProfileIndex(existingCfg, profileName)
 				if index < 0 {
@@ -50,10 +179,18 @@ func newConfigureCommand(deps Dependencies) *cobra.Command {
 				if len(cookieInputs) > 0 {
 					existingCfg.Profiles[index].Cookies = cookieInputs
 				}
+				applySettings(&existingCfg.Profiles[index])
 				if err := deps.Config.Save(cmd.Context(), existingCfg); err != nil {
 					return err
 				}
-				return writeTable(cmd, "🏁 Config auth updated successfully!", "")
+				action, message := "auth_updated", "🏁 Config auth updated successfully!"
+				if !authChanged {
+					action, message = "settings_updated", "🏁 Profile settings updated successfully!"
+				}
+				if machine {
+					return writeConfigureEnvelope(cmd, deps, existingCfg.Profiles[index].Name, action)
+				}
+				return writeTable(cmd, message, "")
 			}
 
 			cfg := domain.Config{
@@ -67,9 +204,13 @@ func newConfigureCommand(deps Dependencies) *cobra.Command {
 					},
 				},
 			}
+			applySettings(&cfg.Profiles[0])
 			if err := deps.Config.Save(cmd.Context(), cfg); err != nil {
 				return err
 			}
+			if machine {
+				return writeConfigureEnvelope(cmd, deps, profileName, "created")
+			}
 			return writeTable(cmd, "🏁 Config was created successfully!", "")
 		},
 	}
@@ -79,9 +220,30 @@ func newConfigureCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringVar(&wrefreshToken, "wrtoken", "", "Optional refresh token saved with the profile for automatic token rotation.")
 	cmd.Flags().StringArrayVar(&cookies, "cookie", nil, "Optional cookie value saved with the profile (repeatable).")
 	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Overwrite existing config")
+	cmd.Flags().Float64Var(&tipPercent, "default-tip-percent", 0, "Courier tip as a percentage of the basket subtotal, used by checkout preview when --tip is omitted (0 disables).")
+	cmd.Flags().BoolVar(&autoApplyPromo, "auto-apply-best-promo", false, "Apply the largest selectable checkout offer when --promo-code is omitted.")
+	cmd.Flags().StringVar(&locale, "locale", "", "Locale for formatted amounts when a command runs without --locale, for example fi-FI (empty clears).")
+	cmd.Flags().StringVar(&latestOrderTime, "latest-order-time", "", "Local time as HH:MM after which cart add/update, venue shop --apply, and checkout preview refuse to run without --force (empty clears).")
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "Run every command for this profile as if --read-only were passed: upstream requests that change the account fail with WOLT_READ_ONLY.")
+	cmd.Flags().StringVar(&approvalThreshold, "approval-threshold", "", "Basket total such as \"50 EUR\" above which cart add/update, venue shop --apply, and checkout preview need a prompt confirmation or --approve-token (empty clears).")
+	cmd.Flags().StringArrayVar(&allowedCountryValues, "allowed-country", nil, "Venue country code such as FIN that cart add/update, venue shop --apply, and checkout preview may use; replaces the list, an empty value clears (repeatable).")
+	cmd.Flags().StringArrayVar(&mealPresetValues, "meal-preset", nil, "Override a discover meal preset as NAME=HH:MM-HH:MM[,tag...]; NAME= removes the override (repeatable).")
+	cmd.Flags().StringArrayVar(&pluginAuth, "plugin-auth", nil, "Plugin name (wolt-<name> on PATH) that receives this profile's credentials; replaces the list, an empty value clears (repeatable).")
+	cmd.Flags().StringVar(&enrichmentMode, "enrichment-mode", "", "Feed enrichment discover feed uses when --enrich and --fast are omitted: all, none, fast, full, wolt-plus-only, or top:N (empty clears).")
+	cmd.Flags().BoolVar(&machine, "machine", false, "Print a JSON envelope instead of the confirmation message.")
 	return cmd
 }
 
+func writeConfigureEnvelope(cmd *cobra.Command, deps Dependencies, profileName string, action string) error {
+	data := map[string]any{
+		"action":      action,
+		"profile":     profileName,
+		"config_path": deps.Config.Path(),
+	}
+	env := output.BuildEnvelope(profileName, "", data, nil, nil)
+	return writeMachinePayload(cmd, env, output.FormatJSON, "")
+}
+
 func findProfileIndex(cfg domain.Config, profileName string) int {
 	trimmed := strings.TrimSpace(profileName)
 	if trimmed != "" {
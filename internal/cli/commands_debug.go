@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const debugParseExampleLimit = 3
+
+func newDebugCommand(deps Dependencies) *cobra.Command {
+	debug := &cobra.Command{
+		Use:   "debug",
+		Short: "Diagnose how the CLI reads raw Wolt payloads.",
+	}
+	debug.AddCommand(newDebugParseCommand(deps))
+	debug.AddCommand(newDebugAnonymizeCommand(deps))
+	debug.AddCommand(newDebugFixtureCommand(deps))
+	return debug
+}
+
+func newDebugParseCommand(_ Dependencies) *cobra.Command {
+	var payloadPath string
+	var kind string
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "parse",
+		Short: "Run the payload extractors on a saved payload and report unresolved fields.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			switch kind {
+			case "assortment", "front", "venue":
+			default:
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--kind must be one of: assortment, front, venue")
+			}
+
+			raw, err := os.ReadFile(payloadPath)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("read payload: %v", err))
+			}
+			var payload map[string]any
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("payload is not a JSON object: %v", err))
+			}
+
+			data, warnings := buildDebugParseReport(kind, payload)
+			data["payload"] = payloadPath
+			if format == output.FormatTable {
+				return writeTable(cmd, buildDebugParseTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&payloadPath, "payload", "", "Path to a raw JSON payload saved from Wolt")
+	cmd.Flags().StringVar(&kind, "kind", "", "Payload kind: assortment, front, or venue")
+	_ = cmd.MarkFlagRequired("payload")
+	_ = cmd.MarkFlagRequired("kind")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// parseFieldCheck counts how often one extracted field resolved across the parsed rows.
+type parseFieldCheck struct {
+	field    string
+	resolved int
+	missing  []string
+}
+
+type parseReport struct {
+	checks []*parseFieldCheck
+	index  map[string]*parseFieldCheck
+}
+
+func newParseReport(fields ...string) *parseReport {
+	report := &parseReport{index: map[string]*parseFieldCheck{}}
+	for _, field := range fields {
+		check := &parseFieldCheck{field: field}
+		report.checks = append(report.checks, check)
+		report.index[field] = check
+	}
+	return report
+}
+
+func (r *parseReport) observe(field string, resolved bool, label string) {
+	check := r.index[field]
+	if resolved {
+		check.resolved++
+		return
+	}
+	check.missing = append(check.missing, fallbackString(label, "-"))
+}
+
+func (r *parseReport) rows() ([]any, []any) {
+	rows := make([]any, 0, len(r.checks))
+	unresolved := []any{}
+	for _, check := range r.checks {
+		examples := []any{}
+		for _, label := range check.missing {
+			if len(examples) == debugParseExampleLimit {
+				break
+			}
+			examples = append(examples, label)
+		}
+		rows = append(rows, map[string]any{
+			"field":    check.field,
+			"resolved": check.resolved,
+			"missing":  len(check.missing),
+			"examples": examples,
+		})
+		if len(check.missing) > 0 {
+			unresolved = append(unresolved, check.field)
+		}
+	}
+	return rows, unresolved
+}
+
+func buildDebugParseReport(kind string, payload map[string]any) (map[string]any, []string) {
+	var report *parseReport
+	extracted := map[string]any{}
+	warnings := []string{}
+
+	switch kind {
+	case "front":
+		report = newParseReport("venue.id", "venue.slug", "venue.name", "venue.address", "venue.currency", "venue.delivery_price_int", "venue.estimate_range", "venue.rating")
+		sections, err := decodeFrontPageSections(payload)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		venues := 0
+		for _, section := range sections {
+			for _, item := range section.Items {
+				if item.Venue == nil {
+					continue
+				}
+				venues++
+				observeFrontPageVenue(report, item.Venue)
+			}
+		}
+		extracted["sections"] = len(sections)
+		extracted["venues"] = venues
+		extracted["categories"] = len(asSlice(observability.BuildCategoryList(sections)["categories"]))
+		if venues == 0 {
+			warnings = append(warnings, "no venues could be extracted from the payload")
+		}
+	default:
+		report = newParseReport("item_id", "name", "base_price.amount", "base_price.currency", "category")
+		venueID := ""
+		if kind == "venue" {
+			venueID = strings.TrimSpace(venueIDFromPayload(payload))
+			venuePayload := asMap(coalesceAny(payload["venue"], payload["venue_raw"]))
+			extracted["venue_id"] = emptyToNil(venueID)
+			extracted["venue_name"] = emptyToNil(asString(venuePayload["name"]))
+			extracted["wolt_plus"] = observability.ExtractVenueWoltPlus(payload)
+			extracted["promotions"] = len(observability.ExtractVenuePromotionLabels(payload))
+			if venueID == "" {
+				warnings = append(warnings, "venue id could not be resolved from the payload")
+			}
+		}
+		items := observability.ExtractMenuItems(payload, venueID, "")
+		for _, item := range items {
+			observeMenuItem(report, item)
+		}
+		extracted["items"] = len(items)
+		if kind == "assortment" {
+			extracted["categories"] = len(collectAssortmentCategorySlugs(payload))
+		}
+		if len(items) == 0 {
+			warnings = append(warnings, "no menu items could be extracted from the payload")
+		}
+	}
+
+	fields, unresolved := report.rows()
+	return map[string]any{
+		"kind":              kind,
+		"extracted":         extracted,
+		"fields":            fields,
+		"unresolved_fields": unresolved,
+	}, warnings
+}
+
+func decodeFrontPageSections(payload map[string]any) ([]domain.Section, error) {
+	raw, ok := payload["sections"]
+	if !ok {
+		return nil, fmt.Errorf("payload has no sections key")
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encode sections: %w", err)
+	}
+	var sections []domain.Section
+	if err := json.Unmarshal(encoded, &sections); err != nil {
+		return nil, fmt.Errorf("decode sections: %w", err)
+	}
+	return sections, nil
+}
+
+func observeFrontPageVenue(report *parseReport, venue *domain.Venue) {
+	label := fallbackString(venue.Slug, venue.Name)
+	report.observe("venue.id", domain.NormalizeID(venue.ID) != "", label)
+	report.observe("venue.slug", strings.TrimSpace(venue.Slug) != "", label)
+	report.observe("venue.name", strings.TrimSpace(venue.Name) != "", label)
+	report.observe("venue.address", strings.TrimSpace(venue.Address) != "", label)
+	report.observe("venue.currency", strings.TrimSpace(venue.Currency) != "", label)
+	report.observe("venue.delivery_price_int", venue.DeliveryPriceInt != nil, label)
+	report.observe("venue.estimate_range", strings.TrimSpace(venue.EstimateRange) != "", label)
+	report.observe("venue.rating", venue.Rating != nil, label)
+}
+
+func observeMenuItem(report *parseReport, item map[string]any) {
+	label := fallbackString(asString(item["item_id"]), asString(item["name"]))
+	price := asMap(item["base_price"])
+	report.observe("item_id", asString(item["item_id"]) != "", label)
+	report.observe("name", strings.TrimSpace(asString(item["name"])) != "", label)
+	report.observe("base_price.amount", price["amount"] != nil, label)
+	report.observe("base_price.currency", price["currency"] != nil, label)
+	report.observe("category", asString(item["category"]) != "uncategorized", label)
+}
+
+func buildDebugParseTable(data map[string]any) string {
+	headers := []string{"Field", "Resolved", "Missing", "Examples"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["fields"]) {
+		row := asMap(value)
+		examples := []string{}
+		for _, example := range asSlice(row["examples"]) {
+			examples = append(examples, asString(example))
+		}
+		rows = append(rows, []string{
+			asString(row["field"]),
+			asString(row["resolved"]),
+			asString(row["missing"]),
+			fallbackString(strings.Join(examples, ", "), "-"),
+		})
+	}
+	return output.RenderTable(fmt.Sprintf("Parse report (%s)", asString(data["kind"])), headers, rows)
+}
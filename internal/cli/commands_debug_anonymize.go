@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/anonymize"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newDebugAnonymizeCommand(_ Dependencies) *cobra.Command {
+	var outPath string
+	var seed string
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "anonymize <payload.json>",
+		Short: "Replace user ids, names, addresses, phones, emails, and coordinates in a saved payload.",
+		Long: "Rewrite a saved Wolt payload so it can be shared as a fixture. Personal values are replaced\n" +
+			"with stand-ins of the same shape, coordinates are moved by up to 0.005 degrees, and the\n" +
+			"JSON structure is kept so the payload still parses the same way.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			payloadPath := args[0]
+
+			raw, err := os.ReadFile(payloadPath)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("read payload: %v", err))
+			}
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.UseNumber()
+			var payload any
+			if err := decoder.Decode(&payload); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("payload is not JSON: %v", err))
+			}
+
+			salt := strings.TrimSpace(seed)
+			if salt == "" {
+				salt = randomAnonymizeSalt()
+			}
+			anonymizer := anonymize.New(salt)
+			encoded, err := json.MarshalIndent(anonymizer.Payload(payload), "", "  ")
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("encode payload: %v", err))
+			}
+
+			outPath = strings.TrimSpace(outPath)
+			if outPath == "" {
+				outPath = anonymizedPayloadPath(payloadPath)
+			}
+			// Tokens and cookies captured with the payload are dropped as well.
+			if err := os.WriteFile(outPath, []byte(woltgateway.Redact(string(encoded))+"\n"), 0o644); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("write anonymized payload: %v", err))
+			}
+
+			counts := anonymizer.Counts()
+			replaced := map[string]any{}
+			total := 0
+			for _, category := range anonymize.Categories {
+				replaced[string(category)] = counts[category]
+				total += counts[category]
+			}
+			data := map[string]any{
+				"payload":  payloadPath,
+				"out":      outPath,
+				"replaced": replaced,
+				"total":    total,
+			}
+			warnings := []string{}
+			if total == 0 {
+				warnings = append(warnings, "no personal data was recognized; review the payload before sharing it")
+			}
+			if format == output.FormatTable {
+				rows := make([][]string, 0, len(anonymize.Categories))
+				for _, category := range anonymize.Categories {
+					rows = append(rows, []string{string(category), fmt.Sprintf("%d", counts[category])})
+				}
+				return writeTable(cmd, output.RenderTable(fmt.Sprintf("Anonymized payload (%s)", outPath), []string{"Category", "Replaced"}, rows), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&outPath, "out", "", "Where to write the anonymized payload (default: <payload>.anonymized.json)")
+	cmd.Flags().StringVar(&seed, "seed", "", "Salt for the stand-ins; reuse it to anonymize related payloads consistently")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// anonymizedPayloadPath puts the anonymized copy next to the original.
+func anonymizedPayloadPath(payloadPath string) string {
+	ext := filepath.Ext(payloadPath)
+	return strings.TrimSuffix(payloadPath, ext) + ".anonymized.json"
+}
+
+func randomAnonymizeSalt() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
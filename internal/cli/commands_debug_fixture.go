@@ -0,0 +1,426 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/anonymize"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// fixtureRecorder is implemented by the live Wolt client.
+type fixtureRecorder interface {
+	SetFixtureRecording(dir string)
+	EndpointFamily(rawURL string) string
+}
+
+// fixtureMockFields maps endpoint families to the mockWolt field in test/e2e
+// that answers them and the field's parameter list. Families served by typed
+// methods (restaurant, sections, items) are left for the contributor.
+var fixtureMockFields = map[string][2]string{
+	"front_page":         {"frontPageFunc", "context.Context, domain.Location"},
+	"search":             {"searchFunc", "context.Context, domain.Location, string"},
+	"venue_page_static":  {"venuePageStaticFunc", "context.Context, string"},
+	"venue_page_dynamic": {"venuePageDynamicFunc", "context.Context, string, woltgateway.VenuePageDynamicOptions"},
+	"assortment":         {"assortmentBySlugFunc", "context.Context, string"},
+	"venue_content":      {"venueContentBySlugFn", "context.Context, string, string, woltgateway.AuthContext"},
+	"venue_item":         {"venueItemPageFunc", "context.Context, string, string"},
+	"cities":             {"citiesFunc", "context.Context"},
+	"user_me":            {"userMeFunc", "context.Context, woltgateway.AuthContext"},
+	"payment_methods":    {"paymentMethodsFunc", "context.Context, woltgateway.AuthContext"},
+	"payment_profile":    {"paymentProfileFunc", "context.Context, woltgateway.AuthContext, woltgateway.PaymentMethodsProfileOptions"},
+	"address_fields":     {"addressFieldsFunc", "context.Context, domain.Location, string, woltgateway.AuthContext"},
+	"delivery_info":      {"deliveryInfoListFunc", "context.Context, woltgateway.AuthContext"},
+	"order_history":      {"orderHistoryFunc", "context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions"},
+	"favorites_page":     {"favoriteVenuesFunc", "context.Context, domain.Location, woltgateway.AuthContext"},
+	"basket_count":       {"basketCountFunc", "context.Context, woltgateway.AuthContext"},
+	"baskets_page":       {"basketsPageFunc", "context.Context, domain.Location, woltgateway.AuthContext"},
+	"basket":             {"addToBasketFunc", "context.Context, map[string]any, woltgateway.AuthContext"},
+	"checkout":           {"checkoutPreviewFunc", "context.Context, map[string]any, woltgateway.AuthContext"},
+}
+
+// recordedFixture is one anonymized exchange of a fixture run.
+type recordedFixture struct {
+	file    string
+	family  string
+	fixture woltgateway.Fixture
+}
+
+func newDebugFixtureCommand(deps Dependencies) *cobra.Command {
+	var outDir string
+	var name string
+	var seed string
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "fixture <command> [args...]",
+		Short: "Record a command against live Wolt APIs as an anonymized test fixture bundle.",
+		Long: "Run a command against the live APIs while recording every response, then write the\n" +
+			"anonymized responses, the command's anonymized JSON output, and a skeleton e2e test that\n" +
+			"serves the responses through mockWolt. Flags before the command belong to debug fixture;\n" +
+			"everything from the command on is passed through unchanged.\n\n" +
+			"The bundle goes to test/e2e/testdata/fixtures/<name> by default. Go ignores testdata, so the\n" +
+			"skeleton test stays inert until it is moved to test/e2e.",
+		Example: "wolt debug fixture search venues --query sushi\n" +
+			"wolt debug fixture --name venue-menu-est venue menu burger-king-tallinn",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// A pipeline that passes --machine to the recorded command expects the
+			// bundle summary on stdout as an envelope too.
+			if !machineMode(cmd) && slices.Contains(args, "--machine") {
+				if err := cmd.Flags().Set("machine", "true"); err != nil {
+					return err
+				}
+				if err := applyMachineMode(cmd); err != nil {
+					return err
+				}
+			}
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if len(args) >= 2 && args[0] == "debug" && args[1] == "fixture" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "debug fixture cannot record itself")
+			}
+			recorder, ok := deps.Wolt.(fixtureRecorder)
+			if !ok {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "debug fixture needs the live Wolt client")
+			}
+
+			innerArgs := slices.Clone(args)
+			if !slices.ContainsFunc(innerArgs, func(arg string) bool { return arg == "--format" || strings.HasPrefix(arg, "--format=") }) {
+				innerArgs = append(innerArgs, "--format", "json")
+			}
+			name = fallbackString(fixtureSlug(name), fixtureSlug(strings.Join(fixtureCommandWords(args), "-")))
+			outDir = fallbackString(strings.TrimSpace(outDir), filepath.Join("test", "e2e", "testdata", "fixtures", name))
+
+			recordDir, err := os.MkdirTemp("", "wolt-fixture-*")
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("create record directory: %v", err))
+			}
+			defer func() { _ = os.RemoveAll(recordDir) }()
+			var innerOut bytes.Buffer
+			recorder.SetFixtureRecording(recordDir)
+			exitCode := executeChain(cmd.Context(), innerArgs, deps, &innerOut, cmd.ErrOrStderr())
+			recorder.SetFixtureRecording("")
+
+			salt := strings.TrimSpace(seed)
+			if salt == "" {
+				salt = randomAnonymizeSalt()
+			}
+			anonymizer := anonymize.New(salt)
+			fixtures, err := readRecordedFixtures(recordDir, recorder, anonymizer)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			warnings := []string{}
+			if len(fixtures) == 0 {
+				warnings = append(warnings, "the command made no upstream requests; the bundle has no fixtures")
+			}
+			if exitCode != 0 {
+				warnings = append(warnings, fmt.Sprintf("the command exited with code %d; the skeleton test expects that", exitCode))
+			}
+
+			files, err := writeFixtureBundle(outDir, name, innerArgs, exitCode, innerOut.Bytes(), fixtures, anonymizer)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("write fixture bundle: %v", err))
+			}
+
+			families := []any{}
+			for _, fixture := range fixtures {
+				families = append(families, map[string]any{"file": fixture.file, "family": fixture.family, "url": fixture.fixture.URL, "status": fixture.fixture.Status})
+			}
+			counts := anonymizer.Counts()
+			replaced := map[string]any{}
+			for _, category := range anonymize.Categories {
+				replaced[string(category)] = counts[category]
+			}
+			data := map[string]any{
+				"name":      name,
+				"out":       outDir,
+				"command":   redactFixtureArgs(innerArgs),
+				"exit_code": exitCode,
+				"fixtures":  families,
+				"files":     files,
+				"replaced":  replaced,
+			}
+			if format == output.FormatTable {
+				rows := [][]string{}
+				for _, fixture := range fixtures {
+					rows = append(rows, []string{fixture.family, fmt.Sprintf("%d", fixture.fixture.Status), fixture.file})
+				}
+				return writeTable(cmd, output.RenderTable(fmt.Sprintf("Fixture bundle (%s)", outDir), []string{"Family", "Status", "File"}, rows), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	// Flags after the recorded command belong to it.
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().StringVar(&outDir, "out", "", "Bundle directory (default: test/e2e/testdata/fixtures/<name>)")
+	cmd.Flags().StringVar(&name, "name", "", "Bundle and test name (default: the command words, such as search-venues)")
+	cmd.Flags().StringVar(&seed, "seed", "", "Salt for the anonymized stand-ins; reuse it to keep related bundles consistent")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// readRecordedFixtures loads the fixtures a run recorded, anonymizes their
+// bodies and URL query values, and names their endpoint family, in file name
+// order.
+func readRecordedFixtures(dir string, recorder fixtureRecorder, anonymizer *anonymize.Anonymizer) ([]recordedFixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read recorded fixtures: %w", err)
+	}
+	fixtures := []recordedFixture{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read recorded fixture: %w", err)
+		}
+		var fixture woltgateway.Fixture
+		if err := json.Unmarshal(raw, &fixture); err != nil {
+			return nil, fmt.Errorf("decode recorded fixture %s: %w", entry.Name(), err)
+		}
+		body, err := anonymizeJSON(fixture.Body, anonymizer)
+		if err != nil {
+			return nil, fmt.Errorf("anonymize %s: %w", entry.Name(), err)
+		}
+		fixture.Body = body
+		family := recorder.EndpointFamily(fixture.URL)
+		fixture.URL = anonymizer.URL(fixture.URL)
+		fixtures = append(fixtures, recordedFixture{file: entry.Name(), family: family, fixture: fixture})
+	}
+	return fixtures, nil
+}
+
+func anonymizeJSON(raw []byte, anonymizer *anonymize.Anonymizer) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var payload any
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, err
+	}
+	encoded, err := json.MarshalIndent(anonymizer.Payload(payload), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(woltgateway.Redact(string(encoded))), nil
+}
+
+// writeFixtureBundle writes the fixtures, command.json, output.json, and the
+// skeleton test into dir and returns the written file names.
+func writeFixtureBundle(dir string, name string, args []string, exitCode int, commandOutput []byte, fixtures []recordedFixture, anonymizer *anonymize.Anonymizer) ([]any, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	files := []any{}
+	write := func(file string, content []byte) error {
+		files = append(files, file)
+		return os.WriteFile(filepath.Join(dir, file), content, 0o644)
+	}
+	for _, fixture := range fixtures {
+		encoded, err := json.MarshalIndent(fixture.fixture, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := write(fixture.file, append(encoded, '\n')); err != nil {
+			return nil, err
+		}
+	}
+	command, err := json.MarshalIndent(map[string]any{"args": fixtureTestArgs(args), "exit_code": exitCode}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := write("command.json", append(command, '\n')); err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(commandOutput)) > 0 {
+		body, err := anonymizeJSON(commandOutput, anonymizer)
+		if err != nil {
+			// Output that is not one JSON document is kept, with credentials redacted.
+			body = json.RawMessage(woltgateway.Redact(string(commandOutput)))
+		}
+		if err := write("output.json", append(bytes.TrimRight(body, "\n"), '\n')); err != nil {
+			return nil, err
+		}
+	}
+	skeleton, err := fixtureTestSkeleton(name, args, exitCode, fixtures)
+	if err != nil {
+		return nil, err
+	}
+	if err := write("fixture_test.go", skeleton); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// fixtureTestSkeleton renders an e2e test that serves the fixtures through
+// mockWolt and runs the recorded command. A family recorded more than once is
+// answered with its first fixture, with the others named in a TODO.
+func fixtureTestSkeleton(name string, args []string, exitCode int, fixtures []recordedFixture) ([]byte, error) {
+	byFamily := map[string][]string{}
+	families := []string{}
+	for _, fixture := range fixtures {
+		if _, seen := byFamily[fixture.family]; !seen {
+			families = append(families, fixture.family)
+		}
+		byFamily[fixture.family] = append(byFamily[fixture.family], fixture.file)
+	}
+	var mocks strings.Builder
+	unmapped := []string{}
+	for _, family := range families {
+		field, ok := fixtureMockFields[family]
+		if !ok {
+			unmapped = append(unmapped, family)
+			continue
+		}
+		files := byFamily[family]
+		if len(files) > 1 {
+			fmt.Fprintf(&mocks, "\t\t\t// TODO: also recorded for %s: %s\n", family, strings.Join(files[1:], ", "))
+		}
+		fmt.Fprintf(&mocks, "\t\t\t%s: func(%s) (map[string]any, error) {\n\t\t\t\treturn fixture(%q), nil\n\t\t\t},\n", field[0], field[1], files[0])
+	}
+	if len(unmapped) > 0 {
+		fmt.Fprintf(&mocks, "\t\t\t// TODO: no mockWolt field answers %s; mock it by hand.\n", strings.Join(unmapped, ", "))
+	}
+
+	quoted := []string{}
+	for _, arg := range fixtureTestArgs(args) {
+		quoted = append(quoted, fmt.Sprintf("%q", arg))
+	}
+	check := "exitCode != 0"
+	if exitCode != 0 {
+		check = fmt.Sprintf("exitCode != %d", exitCode)
+	}
+	imports := []string{`"context"`, `"encoding/json"`, `"os"`, `"path/filepath"`, `"testing"`, "", `"github.com/mekedron/wolt-cli/internal/cli"`, `"github.com/mekedron/wolt-cli/internal/domain"`}
+	if strings.Contains(mocks.String(), "woltgateway.") {
+		imports = append(imports, `woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"`)
+	}
+	if !strings.Contains(mocks.String(), "context.Context") {
+		imports = imports[1:]
+	}
+
+	source := fmt.Sprintf(`package e2e_test
+
+// Generated by wolt debug fixture for: wolt %s
+// Move this file to test/e2e and keep the fixtures in testdata/fixtures/%s.
+
+import (
+	%s
+)
+
+func TestFixture%s(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	fixture := func(file string) map[string]any {
+		t.Helper()
+		raw, err := os.ReadFile(filepath.Join("testdata", "fixtures", %q, file))
+		if err != nil {
+			t.Fatalf("read fixture: %%v", err)
+		}
+		var recorded struct {
+			Body map[string]any `+"`json:\"body\"`"+`
+		}
+		if err := json.Unmarshal(raw, &recorded); err != nil {
+			t.Fatalf("decode fixture %%s: %%v", file, err)
+		}
+		return recorded.Body
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+%s		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, %s)
+	if %s {
+		t.Fatalf("unexpected exit code %%d\noutput:\n%%s", exitCode, out)
+	}
+	// TODO: assert on the payload; output.json holds the envelope of the recorded run.
+	_ = mustJSON(t, out)
+}
+`, strings.Join(redactFixtureArgs(args), " "), name, strings.Join(imports, "\n\t"), fixtureTestName(name), name, mocks.String(), strings.Join(quoted, ", "), check)
+	return format.Source([]byte(source))
+}
+
+// redactFixtureArgs replaces --wtoken and --wrtoken values in args with the
+// placeholder token the e2e tests use.
+func redactFixtureArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		switch {
+		case arg == "--wtoken" || arg == "--wrtoken":
+			out = append(out, arg, "token")
+			idx++
+		case strings.HasPrefix(arg, "--wtoken=") || strings.HasPrefix(arg, "--wrtoken="):
+			flag, _, _ := strings.Cut(arg, "=")
+			out = append(out, flag, "token")
+		default:
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// fixtureTestArgs returns the redacted args with a placeholder --wtoken added
+// when the run had none, so commands that need auth pass the check.
+func fixtureTestArgs(args []string) []string {
+	out := redactFixtureArgs(args)
+	if !slices.Contains(out, "--wtoken") {
+		out = append(out, "--wtoken", "token")
+	}
+	return out
+}
+
+// fixtureCommandWords returns the leading command words of args, before the
+// first flag.
+func fixtureCommandWords(args []string) []string {
+	words := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+	}
+	return words
+}
+
+// fixtureSlug lowercases value and keeps letters and digits, joined by dashes.
+func fixtureSlug(value string) string {
+	fields := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(fields, "-")
+}
+
+// fixtureTestName turns a slug such as search-venues into SearchVenues.
+func fixtureTestName(slug string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(slug, "-") {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+// recordingWoltAPI writes a fixture for each search made while recording, the
+// way the live client's recording transport would.
+type recordingWoltAPI struct {
+	testWoltAPI
+	dir string
+}
+
+func (m *recordingWoltAPI) SetFixtureRecording(dir string) {
+	m.dir = dir
+}
+
+func (m *recordingWoltAPI) EndpointFamily(string) string {
+	return "search"
+}
+
+func (m *recordingWoltAPI) Search(context.Context, domain.Location, string) (map[string]any, error) {
+	if m.dir != "" {
+		raw, _ := json.Marshal(woltgateway.Fixture{
+			Method: "POST",
+			URL:    "https://restaurant-api.wolt.com/v1/pages/search?lat=60.123456&lon=24.987654",
+			Status: 200,
+			Body:   json.RawMessage(`{"sections":[],"user":{"email":"aino.virtanen@example.com"}}`),
+		})
+		_ = os.WriteFile(filepath.Join(m.dir, "POST_restaurant-api.wolt.com_v1_pages_search.json"), raw, 0o644)
+	}
+	return map[string]any{"sections": []any{}}, nil
+}
+
+func TestDebugFixtureWritesAnonymizedBundleAndSkeleton(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	api := &recordingWoltAPI{}
+	deps := Dependencies{
+		Wolt:     api,
+		Profiles: &testProfiles{profile: domain.Profile{Name: "default", Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Config:   &testConfigManager{},
+		Version:  "1.1.1",
+	}
+	out := filepath.Join(t.TempDir(), "bundle")
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	args := []string{"debug", "fixture", "--out", out, "--seed", "s", "--format", "json", "search", "items", "--query", "sushi", "--lat", "60.1", "--lon", "24.9", "--wtoken=secret"}
+	if code := Execute(context.Background(), args, deps, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", code, stdout.String(), stderr.String())
+	}
+	if strings.Contains(stdout.String(), "secret") {
+		t.Fatalf("expected the token to be redacted from the output:\n%s", stdout.String())
+	}
+	if api.dir != "" {
+		t.Fatalf("expected recording to be turned off after the run")
+	}
+	var env map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, stdout.String())
+	}
+	data := asMap(env["data"])
+	if asString(data["name"]) != "search-items" || asInt(data["exit_code"]) != 0 || len(asSlice(data["fixtures"])) != 1 {
+		t.Fatalf("unexpected bundle data: %#v", data)
+	}
+
+	fixture, err := os.ReadFile(filepath.Join(out, "POST_restaurant-api.wolt.com_v1_pages_search.json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if strings.Contains(string(fixture), "aino.virtanen") {
+		t.Fatalf("expected the email to be anonymized, got %s", fixture)
+	}
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatalf("read bundle: %v", err)
+	}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(out, entry.Name()))
+		if err != nil {
+			t.Fatalf("read %s: %v", entry.Name(), err)
+		}
+		for _, coordinate := range []string{"60.123456", "24.987654"} {
+			if strings.Contains(string(content), coordinate) || strings.Contains(stdout.String(), coordinate) {
+				t.Fatalf("expected coordinate %s to be jittered in %s and the summary, got:\n%s\n%s", coordinate, entry.Name(), content, stdout.String())
+			}
+		}
+	}
+	command, err := os.ReadFile(filepath.Join(out, "command.json"))
+	if err != nil || strings.Contains(string(command), "secret") {
+		t.Fatalf("expected command.json without the token, got %s err=%v", command, err)
+	}
+	skeleton, err := os.ReadFile(filepath.Join(out, "fixture_test.go"))
+	if err != nil {
+		t.Fatalf("read skeleton: %v", err)
+	}
+	for _, want := range []string{"func TestFixtureSearchItems(", "searchFunc: func(", `"--wtoken", "token"`} {
+		if !strings.Contains(string(skeleton), want) {
+			t.Fatalf("expected skeleton to contain %q:\n%s", want, skeleton)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "output.json")); err != nil {
+		t.Fatalf("expected output.json: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDebugParseReportFlagsUnresolvedItemFields(t *testing.T) {
+	payload := map[string]any{
+		"categories": []any{
+			map[string]any{"slug": "dairy", "name": "Dairy", "item_ids": []any{"milk", "cheese"}},
+		},
+		"items": []any{
+			map[string]any{"id": "milk", "name": "Milk", "price": 199, "currency": "EUR"},
+			map[string]any{"id": "cheese", "name": "Cheese", "is_sold_out": false},
+		},
+	}
+
+	data, warnings := buildDebugParseReport("assortment", payload)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	extracted := asMap(data["extracted"])
+	if asInt(extracted["items"]) != 2 || asInt(extracted["categories"]) != 1 {
+		t.Fatalf("unexpected extracted counts: %#v", extracted)
+	}
+
+	unresolved := []string{}
+	for _, field := range asSlice(data["unresolved_fields"]) {
+		unresolved = append(unresolved, asString(field))
+	}
+	if !slices.Equal(unresolved, []string{"base_price.amount", "base_price.currency"}) {
+		t.Fatalf("unexpected unresolved fields: %v", unresolved)
+	}
+	for _, value := range asSlice(data["fields"]) {
+		row := asMap(value)
+		if asString(row["field"]) != "base_price.amount" {
+			continue
+		}
+		examples := asSlice(row["examples"])
+		if asInt(row["missing"]) != 1 || len(examples) != 1 || asString(examples[0]) != "cheese" {
+			t.Fatalf("unexpected base_price.amount row: %#v", row)
+		}
+	}
+}
+
+func TestDebugParseReportWarnsWhenFrontPageHasNoSections(t *testing.T) {
+	_, warnings := buildDebugParseReport("front", map[string]any{"page_title": "Discovery"})
+	if !slices.Contains(warnings, "payload has no sections key") {
+		t.Fatalf("expected missing sections warning, got %v", warnings)
+	}
+}
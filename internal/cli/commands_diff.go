@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// diffKeyFields are tried in order when --key is not given; the first one
+// every row has keys the rows.
+var diffKeyFields = []string{"id", "venue_id", "item_id", "order_id", "slug"}
+
+func newDiffCommand(_ Dependencies) *cobra.Command {
+	var flags globalFlags
+	var path string
+	var key string
+
+	cmd := &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "Compare two saved JSON envelopes row by row.",
+		Long: "Compare two saved JSON envelopes of the same command row by row.\n\n" +
+			"--path selects the rows to compare (default data). An array is keyed by --key, or by the first of " +
+			"id, venue_id, item_id, order_id, and slug that every row has; an object is keyed by its field names. " +
+			"Rows only in the new file are added, rows only in the old file are removed, and rows in both with " +
+			"different values are changed, listed per dotted field. No request is sent.",
+		Example: "wolt diff feed-monday.json feed-tuesday.json --path data.sections.0.items\n" +
+			"wolt diff menu-old.json menu-new.json --path data.items --key item_id --format json --expect 'changed_count==0'",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			segments := expectPath(fallbackString(strings.TrimSpace(path), "data"))
+
+			sides := make([]diffSide, 0, 2)
+			for _, file := range args {
+				side, err := readDiffSide(file, segments)
+				if err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+				sides = append(sides, side)
+			}
+
+			warnings := []string{}
+			rowKey := strings.TrimSpace(key)
+			oldRows, newRows := sides[0].rows, sides[1].rows
+			if sides[0].array || sides[1].array {
+				if !sides[0].array || !sides[1].array {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+						fmt.Sprintf("%s is an array in one file and an object in the other", strings.Join(segments, ".")))
+				}
+				if rowKey == "" {
+					rowKey = detectDiffKey(sides[0].list, sides[1].list)
+				}
+				if rowKey == "" {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+						"no common id field in the rows; pass --key")
+				}
+				oldSkipped, newSkipped := 0, 0
+				oldRows, oldSkipped = keyDiffRows(sides[0].list, rowKey, &warnings, args[0])
+				newRows, newSkipped = keyDiffRows(sides[1].list, rowKey, &warnings, args[1])
+				if skipped := oldSkipped + newSkipped; skipped > 0 {
+					warnings = append(warnings, fmt.Sprintf("%d row(s) without %s were skipped", skipped, rowKey))
+				}
+			} else if rowKey != "" {
+				warnings = append(warnings, "--key is ignored; "+strings.Join(segments, ".")+" is an object keyed by field name")
+				rowKey = ""
+			}
+
+			data := buildDiffData(oldRows, newRows)
+			data["path"] = strings.Join(segments, ".")
+			data["key"] = emptyToNil(rowKey)
+			data["old"] = sides[0].summary(args[0])
+			data["new"] = sides[1].summary(args[1])
+
+			switch format {
+			case output.FormatTable:
+				return writeTable(cmd, buildDiffTable(data), flags.Output)
+			case output.FormatMarkdown:
+				return writeMarkdownReport(cmd, "Wolt diff", buildDiffTable(data), warnings, flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	cmd.Flags().StringVar(&path, "path", "data", "Dotted path of the rows to compare, for example data.items.")
+	cmd.Flags().StringVar(&key, "key", "", "Row field that identifies a row across both files (default: auto-detect).")
+	enableMarkdown(cmd)
+	return cmd
+}
+
+// diffSide is one envelope with the value found at --path.
+type diffSide struct {
+	meta  map[string]any
+	array bool
+	list  []any
+	rows  map[string]any
+}
+
+func readDiffSide(file string, segments []string) (diffSide, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return diffSide{}, fmt.Errorf("read %s: %w", file, err)
+	}
+	var document map[string]any
+	if err := json.Unmarshal(raw, &document); err != nil || document == nil {
+		return diffSide{}, fmt.Errorf("%s is not a JSON envelope", file)
+	}
+	if _, ok := document["data"]; !ok {
+		return diffSide{}, fmt.Errorf("%s is not a JSON envelope (no data field)", file)
+	}
+	side := diffSide{meta: asMap(document["meta"])}
+	value, found := lookupExpectPath(document, segments)
+	if !found {
+		return diffSide{}, fmt.Errorf("%s has no %s", file, strings.Join(segments, "."))
+	}
+	switch typed := value.(type) {
+	case []any:
+		side.array = true
+		side.list = typed
+	case map[string]any:
+		side.rows = typed
+	case nil:
+		side.rows = map[string]any{}
+	default:
+		return diffSide{}, fmt.Errorf("%s in %s is neither an array nor an object", strings.Join(segments, "."), file)
+	}
+	return side, nil
+}
+
+func (s diffSide) summary(file string) map[string]any {
+	count := len(s.rows)
+	if s.array {
+		count = len(s.list)
+	}
+	summary := map[string]any{"file": file, "count": count}
+	for _, field := range []string{"request_id", "generated_at", "data_digest"} {
+		summary[field] = s.meta[field]
+	}
+	return summary
+}
+
+func detectDiffKey(lists ...[]any) string {
+	for _, field := range diffKeyFields {
+		found := false
+		everyRow := true
+		for _, list := range lists {
+			for _, value := range list {
+				found = true
+				if row := asMap(value); row == nil || diffRowKey(row, field) == "" {
+					everyRow = false
+				}
+			}
+		}
+		if found && everyRow {
+			return field
+		}
+	}
+	return ""
+}
+
+// keyDiffRows indexes rows by key. Rows without the key are counted and
+// left out; a repeated key keeps the last row and warns.
+func keyDiffRows(list []any, key string, warnings *[]string, file string) (map[string]any, int) {
+	rows := make(map[string]any, len(list))
+	skipped := 0
+	for _, value := range list {
+		row := asMap(value)
+		id := ""
+		if row != nil {
+			id = diffRowKey(row, key)
+		}
+		if id == "" {
+			skipped++
+			continue
+		}
+		if _, ok := rows[id]; ok {
+			*warnings = append(*warnings, fmt.Sprintf("%s repeats %s %q; the last row is compared", file, key, id))
+		}
+		rows[id] = row
+	}
+	return rows, skipped
+}
+
+func diffRowKey(row map[string]any, key string) string {
+	switch value := row[key].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case float64:
+		return formatExpectValue(value)
+	}
+	return ""
+}
+
+func buildDiffData(oldRows map[string]any, newRows map[string]any) map[string]any {
+	added := []any{}
+	removed := []any{}
+	changed := []any{}
+	unchanged := 0
+	for _, id := range sortedDiffKeys(newRows) {
+		if _, ok := oldRows[id]; !ok {
+			added = append(added, map[string]any{"key": id, "row": newRows[id]})
+		}
+	}
+	for _, id := range sortedDiffKeys(oldRows) {
+		newRow, ok := newRows[id]
+		if !ok {
+			removed = append(removed, map[string]any{"key": id, "row": oldRows[id]})
+			continue
+		}
+		changes := []any{}
+		diffValues("", oldRows[id], newRow, &changes)
+		if len(changes) == 0 {
+			unchanged++
+			continue
+		}
+		changed = append(changed, map[string]any{"key": id, "changes": changes})
+	}
+	return map[string]any{
+		"added":           added,
+		"removed":         removed,
+		"changed":         changed,
+		"added_count":     len(added),
+		"removed_count":   len(removed),
+		"changed_count":   len(changed),
+		"unchanged_count": unchanged,
+	}
+}
+
+// diffValues lists the dotted fields that differ. Objects are compared
+// field by field; arrays and scalars are compared as a whole.
+func diffValues(prefix string, oldValue any, newValue any, changes *[]any) {
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+	if oldIsMap && newIsMap {
+		fields := map[string]any{}
+		for field := range oldMap {
+			fields[field] = nil
+		}
+		for field := range newMap {
+			fields[field] = nil
+		}
+		for _, field := range sortedDiffKeys(fields) {
+			path := field
+			if prefix != "" {
+				path = prefix + "." + field
+			}
+			diffValues(path, oldMap[field], newMap[field], changes)
+		}
+		return
+	}
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	*changes = append(*changes, map[string]any{"field": fallbackString(prefix, "."), "old": oldValue, "new": newValue})
+}
+
+func sortedDiffKeys(rows map[string]any) []string {
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func buildDiffTable(data map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(data["added"]) {
+		entry := asMap(value)
+		rows = append(rows, []string{"added", asString(entry["key"]), "-", "-", diffRowLabel(entry["row"])})
+	}
+	for _, value := range asSlice(data["removed"]) {
+		entry := asMap(value)
+		rows = append(rows, []string{"removed", asString(entry["key"]), "-", diffRowLabel(entry["row"]), "-"})
+	}
+	for _, value := range asSlice(data["changed"]) {
+		entry := asMap(value)
+		for _, changeValue := range asSlice(entry["changes"]) {
+			change := asMap(changeValue)
+			rows = append(rows, []string{
+				"changed",
+				asString(entry["key"]),
+				asString(change["field"]),
+				formatDiffCell(change["old"]),
+				formatDiffCell(change["new"]),
+			})
+		}
+	}
+	return output.RenderTable(
+		fmt.Sprintf("Diff %s: +%d -%d ~%d (%d unchanged)", asString(data["path"]),
+			asInt(data["added_count"]), asInt(data["removed_count"]), asInt(data["changed_count"]), asInt(data["unchanged_count"])),
+		[]string{"Change", "Key", "Field", "Old", "New"},
+		rows,
+	)
+}
+
+// diffRowLabel names an added or removed row by its name or title.
+func diffRowLabel(value any) string {
+	row := asMap(value)
+	for _, field := range []string{"name", "title"} {
+		if label := asString(row[field]); label != "" {
+			return label
+		}
+	}
+	return formatDiffCell(value)
+}
+
+func formatDiffCell(value any) string {
+	if value == nil {
+		return "-"
+	}
+	return formatExpectValue(value)
+}
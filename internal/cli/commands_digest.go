@@ -0,0 +1,518 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// digestHistoryMaxPages bounds the order history walk for long --since windows.
+	digestHistoryMaxPages = 10
+	// digestFavoriteLimit caps the venue pages fetched for favourite promotions.
+	digestFavoriteLimit = 20
+	// digestCreditHorizon is how far ahead a balance expiry counts as expiring.
+	digestCreditHorizon = 30 * 24 * time.Hour
+)
+
+var digestLookbackPattern = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+func newDigestCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var since string
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Summarize recent orders, new venues, favourite discounts, and expiring credits.",
+		Long: "Summarize recent account activity in one report.\n\n" +
+			"The digest covers orders paid since --since with spend per currency (rejected, cancelled, and refunded\n" +
+			"orders are listed but not counted as spend), venues near the profile address first seen since then\n" +
+			"according to the local venue map, promotions at favourite venues, and gift card, credit, and benefit\n" +
+			"balances expiring within 30 days. A section whose requests fail is left empty and the report is marked\n" +
+			"partial. --format markdown renders the report as GitHub-flavored markdown.",
+		Example: "wolt digest\n" +
+			"wolt digest --since 14d --format json\n" +
+			"wolt digest --since 2026-02-01 --format markdown --output digest.md",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			now := deps.now()
+			start, err := parseDigestSince(since, now)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+
+			data := map[string]any{
+				"since": start.Format(time.RFC3339),
+				"until": now.Format(time.RFC3339),
+			}
+			orders, warnings, err := loadDigestOrders(cmd, deps, flags, &auth, start)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			for key, value := range orders {
+				data[key] = value
+			}
+			newVenues, venueWarnings := loadDigestNewVenues(cmd, deps, location, start)
+			data["new_venues"] = newVenues
+			warnings = append(warnings, venueWarnings...)
+			discounts, discountWarnings, err := loadDigestFavoriteDiscounts(cmd, deps, flags, &auth, location)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			data["favorite_discounts"] = discounts
+			warnings = append(warnings, discountWarnings...)
+			credits, creditWarnings, err := loadDigestExpiringCredits(cmd, deps, flags, &auth, now)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			data["expiring_credits"] = credits
+			warnings = append(warnings, creditWarnings...)
+
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
+			switch format {
+			case output.FormatTable:
+				return writeTable(cmd, renderDigestTables(data), flags.Output)
+			case output.FormatMarkdown:
+				return output.WriteOutput(cmd.OutOrStdout(), output.RenderMarkdown("Wolt digest", buildDigestTables(data), warnings), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "7d", "Start of the digest: a lookback such as 36h, 7d, or 2w, or a YYYY-MM-DD date")
+	addStrictFlag(cmd, &strict)
+	addGlobalFlags(cmd, &flags)
+	enableMarkdown(cmd)
+	return cmd
+}
+
+// parseDigestSince reads --since as a lookback in hours, days, or weeks from
+// now, or as the local start of a YYYY-MM-DD date.
+func parseDigestSince(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if match := digestLookbackPattern.FindStringSubmatch(value); match != nil {
+		count, err := strconv.Atoi(match[1])
+		if err != nil || count < 1 {
+			return time.Time{}, fmt.Errorf("--since must be a positive lookback such as 7d")
+		}
+		switch match[2] {
+		case "h":
+			return now.Add(-time.Duration(count) * time.Hour), nil
+		case "w":
+			return now.AddDate(0, 0, -7*count), nil
+		}
+		return now.AddDate(0, 0, -count), nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--since must be a lookback such as 36h, 7d, or 2w, or a YYYY-MM-DD date")
+	}
+	if parsed.After(now) {
+		return time.Time{}, fmt.Errorf("--since must not be in the future")
+	}
+	return parsed, nil
+}
+
+// loadDigestOrders lists orders paid since start, newest first, with spend
+// summed per currency. Order history failures leave the section empty; only
+// an authentication failure is returned.
+func loadDigestOrders(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	start time.Time,
+) (map[string]any, []string, error) {
+	orders := []any{}
+	spent := map[string]int{}
+	warnings := []string{}
+	section := func() map[string]any {
+		currencies := make([]string, 0, len(spent))
+		for currency := range spent {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		spend := make([]any, 0, len(currencies))
+		for _, currency := range currencies {
+			amount := budgetAmountData(spent[currency], currency)
+			amount["currency"] = currency
+			spend = append(spend, amount)
+		}
+		return map[string]any{"orders": orders, "order_count": len(orders), "spend": spend}
+	}
+
+	pageToken := ""
+	for pages := 1; ; pages++ {
+		payload, authWarnings, err := invokeWithAuthAutoRefresh(
+			cmd.Context(),
+			deps,
+			flags,
+			auth,
+			func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+				return deps.Wolt.OrderHistory(
+					cmd.Context(),
+					authCtx,
+					woltgateway.OrderHistoryOptions{Limit: profileOrdersMaxLimit, PageToken: pageToken},
+				)
+			},
+		)
+		warnings = append(warnings, authWarnings...)
+		if err != nil {
+			if isUnauthorizedUpstream(err) {
+				return nil, warnings, err
+			}
+			recordPartialFailure(cmd.Context(), "order history", err)
+			return section(), warnings, nil
+		}
+		reachedStart := false
+		for _, value := range extractOrderHistoryOrders(payload, "") {
+			row := asMap(value)
+			paidAt := time.UnixMilli(int64(asInt(row["payment_time_ts"])))
+			if paidAt.Before(start) {
+				reachedStart = true
+				continue
+			}
+			orders = append(orders, map[string]any{
+				"purchase_id":  row["purchase_id"],
+				"received_at":  row["received_at"],
+				"status":       row["status"],
+				"venue_name":   row["venue_name"],
+				"total_amount": row["total_amount"],
+			})
+			switch strings.ToLower(asString(row["status"])) {
+			case "rejected", "cancelled", "canceled", "refunded":
+				continue
+			}
+			total := asString(row["total_amount"])
+			currency := inferCurrency(total)
+			amount, ok := orderTotalMinor(total, currency)
+			if currency == "" || !ok {
+				warnings = append(warnings, fmt.Sprintf("order %s has no readable total and is left out of the spend", asString(row["purchase_id"])))
+				continue
+			}
+			spent[currency] += amount
+		}
+		pageToken = strings.TrimSpace(asString(payload["next_page_token"]))
+		if pageToken == "" || reachedStart {
+			return section(), warnings, nil
+		}
+		if pages >= digestHistoryMaxPages {
+			warnings = append(warnings, fmt.Sprintf("digest counts only the newest %d order history pages", pages))
+			return section(), warnings, nil
+		}
+	}
+}
+
+// loadDigestNewVenues lists venues in the discovery feed at location whose
+// first sighting in the local venue map is at or after start. Venues already
+// known when first-seen tracking started never count as new.
+func loadDigestNewVenues(cmd *cobra.Command, deps Dependencies, location domain.Location, start time.Time) ([]any, []string) {
+	rows := []any{}
+	frontPage, err := deps.Wolt.FrontPage(cmd.Context(), location)
+	if err != nil {
+		recordPartialFailure(cmd.Context(), "discovery feed", err)
+		return rows, nil
+	}
+	sections, err := extractDiscoverSectionsFromFrontPage(frontPage)
+	if err != nil {
+		if sections, err = deps.Wolt.Sections(cmd.Context(), location); err != nil {
+			recordPartialFailure(cmd.Context(), "discovery feed", err)
+			return rows, nil
+		}
+		recordFallback(cmd.Context(), "front_page_sections")
+	}
+	city := fallbackString(asString(asMap(frontPage["city_data"])["name"]), asString(frontPage["city"]))
+	feedItems := []domain.Item{}
+	for _, section := range sections {
+		feedItems = append(feedItems, section.Items...)
+	}
+	rememberItemVenues(deps, feedItems, city)
+
+	file, known, err := loadKnownVenues(deps)
+	if file == nil {
+		return rows, []string{fmt.Sprintf("new venues need the local venue map: %v", err)}
+	}
+	warnings := []string{}
+	tracked, ok := knownVenuesSince(file, deps.now())
+	if !ok {
+		tracked = deps.now()
+	}
+	if tracked.After(start) {
+		warnings = append(warnings, fmt.Sprintf(
+			"first-seen tracking started %s; venues listed before then are not reported as new",
+			tracked.Local().Format("2006-01-02 15:04"),
+		))
+	}
+	seen := map[string]bool{}
+	for _, value := range discoverFeedVenueRows(observability.BuildDiscoveryFeed(sections, city, nil, false)) {
+		row := asMap(value)
+		venueID := strings.ToLower(strings.TrimSpace(asString(row["venue_id"])))
+		venue, found := known[venueID]
+		if !found || seen[venueID] || !venue.FirstSeen.After(tracked) || venue.FirstSeen.Before(start) {
+			continue
+		}
+		seen[venueID] = true
+		rows = append(rows, map[string]any{
+			"venue_id":          row["venue_id"],
+			"slug":              row["slug"],
+			"name":              row["name"],
+			"rating":            row["rating"],
+			"delivery_estimate": row["delivery_estimate"],
+			"first_seen":        venue.FirstSeen.Format(time.RFC3339),
+		})
+	}
+	return rows, warnings
+}
+
+// loadDigestFavoriteDiscounts lists favourite venues whose venue page carries
+// promotions. Up to digestFavoriteLimit favourites are checked, none under
+// --low-bandwidth.
+func loadDigestFavoriteDiscounts(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	location domain.Location,
+) ([]any, []string, error) {
+	rows := []any{}
+	payload, warnings, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.FavoriteVenues(cmd.Context(), location, authCtx)
+		},
+	)
+	if err != nil {
+		if isUnauthorizedUpstream(err) {
+			return nil, warnings, err
+		}
+		recordPartialFailure(cmd.Context(), "favorites", err)
+		return rows, warnings, nil
+	}
+	favorites := extractFavoriteVenues(payload)
+	if lowBandwidth(cmd) {
+		return rows, append(warnings, "low-bandwidth mode skips favourite promotion lookups"), nil
+	}
+	if len(favorites) > digestFavoriteLimit {
+		warnings = append(warnings, fmt.Sprintf("promotions checked for the first %d of %d favourites", digestFavoriteLimit, len(favorites)))
+		favorites = favorites[:digestFavoriteLimit]
+	}
+	lastRequestAt := time.Time{}
+	for _, value := range favorites {
+		favorite := asMap(value)
+		slug := strings.TrimSpace(asString(favorite["slug"]))
+		if slug == "" {
+			continue
+		}
+		page, err := fetchDynamicVenuePayloadWithRetry(cmd.Context(), deps, slug, &location, *auth, &lastRequestAt)
+		if err != nil {
+			recordPartialFailure(cmd.Context(), "favourite promotions", err)
+			continue
+		}
+		labels := observability.ExtractVenuePromotionLabels(page)
+		if len(labels) == 0 {
+			continue
+		}
+		rows = append(rows, map[string]any{
+			"venue_id":   favorite["venue_id"],
+			"slug":       slug,
+			"name":       favorite["name"],
+			"promotions": labels,
+		})
+	}
+	return rows, warnings, nil
+}
+
+// loadDigestExpiringCredits lists stored-value payment methods whose balance
+// expires between now and digestCreditHorizon from now, soonest first.
+func loadDigestExpiringCredits(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	now time.Time,
+) ([]any, []string, error) {
+	rows := []any{}
+	result, warnings, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (profilePaymentsPayload, error) {
+			return fetchProfilePaymentsPayload(cmd.Context(), deps, authCtx)
+		},
+	)
+	if err != nil {
+		if isUnauthorizedUpstream(err) {
+			return nil, warnings, err
+		}
+		recordPartialFailure(cmd.Context(), "payment balances", err)
+		return rows, warnings, nil
+	}
+	warnings = append(warnings, result.Warnings...)
+	methods := extractPaymentMethods(result.Payload, false)
+	warnings = append(warnings, attachPaymentBalances(cmd.Context(), deps, flags, auth, result.Payload, methods)...)
+
+	type expiring struct {
+		row     map[string]any
+		expires time.Time
+	}
+	found := []expiring{}
+	for _, value := range methods {
+		method := asMap(value)
+		balance := asMap(method["balance"])
+		expires, ok := parseCreditExpiry(asString(balance["expires_at"]), now.Location())
+		if balance == nil || !ok || expires.Before(now) || expires.Sub(now) > digestCreditHorizon {
+			continue
+		}
+		found = append(found, expiring{
+			row: map[string]any{
+				"method_id":        method["method_id"],
+				"type":             method["type"],
+				"label":            method["label"],
+				"amount":           balance["amount"],
+				"currency":         balance["currency"],
+				"formatted_amount": balance["formatted_amount"],
+				"expires_at":       balance["expires_at"],
+			},
+			expires: expires,
+		})
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].expires.Before(found[j].expires) })
+	for _, entry := range found {
+		rows = append(rows, entry.row)
+	}
+	return rows, warnings, nil
+}
+
+// parseCreditExpiry reads an RFC 3339 expiry, or a YYYY-MM-DD date that stays
+// valid through the end of that day.
+func parseCreditExpiry(value string, location *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, true
+	}
+	if parsed, err := time.ParseInLocation("2006-01-02", value, location); err == nil {
+		return parsed.AddDate(0, 0, 1), true
+	}
+	return time.Time{}, false
+}
+
+// buildDigestTables lays the digest out as a summary and one table per
+// section; sections without rows have no rows.
+func buildDigestTables(data map[string]any) []output.Table {
+	spend := []string{}
+	for _, value := range asSlice(data["spend"]) {
+		spend = append(spend, asString(asMap(value)["formatted_amount"]))
+	}
+	summary := output.Table{
+		Title:   "Summary",
+		Headers: []string{"Field", "Value"},
+		Rows: [][]string{
+			{"Since", asString(data["since"])},
+			{"Until", asString(data["until"])},
+			{"Orders", strconv.Itoa(asInt(data["order_count"]))},
+			{"Spend", fallbackString(strings.Join(spend, ", "), "-")},
+			{"New venues", strconv.Itoa(len(asSlice(data["new_venues"])))},
+			{"Favourite discounts", strconv.Itoa(len(asSlice(data["favorite_discounts"])))},
+			{"Expiring credits", strconv.Itoa(len(asSlice(data["expiring_credits"])))},
+		},
+	}
+	if asBool(data["partial"]) {
+		summary.Rows = append(summary.Rows, []string{"Partial", "yes"})
+	}
+
+	orders := output.Table{Title: "Orders", Headers: []string{"Received", "Venue", "Status", "Total"}}
+	for _, value := range asSlice(data["orders"]) {
+		row := asMap(value)
+		orders.Rows = append(orders.Rows, []string{
+			fallbackString(asString(row["received_at"]), "-"),
+			fallbackString(asString(row["venue_name"]), "-"),
+			fallbackString(asString(row["status"]), "-"),
+			fallbackString(asString(row["total_amount"]), "-"),
+		})
+	}
+	venues := output.Table{Title: "New venues nearby", Headers: []string{"Venue", "Slug", "Rating", "First seen"}}
+	for _, value := range asSlice(data["new_venues"]) {
+		row := asMap(value)
+		venues.Rows = append(venues.Rows, []string{
+			fallbackString(asString(row["name"]), "-"),
+			fallbackString(asString(row["slug"]), "-"),
+			fallbackString(asString(row["rating"]), "-"),
+			fallbackString(asString(row["first_seen"]), "-"),
+		})
+	}
+	discounts := output.Table{Title: "Discounts at favourites", Headers: []string{"Venue", "Slug", "Promotions"}}
+	for _, value := range asSlice(data["favorite_discounts"]) {
+		row := asMap(value)
+		discounts.Rows = append(discounts.Rows, []string{
+			fallbackString(asString(row["name"]), "-"),
+			fallbackString(asString(row["slug"]), "-"),
+			fallbackString(stringsJoin(asSlice(row["promotions"]), ", "), "-"),
+		})
+	}
+	credits := output.Table{Title: "Expiring credits", Headers: []string{"Method", "Type", "Balance", "Expires"}}
+	for _, value := range asSlice(data["expiring_credits"]) {
+		row := asMap(value)
+		credits.Rows = append(credits.Rows, []string{
+			fallbackString(asString(row["label"]), "-"),
+			fallbackString(asString(row["type"]), "-"),
+			fallbackString(asString(row["formatted_amount"]), "-"),
+			fallbackString(asString(row["expires_at"]), "-"),
+		})
+	}
+	return []output.Table{summary, orders, venues, discounts, credits}
+}
+
+func renderDigestTables(data map[string]any) string {
+	tables := buildDigestTables(data)
+	for idx := range tables {
+		if len(tables[idx].Rows) == 0 {
+			row := make([]string, len(tables[idx].Headers))
+			for col := range row {
+				row[col] = "-"
+			}
+			tables[idx].Rows = [][]string{row}
+		}
+	}
+	return output.RenderTables(tables)
+}
@@ -3,11 +3,12 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
 	"github.com/mekedron/wolt-cli/internal/service/observability"
 	"github.com/mekedron/wolt-cli/internal/service/output"
 	"github.com/spf13/cobra"
@@ -21,6 +22,7 @@ const (
 	discoverFeedSortDeliveryFee discoverFeedSort = "delivery_fee"
 	discoverFeedSortDelivery    discoverFeedSort = "delivery_time"
 	discoverFeedSortName        discoverFeedSort = "name"
+	discoverFeedSortMyRating    discoverFeedSort = "my_rating"
 )
 
 func newDiscoverCommand(deps Dependencies) *cobra.Command {
@@ -30,9 +32,26 @@ func newDiscoverCommand(deps Dependencies) *cobra.Command {
 	}
 	discover.AddCommand(newDiscoverFeedCommand(deps))
 	discover.AddCommand(newDiscoverCategoriesCommand(deps))
+	discover.AddCommand(newDiscoverSectionsCommand(deps))
+	discover.AddCommand(newDiscoverMealCommand(deps, "breakfast", "Show venues for breakfast, open now or when breakfast starts."))
+	discover.AddCommand(newDiscoverMealCommand(deps, "lunch", "Show venues for lunch, open now or when lunch starts."))
+	discover.AddCommand(newDiscoverMealCommand(deps, "dinner", "Show venues for dinner, open now or when dinner starts."))
+	discover.AddCommand(newDiscoverMealCommand(deps, mealNow, "Show venues for the meal preset matching the local time."))
 	return discover
 }
 
+// newDiscoverMealCommand is discover feed with --meal fixed to meal.
+func newDiscoverMealCommand(deps Dependencies, meal string, short string) *cobra.Command {
+	cmd := newDiscoverFeedCommand(deps)
+	cmd.Use = meal
+	cmd.Short = short
+	mealFlag := cmd.Flags().Lookup("meal")
+	_ = mealFlag.Value.Set(meal)
+	mealFlag.DefValue = meal
+	_ = cmd.Flags().MarkHidden("meal")
+	return cmd
+}
+
 func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 	var lat float64
@@ -49,11 +68,22 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 	var promotionsOnly bool
 	var limit int
 	var limitSet bool
+	var noLimit bool
 	var offset int
 	var offsetSet bool
 	var page int
 	var pageSet bool
 	var fast bool
+	var enrichValue string
+	var stream bool
+	var maxRequests int
+	var strict bool
+	var pick rowPick
+	var mealValue string
+	var nowValue string
+	var exclude excludeFilters
+	var onlyNew bool
+	var newDays int
 
 	cmd := &cobra.Command{
 		Use:   "feed",
@@ -63,6 +93,61 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := validateMaxRequests(maxRequests); err != nil {
+				return err
+			}
+			enrichment, err := parseFeedEnrichment(enrichValue)
+			if err != nil {
+				return err
+			}
+			if !fast && !stream && !cmd.Flags().Changed("enrich") {
+				settings, _ := deps.Profiles.Find(cmd.Context(), flags.Profile)
+				if mode := strings.TrimSpace(settings.EnrichmentMode); mode != "" {
+					enrichment, err = parseFeedEnrichment(mode)
+					if err != nil {
+						return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("profile enrichment_mode: %v", err))
+					}
+				}
+			}
+			if fast {
+				if cmd.Flags().Changed("enrich") && !enrichment.skipped() {
+					return fmt.Errorf("--fast cannot be combined with --enrich %s", enrichment)
+				}
+				enrichment = feedEnrichment{mode: enrichModeFast}
+			}
+			if stream {
+				switch {
+				case format != output.FormatJSON:
+					return fmt.Errorf("--stream requires --format json")
+				case enrichment.skipped():
+					return fmt.Errorf("--stream cannot be combined with --fast or --enrich none")
+				case strict:
+					return fmt.Errorf("--stream cannot be combined with --strict")
+				case strings.TrimSpace(flags.Output) != "":
+					return fmt.Errorf("--stream cannot be combined with --output")
+				}
+			}
+			var meal *mealSelection
+			if strings.TrimSpace(mealValue) != "" {
+				at, err := parseVenueNow(nowValue, time.Local, deps.now())
+				if err != nil {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+				settings, _ := deps.Profiles.Find(cmd.Context(), flags.Profile)
+				selection, err := selectMealPreset(mealValue, settings.MealPresets, at)
+				if err != nil {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+				meal = &selection
+			} else if strings.TrimSpace(nowValue) != "" {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--now requires --meal")
+			}
+			if cmd.Flags().Changed("new-days") && !onlyNew {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--new-days requires --only-new-venues")
+			}
+			if newDays < 1 {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--new-days must be at least 1")
+			}
 
 			var latPtr *float64
 			var lonPtr *float64
@@ -102,6 +187,7 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 				if err != nil {
 					return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
 				}
+				recordFallback(cmd.Context(), "front_page_sections")
 				warnings = append(warnings, "front page sections missing; fallback endpoint used")
 			}
 
@@ -109,6 +195,19 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 			if city == "" {
 				city = asString(frontPage["city"])
 			}
+			feedItems := []domain.Item{}
+			for _, section := range sections {
+				feedItems = append(feedItems, section.Items...)
+			}
+			rememberItemVenues(deps, feedItems, city)
+			if exclude.active() {
+				var excluded excludeCounts
+				sections, excluded = exclude.excludeSections(sections)
+				warnings = append(warnings, exclude.warnings(excluded, "venue(s)")...)
+			}
+			if meal != nil {
+				sections = filterSectionsForMeal(sections, *meal)
+			}
 			sortMode, err := parseDiscoverFeedSort(sortValue)
 			if err != nil {
 				return err
@@ -127,6 +226,10 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
+			}
 			data := observability.BuildDiscoveryFeed(sections, city, nil, woltPlus)
 			if strings.TrimSpace(query) != "" {
 				filterDiscoverFeedByQuery(data, query)
@@ -142,27 +245,88 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 					PromotionsOnly:    promotionsOnly,
 				},
 			)
+			if onlyNew {
+				warnings = append(warnings, filterDiscoverFeedNewVenues(deps, data, newDays)...)
+			}
+			annotateFeeTrends(deps, discoverFeedVenueRows(data))
+			annotateVenueNotes(cmd.Context(), deps, flags.Profile, venueRowMaps(discoverFeedVenueRows(data))...)
+			annotateVenueRatings(cmd.Context(), deps, flags.Profile, venueRowMaps(discoverFeedVenueRows(data))...)
+			if !lowBandwidth(cmd) {
+				warnings = append(warnings, annotateBasketRows(cmd.Context(), deps, location, locationAuth, discoverFeedVenueRows(data))...)
+			}
+			if meal != nil {
+				data["meal"] = mealData(*meal)
+			}
+			if meal != nil && !meal.InWindow {
+				if err := checkRequestBudget(
+					cmd,
+					format,
+					profile,
+					flags.Locale,
+					flags.Output,
+					maxRequests,
+					len(discoverFeedVenueRows(data)),
+					"meal opening-hours lookup",
+					"--query <text>",
+					"--meal now",
+				); err != nil {
+					return err
+				}
+				warnings = append(warnings, filterDiscoverFeedOpenAt(cmd.Context(), deps, data, meal.OpensAt)...)
+			}
 			sortDiscoverFeedRows(data, sortMode)
 			data["sort"] = string(sortMode)
-			paginateDiscoveryFeedRows(data, limitPtr, resolvedOffset)
+			paginateDiscoveryFeedRows(data, pageLimit, resolvedOffset)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
 			if pageSet {
 				data["page"] = page
 			}
 
-			if fast {
-				data["enrichment_mode"] = "fast"
+			if err := exportSQLiteRows(cmd, deps, sqliteVenueExport, discoverFeedVenueRows(data)); err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_SQLITE_EXPORT_ERROR", err.Error())
+			}
+			if pick.enabled() {
+				return emitPickedRow(cmd, format, profile, flags.Locale, flags.Output, pick, discoverFeedVenueRows(data), venueRowPicker())
+			}
+			annotateVenueRowSources(discoverFeedVenueRows(data), flags.Verbose, format)
+			if enrichment.skipped() || lowBandwidth(cmd) {
+				data["enrichment_mode"] = enrichModeFast
 				warnings = append(warnings, "fast mode skips per-venue promotion and Wolt+ enrichment")
 			} else {
-				data["enrichment_mode"] = "full"
+				if err := checkRequestBudget(
+					cmd,
+					format,
+					profile,
+					flags.Locale,
+					flags.Output,
+					maxRequests,
+					estimateVenueEnrichmentRequests(enrichment.rows(discoverFeedVenueRows(data)), enrichment.promotions()),
+					"feed enrichment",
+					"--enrich none",
+					"--enrich top:<n>",
+					"--limit <n>",
+				); err != nil {
+					return err
+				}
 				promotionAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+				if stream {
+					return streamDiscoverFeed(cmd, deps, profile, flags.Locale, data, warnings, promotionAuth, enrichment)
+				}
+				data["enrichment_mode"] = enrichment.String()
 				enrichDiscoverFeedRowsWithDynamicPromotions(
 					cmd.Context(),
 					deps,
 					data,
 					nil,
 					promotionAuth,
+					enrichment,
+					nil,
 				)
 			}
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
 
 			if format == output.FormatTable {
 				return writeTable(cmd, buildDiscoveryFeedTable(data), flags.Output)
@@ -178,15 +342,30 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().Lookup("lon").NoOptDefVal = "0"
 	cmd.Flags().BoolVar(&woltPlus, "wolt-plus", false, "Only include Wolt+ venues in the feed.")
 	cmd.Flags().StringVar(&query, "query", "", "Filter venues by name or slug")
-	cmd.Flags().StringVar(&sortValue, "sort", string(discoverFeedSortRecommended), "Sort strategy: recommended, rating, delivery_fee, delivery_time, name")
+	cmd.Flags().StringVar(&sortValue, "sort", string(discoverFeedSortRecommended), "Sort strategy: recommended, rating, delivery_fee, delivery_time, name, my_rating")
 	cmd.Flags().Float64Var(&minRating, "min-rating", 0, "Minimum venue rating score (for example 8.5)")
 	cmd.Flags().IntVar(&maxDeliveryFee, "max-delivery-fee", 0, "Maximum delivery fee in minor units (for example 500 = EUR 5.00)")
 	cmd.Flags().BoolVar(&promotionsOnly, "promotions-only", false, "Only include venues with promotion labels")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned venues across sections")
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned venues across sections")
 	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
-	cmd.Flags().BoolVar(&fast, "fast", false, "Skip extra venue enrichment requests (faster, fewer discounts)")
+	addNoLimitFlag(cmd, &noLimit)
+	cmd.Flags().BoolVar(&fast, "fast", false, "Skip extra venue enrichment requests (faster, fewer discounts); same as --enrich none")
+	cmd.Flags().StringVar(&enrichValue, "enrich", "all", "Feed rows that get promotion and Wolt+ lookups: all, none, wolt-plus-only, or top:N (the first N rows); overrides the profile enrichment_mode")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Print the unenriched feed at once, then promotion and Wolt+ updates per venue, as NDJSON (requires --format json)")
+	cmd.Flags().StringVar(&mealValue, "meal", "", "Meal preset: breakfast, lunch, dinner, late, now, or a profile preset; keeps tagged venues open in its window")
+	cmd.Flags().StringVar(&nowValue, "now", "", "With --meal, local reference time instead of the current time (YYYY-MM-DDTHH:MM)")
+	cmd.Flags().BoolVar(&onlyNew, "only-new-venues", false, "Only include venues first seen by this CLI within --new-days days (tracked in the local venue map)")
+	cmd.Flags().IntVar(&newDays, "new-days", 14, "With --only-new-venues, how many days a venue counts as new")
+	addExcludeVenueFlag(cmd, &exclude)
+	addExcludeTagFlag(cmd, &exclude)
+	addExcludeSectionFlag(cmd, &exclude)
+	addNoAdsFlag(cmd, &exclude)
+	addMaxRequestsFlag(cmd, &maxRequests)
+	addStrictFlag(cmd, &strict)
+	addRowPickFlags(cmd, &pick, "venue slug")
 	addGlobalFlags(cmd, &flags)
+	enableSQLiteExport(cmd)
 
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		latSet = cmd.Flags().Changed("lat")
@@ -202,6 +381,32 @@ func newDiscoverFeedCommand(deps Dependencies) *cobra.Command {
 	return cmd
 }
 
+// streamDiscoverFeed writes the feed before enrichment and then one NDJSON
+// line per venue as its promotions and Wolt+ status resolve.
+func streamDiscoverFeed(
+	cmd *cobra.Command,
+	deps Dependencies,
+	profile string,
+	locale string,
+	data map[string]any,
+	warnings []string,
+	auth woltgateway.AuthContext,
+	enrichment feedEnrichment,
+) error {
+	data["enrichment_mode"] = "stream"
+	stream := newFeedStream(cmd.OutOrStdout(), jsonNaming(cmd))
+	if err := stream.feed(cmd, output.BuildEnvelope(profile, locale, data, warnings, nil)); err != nil {
+		return err
+	}
+	enrichDiscoverFeedRowsWithDynamicPromotions(cmd.Context(), deps, data, nil, auth, enrichment, stream.venue)
+	streamed := len(warnings)
+	warnings, _ = finishPartialRun(cmd, output.FormatJSON, profile, locale, "", false, false, data, warnings)
+	if err := stream.done(append([]string{}, warnings[streamed:]...), asBool(data["partial"])); err != nil {
+		return err
+	}
+	return checkExpectations(cmd, output.BuildEnvelope(profile, locale, data, warnings, nil))
+}
+
 func extractDiscoverSectionsFromFrontPage(page map[string]any) ([]domain.Section, error) {
 	sectionsRaw, ok := page["sections"]
 	if !ok {
@@ -215,6 +420,7 @@ func extractDiscoverSectionsFromFrontPage(page map[string]any) ([]domain.Section
 	if err := json.Unmarshal(encoded, &sections); err != nil {
 		return nil, fmt.Errorf("decode sections: %w", err)
 	}
+	domain.FillVenueCity(sections, asString(page["city"]))
 	return sections, nil
 }
 
@@ -294,10 +500,10 @@ func parseDiscoverFeedSort(raw string) (discoverFeedSort, error) {
 		return discoverFeedSortRecommended, nil
 	}
 	switch value {
-	case discoverFeedSortRecommended, discoverFeedSortRating, discoverFeedSortDeliveryFee, discoverFeedSortDelivery, discoverFeedSortName:
+	case discoverFeedSortRecommended, discoverFeedSortRating, discoverFeedSortDeliveryFee, discoverFeedSortDelivery, discoverFeedSortName, discoverFeedSortMyRating:
 		return value, nil
 	default:
-		return "", fmt.Errorf("invalid --sort value %q; expected one of: recommended, rating, delivery_fee, delivery_time, name", raw)
+		return "", fmt.Errorf("invalid --sort value %q; expected one of: recommended, rating, delivery_fee, delivery_time, name, my_rating", raw)
 	}
 }
 
@@ -365,23 +571,81 @@ func filterDiscoverFeedRows(data map[string]any, filters venueRowFilters) {
 	data["sections"] = filteredSections
 }
 
+// filterDiscoverFeedNewVenues keeps feed venues first seen within the last
+// days days according to the local venue map, and sets first_seen on them.
+// Venues already known when tracking started never count as new, so the first
+// run lists none.
+func filterDiscoverFeedNewVenues(deps Dependencies, data map[string]any, days int) []string {
+	file, known, err := loadKnownVenues(deps)
+	if file == nil {
+		return []string{fmt.Sprintf("--only-new-venues needs the local venue map: %v", err)}
+	}
+	warnings := []string{}
+	now := deps.now()
+	since, tracked := knownVenuesSince(file, now)
+	if !tracked {
+		since = now
+	}
+	if now.Sub(since) < time.Duration(days)*24*time.Hour {
+		warnings = append(warnings, fmt.Sprintf(
+			"first-seen tracking started %s; venues listed before then are not reported as new",
+			since.Local().Format("2006-01-02 15:04"),
+		))
+	}
+	cutoff := now.AddDate(0, 0, -days)
+	filteredSections := make([]any, 0, len(asSlice(data["sections"])))
+	for _, sectionValue := range asSlice(data["sections"]) {
+		section := asMap(sectionValue)
+		if section == nil {
+			continue
+		}
+		filteredItems := []any{}
+		for _, itemValue := range asSlice(section["items"]) {
+			item := asMap(itemValue)
+			venue, ok := known[strings.ToLower(strings.TrimSpace(asString(item["venue_id"])))]
+			if !ok || !venue.FirstSeen.After(since) || venue.FirstSeen.Before(cutoff) {
+				continue
+			}
+			item["first_seen"] = venue.FirstSeen.Format(time.RFC3339)
+			filteredItems = append(filteredItems, item)
+		}
+		if len(filteredItems) == 0 {
+			continue
+		}
+		sectionCopy := map[string]any{}
+		for k, v := range section {
+			sectionCopy[k] = v
+		}
+		sectionCopy["items"] = filteredItems
+		filteredSections = append(filteredSections, sectionCopy)
+	}
+	data["sections"] = filteredSections
+	data["new_within_days"] = days
+	return warnings
+}
+
 func sortDiscoverFeedRows(data map[string]any, sortMode discoverFeedSort) {
 	if data == nil || sortMode == discoverFeedSortRecommended {
 		return
 	}
-	less := func(left map[string]any, right map[string]any) bool {
-		switch sortMode {
-		case discoverFeedSortRating:
-			return discoverFeedRating(left) > discoverFeedRating(right)
-		case discoverFeedSortDeliveryFee:
-			return discoverFeedDeliveryFee(left) < discoverFeedDeliveryFee(right)
-		case discoverFeedSortDelivery:
-			return discoverFeedDeliveryEstimate(left) < discoverFeedDeliveryEstimate(right)
-		case discoverFeedSortName:
-			return strings.ToLower(strings.TrimSpace(asString(left["name"]))) < strings.ToLower(strings.TrimSpace(asString(right["name"])))
-		default:
-			return false
-		}
+	var key func(map[string]any) any
+	compare := compareIntSortKeys
+	switch sortMode {
+	case discoverFeedSortRating:
+		key = func(item map[string]any) any { return discoverFeedRating(item) }
+		compare = compareRatingSortKeys
+	case discoverFeedSortDeliveryFee:
+		key = func(item map[string]any) any { return discoverFeedDeliveryFee(item) }
+	case discoverFeedSortDelivery:
+		key = func(item map[string]any) any { return discoverFeedDeliveryEstimate(item) }
+	case discoverFeedSortName:
+		key = rowNameSortKey
+		compare = compareStringSortKeys
+	case discoverFeedSortMyRating:
+		key = myRatingSortKey
+		compare = compareRatingSortKeys
+	default:
+		return
 	}
 	for _, sectionValue := range asSlice(data["sections"]) {
 		section := asMap(sectionValue)
@@ -389,9 +653,7 @@ func sortDiscoverFeedRows(data map[string]any, sortMode discoverFeedSort) {
 			continue
 		}
 		items := asSlice(section["items"])
-		sort.SliceStable(items, func(i, j int) bool {
-			return less(asMap(items[i]), asMap(items[j]))
-		})
+		sortRowsByKey(items, "slug", key, compare)
 		section["items"] = items
 	}
 }
@@ -504,6 +766,83 @@ func newDiscoverCategoriesCommand(deps Dependencies) *cobra.Command {
 	return cmd
 }
 
+func newDiscoverSectionsCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var lat float64
+	var lon float64
+	var latSet bool
+	var lonSet bool
+
+	cmd := &cobra.Command{
+		Use:   "sections",
+		Short: "List discovery feed sections without their venues.",
+		Long: "List the sections of the discovery feed: name, title, subtitle, \"see all\" link, and item count.\n\n" +
+			"Reads the front page once and skips venue enrichment, so it is cheap enough for building menus " +
+			"before calling discover feed --exclude-section.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+
+			var latPtr *float64
+			var lonPtr *float64
+			if latSet {
+				latPtr = &lat
+			}
+			if lonSet {
+				lonPtr = &lon
+			}
+			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			location, profile, err := resolveLocation(
+				cmd.Context(),
+				deps,
+				latPtr,
+				lonPtr,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&locationAuth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+
+			sections, err := deps.Wolt.Sections(cmd.Context(), location)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			data := observability.BuildSectionList(sections)
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildSectionTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, []string{}, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for location lookup. Provide together with --lon.")
+	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for location lookup. Provide together with --lat.")
+	addGlobalFlags(cmd, &flags)
+	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
+		latSet = cmd.Flags().Changed("lat")
+		lonSet = cmd.Flags().Changed("lon")
+	}
+	return cmd
+}
+
+func discoverFeedVenueRows(data map[string]any) []any {
+	rows := []any{}
+	for _, sectionValue := range asSlice(data["sections"]) {
+		rows = append(rows, asSlice(asMap(sectionValue)["items"])...)
+	}
+	return rows
+}
+
 func buildDiscoveryFeedTable(data map[string]any) string {
 	headers := []string{"Section", "Venue", "Slug", "Rating", "Delivery estimate", "Delivery fee", "Price", "Promotions", "Wolt+"}
 	rows := [][]string{}
@@ -534,6 +873,10 @@ func buildDiscoveryFeedTable(data map[string]any) string {
 				promotions = "-"
 			}
 			name := asString(item["name"])
+			if asBool(item["is_ad"]) {
+				name += " (ad)"
+			}
+			name += basketRowLabel(item)
 			if idx > 0 {
 				sectionName = ""
 			}
@@ -554,9 +897,28 @@ func buildDiscoveryFeedTable(data map[string]any) string {
 	if asBool(data["wolt_plus_only"]) {
 		title += " (Wolt+ only)"
 	}
+	if meal := asMap(data["meal"]); meal != nil {
+		title += fmt.Sprintf(" - %s %s-%s", asString(meal["name"]), asString(meal["from"]), asString(meal["to"]))
+	}
 	return output.RenderTable(title, headers, rows)
 }
 
+func buildSectionTable(data map[string]any) string {
+	headers := []string{"Section", "Title", "Subtitle", "Items", "See all"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["sections"]) {
+		section := asMap(value)
+		rows = append(rows, []string{
+			asString(section["name"]),
+			asString(section["title"]),
+			fallbackString(asString(section["subtitle"]), "-"),
+			strconv.Itoa(asInt(section["total_items"])),
+			fallbackString(asString(asMap(section["see_all"])["target"]), "-"),
+		})
+	}
+	return output.RenderTable(fmt.Sprintf("Discover sections (%d)", asInt(data["count"])), headers, rows)
+}
+
 func buildCategoryTable(data map[string]any) string {
 	headers := []string{"Category", "Slug", "ID"}
 	rows := [][]string{}
@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// reorderMaxMatches caps the other matching order lines listed next to the
+// one that is reordered.
+const reorderMaxMatches = 10
+
+func newItemReorderCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var historyLimit int
+	var count int
+	var minConfidence float64
+	var yes bool
+	var noLock bool
+	var force bool
+	var approveToken string
+
+	cmd := &cobra.Command{
+		Use:   "reorder <name-fragment>",
+		Short: "Find an item in your order history and add today's version of it to the cart.",
+		Long: "Find an item in your order history and add today's version of it to the cart.\n\n" +
+			"The most recent order line whose name contains the fragment is taken; its venue is searched for the same\n" +
+			"item id, or else the closest name. The match is added with the original count after a y/N prompt;\n" +
+			"--yes skips the prompt, and without a terminal the match is only reported unless --yes is passed.\n" +
+			"Options of the original line are not copied.",
+		Example: "wolt item reorder \"coffee beans\"\n" +
+			"wolt item reorder \"coffee beans\" --count 2 --yes",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			fragment := strings.TrimSpace(args[0])
+			if fragment == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "name fragment is required")
+			}
+			if historyLimit < 1 || historyLimit > profileOrdersMaxLimit {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+					fmt.Sprintf("--history-limit must be between 1 and %d", profileOrdersMaxLimit))
+			}
+			if count < 0 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--count must be >= 1")
+			}
+			if minConfidence < 0 || minConfidence > 1 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--min-confidence must be between 0 and 1")
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+
+			history, warnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.OrderHistory(cmd.Context(), authCtx, woltgateway.OrderHistoryOptions{Limit: historyLimit})
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			matches, order := findReorderLines(asSlice(history["orders"]), fragment)
+			if order == nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_NOT_FOUND",
+					fmt.Sprintf("no item matching %q in the last %d orders", fragment, len(asSlice(history["orders"]))))
+			}
+
+			purchaseID := strings.TrimSpace(asString(coalesceAny(order["purchase_id"], order["order_id"], order["id"])))
+			purchase, purchaseWarnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.OrderHistoryPurchase(cmd.Context(), purchaseID, authCtx)
+				},
+			)
+			warnings = append(warnings, purchaseWarnings...)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			line := findReorderPurchaseLine(purchase, fragment)
+			if line == nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_NOT_FOUND",
+					fmt.Sprintf("order %s lists no line matching %q", purchaseID, fragment))
+			}
+			originalName := strings.TrimSpace(asString(line["name"]))
+			originalID := strings.TrimSpace(asString(line["id"]))
+			if count == 0 {
+				count = max(asInt(line["count"]), 1)
+			}
+			if len(asSlice(line["options"])) > 0 {
+				warnings = append(warnings, "the original line had options; they are not copied, see wolt item options")
+			}
+
+			venueID := strings.TrimSpace(asString(coalesceAny(purchase["venue_id"], order["venue_id"])))
+			slug := strings.TrimSpace(asString(order["venue_slug"]))
+			if slug == "" {
+				if venueID == "" {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_NOT_FOUND",
+						fmt.Sprintf("order %s has no venue id to reorder from", purchaseID))
+				}
+				if slug, err = resolveVenueSlug(cmd.Context(), deps, venueID); err != nil {
+					return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+				}
+			}
+			staticPayload := map[string]any{}
+			if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
+				staticPayload = payload
+				if resolvedID := strings.TrimSpace(venueIDFromPayload(payload)); resolvedID != "" {
+					venueID = resolvedID
+				}
+			} else {
+				warnings = append(warnings, "venue static page endpoint unavailable")
+			}
+			searchPayload, err := requestAssortmentItemsSearchPayload(cmd.Context(), deps, slug, originalName, resolveAssortmentLanguage(flags.Locale), auth)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			currency := resolveVenueSearchFallbackCurrency(staticPayload, searchPayload)
+			searchData, _ := buildVenueItemSearchData(venueID, slug, originalName, "", searchPayload, currency, false, nil)
+			match, confidence, matchKind := matchReorderItem(asSlice(searchData["items"]), originalID, originalName)
+			if match == nil || confidence < minConfidence {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_ITEM_NOT_FOUND",
+					fmt.Sprintf("%q is no longer on the menu of %s; search it with \"wolt venue search %s --query <text>\"", originalName, slug, slug))
+			}
+
+			price := asMap(match["base_price"])
+			data := map[string]any{
+				"query": fragment,
+				"order": map[string]any{
+					"purchase_id": purchaseID,
+					"received_at": strings.TrimSpace(asString(order["received_at"])),
+					"venue_name":  strings.TrimSpace(asString(coalesceAny(purchase["venue_name"], order["venue_name"]))),
+				},
+				"original": map[string]any{
+					"item_id": originalID,
+					"name":    originalName,
+					"count":   max(asInt(line["count"]), 1),
+				},
+				"venue_id":   venueID,
+				"venue_slug": slug,
+				"match": map[string]any{
+					"item_id":     match["item_id"],
+					"name":        match["name"],
+					"base_price":  match["base_price"],
+					"is_sold_out": asBool(match["is_sold_out"]),
+				},
+				"match_kind": matchKind,
+				"confidence": confidence,
+				"count":      count,
+				"matches":    matches,
+				"added":      false,
+				"cart":       nil,
+			}
+
+			profile := profileName
+			switch {
+			case asBool(match["is_sold_out"]):
+				warnings = append(warnings, fmt.Sprintf("%s is sold out at %s; nothing was added to the cart", asString(match["name"]), slug))
+			case !yes && !confirmReorder(cmd, count, match, asString(asMap(data["order"])["venue_name"])):
+				if machineMode(cmd) || !isInteractiveInput(cmd.InOrStdin()) {
+					warnings = append(warnings, "nothing was added to the cart; pass --yes to add the match")
+				} else {
+					warnings = append(warnings, "not confirmed; nothing was added to the cart")
+				}
+			default:
+				if err := guardLatestOrderTime(cmd, deps, flags.Profile, force, format, profileName, flags.Locale, flags.Output); err != nil {
+					return err
+				}
+				release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+				if err != nil {
+					return err
+				}
+				defer release()
+				addition := map[string]any{
+					"id":      asString(match["item_id"]),
+					"count":   count,
+					"name":    asString(match["name"]),
+					"price":   asInt(price["amount"]),
+					"options": []any{},
+					"substitution_settings": map[string]any{
+						"is_allowed": false,
+					},
+				}
+				cart, resolvedProfile, addWarnings, err := addLinesToBasket(
+					cmd, deps, flags, &auth, format, venueID, strings.TrimSpace(asString(price["currency"])), []map[string]any{addition}, approveToken,
+				)
+				warnings = append(warnings, addWarnings...)
+				if err != nil {
+					return err
+				}
+				profile = resolvedProfile
+				data["added"] = true
+				data["cart"] = cart
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildItemReorderTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, dedupeStrings(warnings), nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().IntVar(&historyLimit, "history-limit", profileOrdersDefaultLimit, fmt.Sprintf("Number of recent orders to search (1-%d)", profileOrdersMaxLimit))
+	cmd.Flags().IntVar(&count, "count", 0, "Quantity to add (default: the count of the original line)")
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0.5, "Lowest name match confidence (0-1) when the original item id is gone.")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Add the match without asking.")
+	addNoLockFlag(cmd, &noLock)
+	addForceFlag(cmd, &force)
+	addApproveTokenFlag(cmd, &approveToken)
+	addGlobalFlags(cmd, &flags)
+	markAuthRequired(cmd, true)
+	return cmd
+}
+
+// findReorderLines lists order lines whose name contains fragment, newest
+// order first, and returns the newest matching order.
+func findReorderLines(orders []any, fragment string) ([]any, map[string]any) {
+	needle := strings.ToLower(fragment)
+	matches := []any{}
+	var newest map[string]any
+	for _, value := range orders {
+		order := asMap(value)
+		for _, name := range orderHistoryItemNames(order) {
+			if !strings.Contains(strings.ToLower(name), needle) {
+				continue
+			}
+			if newest == nil {
+				newest = order
+			}
+			if len(matches) < reorderMaxMatches {
+				matches = append(matches, map[string]any{
+					"purchase_id": strings.TrimSpace(asString(coalesceAny(order["purchase_id"], order["order_id"], order["id"]))),
+					"received_at": strings.TrimSpace(asString(order["received_at"])),
+					"venue_name":  strings.TrimSpace(asString(order["venue_name"])),
+					"item_name":   name,
+				})
+			}
+		}
+	}
+	return matches, newest
+}
+
+// findReorderPurchaseLine returns the first line of an order detail whose
+// name contains fragment. History summaries may prefix names with counts,
+// which the detail lines do not carry.
+func findReorderPurchaseLine(purchase map[string]any, fragment string) map[string]any {
+	needle := strings.ToLower(fragment)
+	for _, value := range asSlice(purchase["items"]) {
+		line := asMap(value)
+		if strings.Contains(strings.ToLower(asString(line["name"])), needle) {
+			return line
+		}
+	}
+	return nil
+}
+
+// matchReorderItem prefers the search row with the original item id and
+// falls back to the closest name.
+func matchReorderItem(rows []any, itemID string, name string) (map[string]any, float64, string) {
+	if itemID != "" {
+		for _, value := range rows {
+			if row := asMap(value); strings.EqualFold(strings.TrimSpace(asString(row["item_id"])), itemID) {
+				return row, 1, "item_id"
+			}
+		}
+	}
+	match, confidence := bestShoppingMatch(name, rows)
+	return match, confidence, "name"
+}
+
+// confirmReorder asks on the terminal before adding; without one, or in
+// --machine mode, it declines.
+func confirmReorder(cmd *cobra.Command, count int, match map[string]any, venueName string) bool {
+	if machineMode(cmd) || !isInteractiveInput(cmd.InOrStdin()) {
+		return false
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Add %d x %s (%s) from %s to the cart? [y/N] ",
+		count, asString(match["name"]), formatBasePriceForTable(asMap(match["base_price"])), fallbackString(venueName, "the venue"))
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+func buildItemReorderTable(data map[string]any) string {
+	order := asMap(data["order"])
+	original := asMap(data["original"])
+	match := asMap(data["match"])
+	cart := asMap(data["cart"])
+	added := "no"
+	if asBool(data["added"]) {
+		added = fmt.Sprintf("yes (basket %s, %s items, total %s)",
+			fallbackString(asString(cart["basket_id"]), "-"), fallbackString(asString(cart["total_items"]), "-"), fallbackString(asString(asMap(cart["total"])["formatted_amount"]), "-"))
+	}
+	rows := [][]string{
+		{"Order", fmt.Sprintf("%s  %s  %s", fallbackString(asString(order["received_at"]), "-"), fallbackString(asString(order["venue_name"]), "-"), asString(order["purchase_id"]))},
+		{"Ordered item", fmt.Sprintf("%s x %s", asString(original["count"]), asString(original["name"]))},
+		{"Current item", fmt.Sprintf("%s (%s)", asString(match["name"]), asString(match["item_id"]))},
+		{"Matched by", asString(data["match_kind"])},
+		{"Price", formatBasePriceForTable(asMap(match["base_price"]))},
+		{"Sold out", boolToYesNo(asBool(match["is_sold_out"]))},
+		{"Count", asString(data["count"])},
+		{"Added", added},
+	}
+	return output.RenderTable("Reorder: "+asString(data["query"]), []string{"Field", "Value"}, rows)
+}
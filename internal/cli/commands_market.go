@@ -0,0 +1,622 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newMarketCommand(deps Dependencies) *cobra.Command {
+	market := &cobra.Command{
+		Use:   "market",
+		Short: "Browse marketplace venues (Wolt Market, grocery and retail stores) aisle by aisle.",
+	}
+	market.AddCommand(newMarketAislesCommand(deps))
+	market.AddCommand(newMarketAisleCommand(deps))
+	market.AddCommand(newMarketLookupCommand(deps))
+	return market
+}
+
+func newMarketAislesCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "aisles <slug>",
+		Short: "List a marketplace venue's top-level aisles with item counts and banner promotions.",
+		Long: "List a marketplace venue's top-level aisles with item counts and banner promotions.\n\n" +
+			"Item counts come from the assortment overview. Venues that load their assortment partially only\n" +
+			"list item ids once an aisle is opened, so their counts stay empty; use `wolt market aisle` to page\n" +
+			"through one aisle.",
+		Example: "wolt market aisles wolt-market-kamppi",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
+			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			if err != nil {
+				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
+			}
+
+			venueID := strings.TrimSpace(slug)
+			warnings := []string{}
+			banners := []string{}
+			if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
+				if resolvedID := strings.TrimSpace(venueIDFromPayload(payload)); resolvedID != "" {
+					venueID = resolvedID
+				}
+				banners = observability.ExtractVenuePromotionLabels(payload)
+			} else {
+				warnings = append(warnings, "venue static page endpoint unavailable")
+			}
+			assortmentPayload, err := deps.Wolt.AssortmentByVenueSlug(cmd.Context(), slug)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			aisles := collectMarketAisleRows(assortmentPayload)
+			if len(aisles) == 0 {
+				return emitError(cmd, format, profile.Name, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", notMarketVenueMessage(slug))
+			}
+			for _, label := range observability.ExtractBannerLabels(assortmentPayload["banners"]) {
+				if !slices.Contains(banners, label) {
+					banners = append(banners, label)
+				}
+			}
+
+			data := map[string]any{
+				"venue_id":         venueID,
+				"slug":             slug,
+				"loading_strategy": strings.TrimSpace(asString(assortmentPayload["loading_strategy"])),
+				"banners":          banners,
+				"aisles":           aisles,
+				"count":            len(aisles),
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildMarketAislesTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile.Name, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func newMarketAisleCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var limit int
+	var limitSet bool
+	var noLimit bool
+	var offset int
+	var offsetSet bool
+	var page int
+	var pageSet bool
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "aisle <slug> <aisle-slug>",
+		Short: "Page through the items of one marketplace aisle.",
+		Long: "Page through the items of one marketplace aisle.\n\n" +
+			"The aisle's item ids are read from its category pages first; only the items on the requested page\n" +
+			"are then loaded, so large aisles of partially loaded assortments stay cheap to browse. Aisle slugs\n" +
+			"come from `wolt market aisles <slug>`; nested aisles work as well.",
+		Example: "wolt market aisle wolt-market-kamppi dairy --limit 20\n" +
+			"wolt market aisle wolt-market-kamppi dairy --limit 20 --page 2",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
+			aisleSlug := strings.TrimSpace(args[1])
+			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			if err != nil {
+				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			var limitPtr *int
+			if limitSet {
+				limitPtr = &limit
+			}
+			resolvedOffset, err := resolvePageOffset(limit, limitSet, offset, offsetSet, page, pageSet)
+			if err != nil {
+				return err
+			}
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
+			}
+
+			venueID, warnings := venueIDForSlug(cmd.Context(), deps, slug)
+			assortmentPayload, err := deps.Wolt.AssortmentByVenueSlug(cmd.Context(), slug)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			if len(collectMarketAisleRows(assortmentPayload)) == 0 {
+				return emitError(cmd, format, profile.Name, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", notMarketVenueMessage(slug))
+			}
+			aisle := findMarketAisle(assortmentPayload, aisleSlug)
+			if aisle == nil {
+				return emitError(
+					cmd,
+					format,
+					profile.Name,
+					flags.Locale,
+					flags.Output,
+					"WOLT_NOT_FOUND",
+					fmt.Sprintf("aisle %q not found in %s; list aisles with \"wolt market aisles %s\"", aisleSlug, slug, slug),
+				)
+			}
+
+			itemIDs, knownItems, err := loadMarketAisleItemIDs(
+				cmd.Context(),
+				deps,
+				slug,
+				resolveAssortmentLanguage(flags.Locale),
+				auth,
+				assortmentPayload,
+				aisle,
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			pageIDs := make([]any, 0, len(itemIDs))
+			for _, itemID := range itemIDs {
+				pageIDs = append(pageIDs, itemID)
+			}
+			data := map[string]any{
+				"venue_id":         venueID,
+				"slug":             slug,
+				"loading_strategy": strings.TrimSpace(asString(assortmentPayload["loading_strategy"])),
+				"aisle": map[string]any{
+					"slug":    aisleSlug,
+					"name":    strings.TrimSpace(asString(coalesceAny(aisle["name"], aisle["title"], aisleSlug))),
+					"banners": observability.ExtractBannerLabels(aisle["banners"]),
+				},
+				"items": pageIDs,
+			}
+			paginateFlatRows(data, "items", pageLimit, resolvedOffset)
+
+			items := hydrateMarketAisleItems(cmd.Context(), deps, slug, auth, asSlice(data["items"]), knownItems)
+			rows := []any{}
+			if len(items) > 0 {
+				pagePayload := map[string]any{"items": items, "currency": assortmentPayload["currency"]}
+				menu, menuWarnings := observability.BuildVenueMenu(venueID, []map[string]any{pagePayload}, "", false, nil)
+				rows = asSlice(menu["items"])
+				warnings = append(warnings, menuWarnings...)
+			}
+			annotatePackSizes(rows)
+			rememberMenuNames(deps, rows)
+			data["items"] = rows
+			data["count"] = len(rows)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
+			if pageSet {
+				data["page"] = page
+			}
+			warnings, err = finishPartialRun(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildMarketAisleTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile.Name, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addStrictFlag(cmd, &strict)
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned rows")
+	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
+	addNoLimitFlag(cmd, &noLimit)
+	addGlobalFlags(cmd, &flags)
+	cmd.PreRun = func(cmd *cobra.Command, args []string) {
+		limitSet = cmd.Flags().Changed("limit")
+		offsetSet = cmd.Flags().Changed("offset")
+		pageSet = cmd.Flags().Changed("page")
+	}
+	return cmd
+}
+
+func newMarketLookupCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var ean string
+	var fullCatalog bool
+	var maxRequests int
+
+	cmd := &cobra.Command{
+		Use:   "lookup <slug> --ean <code>",
+		Short: "Find a marketplace item by its barcode (EAN/GTIN).",
+		Long: "Find a marketplace item by its barcode (EAN/GTIN).\n\n" +
+			"The code is matched against the barcodes items carry in the assortment overview, then against the\n" +
+			"venue's item search. Partial assortments only list some items up front; --full-catalog also reads\n" +
+			"every category page. Not every venue publishes barcodes; in that case the lookup fails with\n" +
+			"WOLT_NOT_FOUND and says so.",
+		Example: "wolt market lookup wolt-market-kamppi --ean 6415600501194",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if strings.TrimSpace(ean) == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--ean is required")
+			}
+			code := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(ean))
+			gtin, err := parseGTIN(code)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			if err := validateMaxRequests(maxRequests); err != nil {
+				return err
+			}
+			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			if err != nil {
+				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+
+			venueID, warnings := venueIDForSlug(cmd.Context(), deps, slug)
+			assortmentPayload, err := deps.Wolt.AssortmentByVenueSlug(cmd.Context(), slug)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			if len(collectMarketAisleRows(assortmentPayload)) == 0 {
+				return emitError(cmd, format, profile.Name, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", notMarketVenueMessage(slug))
+			}
+
+			lookup := marketBarcodeLookup{gtin: gtin}
+			lookup.scan(assortmentPayload, "assortment")
+			if lookup.match == nil {
+				language := resolveAssortmentLanguage(flags.Locale)
+				if payload, err := requestAssortmentItemsSearchPayload(cmd.Context(), deps, slug, code, language, auth); err == nil {
+					lookup.scan(payload, "search")
+				} else {
+					warnings = append(warnings, "venue item search unavailable for barcode lookup")
+				}
+				if lookup.match == nil && fullCatalog && isAssortmentPartial(assortmentPayload) {
+					if err := checkRequestBudget(
+						cmd,
+						format,
+						profile.Name,
+						flags.Locale,
+						flags.Output,
+						maxRequests,
+						estimateFullCatalogRequests(assortmentPayload),
+						"full catalog barcode lookup",
+						fmt.Sprintf("browse one aisle with \"wolt market aisle %s <aisle-slug>\"", slug),
+					); err != nil {
+						return err
+					}
+					categoryPayloads, categoryWarnings := loadAssortmentCategoryPayloads(cmd.Context(), deps, slug, language, auth, assortmentPayload, 0)
+					warnings = append(warnings, categoryWarnings...)
+					for _, payload := range categoryPayloads {
+						lookup.scan(payload, "catalog")
+					}
+				}
+			}
+			if lookup.match == nil {
+				message := fmt.Sprintf("no item with barcode %s in %s", code, slug)
+				switch {
+				case lookup.barcodes == 0:
+					message += "; the venue's item data carries no barcodes, so search by name with \"wolt venue search " + slug + " --query <text>\""
+				case isAssortmentPartial(assortmentPayload) && !fullCatalog:
+					message += "; the assortment is partial, pass --full-catalog to read every category page"
+				}
+				return emitError(cmd, format, profile.Name, flags.Locale, flags.Output, "WOLT_NOT_FOUND", message)
+			}
+
+			menu, menuWarnings := observability.BuildVenueMenu(
+				venueID,
+				[]map[string]any{{"items": []any{lookup.match}, "currency": assortmentPayload["currency"]}},
+				"",
+				false,
+				nil,
+			)
+			warnings = append(warnings, menuWarnings...)
+			rows := asSlice(menu["items"])
+			annotatePackSizes(rows)
+			rememberMenuNames(deps, rows)
+			var item any
+			if len(rows) > 0 {
+				item = rows[0]
+			}
+			data := map[string]any{
+				"venue_id":     venueID,
+				"slug":         slug,
+				"ean":          code,
+				"gtin":         gtin,
+				"match_source": lookup.source,
+				"item":         item,
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildMarketLookupTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile.Name, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&ean, "ean", "", "Barcode to look up (EAN-8, UPC-A, EAN-13, or GTIN-14)")
+	cmd.Flags().BoolVar(&fullCatalog, "full-catalog", false, "Read every category page of partial assortments when the code is not found up front (can be slow).")
+	addMaxRequestsFlag(cmd, &maxRequests)
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// marketBarcodeLookup scans payload items for one GTIN, counting the
+// barcodes seen so a miss can tell "not stocked" from "no barcode data".
+type marketBarcodeLookup struct {
+	gtin     string
+	barcodes int
+	match    map[string]any
+	source   string
+}
+
+func (l *marketBarcodeLookup) scan(payload map[string]any, source string) {
+	for _, rawItem := range asSlice(payload["items"]) {
+		item := asMap(rawItem)
+		if item == nil {
+			continue
+		}
+		codes := itemGTINs(item)
+		l.barcodes += len(codes)
+		if l.match == nil && slices.Contains(codes, l.gtin) {
+			l.match, l.source = item, source
+		}
+	}
+}
+
+func notMarketVenueMessage(slug string) string {
+	return fmt.Sprintf("venue %q has no marketplace aisles; use \"wolt venue menu %s\"", slug, slug)
+}
+
+// marketAisles returns the assortment's top-level categories.
+func marketAisles(assortmentPayload map[string]any) []map[string]any {
+	aisles := []map[string]any{}
+	for _, key := range []string{"categories", "subcategories"} {
+		for _, rawCategory := range asSlice(assortmentPayload[key]) {
+			if category := asMap(rawCategory); category != nil {
+				aisles = append(aisles, category)
+			}
+		}
+	}
+	return aisles
+}
+
+func collectMarketAisleRows(assortmentPayload map[string]any) []map[string]any {
+	rows := []map[string]any{}
+	partial := isAssortmentPartial(assortmentPayload)
+	for _, aisle := range marketAisles(assortmentPayload) {
+		slug := strings.TrimSpace(asString(coalesceAny(aisle["slug"], aisle["id"])))
+		if slug == "" {
+			continue
+		}
+		var itemCount any
+		if count := len(marketAisleItemIDs(aisle)); count > 0 || !partial {
+			itemCount = count
+		}
+		rows = append(rows, map[string]any{
+			"id":                strings.TrimSpace(asString(aisle["id"])),
+			"slug":              slug,
+			"name":              strings.TrimSpace(asString(coalesceAny(aisle["name"], aisle["title"], slug))),
+			"subcategory_count": len(asSlice(aisle["subcategories"])),
+			"item_count":        itemCount,
+			"banners":           observability.ExtractBannerLabels(aisle["banners"]),
+		})
+	}
+	return rows
+}
+
+// findMarketAisle looks up a category by slug at any depth.
+func findMarketAisle(assortmentPayload map[string]any, slug string) map[string]any {
+	var find func(categories []any) map[string]any
+	find = func(categories []any) map[string]any {
+		for _, rawCategory := range categories {
+			category := asMap(rawCategory)
+			if category == nil {
+				continue
+			}
+			if strings.EqualFold(strings.TrimSpace(asString(coalesceAny(category["slug"], category["id"]))), slug) {
+				return category
+			}
+			if found := find(asSlice(category["subcategories"])); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	for _, key := range []string{"categories", "subcategories"} {
+		if found := find(asSlice(assortmentPayload[key])); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// marketAisleItemIDs lists the item ids of aisle and its subcategories in
+// menu order.
+func marketAisleItemIDs(aisle map[string]any) []string {
+	itemIDs := []string{}
+	var walk func(category map[string]any)
+	walk = func(category map[string]any) {
+		for _, rawItemID := range asSlice(category["item_ids"]) {
+			if itemID := strings.TrimSpace(asString(rawItemID)); itemID != "" {
+				itemIDs = append(itemIDs, itemID)
+			}
+		}
+		for _, rawSubcategory := range asSlice(category["subcategories"]) {
+			if subcategory := asMap(rawSubcategory); subcategory != nil {
+				walk(subcategory)
+			}
+		}
+	}
+	walk(aisle)
+	return dedupeStrings(itemIDs)
+}
+
+// loadMarketAisleItemIDs returns the aisle's item ids and the items already
+// carried by the payloads read on the way. A fully loaded assortment lists
+// them in the overview; a partial one needs the aisle's category pages.
+func loadMarketAisleItemIDs(
+	ctx context.Context,
+	deps Dependencies,
+	venueSlug string,
+	language string,
+	auth woltgateway.AuthContext,
+	assortmentPayload map[string]any,
+	aisle map[string]any,
+) ([]string, map[string]map[string]any, error) {
+	knownItems := map[string]map[string]any{}
+	collectItems := func(payload map[string]any) {
+		for _, rawItem := range asSlice(payload["items"]) {
+			item := asMap(rawItem)
+			if itemID := strings.TrimSpace(asString(coalesceAny(item["id"], item["item_id"]))); itemID != "" {
+				knownItems[itemID] = item
+			}
+		}
+	}
+	collectItems(assortmentPayload)
+	if itemIDs := marketAisleItemIDs(aisle); len(itemIDs) > 0 && !isAssortmentPartial(assortmentPayload) {
+		return itemIDs, knownItems, nil
+	}
+
+	itemIDs := []string{}
+	loaded := 0
+	var lastErr error
+	walkAssortmentCrawlCategories(map[string]any{"categories": []any{aisle}}, func(categorySlug string, _ map[string]any) {
+		payload, err := requestAssortmentCategoryPayload(ctx, deps, venueSlug, categorySlug, language, auth)
+		if err != nil {
+			lastErr = err
+			recordPartialFailure(ctx, "aisle category "+categorySlug, err)
+			return
+		}
+		loaded++
+		collectItems(payload)
+		itemIDs = append(itemIDs, payloadItemIDs(payload)...)
+	})
+	if loaded == 0 && lastErr != nil {
+		return nil, nil, lastErr
+	}
+	return dedupeStrings(itemIDs), knownItems, nil
+}
+
+// hydrateMarketAisleItems returns the items of pageIDs in order, loading
+// only those no payload carried yet.
+func hydrateMarketAisleItems(
+	ctx context.Context,
+	deps Dependencies,
+	venueSlug string,
+	auth woltgateway.AuthContext,
+	pageIDs []any,
+	knownItems map[string]map[string]any,
+) []any {
+	missing := []string{}
+	for _, rawItemID := range pageIDs {
+		if itemID := asString(rawItemID); knownItems[itemID] == nil {
+			missing = append(missing, itemID)
+		}
+	}
+	for _, batch := range batchStrings(missing, assortmentItemsBatchSize) {
+		payload, err := requestAssortmentItemsPayload(ctx, deps, venueSlug, batch, auth)
+		if err != nil {
+			recordPartialFailure(ctx, "aisle item hydration", err)
+			continue
+		}
+		for _, rawItem := range asSlice(payload["items"]) {
+			item := asMap(rawItem)
+			if itemID := strings.TrimSpace(asString(coalesceAny(item["id"], item["item_id"]))); itemID != "" {
+				knownItems[itemID] = item
+			}
+		}
+	}
+	items := make([]any, 0, len(pageIDs))
+	for _, rawItemID := range pageIDs {
+		if item := knownItems[asString(rawItemID)]; item != nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func buildMarketAislesTable(data map[string]any) string {
+	headers := []string{"Slug", "Name", "Subaisles", "Items", "Banners"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["aisles"]) {
+		aisle := asMap(value)
+		itemCount := "-"
+		if aisle["item_count"] != nil {
+			itemCount = asString(aisle["item_count"])
+		}
+		rows = append(rows, []string{
+			asString(aisle["slug"]),
+			asString(aisle["name"]),
+			asString(aisle["subcategory_count"]),
+			itemCount,
+			fallbackString(stringsJoin(asSlice(aisle["banners"]), ", "), "-"),
+		})
+	}
+	title := "Market aisles: " + asString(data["slug"])
+	if strategy := strings.TrimSpace(asString(data["loading_strategy"])); strategy != "" {
+		title += " (" + strategy + ")"
+	}
+	if banners := stringsJoin(asSlice(data["banners"]), ", "); banners != "" {
+		title += " - " + banners
+	}
+	return output.RenderTable(title, headers, rows)
+}
+
+func buildMarketAisleTable(data map[string]any) string {
+	headers := []string{"Item ID", "Name", "Price", "Sold out", "Discounts"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["items"]) {
+		item := asMap(value)
+		rows = append(rows, []string{
+			asString(item["item_id"]),
+			asString(item["name"]),
+			formatBasePriceForTable(asMap(item["base_price"])),
+			boolToYesNo(asBool(item["is_sold_out"])),
+			fallbackString(stringsJoin(asSlice(item["discounts"]), ", "), "-"),
+		})
+	}
+	aisle := asMap(data["aisle"])
+	title := fmt.Sprintf("Market aisle: %s / %s (%d of %d items)", asString(data["slug"]), asString(aisle["name"]), asInt(data["count"]), asInt(data["total"]))
+	return output.RenderTable(title, headers, rows)
+}
+
+func buildMarketLookupTable(data map[string]any) string {
+	item := asMap(data["item"])
+	rows := [][]string{
+		{"EAN", asString(data["ean"])},
+		{"Item ID", fallbackString(asString(item["item_id"]), "-")},
+		{"Name", fallbackString(asString(item["name"]), "-")},
+		{"Price", formatBasePriceForTable(asMap(item["base_price"]))},
+		{"Sold out", boolToYesNo(asBool(item["is_sold_out"]))},
+		{"Found in", asString(data["match_source"])},
+	}
+	return output.RenderTable("Market lookup: "+asString(data["slug"]), []string{"Field", "Value"}, rows)
+}
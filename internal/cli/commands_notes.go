@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/journal"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const journalDirEnv = "WOLT_JOURNAL_DIR"
+
+func newNotesCommand(deps Dependencies) *cobra.Command {
+	notesCmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Keep personal notes and tags on venues.",
+		Long: "Keep personal notes and tags on venues.\n\n" +
+			"Notes are stored per profile in $WOLT_JOURNAL_DIR, or a journal directory next to the config file, " +
+			"and show up as my_note and my_tags on venue show, discover feed, and search venues rows.",
+	}
+	notesCmd.AddCommand(newNotesSetCommand(deps))
+	notesCmd.AddCommand(newNotesShowCommand(deps))
+	return notesCmd
+}
+
+func newNotesSetCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var tags []string
+
+	cmd := &cobra.Command{
+		Use:   "set <slug> [note]",
+		Short: "Save a note and tags for a venue.",
+		Long: "Save a note and tags for a venue, replacing the previous ones.\n\n" +
+			"Without --tag the existing tags are kept; without a note argument the existing text is kept. " +
+			"An empty note with --tag '' clears the entry.",
+		Example: "wolt notes set ramen-place \"great tonkotsu, ask for extra chashu\" --tag ramen --tag late-night\n" +
+			"wolt notes set ramen-place \"\" --tag ''",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := journalProfile(cmd.Context(), deps, flags.Profile)
+			if len(args) == 1 && !cmd.Flags().Changed("tag") {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "pass a note, --tag, or both")
+			}
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
+			store, err := openJournalStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+			notes, err := store.Notes(profileName)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+
+			note := notes[journal.NoteKey(slug)]
+			if len(args) == 2 {
+				note.Text = strings.TrimSpace(args[1])
+			}
+			if cmd.Flags().Changed("tag") {
+				note.Tags = normalizeNoteTags(tags)
+			}
+			note.UpdatedAt = deps.now().UTC()
+			if err := store.SetNote(profileName, slug, note); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+
+			data := buildNoteData(slug, note)
+			if format == output.FormatTable {
+				return writeTable(cmd, buildNoteTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, []string{}, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Personal tag for the venue (repeatable; replaces the existing tags).")
+	return cmd
+}
+
+func newNotesShowCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "show <slug>",
+		Short: "Show your note and tags for a venue.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := journalProfile(cmd.Context(), deps, flags.Profile)
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
+			store, err := openJournalStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+			notes, err := store.Notes(profileName)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+
+			warnings := []string{}
+			note, ok := notes[journal.NoteKey(slug)]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("no note for %s in profile %s", slug, profileName))
+			}
+			data := buildNoteData(slug, note)
+			if format == output.FormatTable {
+				return writeTable(cmd, buildNoteTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func openJournalStore(deps Dependencies) (*journal.Store, error) {
+	dir, err := stateDir(deps, journalDirEnv, "journal")
+	if err != nil {
+		return nil, err
+	}
+	return journal.NewStore(dir), nil
+}
+
+// journalProfile names the profile journal entries are filed under: the
+// resolved profile, so the default profile keeps one journal whether or not
+// --profile names it.
+func journalProfile(ctx context.Context, deps Dependencies, profileFlag string) string {
+	if deps.Profiles != nil {
+		if profile, err := deps.Profiles.Find(ctx, profileFlag); err == nil && strings.TrimSpace(profile.Name) != "" {
+			return profile.Name
+		}
+	}
+	return defaultProfileName(profileFlag)
+}
+
+// annotateVenueNotes adds my_note and my_tags to venue rows the profile has a
+// note for. Notes are an aid, so journal errors leave rows unannotated.
+func annotateVenueNotes(ctx context.Context, deps Dependencies, profileFlag string, rows ...map[string]any) {
+	store, err := openJournalStore(deps)
+	if err != nil {
+		return
+	}
+	notes, err := store.Notes(journalProfile(ctx, deps, profileFlag))
+	if err != nil || len(notes) == 0 {
+		return
+	}
+	for _, row := range rows {
+		note, ok := notes[journal.NoteKey(asString(row["slug"]))]
+		if !ok || row == nil {
+			continue
+		}
+		row["my_note"] = emptyToNil(note.Text)
+		row["my_tags"] = noteTagsValue(note.Tags)
+	}
+}
+
+func venueRowMaps(rows []any) []map[string]any {
+	maps := make([]map[string]any, 0, len(rows))
+	for _, value := range rows {
+		if row := asMap(value); row != nil {
+			maps = append(maps, row)
+		}
+	}
+	return maps
+}
+
+func normalizeNoteTags(values []string) []string {
+	tags := []string{}
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return dedupeStrings(tags)
+}
+
+func noteTagsValue(tags []string) []any {
+	values := make([]any, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, tag)
+	}
+	return values
+}
+
+func buildNoteData(slug string, note journal.Note) map[string]any {
+	var updatedAt any
+	if !note.UpdatedAt.IsZero() {
+		updatedAt = note.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return map[string]any{
+		"slug":       slug,
+		"my_note":    emptyToNil(note.Text),
+		"my_tags":    noteTagsValue(note.Tags),
+		"updated_at": updatedAt,
+	}
+}
+
+func buildNoteTable(data map[string]any) string {
+	return output.RenderTable("Note: "+asString(data["slug"]), []string{"Field", "Value"}, [][]string{
+		{"Note", fallbackString(asString(data["my_note"]), "-")},
+		{"Tags", fallbackString(stringsJoin(asSlice(data["my_tags"]), ", "), "-")},
+		{"Updated", fallbackString(asString(data["updated_at"]), "-")},
+	})
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newPickCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var category string
+	var query string
+	var minRating float64
+	var maxFee int
+	var woltPlus bool
+	var includeClosed bool
+	var withItem bool
+	var maxItemPrice int
+	var seed int64
+
+	cmd := &cobra.Command{
+		Use:   "pick",
+		Short: "Pick one random venue (and optionally one item) matching filters.",
+		Long: "Pick one random venue (and optionally one item) matching filters.\n\n" +
+			"Filters apply to the venue list for the current location; the chosen venue is printed alone. " +
+			"The seed is reported so a pick can be repeated with --seed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if minRating < 0 || maxFee < 0 || maxItemPrice < 0 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--min-rating, --max-fee, and --max-item-price must be >= 0")
+			}
+			if cmd.Flags().Changed("max-item-price") && !withItem {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--max-item-price requires --with-item")
+			}
+			if !cmd.Flags().Changed("seed") {
+				seed = deps.seed()
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+			items, err := deps.Wolt.Items(cmd.Context(), location)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			rememberItemVenues(deps, items, "")
+
+			result, warnings := observability.BuildVenueSearchResult(
+				items,
+				query,
+				observability.VenueSortRecommended,
+				nil,
+				category,
+				!includeClosed,
+				woltPlus,
+				nil,
+				0,
+			)
+			candidates := applyVenueRowFilters(
+				asSlice(result["items"]),
+				venueRowFilters{
+					MinRatingSet:      cmd.Flags().Changed("min-rating"),
+					MinRating:         minRating,
+					MaxDeliveryFeeSet: cmd.Flags().Changed("max-fee"),
+					MaxDeliveryFee:    maxFee,
+				},
+			)
+			if len(candidates) == 0 {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_NOT_FOUND", "no venues matched the filters; nothing to pick")
+			}
+
+			random := rand.New(rand.NewPCG(uint64(seed), 0))
+			venue := asMap(candidates[random.IntN(len(candidates))])
+			data := map[string]any{
+				"seed":       seed,
+				"candidates": len(candidates),
+				"venue":      venue,
+			}
+			if withItem {
+				item, itemCandidates, err := pickVenueItem(cmd, deps, venue, location, auth, maxItemPrice, random)
+				if err != nil {
+					return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+				}
+				data["item_candidates"] = itemCandidates
+				if item != nil {
+					data["item"] = item
+				} else {
+					warnings = append(warnings, fmt.Sprintf("no orderable items matched at %s", fallbackString(asString(venue["slug"]), asString(venue["venue_id"]))))
+				}
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildPickTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&category, "category", "", "Category slug")
+	cmd.Flags().StringVar(&query, "query", "", "Only pick venues matching this text")
+	cmd.Flags().Float64Var(&minRating, "min-rating", 0, "Minimum venue rating score (for example 8.5)")
+	cmd.Flags().IntVar(&maxFee, "max-fee", 0, "Maximum delivery fee in minor units (for example 300 = EUR 3.00)")
+	cmd.Flags().BoolVar(&woltPlus, "wolt-plus", false, "Only pick Wolt+ venues")
+	cmd.Flags().BoolVar(&includeClosed, "include-closed", false, "Also pick venues that are currently closed")
+	cmd.Flags().BoolVar(&withItem, "with-item", false, "Also pick one orderable item from the chosen venue's menu")
+	cmd.Flags().IntVar(&maxItemPrice, "max-item-price", 0, "With --with-item, maximum item price in minor units")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "Random seed; the same seed and candidates give the same pick")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// pickVenueItem draws one item that is not sold out from the venue menu and
+// returns it with the number of items it was drawn from.
+func pickVenueItem(
+	cmd *cobra.Command,
+	deps Dependencies,
+	venue map[string]any,
+	location domain.Location,
+	auth woltgateway.AuthContext,
+	maxPrice int,
+	random *rand.Rand,
+) (map[string]any, int, error) {
+	slug := strings.TrimSpace(asString(venue["slug"]))
+	payload, err := deps.Wolt.VenuePageDynamic(cmd.Context(), slug, woltgateway.VenuePageDynamicOptions{Location: &location, Auth: auth})
+	if err != nil {
+		return nil, 0, err
+	}
+	menu, _ := observability.BuildVenueMenu(asString(venue["venue_id"]), []map[string]any{payload}, "", false, nil)
+	rows, _ := menu["items"].([]map[string]any)
+	orderable := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		amount := asInt(asMap(row["base_price"])["amount"])
+		if asBool(row["is_sold_out"]) || amount <= 0 || (maxPrice > 0 && amount > maxPrice) {
+			continue
+		}
+		orderable = append(orderable, row)
+	}
+	if len(orderable) == 0 {
+		return nil, 0, nil
+	}
+	return orderable[random.IntN(len(orderable))], len(orderable), nil
+}
+
+func buildPickTable(data map[string]any) string {
+	venue := asMap(data["venue"])
+	rows := [][]string{
+		{"Venue", fallbackString(asString(venue["name"]), "-")},
+		{"Slug", fallbackString(asString(venue["slug"]), "-")},
+		{"Rating", fallbackString(asString(venue["rating"]), "-")},
+		{"Delivery fee", fallbackString(asString(asMap(venue["delivery_fee"])["formatted_amount"]), "-")},
+	}
+	if item := asMap(data["item"]); item != nil {
+		rows = append(rows,
+			[]string{"Item", fallbackString(asString(item["name"]), "-")},
+			[]string{"Item ID", fallbackString(asString(item["item_id"]), "-")},
+			[]string{"Price", fallbackString(asString(asMap(item["base_price"])["formatted_amount"]), "-")},
+		)
+	}
+	title := fmt.Sprintf("Picked 1 of %d (seed %d)", asInt(data["candidates"]), data["seed"])
+	return output.RenderTable(title, []string{"Field", "Value"}, rows)
+}
@@ -0,0 +1,391 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// planMaxNeeds bounds --need so the exact cover search stays at 2^n coverage masks.
+const planMaxNeeds = 10
+
+func newPlanCommand(deps Dependencies) *cobra.Command {
+	planCmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Plan orders that span several venues.",
+	}
+	planCmd.AddCommand(newPlanMultiCommand(deps))
+	return planCmd
+}
+
+func newPlanMultiCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var need string
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "multi",
+		Short: "Find the fewest venues that together carry every needed item.",
+		Long: "Find the fewest venues that together carry every needed item.\n\n" +
+			"Runs one item search per need, then picks the smallest set of venues covering all needs, " +
+			"breaking ties by lowest total delivery fee. Delivery fees come from the discovery feed; " +
+			"venues outside it are costed at the highest known fee.",
+		Example: "wolt plan multi --need \"milk,bread,sushi\"",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			needs := splitPlanNeeds(need)
+			if len(needs) == 0 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--need must list at least one item, for example \"milk,bread\"")
+			}
+			if len(needs) > planMaxNeeds {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+					fmt.Sprintf("--need accepts at most %d items", planMaxNeeds))
+			}
+
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+
+			fallbackItems, err := deps.Wolt.Items(cmd.Context(), location)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			warnings := []string{}
+			feedVenues := []any{}
+			if frontPage, err := deps.Wolt.FrontPage(cmd.Context(), location); err != nil {
+				recordPartialFailure(cmd.Context(), "discovery feed", err)
+			} else if sections, err := extractDiscoverSectionsFromFrontPage(frontPage); err == nil {
+				feedVenues = discoverFeedVenueRows(observability.BuildDiscoveryFeed(sections, "", nil, false))
+			} else {
+				warnings = append(warnings, "front page sections missing; delivery fees unknown")
+			}
+
+			matches := map[string][]any{}
+			for _, query := range needs {
+				payloads := []map[string]any{}
+				if payload, err := deps.Wolt.Search(cmd.Context(), location, query); err == nil {
+					payloads = append(payloads, payload)
+				} else {
+					recordPartialFailure(cmd.Context(), "item search", err)
+				}
+				result, _ := observability.BuildItemSearchResult(query, payloads, observability.ItemSortRelevance, "", nil, 0, fallbackItems)
+				matches[query] = asSlice(result["items"])
+			}
+
+			data, planWarnings := buildMultiStopPlan(needs, matches, feedVenues)
+			warnings = append(warnings, planWarnings...)
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildMultiStopPlanTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&need, "need", "", "Comma-separated items to find, for example \"milk,bread,sushi\" (required)")
+	if err := cmd.MarkFlagRequired("need"); err != nil {
+		panic(err)
+	}
+	addStrictFlag(cmd, &strict)
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// splitPlanNeeds splits a comma list, dropping blanks and case-insensitive duplicates.
+func splitPlanNeeds(value string) []string {
+	needs := []string{}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(part)
+		key := strings.ToLower(trimmed)
+		if trimmed == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		needs = append(needs, trimmed)
+	}
+	return needs
+}
+
+// planVenue is one venue that carries at least one need.
+type planVenue struct {
+	id       string
+	slug     string
+	name     string
+	fee      map[string]any
+	cost     int
+	mask     int
+	items    map[int]map[string]any
+	currency string
+}
+
+// buildMultiStopPlan picks the fewest venues whose search matches cover every
+// matchable need, then the lowest total delivery fee among those. matches maps
+// each need to item search rows in relevance order.
+func buildMultiStopPlan(needs []string, matches map[string][]any, feedVenues []any) (map[string]any, []string) {
+	warnings := []string{}
+	feedByKey := map[string]map[string]any{}
+	for _, value := range feedVenues {
+		row := asMap(value)
+		for _, key := range []string{asString(row["venue_id"]), asString(row["slug"])} {
+			if key != "" && feedByKey[key] == nil {
+				feedByKey[key] = row
+			}
+		}
+	}
+
+	venuesByKey := map[string]*planVenue{}
+	order := []string{}
+	for idx, query := range needs {
+		for _, value := range matches[query] {
+			item := asMap(value)
+			if item == nil || asBool(item["is_sold_out"]) {
+				continue
+			}
+			key := fallbackString(asString(item["venue_id"]), asString(item["venue_slug"]))
+			if key == "" {
+				continue
+			}
+			venue := venuesByKey[key]
+			if venue == nil {
+				venue = &planVenue{id: asString(item["venue_id"]), slug: asString(item["venue_slug"]), items: map[int]map[string]any{}}
+				venuesByKey[key] = venue
+				order = append(order, key)
+			}
+			if _, ok := venue.items[idx]; ok {
+				continue
+			}
+			venue.items[idx] = item
+			venue.mask |= 1 << idx
+			if venue.currency == "" {
+				venue.currency = asString(asMap(item["base_price"])["currency"])
+			}
+		}
+	}
+
+	maxKnownFee := 0
+	unknownFees := 0
+	candidates := make([]*planVenue, 0, len(order))
+	for _, key := range order {
+		venue := venuesByKey[key]
+		feed := feedByKey[venue.id]
+		if feed == nil {
+			feed = feedByKey[venue.slug]
+		}
+		if feed != nil {
+			venue.name = asString(feed["name"])
+			venue.slug = fallbackString(venue.slug, asString(feed["slug"]))
+			if fee := asMap(feed["delivery_fee"]); fee != nil && fee["amount"] != nil {
+				venue.fee = fee
+				venue.cost = asInt(fee["amount"])
+				maxKnownFee = max(maxKnownFee, venue.cost)
+			}
+		}
+		candidates = append(candidates, venue)
+	}
+	for _, venue := range candidates {
+		if venue.fee == nil {
+			venue.cost = maxKnownFee
+			unknownFees++
+		}
+	}
+	if unknownFees > 0 {
+		warnings = append(warnings, fmt.Sprintf("delivery fee unknown for %d venue(s) outside the discovery feed; costed at the highest known fee", unknownFees))
+	}
+
+	chosen := coverPlanNeeds(candidates)
+	assigned := map[int]*planVenue{}
+	for idx := range needs {
+		for _, venue := range chosen {
+			item, ok := venue.items[idx]
+			if !ok {
+				continue
+			}
+			if current := assigned[idx]; current == nil || planItemPrice(item) < planItemPrice(current.items[idx]) {
+				assigned[idx] = venue
+			}
+		}
+	}
+
+	stops := make([]any, 0, len(chosen))
+	totalFee := 0
+	currency := ""
+	for _, venue := range chosen {
+		items := []any{}
+		for idx, query := range needs {
+			if assigned[idx] != venue {
+				continue
+			}
+			item := venue.items[idx]
+			items = append(items, map[string]any{
+				"need":       query,
+				"item_id":    item["item_id"],
+				"name":       item["name"],
+				"base_price": item["base_price"],
+			})
+		}
+		var fee any
+		if venue.fee != nil {
+			fee = venue.fee
+			totalFee += venue.cost
+		}
+		currency = fallbackString(currency, venue.currency)
+		stops = append(stops, map[string]any{
+			"venue_id":     venue.id,
+			"slug":         venue.slug,
+			"name":         venue.name,
+			"delivery_fee": fee,
+			"items":        items,
+		})
+	}
+	unmatched := []any{}
+	for idx, query := range needs {
+		if assigned[idx] == nil {
+			unmatched = append(unmatched, query)
+		}
+	}
+	if len(unmatched) > 0 {
+		warnings = append(warnings, fmt.Sprintf("no venue found for: %s", stringsJoin(unmatched, ", ")))
+	}
+
+	return map[string]any{
+		"needs":            needs,
+		"stops":            stops,
+		"venue_count":      len(stops),
+		"candidate_venues": len(candidates),
+		"total_delivery_fee": map[string]any{
+			"amount":           totalFee,
+			"formatted_amount": formatMinorAmount(totalFee, currency),
+		},
+		"unmatched": unmatched,
+	}, warnings
+}
+
+// coverPlanNeeds solves the set cover exactly over coverage masks: the fewest
+// venues covering every need any venue carries, then the lowest summed cost.
+// Among venues with the same coverage only the cheapest can be part of an
+// optimal plan, so at most 2^n candidates remain.
+func coverPlanNeeds(venues []*planVenue) []*planVenue {
+	sorted := append([]*planVenue(nil), venues...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].cost < sorted[j].cost })
+	byMask := map[int]*planVenue{}
+	target := 0
+	masks := []int{}
+	for _, venue := range sorted {
+		target |= venue.mask
+		if byMask[venue.mask] == nil {
+			byMask[venue.mask] = venue
+			masks = append(masks, venue.mask)
+		}
+	}
+	if target == 0 {
+		return []*planVenue{}
+	}
+
+	type state struct {
+		reached bool
+		count   int
+		cost    int
+		prev    int
+		venue   *planVenue
+	}
+	states := make([]state, target+1)
+	states[0] = state{reached: true}
+	for mask := 0; mask <= target; mask++ {
+		current := states[mask]
+		if !current.reached {
+			continue
+		}
+		for _, venueMask := range masks {
+			next := mask | venueMask
+			if next == mask {
+				continue
+			}
+			venue := byMask[venueMask]
+			count, cost := current.count+1, current.cost+venue.cost
+			candidate := &states[next]
+			if !candidate.reached || count < candidate.count || (count == candidate.count && cost < candidate.cost) {
+				*candidate = state{reached: true, count: count, cost: cost, prev: mask, venue: venue}
+			}
+		}
+	}
+
+	chosen := []*planVenue{}
+	for mask := target; mask != 0; mask = states[mask].prev {
+		chosen = append(chosen, states[mask].venue)
+	}
+	sort.SliceStable(chosen, func(i, j int) bool { return chosen[i].cost < chosen[j].cost })
+	return chosen
+}
+
+func planItemPrice(item map[string]any) int {
+	amount := asMap(item["base_price"])["amount"]
+	if amount == nil {
+		return math.MaxInt
+	}
+	return asInt(amount)
+}
+
+func buildMultiStopPlanTable(data map[string]any) string {
+	headers := []string{"Stop", "Venue", "Slug", "Delivery fee", "Items"}
+	rows := [][]string{}
+	for idx, value := range asSlice(data["stops"]) {
+		stop := asMap(value)
+		fee := "-"
+		if formatted := asString(asMap(stop["delivery_fee"])["formatted_amount"]); formatted != "" {
+			fee = formatted
+		}
+		items := []string{}
+		for _, itemValue := range asSlice(stop["items"]) {
+			item := asMap(itemValue)
+			label := fmt.Sprintf("%s: %s", asString(item["need"]), asString(item["name"]))
+			if price := formatBasePriceForTable(asMap(item["base_price"])); price != "-" {
+				label += " " + price
+			}
+			items = append(items, label)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", idx+1),
+			fallbackString(asString(stop["name"]), "-"),
+			fallbackString(asString(stop["slug"]), "-"),
+			fee,
+			fallbackString(strings.Join(items, ", "), "-"),
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"-", "-", "-", "-", "-"})
+	}
+	summary := [][]string{
+		{"Venues", fmt.Sprintf("%d", asInt(data["venue_count"]))},
+		{"Delivery fees", fallbackString(asString(asMap(data["total_delivery_fee"])["formatted_amount"]), "-")},
+		{"Unmatched", fallbackString(stringsJoin(asSlice(data["unmatched"]), ", "), "-")},
+	}
+	return output.RenderTable("Multi-stop plan", headers, rows) + "\n\n" +
+		output.RenderTable("Plan total", []string{"Field", "Value"}, summary)
+}
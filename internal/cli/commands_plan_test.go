@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func planItem(venueID string, itemID string, amount int) map[string]any {
+	return map[string]any{
+		"item_id":    itemID,
+		"venue_id":   venueID,
+		"venue_slug": venueID + "-slug",
+		"name":       itemID,
+		"base_price": map[string]any{"amount": amount, "currency": "EUR"},
+	}
+}
+
+func planFeedVenue(venueID string, fee int) map[string]any {
+	return map[string]any{
+		"venue_id":     venueID,
+		"slug":         venueID + "-slug",
+		"name":         strings.ToUpper(venueID),
+		"delivery_fee": map[string]any{"amount": fee, "formatted_amount": formatMinorAmount(fee, "EUR")},
+	}
+}
+
+func TestBuildMultiStopPlanPrefersFewestVenuesThenLowestFees(t *testing.T) {
+	needs := []string{"milk", "bread", "sushi"}
+	matches := map[string][]any{
+		"milk":  {planItem("market", "milk-1", 150), planItem("kiosk", "milk-2", 200), planItem("deli", "milk-3", 180)},
+		"bread": {planItem("market", "bread-1", 300), planItem("deli", "bread-2", 250)},
+		"sushi": {planItem("sushi-bar", "sushi-1", 1200), planItem("deli", "sushi-2", 1500)},
+	}
+	feed := []any{planFeedVenue("market", 0), planFeedVenue("kiosk", 100), planFeedVenue("deli", 590), planFeedVenue("sushi-bar", 190)}
+
+	data, warnings := buildMultiStopPlan(needs, matches, feed)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings %v", warnings)
+	}
+	stops := asSlice(data["stops"])
+	if len(stops) != 1 || asString(asMap(stops[0])["venue_id"]) != "deli" {
+		t.Fatalf("expected the single venue covering everything, got %#v", stops)
+	}
+
+	// Without sushi at the deli two stops are needed; market+sushi-bar is cheapest.
+	matches["sushi"] = []any{planItem("sushi-bar", "sushi-1", 1200)}
+	data, _ = buildMultiStopPlan(needs, matches, feed)
+	stops = asSlice(data["stops"])
+	if len(stops) != 2 || asString(asMap(stops[0])["venue_id"]) != "market" || asString(asMap(stops[1])["venue_id"]) != "sushi-bar" {
+		t.Fatalf("unexpected stops %#v", stops)
+	}
+	if asInt(asMap(data["total_delivery_fee"])["amount"]) != 190 {
+		t.Fatalf("unexpected total fee %#v", data["total_delivery_fee"])
+	}
+	if items := asSlice(asMap(stops[0])["items"]); len(items) != 2 {
+		t.Fatalf("expected milk and bread at the market, got %#v", items)
+	}
+}
+
+func TestBuildMultiStopPlanReportsUnmatchedAndUnknownFees(t *testing.T) {
+	sold := planItem("market", "milk-1", 150)
+	sold["is_sold_out"] = true
+	matches := map[string][]any{
+		"milk":  {sold},
+		"bread": {planItem("bakery", "bread-1", 300)},
+	}
+	data, warnings := buildMultiStopPlan([]string{"milk", "bread"}, matches, nil)
+	if unmatched := asSlice(data["unmatched"]); len(unmatched) != 1 || unmatched[0] != "milk" {
+		t.Fatalf("expected milk unmatched, got %#v", data["unmatched"])
+	}
+	stop := asMap(asSlice(data["stops"])[0])
+	if stop["delivery_fee"] != nil {
+		t.Fatalf("expected unknown fee, got %#v", stop["delivery_fee"])
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected unknown-fee and unmatched warnings, got %v", warnings)
+	}
+}
+
+func TestSplitPlanNeeds(t *testing.T) {
+	got := splitPlanNeeds(" milk, bread,,Milk ,sushi ")
+	if strings.Join(got, "|") != "milk|bread|sushi" {
+		t.Fatalf("unexpected needs %q", got)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
@@ -76,6 +78,7 @@ func newProfileShowCommand(deps Dependencies) *cobra.Command {
 
 	cmd.Flags().StringVar(&include, "include", "", "Include fields: personal,settings")
 	addGlobalFlags(cmd, &flags)
+	markAuthRequired(cmd, true)
 	return cmd
 }
 
@@ -83,6 +86,7 @@ func newProfilePaymentsCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 	var maskSensitive bool
 	var labelFilter string
+	var includeBalances bool
 
 	cmd := &cobra.Command{
 		Use:   "payments",
@@ -113,10 +117,13 @@ func newProfilePaymentsCommand(deps Dependencies) *cobra.Command {
 			authWarnings = append(authWarnings, result.Warnings...)
 			methods := extractPaymentMethods(result.Payload, maskSensitive)
 			methods = filterPaymentMethodsByLabel(methods, labelFilter)
+			if includeBalances {
+				authWarnings = append(authWarnings, attachPaymentBalances(cmd.Context(), deps, flags, &auth, result.Payload, methods)...)
+			}
 			data := map[string]any{"methods": methods}
 
 			if format == output.FormatTable {
-				return writeTable(cmd, buildProfilePaymentsTable(data), flags.Output)
+				return writeTable(cmd, buildProfilePaymentsTable(data, includeBalances), flags.Output)
 			}
 			env := output.BuildEnvelope(profileName, flags.Locale, data, authWarnings, nil)
 			return writeMachinePayload(cmd, env, format, flags.Output)
@@ -125,7 +132,10 @@ func newProfilePaymentsCommand(deps Dependencies) *cobra.Command {
 
 	cmd.Flags().BoolVar(&maskSensitive, "mask-sensitive", false, "Mask sensitive payment labels.")
 	cmd.Flags().StringVar(&labelFilter, "label", "", "Filter payment methods by case-insensitive label text.")
+	cmd.Flags().BoolVar(&includeBalances, "include-balances", false, "Add remaining balance and expiry for gift cards, credits, and linked benefit providers.")
 	addGlobalFlags(cmd, &flags)
+	cmd.AddCommand(newProfilePaymentsAddCardCommand(deps))
+	markAuthRequired(cmd, true)
 	return cmd
 }
 
@@ -194,6 +204,7 @@ func newProfileAddressesCommand(deps Dependencies) *cobra.Command {
 	cmd.AddCommand(newProfileAddressesRemoveCommand(deps))
 	cmd.AddCommand(newProfileAddressesUseCommand(deps))
 	cmd.AddCommand(newProfileAddressesUpdateCommand(deps))
+	markAuthRequired(cmd, true)
 	return cmd
 }
 
@@ -412,6 +423,8 @@ func newProfileAddressesUseCommand(deps Dependencies) *cobra.Command {
 		},
 	}
 	addGlobalFlags(cmd, &flags)
+	// Only stores the address id in the local profile.
+	markAuthRequired(cmd, false)
 	return cmd
 }
 
@@ -808,6 +821,7 @@ func normalizePaymentMethod(method map[string]any, maskSensitive bool) map[strin
 			nestedMapValue(method, "is_available_for_checkout", "is_available", "enabled"),
 			true,
 		),
+		"corporate": corporatePolicy(method, methodType),
 	}
 }
 
@@ -823,7 +837,9 @@ func nestedMapValue(raw any, keys ...string) any {
 	visit = func(value any) any {
 		switch typed := value.(type) {
 		case map[string]any:
-			for key, nested := range typed {
+			keys := slices.Sorted(maps.Keys(typed))
+			for _, key := range keys {
+				nested := typed[key]
 				if _, ok := targets[strings.ToLower(strings.TrimSpace(key))]; !ok {
 					continue
 				}
@@ -832,8 +848,8 @@ func nestedMapValue(raw any, keys ...string) any {
 				}
 				return nested
 			}
-			for _, nested := range typed {
-				if found := visit(nested); found != nil {
+			for _, key := range keys {
+				if found := visit(typed[key]); found != nil {
 					return found
 				}
 			}
@@ -918,17 +934,32 @@ func paymentLabelType(label string) string {
 	return builder.String()
 }
 
-func buildProfilePaymentsTable(data map[string]any) string {
-	headers := []string{"Label", "Type", "Default", "Available"}
+func buildProfilePaymentsTable(data map[string]any, includeBalances bool) string {
+	headers := []string{"Label", "Type", "Default", "Available", "Work account"}
+	if includeBalances {
+		headers = append(headers, "Balance", "Expires")
+	}
 	rows := [][]string{}
 	for _, value := range asSlice(data["methods"]) {
 		method := asMap(value)
-		rows = append(rows, []string{
+		row := []string{
 			fallbackString(asString(method["label"]), "-"),
 			fallbackString(asString(method["type"]), "-"),
 			boolToYesNo(asBool(method["is_default"])),
 			boolToYesNo(asBool(method["is_available_for_checkout"])),
-		})
+			"-",
+		}
+		if corporate := asMap(method["corporate"]); corporate != nil {
+			row[4] = fallbackString(asString(corporate["company_name"]), "yes")
+		}
+		if includeBalances {
+			balance := asMap(method["balance"])
+			row = append(row,
+				fallbackString(asString(coalesceAny(balance["formatted_amount"], balance["amount"])), "-"),
+				fallbackString(asString(balance["expires_at"]), "-"),
+			)
+		}
+		rows = append(rows, row)
 	}
 	return output.RenderTable("Payment methods", headers, rows)
 }
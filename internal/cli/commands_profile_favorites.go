@@ -22,18 +22,20 @@ func newProfileFavoritesCommand(deps Dependencies) *cobra.Command {
 	var lon float64
 	var latSet bool
 	var lonSet bool
+	var pick rowPick
 
 	cmd := &cobra.Command{
 		Use:     "favorites",
 		Aliases: []string{"favourites"},
 		Short:   "List and manage favourite venues.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runProfileFavoritesList(cmd, deps, flags, lat, lon, latSet, lonSet)
+			return runProfileFavoritesList(cmd, deps, flags, lat, lon, latSet, lonSet, pick)
 		},
 	}
 
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for favorites listing. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for favorites listing. Provide together with --lat.")
+	addRowPickFlags(cmd, &pick, "venue slug")
 	addGlobalFlags(cmd, &flags)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		latSet = cmd.Flags().Changed("lat")
@@ -43,6 +45,8 @@ func newProfileFavoritesCommand(deps Dependencies) *cobra.Command {
 	cmd.AddCommand(newProfileFavoritesListCommand(deps))
 	cmd.AddCommand(newProfileFavoritesAddCommand(deps))
 	cmd.AddCommand(newProfileFavoritesRemoveCommand(deps))
+	cmd.AddCommand(newProfileFavoritesHoursCommand(deps))
+	markAuthRequired(cmd, true)
 	return cmd
 }
 
@@ -52,17 +56,19 @@ func newProfileFavoritesListCommand(deps Dependencies) *cobra.Command {
 	var lon float64
 	var latSet bool
 	var lonSet bool
+	var pick rowPick
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Show favourite venues for the authenticated account.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runProfileFavoritesList(cmd, deps, flags, lat, lon, latSet, lonSet)
+			return runProfileFavoritesList(cmd, deps, flags, lat, lon, latSet, lonSet, pick)
 		},
 	}
 
 	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for favorites listing. Provide together with --lon.")
 	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for favorites listing. Provide together with --lat.")
+	addRowPickFlags(cmd, &pick, "venue slug")
 	addGlobalFlags(cmd, &flags)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		latSet = cmd.Flags().Changed("lat")
@@ -79,6 +85,7 @@ func runProfileFavoritesList(
 	lon float64,
 	latSet bool,
 	lonSet bool,
+	pick rowPick,
 ) error {
 	format, err := parseOutputFormat(flags.Format)
 	if err != nil {
@@ -133,6 +140,10 @@ func runProfileFavoritesList(
 	}
 	data["count"] = len(asSlice(data["favorites"]))
 
+	if pick.enabled() {
+		return emitPickedRow(cmd, format, profile, flags.Locale, flags.Output, pick, asSlice(data["favorites"]), venueRowPicker())
+	}
+
 	if format == output.FormatTable {
 		return writeTable(cmd, buildProfileFavoritesTable(data), flags.Output)
 	}
@@ -187,7 +198,11 @@ func runFavoriteVenueMutation(
 		return err
 	}
 
-	resolution, err := resolveFavoriteVenueReference(cmd.Context(), deps, flags.Profile, flags.Address, &auth, venueInput)
+	override, code, err := locationOverride(cmd.Context(), deps, cmd, flags.Address)
+	if err != nil {
+		return emitError(cmd, format, profileName, flags.Locale, flags.Output, code, err.Error())
+	}
+	resolution, err := resolveFavoriteVenueReference(cmd.Context(), deps, flags.Profile, override, &auth, venueInput)
 	if err != nil {
 		return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
 	}
@@ -250,7 +265,7 @@ func resolveFavoriteVenueReference(
 	ctx context.Context,
 	deps Dependencies,
 	selectedProfile string,
-	addressOverride string,
+	override *domain.Location,
 	auth *woltgateway.AuthContext,
 	rawInput string,
 ) (favoriteVenueReference, error) {
@@ -259,6 +274,13 @@ func resolveFavoriteVenueReference(
 		return favoriteVenueReference{}, fmt.Errorf("venue id or slug is required")
 	}
 	candidate := venueSlugFromInput(input)
+	if isAbsoluteURL(input) {
+		slug, err := resolveVenueLinkSlug(ctx, deps, input)
+		if err != nil {
+			return favoriteVenueReference{}, err
+		}
+		candidate = slug
+	}
 	if candidate == "" {
 		return favoriteVenueReference{}, fmt.Errorf("venue id or slug is required")
 	}
@@ -268,6 +290,9 @@ func resolveFavoriteVenueReference(
 		}, nil
 	}
 
+	if venue, ok := knownVenueBySlug(deps, candidate); ok {
+		return favoriteVenueReference{VenueID: venue.VenueID, Slug: venue.Slug, Name: venue.Name}, nil
+	}
 	if payload, err := deps.Wolt.VenuePageStatic(ctx, candidate); err == nil {
 		reference := favoriteVenueReference{
 			VenueID: strings.TrimSpace(asString(coalesceAny(
@@ -289,11 +314,12 @@ func resolveFavoriteVenueReference(
 			))),
 		}
 		if reference.VenueID != "" {
+			rememberVenues(deps, knownVenue{VenueID: reference.VenueID, Slug: reference.Slug, Name: reference.Name})
 			return reference, nil
 		}
 	}
 
-	location, err := resolveFavoriteVenueLookupLocation(ctx, deps, selectedProfile, addressOverride, auth)
+	location, err := resolveFavoriteVenueLookupLocation(ctx, deps, selectedProfile, override, auth)
 	if err != nil {
 		return favoriteVenueReference{}, fmt.Errorf("unable to resolve venue slug %q to venue id", candidate)
 	}
@@ -331,14 +357,11 @@ func resolveFavoriteVenueLookupLocation(
 	ctx context.Context,
 	deps Dependencies,
 	selectedProfile string,
-	addressOverride string,
+	override *domain.Location,
 	auth *woltgateway.AuthContext,
 ) (domain.Location, error) {
-	if trimmed := strings.TrimSpace(addressOverride); trimmed != "" {
-		if deps.Location == nil {
-			return domain.Location{}, fmt.Errorf("location resolver is not available")
-		}
-		return deps.Location.Get(ctx, trimmed)
+	if override != nil {
+		return *override, nil
 	}
 	profile, err := deps.Profiles.Find(ctx, selectedProfile)
 	if err != nil {
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const favoritesHoursMaxDays = 14
+
+func newProfileFavoritesHoursCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var lat float64
+	var lon float64
+	var timezone string
+	var nowValue string
+	var days int
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "hours",
+		Short: "Show opening hours of every favourite venue.",
+		Long: "Show opening hours of every favourite venue.\n\n" +
+			"Venue details are fetched concurrently. Each favourite lists its weekly opening_windows, open_now at\n" +
+			"the current time (or --now, read in each venue's timezone), and openings: the concrete windows of the\n" +
+			"next --days days. --format ics writes those openings as an iCalendar file with one event per window.\n" +
+			"Favourites whose hours cannot be read are listed under errors.",
+		Example: "wolt profile favorites hours\n" +
+			"wolt profile favorites hours --now 2026-02-16T19:00 --format json\n" +
+			"wolt profile favorites hours --days 3 --format ics --output favorites.ics",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if _, err := parseVenueNow(nowValue, time.UTC, deps.now()); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			if strings.TrimSpace(timezone) != "" {
+				if _, err := time.LoadLocation(strings.TrimSpace(timezone)); err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("unknown timezone %q", timezone))
+				}
+			}
+			if days < 1 || days > favoritesHoursMaxDays {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("--days must be between 1 and %d", favoritesHoursMaxDays))
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+
+			var latPtr *float64
+			var lonPtr *float64
+			if cmd.Flags().Changed("lat") {
+				latPtr = &lat
+			}
+			if cmd.Flags().Changed("lon") {
+				lonPtr = &lon
+			}
+			location, profile, err := resolveLocation(
+				cmd.Context(),
+				deps,
+				latPtr,
+				lonPtr,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+			payload, warnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.FavoriteVenues(cmd.Context(), location, authCtx)
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			favorites := extractFavoriteVenues(payload)
+			data := collectFavoriteHours(cmd, deps, favorites, timezone, nowValue, days)
+			if failed := len(asSlice(data["errors"])); failed > 0 {
+				warnings = append(warnings, fmt.Sprintf("hours of %d of %d favourites could not be read; see data.errors", failed, len(favorites)))
+			}
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case output.FormatTable:
+				return writeTable(cmd, buildFavoriteHoursTable(data), flags.Output)
+			case output.FormatICS:
+				return writeFavoriteHoursICS(cmd, data, warnings, deps.now(), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().Float64Var(&lat, "lat", 0, "Latitude override for favorites listing. Provide together with --lon.")
+	cmd.Flags().Float64Var(&lon, "lon", 0, "Longitude override for favorites listing. Provide together with --lat.")
+	cmd.Flags().StringVar(&timezone, "timezone", "", "Timezone override for every venue")
+	cmd.Flags().StringVar(&nowValue, "now", "", "Compare opening hours against this venue-local time instead of now (YYYY-MM-DDTHH:MM)")
+	cmd.Flags().IntVar(&days, "days", 7, fmt.Sprintf("Days of openings to list from today (1-%d).", favoritesHoursMaxDays))
+	addStrictFlag(cmd, &strict)
+	addGlobalFlags(cmd, &flags)
+	enableICS(cmd)
+	return cmd
+}
+
+type favoriteHoursResult struct {
+	row map[string]any
+	err error
+}
+
+// collectFavoriteHours reads the opening hours of favorites, keeping the
+// favourites order. Venue details load concurrently through the venue cache.
+// Venues whose details fail are listed under errors; failures other than a
+// venue that is gone (404/410) are also recorded as partial failures.
+func collectFavoriteHours(cmd *cobra.Command, deps Dependencies, favorites []any, timezone string, nowValue string, days int) map[string]any {
+	ctx := cmd.Context()
+	venueIDs := make([]string, 0, len(favorites))
+	for _, value := range favorites {
+		venueIDs = append(venueIDs, asString(asMap(value)["venue_id"]))
+	}
+	restaurants := loadRestaurants(ctx, deps, venueIDs)
+
+	results := make([]favoriteHoursResult, len(favorites))
+	for idx := range favorites {
+		results[idx] = favoriteHours(ctx, deps, asMap(favorites[idx]), restaurants, timezone, nowValue, days)
+	}
+
+	rows := []any{}
+	failed := []any{}
+	openNow := 0
+	for idx, res := range results {
+		if res.err != nil {
+			favorite := asMap(favorites[idx])
+			failed = append(failed, map[string]any{
+				"venue_id": favorite["venue_id"],
+				"slug":     favorite["slug"],
+				"message":  res.err.Error(),
+			})
+			continue
+		}
+		if res.row["open_now"] == true {
+			openNow++
+		}
+		rows = append(rows, res.row)
+	}
+	return map[string]any{
+		"favorites":      rows,
+		"count":          len(rows),
+		"open_now_count": openNow,
+		"requested":      len(favorites),
+		"days":           days,
+		"errors":         failed,
+	}
+}
+
+func favoriteHours(
+	ctx context.Context,
+	deps Dependencies,
+	favorite map[string]any,
+	restaurants map[string]restaurantLookup,
+	timezone string,
+	nowValue string,
+	days int,
+) favoriteHoursResult {
+	venueID := strings.TrimSpace(asString(favorite["venue_id"]))
+	if venueID == "" {
+		return favoriteHoursResult{err: fmt.Errorf("favourite has no venue id")}
+	}
+	lookup := restaurants[venueID]
+	if lookup.err != nil {
+		if !isRecoverableRestaurantError(lookup.err) {
+			recordPartialFailure(ctx, "favorites hours", lookup.err)
+		}
+		return favoriteHoursResult{err: lookup.err}
+	}
+	loc, _, err := observability.VenueLocation(lookup.restaurant, timezone)
+	if err != nil {
+		return favoriteHoursResult{err: err}
+	}
+	now, _ := parseVenueNow(nowValue, loc, deps.now())
+	row, err := observability.BuildVenueHours(lookup.restaurant, timezone, now)
+	if err != nil {
+		return favoriteHoursResult{err: err}
+	}
+	openings := []any{}
+	for _, window := range observability.VenueOpenings(lookup.restaurant, now, days) {
+		openings = append(openings, map[string]any{
+			"start": window[0].Format(time.RFC3339),
+			"end":   window[1].Format(time.RFC3339),
+		})
+	}
+	row["venue_id"] = fallbackString(asString(row["venue_id"]), venueID)
+	row["slug"] = favorite["slug"]
+	row["name"] = favorite["name"]
+	row["address"] = favorite["address"]
+	row["openings"] = openings
+	delete(row, "delivery_windows")
+	return favoriteHoursResult{row: row}
+}
+
+// favoriteTodayHours lists the row's openings that start on the day of its
+// compared time, in the venue timezone, as "HH:MM-HH:MM".
+func favoriteTodayHours(row map[string]any) string {
+	now, err := time.Parse(time.RFC3339, asString(row["now"]))
+	if err != nil {
+		return "-"
+	}
+	today := now.Format("2006-01-02")
+	spans := []string{}
+	for _, value := range asSlice(row["openings"]) {
+		opening := asMap(value)
+		start, startErr := time.Parse(time.RFC3339, asString(opening["start"]))
+		end, endErr := time.Parse(time.RFC3339, asString(opening["end"]))
+		if startErr != nil || endErr != nil || start.Format("2006-01-02") != today {
+			continue
+		}
+		spans = append(spans, start.Format("15:04")+"-"+end.Format("15:04"))
+	}
+	if len(spans) == 0 {
+		return "closed"
+	}
+	return strings.Join(spans, ", ")
+}
+
+func buildFavoriteHoursTable(data map[string]any) string {
+	headers := []string{"Name", "Slug", "Open now", "Today", "Timezone"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["favorites"]) {
+		row := asMap(value)
+		openNow := "-"
+		if state, ok := row["open_now"].(bool); ok {
+			openNow = map[bool]string{true: "yes", false: "no"}[state]
+		}
+		rows = append(rows, []string{
+			fallbackString(asString(row["name"]), "-"),
+			fallbackString(asString(row["slug"]), "-"),
+			openNow,
+			favoriteTodayHours(row),
+			asString(row["timezone"]),
+		})
+	}
+	for _, value := range asSlice(data["errors"]) {
+		failure := asMap(value)
+		rows = append(rows, []string{"-", fallbackString(asString(failure["slug"]), "-"), "error: " + asString(failure["message"]), "-", "-"})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"-", "-", "-", "-", "-"})
+	}
+	title := fmt.Sprintf("Favourite venue hours (%d of %d open now)", asInt(data["open_now_count"]), asInt(data["count"]))
+	return output.RenderTable(title, headers, rows)
+}
+
+// writeFavoriteHoursICS writes every favourite's openings as calendar events,
+// ordered by start. Warnings become X-WOLT-WARNING properties.
+func writeFavoriteHoursICS(cmd *cobra.Command, data map[string]any, warnings []string, stamp time.Time, outputPath string) error {
+	events := []output.ICSEvent{}
+	for _, value := range asSlice(data["favorites"]) {
+		row := asMap(value)
+		name := fallbackString(asString(row["name"]), asString(row["slug"]))
+		for _, opening := range asSlice(row["openings"]) {
+			start, startErr := time.Parse(time.RFC3339, asString(asMap(opening)["start"]))
+			end, endErr := time.Parse(time.RFC3339, asString(asMap(opening)["end"]))
+			if startErr != nil || endErr != nil {
+				continue
+			}
+			events = append(events, output.ICSEvent{
+				UID:         fmt.Sprintf("%s-%d@wolt-cli", asString(row["venue_id"]), start.Unix()),
+				Summary:     name + " open",
+				Description: fmt.Sprintf("wolt venue show %s (%s)", asString(row["slug"]), asString(row["timezone"])),
+				Location:    asString(row["address"]),
+				Start:       start,
+				End:         end,
+			})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	properties := [][2]string{}
+	for _, warning := range warnings {
+		properties = append(properties, [2]string{"warning", warning})
+	}
+	return output.WriteOutput(cmd.OutOrStdout(), output.RenderICS("Wolt favourites", events, properties, stamp), outputPath)
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
 	"github.com/mekedron/wolt-cli/internal/service/output"
@@ -16,46 +18,46 @@ const (
 
 func newProfileOrdersCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
-	var limit int
-	var pageToken string
-	var statusFilter string
+	var query profileOrdersQuery
+	var pick rowPick
 
 	cmd := &cobra.Command{
 		Use:     "orders",
 		Aliases: []string{"history", "order-history"},
 		Short:   "Browse account order history.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runProfileOrdersList(cmd, deps, flags, limit, pageToken, statusFilter)
+			return runProfileOrdersList(cmd, deps, flags, query, pick)
 		},
 	}
 
-	cmd.Flags().IntVar(&limit, "limit", profileOrdersDefaultLimit, "Number of orders to return per page (1-50).")
-	cmd.Flags().StringVar(&pageToken, "page-token", "", "Pagination token for older orders.")
-	cmd.Flags().StringVar(&statusFilter, "status", "", "Filter orders by status (case-insensitive).")
+	addProfileOrdersQueryFlags(cmd, &query)
+	addRowPickFlags(cmd, &pick, "purchase ID")
 	addGlobalFlags(cmd, &flags)
+	enableSQLiteExport(cmd)
 	cmd.AddCommand(newProfileOrdersListCommand(deps))
 	cmd.AddCommand(newProfileOrdersShowCommand(deps))
+	cmd.AddCommand(newProfileOrdersExportCommand(deps))
+	markAuthRequired(cmd, true)
 	return cmd
 }
 
 func newProfileOrdersListCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
-	var limit int
-	var pageToken string
-	var statusFilter string
+	var query profileOrdersQuery
+	var pick rowPick
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List account order history entries.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runProfileOrdersList(cmd, deps, flags, limit, pageToken, statusFilter)
+			return runProfileOrdersList(cmd, deps, flags, query, pick)
 		},
 	}
 
-	cmd.Flags().IntVar(&limit, "limit", profileOrdersDefaultLimit, "Number of orders to return per page (1-50).")
-	cmd.Flags().StringVar(&pageToken, "page-token", "", "Pagination token for older orders.")
-	cmd.Flags().StringVar(&statusFilter, "status", "", "Filter orders by status (case-insensitive).")
+	addProfileOrdersQueryFlags(cmd, &query)
+	addRowPickFlags(cmd, &pick, "purchase ID")
 	addGlobalFlags(cmd, &flags)
+	enableSQLiteExport(cmd)
 	return cmd
 }
 
@@ -63,9 +65,8 @@ func runProfileOrdersList(
 	cmd *cobra.Command,
 	deps Dependencies,
 	flags globalFlags,
-	limit int,
-	pageToken string,
-	statusFilter string,
+	query profileOrdersQuery,
+	pick rowPick,
 ) error {
 	format, err := parseOutputFormat(flags.Format)
 	if err != nil {
@@ -77,7 +78,7 @@ func runProfileOrdersList(
 		return err
 	}
 
-	if limit < 1 || limit > profileOrdersMaxLimit {
+	if query.limit < 1 || query.limit > profileOrdersMaxLimit {
 		return emitError(
 			cmd,
 			format,
@@ -88,45 +89,79 @@ func runProfileOrdersList(
 			fmt.Sprintf("limit must be between 1 and %d", profileOrdersMaxLimit),
 		)
 	}
+	if err := query.validate(); err != nil {
+		return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+	}
 
-	payload, authWarnings, err := invokeWithAuthAutoRefresh(
-		cmd.Context(),
-		deps,
-		flags,
-		&auth,
-		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
-			return deps.Wolt.OrderHistory(
-				cmd.Context(),
-				authCtx,
-				woltgateway.OrderHistoryOptions{Limit: limit, PageToken: pageToken},
-			)
-		},
-	)
-	if err != nil {
-		return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+	orders := []any{}
+	warnings := []string{}
+	pageToken := query.pageToken
+	pages := 0
+	for {
+		payload, authWarnings, err := invokeWithAuthAutoRefresh(
+			cmd.Context(),
+			deps,
+			flags,
+			&auth,
+			func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+				return deps.Wolt.OrderHistory(
+					cmd.Context(),
+					authCtx,
+					woltgateway.OrderHistoryOptions{Limit: lowBandwidthPageLimit(cmd, "limit", query.limit), PageToken: pageToken},
+				)
+			},
+		)
+		warnings = append(warnings, authWarnings...)
+		if err != nil {
+			return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+		}
+		pages++
+		orders = append(orders, extractOrderHistoryOrders(payload, query.status)...)
+		pageToken = strings.TrimSpace(asString(payload["next_page_token"]))
+		if pageToken == "" || pages >= query.maxPages {
+			break
+		}
 	}
+	orders, filterWarnings := query.apply(orders)
+	warnings = append(warnings, filterWarnings...)
 
-	orders := extractOrderHistoryOrders(payload, statusFilter)
 	data := map[string]any{
 		"orders": orders,
 		"count":  len(orders),
+		"pages":  pages,
 	}
-	if token := strings.TrimSpace(asString(payload["next_page_token"])); token != "" {
-		data["next_page_token"] = token
+	if pageToken != "" {
+		data["next_page_token"] = pageToken
 	}
-	if filter := strings.TrimSpace(statusFilter); filter != "" {
+	if filter := strings.TrimSpace(query.status); filter != "" {
 		data["status_filter"] = strings.ToLower(filter)
 	}
+	if venue := strings.TrimSpace(query.venue); venue != "" {
+		data["venue_filter"] = venue
+	}
+	if query.minTotal != "" || query.maxTotal != "" {
+		data["total_filter"] = map[string]any{"min": emptyToNil(query.minTotal), "max": emptyToNil(query.maxTotal)}
+	}
+	if query.sort != "" {
+		data["sort"] = query.sort
+	}
 
+	if err := exportSQLiteRows(cmd, deps, sqliteOrderExport, orders); err != nil {
+		return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_SQLITE_EXPORT_ERROR", err.Error())
+	}
+	if pick.enabled() {
+		return emitPickedRow(cmd, format, profileName, flags.Locale, flags.Output, pick, orders, orderRowPicker())
+	}
 	if format == output.FormatTable {
 		return writeTable(cmd, buildProfileOrdersTable(data), flags.Output)
 	}
-	env := output.BuildEnvelope(profileName, flags.Locale, data, authWarnings, nil)
+	env := output.BuildEnvelope(profileName, flags.Locale, data, dedupeStrings(warnings), nil)
 	return writeMachinePayload(cmd, env, format, flags.Output)
 }
 
 func newProfileOrdersShowCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
+	var expense expenseFlags
 
 	cmd := &cobra.Command{
 		Use:   "show <purchase-id>",
@@ -160,7 +195,21 @@ func newProfileOrdersShowCommand(deps Dependencies) *cobra.Command {
 				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
 			}
 
+			if expense.set() {
+				entry := expense.entry("profile orders show", deps.now())
+				entry.PurchaseID = purchaseID
+				if err := appendAuditEntry(deps, entry); err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_AUDIT_LOG_ERROR", err.Error())
+				}
+			}
+
 			data := buildOrderHistoryDetail(payload)
+			authWarnings = append(authWarnings, woltPlusBenefitWarnings(cmd, deps, flags, &auth, orderDetailWoltPlusOrder(payload))...)
+			if tags, err := loadPurchaseExpenseTags(deps); err != nil {
+				authWarnings = append(authWarnings, fmt.Sprintf("expense tags unavailable: %v", err))
+			} else if tag, ok := tags[purchaseID]; ok {
+				data["expense"] = expenseTagsData(tag)
+			}
 			if format == output.FormatTable {
 				return writeTable(cmd, buildProfileOrderDetailTable(data), flags.Output)
 			}
@@ -168,7 +217,9 @@ func newProfileOrdersShowCommand(deps Dependencies) *cobra.Command {
 			return writeMachinePayload(cmd, env, format, flags.Output)
 		},
 	}
+	addExpenseFlags(cmd, &expense)
 	addGlobalFlags(cmd, &flags)
+	enableHASensor(cmd, "order")
 	return cmd
 }
 
@@ -184,11 +235,18 @@ func extractOrderHistoryOrders(payload map[string]any, statusFilter string) []an
 		if filter != "" && !strings.EqualFold(status, filter) {
 			continue
 		}
+		receivedAt := strings.TrimSpace(asString(order["received_at"]))
+		// received_at is preformatted upstream; --tz and --time-format need a
+		// real timestamp, which the payment time provides.
+		if paidAt := asInt(order["payment_time_ts"]); paidAt > 0 && output.TimeDisplayActive() {
+			receivedAt = time.UnixMilli(int64(paidAt)).UTC().Format(time.RFC3339)
+		}
 		rows = append(rows, map[string]any{
 			"purchase_id":         strings.TrimSpace(asString(coalesceAny(order["purchase_id"], order["order_id"], order["id"]))),
-			"received_at":         strings.TrimSpace(asString(order["received_at"])),
+			"received_at":         receivedAt,
 			"status":              status,
 			"venue_name":          strings.TrimSpace(asString(order["venue_name"])),
+			"venue_slug":          strings.TrimSpace(asString(order["venue_slug"])),
 			"total_amount":        strings.TrimSpace(asString(coalesceAny(order["total_amount"], order["total"]))),
 			"is_active":           asBool(order["is_active"]),
 			"items_summary":       orderHistoryItemsSummary(order),
@@ -200,6 +258,22 @@ func extractOrderHistoryOrders(payload map[string]any, statusFilter string) []an
 	return rows
 }
 
+func orderRowPicker() rowPicker {
+	return rowPicker{
+		Label: func(row map[string]any) string {
+			return fmt.Sprintf(
+				"%s  %s  %s",
+				fallbackString(asString(row["received_at"]), "-"),
+				fallbackString(asString(row["venue_name"]), "-"),
+				fallbackString(asString(row["total_amount"]), "-"),
+			)
+		},
+		Identifiers: func(row map[string]any) []string {
+			return []string{asString(row["purchase_id"])}
+		},
+	}
+}
+
 func orderHistoryItemsSummary(order map[string]any) string {
 	if rawSummary, ok := order["items"].(string); ok {
 		summary := strings.TrimSpace(rawSummary)
@@ -270,10 +344,11 @@ func buildOrderHistoryDetail(payload map[string]any) map[string]any {
 			"tokens":      orderHistoryAmount(asInt(payload["tokens"]), currency),
 			"total":       orderHistoryAmount(asInt(payload["total_price"]), currency),
 		},
-		"items":      extractOrderHistoryDetailItems(payload, currency),
-		"payments":   extractOrderHistoryDetailPayments(payload, currency),
-		"discounts":  extractOrderHistoryAdjustmentRows(asSlice(payload["discounts"]), currency),
-		"surcharges": extractOrderHistoryAdjustmentRows(asSlice(payload["surcharges"]), currency),
+		"items":         extractOrderHistoryDetailItems(payload, currency),
+		"payments":      extractOrderHistoryDetailPayments(payload, currency),
+		"discounts":     extractOrderHistoryAdjustmentRows(asSlice(payload["discounts"]), currency),
+		"surcharges":    extractOrderHistoryAdjustmentRows(asSlice(payload["surcharges"]), currency),
+		"tax_breakdown": buildTaxBreakdown(payload, asSlice(payload["items"]), currency),
 		"delivery": map[string]any{
 			"alias":   strings.TrimSpace(asString(delivery["alias"])),
 			"address": strings.TrimSpace(asString(coalesceAny(delivery["street"], delivery["address"]))),
@@ -286,26 +361,116 @@ func buildOrderHistoryDetail(payload map[string]any) map[string]any {
 }
 
 func extractOrderHistoryDetailItems(payload map[string]any, currency string) []any {
-	rows := make([]any, 0)
+	items := make([]map[string]any, 0)
 	for _, value := range asSlice(payload["items"]) {
-		item := asMap(value)
-		if item == nil {
-			continue
+		if item := asMap(value); item != nil {
+			items = append(items, item)
 		}
+	}
+	attributed := attributeOrderDiscounts(asSlice(payload["discounts"]), items)
+
+	rows := make([]any, 0, len(items))
+	for idx, item := range items {
 		price := asInt(item["price"])
 		endAmount := asInt(item["end_amount"])
+		applied := make([]any, 0)
+		for _, value := range asSlice(coalesceAny(item["applied_discounts"], item["discounts"])) {
+			if discount := asMap(value); discount != nil && asInt(discount["amount"]) != 0 {
+				applied = append(applied, appliedDiscountRow(discount, asInt(discount["amount"]), "line", currency))
+			}
+		}
+		for _, share := range attributed[idx] {
+			applied = append(applied, appliedDiscountRow(share.discount, share.amount, share.source, currency))
+		}
 		rows = append(rows, map[string]any{
-			"id":         strings.TrimSpace(asString(item["id"])),
-			"name":       strings.TrimSpace(asString(item["name"])),
-			"count":      asInt(item["count"]),
-			"price":      orderHistoryAmount(price, currency),
-			"line_total": orderHistoryAmount(endAmount, currency),
-			"options":    asSlice(item["options"]),
+			"id":                strings.TrimSpace(asString(item["id"])),
+			"name":              strings.TrimSpace(asString(item["name"])),
+			"count":             asInt(item["count"]),
+			"price":             orderHistoryAmount(price, currency),
+			"line_total":        orderHistoryAmount(endAmount, currency),
+			"options":           asSlice(item["options"]),
+			"applied_discounts": applied,
 		})
 	}
 	return rows
 }
 
+// orderDiscountShare is the part of an order-level discount attributed to
+// one line.
+type orderDiscountShare struct {
+	discount map[string]any
+	amount   int
+	source   string
+}
+
+// attributeOrderDiscounts spreads order-level discounts that name the items
+// they apply to over those lines, keyed by line index. A discount naming a
+// single line is attributed in full ("order"); one naming several is split
+// in proportion to the lines' undiscounted amounts ("order_split"), with the
+// rounding remainder on the last line. Discounts without an item list stay
+// order-level only.
+func attributeOrderDiscounts(discounts []any, items []map[string]any) map[int][]orderDiscountShare {
+	out := map[int][]orderDiscountShare{}
+	for _, value := range discounts {
+		discount := asMap(value)
+		if discount == nil || asInt(discount["amount"]) == 0 {
+			continue
+		}
+		lines := []int{}
+		for _, rawID := range asSlice(coalesceAny(discount["items"], discount["item_ids"])) {
+			itemID := strings.TrimSpace(asString(coalesceAny(asMap(rawID)["id"], rawID)))
+			for idx, item := range items {
+				if itemID != "" && strings.TrimSpace(asString(item["id"])) == itemID && !slices.Contains(lines, idx) {
+					lines = append(lines, idx)
+				}
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		amount := asInt(discount["amount"])
+		if len(lines) == 1 {
+			out[lines[0]] = append(out[lines[0]], orderDiscountShare{discount: discount, amount: amount, source: "order"})
+			continue
+		}
+		base := 0
+		for _, idx := range lines {
+			base += orderLineBaseAmount(items[idx])
+		}
+		remaining := amount
+		for position, idx := range lines {
+			share := remaining
+			if position < len(lines)-1 {
+				if base > 0 {
+					share = amount * orderLineBaseAmount(items[idx]) / base
+				} else {
+					share = amount / len(lines)
+				}
+			}
+			remaining -= share
+			out[idx] = append(out[idx], orderDiscountShare{discount: discount, amount: share, source: "order_split"})
+		}
+	}
+	return out
+}
+
+func orderLineBaseAmount(item map[string]any) int {
+	count := asInt(item["count"])
+	if count < 1 {
+		count = 1
+	}
+	return asInt(item["price"]) * count
+}
+
+func appliedDiscountRow(discount map[string]any, amount int, source string, currency string) map[string]any {
+	return map[string]any{
+		"campaign_id": strings.TrimSpace(asString(coalesceAny(discount["campaign_id"], discount["id"]))),
+		"title":       strings.TrimSpace(asString(coalesceAny(discount["title"], discount["name"]))),
+		"amount":      orderHistoryAmount(amount, currency),
+		"source":      source,
+	}
+}
+
 func extractOrderHistoryDetailPayments(payload map[string]any, currency string) []any {
 	rows := make([]any, 0)
 	for _, value := range asSlice(payload["payments"]) {
@@ -362,6 +527,12 @@ func buildProfileOrderDetailTable(data map[string]any) string {
 		{"Venue", fallbackString(asString(venue["name"]), "-")},
 		{"Total", fallbackString(asString(asMap(totals["total"])["formatted_amount"]), "-")},
 	}
+	if expense := asMap(data["expense"]); expense != nil {
+		rows = append(rows,
+			[]string{"Expense code", fallbackString(asString(expense["expense_code"]), "-")},
+			[]string{"Cost center", fallbackString(asString(expense["cost_center"]), "-")},
+		)
+	}
 
 	for _, value := range asSlice(data["items"]) {
 		item := asMap(value)
@@ -374,6 +545,17 @@ func buildProfileOrderDetailTable(data map[string]any) string {
 				fallbackString(asString(asMap(item["line_total"])["formatted_amount"]), "-"),
 			),
 		})
+		for _, applied := range asSlice(item["applied_discounts"]) {
+			discount := asMap(applied)
+			rows = append(rows, []string{
+				"  Discount",
+				fmt.Sprintf(
+					"%s (-%s)",
+					fallbackString(asString(discount["title"]), fallbackString(asString(discount["campaign_id"]), "-")),
+					fallbackString(asString(asMap(discount["amount"])["formatted_amount"]), "-"),
+				),
+			})
+		}
 	}
 	for _, value := range asSlice(data["payments"]) {
 		payment := asMap(value)
@@ -387,5 +569,9 @@ func buildProfileOrderDetailTable(data map[string]any) string {
 		})
 	}
 
-	return output.RenderTable("Order details", headers, rows)
+	text := output.RenderTable("Order details", headers, rows)
+	if breakdown := asMap(data["tax_breakdown"]); breakdown != nil {
+		text += "\n\n" + buildTaxBreakdownTable(breakdown)
+	}
+	return text
 }
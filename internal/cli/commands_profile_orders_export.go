@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	profileOrdersExportDefaultPages = 10
+	beancountDefaultAccount         = "Expenses:Food:Delivery"
+	beancountDefaultFundingAccount  = "Liabilities:CreditCard"
+)
+
+func newProfileOrdersExportCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var since string
+	var until string
+	var statusFilter string
+	var maxPages int
+	var account string
+	var fundingAccount string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export order history with locally recorded expense tags.",
+		Long: "Export order history with locally recorded expense tags.\n\n" +
+			"Walks order history pages (newest first) and adds the expense_code and cost_center " +
+			"recorded with --expense-code/--cost-center on `profile orders show`.\n\n" +
+			"--format beancount reads each order's details and prints one Beancount transaction per paid order.",
+		Example: "wolt profile orders export --since 2026-02-01 --until 2026-02-28 --format json\n" +
+			"wolt profile orders export --since 2026-02-01 --format beancount --account Expenses:Food:Delivery >> ledger.beancount",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+			if maxPages < 1 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--max-pages must be at least 1")
+			}
+			if format == output.FormatBeancount {
+				for _, name := range []string{account, fundingAccount} {
+					if !output.ValidBeancountAccount(name) {
+						return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf(
+							"%q is not a Beancount account; use a name such as %s", name, beancountDefaultAccount,
+						))
+					}
+				}
+			}
+			start, end, err := parseOrderExportRange(since, until)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			tags, err := loadPurchaseExpenseTags(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_AUDIT_LOG_ERROR", err.Error())
+			}
+
+			orders := []any{}
+			warnings := []string{}
+			pageToken := ""
+			pages := 0
+			truncated := false
+			for {
+				payload, authWarnings, err := invokeWithAuthAutoRefresh(
+					cmd.Context(),
+					deps,
+					flags,
+					&auth,
+					func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+						return deps.Wolt.OrderHistory(
+							cmd.Context(),
+							authCtx,
+							woltgateway.OrderHistoryOptions{Limit: profileOrdersMaxLimit, PageToken: pageToken},
+						)
+					},
+				)
+				warnings = append(warnings, authWarnings...)
+				if err != nil {
+					return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+				}
+				pages++
+				reachedStart := false
+				for _, value := range extractOrderHistoryOrders(payload, statusFilter) {
+					row := asMap(value)
+					paidAt := time.UnixMilli(int64(asInt(row["payment_time_ts"])))
+					if !start.IsZero() && paidAt.Before(start) {
+						reachedStart = true
+						continue
+					}
+					if !end.IsZero() && !paidAt.Before(end) {
+						continue
+					}
+					tag := tags[asString(row["purchase_id"])]
+					row["expense_code"] = emptyToNil(tag.ExpenseCode)
+					row["cost_center"] = emptyToNil(tag.CostCenter)
+					orders = append(orders, row)
+				}
+				pageToken = strings.TrimSpace(asString(payload["next_page_token"]))
+				if pageToken == "" || reachedStart {
+					break
+				}
+				if pages >= maxPages {
+					truncated = true
+					warnings = append(warnings, fmt.Sprintf("stopped after %d pages; raise --max-pages or narrow --since for older orders", pages))
+					break
+				}
+			}
+
+			if format == output.FormatBeancount {
+				return writeOrdersBeancount(cmd, deps, flags, &auth, orders, account, fundingAccount, warnings)
+			}
+			data := map[string]any{
+				"orders":    orders,
+				"count":     len(orders),
+				"since":     emptyToNil(strings.TrimSpace(since)),
+				"until":     emptyToNil(strings.TrimSpace(until)),
+				"pages":     pages,
+				"truncated": truncated,
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildProfileOrdersExportTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only export orders paid on or after this day (YYYY-MM-DD, local time).")
+	cmd.Flags().StringVar(&until, "until", "", "Only export orders paid on or before this day (YYYY-MM-DD, local time).")
+	cmd.Flags().StringVar(&statusFilter, "status", "", "Filter orders by status (case-insensitive).")
+	cmd.Flags().IntVar(&maxPages, "max-pages", profileOrdersExportDefaultPages, "Maximum order history pages to read (50 orders per page).")
+	cmd.Flags().StringVar(&account, "account", beancountDefaultAccount, "Beancount expense account for order totals (--format beancount).")
+	cmd.Flags().StringVar(&fundingAccount, "funding-account", beancountDefaultFundingAccount, "Beancount account that paid for the orders (--format beancount).")
+	addGlobalFlags(cmd, &flags)
+	enableBeancount(cmd)
+	return cmd
+}
+
+// writeOrdersBeancount fetches each exported order's details and prints them
+// as Beancount transactions, oldest first. Orders whose details fail or carry
+// no total become comments instead of entries.
+func writeOrdersBeancount(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	orders []any,
+	account string,
+	fundingAccount string,
+	warnings []string,
+) error {
+	transactions := make([]output.BeancountTransaction, 0, len(orders))
+	for idx := len(orders) - 1; idx >= 0; idx-- {
+		row := asMap(orders[idx])
+		purchaseID := asString(row["purchase_id"])
+		payload, authWarnings, err := invokeWithAuthAutoRefresh(
+			cmd.Context(),
+			deps,
+			flags,
+			auth,
+			func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+				return deps.Wolt.OrderHistoryPurchase(cmd.Context(), purchaseID, authCtx)
+			},
+		)
+		warnings = append(warnings, authWarnings...)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipped %s: order details unavailable: %v", purchaseID, err))
+			continue
+		}
+		txn, ok := orderBeancountTransaction(row, buildOrderHistoryDetail(payload))
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("skipped %s: no paid total", purchaseID))
+			continue
+		}
+		txn.Account = account
+		txn.FundingAccount = fundingAccount
+		transactions = append(transactions, txn)
+	}
+	comments := append([]string{fmt.Sprintf("wolt profile orders export: %d transactions", len(transactions))}, warnings...)
+	return output.WriteOutput(cmd.OutOrStdout(), output.RenderBeancount(transactions, comments), flags.Output)
+}
+
+// orderBeancountTransaction maps an export row and its order detail onto one
+// transaction. Tags mark fees and discounts that are folded into the total.
+func orderBeancountTransaction(row map[string]any, detail map[string]any) (output.BeancountTransaction, bool) {
+	totals := asMap(detail["totals"])
+	amount := asInt(asMap(totals["total"])["amount"])
+	paidAt := asInt(row["payment_time_ts"])
+	if amount <= 0 || paidAt <= 0 {
+		return output.BeancountTransaction{}, false
+	}
+	tags := []string{"wolt"}
+	if asInt(asMap(totals["delivery"])["amount"]) > 0 {
+		tags = append(tags, "delivery-fee")
+	}
+	if asInt(asMap(totals["service_fee"])["amount"]) > 0 {
+		tags = append(tags, "service-fee")
+	}
+	if len(asSlice(detail["surcharges"])) > 0 {
+		tags = append(tags, "surcharge")
+	}
+	if len(asSlice(detail["discounts"])) > 0 || asInt(asMap(totals["credits"])["amount"]) > 0 || asInt(asMap(totals["tokens"])["amount"]) > 0 {
+		tags = append(tags, "discount")
+	}
+	meta := [][2]string{{"purchase_id", asString(row["purchase_id"])}}
+	for _, key := range []string{"expense_code", "cost_center"} {
+		if value := asString(row[key]); value != "" {
+			meta = append(meta, [2]string{key, value})
+		}
+	}
+	return output.BeancountTransaction{
+		Date:      time.UnixMilli(int64(paidAt)),
+		Payee:     fallbackString(asString(asMap(detail["venue"])["name"]), asString(row["venue_name"])),
+		Narration: asString(row["items_summary"]),
+		Tags:      tags,
+		Meta:      meta,
+		Amount:    amount,
+		Currency:  asString(detail["currency"]),
+	}, true
+}
+
+// parseOrderExportRange turns inclusive --since/--until days into a half-open
+// [start, end) range in local time. Zero times mean no bound.
+func parseOrderExportRange(since string, until string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	if value := strings.TrimSpace(since); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return start, end, fmt.Errorf("--since must be YYYY-MM-DD")
+		}
+		start = parsed
+	}
+	if value := strings.TrimSpace(until); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return start, end, fmt.Errorf("--until must be YYYY-MM-DD")
+		}
+		end = parsed.AddDate(0, 0, 1)
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return start, end, fmt.Errorf("--since must not be after --until")
+	}
+	return start, end, nil
+}
+
+func buildProfileOrdersExportTable(data map[string]any) string {
+	headers := []string{"Purchase ID", "Received", "Venue", "Total", "Expense code", "Cost center"}
+	rows := make([][]string, 0)
+	for _, value := range asSlice(data["orders"]) {
+		order := asMap(value)
+		rows = append(rows, []string{
+			fallbackString(asString(order["purchase_id"]), "-"),
+			fallbackString(asString(order["received_at"]), "-"),
+			fallbackString(asString(order["venue_name"]), "-"),
+			fallbackString(asString(order["total_amount"]), "-"),
+			fallbackString(asString(order["expense_code"]), "-"),
+			fallbackString(asString(order["cost_center"]), "-"),
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"-", "-", "-", "-", "-", "-"})
+	}
+	return output.RenderTable("Order export", headers, rows)
+}
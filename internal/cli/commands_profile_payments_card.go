@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newProfilePaymentsAddCardCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var country string
+	var returnURL string
+	var noWait bool
+	var timeout time.Duration
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "add-card",
+		Short: "Add a payment card through the provider's secure browser page.",
+		Long: "Add a payment card through the provider's secure browser page.\n\n" +
+			"Starts a card setup session and prints the URL where the card is entered and 3-D Secure is completed; " +
+			"card details never pass through the CLI. The command then polls the account's payment methods until " +
+			"the new card appears, or returns right away with --no-wait.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if timeout <= 0 || interval <= 0 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--timeout and --interval must be greater than 0")
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+
+			listMethods := func() ([]any, []string, error) {
+				result, warnings, err := invokeWithAuthAutoRefresh(
+					cmd.Context(),
+					deps,
+					flags,
+					&auth,
+					func(authCtx woltgateway.AuthContext) (profilePaymentsPayload, error) {
+						return fetchProfilePaymentsPayload(cmd.Context(), deps, authCtx)
+					},
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				if country == "" {
+					country = paymentCountryFromUserMe(asMap(result.Payload["user"]))
+				}
+				return extractPaymentMethods(result.Payload, false), append(warnings, result.Warnings...), nil
+			}
+
+			if country == "" {
+				country = paymentCountryFromToken(auth.WToken)
+			}
+			before, warnings, err := listMethods()
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			session, sessionWarnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.CardSetupSession(cmd.Context(), authCtx, woltgateway.CardSetupOptions{Country: country, ReturnURL: returnURL})
+				},
+			)
+			warnings = append(warnings, sessionWarnings...)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			setupURL, sessionID := cardSetupSessionLink(session)
+			if setupURL == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_UPSTREAM_ERROR", "card setup session returned no browser URL")
+			}
+			data := map[string]any{
+				"session_id": emptyToNil(sessionID),
+				"url":        setupURL,
+				"status":     "pending",
+				"card":       nil,
+			}
+
+			if !noWait {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Open this URL to enter the card and complete 3-D Secure:\n%s\nWaiting up to %s for the card to appear...\n", setupURL, timeout)
+				started := time.Now()
+				deadline := started.Add(timeout)
+				for data["card"] == nil {
+					if !time.Now().Before(deadline) {
+						return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_CARD_SETUP_PENDING",
+							fmt.Sprintf("no new card appeared within %s; finish the setup at %s and check wolt profile payments", timeout, setupURL))
+					}
+					select {
+					case <-cmd.Context().Done():
+						return cmd.Context().Err()
+					case <-time.After(min(interval, time.Until(deadline))):
+					}
+					// Polls repeat the first lookup's warnings, so only that one is reported.
+					after, _, err := listMethods()
+					if err != nil {
+						return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+					}
+					if card := newPaymentMethod(before, after); card != nil {
+						data["card"] = card
+						data["status"] = "added"
+						data["waited_seconds"] = int(time.Since(started).Seconds())
+					}
+				}
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildCardSetupTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&country, "country", "", "Account country code for the payments provider (default: from the token or account).")
+	cmd.Flags().StringVar(&returnURL, "return-url", "", "URL the provider page redirects to when setup finishes.")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "Print the setup URL and return without waiting for the card.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the new card to appear.")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Delay between payment method checks while waiting.")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// cardSetupSessionLink reads the browser URL and session id from a card
+// setup response, which may nest them under session or action.
+func cardSetupSessionLink(payload map[string]any) (string, string) {
+	session := asMap(payload["session"])
+	action := asMap(payload["action"])
+	setupURL := asString(coalesceAny(
+		payload["url"],
+		payload["redirect_url"],
+		payload["setup_url"],
+		session["url"],
+		session["redirect_url"],
+		action["url"],
+	))
+	sessionID := asString(coalesceAny(payload["session_id"], payload["id"], session["id"]))
+	return strings.TrimSpace(setupURL), strings.TrimSpace(sessionID)
+}
+
+// newPaymentMethod returns the first card in after that was not in before.
+func newPaymentMethod(before []any, after []any) map[string]any {
+	known := map[string]struct{}{}
+	for _, value := range before {
+		known[paymentMethodKey(asMap(value))] = struct{}{}
+	}
+	for _, value := range after {
+		method := asMap(value)
+		if _, ok := known[paymentMethodKey(method)]; ok {
+			continue
+		}
+		if methodType := strings.ToLower(asString(method["type"])); methodType != "" && !strings.Contains(methodType, "card") {
+			continue
+		}
+		return method
+	}
+	return nil
+}
+
+func paymentMethodKey(method map[string]any) string {
+	if id := strings.ToLower(strings.TrimSpace(asString(method["method_id"]))); id != "" {
+		return id
+	}
+	return strings.ToLower(strings.TrimSpace(asString(method["type"]) + "|" + asString(method["label"])))
+}
+
+func buildCardSetupTable(data map[string]any) string {
+	card := asMap(data["card"])
+	rows := [][]string{
+		{"Status", asString(data["status"])},
+		{"Setup URL", asString(data["url"])},
+		{"Card", fallbackString(asString(card["label"]), "-")},
+		{"Method ID", fallbackString(asString(card["method_id"]), "-")},
+	}
+	return output.RenderTable("Add payment card", []string{"Field", "Value"}, rows)
+}
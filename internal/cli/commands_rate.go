@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/journal"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	minRatingScore = 1
+	maxRatingScore = 10
+)
+
+func newRateCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var score int
+	var note string
+
+	cmd := &cobra.Command{
+		Use:   "rate <purchase-id>",
+		Short: "Rate a past order for your own ratings log.",
+		Long: "Rate a past order for your own ratings log.\n\n" +
+			"Looks up the order to find its venue and stores the score in the journal next to venue notes " +
+			"($WOLT_JOURNAL_DIR, or a journal directory next to the config file). Rating the same order again " +
+			"replaces the earlier score. discover feed and search venues show the average of your scores per venue " +
+			"as my_rating, and --sort my_rating puts your favourites first. Wolt has no public endpoint for order " +
+			"ratings, so nothing is sent upstream.",
+		Example: "wolt rate <purchase-id> --score 9 --note \"fast, hot\"\n" +
+			"wolt discover feed --sort my_rating",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := journalProfile(cmd.Context(), deps, flags.Profile)
+			if !cmd.Flags().Changed("score") {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--score is required")
+			}
+			if score < minRatingScore || score > maxRatingScore {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("--score must be between %d and %d", minRatingScore, maxRatingScore))
+			}
+			purchaseID := strings.TrimSpace(args[0])
+			if purchaseID == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "purchase id is required")
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+
+			payload, warnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.OrderHistoryPurchase(cmd.Context(), purchaseID, authCtx)
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			rating := journal.Rating{
+				PurchaseID: purchaseID,
+				VenueID:    strings.TrimSpace(asString(payload["venue_id"])),
+				VenueName:  strings.TrimSpace(asString(payload["venue_name"])),
+				Score:      score,
+				Note:       strings.TrimSpace(note),
+				RatedAt:    deps.now().UTC(),
+			}
+			store, err := openJournalStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+			if err := store.SetRating(profileName, rating); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+			ratings, err := store.Ratings(profileName)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_JOURNAL_STORE_ERROR", err.Error())
+			}
+			if rating.VenueID == "" {
+				warnings = append(warnings, "order has no venue id; the rating will not show up as my_rating")
+			}
+
+			data := buildRatingData(rating, summarizeVenueRatings(ratings)[venueRatingKey(rating.VenueID)])
+			if format == output.FormatTable {
+				return writeTable(cmd, buildRatingTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	cmd.Flags().IntVar(&score, "score", 0, "Your score for the order, 1-10")
+	cmd.Flags().StringVar(&note, "note", "", "Short note stored with the score")
+	return cmd
+}
+
+// venueRatingSummary is the mean of the profile's scores for one venue.
+type venueRatingSummary struct {
+	Average float64
+	Count   int
+}
+
+func summarizeVenueRatings(ratings map[string]journal.Rating) map[string]venueRatingSummary {
+	totals := map[string]int{}
+	summaries := map[string]venueRatingSummary{}
+	for _, rating := range ratings {
+		key := venueRatingKey(rating.VenueID)
+		if key == "" {
+			continue
+		}
+		totals[key] += rating.Score
+		summary := summaries[key]
+		summary.Count++
+		summaries[key] = summary
+	}
+	for key, summary := range summaries {
+		summary.Average = math.Round(float64(totals[key])/float64(summary.Count)*10) / 10
+		summaries[key] = summary
+	}
+	return summaries
+}
+
+func venueRatingKey(venueID string) string {
+	return strings.ToLower(strings.TrimSpace(venueID))
+}
+
+// annotateVenueRatings adds my_rating and my_rating_count to venue rows the
+// profile has rated orders from. Like notes, journal errors leave rows as
+// they are.
+func annotateVenueRatings(ctx context.Context, deps Dependencies, profileFlag string, rows ...map[string]any) {
+	store, err := openJournalStore(deps)
+	if err != nil {
+		return
+	}
+	ratings, err := store.Ratings(journalProfile(ctx, deps, profileFlag))
+	if err != nil || len(ratings) == 0 {
+		return
+	}
+	summaries := summarizeVenueRatings(ratings)
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		summary, ok := summaries[venueRatingKey(asString(row["venue_id"]))]
+		if !ok {
+			continue
+		}
+		row["my_rating"] = summary.Average
+		row["my_rating_count"] = summary.Count
+	}
+}
+
+func myRatingSortKey(row map[string]any) any {
+	if rating, ok := asFloat(row["my_rating"]); ok {
+		return rating
+	}
+	return nil
+}
+
+func buildRatingData(rating journal.Rating, summary venueRatingSummary) map[string]any {
+	var myRating any
+	if summary.Count > 0 {
+		myRating = summary.Average
+	}
+	return map[string]any{
+		"purchase_id":     rating.PurchaseID,
+		"venue_id":        emptyToNil(rating.VenueID),
+		"venue_name":      emptyToNil(rating.VenueName),
+		"score":           rating.Score,
+		"note":            emptyToNil(rating.Note),
+		"rated_at":        rating.RatedAt.UTC().Format(time.RFC3339),
+		"submitted":       false,
+		"my_rating":       myRating,
+		"my_rating_count": summary.Count,
+	}
+}
+
+func buildRatingTable(data map[string]any) string {
+	myRating := "-"
+	if value, ok := asFloat(data["my_rating"]); ok {
+		myRating = fmt.Sprintf("%s (%d ratings)", strconv.FormatFloat(value, 'f', 1, 64), asInt(data["my_rating_count"]))
+	}
+	return output.RenderTable("Rating: "+asString(data["purchase_id"]), []string{"Field", "Value"}, [][]string{
+		{"Venue", fallbackString(asString(data["venue_name"]), "-")},
+		{"Score", fmt.Sprintf("%d/%d", asInt(data["score"]), maxRatingScore)},
+		{"Note", fallbackString(asString(data["note"]), "-")},
+		{"Rated", asString(data["rated_at"])},
+		{"My venue rating", myRating},
+	})
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newRawCommand(deps Dependencies) *cobra.Command {
+	raw := &cobra.Command{
+		Use:   "raw",
+		Short: "Call Wolt endpoints directly and print the unmodified JSON response.",
+	}
+	raw.AddCommand(newRawRequestCommand(deps, http.MethodGet))
+	raw.AddCommand(newRawRequestCommand(deps, http.MethodPost))
+	return raw
+}
+
+func newRawRequestCommand(deps Dependencies, method string) *cobra.Command {
+	var bodyValue string
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   strings.ToLower(method) + " <path>",
+		Short: fmt.Sprintf("Send a %s request with profile auth and print the upstream JSON.", method),
+		Long: "Path is resolved against https://consumer-api.wolt.com; absolute https URLs on other " +
+			"wolt.com hosts are accepted too. Table output prints the body verbatim; json/yaml wrap it in " +
+			"the standard envelope under data.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+
+			var body []byte
+			if method == http.MethodPost {
+				body, err = readRawRequestBody(bodyValue)
+				if err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+			}
+
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			invoke := func(authCtx woltgateway.AuthContext) (json.RawMessage, error) {
+				return deps.Wolt.Raw(cmd.Context(), method, args[0], body, authCtx)
+			}
+			var payload json.RawMessage
+			warnings := []string{}
+			if auth.HasCredentials() {
+				payload, warnings, err = invokeWithAuthAutoRefresh(cmd.Context(), deps, flags, &auth, invoke)
+			} else {
+				payload, err = invoke(auth)
+			}
+			if errors.Is(err, woltgateway.ErrRawTarget) {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			if format == output.FormatTable {
+				return output.WriteOutput(cmd.OutOrStdout(), string(payload), flags.Output)
+			}
+			var data any
+			if len(payload) > 0 {
+				decoder := json.NewDecoder(bytes.NewReader(payload))
+				decoder.UseNumber()
+				if err := decoder.Decode(&data); err != nil {
+					return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+				}
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	if method == http.MethodPost {
+		cmd.Flags().StringVar(&bodyValue, "body", "", "JSON request body, or @file.json to read it from a file")
+		_ = cmd.MarkFlagRequired("body")
+	}
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// readRawRequestBody resolves --body, reading @file references, and checks it is JSON.
+func readRawRequestBody(value string) ([]byte, error) {
+	body := []byte(value)
+	if path, ok := strings.CutPrefix(strings.TrimSpace(value), "@"); ok {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read --body file: %w", err)
+		}
+		body = content
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("--body must be valid JSON")
+	}
+	return body, nil
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newRetryCommand(deps Dependencies) *cobra.Command {
+	var attempts int
+	var untilExit int
+	var delay time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "retry [flags] -- <command> [args...]",
+		Short: "Re-run a wolt command until it exits with the expected code.",
+		Long: "Re-run a wolt command until it exits with the expected code or the attempts run out.\n\n" +
+			"The inner command runs in this process, so it shares the request rate limit and local caches with\n" +
+			"every attempt. Only the last attempt's stdout is printed; progress goes to stderr.",
+		Example: "  wolt retry --attempts 5 --delay 30s -- venue show burger-place --format json --expect 'is_open==true'",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if attempts < 1 {
+				return fmt.Errorf("--attempts must be at least 1")
+			}
+			if delay < 0 {
+				return fmt.Errorf("--delay must not be negative")
+			}
+			if strings.TrimSpace(args[0]) == cmd.Name() {
+				return fmt.Errorf("retry cannot wrap another retry")
+			}
+
+			ctx := cmd.Context()
+			stderr := cmd.ErrOrStderr()
+			code := 0
+			var attemptOut bytes.Buffer
+			for attempt := 1; attempt <= attempts; attempt++ {
+				attemptOut.Reset()
+				code = executeStage(ctx, args, deps, &attemptOut, stderr)
+				if code == untilExit || ctx.Err() != nil || attempt == attempts {
+					break
+				}
+				_, _ = fmt.Fprintf(stderr, "[retry] attempt %d/%d exited %d; retrying in %s\n", attempt, attempts, code, delay)
+				select {
+				case <-ctx.Done():
+				case <-time.After(delay):
+				}
+				if ctx.Err() != nil {
+					break
+				}
+			}
+			if _, err := cmd.OutOrStdout().Write(attemptOut.Bytes()); err != nil {
+				return err
+			}
+			switch {
+			case code == untilExit:
+				return nil
+			case ctx.Err() != nil:
+				return &exitError{code: exitCodeInterrupted}
+			}
+			_, _ = fmt.Fprintf(stderr, "[retry] gave up after %d attempts; last exit code %d\n", attempts, code)
+			if code == 0 {
+				code = 1
+			}
+			return &exitError{code: code}
+		},
+	}
+
+	cmd.Flags().IntVar(&attempts, "attempts", 3, "Maximum number of runs, including the first.")
+	cmd.Flags().IntVar(&untilExit, "until-exit", 0, "Exit code that ends the retries successfully.")
+	cmd.Flags().DurationVar(&delay, "delay", 10*time.Second, "Wait between attempts, for example 30s or 2m.")
+	// Flags after the inner command belong to it, so -- is optional.
+	cmd.Flags().SetInterspersed(false)
+	return cmd
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/mekedron/wolt-cli/internal/service/schedule"
+	"github.com/spf13/cobra"
+)
+
+// scheduleExecutable resolves the wolt binary written into job definitions;
+// tests replace it for stable paths.
+var scheduleExecutable = os.Executable
+
+func newScheduleCommand(deps Dependencies) *cobra.Command {
+	scheduleCmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Generate recurring jobs for systemd, launchd, or cron.",
+	}
+	scheduleCmd.AddCommand(newScheduleInstallCommand(deps))
+	return scheduleCmd
+}
+
+func newScheduleInstallCommand(_ Dependencies) *cobra.Command {
+	var flags globalFlags
+	var command string
+	var every string
+	var backendValue string
+	var name string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Write a recurring job that runs a wolt command.",
+		Long: "Write a recurring job that runs a wolt command.\n\n" +
+			"Writes a systemd user service and timer on Linux, a launchd agent on macOS, " +
+			"and prints a crontab line elsewhere. Nothing is activated; the output shows the command that does it.",
+		Example: "wolt schedule install --command \"track run\" --every 30m\n" +
+			"wolt schedule install --command \"track run\" --every 1h --backend cron --dry-run",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+
+			words := strings.Fields(command)
+			if len(words) > 0 && words[0] == "wolt" {
+				words = words[1:]
+			}
+			target, _, err := cmd.Root().Find(words)
+			if len(words) == 0 || err != nil || target == cmd.Root() || !target.Runnable() {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf(
+					"--command must name a wolt command, for example \"track run\"; got %q",
+					command,
+				))
+			}
+			interval, err := time.ParseDuration(strings.TrimSpace(every))
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--every must be a duration such as 30m or 6h")
+			}
+			backend := schedule.DefaultBackend(runtime.GOOS)
+			if strings.TrimSpace(backendValue) != "" {
+				backend, err = schedule.ParseBackend(backendValue)
+				if err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+			}
+			jobName := schedule.JobName([]string{strings.TrimSpace(name)})
+			if jobName == "" {
+				jobName = schedule.JobName(strings.Fields(target.CommandPath())[1:])
+			}
+
+			executable, err := scheduleExecutable()
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_SCHEDULE_ERROR", fmt.Sprintf("resolve wolt executable: %v", err))
+			}
+			args := append([]string{executable}, words...)
+			if strings.TrimSpace(flags.Profile) != "" {
+				args = append(args, "--profile", strings.TrimSpace(flags.Profile))
+			}
+			// Both lookups only fail without $HOME; the backend that needs one reports it.
+			configDir, _ := os.UserConfigDir()
+			homeDir, _ := os.UserHomeDir()
+			if (backend == schedule.BackendSystemd && configDir == "") || (backend == schedule.BackendLaunchd && homeDir == "") {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_SCHEDULE_ERROR", "cannot locate the user home directory")
+			}
+			plan, err := schedule.Build(backend, schedule.Job{Name: jobName, Args: args, Every: interval}, configDir, homeDir)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+
+			if !dryRun {
+				for _, file := range plan.Files {
+					if err := writeScheduleFile(file); err != nil {
+						return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_SCHEDULE_ERROR", err.Error())
+					}
+				}
+			}
+
+			files := make([]any, 0, len(plan.Files))
+			for _, file := range plan.Files {
+				files = append(files, map[string]any{"path": file.Path, "content": file.Content})
+			}
+			data := map[string]any{
+				"backend":  string(plan.Backend),
+				"name":     jobName,
+				"command":  args,
+				"every":    schedule.FormatInterval(interval),
+				"dry_run":  dryRun,
+				"written":  !dryRun && len(plan.Files) > 0,
+				"files":    files,
+				"activate": plan.Activate,
+			}
+			warnings := []string{}
+			if plan.Line != "" {
+				data["cron_line"] = plan.Line
+				warnings = append(warnings, "cron jobs are not written automatically; add cron_line with \"crontab -e\"")
+			}
+
+			if format == output.FormatTable {
+				if dryRun {
+					return output.WriteOutput(cmd.OutOrStdout(), renderSchedulePlan(plan), flags.Output)
+				}
+				return writeTable(cmd, buildScheduleInstallTable(data, plan), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&command, "command", "", "wolt command to run, for example \"track run\" (required)")
+	cmd.Flags().StringVar(&every, "every", "", "Interval such as 30m or 6h (required, at least 1m)")
+	cmd.Flags().StringVar(&backendValue, "backend", "", "Scheduler: systemd, launchd, or cron (default: systemd on Linux, launchd on macOS, cron elsewhere)")
+	cmd.Flags().StringVar(&name, "name", "", "Job name used in unit file names (default: derived from --command)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the job definition without writing files")
+	for _, required := range []string{"command", "every"} {
+		if err := cmd.MarkFlagRequired(required); err != nil {
+			panic(err)
+		}
+	}
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func writeScheduleFile(file schedule.File) error {
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0o755); err != nil {
+		return fmt.Errorf("create schedule directory: %w", err)
+	}
+	if err := os.WriteFile(file.Path, []byte(file.Content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// renderSchedulePlan prints each definition file under a path comment, or the
+// crontab line, so --dry-run output can be pasted as-is.
+func renderSchedulePlan(plan schedule.Plan) string {
+	if plan.Line != "" {
+		return plan.Line
+	}
+	parts := make([]string, 0, len(plan.Files))
+	for _, file := range plan.Files {
+		parts = append(parts, "# "+file.Path+"\n"+strings.TrimRight(file.Content, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func buildScheduleInstallTable(data map[string]any, plan schedule.Plan) string {
+	rows := [][]string{
+		{"Backend", asString(data["backend"])},
+		{"Name", asString(data["name"])},
+		{"Every", asString(data["every"])},
+		{"Command", strings.Join(toStringSlice(asSlice(data["command"])), " ")},
+	}
+	for _, file := range plan.Files {
+		rows = append(rows, []string{"Wrote", file.Path})
+	}
+	if plan.Line != "" {
+		rows = append(rows, []string{"Cron line", plan.Line})
+	}
+	rows = append(rows, []string{"Activate", plan.Activate})
+	return output.RenderTable("Scheduled job", []string{"Field", "Value"}, rows)
+}
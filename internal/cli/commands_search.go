@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/mekedron/wolt-cli/internal/domain"
 	"github.com/mekedron/wolt-cli/internal/service/observability"
 	"github.com/mekedron/wolt-cli/internal/service/output"
 	"github.com/spf13/cobra"
@@ -15,6 +18,7 @@ func newSearchCommand(deps Dependencies) *cobra.Command {
 	}
 	search.AddCommand(newSearchVenuesCommand(deps))
 	search.AddCommand(newSearchItemsCommand(deps))
+	search.AddCommand(newSearchAllCommand(deps))
 	return search
 }
 
@@ -25,9 +29,11 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 	var typeValue string
 	var category string
 	var openNow bool
+	var nowValue string
 	var woltPlus bool
 	var limit int
 	var limitSet bool
+	var noLimit bool
 	var offset int
 	var offsetSet bool
 	var page int
@@ -37,6 +43,14 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 	var maxDeliveryFee int
 	var maxDeliveryFeeSet bool
 	var promotionsOnly bool
+	var maxRequests int
+	var strict bool
+	var pick rowPick
+	var exclude excludeFilters
+	var near string
+	var radiusKm float64
+	var groupChains bool
+	var cityNames []string
 
 	cmd := &cobra.Command{
 		Use:   "venues",
@@ -46,6 +60,20 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := validateMaxRequests(maxRequests); err != nil {
+				return err
+			}
+			if strings.TrimSpace(nowValue) != "" {
+				if !openNow {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--now requires --open-now")
+				}
+				if _, err := parseVenueNow(nowValue, time.UTC, deps.now()); err != nil {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+			}
+			// Without --now the upstream online flag already reflects each
+			// venue's local time; --now needs the venue hours instead.
+			openAtNow := openNow && strings.TrimSpace(nowValue) == ""
 			sortMode, err := observability.ParseVenueSort(sortValue)
 			if err != nil {
 				return err
@@ -58,25 +86,67 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 				}
 				venueType = &parsedType
 			}
+			if radiusKm < 0 {
+				return fmt.Errorf("--radius-km must be > 0")
+			}
+			address := flags.Address
+			near = strings.TrimSpace(near)
+			if near != "" {
+				if strings.TrimSpace(flags.Address) != "" {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "Do not combine --near with --address.")
+				}
+				if lat, lon := coordinateFlags(cmd); lat != nil || lon != nil {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "Do not combine --near with --lat/--lon.")
+				}
+				address = near
+			}
+			var cities []searchCity
+			if len(cityNames) > 0 {
+				if lat, lon := coordinateFlags(cmd); near != "" || strings.TrimSpace(flags.Address) != "" || lat != nil || lon != nil {
+					return emitError(cmd, format, resolveProfileLabel(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "Do not combine --city with --near, --address, or --lat/--lon.")
+				}
+				payload, err := deps.Wolt.Cities(cmd.Context())
+				if err != nil {
+					return emitUpstreamError(cmd, format, resolveProfileLabel(flags.Profile), flags.Locale, flags.Output, flags.Verbose, err)
+				}
+				var unknown []string
+				cities, unknown = resolveSearchCities(payload, cityNames)
+				if len(unknown) > 0 {
+					return emitError(cmd, format, resolveProfileLabel(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("Wolt does not operate in %s.", strings.Join(unknown, ", ")))
+				}
+			}
 			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
-			location, profile, err := resolveProfileLocation(
-				cmd.Context(),
-				deps,
-				flags.Address,
-				flags.Profile,
-				format,
-				flags.Locale,
-				flags.Output,
-				&locationAuth,
-				cmd,
-			)
-			if err != nil {
-				return err
+			var location domain.Location
+			var profile string
+			var items []domain.Item
+			var cityByVenue map[string]string
+			var cityWarnings []string
+			if len(cities) > 0 {
+				// Basket lookups and the suggestions below use the first city.
+				location, profile = cities[0].location, resolveProfileLabel(flags.Profile)
+				items, cityByVenue, cityWarnings, err = searchCityItems(cmd.Context(), deps, cities)
+			} else {
+				location, profile, err = resolveProfileLocation(
+					cmd.Context(),
+					deps,
+					address,
+					flags.Profile,
+					format,
+					flags.Locale,
+					flags.Output,
+					&locationAuth,
+					cmd,
+				)
+				if err != nil {
+					return err
+				}
+				items, err = deps.Wolt.Items(cmd.Context(), location)
 			}
-			items, err := deps.Wolt.Items(cmd.Context(), location)
 			if err != nil {
 				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
 			}
+			rememberItemVenues(deps, items, "")
+			items, excluded := exclude.excludeItems(items)
 			var limitPtr *int
 			if limitSet {
 				limitPtr = &limit
@@ -85,6 +155,10 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
+			}
 			if minRatingSet && minRating < 0 {
 				return fmt.Errorf("--min-rating must be >= 0")
 			}
@@ -97,11 +171,13 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 				sortMode,
 				venueType,
 				category,
-				openNow,
+				openAtNow,
 				woltPlus,
 				nil,
 				0,
 			)
+			warnings = append(warnings, cityWarnings...)
+			warnings = append(warnings, exclude.warnings(excluded, "venue(s)")...)
 			data["items"] = applyVenueRowFilters(
 				asSlice(data["items"]),
 				venueRowFilters{
@@ -112,18 +188,116 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 					PromotionsOnly:    promotionsOnly,
 				},
 			)
-			paginateFlatRows(data, "items", limitPtr, resolvedOffset)
+			if near != "" {
+				data["near"] = map[string]any{"address": near, "lat": location.Lat, "lon": location.Lon}
+			}
+			if len(cities) > 0 {
+				tagVenueRowsWithCity(asSlice(data["items"]), cityByVenue)
+				data["cities"] = searchCitiesData(cities)
+			}
+			if near != "" || radiusKm > 0 {
+				rows, unknown := annotateVenueDistances(asSlice(data["items"]), location, radiusKm)
+				if len(cities) > 0 {
+					rows, unknown = annotateCityVenueDistances(asSlice(data["items"]), cities, radiusKm)
+				}
+				data["items"] = rows
+				if radiusKm > 0 {
+					data["radius_km"] = radiusKm
+					if unknown > 0 {
+						warnings = append(warnings, fmt.Sprintf("--radius-km skipped %d venue(s) without coordinates", unknown))
+					}
+				}
+			}
+			annotateFeeTrends(deps, asSlice(data["items"]))
+			annotateVenueNotes(cmd.Context(), deps, flags.Profile, venueRowMaps(asSlice(data["items"]))...)
+			annotateVenueRatings(cmd.Context(), deps, flags.Profile, venueRowMaps(asSlice(data["items"]))...)
+			if sortMode == observability.VenueSortMyRating {
+				rows := asSlice(data["items"])
+				sortRowsByKey(rows, "venue_id", myRatingSortKey, compareRatingSortKeys)
+				data["items"] = rows
+			}
+			if !lowBandwidth(cmd) {
+				warnings = append(warnings, annotateBasketRows(cmd.Context(), deps, location, locationAuth, asSlice(data["items"]))...)
+			}
+			if openNow {
+				data["now"] = deps.now().UTC().Format(time.RFC3339)
+			}
+			if openNow && !openAtNow {
+				if err := checkRequestBudget(
+					cmd,
+					format,
+					profile,
+					flags.Locale,
+					flags.Output,
+					maxRequests,
+					len(asSlice(data["items"])),
+					"--open-now --now hours lookup",
+					"--query <text>",
+					"--category <slug>",
+				); err != nil {
+					return err
+				}
+				rows, openWarnings := filterVenueRowsOpenAt(cmd.Context(), deps, asSlice(data["items"]), nowValue)
+				data["items"] = rows
+				data["now"] = strings.TrimSpace(nowValue)
+				warnings = append(warnings, openWarnings...)
+			}
+			if groupChains {
+				rows := asSlice(data["items"])
+				if len(cities) > 0 && radiusKm <= 0 {
+					rows, _ = annotateCityVenueDistances(rows, cities, 0)
+				} else if near == "" && radiusKm <= 0 {
+					rows, _ = annotateVenueDistances(rows, location, 0)
+				}
+				rows, chains := groupVenueChains(rows)
+				data["items"] = rows
+				data["chains"] = chains
+			}
+			if len(asSlice(data["items"])) == 0 {
+				warnings = append(warnings, addSearchSuggestions(deps, data, query, items)...)
+			}
+			paginateFlatRows(data, "items", pageLimit, resolvedOffset)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
 			if pageSet {
 				data["page"] = page
 			}
-			promotionAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
-			enrichVenueSearchRowsWithDynamicPromotions(
-				cmd.Context(),
-				deps,
-				data,
-				nil,
-				promotionAuth,
-			)
+			if err := exportSQLiteRows(cmd, deps, sqliteVenueExport, asSlice(data["items"])); err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_SQLITE_EXPORT_ERROR", err.Error())
+			}
+			if pick.enabled() {
+				return emitPickedRow(cmd, format, profile, flags.Locale, flags.Output, pick, asSlice(data["items"]), venueRowPicker())
+			}
+			annotateVenueRowSources(asSlice(data["items"]), flags.Verbose, format)
+			if lowBandwidth(cmd) {
+				warnings = append(warnings, "low-bandwidth mode skips per-venue promotion and Wolt+ enrichment")
+			} else {
+				if err := checkRequestBudget(
+					cmd,
+					format,
+					profile,
+					flags.Locale,
+					flags.Output,
+					maxRequests,
+					estimateVenueEnrichmentRequests(asSlice(data["items"]), true),
+					"venue enrichment",
+					"--limit <n>",
+					"--query <text>",
+				); err != nil {
+					return err
+				}
+				promotionAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+				enrichVenueSearchRowsWithDynamicPromotions(
+					cmd.Context(),
+					deps,
+					data,
+					nil,
+					promotionAuth,
+				)
+			}
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
 
 			if format == output.FormatTable {
 				return writeTable(cmd, buildVenueSearchTable(data), flags.Output)
@@ -138,14 +312,26 @@ func newSearchVenuesCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringVar(&typeValue, "type", "", "Venue type")
 	cmd.Flags().StringVar(&category, "category", "", "Category slug")
 	cmd.Flags().BoolVar(&openNow, "open-now", false, "Only include currently open venues")
+	cmd.Flags().StringVar(&nowValue, "now", "", "With --open-now, check opening hours at this venue-local time (YYYY-MM-DDTHH:MM)")
 	cmd.Flags().BoolVar(&woltPlus, "wolt-plus", false, "Only include Wolt+ venues")
 	cmd.Flags().Float64Var(&minRating, "min-rating", 0, "Minimum venue rating score (for example 8.5)")
 	cmd.Flags().IntVar(&maxDeliveryFee, "max-delivery-fee", 0, "Maximum delivery fee in minor units (for example 500 = EUR 5.00)")
 	cmd.Flags().BoolVar(&promotionsOnly, "promotions-only", false, "Only include venues with promotion labels")
+	cmd.Flags().StringVar(&near, "near", "", "Search around this address instead of the profile location and add distance_km to each venue (no profile needed)")
+	cmd.Flags().Float64Var(&radiusKm, "radius-km", 0, "Only include venues within this many kilometres of the search location")
+	cmd.Flags().StringArrayVar(&cityNames, "city", nil, "Search around the center of this city instead of the profile location; repeat to search several cities at once")
+	cmd.Flags().BoolVar(&groupChains, "group-chains", false, "Collapse branches of one chain into a single row showing the nearest branch")
+	addExcludeVenueFlag(cmd, &exclude)
+	addExcludeTagFlag(cmd, &exclude)
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned rows")
 	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
+	addNoLimitFlag(cmd, &noLimit)
+	addMaxRequestsFlag(cmd, &maxRequests)
+	addStrictFlag(cmd, &strict)
+	addRowPickFlags(cmd, &pick, "venue slug")
 	addGlobalFlags(cmd, &flags)
+	enableSQLiteExport(cmd)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		limitSet = cmd.Flags().Changed("limit")
 		offsetSet = cmd.Flags().Changed("offset")
@@ -164,16 +350,17 @@ func newSearchItemsCommand(deps Dependencies) *cobra.Command {
 	var category string
 	var limit int
 	var limitSet bool
+	var noLimit bool
 	var offset int
 	var offsetSet bool
 	var page int
 	var pageSet bool
-	var minPrice int
-	var minPriceSet bool
-	var maxPrice int
-	var maxPriceSet bool
+	var minPrice priceFlag
+	var maxPrice priceFlag
 	var hideSoldOut bool
 	var discountsOnly bool
+	var pick rowPick
+	var exclude excludeFilters
 
 	cmd := &cobra.Command{
 		Use:   "items",
@@ -217,6 +404,7 @@ func newSearchItemsCommand(deps Dependencies) *cobra.Command {
 			if payload, err := deps.Wolt.Search(cmd.Context(), location, query); err == nil {
 				payloads = append(payloads, payload)
 			} else {
+				recordFallback(cmd.Context(), "search_catalog")
 				warnings = append(warnings, "search endpoint unavailable; using basic fallback data")
 			}
 
@@ -228,14 +416,12 @@ func newSearchItemsCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if minPriceSet && minPrice < 0 {
-				return fmt.Errorf("--min-price must be >= 0")
-			}
-			if maxPriceSet && maxPrice < 0 {
-				return fmt.Errorf("--max-price must be >= 0")
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
 			}
-			if minPriceSet && maxPriceSet && minPrice > maxPrice {
-				return fmt.Errorf("--min-price cannot be greater than --max-price")
+			if err := validatePriceRange(minPrice, maxPrice); err != nil {
+				return err
 			}
 
 			data, itemWarnings := observability.BuildItemSearchResult(
@@ -250,20 +436,34 @@ func newSearchItemsCommand(deps Dependencies) *cobra.Command {
 			data["items"] = applyItemRowFilters(
 				asSlice(data["items"]),
 				itemRowFilters{
-					MinPriceSet:   minPriceSet,
 					MinPrice:      minPrice,
-					MaxPriceSet:   maxPriceSet,
 					MaxPrice:      maxPrice,
 					HideSoldOut:   hideSoldOut,
 					DiscountsOnly: discountsOnly,
 				},
 			)
-			paginateFlatRows(data, "items", limitPtr, resolvedOffset)
+			rows, excluded := exclude.excludeItemRows(asSlice(data["items"]))
+			data["items"] = rows
+			warnings = append(warnings, exclude.warnings(excluded, "item(s)")...)
+			if len(rows) == 0 {
+				warnings = append(warnings, addSearchSuggestions(deps, data, query, fallbackItems)...)
+			} else {
+				rememberMenuNames(deps, rows)
+			}
+			paginateFlatRows(data, "items", pageLimit, resolvedOffset)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
 			if pageSet {
 				data["page"] = page
 			}
 			warnings = append(warnings, itemWarnings...)
+			if err := exportSQLiteRows(cmd, deps, sqliteItemExport, asSlice(data["items"])); err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_SQLITE_EXPORT_ERROR", err.Error())
+			}
 
+			if pick.enabled() {
+				picker := itemRowPicker(func(row map[string]any) string { return asString(row["venue_id"]) })
+				return emitPickedRow(cmd, format, profile, flags.Locale, flags.Output, pick, asSlice(data["items"]), picker)
+			}
 			if format == output.FormatTable {
 				return writeTable(cmd, buildItemSearchTable(data), flags.Output)
 			}
@@ -275,23 +475,24 @@ func newSearchItemsCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringVar(&query, "query", "", "Search query")
 	cmd.Flags().StringVar(&sortValue, "sort", string(observability.ItemSortRelevance), "Sort strategy")
 	cmd.Flags().StringVar(&category, "category", "", "Category slug")
-	cmd.Flags().IntVar(&minPrice, "min-price", 0, "Minimum item base price in minor units")
-	cmd.Flags().IntVar(&maxPrice, "max-price", 0, "Maximum item base price in minor units")
+	addItemPriceFlags(cmd, &minPrice, &maxPrice)
 	cmd.Flags().BoolVar(&hideSoldOut, "hide-sold-out", false, "Exclude sold-out items")
 	cmd.Flags().BoolVar(&discountsOnly, "discounts-only", false, "Only include items with discounts")
+	addExcludeVenueFlag(cmd, &exclude)
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned rows")
 	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
+	addNoLimitFlag(cmd, &noLimit)
+	addRowPickFlags(cmd, &pick, "venue ID and item ID")
 	if err := cmd.MarkFlagRequired("query"); err != nil {
 		panic(err)
 	}
 	addGlobalFlags(cmd, &flags)
+	enableSQLiteExport(cmd)
 	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
 		limitSet = cmd.Flags().Changed("limit")
 		offsetSet = cmd.Flags().Changed("offset")
 		pageSet = cmd.Flags().Changed("page")
-		minPriceSet = cmd.Flags().Changed("min-price")
-		maxPriceSet = cmd.Flags().Changed("max-price")
 	}
 
 	return cmd
@@ -299,6 +500,14 @@ func newSearchItemsCommand(deps Dependencies) *cobra.Command {
 
 func buildVenueSearchTable(data map[string]any) string {
 	headers := []string{"Venue", "Slug", "Address", "Rating", "Delivery", "Fee", "Price", "Promotions", "Wolt+"}
+	withDistance := data["near"] != nil || data["radius_km"] != nil || data["chains"] != nil
+	withCity := data["cities"] != nil
+	if withCity {
+		headers = append(headers, "City")
+	}
+	if withDistance {
+		headers = append(headers, "Distance")
+	}
 	rows := [][]string{}
 	for _, value := range asSlice(data["items"]) {
 		item := asMap(value)
@@ -318,8 +527,8 @@ func buildVenueSearchTable(data map[string]any) string {
 		if promotions == "" {
 			promotions = "-"
 		}
-		rows = append(rows, []string{
-			asString(item["name"]),
+		row := []string{
+			asString(item["name"]) + chainRowLabel(item) + basketRowLabel(item),
 			fallbackString(asString(item["slug"]), "-"),
 			asString(item["address"]),
 			rating,
@@ -328,7 +537,14 @@ func buildVenueSearchTable(data map[string]any) string {
 			priceRange,
 			promotions,
 			boolToYesNo(asBool(item["wolt_plus"])),
-		})
+		}
+		if withCity {
+			row = append(row, fallbackString(asString(item["city"]), "-"))
+		}
+		if withDistance {
+			row = append(row, formatDistanceForTable(item))
+		}
+		rows = append(rows, row)
 	}
 	return output.RenderTable("Venue search: "+asString(data["query"]), headers, rows)
 }
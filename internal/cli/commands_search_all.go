@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// searchAllItemBoost is added to a venue's score for each matching item it
+	// sells, up to searchAllBoostedItems items.
+	searchAllItemBoost    = 0.5
+	searchAllBoostedItems = 2
+)
+
+func newSearchAllCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var query string
+	var limit int
+	var limitSet bool
+	var noLimit bool
+	var offset int
+	var offsetSet bool
+	var page int
+	var pageSet bool
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "Search venues and menu items together in one ranked list.",
+		Long: "Search venues and menu items together in one ranked list.\n\n" +
+			"Venue and item search run concurrently. Each row is tagged with its type; rows score by their rank " +
+			"in their own search, and venues gain for every matching item they sell.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if query == "" {
+				return fmt.Errorf("%s", requiredArg("--query"))
+			}
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			var limitPtr *int
+			if limitSet {
+				limitPtr = &limit
+			}
+			resolvedOffset, err := resolvePageOffset(limit, limitSet, offset, offsetSet, page, pageSet)
+			if err != nil {
+				return err
+			}
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
+			}
+
+			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&locationAuth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+
+			var venues []domain.Item
+			var venuesErr error
+			var searchPayload map[string]any
+			var searchErr error
+			workers := sync.WaitGroup{}
+			workers.Go(func() {
+				venues, venuesErr = deps.Wolt.Items(cmd.Context(), location)
+			})
+			workers.Go(func() {
+				searchPayload, searchErr = deps.Wolt.Search(cmd.Context(), location, query)
+			})
+			workers.Wait()
+			if venuesErr != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, venuesErr)
+			}
+			rememberItemVenues(deps, venues, "")
+
+			venueData, warnings := observability.BuildVenueSearchResult(
+				venues,
+				query,
+				observability.VenueSortRecommended,
+				nil,
+				"",
+				false,
+				false,
+				nil,
+				0,
+			)
+			itemRows := []any{}
+			if searchErr != nil {
+				recordPartialFailure(cmd.Context(), "item search", searchErr)
+				warnings = append(warnings, "item search unavailable; showing venues only")
+			} else {
+				// No fallback items: venue-level placeholders would repeat the venue rows.
+				itemData, itemWarnings := observability.BuildItemSearchResult(
+					query,
+					[]map[string]any{searchPayload},
+					observability.ItemSortRelevance,
+					"",
+					nil,
+					0,
+					nil,
+				)
+				itemRows = asSlice(itemData["items"])
+				warnings = append(warnings, itemWarnings...)
+				rememberMenuNames(deps, itemRows)
+			}
+
+			venueRows := asSlice(venueData["items"])
+			data := map[string]any{
+				"query":       query,
+				"venue_count": len(venueRows),
+				"item_count":  len(itemRows),
+				"items":       rankSearchAllRows(venueRows, itemRows),
+			}
+			if len(venueRows)+len(itemRows) == 0 {
+				warnings = append(warnings, addSearchSuggestions(deps, data, query, venues)...)
+			}
+			paginateFlatRows(data, "items", pageLimit, resolvedOffset)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
+			if pageSet {
+				data["page"] = page
+			}
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildSearchAllTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&query, "query", "", "Search query")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned rows")
+	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
+	addNoLimitFlag(cmd, &noLimit)
+	addStrictFlag(cmd, &strict)
+	if err := cmd.MarkFlagRequired("query"); err != nil {
+		panic(err)
+	}
+	addGlobalFlags(cmd, &flags)
+	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
+		limitSet = cmd.Flags().Changed("limit")
+		offsetSet = cmd.Flags().Changed("offset")
+		pageSet = cmd.Flags().Changed("page")
+	}
+
+	return cmd
+}
+
+// rankSearchAllRows merges venue and item search rows into one list ordered by
+// score. A row at rank r in its own search scores 1/(r+1); venues add
+// searchAllItemBoost per matching item. Ties keep venues ahead of items.
+func rankSearchAllRows(venueRows []any, itemRows []any) []any {
+	matches := map[string]int{}
+	for _, value := range itemRows {
+		if venueID := strings.TrimSpace(asString(asMap(value)["venue_id"])); venueID != "" {
+			matches[venueID]++
+		}
+	}
+	rows := make([]any, 0, len(venueRows)+len(itemRows))
+	for idx, value := range venueRows {
+		row := asMap(value)
+		matching := matches[strings.TrimSpace(asString(row["venue_id"]))]
+		row["type"] = "venue"
+		row["matching_items"] = matching
+		row["score"] = searchAllScore(idx, min(matching, searchAllBoostedItems))
+		rows = append(rows, row)
+	}
+	for idx, value := range itemRows {
+		row := asMap(value)
+		row["type"] = "item"
+		row["score"] = searchAllScore(idx, 0)
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return asMap(rows[i])["score"].(float64) > asMap(rows[j])["score"].(float64)
+	})
+	return rows
+}
+
+func searchAllScore(rank int, boostedItems int) float64 {
+	score := 1/float64(rank+1) + searchAllItemBoost*float64(boostedItems)
+	return math.Round(score*1000) / 1000
+}
+
+func buildSearchAllTable(data map[string]any) string {
+	headers := []string{"Type", "Name", "Venue", "Price", "Rating", "Matching items", "Score"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["items"]) {
+		row := asMap(value)
+		venue := fallbackString(asString(row["slug"]), asString(row["venue_slug"]))
+		matching := "-"
+		if asString(row["type"]) == "venue" {
+			matching = fmt.Sprintf("%d", asInt(row["matching_items"]))
+		}
+		score, _ := asFloat(row["score"])
+		rows = append(rows, []string{
+			asString(row["type"]),
+			asString(row["name"]),
+			fallbackString(venue, "-"),
+			fallbackString(asString(asMap(row["base_price"])["formatted_amount"]), "-"),
+			fallbackString(asString(row["rating"]), "-"),
+			matching,
+			fmt.Sprintf("%.3f", score),
+		})
+	}
+	return output.RenderTable("Search: "+asString(data["query"]), headers, rows)
+}
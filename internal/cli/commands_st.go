@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	basketBadgeCacheFile = "baskets.json"
+	// basketBadgeMaxAge keeps wolt st from calling Wolt on every shell prompt.
+	basketBadgeMaxAge = time.Minute
+)
+
+// basketBadge is the cached open-basket summary of one profile.
+type basketBadge struct {
+	BasketCount int       `json:"basket_count"`
+	ItemCount   int       `json:"item_count"`
+	TotalAmount int       `json:"total_amount"`
+	Currency    string    `json:"currency"`
+	CheckedAt   time.Time `json:"checked_at"`
+	// RefreshDue marks a summary served stale by --swr; the next call
+	// fetches live instead of serving it again.
+	RefreshDue bool `json:"refresh_due,omitempty"`
+}
+
+func newStCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var maxAge time.Duration
+	var prompt bool
+	var swr bool
+
+	cmd := &cobra.Command{
+		Use:   "st",
+		Short: "Show profile, login state, and open baskets in one short line.",
+		Long: "Show profile, login state, and open baskets in one short line.\n\n" +
+			"Built for shell prompts: open baskets are fetched with one request and cached for --max-age, so " +
+			"repeated calls answer from disk. Without credentials no request is sent. A failed basket lookup " +
+			"warns and still exits 0. --prompt prints one plain line such as \"default 2 carts €18.40\".\n\n" +
+			"--swr (stale-while-revalidate) answers from an expired summary too, marked stale with its age; " +
+			"the next call then fetches live, so at most one reply per expiry waits for Wolt.",
+		Example: "wolt st\n" +
+			"wolt st --format json\n" +
+			"wolt st --swr --max-age 5m --format json\n" +
+			"PS1='$(wolt st --prompt) \\$ '",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			var profile domain.Profile
+			if deps.Profiles != nil {
+				if found, err := deps.Profiles.Find(cmd.Context(), flags.Profile); err == nil {
+					profile = found
+					profileName = fallbackString(strings.TrimSpace(found.Name), profileName)
+				}
+			}
+
+			data := map[string]any{
+				"profile":       profileName,
+				"authenticated": false,
+				"basket_count":  nil,
+				"item_count":    nil,
+				"total":         nil,
+				"cached":        false,
+				"checked_at":    nil,
+			}
+			warnings := []string{}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if auth.HasCredentials() {
+				data["authenticated"] = true
+				badge, cached, badgeWarnings := loadBasketBadge(cmd, deps, flags, &auth, profileName, profile.Location, maxAge, swr)
+				warnings = append(warnings, badgeWarnings...)
+				if badge != nil {
+					data["basket_count"] = badge.BasketCount
+					data["item_count"] = badge.ItemCount
+					data["total"] = map[string]any{
+						"amount":           badge.TotalAmount,
+						"formatted_amount": emptyToNil(formatMinorAmount(badge.TotalAmount, badge.Currency)),
+					}
+					data["cached"] = cached
+					data["checked_at"] = badge.CheckedAt.UTC().Format(time.RFC3339)
+					if badge.RefreshDue {
+						data["stale"] = true
+						data["age"] = fmt.Sprintf("%ds", int(deps.now().Sub(badge.CheckedAt).Seconds()))
+					}
+				}
+			}
+
+			if prompt {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), basketBadgeLine(data))
+				return err
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildStTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	cmd.Flags().DurationVar(&maxAge, "max-age", basketBadgeMaxAge, "Reuse the cached basket summary while it is younger than this; 0 always fetches.")
+	cmd.Flags().BoolVar(&prompt, "prompt", false, "Print one plain line for shell prompts instead of a table or envelope.")
+	cmd.Flags().BoolVar(&swr, "swr", false, "Answer from an expired cached summary (marked stale) and fetch live on the next call.")
+	return cmd
+}
+
+// loadBasketBadge returns the profile's cached basket summary while it is
+// fresh, else fetches the baskets page once and caches the result. With swr an
+// expired summary is returned once with RefreshDue set, and the call after it
+// fetches. A failed fetch returns nil with a warning so prompts keep rendering.
+func loadBasketBadge(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	profileName string,
+	location domain.Location,
+	maxAge time.Duration,
+	swr bool,
+) (*basketBadge, bool, []string) {
+	file, cacheErr := openCLICache(deps, basketBadgeCacheFile)
+	var badge basketBadge
+	if file != nil && maxAge > 0 && file.Get(profileName, maxAge, deps.now(), &badge) && !badge.RefreshDue {
+		return &badge, true, nil
+	}
+	if swr && file != nil && file.Get(profileName, 0, deps.now(), &badge) && !badge.RefreshDue {
+		badge.RefreshDue = true
+		// Keep the original store time so the entry stays expired.
+		_ = file.Put(profileName, badge, badge.CheckedAt)
+		var warnings []string
+		if err := file.Save(); err != nil {
+			warnings = append(warnings, fmt.Sprintf("basket cache not saved: %v", err))
+		}
+		return &badge, true, warnings
+	}
+
+	page, warnings, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.BasketsPage(cmd.Context(), location, authCtx)
+		},
+	)
+	if err != nil {
+		return nil, false, append(warnings, "open baskets could not be loaded: "+woltgateway.Redact(err.Error()))
+	}
+	badge = summarizeBaskets(page)
+	badge.CheckedAt = deps.now().UTC()
+	if file == nil {
+		return &badge, false, append(warnings, fmt.Sprintf("basket cache unavailable: %v", cacheErr))
+	}
+	// The summary is plain ints and strings, so encoding cannot fail.
+	_ = file.Put(profileName, badge, deps.now())
+	if err := file.Save(); err != nil {
+		warnings = append(warnings, fmt.Sprintf("basket cache not saved: %v", err))
+	}
+	return &badge, false, warnings
+}
+
+// summarizeBaskets counts non-empty baskets and adds up their totals the way
+// cart show does: the telemetry basket total, else the line subtotal.
+func summarizeBaskets(page map[string]any) basketBadge {
+	badge := basketBadge{}
+	for _, value := range asSlice(page["baskets"]) {
+		basket := asMap(value)
+		items := asSlice(basket["items"])
+		if len(items) == 0 {
+			continue
+		}
+		badge.BasketCount++
+		for _, item := range items {
+			badge.ItemCount += max(asInt(asMap(item)["count"]), 1)
+		}
+		total := asInt(asMap(basket["telemetry"])["basket_total"])
+		if total <= 0 {
+			total = basketSubtotal(basket)
+		}
+		badge.TotalAmount += total
+		if badge.Currency == "" {
+			badge.Currency = inferCurrency(asString(basket["total"]))
+		}
+	}
+	return badge
+}
+
+// basketBadgeLine renders data as "<profile>", "<profile> (logged out)", or
+// "<profile> 2 carts €18.40".
+func basketBadgeLine(data map[string]any) string {
+	line := asString(data["profile"])
+	if !asBool(data["authenticated"]) {
+		return line + " (logged out)"
+	}
+	if data["basket_count"] == nil {
+		return line + " ?"
+	}
+	count := asInt(data["basket_count"])
+	if count == 0 {
+		return line
+	}
+	noun := "carts"
+	if count == 1 {
+		noun = "cart"
+	}
+	line = fmt.Sprintf("%s %d %s", line, count, noun)
+	if total := asString(asMap(data["total"])["formatted_amount"]); total != "" {
+		line += " " + total
+	}
+	return line
+}
+
+// cachedLabel is "yes", "no", or "stale (143s)" for a summary served by --swr.
+func cachedLabel(data map[string]any) string {
+	if asBool(data["stale"]) {
+		return fmt.Sprintf("stale (%s)", asString(data["age"]))
+	}
+	return boolToYesNo(asBool(data["cached"]))
+}
+
+func buildStTable(data map[string]any) string {
+	baskets, items, total := "-", "-", "-"
+	if data["basket_count"] != nil {
+		baskets = asString(data["basket_count"])
+		items = asString(data["item_count"])
+		total = fallbackString(asString(asMap(data["total"])["formatted_amount"]), "-")
+	}
+	rows := [][]string{{
+		asString(data["profile"]),
+		boolToYesNo(asBool(data["authenticated"])),
+		baskets,
+		items,
+		total,
+		cachedLabel(data),
+	}}
+	return output.RenderTable("Status", []string{"Profile", "Logged in", "Baskets", "Items", "Total", "Cached"}, rows)
+}
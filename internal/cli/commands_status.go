@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusProbeAddress = "Helsinki"
+	statusProbeQuery   = "pizza"
+)
+
+// statusFallbackLocation is used for location-scoped probes when neither the
+// profile nor the geocoder yields coordinates.
+var statusFallbackLocation = domain.Location{Lat: 60.1699, Lon: 24.9384}
+
+// statusProbe checks one endpoint family. auth probes are skipped without
+// credentials; venue probes are skipped without --venue.
+type statusProbe struct {
+	name   string
+	family string
+	auth   bool
+	venue  bool
+	call   func(ctx context.Context, location domain.Location, slug string, auth woltgateway.AuthContext) error
+}
+
+func statusProbes(deps Dependencies) []statusProbe {
+	return []statusProbe{
+		{name: "front_page", family: "consumer-api", call: func(ctx context.Context, location domain.Location, _ string, _ woltgateway.AuthContext) error {
+			_, err := deps.Wolt.FrontPage(ctx, location)
+			return err
+		}},
+		{name: "search", family: "restaurant-api", call: func(ctx context.Context, location domain.Location, _ string, _ woltgateway.AuthContext) error {
+			_, err := deps.Wolt.Search(ctx, location, statusProbeQuery)
+			return err
+		}},
+		{name: "venue_page_static", family: "restaurant-api", venue: true, call: func(ctx context.Context, _ domain.Location, slug string, _ woltgateway.AuthContext) error {
+			_, err := deps.Wolt.VenuePageStatic(ctx, slug)
+			return err
+		}},
+		{name: "assortment", family: "consumer-api", venue: true, call: func(ctx context.Context, _ domain.Location, slug string, _ woltgateway.AuthContext) error {
+			_, err := deps.Wolt.AssortmentByVenueSlug(ctx, slug)
+			return err
+		}},
+		{name: "user_me", family: "restaurant-api", auth: true, call: func(ctx context.Context, _ domain.Location, _ string, auth woltgateway.AuthContext) error {
+			_, err := deps.Wolt.UserMe(ctx, auth)
+			return err
+		}},
+		{name: "basket_count", family: "consumer-api", auth: true, call: func(ctx context.Context, _ domain.Location, _ string, auth woltgateway.AuthContext) error {
+			_, err := deps.Wolt.BasketCount(ctx, auth)
+			return err
+		}},
+		{name: "order_history", family: "consumer-api", auth: true, call: func(ctx context.Context, _ domain.Location, _ string, auth woltgateway.AuthContext) error {
+			_, err := deps.Wolt.OrderHistory(ctx, auth, woltgateway.OrderHistoryOptions{Limit: 1})
+			return err
+		}},
+		{name: "payment_methods", family: "payment-service", auth: true, call: func(ctx context.Context, _ domain.Location, _ string, auth woltgateway.AuthContext) error {
+			_, err := deps.Wolt.PaymentMethods(ctx, auth)
+			return err
+		}},
+	}
+}
+
+func newStatusCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var venueSlug string
+	var failOnError bool
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Check reachability and latency of the Wolt endpoints and the geocoder.",
+		Long: "Check reachability and latency of the Wolt endpoints and the geocoder.\n\n" +
+			"Public endpoints are probed without credentials and account endpoints with the profile token, so the\n" +
+			"diagnosis tells an upstream outage (wolt_unreachable) apart from a broken token (auth_failed).",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			venueSlug = strings.TrimSpace(venueSlug)
+			lat, lon := coordinateFlags(cmd)
+			switch {
+			case (lat != nil || lon != nil) && strings.TrimSpace(flags.Address) != "":
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", addressWithCoordinatesMessage)
+			case (lat == nil) != (lon == nil):
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", partialCoordinatesMessage)
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			ctx := cmd.Context()
+			// Probe every family for real; 410 answers are recorded again.
+			forgetUnsupportedFamilies(deps, profileName)
+
+			endpoints := make([]any, 0)
+			location, located := domain.Location{}, false
+			if deps.Profiles != nil {
+				if profile, err := deps.Profiles.Find(ctx, flags.Profile); err == nil {
+					profileName = profile.Name
+					if profile.Location != (domain.Location{}) {
+						location, located = profile.Location, true
+					}
+				}
+			}
+			address := fallbackString(strings.TrimSpace(flags.Address), statusProbeAddress)
+			if deps.Location == nil {
+				endpoints = append(endpoints, statusEndpoint("geocoder", "geocoder", "skipped", "location resolver is not available"))
+			} else {
+				started := time.Now()
+				geocoded, err := deps.Location.Get(ctx, address)
+				endpoints = append(endpoints, statusResult("geocoder", "geocoder", time.Since(started), err))
+				if err == nil && (strings.TrimSpace(flags.Address) != "" || !located) {
+					location, located = geocoded, true
+				}
+			}
+			if lat != nil && lon != nil {
+				location, located = domain.Location{Lat: *lat, Lon: *lon}, true
+			}
+			if !located {
+				location = statusFallbackLocation
+			}
+
+			warnings := []string{}
+			for _, probe := range statusProbes(deps) {
+				switch {
+				case probe.auth && !auth.HasCredentials():
+					endpoints = append(endpoints, statusEndpoint(probe.name, probe.family, "skipped", "no auth credentials provided"))
+					continue
+				case probe.venue && venueSlug == "":
+					endpoints = append(endpoints, statusEndpoint(probe.name, probe.family, "skipped", "pass --venue to probe venue endpoints"))
+					continue
+				}
+				started := time.Now()
+				var err error
+				if probe.auth {
+					var authWarnings []string
+					_, authWarnings, err = invokeWithAuthAutoRefresh(ctx, deps, flags, &auth, func(authCtx woltgateway.AuthContext) (struct{}, error) {
+						return struct{}{}, probe.call(ctx, location, venueSlug, authCtx)
+					})
+					warnings = append(warnings, authWarnings...)
+				} else {
+					err = probe.call(ctx, location, venueSlug, auth)
+				}
+				endpoints = append(endpoints, statusResult(probe.name, probe.family, time.Since(started), err))
+			}
+
+			data := map[string]any{
+				"checked_at": deps.now().UTC().Format(time.RFC3339),
+				"location":   map[string]any{"lat": location.Lat, "lon": location.Lon},
+				"endpoints":  endpoints,
+			}
+			summary, diagnosis := summarizeStatus(endpoints)
+			data["summary"] = summary
+			data["diagnosis"] = diagnosis
+
+			if format == output.FormatTable {
+				err = writeTable(cmd, buildStatusTable(data), flags.Output)
+			} else {
+				env := output.BuildEnvelope(profileName, flags.Locale, data, dedupeStrings(warnings), nil)
+				err = writeMachinePayload(cmd, env, format, flags.Output)
+			}
+			if err == nil && failOnError && diagnosis != "healthy" {
+				return &exitError{code: 1}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&venueSlug, "venue", "", "Venue slug used to probe the venue page and assortment endpoints.")
+	cmd.Flags().BoolVar(&failOnError, "fail-on-error", false, "Exit with code 1 unless every probed endpoint is healthy.")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func statusEndpoint(name string, family string, status string, detail string) map[string]any {
+	return map[string]any{
+		"name":        name,
+		"family":      family,
+		"status":      status,
+		"http_status": nil,
+		"latency_ms":  nil,
+		"detail":      emptyToNil(detail),
+	}
+}
+
+// statusResult classifies one probe: ok, auth_error for 401/403,
+// unsupported_in_region for 410, offline, or error for anything else.
+func statusResult(name string, family string, elapsed time.Duration, err error) map[string]any {
+	endpoint := statusEndpoint(name, family, "ok", "")
+	endpoint["latency_ms"] = elapsed.Milliseconds()
+	if err == nil {
+		return endpoint
+	}
+	endpoint["status"] = "error"
+	endpoint["detail"] = woltgateway.Redact(err.Error())
+	var upstreamErr *woltgateway.UpstreamRequestError
+	switch {
+	case errors.Is(err, domain.ErrOffline):
+		endpoint["status"] = "offline"
+		endpoint["latency_ms"] = nil
+	case errors.As(err, &upstreamErr) && upstreamErr.StatusCode > 0:
+		endpoint["http_status"] = upstreamErr.StatusCode
+		endpoint["detail"] = fmt.Sprintf("upstream returned status %d", upstreamErr.StatusCode)
+		switch upstreamErr.StatusCode {
+		case 401, 403:
+			endpoint["status"] = "auth_error"
+		case 410:
+			endpoint["status"] = "unsupported_in_region"
+		}
+	}
+	return endpoint
+}
+
+// summarizeStatus counts probe outcomes and names the likely cause:
+// healthy, wolt_unreachable when no Wolt endpoint answered, auth_failed when
+// only account endpoints failed, and degraded otherwise.
+func summarizeStatus(endpoints []any) (map[string]any, string) {
+	counts := map[string]int{}
+	woltOK, woltFailed, authFailed, otherFailed := 0, 0, 0, 0
+	for _, value := range endpoints {
+		endpoint := asMap(value)
+		status := asString(endpoint["status"])
+		counts[status]++
+		isWolt := asString(endpoint["name"]) != "geocoder"
+		switch status {
+		case "ok":
+			if isWolt {
+				woltOK++
+			}
+		case "skipped", "unsupported_in_region":
+		case "auth_error":
+			authFailed++
+			woltFailed++
+		default:
+			otherFailed++
+			if isWolt {
+				woltFailed++
+			}
+		}
+	}
+	summary := map[string]any{
+		"ok":          counts["ok"],
+		"failed":      authFailed + otherFailed,
+		"skipped":     counts["skipped"],
+		"unsupported": counts["unsupported_in_region"],
+	}
+	switch {
+	case woltFailed > 0 && woltOK == 0 && authFailed < woltFailed:
+		return summary, "wolt_unreachable"
+	case authFailed > 0 && otherFailed == 0:
+		return summary, "auth_failed"
+	case authFailed+otherFailed > 0:
+		return summary, "degraded"
+	default:
+		return summary, "healthy"
+	}
+}
+
+func buildStatusTable(data map[string]any) string {
+	headers := []string{"Endpoint", "Family", "Status", "HTTP", "Latency", "Detail"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["endpoints"]) {
+		endpoint := asMap(value)
+		latency := "-"
+		if endpoint["latency_ms"] != nil {
+			latency = fmt.Sprintf("%d ms", asInt(endpoint["latency_ms"]))
+		}
+		httpStatus := "-"
+		if endpoint["http_status"] != nil {
+			httpStatus = asString(endpoint["http_status"])
+		}
+		rows = append(rows, []string{
+			asString(endpoint["name"]),
+			asString(endpoint["family"]),
+			asString(endpoint["status"]),
+			httpStatus,
+			latency,
+			fallbackString(asString(endpoint["detail"]), "-"),
+		})
+	}
+	return output.RenderTable("Wolt status: "+asString(data["diagnosis"]), headers, rows)
+}
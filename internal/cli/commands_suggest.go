@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	suggestBasedOnPurchaseHistory = "purchase-history"
+	suggestDefaultLimit           = 5
+	suggestTopItems               = 3
+)
+
+func newSuggestCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var basedOn string
+	var historyLimit int
+	var limit int
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "suggest",
+		Short: "Rank venues and items you reorder often and flag current deals on them.",
+		Long: "Rank venues and items you reorder often and flag current deals on them.\n\n" +
+			"Combines order history, the discovery feed for the current location, and the dynamic venue page " +
+			"of each suggested venue, so a run costs one request per suggested venue on top of history and feed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if strings.TrimSpace(basedOn) != suggestBasedOnPurchaseHistory {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+					fmt.Sprintf("--based-on must be %s", suggestBasedOnPurchaseHistory))
+			}
+			if historyLimit < 1 || historyLimit > profileOrdersMaxLimit {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT",
+					fmt.Sprintf("--history-limit must be between 1 and %d", profileOrdersMaxLimit))
+			}
+			if limit < 1 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--limit must be >= 1")
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+				return err
+			}
+
+			history, warnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.OrderHistory(cmd.Context(), authCtx, woltgateway.OrderHistoryOptions{Limit: lowBandwidthPageLimit(cmd, "history-limit", historyLimit)})
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&auth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+			feed := map[string]any{}
+			if frontPage, err := deps.Wolt.FrontPage(cmd.Context(), location); err != nil {
+				recordPartialFailure(cmd.Context(), "discovery feed", err)
+			} else if sections, err := extractDiscoverSectionsFromFrontPage(frontPage); err == nil {
+				feed = observability.BuildDiscoveryFeed(sections, "", nil, false)
+			} else {
+				warnings = append(warnings, "front page sections missing; deal flags limited to venue pages")
+			}
+
+			orders := asSlice(history["orders"])
+			venues := buildPurchaseHistorySuggestions(orders, feed)
+			if len(venues) > limit {
+				venues = venues[:limit]
+			}
+			for _, venue := range venues {
+				slug := asString(venue["slug"])
+				if slug == "" {
+					continue
+				}
+				payload, err := deps.Wolt.VenuePageDynamic(cmd.Context(), slug, woltgateway.VenuePageDynamicOptions{Location: &location, Auth: auth})
+				if err != nil {
+					recordPartialFailure(cmd.Context(), "venue dynamic page", err)
+					continue
+				}
+				menu, _ := observability.BuildVenueMenu(asString(venue["venue_id"]), []map[string]any{payload}, "", false, nil)
+				markDiscountedSuggestionItems(venue, menu)
+			}
+
+			rows := make([]any, 0, len(venues))
+			for _, venue := range venues {
+				rows = append(rows, venue)
+			}
+			data := map[string]any{
+				"based_on":        suggestBasedOnPurchaseHistory,
+				"orders_analyzed": len(orders),
+				"venues":          rows,
+				"count":           len(rows),
+			}
+			if len(orders) == 0 {
+				warnings = append(warnings, "order history is empty; nothing to suggest")
+			}
+			warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildSuggestTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&basedOn, "based-on", suggestBasedOnPurchaseHistory, "Suggestion source: purchase-history")
+	cmd.Flags().IntVar(&historyLimit, "history-limit", profileOrdersDefaultLimit, fmt.Sprintf("Number of recent orders to analyze (1-%d)", profileOrdersMaxLimit))
+	cmd.Flags().IntVar(&limit, "limit", suggestDefaultLimit, "Number of venues to suggest")
+	addStrictFlag(cmd, &strict)
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// buildPurchaseHistorySuggestions groups orders by venue, ranks venues by order
+// count (ties keep the most recently ordered first) and joins discovery feed rows
+// for free delivery and venue promotions.
+func buildPurchaseHistorySuggestions(orders []any, feed map[string]any) []map[string]any {
+	feedVenues := map[string]map[string]any{}
+	for _, section := range asSlice(feed["sections"]) {
+		for _, value := range asSlice(asMap(section)["items"]) {
+			row := asMap(value)
+			for _, key := range []string{asString(row["venue_id"]), asString(row["slug"]), asString(row["name"])} {
+				if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+					if _, exists := feedVenues[key]; !exists {
+						feedVenues[key] = row
+					}
+				}
+			}
+		}
+	}
+
+	type venueStats struct {
+		row        map[string]any
+		orders     int
+		firstIndex int
+		itemCounts map[string]int
+		itemOrder  []string
+	}
+	byKey := map[string]*venueStats{}
+	ordered := []*venueStats{}
+	for idx, value := range orders {
+		order := asMap(value)
+		name := strings.TrimSpace(asString(order["venue_name"]))
+		venueID := strings.TrimSpace(asString(order["venue_id"]))
+		key := strings.ToLower(fallbackString(venueID, name))
+		if key == "" {
+			continue
+		}
+		stats, ok := byKey[key]
+		if !ok {
+			stats = &venueStats{
+				row: map[string]any{
+					"venue_name":      name,
+					"venue_id":        venueID,
+					"slug":            strings.TrimSpace(asString(order["venue_slug"])),
+					"last_ordered_at": strings.TrimSpace(asString(order["received_at"])),
+				},
+				firstIndex: idx,
+				itemCounts: map[string]int{},
+			}
+			byKey[key] = stats
+			ordered = append(ordered, stats)
+		}
+		stats.orders++
+		for _, itemName := range orderHistoryItemNames(order) {
+			if _, seen := stats.itemCounts[itemName]; !seen {
+				stats.itemOrder = append(stats.itemOrder, itemName)
+			}
+			stats.itemCounts[itemName]++
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].orders != ordered[j].orders {
+			return ordered[i].orders > ordered[j].orders
+		}
+		return ordered[i].firstIndex < ordered[j].firstIndex
+	})
+
+	venues := make([]map[string]any, 0, len(ordered))
+	for _, stats := range ordered {
+		row := stats.row
+		row["order_count"] = stats.orders
+
+		var feedRow map[string]any
+		for _, key := range []string{asString(row["venue_id"]), asString(row["slug"]), asString(row["venue_name"])} {
+			if match, ok := feedVenues[strings.ToLower(key)]; ok && key != "" {
+				feedRow = match
+				break
+			}
+		}
+		row["in_feed"] = feedRow != nil
+		row["free_delivery"] = false
+		row["promotions"] = []string{}
+		row["delivery_fee"] = nil
+		if feedRow != nil {
+			row["venue_id"] = fallbackString(asString(row["venue_id"]), asString(feedRow["venue_id"]))
+			row["slug"] = fallbackString(asString(row["slug"]), asString(feedRow["slug"]))
+			fee := asMap(feedRow["delivery_fee"])
+			row["delivery_fee"] = fee
+			row["free_delivery"] = fee["amount"] != nil && asInt(fee["amount"]) == 0
+			row["promotions"] = toStringSlice(asSlice(feedRow["promotions"]))
+		}
+
+		itemNames := append([]string(nil), stats.itemOrder...)
+		sort.SliceStable(itemNames, func(i, j int) bool {
+			return stats.itemCounts[itemNames[i]] > stats.itemCounts[itemNames[j]]
+		})
+		items := make([]any, 0, len(itemNames))
+		for _, itemName := range itemNames {
+			items = append(items, map[string]any{
+				"name":        itemName,
+				"order_count": stats.itemCounts[itemName],
+				"discounted":  false,
+				"discounts":   []string{},
+			})
+		}
+		row["items"] = items
+		venues = append(venues, row)
+	}
+	return venues
+}
+
+func orderHistoryItemNames(order map[string]any) []string {
+	names := []string{}
+	if _, isList := order["items"].([]any); isList {
+		for _, value := range asSlice(order["items"]) {
+			if name := strings.TrimSpace(asString(asMap(value)["name"])); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	for _, part := range strings.Split(orderHistoryItemsSummary(order), ",") {
+		if name := strings.TrimSpace(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// markDiscountedSuggestionItems flags reordered items that carry a discount on the
+// venue's dynamic page, matching by item name.
+func markDiscountedSuggestionItems(venue map[string]any, menu map[string]any) {
+	discountsByName := map[string][]string{}
+	rows, _ := menu["items"].([]map[string]any)
+	for _, row := range rows {
+		labels := []string{}
+		for _, label := range asSlice(row["discounts"]) {
+			if text := strings.TrimSpace(asString(label)); text != "" {
+				labels = append(labels, text)
+			}
+		}
+		if len(labels) > 0 {
+			discountsByName[strings.ToLower(asString(row["name"]))] = labels
+		}
+	}
+	for _, value := range asSlice(venue["items"]) {
+		item := asMap(value)
+		if labels, ok := discountsByName[strings.ToLower(asString(item["name"]))]; ok {
+			item["discounted"] = true
+			item["discounts"] = labels
+		}
+	}
+}
+
+func buildSuggestTable(data map[string]any) string {
+	headers := []string{"Venue", "Orders", "Last ordered", "Free delivery", "Promotions", "Top items"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["venues"]) {
+		venue := asMap(value)
+		freeDelivery := "no"
+		if asBool(venue["free_delivery"]) {
+			freeDelivery = "yes"
+		} else if !asBool(venue["in_feed"]) {
+			freeDelivery = "-"
+		}
+		promotions := "-"
+		if labels := stringsJoin(asSlice(venue["promotions"]), ", "); labels != "" {
+			promotions = labels
+		}
+		topItems := []string{}
+		for idx, itemValue := range asSlice(venue["items"]) {
+			if idx == suggestTopItems {
+				break
+			}
+			item := asMap(itemValue)
+			label := fmt.Sprintf("%s x%d", asString(item["name"]), asInt(item["order_count"]))
+			if asBool(item["discounted"]) {
+				label += " (deal)"
+			}
+			topItems = append(topItems, label)
+		}
+		rows = append(rows, []string{
+			fallbackString(asString(venue["venue_name"]), "-"),
+			fmt.Sprintf("%d", asInt(venue["order_count"])),
+			fallbackString(asString(venue["last_ordered_at"]), "-"),
+			freeDelivery,
+			promotions,
+			fallbackString(strings.Join(topItems, ", "), "-"),
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"-", "-", "-", "-", "-", "-"})
+	}
+	return output.RenderTable("Suggestions from purchase history", headers, rows)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestBuildPurchaseHistorySuggestionsRanksByOrderCount(t *testing.T) {
+	orders := []any{
+		map[string]any{"venue_name": "Sushi Bar", "received_at": "16/02/2026, 19:00", "items": "Salmon roll, Miso soup"},
+		map[string]any{"venue_name": "Burger Place", "received_at": "15/02/2026, 10:06", "items": []any{map[string]any{"name": "Fries"}}},
+		map[string]any{"venue_name": "Burger Place", "received_at": "10/02/2026, 12:00", "items": []any{map[string]any{"name": "Fries"}, map[string]any{"name": "Whopper"}}},
+	}
+	feed := map[string]any{
+		"sections": []any{
+			map[string]any{"items": []any{
+				map[string]any{
+					"venue_id":     "venue-1",
+					"slug":         "burger-place",
+					"name":         "Burger Place",
+					"delivery_fee": map[string]any{"amount": 0, "formatted_amount": "€0.00"},
+					"promotions":   []string{"2 for 1"},
+				},
+			}},
+		},
+	}
+
+	venues := buildPurchaseHistorySuggestions(orders, feed)
+	if len(venues) != 2 || venues[0]["venue_name"] != "Burger Place" || venues[0]["order_count"] != 2 {
+		t.Fatalf("unexpected ranking: %#v", venues)
+	}
+	burger := venues[0]
+	if burger["slug"] != "burger-place" || burger["free_delivery"] != true || burger["last_ordered_at"] != "15/02/2026, 10:06" {
+		t.Fatalf("expected feed data joined into burger row: %#v", burger)
+	}
+	items := asSlice(burger["items"])
+	if asString(asMap(items[0])["name"]) != "Fries" || asInt(asMap(items[0])["order_count"]) != 2 {
+		t.Fatalf("unexpected item ranking: %#v", items)
+	}
+	if venues[1]["in_feed"] != false || len(asSlice(venues[1]["items"])) != 2 {
+		t.Fatalf("unexpected sushi row: %#v", venues[1])
+	}
+
+	markDiscountedSuggestionItems(burger, map[string]any{
+		"items": []map[string]any{{"name": "Whopper", "discounts": []string{"-30%"}}},
+	})
+	whopper := asMap(items[1])
+	if whopper["discounted"] != true || stringsJoin(asSlice(whopper["discounts"]), ",") != "-30%" {
+		t.Fatalf("expected discounted whopper, got %#v", whopper)
+	}
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/mekedron/wolt-cli/internal/service/track"
+	"github.com/spf13/cobra"
+)
+
+const trackDirEnv = "WOLT_TRACK_DIR"
+
+func newTrackCommand(deps Dependencies) *cobra.Command {
+	trackCmd := &cobra.Command{
+		Use:   "track",
+		Short: "Track item prices over time in a local CSV store.",
+		Long: "Track item prices over time in a local CSV store.\n\n" +
+			"Data lives in $WOLT_TRACK_DIR, or a track directory next to the config file. " +
+			"Schedule `wolt track run` (for example from cron) to build up history.",
+	}
+	trackCmd.AddCommand(newTrackAddCommand(deps))
+	trackCmd.AddCommand(newTrackRunCommand(deps))
+	trackCmd.AddCommand(newTrackChartCommand(deps))
+	return trackCmd
+}
+
+func newTrackAddCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "add <venue-slug> <item-id>",
+		Short: "Add a venue item to the price-tracking watchlist.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			venueSlug := strings.TrimSpace(args[0])
+			itemID := strings.TrimSpace(args[1])
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			venueSlug, err = resolveVenueSlugArgument(cmd, deps, flags, format, venueSlug)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			store, err := openTrackStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			observation, warnings, ok := observeTrackedItem(cmd, deps, track.Target{VenueSlug: venueSlug, ItemID: itemID}, auth)
+			if !ok {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_ITEM_NOT_FOUND", fmt.Sprintf(
+					"item %q was not found for venue slug %q; run \"wolt venue menu %s\" to list valid item IDs",
+					itemID,
+					venueSlug,
+					venueSlug,
+				))
+			}
+			target := track.Target{VenueSlug: venueSlug, ItemID: itemID, Name: observation.Name, AddedAt: deps.now().UTC()}
+			added, err := store.AddTarget(target)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+			if !added {
+				warnings = append(warnings, "item is already tracked")
+			}
+			targets, err := store.Targets()
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+
+			data := map[string]any{
+				"venue_slug": venueSlug,
+				"item_id":    itemID,
+				"name":       observation.Name,
+				"added":      added,
+				"tracked":    len(targets),
+				"store":      store.Dir(),
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, output.RenderTable("Tracked item", []string{"Field", "Value"}, [][]string{
+					{"Venue", venueSlug},
+					{"Item ID", itemID},
+					{"Name", fallbackString(observation.Name, "-")},
+					{"Added", fmt.Sprintf("%t", added)},
+					{"Tracked items", fmt.Sprintf("%d", len(targets))},
+					{"Store", store.Dir()},
+				}), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func newTrackRunCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Record the current price of every tracked item.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			store, err := openTrackStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+			targets, err := store.Targets()
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			observedAt := deps.now().UTC()
+			observations := []track.Observation{}
+			warnings := []string{}
+			for _, target := range targets {
+				observation, itemWarnings, ok := observeTrackedItem(cmd, deps, target, auth)
+				if !ok {
+					warnings = append(warnings, fmt.Sprintf("%s/%s: item not found; skipped", target.VenueSlug, target.ItemID))
+					continue
+				}
+				warnings = append(warnings, itemWarnings...)
+				observation.ObservedAt = observedAt
+				observations = append(observations, observation)
+			}
+			if err := store.Append(observations); err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+			if len(targets) == 0 {
+				warnings = append(warnings, "no tracked items; add one with \"wolt track add <venue-slug> <item-id>\"")
+			}
+
+			rows := make([]any, 0, len(observations))
+			for _, observation := range observations {
+				rows = append(rows, trackObservationRow(observation))
+			}
+			data := map[string]any{
+				"observed_at":  observedAt.Format(time.RFC3339),
+				"observations": rows,
+				"count":        len(rows),
+				"store":        store.ObservationsPath(),
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildTrackRunTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func newTrackChartCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "chart <item-id>",
+		Short: "Print an ASCII sparkline of an item's recorded price history.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			itemID := strings.TrimSpace(args[0])
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			store, err := openTrackStore(deps)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+			observations, err := store.Observations(itemID)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_TRACK_STORE_ERROR", err.Error())
+			}
+
+			data, warnings := buildTrackChart(itemID, observations)
+			if format == output.FormatTable {
+				return writeTable(cmd, buildTrackChartTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func openTrackStore(deps Dependencies) (*track.Store, error) {
+	dir, err := stateDir(deps, trackDirEnv, "track")
+	if err != nil {
+		return nil, err
+	}
+	return track.NewStore(dir), nil
+}
+
+// observeTrackedItem reads the current menu row for target. ok is false when the
+// venue payloads no longer contain the item.
+func observeTrackedItem(
+	cmd *cobra.Command,
+	deps Dependencies,
+	target track.Target,
+	auth woltgateway.AuthContext,
+) (track.Observation, []string, bool) {
+	venueID, payload, warnings := resolveVenueItemPayloadBySlug(cmd.Context(), deps, target.VenueSlug, target.ItemID, auth)
+	if !payloadContainsItem(payload, venueID, target.ItemID) {
+		return track.Observation{}, warnings, false
+	}
+	menu, _ := observability.BuildVenueMenu(venueID, []map[string]any{payload}, "", false, nil)
+	rows, _ := menu["items"].([]map[string]any)
+	for _, row := range rows {
+		if !strings.EqualFold(asString(row["item_id"]), target.ItemID) {
+			continue
+		}
+		basePrice := asMap(row["base_price"])
+		return track.Observation{
+			VenueSlug:      target.VenueSlug,
+			ItemID:         target.ItemID,
+			Name:           asString(row["name"]),
+			Amount:         asInt(basePrice["amount"]),
+			Currency:       asString(basePrice["currency"]),
+			OriginalAmount: asInt(asMap(row["original_price"])["amount"]),
+			Discounts:      toStringSlice(asSlice(row["discounts"])),
+		}, warnings, true
+	}
+	return track.Observation{}, warnings, false
+}
+
+func trackObservationRow(observation track.Observation) map[string]any {
+	var originalAmount any
+	if observation.OriginalAmount > 0 {
+		originalAmount = observation.OriginalAmount
+	}
+	return map[string]any{
+		"observed_at":     observation.ObservedAt.Format(time.RFC3339),
+		"venue_slug":      observation.VenueSlug,
+		"item_id":         observation.ItemID,
+		"name":            observation.Name,
+		"amount":          observation.Amount,
+		"currency":        observation.Currency,
+		"original_amount": originalAmount,
+		"discounts":       observation.Discounts,
+	}
+}
+
+func buildTrackChart(itemID string, observations []track.Observation) (map[string]any, []string) {
+	rows := make([]any, 0, len(observations))
+	amounts := make([]int, 0, len(observations))
+	for _, observation := range observations {
+		rows = append(rows, trackObservationRow(observation))
+		amounts = append(amounts, observation.Amount)
+	}
+	data := map[string]any{
+		"item_id":      itemID,
+		"name":         nil,
+		"currency":     nil,
+		"observations": rows,
+		"count":        len(rows),
+		"min":          nil,
+		"max":          nil,
+		"latest":       nil,
+		"sparkline":    track.Sparkline(amounts),
+	}
+	if len(observations) == 0 {
+		return data, []string{fmt.Sprintf("no observations for item %q; run \"wolt track run\" first", itemID)}
+	}
+	latest := observations[len(observations)-1]
+	data["name"] = latest.Name
+	data["currency"] = latest.Currency
+	data["min"] = slices.Min(amounts)
+	data["max"] = slices.Max(amounts)
+	data["latest"] = latest.Amount
+	return data, nil
+}
+
+func buildTrackRunTable(data map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(data["observations"]) {
+		row := asMap(value)
+		rows = append(rows, []string{
+			asString(row["venue_slug"]),
+			asString(row["item_id"]),
+			asString(row["name"]),
+			trackAmountLabel(row["amount"], asString(row["currency"])),
+			fallbackString(stringsJoin(asSlice(row["discounts"]), ", "), "-"),
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"-", "-", "-", "-", "-"})
+	}
+	return output.RenderTable("Price observations "+asString(data["observed_at"]), []string{"Venue", "Item ID", "Name", "Price", "Discounts"}, rows)
+}
+
+func buildTrackChartTable(data map[string]any) string {
+	currency := asString(data["currency"])
+	summary := output.RenderTable("Price history: "+fallbackString(asString(data["name"]), asString(data["item_id"])), []string{"Field", "Value"}, [][]string{
+		{"Sparkline", fallbackString(asString(data["sparkline"]), "-")},
+		{"Observations", fmt.Sprintf("%d", asInt(data["count"]))},
+		{"Min", trackAmountLabel(data["min"], currency)},
+		{"Max", trackAmountLabel(data["max"], currency)},
+		{"Latest", trackAmountLabel(data["latest"], currency)},
+	})
+	rows := [][]string{}
+	for _, value := range asSlice(data["observations"]) {
+		row := asMap(value)
+		rows = append(rows, []string{
+			asString(row["observed_at"]),
+			trackAmountLabel(row["amount"], currency),
+			fallbackString(stringsJoin(asSlice(row["discounts"]), ", "), "-"),
+		})
+	}
+	if len(rows) == 0 {
+		return summary
+	}
+	return summary + "\n\n" + output.RenderTable("Observations", []string{"Observed", "Price", "Discounts"}, rows)
+}
+
+func trackAmountLabel(amount any, currency string) string {
+	if amount == nil {
+		return "-"
+	}
+	return formatBasePriceForTable(map[string]any{"amount": amount, "currency": currency})
+}
@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const defaultTravelProfileName = "travel"
+
+func newTravelCommand(deps Dependencies) *cobra.Command {
+	travel := &cobra.Command{
+		Use:   "travel",
+		Short: "Switch to a temporary profile located in another city.",
+	}
+	travel.AddCommand(newTravelSetCommand(deps))
+	travel.AddCommand(newTravelClearCommand(deps))
+	return travel
+}
+
+func newTravelSetCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var name string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "set <address>",
+		Short: "Geocode a destination, check Wolt delivers there, and make a travel profile the default.",
+		Long: "Geocode a destination, check Wolt delivers there, and make a travel profile the default.\n\n" +
+			"The travel profile copies credentials from the current profile and uses the destination instead of\n" +
+			"the Wolt account address until wolt travel clear restores the previous default.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			name = strings.TrimSpace(name)
+			address := strings.TrimSpace(args[0])
+			if address == "" || name == "" {
+				return emitError(cmd, format, name, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "address and --name must not be empty")
+			}
+			if deps.Location == nil {
+				return emitError(cmd, format, name, flags.Locale, flags.Output, "WOLT_LOCATION_RESOLVE_ERROR", "Location resolver is not available.")
+			}
+			location, _, err := geocodeAddress(cmd.Context(), deps, address)
+			if err != nil {
+				return emitError(cmd, format, name, flags.Locale, flags.Output, locationErrorCode(err), err.Error())
+			}
+
+			sections, err := deps.Wolt.Sections(cmd.Context(), location)
+			if err != nil {
+				return emitUpstreamError(cmd, format, name, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			coverage := travelCoverage(sections)
+			warnings := []string{}
+			if asInt(coverage["venue_count"]) == 0 {
+				if !force {
+					return emitError(cmd, format, name, flags.Locale, flags.Output, "WOLT_NOT_COVERED", fmt.Sprintf("Wolt lists no venues near %q; pass --force to switch anyway", address))
+				}
+				warnings = append(warnings, "Wolt lists no venues near the destination")
+			}
+
+			source := domain.Profile{}
+			if deps.Profiles != nil {
+				if profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile); err == nil {
+					source = profile
+				}
+			}
+			cfg, loadErr := deps.Config.Load(cmd.Context())
+			if loadErr != nil {
+				cfg = domain.Config{}
+			}
+			travelProfile, previousDefault := upsertTravelProfile(&cfg, name, source)
+			travelProfile.Location = location
+			travelProfile.Travel = &domain.TravelState{
+				Address:         address,
+				Country:         asString(coverage["country"]),
+				Currency:        asString(coverage["currency"]),
+				PreviousDefault: previousDefault,
+				SetAt:           deps.now().UTC().Format(time.RFC3339),
+			}
+			if err := deps.Config.Save(cmd.Context(), cfg); err != nil {
+				return emitError(cmd, format, name, flags.Locale, flags.Output, "WOLT_PROFILE_ERROR", err.Error())
+			}
+
+			data := map[string]any{
+				"profile":          travelProfile.Name,
+				"address":          address,
+				"location":         map[string]any{"lat": location.Lat, "lon": location.Lon},
+				"country":          emptyToNil(asString(coverage["country"])),
+				"currency":         emptyToNil(asString(coverage["currency"])),
+				"venue_count":      coverage["venue_count"],
+				"previous_default": emptyToNil(previousDefault),
+				"authenticated":    authContextFromProfile(*travelProfile).HasCredentials(),
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildTravelTable("Travel profile", data), flags.Output)
+			}
+			env := output.BuildEnvelope(travelProfile.Name, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", defaultTravelProfileName, "Name of the travel profile.")
+	cmd.Flags().BoolVar(&force, "force", false, "Switch even when Wolt lists no venues at the destination.")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func newTravelClearCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the travel profile and restore the previous default profile.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			data := map[string]any{
+				"cleared":         false,
+				"removed_profile": nil,
+				"default_profile": nil,
+			}
+			warnings := []string{}
+			cfg, loadErr := deps.Config.Load(cmd.Context())
+			removed, restored := "", ""
+			if loadErr == nil {
+				removed, restored = removeTravelProfile(&cfg)
+			}
+			if removed == "" {
+				warnings = append(warnings, "no travel profile is set")
+			} else {
+				if err := deps.Config.Save(cmd.Context(), cfg); err != nil {
+					return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_PROFILE_ERROR", err.Error())
+				}
+				profileName = fallbackString(restored, profileName)
+				data["cleared"] = true
+				data["removed_profile"] = removed
+				data["default_profile"] = emptyToNil(restored)
+			}
+			if format == output.FormatTable {
+				return writeTable(cmd, buildTravelTable("Travel cleared", data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// travelCoverage counts the venues the front page lists at a destination and
+// takes country and currency from the first one.
+func travelCoverage(sections []domain.Section) map[string]any {
+	seen := map[string]struct{}{}
+	country, currency := "", ""
+	for _, section := range sections {
+		for _, item := range section.Items {
+			if item.Venue == nil {
+				continue
+			}
+			id := fallbackString(domain.NormalizeID(item.Venue.ID), item.Venue.Slug)
+			if id == "" {
+				continue
+			}
+			seen[id] = struct{}{}
+			country = fallbackString(country, strings.TrimSpace(item.Venue.Country))
+			currency = fallbackString(currency, strings.TrimSpace(item.Venue.Currency))
+		}
+	}
+	return map[string]any{"venue_count": len(seen), "country": country, "currency": currency}
+}
+
+// upsertTravelProfile makes the named travel profile the only default,
+// copying credentials and locale from source. It returns the profile and the
+// default it replaces, keeping the original one when travel set runs twice.
+func upsertTravelProfile(cfg *domain.Config, name string, source domain.Profile) (*domain.Profile, string) {
+	previousDefault := ""
+	index := -1
+	for i, profile := range cfg.Profiles {
+		if strings.EqualFold(strings.TrimSpace(profile.Name), name) {
+			index = i
+			if profile.Travel != nil {
+				previousDefault = profile.Travel.PreviousDefault
+			}
+			continue
+		}
+		if profile.IsDefault && previousDefault == "" {
+			previousDefault = profile.Name
+		}
+	}
+	if index < 0 {
+		cfg.Profiles = append(cfg.Profiles, domain.Profile{Name: name})
+		index = len(cfg.Profiles) - 1
+	}
+	for i := range cfg.Profiles {
+		cfg.Profiles[i].IsDefault = i == index
+	}
+	travel := &cfg.Profiles[index]
+	if !strings.EqualFold(strings.TrimSpace(source.Name), name) {
+		travel.WToken = source.WToken
+		travel.WRefreshToken = source.WRefreshToken
+		travel.Cookies = source.Cookies
+		travel.Locale = source.Locale
+	}
+	return travel, previousDefault
+}
+
+// removeTravelProfile drops the travel profile and makes its previous default
+// the default again, carrying back tokens rotated while travelling.
+func removeTravelProfile(cfg *domain.Config) (string, string) {
+	index := -1
+	for i, profile := range cfg.Profiles {
+		if profile.Travel != nil {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return "", ""
+	}
+	travel := cfg.Profiles[index]
+	cfg.Profiles = append(cfg.Profiles[:index], cfg.Profiles[index+1:]...)
+
+	restore := -1
+	for i, profile := range cfg.Profiles {
+		if strings.EqualFold(strings.TrimSpace(profile.Name), strings.TrimSpace(travel.Travel.PreviousDefault)) {
+			restore = i
+			break
+		}
+	}
+	if restore < 0 && len(cfg.Profiles) > 0 {
+		restore = 0
+	}
+	if restore < 0 {
+		return travel.Name, ""
+	}
+	for i := range cfg.Profiles {
+		cfg.Profiles[i].IsDefault = i == restore
+	}
+	previous := &cfg.Profiles[restore]
+	if strings.TrimSpace(travel.WToken) != "" && strings.TrimSpace(travel.WRefreshToken) != "" {
+		previous.WToken = travel.WToken
+		previous.WRefreshToken = travel.WRefreshToken
+	}
+	return travel.Name, previous.Name
+}
+
+func buildTravelTable(title string, data map[string]any) string {
+	headers := []string{"Field", "Value"}
+	rows := [][]string{}
+	if _, ok := data["cleared"]; ok {
+		rows = append(rows,
+			[]string{"Cleared", boolToYesNo(asBool(data["cleared"]))},
+			[]string{"Removed profile", fallbackString(asString(data["removed_profile"]), "-")},
+			[]string{"Default profile", fallbackString(asString(data["default_profile"]), "-")},
+		)
+		return output.RenderTable(title, headers, rows)
+	}
+	location := asMap(data["location"])
+	rows = append(rows,
+		[]string{"Profile", asString(data["profile"])},
+		[]string{"Address", asString(data["address"])},
+		[]string{"Location", fmt.Sprintf("%s, %s", asString(location["lat"]), asString(location["lon"]))},
+		[]string{"Country", fallbackString(asString(data["country"]), "-")},
+		[]string{"Venues nearby", asString(data["venue_count"])},
+		[]string{"Previous default", fallbackString(asString(data["previous_default"]), "-")},
+		[]string{"Authenticated", boolToYesNo(asBool(data["authenticated"]))},
+	)
+	return output.RenderTable(title, headers, rows)
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newVenueCarouselCommand(deps Dependencies, carousel observability.VenueCarousel) *cobra.Command {
+	var flags globalFlags
+	var includeOptions bool
+	var limit int
+	var limitSet bool
+
+	use, short := "popular <slug>", "Show the venue page's popular items carousel."
+	long := short + "\n\nRows use the same shape as `wolt venue menu`, in carousel order."
+	if carousel == observability.VenueCarouselRecommended {
+		use, short = "recommendations <slug>", "Show the venue page's \"recommended for you\" carousel."
+		long = short + "\n\nRows use the same shape as `wolt venue menu`, in carousel order. Recommendations are " +
+			"personalised, so they need profile auth to match what the Wolt app shows."
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug := args[0]
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			slug, err = resolveVenueSlugArgument(cmd, deps, flags, format, slug)
+			if err != nil {
+				return err
+			}
+			if limitSet && limit < 0 {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--limit must be >= 0")
+			}
+			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			if err != nil {
+				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+
+			venueID := strings.TrimSpace(slug)
+			payloads := []map[string]any{}
+			warnings := []string{}
+			if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
+				payloads = append(payloads, payload)
+				if resolvedID := strings.TrimSpace(venueIDFromPayload(payload)); resolvedID != "" {
+					venueID = resolvedID
+				}
+			} else {
+				warnings = append(warnings, "venue static page endpoint unavailable")
+			}
+			dynamicPayload, err := loadVenueDynamicPayload(cmd, deps, flags, format, profile, auth, slug)
+			if err != nil {
+				return err
+			}
+			if dynamicPayload != nil {
+				payloads = append(payloads, dynamicPayload)
+			} else {
+				warnings = append(warnings, "venue dynamic page endpoint unavailable")
+			}
+			contentPayloads, contentWarnings := loadVenueContentPayloads(cmd.Context(), deps, slug, auth, 1)
+			payloads = append(payloads, contentPayloads...)
+			warnings = append(warnings, contentWarnings...)
+			if payload, err := deps.Wolt.AssortmentByVenueSlug(cmd.Context(), slug); err == nil {
+				payloads = append(payloads, payload)
+			}
+
+			var limitPtr *int
+			if limitSet && limit > 0 {
+				limitPtr = &limit
+			}
+			data, carouselWarnings := observability.BuildVenueCarousel(venueID, payloads, carousel, includeOptions, limitPtr)
+			warnings = append(warnings, carouselWarnings...)
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildVenueCarouselTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile.Name, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeOptions, "include-options", false, "Include option group IDs")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
+	addGlobalFlags(cmd, &flags)
+	cmd.PreRun = func(cmd *cobra.Command, args []string) {
+		limitSet = cmd.Flags().Changed("limit")
+	}
+	return cmd
+}
+
+// buildVenueCarouselTable reuses the menu table and retitles it after the carousel.
+func buildVenueCarouselTable(data map[string]any) string {
+	tables := output.ParseTables(buildVenueMenuTable(data))
+	title := asString(data["title"])
+	if title == "" {
+		title = asString(data["carousel"])
+	}
+	tables[0].Title = "Venue " + asString(data["venue_id"]) + ": " + title
+	if asBool(data["wolt_plus"]) {
+		tables[0].Title += " (Wolt+)"
+	}
+	return output.RenderTables(tables)
+}
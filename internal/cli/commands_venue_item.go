@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
 	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/money"
 	"github.com/mekedron/wolt-cli/internal/service/observability"
 	"github.com/mekedron/wolt-cli/internal/service/output"
 	"github.com/spf13/cobra"
@@ -22,8 +24,14 @@ func newVenueCommand(deps Dependencies) *cobra.Command {
 	venue.AddCommand(newVenueShowCommand(deps))
 	venue.AddCommand(newVenueCategoriesCommand(deps))
 	venue.AddCommand(newVenueSearchCommand(deps))
+	venue.AddCommand(newVenueShopCommand(deps))
 	venue.AddCommand(newVenueMenuCommand(deps))
 	venue.AddCommand(newVenueHoursCommand(deps))
+	venue.AddCommand(newVenueSlotsCommand(deps))
+	venue.AddCommand(newVenueResolveCommand(deps))
+	venue.AddCommand(newVenueKnownCommand(deps))
+	venue.AddCommand(newVenueCarouselCommand(deps, observability.VenueCarouselPopular))
+	venue.AddCommand(newVenueCarouselCommand(deps, observability.VenueCarouselRecommended))
 	return venue
 }
 
@@ -34,23 +42,48 @@ func newItemCommand(deps Dependencies) *cobra.Command {
 	}
 	item.AddCommand(newItemShowCommand(deps))
 	item.AddCommand(newItemOptionsCommand(deps))
+	item.AddCommand(newItemReorderCommand(deps))
 	return item
 }
 
 func newVenueShowCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 	var include string
+	var noFallback bool
+	var slugValues []string
+	var slugsFile string
+	var strict bool
 
 	cmd := &cobra.Command{
-		Use:   "show <slug>",
+		Use:   "show [slug]",
 		Short: "Show venue details by slug.",
-		Args:  cobra.ExactArgs(1),
+		Long: "Show venue details by slug.\n\n" +
+			"Repeat --slug or pass --slugs-file (one slug per line, - for stdin) to fetch many venues " +
+			"concurrently in one run; the output is then a list of venue details.",
+		Example: "wolt venue show burger-place\n" +
+			"wolt venue show --slug burger-place --slug pizza-place --format json\n" +
+			"wolt venue show --slugs-file venues.txt --format json",
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			slug := args[0]
 			format, err := parseOutputFormat(flags.Format)
 			if err != nil {
 				return err
 			}
+			bulk := len(slugValues) > 0 || strings.TrimSpace(slugsFile) != ""
+			if bulk == (len(args) == 1) {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "pass one venue slug argument, or --slug/--slugs-file for several venues")
+			}
+			if bulk {
+				slugs, err := readVenueShowSlugs(cmd, slugValues, slugsFile)
+				if err != nil {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+				}
+				return runVenueShowBulk(cmd, deps, flags, format, slugs, include, noFallback, strict)
+			}
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
 			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
 			location, profile, err := resolveProfileLocation(
 				cmd.Context(),
@@ -66,46 +99,87 @@ func newVenueShowCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			item, venueID, staticPayload, fallbackWarnings, err := resolveVenueBySlug(cmd.Context(), deps, location, slug)
-			if err != nil {
-				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
-			}
-			if item == nil || strings.TrimSpace(venueID) == "" {
+			result, err := loadVenueDetail(cmd.Context(), deps, location, slug, include)
+			if errors.Is(err, errVenueNotInCatalog) {
 				return fmt.Errorf("venue slug %q was not found in profile %q catalog", slug, profile)
 			}
-			restaurant, err := deps.Wolt.RestaurantByID(cmd.Context(), venueID)
 			if err != nil {
-				if isRecoverableRestaurantError(err) {
-					data, warnings := buildVenueDetailFallback(slug, venueID, item, staticPayload, splitCSV(include))
-					warnings = append(warnings, fallbackWarnings...)
-					if format == output.FormatTable {
-						return writeTable(cmd, buildVenueDetailTable(data), flags.Output)
-					}
-					env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
-					return writeMachinePayload(cmd, env, format, flags.Output)
-				}
 				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
 			}
-
-			data, warnings, err := observability.BuildVenueDetail(item, restaurant, splitCSV(include))
-			if err != nil {
+			if err := refuseFallback(cmd, format, profile, flags.Locale, flags.Output, noFallback, result.fallback); err != nil {
 				return err
 			}
-			warnings = append(warnings, fallbackWarnings...)
+			annotateVenueNotes(cmd.Context(), deps, flags.Profile, result.data)
 
 			if format == output.FormatTable {
-				return writeTable(cmd, buildVenueDetailTable(data), flags.Output)
+				return writeTable(cmd, buildVenueDetailTable(result.data), flags.Output)
 			}
-			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			env := output.BuildEnvelope(profile, flags.Locale, result.data, result.warnings, nil)
 			return writeMachinePayload(cmd, env, format, flags.Output)
 		},
 	}
 
 	cmd.Flags().StringVar(&include, "include", "", "Include sections: hours,tags,rating,fees")
+	cmd.Flags().StringArrayVar(&slugValues, "slug", nil, "Venue slug, URL, or id to show (repeatable; replaces the slug argument)")
+	cmd.Flags().StringVar(&slugsFile, "slugs-file", "", "File with one venue slug per line (- for stdin); # starts a comment")
+	addNoFallbackFlag(cmd, &noFallback)
+	addStrictFlag(cmd, &strict)
 	addGlobalFlags(cmd, &flags)
 	return cmd
 }
 
+var errVenueNotInCatalog = errors.New("venue not found in catalog")
+
+// venueDetailResult is one venue show lookup. fallback holds the warnings of
+// any static-payload fallback it took, for --no-fallback.
+type venueDetailResult struct {
+	data     map[string]any
+	warnings []string
+	fallback []string
+}
+
+// loadVenueDetail builds venue show data from the catalog and restaurant
+// endpoints, degrading to the static venue page when either is unavailable.
+func loadVenueDetail(
+	ctx context.Context,
+	deps Dependencies,
+	location domain.Location,
+	slug string,
+	include string,
+) (venueDetailResult, error) {
+	item, venueID, staticPayload, fallbackWarnings, err := resolveVenueBySlug(ctx, deps, location, slug)
+	if err != nil {
+		return venueDetailResult{}, err
+	}
+	if item == nil || strings.TrimSpace(venueID) == "" {
+		return venueDetailResult{}, errVenueNotInCatalog
+	}
+	result := venueDetailResult{fallback: fallbackWarnings}
+	restaurant, err := deps.Wolt.RestaurantByID(ctx, venueID)
+	switch {
+	case err != nil && isRecoverableRestaurantError(err):
+		recordFallback(ctx, "restaurant_static")
+		data, warnings := buildVenueDetailFallback(slug, venueID, item, staticPayload, splitCSV(include))
+		result.fallback = append(append([]string{}, warnings...), fallbackWarnings...)
+		result.data = data
+		result.warnings = append(warnings, fallbackWarnings...)
+	case err != nil:
+		return venueDetailResult{}, err
+	default:
+		data, warnings, err := observability.BuildVenueDetail(item, restaurant, splitCSV(include))
+		if err != nil {
+			return venueDetailResult{}, err
+		}
+		rememberVenues(deps, knownVenue{VenueID: venueID, Slug: slug, Name: item.Title, City: restaurant.City})
+		result.data = data
+		result.warnings = append(warnings, fallbackWarnings...)
+	}
+	if len(fallbackWarnings) > 0 {
+		attributeCatalogToStaticPage(result.data)
+	}
+	return result, nil
+}
+
 func newVenueCategoriesCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 
@@ -119,20 +193,16 @@ func newVenueCategoriesCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			slug, err = resolveVenueSlugArgument(cmd, deps, flags, format, slug)
+			if err != nil {
+				return err
+			}
 			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
 			if err != nil {
 				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
 			}
 
-			venueID := strings.TrimSpace(slug)
-			staticWarnings := []string{}
-			if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
-				if resolvedID := venueIDFromPayload(payload); strings.TrimSpace(resolvedID) != "" {
-					venueID = strings.TrimSpace(resolvedID)
-				}
-			} else {
-				staticWarnings = append(staticWarnings, "venue static page endpoint unavailable")
-			}
+			venueID, staticWarnings := venueIDForSlug(cmd.Context(), deps, slug)
 
 			assortmentPayload, err := deps.Wolt.AssortmentByVenueSlug(cmd.Context(), slug)
 			if err != nil {
@@ -217,16 +287,18 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 	var sortValue string
 	var limit int
 	var limitSet bool
+	var noLimit bool
 	var offset int
 	var offsetSet bool
 	var page int
 	var pageSet bool
-	var minPrice int
-	var minPriceSet bool
-	var maxPrice int
-	var maxPriceSet bool
+	var minPrice priceFlag
+	var maxPrice priceFlag
 	var hideSoldOut bool
 	var discountsOnly bool
+	var maxRequests int
+	var strict bool
+	var pick rowPick
 
 	cmd := &cobra.Command{
 		Use:   "menu <slug>",
@@ -241,6 +313,13 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			slug, err = resolveVenueSlugArgument(cmd, deps, flags, format, slug)
+			if err != nil {
+				return err
+			}
+			if err := validateMaxRequests(maxRequests); err != nil {
+				return err
+			}
 			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
 			if err != nil {
 				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
@@ -258,14 +337,12 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if minPriceSet && minPrice < 0 {
-				return fmt.Errorf("--min-price must be >= 0")
-			}
-			if maxPriceSet && maxPrice < 0 {
-				return fmt.Errorf("--max-price must be >= 0")
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
 			}
-			if minPriceSet && maxPriceSet && minPrice > maxPrice {
-				return fmt.Errorf("--min-price cannot be greater than --max-price")
+			if err := validatePriceRange(minPrice, maxPrice); err != nil {
+				return err
 			}
 			venueID := strings.TrimSpace(slug)
 			payloads := []map[string]any{}
@@ -279,52 +356,12 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 			} else {
 				warnings = append(warnings, "venue static page endpoint unavailable")
 			}
-			var dynamicLocation *domain.Location
-			if trimmed := strings.TrimSpace(flags.Address); trimmed != "" {
-				if deps.Location == nil {
-					return emitError(
-						cmd,
-						format,
-						profile.Name,
-						flags.Locale,
-						flags.Output,
-						"WOLT_LOCATION_RESOLVE_ERROR",
-						"location resolver is not available",
-					)
-				}
-				location, locationErr := deps.Location.Get(cmd.Context(), trimmed)
-				if locationErr != nil {
-					return emitError(
-						cmd,
-						format,
-						profile.Name,
-						flags.Locale,
-						flags.Output,
-						"WOLT_LOCATION_RESOLVE_ERROR",
-						locationErr.Error(),
-					)
-				}
-				dynamicLocation = &location
-			} else if location, locationErr := resolveAccountLocation(cmd.Context(), deps, profile, &auth); locationErr == nil {
-				dynamicLocation = &location
-			}
-			dynamicOptions := woltgateway.VenuePageDynamicOptions{
-				Location: dynamicLocation,
-				Auth:     auth,
+			dynamicPayload, err := loadVenueDynamicPayload(cmd, deps, flags, format, profile, auth, slug)
+			if err != nil {
+				return err
 			}
-			if payload, err := deps.Wolt.VenuePageDynamic(
-				cmd.Context(),
-				slug,
-				dynamicOptions,
-			); err == nil {
-				payloads = append(payloads, payload)
-			} else if isUnauthorized(err) && dynamicOptions.Auth.HasCredentials() {
-				dynamicOptions.Auth = woltgateway.AuthContext{}
-				if payload, retryErr := deps.Wolt.VenuePageDynamic(cmd.Context(), slug, dynamicOptions); retryErr == nil {
-					payloads = append(payloads, payload)
-				} else {
-					warnings = append(warnings, "venue dynamic page endpoint unavailable")
-				}
+			if dynamicPayload != nil {
+				payloads = append(payloads, dynamicPayload)
 			} else {
 				warnings = append(warnings, "venue dynamic page endpoint unavailable")
 			}
@@ -369,6 +406,20 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 				)
 			case needsVenueContentFallback(assortmentPayload, venueID):
 				if isAssortmentPartial(assortmentPayload) && fullCatalog {
+					if err := checkRequestBudget(
+						cmd,
+						format,
+						profile.Name,
+						flags.Locale,
+						flags.Output,
+						maxRequests,
+						estimateFullCatalogRequests(assortmentPayload),
+						"full catalog crawl",
+						fmt.Sprintf("--category <slug> (list with \"wolt venue categories %s\")", slug),
+						fmt.Sprintf("\"wolt venue search %s --query <text>\"", slug),
+					); err != nil {
+						return err
+					}
 					warnings = append(warnings, "full catalog mode enabled for partial assortment; loading all categories (this may be slow)")
 					categoryPayloads, categoryWarnings := loadAssortmentCategoryPayloads(
 						cmd.Context(),
@@ -383,6 +434,9 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 					warnings = append(warnings, categoryWarnings...)
 				}
 				venueContentPayloads, fallbackWarnings := loadVenueContentPayloads(cmd.Context(), deps, slug, auth, 2)
+				if len(venueContentPayloads) > 0 {
+					recordFallback(cmd.Context(), "assortment_venue_content")
+				}
 				payloads = append(payloads, venueContentPayloads...)
 				warnings = append(warnings, fallbackWarnings...)
 			}
@@ -391,22 +445,34 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 			data["items"] = applyItemRowFilters(
 				asSlice(data["items"]),
 				itemRowFilters{
-					MinPriceSet:   minPriceSet,
 					MinPrice:      minPrice,
-					MaxPriceSet:   maxPriceSet,
 					MaxPrice:      maxPrice,
 					HideSoldOut:   hideSoldOut,
 					DiscountsOnly: discountsOnly,
 				},
 			)
+			annotatePackSizes(asSlice(data["items"]))
 			sortItemRows(asSlice(data["items"]), sortMode)
 			data["sort"] = string(sortMode)
-			paginateFlatRows(data, "items", limitPtr, resolvedOffset)
+			rememberMenuNames(deps, asSlice(data["items"]))
+			paginateFlatRows(data, "items", pageLimit, resolvedOffset)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
 			if pageSet {
 				data["page"] = page
 			}
 			warnings = append(warnings, menuWarnings...)
+			warnings, err = finishPartialRun(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+			if err != nil {
+				return err
+			}
 
+			if err := exportSQLiteRows(cmd, deps, sqliteItemExport, sqliteMenuRows(venueID, slug, asSlice(data["items"]))); err != nil {
+				return emitError(cmd, format, profile.Name, flags.Locale, flags.Output, "WOLT_SQLITE_EXPORT_ERROR", err.Error())
+			}
+			if pick.enabled() {
+				picker := itemRowPicker(func(map[string]any) string { return venueID })
+				return emitPickedRow(cmd, format, profile.Name, flags.Locale, flags.Output, pick, asSlice(data["items"]), picker)
+			}
 			if format == output.FormatTable {
 				return writeTable(cmd, buildVenueMenuTable(data), flags.Output)
 			}
@@ -417,22 +483,24 @@ func newVenueMenuCommand(deps Dependencies) *cobra.Command {
 
 	cmd.Flags().StringVar(&category, "category", "", "Category slug")
 	cmd.Flags().BoolVar(&fullCatalog, "full-catalog", false, "Force full cross-category crawl for partial assortments (can be slow).")
+	addMaxRequestsFlag(cmd, &maxRequests)
+	addStrictFlag(cmd, &strict)
 	cmd.Flags().BoolVar(&includeOptions, "include-options", false, "Include option group IDs")
-	cmd.Flags().StringVar(&sortValue, "sort", string(itemRowSortRecommended), "Sort strategy: recommended, price, name")
-	cmd.Flags().IntVar(&minPrice, "min-price", 0, "Minimum item base price in minor units")
-	cmd.Flags().IntVar(&maxPrice, "max-price", 0, "Maximum item base price in minor units")
+	cmd.Flags().StringVar(&sortValue, "sort", string(itemRowSortRecommended), "Sort strategy: recommended, price, name, unit-price")
+	addItemPriceFlags(cmd, &minPrice, &maxPrice)
 	cmd.Flags().BoolVar(&hideSoldOut, "hide-sold-out", false, "Exclude sold-out items")
 	cmd.Flags().BoolVar(&discountsOnly, "discounts-only", false, "Only include items with discounts")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned rows")
 	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
+	addNoLimitFlag(cmd, &noLimit)
+	addRowPickFlags(cmd, &pick, "venue ID and item ID")
 	addGlobalFlags(cmd, &flags)
+	enableSQLiteExport(cmd)
 	cmd.PreRun = func(cmd *cobra.Command, args []string) {
 		limitSet = cmd.Flags().Changed("limit")
 		offsetSet = cmd.Flags().Changed("offset")
 		pageSet = cmd.Flags().Changed("page")
-		minPriceSet = cmd.Flags().Changed("min-price")
-		maxPriceSet = cmd.Flags().Changed("max-price")
 	}
 	return cmd
 }
@@ -445,16 +513,16 @@ func newVenueSearchCommand(deps Dependencies) *cobra.Command {
 	var sortValue string
 	var limit int
 	var limitSet bool
+	var noLimit bool
 	var offset int
 	var offsetSet bool
 	var page int
 	var pageSet bool
-	var minPrice int
-	var minPriceSet bool
-	var maxPrice int
-	var maxPriceSet bool
+	var minPrice priceFlag
+	var maxPrice priceFlag
 	var hideSoldOut bool
 	var discountsOnly bool
+	var pick rowPick
 
 	cmd := &cobra.Command{
 		Use:   "search <slug>",
@@ -471,6 +539,10 @@ func newVenueSearchCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			slug, err = resolveVenueSlugArgument(cmd, deps, flags, format, slug)
+			if err != nil {
+				return err
+			}
 			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
 			if err != nil {
 				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
@@ -488,14 +560,12 @@ func newVenueSearchCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if minPriceSet && minPrice < 0 {
-				return fmt.Errorf("--min-price must be >= 0")
-			}
-			if maxPriceSet && maxPrice < 0 {
-				return fmt.Errorf("--max-price must be >= 0")
+			pageLimit, capped, err := outputLimit(limitPtr, noLimit)
+			if err != nil {
+				return err
 			}
-			if minPriceSet && maxPriceSet && minPrice > maxPrice {
-				return fmt.Errorf("--min-price cannot be greater than --max-price")
+			if err := validatePriceRange(minPrice, maxPrice); err != nil {
+				return err
 			}
 
 			venueID := strings.TrimSpace(slug)
@@ -510,48 +580,96 @@ func newVenueSearchCommand(deps Dependencies) *cobra.Command {
 				warnings = append(warnings, "venue static page endpoint unavailable")
 			}
 
-			searchPayload, err := requestAssortmentItemsSearchPayload(
-				cmd.Context(),
-				deps,
-				slug,
-				strings.TrimSpace(query),
-				resolveAssortmentLanguage(flags.Locale),
-				auth,
-			)
+			parsedQuery, advanced, err := parseSearchQuery(query)
 			if err != nil {
-				return emitUpstreamError(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, err)
+				return err
+			}
+			upstreamQueries := []string{strings.TrimSpace(query)}
+			if advanced {
+				upstreamQueries = parsedQuery.upstreamQueries()
+			}
+			var data map[string]any
+			var searchWarnings []string
+			seenItems := map[string]bool{}
+			for _, upstreamQuery := range upstreamQueries {
+				searchPayload, err := requestAssortmentItemsSearchPayload(
+					cmd.Context(),
+					deps,
+					slug,
+					upstreamQuery,
+					resolveAssortmentLanguage(flags.Locale),
+					auth,
+				)
+				if err != nil {
+					return emitUpstreamError(cmd, format, profile.Name, flags.Locale, flags.Output, flags.Verbose, err)
+				}
+				fallbackCurrency := resolveVenueSearchFallbackCurrency(staticPayload, searchPayload)
+				queryData, queryWarnings := buildVenueItemSearchData(
+					venueID,
+					slug,
+					query,
+					category,
+					searchPayload,
+					fallbackCurrency,
+					includeOptions,
+					nil,
+				)
+				// OR branches can return the same item; keep its first row.
+				rows := []any{}
+				for _, row := range asSlice(queryData["items"]) {
+					itemID := asString(asMap(row)["item_id"])
+					if advanced && itemID != "" && seenItems[itemID] {
+						continue
+					}
+					seenItems[itemID] = true
+					rows = append(rows, row)
+				}
+				if data == nil {
+					data, searchWarnings = queryData, queryWarnings
+					data["items"] = rows
+				} else {
+					data["items"] = append(asSlice(data["items"]), rows...)
+				}
+			}
+			data["parsed_query"] = nil
+			if advanced {
+				matched := []any{}
+				for _, row := range asSlice(data["items"]) {
+					if parsedQuery.matches(asString(asMap(row)["name"])) {
+						matched = append(matched, row)
+					}
+				}
+				data["items"] = matched
+				data["parsed_query"] = parsedQuery.data()
+				searchWarnings = nil
+				if len(matched) == 0 {
+					searchWarnings = append(searchWarnings, "no items matched this venue search query")
+				}
 			}
-
-			fallbackCurrency := resolveVenueSearchFallbackCurrency(staticPayload, searchPayload)
-			data, searchWarnings := buildVenueItemSearchData(
-				venueID,
-				slug,
-				query,
-				category,
-				searchPayload,
-				fallbackCurrency,
-				includeOptions,
-				nil,
-			)
 			data["items"] = applyItemRowFilters(
 				asSlice(data["items"]),
 				itemRowFilters{
-					MinPriceSet:   minPriceSet,
 					MinPrice:      minPrice,
-					MaxPriceSet:   maxPriceSet,
 					MaxPrice:      maxPrice,
 					HideSoldOut:   hideSoldOut,
 					DiscountsOnly: discountsOnly,
 				},
 			)
+			annotatePackSizes(asSlice(data["items"]))
 			sortItemRows(asSlice(data["items"]), sortMode)
 			data["sort"] = string(sortMode)
-			paginateFlatRows(data, "items", limitPtr, resolvedOffset)
+			rememberMenuNames(deps, asSlice(data["items"]))
+			paginateFlatRows(data, "items", pageLimit, resolvedOffset)
+			warnings = append(warnings, reportDefaultLimit(cmd, format, data, capped)...)
 			if pageSet {
 				data["page"] = page
 			}
 			warnings = append(warnings, searchWarnings...)
 
+			if pick.enabled() {
+				picker := itemRowPicker(func(map[string]any) string { return asString(data["venue_id"]) })
+				return emitPickedRow(cmd, format, profile.Name, flags.Locale, flags.Output, pick, asSlice(data["items"]), picker)
+			}
 			if format == output.FormatTable {
 				return writeTable(cmd, buildVenueItemSearchTable(data), flags.Output)
 			}
@@ -563,14 +681,15 @@ func newVenueSearchCommand(deps Dependencies) *cobra.Command {
 	cmd.Flags().StringVar(&query, "query", "", "Search query")
 	cmd.Flags().StringVar(&category, "category", "", "Category slug filter")
 	cmd.Flags().BoolVar(&includeOptions, "include-options", false, "Include option-group IDs")
-	cmd.Flags().StringVar(&sortValue, "sort", string(itemRowSortRecommended), "Sort strategy: recommended, price, name")
-	cmd.Flags().IntVar(&minPrice, "min-price", 0, "Minimum item base price in minor units")
-	cmd.Flags().IntVar(&maxPrice, "max-price", 0, "Maximum item base price in minor units")
+	cmd.Flags().StringVar(&sortValue, "sort", string(itemRowSortRecommended), "Sort strategy: recommended, price, name, unit-price")
+	addItemPriceFlags(cmd, &minPrice, &maxPrice)
 	cmd.Flags().BoolVar(&hideSoldOut, "hide-sold-out", false, "Exclude sold-out items")
 	cmd.Flags().BoolVar(&discountsOnly, "discounts-only", false, "Only include items with discounts")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit returned rows")
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset returned rows")
 	cmd.Flags().IntVar(&page, "page", 0, "1-based page number (requires --limit; cannot be combined with --offset)")
+	addNoLimitFlag(cmd, &noLimit)
+	addRowPickFlags(cmd, &pick, "venue ID and item ID")
 	if err := cmd.MarkFlagRequired("query"); err != nil {
 		panic(err)
 	}
@@ -579,8 +698,6 @@ func newVenueSearchCommand(deps Dependencies) *cobra.Command {
 		limitSet = cmd.Flags().Changed("limit")
 		offsetSet = cmd.Flags().Changed("offset")
 		pageSet = cmd.Flags().Changed("page")
-		minPriceSet = cmd.Flags().Changed("min-price")
-		maxPriceSet = cmd.Flags().Changed("max-price")
 	}
 	return cmd
 }
@@ -625,17 +742,36 @@ func resolveAssortmentLanguage(locale string) string {
 func newVenueHoursCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 	var timezone string
+	var nowValue string
+	var noFallback bool
 
 	cmd := &cobra.Command{
 		Use:   "hours <slug>",
 		Short: "Show venue opening hours by slug.",
-		Args:  cobra.ExactArgs(1),
+		Long: "Show venue opening hours by slug.\n\n" +
+			"open_now compares the current time, or --now, with the opening hours in the venue timezone; " +
+			"now in the output is the compared timestamp.",
+		Example: "wolt venue hours burger-place\n" +
+			"wolt venue hours burger-place --now 2026-02-16T19:00 --format json",
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			slug := args[0]
 			format, err := parseOutputFormat(flags.Format)
 			if err != nil {
 				return err
 			}
+			if _, err := parseVenueNow(nowValue, time.UTC, deps.now()); err != nil {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			if strings.TrimSpace(timezone) != "" {
+				if _, err := time.LoadLocation(strings.TrimSpace(timezone)); err != nil {
+					return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("unknown timezone %q", timezone))
+				}
+			}
+			slug, err = resolveVenueSlugArgument(cmd, deps, flags, format, slug)
+			if err != nil {
+				return err
+			}
 			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
 			location, profile, err := resolveProfileLocation(
 				cmd.Context(),
@@ -658,10 +794,17 @@ func newVenueHoursCommand(deps Dependencies) *cobra.Command {
 			if item == nil || strings.TrimSpace(venueID) == "" {
 				return fmt.Errorf("venue slug %q was not found in profile %q catalog", slug, profile)
 			}
+			if err := refuseFallback(cmd, format, profile, flags.Locale, flags.Output, noFallback, fallbackWarnings); err != nil {
+				return err
+			}
 			restaurant, err := deps.Wolt.RestaurantByID(cmd.Context(), venueID)
 			if err != nil {
 				if isRecoverableRestaurantError(err) {
-					data, warnings := buildVenueHoursFallback(venueID, timezone, staticPayload)
+					recordFallback(cmd.Context(), "restaurant_static")
+					data, warnings := buildVenueHoursFallback(venueID, timezone, nowValue, deps.now(), staticPayload)
+					if err := refuseFallback(cmd, format, profile, flags.Locale, flags.Output, noFallback, warnings); err != nil {
+						return err
+					}
 					warnings = append(warnings, fallbackWarnings...)
 					if format == output.FormatTable {
 						return writeTable(cmd, buildVenueHoursTable(data), flags.Output)
@@ -672,7 +815,15 @@ func newVenueHoursCommand(deps Dependencies) *cobra.Command {
 				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
 			}
 
-			data := observability.BuildVenueHours(restaurant, timezone)
+			loc, _, err := observability.VenueLocation(restaurant, timezone)
+			if err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_UPSTREAM_ERROR", err.Error())
+			}
+			now, _ := parseVenueNow(nowValue, loc, deps.now())
+			data, err := observability.BuildVenueHours(restaurant, timezone, now)
+			if err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_UPSTREAM_ERROR", err.Error())
+			}
 			if format == output.FormatTable {
 				return writeTable(cmd, buildVenueHoursTable(data), flags.Output)
 			}
@@ -682,6 +833,8 @@ func newVenueHoursCommand(deps Dependencies) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&timezone, "timezone", "", "Timezone override")
+	cmd.Flags().StringVar(&nowValue, "now", "", "Compare opening hours against this venue-local time instead of now (YYYY-MM-DDTHH:MM)")
+	addNoFallbackFlag(cmd, &noFallback)
 	addGlobalFlags(cmd, &flags)
 	return cmd
 }
@@ -705,6 +858,7 @@ func resolveVenueBySlug(
 		}
 	}
 	if itemErr != nil {
+		recordFallback(ctx, "venue_catalog_static")
 		warnings = append(warnings, "venue catalog lookup failed; using static venue payload fallback")
 	}
 
@@ -834,6 +988,17 @@ func buildVenueDetailFallback(
 		staticPayload["currency"],
 	)))
 	rating := itemRating(item)
+	sources := map[string]string{
+		"name":             observability.VenueSourceStaticPage,
+		"address":          observability.VenueSourceStaticPage,
+		"currency":         observability.VenueSourceStaticPage,
+		"rating":           observability.VenueSourceCatalog,
+		"delivery_methods": observability.VenueSourceStaticPage,
+		"order_minimum":    observability.VenueSourceStaticPage,
+	}
+	if itemTitle(item) != "" {
+		sources["name"] = observability.VenueSourceCatalog
+	}
 
 	data := map[string]any{
 		"venue_id":         venueID,
@@ -851,8 +1016,10 @@ func buildVenueDetailFallback(
 
 	if _, ok := include["hours"]; ok {
 		data["opening_windows"] = []any{}
+		sources["opening_windows"] = observability.VenueSourceStaticPage
 	}
 	if _, ok := include["tags"]; ok {
+		sources["tags"] = observability.VenueSourceStaticPage
 		tags := asSlice(venuePayload["tags"])
 		if len(tags) == 0 {
 			tags = asSlice(staticPayload["tags"])
@@ -867,14 +1034,18 @@ func buildVenueDetailFallback(
 		}
 		data["tags"] = resolvedTags
 	}
-	if _, ok := include["rating"]; ok && rating != nil {
-		data["rating_details"] = map[string]any{
-			"score":  rating,
-			"text":   nil,
-			"volume": nil,
+	if _, ok := include["rating"]; ok {
+		if rating != nil {
+			data["rating_details"] = map[string]any{
+				"score":  rating,
+				"text":   nil,
+				"volume": nil,
+			}
 		}
+		sources["rating_details"] = observability.VenueSourceCatalog
 	}
 	if _, ok := include["fees"]; ok {
+		sources["delivery_fee"] = observability.VenueSourceCatalog
 		amount := itemDeliveryFee(item)
 		formatted := any(nil)
 		if amount != nil {
@@ -886,6 +1057,8 @@ func buildVenueDetailFallback(
 		}
 	}
 
+	data["completeness"] = observability.VenueCompleteness(data, sources)
+
 	warnings := []string{
 		"restaurant detail endpoint unavailable; showing basic venue details from static payload",
 		"order minimum is unavailable in basic mode and returned as null",
@@ -893,14 +1066,31 @@ func buildVenueDetailFallback(
 	return data, warnings
 }
 
-func buildVenueHoursFallback(venueID string, timezone string, _ map[string]any) (map[string]any, []string) {
-	resolvedTimezone := strings.TrimSpace(timezone)
-	if resolvedTimezone == "" {
-		resolvedTimezone = "UTC"
+// attributeCatalogToStaticPage relabels catalog-sourced completeness sections
+// when the catalog lookup failed and the venue item was rebuilt from the
+// static page.
+func attributeCatalogToStaticPage(data map[string]any) {
+	sections := asMap(asMap(data["completeness"])["sections"])
+	for section, source := range sections {
+		if source == observability.VenueSourceCatalog {
+			sections[section] = observability.VenueSourceStaticPage
+		}
+	}
+}
+
+func buildVenueHoursFallback(venueID string, timezone string, nowValue string, current time.Time, _ map[string]any) (map[string]any, []string) {
+	loc, resolvedTimezone, err := observability.VenueLocation(nil, timezone)
+	if err != nil {
+		loc, resolvedTimezone = time.UTC, "UTC"
 	}
+	now, _ := parseVenueNow(nowValue, loc, current)
 	data := map[string]any{
 		"venue_id":         venueID,
 		"timezone":         resolvedTimezone,
+		"now":              now.Format(time.RFC3339),
+		"open_now":         nil,
+		"opens_at":         nil,
+		"closes_at":        nil,
 		"opening_windows":  []any{},
 		"delivery_windows": []any{},
 	}
@@ -948,24 +1138,45 @@ func amountValue(amount *int) any {
 func newItemShowCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 	var includeUpsell bool
+	var itemIDs []string
 
 	cmd := &cobra.Command{
-		Use:   "show <venue-slug> <item-id>",
+		Use:   "show <venue-slug> [item-id]",
 		Short: "Show item details by venue slug and item ID.",
-		Args:  cobra.ExactArgs(2),
+		Long: "Show item details by venue slug and item ID.\n\n" +
+			"Repeat --item-id instead of the item-id argument to look up several items of one venue; " +
+			"the venue assortment is downloaded once and the output is a list of item details.",
+		Example: "wolt item show burger-place item-1\n" +
+			"wolt item show burger-place --item-id item-1 --item-id item-2 --format json",
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			venueSlug := args[0]
-			itemID := args[1]
 			format, err := parseOutputFormat(flags.Format)
 			if err != nil {
 				return err
 			}
+			if (len(itemIDs) > 0) == (len(args) == 2) {
+				return emitError(cmd, format, defaultProfileName(flags.Profile), flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "pass one item-id argument, or --item-id for several items")
+			}
+			venueSlug, err = resolveVenueSlugArgument(cmd, deps, flags, format, venueSlug)
+			if err != nil {
+				return err
+			}
 
 			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
 			if err != nil {
 				return profileError(err, format, flags.Profile, flags.Locale, flags.Output, cmd)
 			}
 			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if len(itemIDs) > 0 {
+				data, warnings := buildItemShowBatch(newVenueItemLookup(cmd.Context(), deps, venueSlug, auth), venueSlug, dedupeStrings(itemIDs), includeUpsell)
+				if format == output.FormatTable {
+					return writeTable(cmd, buildItemShowBatchTable(data), flags.Output)
+				}
+				env := output.BuildEnvelope(profile.Name, flags.Locale, data, warnings, nil)
+				return writeMachinePayload(cmd, env, format, flags.Output)
+			}
+			itemID := args[1]
 
 			venueID, payload, warnings := resolveVenueItemPayloadBySlug(cmd.Context(), deps, venueSlug, itemID, auth)
 			if !payloadContainsItem(payload, venueID, itemID) {
@@ -988,10 +1199,68 @@ func newItemShowCommand(deps Dependencies) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&includeUpsell, "include-upsell", false, "Include upsell items")
+	cmd.Flags().StringArrayVar(&itemIDs, "item-id", nil, "Item ID to show (repeatable; replaces the item-id argument)")
 	addGlobalFlags(cmd, &flags)
 	return cmd
 }
 
+// buildItemShowBatch resolves every item through one venue lookup. Items that
+// cannot be found are listed under errors instead of failing the batch.
+func buildItemShowBatch(lookup *venueItemLookup, venueSlug string, itemIDs []string, includeUpsell bool) (map[string]any, []string) {
+	items := []any{}
+	failed := []any{}
+	warnings := []string{}
+	for _, itemID := range itemIDs {
+		venueID, payload, itemWarnings := lookup.item(itemID)
+		if !payloadContainsItem(payload, venueID, itemID) {
+			failed = append(failed, map[string]any{
+				"item_id": itemID,
+				"message": fmt.Sprintf("item %q was not found for venue slug %q", itemID, venueSlug),
+			})
+			continue
+		}
+		data, detailWarnings := observability.BuildItemDetail(itemID, venueID, payload, includeUpsell)
+		items = append(items, data)
+		warnings = append(warnings, itemWarnings...)
+		warnings = append(warnings, detailWarnings...)
+	}
+	if len(failed) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%d of %d items were not found; run \"wolt venue menu %s --include-options\" to list valid item IDs",
+			len(failed),
+			len(itemIDs),
+			venueSlug,
+		))
+	}
+	return map[string]any{
+		"venue_id":  lookup.venueID,
+		"items":     items,
+		"count":     len(items),
+		"requested": len(itemIDs),
+		"errors":    failed,
+	}, dedupeStrings(warnings)
+}
+
+func buildItemShowBatchTable(data map[string]any) string {
+	headers := []string{"Item ID", "Name", "Price", "Option groups"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["items"]) {
+		item := asMap(value)
+		rows = append(rows, []string{
+			asString(item["item_id"]),
+			fallbackString(asString(item["name"]), "-"),
+			fallbackString(asString(asMap(item["price"])["formatted_amount"]), "-"),
+			fmt.Sprintf("%d", len(asSlice(item["option_groups"]))),
+		})
+	}
+	for _, value := range asSlice(data["errors"]) {
+		failure := asMap(value)
+		rows = append(rows, []string{asString(failure["item_id"]), "error: not found", "-", "-"})
+	}
+	title := fmt.Sprintf("Items: %s (%d of %d)", asString(data["venue_id"]), asInt(data["count"]), asInt(data["requested"]))
+	return output.RenderTable(title, headers, rows)
+}
+
 func newItemOptionsCommand(deps Dependencies) *cobra.Command {
 	var flags globalFlags
 
@@ -1006,6 +1275,10 @@ func newItemOptionsCommand(deps Dependencies) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			venueSlug, err = resolveVenueSlugArgument(cmd, deps, flags, format, venueSlug)
+			if err != nil {
+				return err
+			}
 
 			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
 			if err != nil {
@@ -1049,7 +1322,15 @@ func buildVenueDetailTable(data map[string]any) string {
 		{"Delivery methods", stringsJoin(asSlice(data["delivery_methods"]), ", ")},
 		{"Order minimum", fallbackString(asString(asMap(data["order_minimum"])["formatted_amount"]), "-")},
 	}
-	optional := []string{"tags", "opening_windows", "rating_details", "delivery_fee"}
+	if completeness := asMap(data["completeness"]); completeness != nil {
+		score, _ := asFloat(completeness["score"])
+		value := fmt.Sprintf("%.0f%%", score*100)
+		if missing := stringsJoin(asSlice(completeness["missing"]), ", "); missing != "" {
+			value += " (missing: " + missing + ")"
+		}
+		rows = append(rows, []string{"Completeness", value})
+	}
+	optional := []string{"tags", "opening_windows", "rating_details", "delivery_fee", "my_note", "my_tags"}
 	for _, field := range optional {
 		if value, ok := data[field]; ok {
 			rows = append(rows, []string{field, fmt.Sprintf("%v", value)})
@@ -1065,76 +1346,100 @@ func resolveVenueItemPayloadBySlug(
 	itemID string,
 	auth woltgateway.AuthContext,
 ) (string, map[string]any, []string) {
-	venueID := strings.TrimSpace(venueSlug)
-	warnings := []string{}
-	assortmentPayload := map[string]any{}
-	venueContentPayloads := []map[string]any{}
-	venueContentLoaded := false
-	loadVenueContent := func() {
-		if venueContentLoaded {
-			return
-		}
-		venueContentLoaded = true
-		payloads, fallbackWarnings := loadVenueContentPayloads(ctx, deps, venueSlug, auth, 2)
-		venueContentPayloads = payloads
-		warnings = append(warnings, fallbackWarnings...)
-	}
+	return newVenueItemLookup(ctx, deps, venueSlug, auth).item(itemID)
+}
 
-	if payload, err := deps.Wolt.VenuePageStatic(ctx, venueSlug); err == nil {
-		if resolvedID := venueIDFromPayload(payload); strings.TrimSpace(resolvedID) != "" {
-			venueID = strings.TrimSpace(resolvedID)
-		}
-	} else {
-		warnings = append(warnings, "venue static page endpoint unavailable")
+// venueItemLookup resolves item payloads for one venue. The static page,
+// assortment, and venue content are fetched once and shared by every item.
+type venueItemLookup struct {
+	ctx                  context.Context
+	deps                 Dependencies
+	venueSlug            string
+	auth                 woltgateway.AuthContext
+	venueID              string
+	warnings             []string
+	assortmentPayload    map[string]any
+	venueContentPayloads []map[string]any
+	venueContentLoaded   bool
+}
+
+func newVenueItemLookup(ctx context.Context, deps Dependencies, venueSlug string, auth woltgateway.AuthContext) *venueItemLookup {
+	lookup := &venueItemLookup{
+		ctx:               ctx,
+		deps:              deps,
+		venueSlug:         venueSlug,
+		auth:              auth,
+		assortmentPayload: map[string]any{},
 	}
+	lookup.venueID, lookup.warnings = venueIDForSlug(ctx, deps, venueSlug)
 	if payload, err := deps.Wolt.AssortmentByVenueSlug(ctx, venueSlug); err == nil {
-		assortmentPayload = payload
+		lookup.assortmentPayload = payload
 	} else {
-		warnings = append(warnings, "venue assortment endpoint unavailable")
+		lookup.warnings = append(lookup.warnings, "venue assortment endpoint unavailable")
+	}
+	if needsVenueContentFallback(lookup.assortmentPayload, lookup.venueID) {
+		lookup.loadVenueContent()
 	}
-	if needsVenueContentFallback(assortmentPayload, venueID) {
-		loadVenueContent()
+	return lookup
+}
+
+func (l *venueItemLookup) loadVenueContent() {
+	if l.venueContentLoaded {
+		return
 	}
+	l.venueContentLoaded = true
+	payloads, fallbackWarnings := loadVenueContentPayloads(l.ctx, l.deps, l.venueSlug, l.auth, 2)
+	l.venueContentPayloads = payloads
+	l.warnings = append(l.warnings, fallbackWarnings...)
+}
 
+// item returns the venue id, the best available payload for itemID, and the
+// venue and item warnings.
+func (l *venueItemLookup) item(itemID string) (string, map[string]any, []string) {
+	venueID := l.venueID
+	warnings := []string{}
 	payload := map[string]any{}
 	if venueID != "" {
-		if itemPayload, err := deps.Wolt.VenueItemPage(ctx, venueID, itemID); err == nil {
+		if itemPayload, err := l.deps.Wolt.VenueItemPage(l.ctx, venueID, itemID); err == nil {
 			payload = itemPayload
-			if fallback := buildItemPayloadFromAssortment(assortmentPayload, itemID); fallback != nil {
+			if fallback := buildItemPayloadFromAssortment(l.assortmentPayload, itemID); fallback != nil {
 				payload = mergeItemPayloadFallback(payload, fallback)
 			}
 			if !payloadContainsItem(payload, venueID, itemID) {
-				if fallback := buildItemPayloadFromMenuPayloads(venueContentPayloads, venueID, itemID); fallback != nil {
+				if fallback := buildItemPayloadFromMenuPayloads(l.venueContentPayloads, venueID, itemID); fallback != nil {
 					payload = mergeItemPayloadFallback(payload, fallback)
+					recordFallback(l.ctx, "item_venue_content")
 					warnings = append(warnings, "item endpoint payload incomplete; used venue content fallback metadata")
 				}
 			}
 		} else {
 			warnings = append(warnings, "item endpoint unavailable")
-			if fallback := buildItemPayloadFromAssortment(assortmentPayload, itemID); fallback != nil {
+			if fallback := buildItemPayloadFromAssortment(l.assortmentPayload, itemID); fallback != nil {
 				payload = fallback
+				recordFallback(l.ctx, "item_assortment")
 			}
 			if !payloadContainsItem(payload, venueID, itemID) {
-				if len(venueContentPayloads) == 0 {
-					loadVenueContent()
+				if len(l.venueContentPayloads) == 0 {
+					l.loadVenueContent()
 				}
-				if fallback := buildItemPayloadFromMenuPayloads(venueContentPayloads, venueID, itemID); fallback != nil {
+				if fallback := buildItemPayloadFromMenuPayloads(l.venueContentPayloads, venueID, itemID); fallback != nil {
 					payload = mergeItemPayloadFallback(payload, fallback)
+					recordFallback(l.ctx, "item_venue_content")
 					warnings = append(warnings, "used venue content fallback metadata for item lookup")
 				}
 			}
 		}
 	}
-	if len(payload) == 0 && len(venueContentPayloads) > 0 {
-		payload = venueContentPayloads[0]
+	if len(payload) == 0 && len(l.venueContentPayloads) > 0 {
+		payload = l.venueContentPayloads[0]
 	}
-	if len(payload) == 0 && len(assortmentPayload) > 0 {
-		payload = assortmentPayload
+	if len(payload) == 0 && len(l.assortmentPayload) > 0 {
+		payload = l.assortmentPayload
 	}
 	if len(payload) == 0 {
 		warnings = append(warnings, "item payload fallback unavailable")
 	}
-	return venueID, payload, dedupeStrings(warnings)
+	return venueID, payload, dedupeStrings(append(append([]string{}, l.warnings...), warnings...))
 }
 
 func itemOptionGroupIDsFromPayload(payload map[string]any, venueID string, itemID string) []string {
@@ -1297,7 +1602,7 @@ func buildVenueMenuTable(data map[string]any) string {
 }
 
 func buildVenueItemSearchTable(data map[string]any) string {
-	headers := []string{"Item ID", "Name", "Category", "Price", "Sold out", "Discounts", "Option groups"}
+	headers := []string{"Item ID", "Name", "Category", "Price", "Unit price", "Sold out", "Discounts", "Option groups"}
 	rows := make([][]string, 0, len(asSlice(data["items"])))
 	for _, value := range asSlice(data["items"]) {
 		item := asMap(value)
@@ -1320,13 +1625,14 @@ func buildVenueItemSearchTable(data map[string]any) string {
 			fallbackString(asString(item["name"]), "-"),
 			fallbackString(asString(item["category"]), "-"),
 			formatVenueSearchPriceForTable(asMap(item["base_price"]), asMap(item["original_price"])),
+			formatUnitPriceForTable(item),
 			boolToYesNo(asBool(item["is_sold_out"])),
 			discounts,
 			optionGroups,
 		})
 	}
 	if len(rows) == 0 {
-		rows = append(rows, []string{"-", "-", "-", "-", "-", "-", "-"})
+		rows = append(rows, []string{"-", "-", "-", "-", "-", "-", "-", "-"})
 	}
 	return output.RenderTable(
 		fmt.Sprintf("Venue item search: %s (%s)", asString(data["venue_slug"]), asString(data["query"])),
@@ -1348,9 +1654,9 @@ func formatBasePriceForTable(basePrice map[string]any) string {
 	amount := asInt(basePrice["amount"])
 	currency := strings.TrimSpace(asString(basePrice["currency"]))
 	if currency == "" {
-		return fmt.Sprintf("%.2f", float64(amount)/100)
+		return money.Decimal(amount, "")
 	}
-	return fmt.Sprintf("%s %.2f", currency, float64(amount)/100)
+	return currency + " " + money.Decimal(amount, currency)
 }
 
 func formatVenueSearchPriceForTable(basePrice map[string]any, originalPrice map[string]any) string {
@@ -1436,7 +1742,7 @@ func normalizeVenueSearchPrice(price map[string]any, fallbackCurrency string) ma
 		if currency != "" {
 			normalized["formatted_amount"] = formatMinorAmount(amount, currency)
 		} else {
-			normalized["formatted_amount"] = fmt.Sprintf("%.2f", float64(amount)/100)
+			normalized["formatted_amount"] = money.Decimal(amount, "")
 		}
 	}
 	return normalized
@@ -1487,7 +1793,20 @@ func buildVenueHoursTable(data map[string]any) string {
 		window := asMap(value)
 		rows = append(rows, []string{asString(window["day"]), asString(window["open"]), asString(window["close"])})
 	}
-	return output.RenderTable("Venue hours ("+asString(data["timezone"])+")", headers, rows)
+	title := "Venue hours (" + asString(data["timezone"])
+	if openNow, ok := data["open_now"].(bool); ok {
+		state := "closed"
+		if openNow {
+			state = "open"
+		}
+		title += "; " + state + " at " + displayTimestamp(asString(data["now"]))
+		if closesAt := asString(data["closes_at"]); closesAt != "" {
+			title += ", closes " + displayTimestamp(closesAt)
+		} else if opensAt := asString(data["opens_at"]); opensAt != "" {
+			title += ", opens " + displayTimestamp(opensAt)
+		}
+	}
+	return output.RenderTable(title+")", headers, rows)
 }
 
 func buildItemDetailTable(data map[string]any) string {
@@ -1695,3 +2014,39 @@ func join(values []string, separator string) string {
 	}
 	return result
 }
+
+// loadVenueDynamicPayload fetches the dynamic venue page for the --address,
+// --lat/--lon, or account location, retrying anonymously on 401. A nil payload means the endpoint
+// was unavailable; an error has already been emitted.
+func loadVenueDynamicPayload(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	format output.Format,
+	profile domain.Profile,
+	auth woltgateway.AuthContext,
+	slug string,
+) (map[string]any, error) {
+	dynamicLocation, code, err := locationOverride(cmd.Context(), deps, cmd, flags.Address)
+	if err != nil {
+		return nil, emitError(cmd, format, profile.Name, flags.Locale, flags.Output, code, err.Error())
+	}
+	if dynamicLocation == nil {
+		if location, locationErr := resolveAccountLocation(cmd.Context(), deps, profile, &auth); locationErr == nil {
+			dynamicLocation = &location
+		}
+	}
+	dynamicOptions := woltgateway.VenuePageDynamicOptions{
+		Location: dynamicLocation,
+		Auth:     auth,
+	}
+	payload, err := deps.Wolt.VenuePageDynamic(cmd.Context(), slug, dynamicOptions)
+	if err != nil && isUnauthorized(err) && dynamicOptions.Auth.HasCredentials() {
+		dynamicOptions.Auth = woltgateway.AuthContext{}
+		payload, err = deps.Wolt.VenuePageDynamic(cmd.Context(), slug, dynamicOptions)
+	}
+	if err != nil {
+		return nil, nil
+	}
+	return payload, nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newVenueKnownCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "known [query]",
+		Short: "List venues from the local id/slug map.",
+		Long: "List venues from the local id/slug map.\n\n" +
+			"Discovery, search, and venue commands record the slug, id, name, and city of every venue they see, " +
+			"so venue ids resolve and item lookups skip the static venue page without a request. " +
+			"The map lives in venues.json under $WOLT_CACHE_DIR, or the cache directory next to the config file. " +
+			"An optional query keeps venues whose slug, id, name, or city contains it.",
+		Example: "wolt venue known\n" +
+			"wolt venue known burger --format json",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			query := ""
+			if len(args) == 1 {
+				query = strings.ToLower(strings.TrimSpace(args[0]))
+			}
+
+			file, known, err := loadKnownVenues(deps)
+			if file == nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_CACHE_ERROR", err.Error())
+			}
+			warnings := []string{}
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("venue map reset: %v", err))
+			}
+
+			rows := []any{}
+			for _, venue := range sortedKnownVenues(known) {
+				if query != "" && !knownVenueMatches(venue, query) {
+					continue
+				}
+				row := map[string]any{
+					"venue_id":  venue.VenueID,
+					"slug":      venue.Slug,
+					"name":      emptyToNil(venue.Name),
+					"city":      emptyToNil(venue.City),
+					"last_seen": venue.LastSeen.UTC().Format(time.RFC3339),
+				}
+				if !venue.FirstSeen.IsZero() {
+					row["first_seen"] = venue.FirstSeen.UTC().Format(time.RFC3339)
+				}
+				rows = append(rows, row)
+			}
+			data := map[string]any{
+				"venues": rows,
+				"count":  len(rows),
+				"path":   file.Path(),
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildVenueKnownTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func knownVenueMatches(venue knownVenue, query string) bool {
+	for _, value := range []string{venue.Slug, venue.VenueID, venue.Name, venue.City} {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildVenueKnownTable(data map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(data["venues"]) {
+		venue := asMap(value)
+		rows = append(rows, []string{
+			asString(venue["slug"]),
+			asString(venue["venue_id"]),
+			fallbackString(asString(venue["name"]), "-"),
+			fallbackString(asString(venue["city"]), "-"),
+			asString(venue["last_seen"]),
+		})
+	}
+	return output.RenderTable(
+		fmt.Sprintf("Known venues (%d)", asInt(data["count"])),
+		[]string{"Slug", "Venue ID", "Name", "City", "Last seen"},
+		rows,
+	)
+}
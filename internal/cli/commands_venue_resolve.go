@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newVenueResolveCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "resolve <venue-id>",
+		Short: "Map a venue id to its slug, name, and public URL.",
+		Long: "Map a venue id to its slug, name, and public URL.\n\n" +
+			"Baskets, orders, and favourites reference venues by id; the slug is what venue and item commands take.",
+		Example: "wolt venue resolve 5a8426f188b5de000b8857bb\n" +
+			"wolt venue menu \"$(wolt venue resolve 5a8426f188b5de000b8857bb --format json | jq -r .data.slug)\"",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			venueID := strings.ToLower(strings.TrimSpace(args[0]))
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if !woltVenueIDPattern.MatchString(venueID) {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf(
+					"%q is not a venue id; venue ids are 24 hexadecimal characters", args[0],
+				))
+			}
+			restaurant, err := deps.Wolt.RestaurantByID(cmd.Context(), venueID)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			if restaurant == nil || strings.TrimSpace(restaurant.Slug) == "" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_NOT_FOUND", fmt.Sprintf("venue %s was not found", venueID))
+			}
+
+			slug := strings.TrimSpace(restaurant.Slug)
+			data := map[string]any{
+				"venue_id":   venueID,
+				"slug":       slug,
+				"name":       restaurantName(restaurant, flags.Locale),
+				"public_url": strings.TrimSpace(restaurant.PublicURL),
+				"address":    strings.TrimSpace(restaurant.Address),
+				"city":       strings.TrimSpace(restaurant.City),
+				"country":    strings.TrimSpace(restaurant.Country),
+			}
+			rememberVenues(deps, knownVenue{VenueID: venueID, Slug: slug, Name: asString(data["name"]), City: restaurant.City})
+			warnings := []string{}
+			if data["name"] == "" || data["public_url"] == "" {
+				// The static venue page carries the same fields for venues whose
+				// restaurant payload omits them.
+				if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
+					venue := asMap(payload["venue"])
+					if data["name"] == "" {
+						data["name"] = strings.TrimSpace(asString(coalesceAny(venue["name"], payload["name"])))
+					}
+					if data["public_url"] == "" {
+						data["public_url"] = strings.TrimSpace(asString(coalesceAny(venue["public_url"], venue["share_url"], payload["public_url"])))
+					}
+				} else {
+					warnings = append(warnings, "venue static page endpoint unavailable")
+				}
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildVenueResolveTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// restaurantName picks the venue name translation for locale, falling back to
+// English and then to the first translation.
+func restaurantName(restaurant *domain.Restaurant, locale string) string {
+	language := strings.ToLower(strings.SplitN(strings.TrimSpace(locale), "-", 2)[0])
+	fallback := ""
+	for _, translation := range restaurant.Name {
+		value := strings.TrimSpace(translation.Value)
+		if value == "" {
+			continue
+		}
+		lang := strings.ToLower(strings.TrimSpace(translation.Lang))
+		if language != "" && lang == language {
+			return value
+		}
+		if fallback == "" || lang == "en" {
+			fallback = value
+		}
+	}
+	return fallback
+}
+
+func buildVenueResolveTable(data map[string]any) string {
+	rows := [][]string{
+		{"Venue ID", asString(data["venue_id"])},
+		{"Slug", asString(data["slug"])},
+		{"Name", fallbackString(asString(data["name"]), "-")},
+		{"Public URL", fallbackString(asString(data["public_url"]), "-")},
+		{"City", fallbackString(asString(data["city"]), "-")},
+	}
+	return output.RenderTable("Venue", []string{"Field", "Value"}, rows)
+}
@@ -0,0 +1,455 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// shoppingListLine is one grocery list entry: the line as written, the
+// search query left after the quantity is taken off, and that quantity.
+type shoppingListLine struct {
+	text  string
+	query string
+	count int
+}
+
+var (
+	shoppingLeadingCount  = regexp.MustCompile(`(?i)^(\d+)\s*(?:x|×|kpl|pcs)?\s+(.+)$`)
+	shoppingTrailingCount = regexp.MustCompile(`(?i)^(.+?)\s+[x×](\d+)$`)
+	shoppingBullet        = regexp.MustCompile(`^(?:[-*•]\s*)?(?:\[[ xX]?\]\s*)?`)
+)
+
+func newVenueShopCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var listPath string
+	var minConfidence float64
+	var apply bool
+	var noLock bool
+	var force bool
+	var approveToken string
+
+	cmd := &cobra.Command{
+		Use:   "shop <slug>",
+		Short: "Match a shopping list against one venue and optionally fill the cart.",
+		Long: "Match a shopping list against one venue and optionally fill the cart.\n\n" +
+			"Each list line is searched inside the venue and the closest item name is picked with a confidence score. " +
+			"A leading or trailing count (\"2 x milk\", \"eggs x2\") sets the quantity. Lines below --min-confidence, " +
+			"sold out, or without results are reported as misses. --apply adds every match to the cart in one request.",
+		Example: "wolt venue shop wolt-market-kamppi --list groceries.txt\n" +
+			"cat groceries.txt | wolt venue shop wolt-market-kamppi --list - --apply",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			slug, err := resolveVenueSlugArgument(cmd, deps, flags, format, args[0])
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			if minConfidence < 0 || minConfidence > 1 {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--min-confidence must be between 0 and 1")
+			}
+			lines, err := readShoppingList(cmd, listPath)
+			if err != nil {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if apply {
+				if err := requireAuth(cmd, format, profileName, flags.Locale, flags.Output, auth); err != nil {
+					return err
+				}
+				if err := guardLatestOrderTime(cmd, deps, flags.Profile, force, format, profileName, flags.Locale, flags.Output); err != nil {
+					return err
+				}
+				release, err := lockProfile(cmd, deps, noLock, format, profileName, flags.Locale, flags.Output)
+				if err != nil {
+					return err
+				}
+				defer release()
+			}
+
+			venueID := slug
+			warnings := []string{}
+			staticPayload := map[string]any{}
+			if payload, err := deps.Wolt.VenuePageStatic(cmd.Context(), slug); err == nil {
+				staticPayload = payload
+				if resolvedID := strings.TrimSpace(venueIDFromPayload(payload)); resolvedID != "" {
+					venueID = resolvedID
+				}
+			} else {
+				warnings = append(warnings, "venue static page endpoint unavailable")
+			}
+
+			language := resolveAssortmentLanguage(flags.Locale)
+			rows := make([]any, 0, len(lines))
+			missing := []string{}
+			currency := ""
+			additions := []map[string]any{}
+			additionByID := map[string]map[string]any{}
+			for _, line := range lines {
+				row := map[string]any{
+					"line":       line.text,
+					"query":      line.query,
+					"count":      line.count,
+					"status":     "missing",
+					"confidence": 0.0,
+					"match":      nil,
+				}
+				rows = append(rows, row)
+				searchPayload, err := requestAssortmentItemsSearchPayload(cmd.Context(), deps, slug, line.query, language, auth)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("search for %q failed: %v", line.query, err))
+					missing = append(missing, line.text)
+					continue
+				}
+				lineCurrency := resolveVenueSearchFallbackCurrency(staticPayload, searchPayload)
+				data, _ := buildVenueItemSearchData(venueID, slug, line.query, "", searchPayload, lineCurrency, false, nil)
+				match, confidence := bestShoppingMatch(line.query, asSlice(data["items"]))
+				if match == nil {
+					missing = append(missing, line.text)
+					continue
+				}
+				row["confidence"] = confidence
+				row["match"] = map[string]any{
+					"item_id":     match["item_id"],
+					"name":        match["name"],
+					"base_price":  match["base_price"],
+					"is_sold_out": asBool(match["is_sold_out"]),
+				}
+				switch {
+				case asBool(match["is_sold_out"]):
+					row["status"] = "sold_out"
+				case confidence < minConfidence:
+					row["status"] = "low_confidence"
+				default:
+					row["status"] = "matched"
+				}
+				if row["status"] != "matched" {
+					missing = append(missing, line.text)
+					continue
+				}
+				price := asMap(match["base_price"])
+				if currency == "" {
+					currency = strings.TrimSpace(asString(price["currency"]))
+				}
+				itemID := asString(match["item_id"])
+				if existing, ok := additionByID[itemID]; ok {
+					existing["count"] = asInt(existing["count"]) + line.count
+					continue
+				}
+				addition := map[string]any{
+					"id":      itemID,
+					"count":   line.count,
+					"name":    asString(match["name"]),
+					"price":   asInt(price["amount"]),
+					"options": []any{},
+					"substitution_settings": map[string]any{
+						"is_allowed": false,
+					},
+				}
+				additionByID[itemID] = addition
+				additions = append(additions, addition)
+			}
+
+			data := map[string]any{
+				"venue_id":       venueID,
+				"venue_slug":     slug,
+				"min_confidence": minConfidence,
+				"lines":          rows,
+				"matched":        len(lines) - len(missing),
+				"missing":        missing,
+				"applied":        false,
+				"cart":           nil,
+			}
+			profile := profileName
+			if apply && len(additions) > 0 {
+				cart, resolvedProfile, addWarnings, err := addLinesToBasket(cmd, deps, flags, &auth, format, venueID, currency, additions, approveToken)
+				warnings = append(warnings, addWarnings...)
+				if err != nil {
+					return err
+				}
+				profile = resolvedProfile
+				data["applied"] = true
+				data["cart"] = cart
+			} else if apply {
+				warnings = append(warnings, "no list line matched; nothing was added to the cart")
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildVenueShopTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, dedupeStrings(warnings), nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&listPath, "list", "", "Shopping list file with one item per line, or - for stdin (# starts a comment).")
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0.5, "Lowest match confidence (0-1) that counts as a match.")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Add every matched item to the cart in one batch.")
+	addNoLockFlag(cmd, &noLock)
+	addForceFlag(cmd, &force)
+	addApproveTokenFlag(cmd, &approveToken)
+	if err := cmd.MarkFlagRequired("list"); err != nil {
+		panic(err)
+	}
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// addLinesToBasket adds additions to the venue's basket in one request,
+// re-sending the lines already in it, after the country and approval guards.
+// It returns the cart summary {basket_id,added_lines,total_items,total} and
+// the resolved profile name; errors are already emitted.
+func addLinesToBasket(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	format output.Format,
+	venueID string,
+	currency string,
+	additions []map[string]any,
+	approveToken string,
+) (map[string]any, string, []string, error) {
+	location, profile, err := resolveProfileLocation(
+		cmd.Context(),
+		deps,
+		flags.Address,
+		flags.Profile,
+		format,
+		flags.Locale,
+		flags.Output,
+		auth,
+		cmd,
+	)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	warnings := []string{}
+	items := []any{}
+	for _, addition := range additions {
+		items = append(items, addition)
+	}
+	venueMutationID := venueID
+	var basket map[string]any
+	page, pageWarnings, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.BasketsPage(cmd.Context(), location, authCtx)
+		},
+	)
+	warnings = append(warnings, pageWarnings...)
+	if err != nil {
+		warnings = append(warnings, "unable to load existing basket snapshot before add; upstream may replace existing lines")
+	} else if basket, _, _ = selectBasketWithMeta(page, venueID); basket != nil {
+		if resolvedID := strings.TrimSpace(asString(asMap(basket["venue"])["id"])); resolvedID != "" {
+			venueMutationID = resolvedID
+		}
+		items = mergeBasketAddLines(asSlice(basket["items"]), additions)
+	}
+	if err := guardAllowedCountry(cmd, deps, flags.Profile, basket, venueMutationID, format, profile, flags.Locale, flags.Output); err != nil {
+		return nil, profile, warnings, err
+	}
+	approvalWarning, err := guardApproval(cmd, deps, flags.Profile, approveToken, basketLinesTotal(items), fallbackString(currency, "EUR"), format, profile, flags.Locale, flags.Output)
+	if err != nil {
+		return nil, profile, warnings, err
+	}
+	if approvalWarning != "" {
+		warnings = append(warnings, approvalWarning)
+	}
+	result, addWarnings, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.AddToBasket(cmd.Context(), map[string]any{
+				"items":    items,
+				"venue_id": venueMutationID,
+				"currency": fallbackString(currency, "EUR"),
+			}, authCtx)
+		},
+	)
+	warnings = append(warnings, addWarnings...)
+	if err != nil {
+		return nil, profile, warnings, emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+	}
+	recordAddedPrices(deps, venueMutationID, fallbackString(currency, "EUR"), additions)
+	cart := map[string]any{
+		"basket_id":   asString(result["id"]),
+		"added_lines": len(additions),
+		"total_items": nil,
+		"total":       nil,
+	}
+	if page, _, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.BasketsPage(cmd.Context(), location, authCtx)
+		},
+	); err == nil {
+		state, _ := buildCartState(page, venueMutationID)
+		cart["total_items"] = state["total_items"]
+		cart["total"] = state["total"]
+	}
+	return cart, profile, warnings, nil
+}
+
+// readShoppingList reads list lines, skipping blanks and # comments and
+// dropping list bullets and checkboxes.
+func readShoppingList(cmd *cobra.Command, path string) ([]shoppingListLine, error) {
+	path = strings.TrimSpace(path)
+	var reader io.Reader
+	if path == "-" {
+		reader = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("read --list: %w", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+	lines := []shoppingListLine{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(shoppingBullet.ReplaceAllString(strings.TrimSpace(text), ""))
+		if text == "" {
+			continue
+		}
+		line := shoppingListLine{text: text, query: text, count: 1}
+		if match := shoppingLeadingCount.FindStringSubmatch(text); match != nil {
+			line.count, _ = strconv.Atoi(match[1])
+			line.query = strings.TrimSpace(match[2])
+		} else if match := shoppingTrailingCount.FindStringSubmatch(text); match != nil {
+			line.count, _ = strconv.Atoi(match[2])
+			line.query = strings.TrimSpace(match[1])
+		}
+		if line.count <= 0 {
+			line.count = 1
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read --list: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("--list has no items")
+	}
+	return lines, nil
+}
+
+// bestShoppingMatch returns the search row whose name best fits query. A row
+// that is in stock wins over a sold-out one; ties keep the upstream order.
+func bestShoppingMatch(query string, rows []any) (map[string]any, float64) {
+	var best map[string]any
+	bestScore := -1.0
+	for _, value := range rows {
+		row := asMap(value)
+		if row == nil || strings.TrimSpace(asString(row["item_id"])) == "" {
+			continue
+		}
+		confidence := shoppingMatchConfidence(query, asString(row["name"]))
+		score := confidence
+		if asBool(row["is_sold_out"]) {
+			score -= 1
+		}
+		if score > bestScore {
+			best, bestScore = row, score
+		}
+	}
+	if best == nil {
+		return nil, 0
+	}
+	return best, shoppingMatchConfidence(query, asString(best["name"]))
+}
+
+// shoppingMatchConfidence scores how well an item name covers a list query,
+// from 0 to 1: mostly the share of query words found in the name (a shared
+// prefix of at least three letters counts for less), plus a smaller share
+// for how little of the name is left unexplained.
+func shoppingMatchConfidence(query string, name string) float64 {
+	queryWords := shoppingWords(query)
+	nameWords := shoppingWords(name)
+	if len(queryWords) == 0 || len(nameWords) == 0 {
+		return 0
+	}
+	covered := 0.0
+	used := map[int]struct{}{}
+	for _, queryWord := range queryWords {
+		bestWord, bestIndex := 0.0, -1
+		for index, nameWord := range nameWords {
+			if _, ok := used[index]; ok {
+				continue
+			}
+			score := 0.0
+			switch {
+			case nameWord == queryWord:
+				score = 1
+			case min(len(nameWord), len(queryWord)) >= 3 && (strings.HasPrefix(nameWord, queryWord) || strings.HasPrefix(queryWord, nameWord)):
+				score = 0.8
+			}
+			if score > bestWord {
+				bestWord, bestIndex = score, index
+			}
+		}
+		if bestIndex >= 0 {
+			used[bestIndex] = struct{}{}
+			covered += bestWord
+		}
+	}
+	confidence := 0.75*covered/float64(len(queryWords)) + 0.25*float64(len(used))/float64(len(nameWords))
+	return math.Round(confidence*100) / 100
+}
+
+func shoppingWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func buildVenueShopTable(data map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(data["lines"]) {
+		line := asMap(value)
+		match := asMap(line["match"])
+		rows = append(rows, []string{
+			asString(line["line"]),
+			strconv.Itoa(asInt(line["count"])),
+			fallbackString(asString(match["name"]), "-"),
+			formatBasePriceForTable(asMap(match["base_price"])),
+			fmt.Sprintf("%.2f", line["confidence"]),
+			asString(line["status"]),
+		})
+	}
+	title := fmt.Sprintf("Shopping list: %s (%d matched, %d missing)", asString(data["venue_slug"]), asInt(data["matched"]), len(asSlice(data["missing"])))
+	text := output.RenderTable(title, []string{"Line", "Qty", "Match", "Price", "Confidence", "Status"}, rows)
+	if cart := asMap(data["cart"]); cart != nil {
+		text += "\n\n" + output.RenderTable("Cart", []string{"Field", "Value"}, [][]string{
+			{"Basket ID", fallbackString(asString(cart["basket_id"]), "-")},
+			{"Lines added", asString(cart["added_lines"])},
+			{"Total items", fallbackString(asString(cart["total_items"]), "-")},
+			{"Total", fallbackString(asString(asMap(cart["total"])["formatted_amount"]), "-")},
+		})
+	}
+	return text
+}
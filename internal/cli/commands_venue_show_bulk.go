@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const venueShowConcurrency = 4
+
+// readVenueShowSlugs merges --slug values with --slugs-file lines, skipping
+// blank lines and # comments and keeping the first occurrence of each slug.
+func readVenueShowSlugs(cmd *cobra.Command, values []string, path string) ([]string, error) {
+	slugs := []string{}
+	for _, value := range values {
+		slugs = append(slugs, strings.TrimSpace(value))
+	}
+	if path = strings.TrimSpace(path); path != "" {
+		var reader io.Reader
+		if path == "-" {
+			reader = cmd.InOrStdin()
+		} else {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("read --slugs-file: %w", err)
+			}
+			defer file.Close()
+			reader = file
+		}
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			slugs = append(slugs, strings.TrimSpace(line))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read --slugs-file: %w", err)
+		}
+	}
+	slugs = dedupeStrings(slugs)
+	if len(slugs) == 0 {
+		return nil, fmt.Errorf("--slug/--slugs-file listed no venues")
+	}
+	return slugs, nil
+}
+
+type venueShowBulkResult struct {
+	slug   string
+	result venueDetailResult
+	err    error
+	// upstream marks err as a failed request rather than a bad or refused slug.
+	upstream bool
+}
+
+// runVenueShowBulk fetches venue details for slugs with a small worker pool
+// sharing one HTTP client. Venues that fail are listed under errors and mark
+// the run partial when a request failed; --strict turns that into a command
+// failure.
+func runVenueShowBulk(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	format output.Format,
+	slugs []string,
+	include string,
+	noFallback bool,
+	strict bool,
+) error {
+	locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+	location, profile, err := resolveProfileLocation(
+		cmd.Context(),
+		deps,
+		flags.Address,
+		flags.Profile,
+		format,
+		flags.Locale,
+		flags.Output,
+		&locationAuth,
+		cmd,
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	results := make([]venueShowBulkResult, len(slugs))
+	jobs := make(chan int)
+	workers := sync.WaitGroup{}
+	workerCount := min(venueShowConcurrency, len(slugs))
+	workers.Add(workerCount)
+	for range workerCount {
+		go func() {
+			defer workers.Done()
+			for idx := range jobs {
+				res := venueShowBulkResult{slug: slugs[idx]}
+				slug, err := resolveVenueSlug(ctx, deps, slugs[idx])
+				var argErr *venueArgumentError
+				if err == nil {
+					res.slug = slug
+					res.result, err = loadVenueDetail(ctx, deps, location, slug, include)
+					if errors.Is(err, errVenueNotInCatalog) {
+						err = fmt.Errorf("venue slug %q was not found in profile %q catalog", slug, profile)
+					} else if err != nil {
+						res.upstream = true
+					}
+				} else if !errors.As(err, &argErr) {
+					res.upstream = true
+				}
+				if err == nil && noFallback && len(res.result.fallback) > 0 {
+					err = fmt.Errorf("--no-fallback: %s", strings.Join(res.result.fallback, "; "))
+				}
+				res.err = err
+				results[idx] = res
+			}
+		}()
+	}
+	for idx := range slugs {
+		jobs <- idx
+	}
+	close(jobs)
+	workers.Wait()
+
+	venues := []any{}
+	failed := []any{}
+	warnings := []string{}
+	for _, res := range results {
+		if res.err != nil {
+			if res.upstream {
+				recordPartialFailure(ctx, "venue show", res.err)
+			}
+			failed = append(failed, map[string]any{"slug": res.slug, "message": res.err.Error()})
+			continue
+		}
+		venues = append(venues, res.result.data)
+		warnings = append(warnings, res.result.warnings...)
+	}
+	warnings = dedupeStrings(warnings)
+	annotateVenueNotes(ctx, deps, flags.Profile, venueRowMaps(venues)...)
+	data := map[string]any{
+		"venues":    venues,
+		"count":     len(venues),
+		"requested": len(slugs),
+		"errors":    failed,
+	}
+	if len(failed) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d of %d venues could not be shown; see data.errors", len(failed), len(slugs)))
+	}
+	warnings, err = finishPartialRun(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, strict, data, warnings)
+	if err != nil {
+		return err
+	}
+
+	if format == output.FormatTable {
+		return writeTable(cmd, buildVenueShowBulkTable(data), flags.Output)
+	}
+	env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+	return writeMachinePayload(cmd, env, format, flags.Output)
+}
+
+func buildVenueShowBulkTable(data map[string]any) string {
+	headers := []string{"Slug", "Name", "Address", "Rating", "Completeness"}
+	rows := [][]string{}
+	for _, value := range asSlice(data["venues"]) {
+		venue := asMap(value)
+		score, _ := asFloat(asMap(venue["completeness"])["score"])
+		rows = append(rows, []string{
+			asString(venue["slug"]),
+			fallbackString(asString(venue["name"]), "-"),
+			fallbackString(asString(venue["address"]), "-"),
+			fallbackString(asString(venue["rating"]), "-"),
+			fmt.Sprintf("%.0f%%", score*100),
+		})
+	}
+	for _, value := range asSlice(data["errors"]) {
+		failure := asMap(value)
+		rows = append(rows, []string{asString(failure["slug"]), "error: " + asString(failure["message"]), "-", "-", "-"})
+	}
+	return output.RenderTable(fmt.Sprintf("Venues (%d of %d)", asInt(data["count"]), asInt(data["requested"])), headers, rows)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const venueSlotsMaxDays = 14
+
+func newVenueSlotsCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+	var timezone string
+	var date string
+	var days int
+	var interval time.Duration
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "slots <slug>",
+		Short: "List preorder delivery and pickup time slots by slug.",
+		Long: "List preorder delivery and pickup time slots by slug.\n\n" +
+			"Slots come from the venue's weekly preorder windows, laid out in the venue timezone. " +
+			"Slots inside the venue's minimum preorder lead time are skipped.",
+		Example: "wolt venue slots burger-place\n" +
+			"wolt venue slots burger-place --date 2026-03-02 --mode delivery --interval 30m",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug := args[0]
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			slug, err = resolveVenueSlugArgument(cmd, deps, flags, format, slug)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			mode = strings.ToLower(strings.TrimSpace(mode))
+			if mode != "" && mode != "delivery" && mode != "pickup" {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--mode must be delivery or pickup")
+			}
+			if cmd.Flags().Changed("days") && (days < 1 || days > venueSlotsMaxDays) {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", fmt.Sprintf("--days must be between 1 and %d", venueSlotsMaxDays))
+			}
+			if cmd.Flags().Changed("interval") && (interval < 5*time.Minute || interval%time.Minute != 0) {
+				return emitError(cmd, format, profileName, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", "--interval must be a whole number of minutes, at least 5m")
+			}
+
+			locationAuth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			location, profile, err := resolveProfileLocation(
+				cmd.Context(),
+				deps,
+				flags.Address,
+				flags.Profile,
+				format,
+				flags.Locale,
+				flags.Output,
+				&locationAuth,
+				cmd,
+			)
+			if err != nil {
+				return err
+			}
+			item, venueID, _, warnings, err := resolveVenueBySlug(cmd.Context(), deps, location, slug)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			if item == nil || strings.TrimSpace(venueID) == "" {
+				return fmt.Errorf("venue slug %q was not found in profile %q catalog", slug, profile)
+			}
+			restaurant, err := deps.Wolt.RestaurantByID(cmd.Context(), venueID)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+
+			data, err := observability.BuildVenueSlots(restaurant, observability.VenueSlotOptions{
+				Timezone: timezone,
+				Now:      deps.now(),
+				Date:     date,
+				Days:     days,
+				Interval: interval,
+				Mode:     mode,
+			})
+			if err != nil {
+				return emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+			}
+			data["slug"] = slug
+			if !restaurant.PreorderEnabled {
+				warnings = append(warnings, "venue does not accept preorders; no slots listed")
+			}
+
+			if format == output.FormatTable {
+				return writeTable(cmd, buildVenueSlotsTable(data), flags.Output)
+			}
+			env := output.BuildEnvelope(profile, flags.Locale, data, warnings, nil)
+			return writeMachinePayload(cmd, env, format, flags.Output)
+		},
+	}
+
+	cmd.Flags().StringVar(&timezone, "timezone", "", "Timezone override (default: venue timezone)")
+	cmd.Flags().StringVar(&date, "date", "", "Only list slots on this day (YYYY-MM-DD, venue timezone)")
+	cmd.Flags().IntVar(&days, "days", 0, fmt.Sprintf("Days ahead to list when --date is omitted, 1-%d (default: the venue's preorder horizon)", venueSlotsMaxDays))
+	cmd.Flags().DurationVar(&interval, "interval", 0, "Slot length such as 30m (default: the venue's preorder time step)")
+	cmd.Flags().StringVar(&mode, "mode", "", "Only list delivery or pickup slots")
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+func buildVenueSlotsTable(data map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(data["slots"]) {
+		slot := asMap(value)
+		rows = append(rows, []string{
+			asString(slot["date"]),
+			asString(slot["mode"]),
+			venueSlotClock(asString(slot["start"])) + " - " + venueSlotClock(asString(slot["end"])),
+		})
+	}
+	return output.RenderTable("Preorder slots ("+asString(data["timezone"])+")", []string{"Date", "Mode", "Time"}, rows)
+}
+
+func venueSlotClock(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.Format("15:04")
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newWhoamiCommand(deps Dependencies) *cobra.Command {
+	var flags globalFlags
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the account, profile, and token behind this CLI in one view.",
+		Long: "Show the account, profile, and token behind this CLI in one view.\n\n" +
+			"Combines auth status and profile show: user id, name, country, Wolt+ status, the default delivery " +
+			"address, the active profile, and when the access token expires. Without credentials it reports " +
+			"the profile only and sends no request.",
+		Example: "wolt whoami\n" +
+			"wolt whoami --profile work --format json",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := parseOutputFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			profileName := defaultProfileName(flags.Profile)
+			profile, err := deps.Profiles.Find(cmd.Context(), flags.Profile)
+			if err == nil && strings.TrimSpace(profile.Name) != "" {
+				profileName = profile.Name
+			}
+
+			data := map[string]any{
+				"profile":              profileName,
+				"authenticated":        false,
+				"user_id":              nil,
+				"name":                 nil,
+				"country":              nil,
+				"wolt_plus_subscriber": false,
+				"default_address":      nil,
+				"token_expires_at":     nil,
+			}
+			auth := buildAuthContextWithProfile(cmd.Context(), deps, flags)
+			if !auth.HasCredentials() {
+				return writeWhoami(cmd, format, flags, profileName, data, []string{"no auth credentials provided"})
+			}
+
+			payload, warnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.UserMe(cmd.Context(), authCtx)
+				},
+			)
+			if err != nil {
+				return emitUpstreamError(cmd, format, profileName, flags.Locale, flags.Output, flags.Verbose, err)
+			}
+			summary := buildProfileSummary(payload, nil)
+			woltPlusSubscriber, _ := extractWoltPlusSubscriber(payload)
+			data["authenticated"] = true
+			data["user_id"] = emptyToNil(asString(summary["user_id"]))
+			data["name"] = emptyToNil(asString(summary["name"]))
+			data["country"] = emptyToNil(asString(coalesceAny(summary["country"], payload["country"])))
+			data["wolt_plus_subscriber"] = woltPlusSubscriber
+			data["token_expires_at"] = emptyToNil(tokenExpiryRFC3339(auth.WToken))
+
+			addresses, addressWarnings, err := invokeWithAuthAutoRefresh(
+				cmd.Context(),
+				deps,
+				flags,
+				&auth,
+				func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+					return deps.Wolt.DeliveryInfoList(cmd.Context(), authCtx)
+				},
+			)
+			warnings = append(warnings, addressWarnings...)
+			if err != nil {
+				warnings = append(warnings, "default address unavailable: "+woltgateway.Redact(err.Error()))
+			} else {
+				data["default_address"] = whoamiDefaultAddress(addresses, profile)
+			}
+			return writeWhoami(cmd, format, flags, profileName, data, warnings)
+		},
+	}
+
+	addGlobalFlags(cmd, &flags)
+	return cmd
+}
+
+// whoamiDefaultAddress picks the address the profile selected with
+// profile addresses use, else the first saved one, as location lookups do.
+func whoamiDefaultAddress(payload map[string]any, profile domain.Profile) any {
+	rows := extractDeliveryAddresses(payload, profile.WoltAddressID)
+	if len(rows) == 0 {
+		return nil
+	}
+	selected := asMap(rows[0])
+	for _, value := range rows {
+		if row := asMap(value); asBool(row["is_default"]) {
+			selected = row
+			break
+		}
+	}
+	return map[string]any{
+		"address_id": selected["address_id"],
+		"label":      selected["label"],
+		"street":     selected["street"],
+	}
+}
+
+func writeWhoami(cmd *cobra.Command, format output.Format, flags globalFlags, profileName string, data map[string]any, warnings []string) error {
+	if format == output.FormatTable {
+		return writeTable(cmd, buildWhoamiTable(data), flags.Output)
+	}
+	env := output.BuildEnvelope(profileName, flags.Locale, data, warnings, nil)
+	return writeMachinePayload(cmd, env, format, flags.Output)
+}
+
+func buildWhoamiTable(data map[string]any) string {
+	address := "-"
+	if row := asMap(data["default_address"]); row != nil {
+		address = asString(row["label"]) + ": " + fallbackString(asString(row["street"]), "-")
+	}
+	rows := [][]string{
+		{"Profile", asString(data["profile"])},
+		{"Authenticated", boolToYesNo(asBool(data["authenticated"]))},
+		{"User ID", fallbackString(asString(data["user_id"]), "-")},
+		{"Name", fallbackString(asString(data["name"]), "-")},
+		{"Country", fallbackString(asString(data["country"]), "-")},
+		{"Wolt+ subscriber", boolToYesNo(asBool(data["wolt_plus_subscriber"]))},
+		{"Default address", address},
+		{"Token expires", fallbackString(asString(data["token_expires_at"]), "-")},
+	}
+	return output.RenderTable("Who am I", []string{"Field", "Value"}, rows)
+}
@@ -22,23 +22,56 @@ func (e *exitError) Error() string {
 }
 
 type globalFlags struct {
-	Format        string
-	Profile       string
-	Address       string
-	Locale        string
-	NoColor       bool
-	Output        string
-	WToken        string
-	WRefreshToken string
-	Cookies       []string
-	Verbose       bool
+	Format            string
+	Profile           string
+	Address           string
+	Lat               float64
+	Lon               float64
+	Locale            string
+	NoColor           bool
+	Output            string
+	WToken            string
+	WRefreshToken     string
+	WTokenStdin       bool
+	Cookies           []string
+	AuthPreflight     bool
+	UserAgent         string
+	Verbose           bool
+	Stats             bool
+	ExplainRequest    bool
+	ExplainOnly       bool
+	RevealSecrets     bool
+	SaveSession       string
+	Machine           bool
+	Offline           bool
+	ReadOnly          bool
+	LowBandwidth      bool
+	ReportDegradation bool
+	TZ                string
+	TimeFormat        string
+	JSONNaming        string
+	NoPager           bool
+	MaxRows           int
+	Columns           string
+	MaxColWidth       int
+	ColWidths         []string
+	FullWidth         bool
+	Layout            string
+	Meta              []string
+	Expect            []string
+	ExpectNonempty    []string
 }
 
 const sharedGlobalFlagAnnotation = "wolt_cli_shared_global"
 
+const (
+	addressWithCoordinatesMessage = "Do not combine --address with --lat/--lon. Use either --address or both --lat and --lon."
+	partialCoordinatesMessage     = "Both --lat and --lon must be provided together, or omit both to use Wolt account address."
+)
+
 func addGlobalFlags(cmd *cobra.Command, flags *globalFlags) {
 	addSharedGlobalFlag(cmd, "format", func() {
-		cmd.Flags().StringVar(&flags.Format, "format", "table", "Output format: table, json, or yaml.")
+		cmd.Flags().StringVar(&flags.Format, "format", "table", "Output format: table, json, yaml, ha-sensor, beancount, ics, or markdown.")
 	})
 	addSharedGlobalFlag(cmd, "profile", func() {
 		cmd.Flags().StringVar(&flags.Profile, "profile", "", "Profile name for saved local defaults.")
@@ -46,9 +79,24 @@ func addGlobalFlags(cmd *cobra.Command, flags *globalFlags) {
 	addSharedGlobalFlag(cmd, "address", func() {
 		cmd.Flags().StringVar(&flags.Address, "address", "", "Temporary address override for this command. Geocoded to coordinates. Cannot be combined with --lat/--lon.")
 	})
+	addSharedGlobalFlag(cmd, "lat", func() {
+		cmd.Flags().Float64Var(&flags.Lat, "lat", 0, "Latitude override for this command. Provide together with --lon. Cannot be combined with --address.")
+	})
+	addSharedGlobalFlag(cmd, "lon", func() {
+		cmd.Flags().Float64Var(&flags.Lon, "lon", 0, "Longitude override for this command. Provide together with --lat. Cannot be combined with --address.")
+	})
 	addSharedGlobalFlag(cmd, "locale", func() {
 		cmd.Flags().StringVar(&flags.Locale, "locale", "en-FI", "Response locale in BCP-47 format, for example en-FI.")
 	})
+	addSharedGlobalFlag(cmd, "tz", func() {
+		cmd.Flags().StringVar(&flags.TZ, "tz", "", "Write timestamps in this IANA timezone, for example Europe/Helsinki.")
+	})
+	addSharedGlobalFlag(cmd, "time-format", func() {
+		cmd.Flags().StringVar(&flags.TimeFormat, "time-format", "rfc3339", "Timestamp format: rfc3339, unix (seconds), or relative (\"in 2h 15m\").")
+	})
+	addSharedGlobalFlag(cmd, "json-naming", func() {
+		cmd.Flags().StringVar(&flags.JSONNaming, "json-naming", "snake", "Key naming in json and yaml output: snake (delivery_fee) or camel (deliveryFee).")
+	})
 	addSharedGlobalFlag(cmd, "no-color", func() {
 		cmd.Flags().BoolVar(&flags.NoColor, "no-color", false, "Disable ANSI color codes in table output.")
 	})
@@ -58,12 +106,114 @@ func addGlobalFlags(cmd *cobra.Command, flags *globalFlags) {
 	addSharedGlobalFlag(cmd, "wrtoken", func() {
 		cmd.Flags().StringVar(&flags.WRefreshToken, "wrtoken", "", "Wolt refresh token for automatic access token rotation (or payload with refreshToken).")
 	})
+	addSharedGlobalFlag(cmd, "wtoken-stdin", func() {
+		cmd.Flags().BoolVar(&flags.WTokenStdin, "wtoken-stdin", false, "Read the Wolt token from the first line of stdin instead of --wtoken; it is never saved to the config file.")
+	})
 	addSharedGlobalFlag(cmd, "cookie", func() {
 		cmd.Flags().StringArrayVar(&flags.Cookies, "cookie", nil, "HTTP cookie header value to forward (repeatable).")
 	})
+	addSharedGlobalFlag(cmd, "auth-preflight", func() {
+		cmd.Flags().BoolVar(&flags.AuthPreflight, "auth-preflight", false, "Fail cart, checkout, and account commands at once with WOLT_AUTH_REQUIRED when credentials are missing or the token has expired (no network).")
+	})
+	addSharedGlobalFlag(cmd, "user-agent", func() {
+		cmd.Flags().StringVar(&flags.UserAgent, "user-agent", "", "User-Agent header for upstream requests (overrides one set in WOLT_CLIENT_HEADERS).")
+	})
 	addSharedGlobalFlag(cmd, "verbose", func() {
 		cmd.Flags().BoolVar(&flags.Verbose, "verbose", false, "Enable verbose output (prints upstream request trace and detailed error diagnostics).")
 	})
+	addSharedGlobalFlag(cmd, "stats", func() {
+		cmd.Flags().BoolVar(&flags.Stats, "stats", false, "Print upstream request counts per endpoint family to stderr, including requests collapsed into an identical one in flight and memory cache hits.")
+	})
+	addSharedGlobalFlag(cmd, "explain-request", func() {
+		cmd.Flags().BoolVar(&flags.ExplainRequest, "explain-request", false, "Print the resolved profile, coordinates and their source, auth, locale, and request pacing to stderr before running.")
+	})
+	addSharedGlobalFlag(cmd, "explain-only", func() {
+		cmd.Flags().BoolVar(&flags.ExplainOnly, "explain-only", false, "Print what --explain-request would and stop without running the command.")
+	})
+	addSharedGlobalFlag(cmd, "reveal-secrets", func() {
+		cmd.Flags().BoolVar(&flags.RevealSecrets, "reveal-secrets", false, "Show tokens and cookies in verbose traces and error messages instead of redacting them.")
+	})
+	addSharedGlobalFlag(cmd, "save-session", func() {
+		cmd.Flags().StringVar(&flags.SaveSession, "save-session", "", "Write a zip for bug reports with the invocation, request trace, output, and version/OS info; credentials are scrubbed.")
+	})
+	addSharedGlobalFlag(cmd, "output", func() {
+		cmd.Flags().StringVar(&flags.Output, "output", "", "Also write output to this file; sqlite:<path> upserts list rows into a SQLite database instead.")
+	})
+	addSharedGlobalFlag(cmd, "no-pager", func() {
+		cmd.Flags().BoolVar(&flags.NoPager, "no-pager", false, "Never pipe long table output through $PAGER.")
+	})
+	addSharedGlobalFlag(cmd, "max-rows", func() {
+		cmd.Flags().IntVar(&flags.MaxRows, "max-rows", 0, "Show at most n rows per table in table output (0 = all).")
+	})
+	addSharedGlobalFlag(cmd, "columns", func() {
+		cmd.Flags().StringVar(&flags.Columns, "columns", "", "Comma-separated table columns to show, in order (for example name,price).")
+	})
+	addSharedGlobalFlag(cmd, "max-col-width", func() {
+		cmd.Flags().IntVar(&flags.MaxColWidth, "max-col-width", 0, "Truncate table cells longer than n characters (0 = no limit).")
+	})
+	addSharedGlobalFlag(cmd, "col-width", func() {
+		cmd.Flags().StringArrayVar(&flags.ColWidths, "col-width", nil, "Cap a table column as column=width, shortening longer cells in the middle; 0 lifts the cap (repeatable).")
+	})
+	addSharedGlobalFlag(cmd, "full-width", func() {
+		cmd.Flags().BoolVar(&flags.FullWidth, "full-width", false, "Print table cells in full instead of shortening long names and promotions.")
+	})
+	addSharedGlobalFlag(cmd, "layout", func() {
+		cmd.Flags().StringVar(&flags.Layout, "layout", "wide", "Table layout: wide (one line per row) or long (one key/value block per row).")
+	})
+	addSharedGlobalFlag(cmd, "offline", func() {
+		cmd.Flags().BoolVar(&flags.Offline, "offline", false, "Forbid network calls and answer only from responses recorded into WOLT_RECORD_DIR.")
+	})
+	addSharedGlobalFlag(cmd, "read-only", func() {
+		cmd.Flags().BoolVar(&flags.ReadOnly, "read-only", false, "Refuse every upstream request that would change the account (cart, addresses, favorites, payment methods) with WOLT_READ_ONLY.")
+	})
+	addSharedGlobalFlag(cmd, "low-bandwidth", func() {
+		cmd.Flags().BoolVar(&flags.LowBandwidth, "low-bandwidth", false, "Save data on slow links: drop image fields, skip enrichment requests, request small pages, and report bytes received.")
+	})
+	addSharedGlobalFlag(cmd, "report-degradation", func() {
+		cmd.Flags().BoolVar(&flags.ReportDegradation, "report-degradation", false, "List every fallback path the run took, with counts, in meta.degradation (or on stderr for table output).")
+	})
+	addSharedGlobalFlag(cmd, "machine", func() {
+		cmd.Flags().BoolVar(&flags.Machine, "machine", false, "Strict pipeline mode: stdout carries only the JSON/YAML envelope, human text goes to stderr, prompts are disabled.")
+	})
+	addSharedGlobalFlag(cmd, "meta", func() {
+		cmd.Flags().StringArrayVar(&flags.Meta, "meta", nil, "Add key=value to meta.tags of the JSON/YAML envelope, for example --meta job=nightly (repeatable).")
+	})
+	addSharedGlobalFlag(cmd, "expect", func() {
+		cmd.Flags().StringArrayVar(&flags.Expect, "expect", nil, "Assert PATH OP VALUE on the JSON/YAML result, for example 'data.total_items>=1'; exit 3 when it fails (repeatable).")
+	})
+	addSharedGlobalFlag(cmd, "expect-nonempty", func() {
+		cmd.Flags().StringArrayVar(&flags.ExpectNonempty, "expect-nonempty", nil, "Assert that PATH (relative to data unless it starts with meta, warnings, or error) is present and not empty; exit 3 otherwise (repeatable).")
+	})
+}
+
+// applyRevealSecrets switches credential redaction for this run; it is on
+// unless --reveal-secrets is set.
+func applyRevealSecrets(cmd *cobra.Command) {
+	reveal, _ := cmd.Flags().GetBool("reveal-secrets")
+	woltgateway.SetRevealSecrets(reveal)
+}
+
+// applyMachineMode defaults --machine runs to JSON envelopes and rejects table output.
+func applyMachineMode(cmd *cobra.Command) error {
+	if !machineMode(cmd) {
+		return nil
+	}
+	formatFlag := cmd.Flags().Lookup("format")
+	if formatFlag == nil {
+		return nil
+	}
+	if !formatFlag.Changed {
+		return cmd.Flags().Set("format", string(output.FormatJSON))
+	}
+	if strings.EqualFold(strings.TrimSpace(formatFlag.Value.String()), string(output.FormatTable)) {
+		return fmt.Errorf("--machine cannot be combined with --format table")
+	}
+	return nil
+}
+
+func machineMode(cmd *cobra.Command) bool {
+	machine, _ := cmd.Flags().GetBool("machine")
+	return machine
 }
 
 func addSharedGlobalFlag(cmd *cobra.Command, name string, register func()) {
@@ -100,7 +250,53 @@ func resolveProfileLocation(
 	auth *woltgateway.AuthContext,
 	cmd *cobra.Command,
 ) (domain.Location, string, error) {
-	return resolveLocation(ctx, deps, nil, nil, address, profileName, format, locale, outputPath, auth, cmd)
+	lat, lon := coordinateFlags(cmd)
+	return resolveLocation(ctx, deps, lat, lon, address, profileName, format, locale, outputPath, auth, cmd)
+}
+
+// coordinateFlags returns the --lat and --lon values set on cmd; unset flags
+// are nil.
+func coordinateFlags(cmd *cobra.Command) (*float64, *float64) {
+	if cmd == nil {
+		return nil, nil
+	}
+	read := func(name string) *float64 {
+		if !cmd.Flags().Changed(name) {
+			return nil
+		}
+		value, err := cmd.Flags().GetFloat64(name)
+		if err != nil {
+			return nil
+		}
+		return &value
+	}
+	return read("lat"), read("lon")
+}
+
+// locationOverride resolves --address or --lat/--lon for commands that fall
+// back to the account location on their own. A nil location means neither
+// was given; errors come with the error code to report them under.
+func locationOverride(ctx context.Context, deps Dependencies, cmd *cobra.Command, address string) (*domain.Location, string, error) {
+	lat, lon := coordinateFlags(cmd)
+	address = strings.TrimSpace(address)
+	switch {
+	case address != "" && (lat != nil || lon != nil):
+		return nil, "WOLT_INVALID_ARGUMENT", errors.New(addressWithCoordinatesMessage)
+	case address != "":
+		if deps.Location == nil {
+			return nil, "WOLT_LOCATION_RESOLVE_ERROR", errors.New("location resolver is not available")
+		}
+		location, _, err := geocodeAddress(ctx, deps, address)
+		if err != nil {
+			return nil, locationErrorCode(err), err
+		}
+		return &location, "", nil
+	case lat == nil && lon == nil:
+		return nil, "", nil
+	case lat == nil || lon == nil:
+		return nil, "WOLT_INVALID_ARGUMENT", errors.New(partialCoordinatesMessage)
+	}
+	return &domain.Location{Lat: *lat, Lon: *lon}, "", nil
 }
 
 func parseOutputFormat(format string) (output.Format, error) {
@@ -108,6 +304,13 @@ func parseOutputFormat(format string) (output.Format, error) {
 }
 
 func writeTable(cmd *cobra.Command, text string, outputPath string) error {
+	text, err := applyTableView(cmd, text)
+	if err != nil {
+		return err
+	}
+	if outputPath == "" && pageTable(cmd, text) {
+		return nil
+	}
 	if err := output.WriteOutput(cmd.OutOrStdout(), text, outputPath); err != nil {
 		return err
 	}
@@ -115,14 +318,40 @@ func writeTable(cmd *cobra.Command, text string, outputPath string) error {
 }
 
 func writeMachinePayload(cmd *cobra.Command, env output.Envelope, format output.Format, outputPath string) error {
-	rendered, err := output.RenderPayload(env, format)
+	env.Warnings = append(env.Warnings, capabilityNoticesFromContext(cmd.Context()).warnings()...)
+	env.Warnings = append(env.Warnings, geocodeNoticesFromContext(cmd.Context()).warnings()...)
+	env.Warnings = append(env.Warnings, payloadAnomalyWarnings(cmd.Context())...)
+	if source := localeSourceFromContext(cmd.Context()); source != "" && env.Meta != nil {
+		env.Meta["locale_source"] = source
+	}
+	annotateEnvelopeMeta(cmd.Context(), env)
+	annotateDegradation(cmd, env)
+	if lowBandwidth(cmd) {
+		stripImageFields(env.Data)
+		if env.Meta != nil {
+			env.Meta["transfer"] = transferSummary(woltgateway.RequestTimingsFromContext(cmd.Context()))
+		}
+	}
+	if format == output.FormatHASensor {
+		return writeHASensor(cmd, env, outputPath)
+	}
+	if format == output.FormatBeancount {
+		return writeBeancountEnvelope(cmd, env, outputPath)
+	}
+	if format == output.FormatICS {
+		return writeICSEnvelope(cmd, env, outputPath)
+	}
+	if format == output.FormatMarkdown {
+		return writeMarkdownEnvelope(cmd, env, outputPath)
+	}
+	rendered, err := output.RenderPayload(env.WithKeyNaming(jsonNaming(cmd)), format)
 	if err != nil {
 		return err
 	}
 	if err := output.WriteOutput(cmd.OutOrStdout(), rendered, outputPath); err != nil {
 		return err
 	}
-	return nil
+	return checkExpectations(cmd, env)
 }
 
 func emitError(
@@ -134,6 +363,7 @@ func emitError(
 	code string,
 	message string,
 ) error {
+	message = woltgateway.Redact(message)
 	if format == output.FormatTable {
 		if err := output.WriteOutput(cmd.OutOrStdout(), message, outputPath); err != nil {
 			return err
@@ -173,7 +403,7 @@ func resolveLocation(
 				locale,
 				outputPath,
 				"WOLT_INVALID_ARGUMENT",
-				"Do not combine --address with --lat/--lon. Use either --address or both --lat and --lon.",
+				addressWithCoordinatesMessage,
 			)
 		}
 		if deps.Location == nil {
@@ -187,7 +417,7 @@ func resolveLocation(
 				"Location resolver is not available.",
 			)
 		}
-		location, err := deps.Location.Get(ctx, resolvedAddress)
+		location, _, err := geocodeAddress(ctx, deps, resolvedAddress)
 		if err != nil {
 			return domain.Location{}, "", emitError(
 				cmd,
@@ -195,7 +425,7 @@ func resolveLocation(
 				resolveProfileLabel(profileName),
 				locale,
 				outputPath,
-				"WOLT_LOCATION_RESOLVE_ERROR",
+				locationErrorCode(err),
 				err.Error(),
 			)
 		}
@@ -207,10 +437,16 @@ func resolveLocation(
 		if err != nil {
 			return domain.Location{}, "", profileError(err, format, profileName, locale, outputPath, cmd)
 		}
+		if profile.Travel != nil && profile.Location != (domain.Location{}) {
+			return profile.Location, profile.Name, nil
+		}
 		location, locationErr := resolveAccountLocation(ctx, deps, profile, auth)
 		if locationErr == nil {
 			return location, profile.Name, nil
 		}
+		if errors.Is(locationErr, domain.ErrOffline) {
+			return domain.Location{}, "", emitError(cmd, format, profile.Name, locale, outputPath, "WOLT_OFFLINE", offlineErrorMessage)
+		}
 		return domain.Location{}, "", emitError(
 			cmd,
 			format,
@@ -230,7 +466,7 @@ func resolveLocation(
 			locale,
 			outputPath,
 			"WOLT_INVALID_ARGUMENT",
-			"Both --lat and --lon must be provided together, or omit both to use Wolt account address.",
+			partialCoordinatesMessage,
 		)
 	}
 
@@ -257,6 +493,15 @@ func emitUpstreamError(
 	if err == nil {
 		err = woltgateway.ErrUpstream
 	}
+	if errors.Is(err, domain.ErrOffline) {
+		return emitOfflineError(cmd, format, profile, locale, outputPath, verbose, err)
+	}
+	if errors.Is(err, woltgateway.ErrReadOnly) {
+		return emitError(cmd, format, profile, locale, outputPath, "WOLT_READ_ONLY", readOnlyErrorMessage)
+	}
+	if errors.Is(err, woltgateway.ErrUnsupportedInRegion) {
+		return emitError(cmd, format, profile, locale, outputPath, "WOLT_UNSUPPORTED_IN_REGION", "this endpoint is not available in the profile's region (answered 410 Gone); run wolt status to re-check")
+	}
 	if verbose {
 		return emitError(cmd, format, profile, locale, outputPath, "WOLT_UPSTREAM_ERROR", err.Error())
 	}
@@ -318,6 +563,7 @@ func buildAuthContext(flags globalFlags) woltgateway.AuthContext {
 	if strings.TrimSpace(auth.RefreshToken) == "" {
 		auth.RefreshToken = extractRefreshTokenFromCookieInputs(auth.Cookies)
 	}
+	woltgateway.RememberAuthSecrets(auth)
 	return auth
 }
 
@@ -348,6 +594,7 @@ func buildAuthContextWithProfile(ctx context.Context, deps Dependencies, flags g
 	if strings.TrimSpace(auth.RefreshToken) == "" {
 		auth.RefreshToken = extractRefreshTokenFromCookieInputs(auth.Cookies)
 	}
+	woltgateway.RememberAuthSecrets(auth)
 	return auth
 }
 
@@ -462,12 +709,43 @@ func refreshAuthContext(
 		auth.RefreshToken = candidate
 	}
 	warnings = append(warnings, "access token refreshed automatically")
+	if credentialsInjected(ctx) {
+		return true, warnings, nil
+	}
 	if err := upsertProfileTokens(ctx, deps, selectedProfile, auth.WToken, auth.RefreshToken); err != nil {
 		warnings = append(warnings, "failed to persist rotated tokens in profile config")
 	}
 	return true, warnings, nil
 }
 
+// gatewayTokenRefresher is implemented by gateways that refresh the access
+// token and retry once when an authenticated request fails with 401.
+type gatewayTokenRefresher interface {
+	SetTokenRefreshHandler(handler woltgateway.TokenRefreshHandler)
+	RotatedAuth(auth woltgateway.AuthContext) (woltgateway.AuthContext, bool)
+}
+
+// attachTokenRefreshHandler saves tokens the gateway rotates during this run
+// into the selected profile, whichever command triggered the refresh, unless
+// the run's credentials were injected from the environment or stdin.
+func attachTokenRefreshHandler(cmd *cobra.Command, deps Dependencies) {
+	refresher, ok := deps.Wolt.(gatewayTokenRefresher)
+	if !ok {
+		return
+	}
+	profileName, _ := cmd.Flags().GetString("profile")
+	stderr := cmd.ErrOrStderr()
+	injected := credentialsInjected(cmd.Context())
+	refresher.SetTokenRefreshHandler(func(ctx context.Context, _ woltgateway.AuthContext, result woltgateway.TokenRefreshResult) {
+		if injected {
+			return
+		}
+		if err := upsertProfileTokens(ctx, deps, profileName, result.AccessToken, result.RefreshToken); err != nil {
+			_, _ = fmt.Fprintf(stderr, "warning: failed to persist rotated tokens in profile config: %v\n", err)
+		}
+	})
+}
+
 func invokeWithAuthAutoRefresh[T any](
 	ctx context.Context,
 	deps Dependencies,
@@ -481,7 +759,7 @@ func invokeWithAuthAutoRefresh[T any](
 		return zero, warnings, fmt.Errorf("auth context is nil")
 	}
 	selectedProfile := strings.TrimSpace(flags.Profile)
-	if tokenExpired(auth.WToken, time.Now().UTC(), 30*time.Second) {
+	if tokenExpired(auth.WToken, deps.now().UTC(), 30*time.Second) {
 		_, refreshWarnings, refreshErr := refreshAuthContext(ctx, deps, selectedProfile, auth)
 		warnings = append(warnings, refreshWarnings...)
 		if refreshErr != nil {
@@ -490,6 +768,15 @@ func invokeWithAuthAutoRefresh[T any](
 	}
 
 	result, err := invoke(*auth)
+	if refresher, ok := deps.Wolt.(gatewayTokenRefresher); ok {
+		// The gateway refreshes and retries on 401 itself; adopt what it rotated.
+		if rotated, changed := refresher.RotatedAuth(*auth); changed {
+			auth.WToken = rotated.WToken
+			auth.RefreshToken = rotated.RefreshToken
+			warnings = append(warnings, "access token refreshed automatically")
+		}
+		return result, warnings, err
+	}
 	if err == nil {
 		return result, warnings, nil
 	}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// countryCodePattern matches the ISO 3166-1 alpha-3 codes Wolt uses for
+// venue countries, such as FIN or EST.
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// parseAllowedCountries normalizes --allowed-country values; empty values
+// are skipped, so a single empty value clears the list.
+func parseAllowedCountries(values []string) ([]string, error) {
+	countries := []string{}
+	for _, value := range values {
+		country := strings.ToUpper(strings.TrimSpace(value))
+		if country == "" {
+			continue
+		}
+		if !countryCodePattern.MatchString(country) {
+			return nil, fmt.Errorf("--allowed-country %q must be a three-letter country code such as FIN", value)
+		}
+		countries = append(countries, country)
+	}
+	return dedupeStrings(countries), nil
+}
+
+// guardAllowedCountry refuses a cart or checkout command for a venue outside
+// the profile's allowed_countries. The venue country comes from the basket
+// when there is one, else from the venue details of venueID; a venue whose
+// country cannot be read is refused too. Profiles without the list are never
+// blocked, and --force does not lift it.
+func guardAllowedCountry(
+	cmd *cobra.Command,
+	deps Dependencies,
+	profileFlag string,
+	basket map[string]any,
+	venueID string,
+	format output.Format,
+	profileName string,
+	locale string,
+	outputPath string,
+) error {
+	if deps.Profiles == nil {
+		return nil
+	}
+	profile, err := deps.Profiles.Find(cmd.Context(), profileFlag)
+	if err != nil || len(profile.AllowedCountries) == 0 {
+		return nil
+	}
+	country := strings.ToUpper(strings.TrimSpace(asString(asMap(basket["venue"])["country"])))
+	if country == "" && strings.TrimSpace(venueID) != "" && deps.Wolt != nil {
+		if restaurant, err := deps.Wolt.RestaurantByID(cmd.Context(), strings.TrimSpace(venueID)); err == nil && restaurant != nil {
+			country = strings.ToUpper(strings.TrimSpace(restaurant.Country))
+		}
+	}
+	allowed := strings.Join(profile.AllowedCountries, ", ")
+	if country == "" {
+		return emitError(cmd, format, profileName, locale, outputPath, "WOLT_COUNTRY_NOT_ALLOWED",
+			fmt.Sprintf("venue country is unknown, so it cannot be checked against this profile's allowed_countries %s", allowed))
+	}
+	if slices.Contains(profile.AllowedCountries, country) {
+		return nil
+	}
+	return emitError(cmd, format, profileName, locale, outputPath, "WOLT_COUNTRY_NOT_ALLOWED",
+		fmt.Sprintf("venue is in %s, outside this profile's allowed_countries %s; switch profile (or run wolt travel clear) or update the list with wolt configure --allowed-country", country, allowed))
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	wtokenEnv  = "WOLT_WTOKEN"
+	wrtokenEnv = "WOLT_WRTOKEN"
+)
+
+type injectedCredentialsKey struct{}
+
+// applyInjectedCredentials fills --wtoken and --wrtoken from WOLT_WTOKEN,
+// WOLT_WRTOKEN, or, for --wtoken-stdin, the first line of stdin, so CI jobs
+// never pass secrets as arguments. Flags given on the command line win over
+// the environment. A run with injected credentials never writes tokens to the
+// config file, rotated ones included.
+func applyInjectedCredentials(cmd *cobra.Command) error {
+	wtoken := cmd.Flags().Lookup("wtoken")
+	if wtoken == nil || !isSharedGlobalFlag(wtoken) {
+		return nil
+	}
+	injected := false
+	fromStdin, _ := cmd.Flags().GetBool("wtoken-stdin")
+	switch {
+	case fromStdin && wtoken.Changed:
+		return fmt.Errorf("--wtoken-stdin cannot be combined with --wtoken")
+	case fromStdin:
+		token, err := readSecretLine(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("--wtoken-stdin: %w", err)
+		}
+		if err := wtoken.Value.Set(token); err != nil {
+			return err
+		}
+		injected = true
+	case !wtoken.Changed:
+		if token := strings.TrimSpace(os.Getenv(wtokenEnv)); token != "" {
+			if err := wtoken.Value.Set(token); err != nil {
+				return err
+			}
+			injected = true
+		}
+	}
+	if wrtoken := cmd.Flags().Lookup("wrtoken"); wrtoken != nil && !wrtoken.Changed {
+		if token := strings.TrimSpace(os.Getenv(wrtokenEnv)); token != "" {
+			if err := wrtoken.Value.Set(token); err != nil {
+				return err
+			}
+			injected = true
+		}
+	}
+	if injected {
+		cmd.SetContext(context.WithValue(cmd.Context(), injectedCredentialsKey{}, true))
+	}
+	return nil
+}
+
+// credentialsInjected reports whether this run took its tokens from the
+// environment or stdin.
+func credentialsInjected(ctx context.Context) bool {
+	injected, _ := ctx.Value(injectedCredentialsKey{}).(bool)
+	return injected
+}
+
+// readSecretLine reads stdin up to the first newline one byte at a time, so
+// the rest stays available to flags such as --from-json -.
+func readSecretLine(r io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			line = append(line, buf[0])
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	token := strings.TrimSpace(string(line))
+	if token == "" {
+		return "", fmt.Errorf("no token on stdin")
+	}
+	return token, nil
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyInjectedCredentials(t *testing.T) {
+	run := func(stdin string, args ...string) (globalFlags, *cobra.Command, error) {
+		var flags globalFlags
+		cmd := &cobra.Command{Use: "probe"}
+		addGlobalFlags(cmd, &flags)
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetContext(context.Background())
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("parse flags: %v", err)
+		}
+		return flags, cmd, applyInjectedCredentials(cmd)
+	}
+
+	t.Setenv(wtokenEnv, "env-token")
+	t.Setenv(wrtokenEnv, "env-refresh")
+	flags, cmd, err := run("")
+	if err != nil || flags.WToken != "env-token" || flags.WRefreshToken != "env-refresh" || !credentialsInjected(cmd.Context()) {
+		t.Fatalf("expected tokens from the environment, got %q/%q (err %v)", flags.WToken, flags.WRefreshToken, err)
+	}
+	flags, _, _ = run("", "--wtoken", "flag-token")
+	if flags.WToken != "flag-token" {
+		t.Fatalf("expected --wtoken to win over %s, got %q", wtokenEnv, flags.WToken)
+	}
+
+	t.Setenv(wtokenEnv, "")
+	t.Setenv(wrtokenEnv, "")
+	flags, cmd, err = run("stdin-token\n{\"count\":2}\n", "--wtoken-stdin")
+	if err != nil || flags.WToken != "stdin-token" {
+		t.Fatalf("expected the first stdin line as token, got %q (err %v)", flags.WToken, err)
+	}
+	if rest, _ := io.ReadAll(cmd.InOrStdin()); string(rest) != "{\"count\":2}\n" {
+		t.Fatalf("expected the rest of stdin to stay unread, got %q", rest)
+	}
+	if _, _, err := run("stdin-token\n", "--wtoken-stdin", "--wtoken", "flag-token"); err == nil {
+		t.Fatal("expected --wtoken-stdin with --wtoken to fail")
+	}
+	if _, _, err := run("\n", "--wtoken-stdin"); err == nil {
+		t.Fatal("expected an empty stdin line to fail")
+	}
+	flags, cmd, _ = run("")
+	if flags.WToken != "" || credentialsInjected(cmd.Context()) {
+		t.Fatalf("expected no injected credentials, got %q", flags.WToken)
+	}
+}
+
+func TestRefreshAuthContextDoesNotPersistInjectedTokens(t *testing.T) {
+	config := &testConfigManager{cfg: domain.Config{Profiles: []domain.Profile{{Name: "default", IsDefault: true, WToken: "saved"}}}}
+	deps := Dependencies{
+		Wolt: &testWoltAPI{
+			refreshAccessTokenFn: func(context.Context, string, woltgateway.AuthContext) (woltgateway.TokenRefreshResult, error) {
+				return woltgateway.TokenRefreshResult{AccessToken: "rotated", RefreshToken: "refresh-2"}, nil
+			},
+		},
+		Config: config,
+	}
+	auth := &woltgateway.AuthContext{WToken: "expired", RefreshToken: "refresh-1"}
+	ctx := context.WithValue(context.Background(), injectedCredentialsKey{}, true)
+	refreshed, _, err := refreshAuthContext(ctx, deps, "default", auth)
+	if err != nil || !refreshed || auth.WToken != "rotated" {
+		t.Fatalf("expected an in-memory refresh, got %v %q (err %v)", refreshed, auth.WToken, err)
+	}
+	if got := config.cfg.Profiles[0].WToken; got != "saved" {
+		t.Fatalf("expected injected tokens to stay out of the config, got %q", got)
+	}
+}
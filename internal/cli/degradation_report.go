@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// fallbackPaths names the degraded routes a run can take, as the endpoint
+// that failed or came back incomplete and what answered in its place.
+var fallbackPaths = map[string][2]string{
+	"venue_catalog_static":     {"venue catalog", "static venue page"},
+	"restaurant_static":        {"restaurant endpoint", "static venue page"},
+	"item_assortment":          {"item endpoint", "assortment"},
+	"item_venue_content":       {"item endpoint", "venue content"},
+	"assortment_venue_content": {"assortment", "venue content"},
+	"search_catalog":           {"search endpoint", "catalog items"},
+	"front_page_sections":      {"front page", "sections endpoint"},
+}
+
+type degradationReportKey struct{}
+
+// degradationReport counts the fallback paths a run took, in first-seen order.
+type degradationReport struct {
+	mu     sync.Mutex
+	paths  []string
+	counts map[string]int
+}
+
+func withDegradationReport(ctx context.Context) (context.Context, *degradationReport) {
+	report := &degradationReport{counts: map[string]int{}}
+	return context.WithValue(ctx, degradationReportKey{}, report), report
+}
+
+func degradationReportFromContext(ctx context.Context) *degradationReport {
+	if ctx == nil {
+		return nil
+	}
+	report, _ := ctx.Value(degradationReportKey{}).(*degradationReport)
+	return report
+}
+
+// recordFallback notes one use of a fallbackPaths entry; it is a no-op outside
+// a tracked run.
+func recordFallback(ctx context.Context, path string) {
+	report := degradationReportFromContext(ctx)
+	if report == nil {
+		return
+	}
+	report.mu.Lock()
+	defer report.mu.Unlock()
+	if _, seen := report.counts[path]; !seen {
+		report.paths = append(report.paths, path)
+	}
+	report.counts[path]++
+}
+
+func reportDegradation(cmd *cobra.Command) bool {
+	enabled, _ := cmd.Flags().GetBool("report-degradation")
+	return enabled
+}
+
+// degradationSummary is the --report-degradation block: the fallback paths
+// taken with counts, the stages that returned partial results, and the
+// endpoint families skipped as unsupported in the region.
+func degradationSummary(ctx context.Context) map[string]any {
+	fallbacks := []any{}
+	total := 0
+	if report := degradationReportFromContext(ctx); report != nil {
+		report.mu.Lock()
+		for _, path := range report.paths {
+			route := fallbackPaths[path]
+			fallbacks = append(fallbacks, map[string]any{
+				"path":  path,
+				"from":  route[0],
+				"to":    route[1],
+				"count": report.counts[path],
+			})
+			total += report.counts[path]
+		}
+		report.mu.Unlock()
+	}
+	partial := []any{}
+	if failures := partialFailuresFromContext(ctx); failures != nil {
+		failures.mu.Lock()
+		for _, stage := range failures.stages {
+			partial = append(partial, map[string]any{"stage": stage, "count": failures.counts[stage]})
+		}
+		failures.mu.Unlock()
+	}
+	skipped := []any{}
+	if notices := capabilityNoticesFromContext(ctx); notices != nil {
+		notices.mu.Lock()
+		for _, family := range notices.families {
+			skipped = append(skipped, family)
+		}
+		notices.mu.Unlock()
+	}
+	return map[string]any{
+		"degraded":         total > 0 || len(partial) > 0 || len(skipped) > 0,
+		"fallback_count":   total,
+		"fallbacks":        fallbacks,
+		"partial_failures": partial,
+		"skipped_families": skipped,
+	}
+}
+
+// annotateDegradation adds meta.degradation to env under --report-degradation.
+func annotateDegradation(cmd *cobra.Command, env output.Envelope) {
+	if env.Meta == nil || !reportDegradation(cmd) {
+		return
+	}
+	env.Meta["degradation"] = degradationSummary(cmd.Context())
+}
+
+// writeDegradationSummary prints the fallbacks of a --report-degradation run
+// with table output; machine formats carry them in meta.degradation.
+func writeDegradationSummary(ctx context.Context, out io.Writer, cmd *cobra.Command) {
+	if format, _ := cmd.Flags().GetString("format"); !strings.EqualFold(format, string(output.FormatTable)) {
+		return
+	}
+	summary := degradationSummary(ctx)
+	parts := []string{fmt.Sprintf("fallbacks=%d", summary["fallback_count"])}
+	for _, value := range asSlice(summary["fallbacks"]) {
+		fallback := asMap(value)
+		parts = append(parts, fmt.Sprintf("%s=%d", fallback["path"], fallback["count"]))
+	}
+	for _, value := range asSlice(summary["partial_failures"]) {
+		failure := asMap(value)
+		parts = append(parts, fmt.Sprintf("partial(%s)=%d", failure["stage"], failure["count"]))
+	}
+	for _, family := range asSlice(summary["skipped_families"]) {
+		parts = append(parts, fmt.Sprintf("skipped(%s)", family))
+	}
+	_, _ = fmt.Fprintf(out, "[degradation] %s\n", strings.Join(parts, " "))
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDegradationSummaryFoldsInPartialFailuresAndSkippedFamilies(t *testing.T) {
+	ctx, _ := withPartialFailures(context.Background())
+	ctx, notices := withCapabilityNotices(ctx)
+	ctx, _ = withDegradationReport(ctx)
+
+	summary := degradationSummary(ctx)
+	if summary["degraded"] != false || summary["fallback_count"] != 0 {
+		t.Fatalf("expected a clean run, got %v", summary)
+	}
+
+	recordFallback(ctx, "item_assortment")
+	recordFallback(ctx, "item_assortment")
+	recordPartialFailure(ctx, "venue content pagination", errors.New("boom"))
+	notices.add("wolt_plus")
+	summary = degradationSummary(ctx)
+	fallbacks := asSlice(summary["fallbacks"])
+	if summary["degraded"] != true || summary["fallback_count"] != 2 || len(fallbacks) != 1 {
+		t.Fatalf("unexpected summary: %v", summary)
+	}
+	if fallback := asMap(fallbacks[0]); fallback["from"] != "item endpoint" || fallback["count"] != 2 {
+		t.Fatalf("unexpected fallback: %v", fallback)
+	}
+	if partial := asSlice(summary["partial_failures"]); len(partial) != 1 || asMap(partial[0])["stage"] != "venue content pagination" {
+		t.Fatalf("unexpected partial failures: %v", partial)
+	}
+	if skipped := asSlice(summary["skipped_families"]); len(skipped) != 1 || skipped[0] != "wolt_plus" {
+		t.Fatalf("unexpected skipped families: %v", skipped)
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"regexp"
+	"syscall"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
 	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/spf13/cobra"
 )
 
 var unknownCommandPattern = regexp.MustCompile(`unknown command "([^"]+)"`)
@@ -37,19 +41,115 @@ type Dependencies struct {
 	Location LocationResolver
 	Config   ConfigManager
 	Version  string
+	// Clock and Random default to the system clock and random source.
+	Clock  Clock
+	Random Random
 }
 
 var errVersionShown = fmt.Errorf("version shown")
 
+// exitCodeInterrupted follows the shell convention of 128 + SIGINT.
+const exitCodeInterrupted = 130
+
+var notifyInterrupt = func(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+}
+
 // Execute runs the CLI with injected dependencies.
+// An interrupt cancels in-flight requests; commands still write what they collected
+// (marked partial) before Execute returns exitCodeInterrupted.
 func Execute(ctx context.Context, args []string, deps Dependencies, stdout io.Writer, stderr io.Writer) int {
+	runCtx, stop := notifyInterrupt(ctx)
+	defer stop()
+	// Restore default signal handling so a second Ctrl-C terminates immediately.
+	context.AfterFunc(runCtx, stop)
+
+	code := executeChain(withRunID(runCtx), args, deps, stdout, stderr)
+	if runCtx.Err() != nil && ctx.Err() == nil {
+		_, _ = fmt.Fprintln(stderr, "interrupted; output contains partial results")
+		return exitCodeInterrupted
+	}
+	return code
+}
+
+func executeChain(ctx context.Context, args []string, deps Dependencies, stdout io.Writer, stderr io.Writer) int {
+	stages := splitChainArgs(NewRootCommand(deps), args)
+	if len(stages) == 1 {
+		return executeStage(ctx, stages[0], deps, stdout, stderr)
+	}
+
+	var carried []string
+	for i, stageArgs := range stages {
+		stageArgs = append(append([]string{}, stageArgs...), carried...)
+		if len(stageArgs) == 0 {
+			_, _ = fmt.Fprintln(stderr, chainStageError(i+1, "command is empty"))
+			return 2
+		}
+		if i == len(stages)-1 {
+			return executeStage(ctx, stageArgs, deps, stdout, stderr)
+		}
+		stageCtx, selection := withChainSelection(ctx)
+		// Intermediate stages report to stderr so stdout only carries the final result.
+		if code := executeStage(stageCtx, stageArgs, deps, stderr, stderr); code != 0 || ctx.Err() != nil {
+			return code
+		}
+		if !selection.Picked {
+			_, _ = fmt.Fprintln(stderr, chainStageError(i+1, "command did not select a row; add --pick-first"))
+			return 2
+		}
+		carried = selection.IDs
+	}
+	return 0
+}
+
+func executeStage(ctx context.Context, args []string, deps Dependencies, stdout io.Writer, stderr io.Writer) int {
 	cmd := NewRootCommand(deps)
 	cmd.SetOut(stdout)
 	cmd.SetErr(stderr)
 	cmd.SetArgs(args)
+	if name, path, ok := findPlugin(cmd, args); ok {
+		return runPlugin(ctx, deps, name, path, args[1:], cmd.InOrStdin(), stdout, stderr)
+	}
 
-	err := cmd.ExecuteContext(ctx)
-	if err == nil || err == errVersionShown {
+	ctx, _ = withPartialFailures(ctx)
+	ctx, _ = withCapabilityNotices(ctx)
+	ctx, _ = withGeocodeNotices(ctx)
+	ctx, _ = withDegradationReport(ctx)
+	ctx = withHookArgs(ctx, args)
+	ctx = withClock(ctx, deps)
+	ctx = woltgateway.WithPayloadAnomalies(ctx, &woltgateway.PayloadAnomalies{})
+	timings := &woltgateway.RequestTimings{}
+	executed, err := cmd.ExecuteContextC(woltgateway.WithRequestTimings(ctx, timings))
+	recorder := sessionRecorderFrom(executed)
+	if recorder != nil {
+		stderr = io.MultiWriter(stderr, &recorder.stderr)
+	}
+	if executed != nil {
+		if verbose, _ := executed.Flags().GetBool("verbose"); verbose {
+			writeRequestTimingSummary(stderr, timings)
+		}
+		if stats, _ := executed.Flags().GetBool("stats"); stats {
+			writeRequestStats(stderr, timings, deps.Wolt)
+		}
+		if lowBandwidth(executed) {
+			writeTransferSummary(stderr, timings)
+		}
+		if reportDegradation(executed) {
+			writeDegradationSummary(ctx, stderr, executed)
+		}
+	}
+	code := stageExitCode(cmd, args, err, stderr)
+	runPostCommandHooks(executed, deps, args, code, stderr)
+	if recorder != nil {
+		finishSaveSession(executed, deps, args, code, stderr)
+	}
+	return code
+}
+
+// stageExitCode maps the error of one executed stage to its exit code,
+// printing errors no command reported itself.
+func stageExitCode(cmd *cobra.Command, args []string, err error, stderr io.Writer) int {
+	if err == nil || err == errVersionShown || err == errExplainShown {
 		return 0
 	}
 	var controlled *exitError
@@ -58,11 +158,17 @@ func Execute(ctx context.Context, args []string, deps Dependencies, stdout io.Wr
 	}
 
 	if matches := unknownCommandPattern.FindStringSubmatch(err.Error()); len(matches) > 1 {
-		_, _ = fmt.Fprintf(stderr, "No such command '%s'\n", matches[1])
+		if !emitArgumentError(cmd, args, fmt.Sprintf("No such command '%s'", matches[1])) {
+			_, _ = fmt.Fprintf(stderr, "No such command '%s'\n", matches[1])
+		}
 		return 2
 	}
 
-	if msg := err.Error(); msg != "" {
+	msg := woltgateway.Redact(err.Error())
+	if emitArgumentError(cmd, args, msg) {
+		return 1
+	}
+	if msg != "" {
 		_, _ = fmt.Fprintln(stderr, msg)
 	}
 	return 1
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+type interruptingWoltAPI struct {
+	testWoltAPI
+	interrupt func()
+}
+
+func (m *interruptingWoltAPI) Sections(context.Context, domain.Location) ([]domain.Section, error) {
+	return []domain.Section{
+		{
+			Name:  "popular",
+			Title: "Popular",
+			Items: []domain.Item{
+				{
+					Title: "Burger Place",
+					Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place", Name: "Burger Place", Promotions: []any{map[string]any{"text": "Free delivery"}}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (m *interruptingWoltAPI) VenuePageDynamic(ctx context.Context, _ string, _ woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+	m.interrupt()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestExecuteFlushesPartialOutputOnInterrupt(t *testing.T) {
+	api := &interruptingWoltAPI{}
+	original := notifyInterrupt
+	notifyInterrupt = func(ctx context.Context) (context.Context, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(ctx)
+		api.interrupt = cancel
+		return ctx, cancel
+	}
+	defer func() { notifyInterrupt = original }()
+
+	deps := Dependencies{
+		Wolt:     api,
+		Profiles: &testProfiles{profile: domain.Profile{Name: "default", Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Config:   &testConfigManager{},
+		Version:  "1.1.1",
+	}
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	code := Execute(context.Background(), []string{"discover", "feed", "--lat", "60.1", "--lon", "24.9", "--format", "json"}, deps, &stdout, &stderr)
+	if code != exitCodeInterrupted {
+		t.Fatalf("expected exit %d, got %d\nstderr:\n%s", exitCodeInterrupted, code, stderr.String())
+	}
+	var envelope map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+		t.Fatalf("expected flushed JSON envelope, got %q: %v", stdout.String(), err)
+	}
+	if asMap(envelope["data"])["partial"] != true {
+		t.Fatalf("expected partial=true after interrupt, got %v", envelope["data"])
+	}
+	if !strings.Contains(stderr.String(), "interrupted") {
+		t.Fatalf("expected interrupt notice on stderr, got %q", stderr.String())
+	}
+}
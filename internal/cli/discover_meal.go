@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+// mealNow selects the preset whose window covers the local time.
+const mealNow = "now"
+
+var mealNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// mealPreset is a named local time window with the venue tags that suit it.
+// From and To are minutes after midnight; a window may wrap past midnight.
+type mealPreset struct {
+	Name string
+	From int
+	To   int
+	Tags []string
+}
+
+// defaultMealPresets cover the whole day so "now" always lands in one.
+var defaultMealPresets = []mealPreset{
+	{Name: "breakfast", From: 5 * 60, To: 11 * 60, Tags: []string{"breakfast", "brunch", "bakery", "cafe", "coffee", "pastry", "bagel"}},
+	{Name: "lunch", From: 11 * 60, To: 16 * 60, Tags: []string{"lunch", "salad", "sandwich", "soup", "bowl", "poke", "burger", "pizza", "sushi", "asian", "kebab", "wrap"}},
+	{Name: "dinner", From: 16 * 60, To: 22 * 60, Tags: []string{"pizza", "burger", "sushi", "asian", "indian", "italian", "thai", "chinese", "mexican", "steak", "ramen", "korean", "vietnamese", "nepalese", "middle-eastern"}},
+	{Name: "late", From: 22 * 60, To: 5 * 60, Tags: []string{"pizza", "burger", "kebab", "fast-food", "fries", "hot-dog", "snacks"}},
+}
+
+// mealSelection is a preset resolved against a reference time. Outside the
+// window, OpensAt is the next time the window starts.
+type mealSelection struct {
+	Preset   mealPreset
+	At       time.Time
+	InWindow bool
+	OpensAt  time.Time
+}
+
+func (p mealPreset) contains(minute int) bool {
+	if p.From <= p.To {
+		return minute >= p.From && minute < p.To
+	}
+	return minute >= p.From || minute < p.To
+}
+
+func formatMealClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func parseMealClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("meal time %q must be HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// mealPresets returns the built-in presets with profile overrides applied,
+// ordered by window start.
+func mealPresets(overrides map[string]domain.MealPreset) []mealPreset {
+	byName := map[string]mealPreset{}
+	for _, preset := range defaultMealPresets {
+		byName[preset.Name] = preset
+	}
+	for name, override := range overrides {
+		from, fromErr := parseMealClock(override.From)
+		to, toErr := parseMealClock(override.To)
+		if fromErr != nil || toErr != nil {
+			continue
+		}
+		tags := override.Tags
+		if len(tags) == 0 {
+			tags = byName[name].Tags
+		}
+		byName[name] = mealPreset{Name: name, From: from, To: to, Tags: tags}
+	}
+	presets := make([]mealPreset, 0, len(byName))
+	for _, preset := range byName {
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool {
+		if presets[i].From != presets[j].From {
+			return presets[i].From < presets[j].From
+		}
+		return presets[i].Name < presets[j].Name
+	})
+	return presets
+}
+
+// selectMealPreset resolves name ("now" picks by the local time of at) and
+// reports whether at falls inside its window.
+func selectMealPreset(name string, overrides map[string]domain.MealPreset, at time.Time) (mealSelection, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	presets := mealPresets(overrides)
+	minute := at.Hour()*60 + at.Minute()
+	names := make([]string, 0, len(presets))
+	for _, preset := range presets {
+		names = append(names, preset.Name)
+		if (name == mealNow && preset.contains(minute)) || name == preset.Name {
+			selection := mealSelection{Preset: preset, At: at, InWindow: preset.contains(minute)}
+			if !selection.InWindow {
+				start := time.Date(at.Year(), at.Month(), at.Day(), preset.From/60, preset.From%60, 0, 0, at.Location())
+				if !start.After(at) {
+					start = start.AddDate(0, 0, 1)
+				}
+				selection.OpensAt = start
+			}
+			return selection, nil
+		}
+	}
+	if name == mealNow {
+		return mealSelection{}, fmt.Errorf("no meal preset covers %s; adjust presets with wolt configure --meal-preset", at.Format("15:04"))
+	}
+	return mealSelection{}, fmt.Errorf("invalid --meal value %q; expected one of: %s, %s", name, strings.Join(names, ", "), mealNow)
+}
+
+// parseMealPresetFlag reads NAME=HH:MM-HH:MM[,tag...]; an empty value after
+// "=" removes the override.
+func parseMealPresetFlag(value string) (string, *domain.MealPreset, error) {
+	name, spec, ok := strings.Cut(strings.TrimSpace(value), "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !ok || !mealNamePattern.MatchString(name) || name == mealNow {
+		return "", nil, fmt.Errorf("--meal-preset must be NAME=HH:MM-HH:MM[,tag...], got %q", value)
+	}
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return name, nil, nil
+	}
+	parts := strings.Split(spec, ",")
+	from, to, ok := strings.Cut(parts[0], "-")
+	if !ok {
+		return "", nil, fmt.Errorf("--meal-preset %s needs a HH:MM-HH:MM window", name)
+	}
+	fromMinute, err := parseMealClock(from)
+	if err != nil {
+		return "", nil, err
+	}
+	toMinute, err := parseMealClock(to)
+	if err != nil {
+		return "", nil, err
+	}
+	if fromMinute == toMinute {
+		return "", nil, fmt.Errorf("--meal-preset %s window must not be empty", name)
+	}
+	preset := &domain.MealPreset{From: formatMealClock(fromMinute), To: formatMealClock(toMinute)}
+	for _, tag := range parts[1:] {
+		if tag = normalizeVenueTag(tag); tag != "" {
+			preset.Tags = append(preset.Tags, tag)
+		}
+	}
+	return name, preset, nil
+}
+
+func normalizeVenueTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(tag)
+}
+
+// filterSectionsForMeal keeps venues tagged for the preset. Inside the window
+// venues the upstream marks offline are dropped as well.
+func filterSectionsForMeal(sections []domain.Section, selection mealSelection) []domain.Section {
+	filtered := make([]domain.Section, 0, len(sections))
+	for _, section := range sections {
+		items := make([]domain.Item, 0, len(section.Items))
+		for _, item := range section.Items {
+			if item.Venue == nil || !venueMatchesMealTags(item.Venue.Tags, selection.Preset.Tags) {
+				continue
+			}
+			if selection.InWindow && item.Venue.Online != nil && !*item.Venue.Online {
+				continue
+			}
+			items = append(items, item)
+		}
+		if len(items) == 0 {
+			continue
+		}
+		section.Items = items
+		filtered = append(filtered, section)
+	}
+	return filtered
+}
+
+func venueMatchesMealTags(venueTags []string, mealTags []string) bool {
+	if len(mealTags) == 0 {
+		return true
+	}
+	for _, venueTag := range venueTags {
+		venueTag = normalizeVenueTag(venueTag)
+		for _, mealTag := range mealTags {
+			if strings.Contains(venueTag, normalizeVenueTag(mealTag)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterDiscoverFeedOpenAt keeps feed venues open when the meal window next
+// starts, reading each venue's opening hours.
+func filterDiscoverFeedOpenAt(ctx context.Context, deps Dependencies, data map[string]any, opensAt time.Time) []string {
+	warnings := []string{}
+	sections := make([]any, 0, len(asSlice(data["sections"])))
+	for _, sectionValue := range asSlice(data["sections"]) {
+		section := asMap(sectionValue)
+		rows, rowWarnings := filterVenueRowsOpenAt(ctx, deps, asSlice(section["items"]), opensAt.Format("2006-01-02T15:04"))
+		warnings = append(warnings, rowWarnings...)
+		if len(rows) == 0 {
+			continue
+		}
+		section["items"] = rows
+		sections = append(sections, section)
+	}
+	data["sections"] = sections
+	return warnings
+}
+
+// mealData describes the applied preset in the feed payload.
+func mealData(selection mealSelection) map[string]any {
+	tags := selection.Preset.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	data := map[string]any{
+		"name":       selection.Preset.Name,
+		"from":       formatMealClock(selection.Preset.From),
+		"to":         formatMealClock(selection.Preset.To),
+		"tags":       tags,
+		"at":         selection.At.Format(time.RFC3339),
+		"in_window":  selection.InWindow,
+		"open_check": "online",
+	}
+	if !selection.InWindow {
+		data["open_check"] = "opening_hours"
+		data["opens_at"] = selection.OpensAt.Format(time.RFC3339)
+	}
+	return data
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// runIDEnv lets a pipeline hand its own correlation id to every wolt call.
+const runIDEnv = "WOLT_RUN_ID"
+
+type runIDKey struct{}
+
+type envelopeTagsKey struct{}
+
+// withRunID attaches the run id shared by every chained stage and plugin of
+// one invocation: $WOLT_RUN_ID when set, else a new random id.
+func withRunID(ctx context.Context) context.Context {
+	runID := strings.TrimSpace(os.Getenv(runIDEnv))
+	if runID == "" {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			runID = "run_fallback"
+		} else {
+			runID = "run_" + hex.EncodeToString(buf)
+		}
+	}
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+func runIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
+
+// applyEnvelopeTags validates --meta key=value pairs and keeps them for
+// meta.tags.
+func applyEnvelopeTags(cmd *cobra.Command) error {
+	values, _ := cmd.Flags().GetStringArray("meta")
+	if len(values) == 0 {
+		return nil
+	}
+	tags := make(map[string]any, len(values))
+	for _, value := range values {
+		key, tag, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("--meta expects key=value, got %q", value)
+		}
+		tags[key] = strings.TrimSpace(tag)
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), envelopeTagsKey{}, tags))
+	return nil
+}
+
+// annotateEnvelopeMeta adds the run id and --meta tags to env.
+func annotateEnvelopeMeta(ctx context.Context, env output.Envelope) {
+	if env.Meta == nil || ctx == nil {
+		return
+	}
+	if runID := runIDFromContext(ctx); runID != "" {
+		env.Meta["run_id"] = runID
+	}
+	if tags, _ := ctx.Value(envelopeTagsKey{}).(map[string]any); len(tags) > 0 {
+		env.Meta["tags"] = tags
+	}
+}
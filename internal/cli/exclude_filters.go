@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// excludeFilters holds the repeatable --exclude-* flags. Values are matched
+// case-insensitively after the payload is fetched.
+type excludeFilters struct {
+	Venues   []string
+	Tags     []string
+	Sections []string
+	NoAds    bool
+}
+
+// excludeCounts tallies what each --exclude-* flag removed, for warnings.
+type excludeCounts struct {
+	Venues   int
+	Tags     int
+	Sections int
+	Ads      int
+}
+
+func addExcludeVenueFlag(cmd *cobra.Command, filters *excludeFilters) {
+	cmd.Flags().StringArrayVar(&filters.Venues, "exclude-venue", nil, "Drop a venue by slug or id (repeatable)")
+}
+
+func addExcludeTagFlag(cmd *cobra.Command, filters *excludeFilters) {
+	cmd.Flags().StringArrayVar(&filters.Tags, "exclude-tag", nil, "Drop venues carrying this tag, for example pizza (repeatable)")
+}
+
+func addExcludeSectionFlag(cmd *cobra.Command, filters *excludeFilters) {
+	cmd.Flags().StringArrayVar(&filters.Sections, "exclude-section", nil, "Drop a feed section by name or title (repeatable)")
+}
+
+func addNoAdsFlag(cmd *cobra.Command, filters *excludeFilters) {
+	cmd.Flags().BoolVar(&filters.NoAds, "no-ads", false, "Drop sponsored placements (rows with is_ad)")
+}
+
+func matchesExcluded(values []string, candidates ...string) bool {
+	for _, value := range values {
+		value = normalizeVenueTag(value)
+		if value == "" {
+			continue
+		}
+		for _, candidate := range candidates {
+			if normalizeVenueTag(candidate) == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// excludedVenue reports whether venue is excluded and counts it against the
+// first matching flag, --exclude-venue before --exclude-tag.
+func (f excludeFilters) excludedVenue(venue *domain.Venue, target string, counts *excludeCounts) bool {
+	if matchesExcluded(f.Venues, venue.Slug, domain.NormalizeID(coalesceAny(venue.ID, target))) {
+		counts.Venues++
+		return true
+	}
+	if matchesExcluded(f.Tags, venue.Tags...) {
+		counts.Tags++
+		return true
+	}
+	return false
+}
+
+// excludeSections drops excluded sections and venues from feed sections.
+// Venues inside a dropped section count towards the section filter only.
+func (f excludeFilters) excludeSections(sections []domain.Section) ([]domain.Section, excludeCounts) {
+	counts := excludeCounts{}
+	kept := make([]domain.Section, 0, len(sections))
+	for _, section := range sections {
+		if matchesExcluded(f.Sections, section.Name, section.Title) {
+			counts.Sections++
+			continue
+		}
+		items := make([]domain.Item, 0, len(section.Items))
+		for _, item := range section.Items {
+			if f.NoAds && item.IsAd() {
+				counts.Ads++
+				continue
+			}
+			if item.Venue != nil && f.excludedVenue(item.Venue, item.Link.Target, &counts) {
+				continue
+			}
+			items = append(items, item)
+		}
+		section.Items = items
+		kept = append(kept, section)
+	}
+	return kept, counts
+}
+
+// excludeItems drops excluded venues from catalog items.
+func (f excludeFilters) excludeItems(items []domain.Item) ([]domain.Item, excludeCounts) {
+	counts := excludeCounts{}
+	kept := make([]domain.Item, 0, len(items))
+	for _, item := range items {
+		if item.Venue != nil && f.excludedVenue(item.Venue, item.Link.Target, &counts) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept, counts
+}
+
+// excludeItemRows drops menu item rows that belong to excluded venues.
+func (f excludeFilters) excludeItemRows(rows []any) ([]any, excludeCounts) {
+	counts := excludeCounts{}
+	kept := make([]any, 0, len(rows))
+	for _, value := range rows {
+		row := asMap(value)
+		if matchesExcluded(f.Venues, asString(row["venue_slug"]), asString(row["venue_id"])) {
+			counts.Venues++
+			continue
+		}
+		kept = append(kept, value)
+	}
+	return kept, counts
+}
+
+// warnings reports what each flag in use removed; rows names what
+// --exclude-venue drops, such as "venue(s)" or "item(s)".
+func (f excludeFilters) warnings(counts excludeCounts, rows string) []string {
+	warnings := []string{}
+	if len(f.Venues) > 0 {
+		warnings = append(warnings, fmt.Sprintf("--exclude-venue removed %d %s", counts.Venues, rows))
+	}
+	if len(f.Tags) > 0 {
+		warnings = append(warnings, fmt.Sprintf("--exclude-tag removed %d venue(s)", counts.Tags))
+	}
+	if len(f.Sections) > 0 {
+		warnings = append(warnings, fmt.Sprintf("--exclude-section removed %d section(s)", counts.Sections))
+	}
+	if f.NoAds {
+		warnings = append(warnings, fmt.Sprintf("--no-ads removed %d sponsored venue(s)", counts.Ads))
+	}
+	return warnings
+}
+
+func (f excludeFilters) active() bool {
+	return f.NoAds || len(f.Venues)+len(f.Tags)+len(f.Sections) > 0
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// exitCodeExpectationFailed is returned when the command succeeded but an
+// --expect or --expect-nonempty assertion did not hold.
+const exitCodeExpectationFailed = 3
+
+var expectPattern = regexp.MustCompile(`^\s*([A-Za-z0-9_.\-]+)\s*(==|!=|>=|<=|>|<)\s*(.*?)\s*$`)
+
+type expectationsKey struct{}
+
+// expectation is one assertion on the result envelope. nonEmpty assertions
+// have no operator.
+type expectation struct {
+	raw      string
+	path     []string
+	op       string
+	value    string
+	nonEmpty bool
+}
+
+// applyExpectations parses --expect and --expect-nonempty before the command
+// runs; the assertions are checked when the envelope is written.
+func applyExpectations(cmd *cobra.Command) error {
+	exprs, _ := cmd.Flags().GetStringArray("expect")
+	paths, _ := cmd.Flags().GetStringArray("expect-nonempty")
+	if len(exprs) == 0 && len(paths) == 0 {
+		return nil
+	}
+	if format, _ := cmd.Flags().GetString("format"); !strings.EqualFold(strings.TrimSpace(format), string(output.FormatJSON)) &&
+		!strings.EqualFold(strings.TrimSpace(format), string(output.FormatYAML)) {
+		return fmt.Errorf("--expect and --expect-nonempty need --format json or yaml")
+	}
+	expectations := make([]expectation, 0, len(exprs)+len(paths))
+	for _, expr := range exprs {
+		match := expectPattern.FindStringSubmatch(expr)
+		if match == nil {
+			return fmt.Errorf("--expect %q must look like PATH OP VALUE with OP one of ==, !=, >=, <=, >, <", expr)
+		}
+		expectations = append(expectations, expectation{raw: strings.TrimSpace(expr), path: expectPath(match[1]), op: match[2], value: unquoteExpectValue(match[3])})
+	}
+	for _, path := range paths {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("--expect-nonempty needs a path")
+		}
+		expectations = append(expectations, expectation{raw: "nonempty " + strings.TrimSpace(path), path: expectPath(path), nonEmpty: true})
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), expectationsKey{}, expectations))
+	return nil
+}
+
+// expectPath splits a dotted path. Paths that do not start at an envelope
+// field are relative to data.
+func expectPath(raw string) []string {
+	path := strings.Split(strings.TrimSpace(raw), ".")
+	switch path[0] {
+	case "data", "meta", "warnings", "error":
+		return path
+	}
+	return append([]string{"data"}, path...)
+}
+
+func unquoteExpectValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// checkExpectations evaluates the assertions against a successful envelope
+// and reports each failure on stderr.
+func checkExpectations(cmd *cobra.Command, env output.Envelope) error {
+	expectations, _ := cmd.Context().Value(expectationsKey{}).([]expectation)
+	if len(expectations) == 0 || env.Error != nil {
+		return nil
+	}
+	encoded, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	var document map[string]any
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return err
+	}
+	failed := false
+	for _, expect := range expectations {
+		if reason := expect.evaluate(document); reason != "" {
+			failed = true
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "expect failed: %s (%s)\n", expect.raw, reason)
+		}
+	}
+	if failed {
+		return &exitError{code: exitCodeExpectationFailed}
+	}
+	return nil
+}
+
+// evaluate returns why the assertion does not hold, or "" when it does.
+func (e expectation) evaluate(document map[string]any) string {
+	value, found := lookupExpectPath(document, e.path)
+	if e.nonEmpty {
+		if !found || isEmptyExpectValue(value) {
+			return "empty or missing"
+		}
+		return ""
+	}
+	if !found {
+		return "path not found"
+	}
+	got := formatExpectValue(value)
+	actual, actualIsNumber := value.(float64)
+	expected, err := strconv.ParseFloat(e.value, 64)
+	if actualIsNumber && err == nil {
+		if compareExpectNumbers(actual, e.op, expected) {
+			return ""
+		}
+		return "got " + got
+	}
+	switch e.op {
+	case "==":
+		if got == e.value {
+			return ""
+		}
+	case "!=":
+		if got != e.value {
+			return ""
+		}
+	default:
+		return fmt.Sprintf("got %s, which is not a number", got)
+	}
+	return "got " + got
+}
+
+// lookupExpectPath walks objects by key and arrays by index. A final
+// "length" segment that is not a key yields the size of an array, object,
+// or string.
+func lookupExpectPath(document map[string]any, path []string) (any, bool) {
+	var current any = document
+	for i, segment := range path {
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[segment]
+			if !ok {
+				if segment == "length" && i == len(path)-1 {
+					return float64(len(node)), true
+				}
+				return nil, false
+			}
+			current = next
+		case []any:
+			if segment == "length" && i == len(path)-1 {
+				return float64(len(node)), true
+			}
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		case string:
+			if segment == "length" && i == len(path)-1 {
+				return float64(len([]rune(node))), true
+			}
+			return nil, false
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func isEmptyExpectValue(value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(typed) == ""
+	case []any:
+		return len(typed) == 0
+	case map[string]any:
+		return len(typed) == 0
+	}
+	return false
+}
+
+func compareExpectNumbers(actual float64, op string, expected float64) bool {
+	switch op {
+	case "==":
+		return actual == expected
+	case "!=":
+		return actual != expected
+	case ">=":
+		return actual >= expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	default:
+		return actual < expected
+	}
+}
+
+func formatExpectValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typed)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
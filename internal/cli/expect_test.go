@@ -0,0 +1,33 @@
+package cli
+
+import "testing"
+
+func TestExpectationEvaluate(t *testing.T) {
+	document := map[string]any{
+		"data": map[string]any{
+			"venues": []any{map[string]any{"slug": "burger-place", "rating": 9.1}},
+			"total":  map[string]any{"amount": float64(1250)},
+			"note":   "",
+		},
+		"warnings": []any{},
+	}
+	cases := []struct {
+		expect expectation
+		ok     bool
+	}{
+		{expectation{path: expectPath("venues.0.slug"), op: "==", value: "burger-place"}, true},
+		{expectation{path: expectPath("venues.0.rating"), op: ">", value: "9"}, true},
+		{expectation{path: expectPath("data.venues.length"), op: "==", value: "2"}, false},
+		{expectation{path: expectPath("total.amount"), op: "<=", value: "1000"}, false},
+		{expectation{path: expectPath("venues.0.slug"), op: ">", value: "a"}, false},
+		{expectation{path: expectPath("missing"), op: "!=", value: "x"}, false},
+		{expectation{path: expectPath("venues"), nonEmpty: true}, true},
+		{expectation{path: expectPath("note"), nonEmpty: true}, false},
+		{expectation{path: expectPath("warnings"), nonEmpty: true}, false},
+	}
+	for _, tc := range cases {
+		if reason := tc.expect.evaluate(document); (reason == "") != tc.ok {
+			t.Fatalf("expectation %v: expected ok=%v, got reason %q", tc.expect.path, tc.ok, reason)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// errExplainShown stops an --explain-only run after the explanation was written.
+var errExplainShown = errors.New("explain shown")
+
+type requestMinIntervalReporter interface {
+	RequestMinInterval() time.Duration
+}
+
+// applyExplainRequest writes the inputs the command is about to run with:
+// with --explain-request as [explain] lines on stderr before the run, with
+// --explain-only as the command's own output instead of running it.
+func applyExplainRequest(cmd *cobra.Command, deps Dependencies) error {
+	explain, _ := cmd.Flags().GetBool("explain-request")
+	only, _ := cmd.Flags().GetBool("explain-only")
+	if !explain && !only {
+		return nil
+	}
+	data := explainRequest(cmd, deps)
+	if !only {
+		for _, row := range explainRequestRows(data) {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "[explain] %s: %s (%s)\n", row[0], row[1], row[2])
+		}
+		return nil
+	}
+
+	formatValue, _ := cmd.Flags().GetString("format")
+	format, err := parseOutputFormat(formatValue)
+	if err != nil {
+		return err
+	}
+	outputPath, _ := cmd.Flags().GetString("output")
+	if format == output.FormatTable {
+		table := output.RenderTable("Request: "+asString(data["command"]), []string{"Setting", "Value", "Source"}, explainRequestRows(data))
+		if err := writeTable(cmd, table, outputPath); err != nil {
+			return err
+		}
+		return errExplainShown
+	}
+	locale, _ := cmd.Flags().GetString("locale")
+	profile := resolveProfileLabel(asString(asMap(data["profile"])["name"]))
+	env := output.BuildEnvelope(profile, locale, data, nil, nil)
+	if err := writeMachinePayload(cmd, env, format, outputPath); err != nil {
+		return err
+	}
+	return errExplainShown
+}
+
+// explainRequest resolves the profile, location, credentials, locale, and
+// request pacing the way the commands do. Locating through the Wolt account
+// or --address makes the same lookup the command makes afterwards.
+func explainRequest(cmd *cobra.Command, deps Dependencies) map[string]any {
+	ctx := cmd.Context()
+	flags := globalFlags{}
+	flags.Profile, _ = cmd.Flags().GetString("profile")
+	flags.Address, _ = cmd.Flags().GetString("address")
+	flags.WToken, _ = cmd.Flags().GetString("wtoken")
+	flags.WRefreshToken, _ = cmd.Flags().GetString("wrtoken")
+	flags.Cookies, _ = cmd.Flags().GetStringArray("cookie")
+
+	profileData := map[string]any{"name": nil, "source": "default"}
+	if strings.TrimSpace(flags.Profile) != "" {
+		profileData["source"] = "flag"
+	}
+	var profile domain.Profile
+	profileFound := false
+	if deps.Profiles != nil {
+		found, err := deps.Profiles.Find(ctx, flags.Profile)
+		if err != nil {
+			profileData["error"] = err.Error()
+		} else {
+			profile, profileFound = found, true
+			profileData["name"] = found.Name
+			profileData["travel"] = found.Travel != nil
+		}
+	}
+
+	auth := buildAuthContextWithProfile(ctx, deps, flags)
+	authData := map[string]any{
+		"authenticated": auth.HasCredentials(),
+		"source":        explainAuthSource(cmd, flags, auth),
+		"refresh_token": strings.TrimSpace(auth.RefreshToken) != "",
+		"cookie_count":  len(auth.Cookies),
+		"expires_at":    emptyToNil(tokenExpiryRFC3339(auth.WToken)),
+	}
+	if expiry, ok := tokenExpiry(auth.WToken); ok {
+		authData["expired"] = !expiry.After(deps.now())
+	}
+
+	locationData := explainLocation(cmd, deps, flags.Address, profile, profileFound, &auth)
+
+	locale, _ := cmd.Flags().GetString("locale")
+	localeData := map[string]any{
+		"locale":   locale,
+		"source":   fallbackString(localeSourceFromContext(ctx), localeSourceDefault),
+		"language": resolveAssortmentLanguage(locale),
+	}
+
+	rateLimit := map[string]any{"min_interval_ms": nil}
+	if reporter, ok := deps.Wolt.(requestMinIntervalReporter); ok {
+		rateLimit["min_interval_ms"] = reporter.RequestMinInterval().Milliseconds()
+	}
+	offline, _ := cmd.Flags().GetBool("offline")
+	readOnly, _ := cmd.Flags().GetBool("read-only")
+	rateLimit["offline"] = offline
+	rateLimit["read_only"] = readOnly || (profileFound && profile.ReadOnly)
+
+	return map[string]any{
+		"command":    strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())),
+		"profile":    profileData,
+		"location":   locationData,
+		"auth":       authData,
+		"locale":     localeData,
+		"rate_limit": rateLimit,
+	}
+}
+
+// explainLocation follows resolveLocation: --address, then --lat/--lon, then
+// the travel location, then the Wolt account's saved address.
+func explainLocation(
+	cmd *cobra.Command,
+	deps Dependencies,
+	address string,
+	profile domain.Profile,
+	profileFound bool,
+	auth *woltgateway.AuthContext,
+) map[string]any {
+	ctx := cmd.Context()
+	lat, lon := coordinateFlags(cmd)
+	address = strings.TrimSpace(address)
+	data := map[string]any{"lat": nil, "lon": nil}
+	set := func(location domain.Location) {
+		data["lat"] = location.Lat
+		data["lon"] = location.Lon
+	}
+	switch {
+	case address != "" && (lat != nil || lon != nil):
+		data["source"] = "invalid"
+		data["error"] = addressWithCoordinatesMessage
+	case address != "":
+		data["source"] = "address"
+		data["address"] = address
+		if deps.Location == nil {
+			data["error"] = "location resolver is not available"
+			break
+		}
+		location, matched, err := geocodeAddress(ctx, deps, address)
+		if err != nil {
+			data["error"] = err.Error()
+			break
+		}
+		if matched != address {
+			data["geocoded_address"] = matched
+		}
+		set(location)
+	case lat != nil && lon != nil:
+		data["source"] = "flags"
+		set(domain.Location{Lat: *lat, Lon: *lon})
+	case lat != nil || lon != nil:
+		data["source"] = "invalid"
+		data["error"] = partialCoordinatesMessage
+	case !profileFound:
+		data["source"] = "account"
+		data["error"] = "no profile to read the Wolt account from"
+	case profile.Travel != nil && profile.Location != (domain.Location{}):
+		data["source"] = "travel"
+		data["address"] = profile.Travel.Address
+		set(profile.Location)
+	default:
+		data["source"] = "account"
+		data["wolt_address_id"] = emptyToNil(profile.WoltAddressID)
+		location, err := resolveAccountLocation(ctx, deps, profile, auth)
+		if err != nil {
+			data["error"] = err.Error()
+			break
+		}
+		set(location)
+	}
+	return data
+}
+
+func explainAuthSource(cmd *cobra.Command, flags globalFlags, auth woltgateway.AuthContext) string {
+	switch {
+	case !auth.HasCredentials():
+		return "none"
+	case credentialsInjected(cmd.Context()):
+		if fromStdin, _ := cmd.Flags().GetBool("wtoken-stdin"); fromStdin {
+			return "stdin"
+		}
+		return "environment"
+	case strings.TrimSpace(flags.WToken) != "":
+		return "flag"
+	case len(flags.Cookies) > 0:
+		return "cookie"
+	}
+	return "profile"
+}
+
+func explainRequestRows(data map[string]any) [][]string {
+	profile := asMap(data["profile"])
+	location := asMap(data["location"])
+	auth := asMap(data["auth"])
+	locale := asMap(data["locale"])
+	rateLimit := asMap(data["rate_limit"])
+
+	profileValue := fallbackString(asString(profile["name"]), "-")
+	if message := asString(profile["error"]); message != "" {
+		profileValue = "unavailable: " + message
+	}
+
+	locationValue := "-"
+	if lat, ok := asFloat(location["lat"]); ok {
+		lon, _ := asFloat(location["lon"])
+		locationValue = fmt.Sprintf("%.6f,%.6f", lat, lon)
+	}
+	if message := asString(location["error"]); message != "" {
+		locationValue = "unresolved: " + message
+	}
+	locationSource := asString(location["source"])
+	if address := asString(location["address"]); address != "" {
+		locationSource += " " + address
+	}
+	if matched := asString(location["geocoded_address"]); matched != "" {
+		locationSource += fmt.Sprintf(" (found as %q)", matched)
+	}
+	if addressID := asString(location["wolt_address_id"]); addressID != "" {
+		locationSource += " " + addressID
+	}
+
+	authValue := "no credentials"
+	if asBool(auth["authenticated"]) {
+		parts := []string{"access token"}
+		if asBool(auth["refresh_token"]) {
+			parts = append(parts, "refresh token")
+		}
+		if count := asInt(auth["cookie_count"]); count > 0 {
+			parts = append(parts, fmt.Sprintf("%d cookies", count))
+		}
+		authValue = strings.Join(parts, ", ")
+		if expiresAt := asString(auth["expires_at"]); expiresAt != "" {
+			state := "expires"
+			if asBool(auth["expired"]) {
+				state = "expired"
+			}
+			authValue += ", " + state + " " + expiresAt
+		}
+	}
+
+	pacing := "not reported by the client"
+	if rateLimit["min_interval_ms"] != nil {
+		pacing = fmt.Sprintf("%dms between requests", asInt(rateLimit["min_interval_ms"]))
+	}
+	modes := []string{}
+	if asBool(rateLimit["offline"]) {
+		modes = append(modes, "offline")
+	}
+	if asBool(rateLimit["read_only"]) {
+		modes = append(modes, "read-only")
+	}
+	if len(modes) > 0 {
+		pacing += ", " + strings.Join(modes, ", ")
+	}
+
+	return [][]string{
+		{"Profile", profileValue, asString(profile["source"])},
+		{"Location", locationValue, locationSource},
+		{"Auth", authValue, asString(auth["source"])},
+		{"Locale", fmt.Sprintf("%s (language %s)", asString(locale["locale"]), asString(locale["language"])), asString(locale["source"])},
+		{"Rate limit", pacing, "WOLT_HTTP_MIN_INTERVAL_MS"},
+	}
+}
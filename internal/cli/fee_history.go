@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	feeHistoryCacheFile = "fees.json"
+	feeHistoryCacheKey  = "delivery_fees"
+	// feeHistoryLimit caps the history; the least recently seen venues go first.
+	feeHistoryLimit = 2000
+)
+
+// feeObservation is the last delivery fee seen for a venue and, once it has
+// changed, the value it replaced.
+type feeObservation struct {
+	Amount            int       `json:"amount"`
+	Formatted         string    `json:"formatted_amount,omitempty"`
+	SeenAt            time.Time `json:"seen_at"`
+	PreviousAmount    *int      `json:"previous_amount,omitempty"`
+	PreviousFormatted string    `json:"previous_formatted_amount,omitempty"`
+	ChangedAt         time.Time `json:"changed_at,omitzero"`
+}
+
+// feeHistoryMu serializes read-modify-write cycles of the fee history file.
+var feeHistoryMu sync.Mutex
+
+// annotateFeeTrends compares each venue row's delivery fee with the cached
+// history and records the current fees. Rows of venues seen before get
+// fee_trend (up, down, or same) and previous_delivery_fee; fee_changed_at is
+// set once the fee has changed. The history is an aid, so cache failures
+// leave rows unannotated.
+func annotateFeeTrends(deps Dependencies, rows []any) {
+	feeHistoryMu.Lock()
+	defer feeHistoryMu.Unlock()
+	file, _ := openCLICache(deps, feeHistoryCacheFile)
+	if file == nil {
+		return
+	}
+	history := map[string]feeObservation{}
+	file.Get(feeHistoryCacheKey, 0, deps.now(), &history)
+	now := deps.now().UTC()
+	// A venue listed in several feed sections is compared once per run.
+	observed := map[string]bool{}
+	for _, value := range rows {
+		row := asMap(value)
+		venueID := strings.ToLower(strings.TrimSpace(asString(row["venue_id"])))
+		fee := asMap(row["delivery_fee"])
+		if venueID == "" || fee == nil || fee["amount"] == nil {
+			continue
+		}
+		if seen, done := observed[venueID]; done {
+			if seen {
+				annotateFeeTrend(row, history[venueID])
+			}
+			continue
+		}
+		current := feeObservation{Amount: asInt(fee["amount"]), Formatted: asString(fee["formatted_amount"]), SeenAt: now}
+		previous, seen := history[venueID]
+		observed[venueID] = seen
+		if seen && previous.Amount != current.Amount {
+			amount := previous.Amount
+			current.PreviousAmount = &amount
+			current.PreviousFormatted = previous.Formatted
+			current.ChangedAt = now
+		} else if seen {
+			current.PreviousAmount = previous.PreviousAmount
+			current.PreviousFormatted = previous.PreviousFormatted
+			current.ChangedAt = previous.ChangedAt
+		}
+		if seen {
+			annotateFeeTrend(row, current)
+		}
+		history[venueID] = current
+	}
+	if len(history) > feeHistoryLimit {
+		ids := make([]string, 0, len(history))
+		for id := range history {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return history[ids[i]].SeenAt.After(history[ids[j]].SeenAt) })
+		for _, id := range ids[feeHistoryLimit:] {
+			delete(history, id)
+		}
+	}
+	if len(observed) > 0 && file.Put(feeHistoryCacheKey, history, now) == nil {
+		_ = file.Save()
+	}
+}
+
+func annotateFeeTrend(row map[string]any, observation feeObservation) {
+	if observation.PreviousAmount == nil {
+		row["fee_trend"] = "same"
+		row["previous_delivery_fee"] = map[string]any{"amount": observation.Amount, "formatted_amount": observation.Formatted}
+		return
+	}
+	previous := *observation.PreviousAmount
+	switch {
+	case observation.Amount > previous:
+		row["fee_trend"] = "up"
+	case observation.Amount < previous:
+		row["fee_trend"] = "down"
+	default:
+		row["fee_trend"] = "same"
+	}
+	row["previous_delivery_fee"] = map[string]any{"amount": previous, "formatted_amount": observation.PreviousFormatted}
+	row["fee_changed_at"] = observation.ChangedAt.Format(time.RFC3339)
+}
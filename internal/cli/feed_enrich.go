@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	enrichModeFull         = "full"
+	enrichModeFast         = "fast"
+	enrichModeWoltPlusOnly = "wolt-plus-only"
+)
+
+// feedEnrichment is a parsed --enrich value: which feed rows get per-venue
+// promotion and Wolt+ requests. top limits enrichment to the first top rows
+// of the feed as listed.
+type feedEnrichment struct {
+	mode string
+	top  int
+}
+
+// parseFeedEnrichment reads --enrich: all (or full), none (or fast),
+// wolt-plus-only, or top:N.
+func parseFeedEnrichment(value string) (feedEnrichment, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "all", enrichModeFull:
+		return feedEnrichment{mode: enrichModeFull}, nil
+	case "none", enrichModeFast:
+		return feedEnrichment{mode: enrichModeFast}, nil
+	case enrichModeWoltPlusOnly:
+		return feedEnrichment{mode: enrichModeWoltPlusOnly}, nil
+	}
+	if count, ok := strings.CutPrefix(value, "top:"); ok {
+		top, err := strconv.Atoi(count)
+		if err == nil && top > 0 {
+			return feedEnrichment{mode: enrichModeFull, top: top}, nil
+		}
+	}
+	return feedEnrichment{}, fmt.Errorf("--enrich must be all, none, wolt-plus-only, or top:N with N of at least 1, got %q", value)
+}
+
+// String is the enrichment_mode reported in feed data.
+func (e feedEnrichment) String() string {
+	if e.top > 0 {
+		return fmt.Sprintf("top:%d", e.top)
+	}
+	return e.mode
+}
+
+// skipped reports whether no enrichment request is sent at all.
+func (e feedEnrichment) skipped() bool {
+	return e.mode == enrichModeFast
+}
+
+// promotions reports whether dynamic venue pages are fetched for promotions.
+func (e feedEnrichment) promotions() bool {
+	return e.mode == enrichModeFull
+}
+
+// rows returns the feed rows enrichment covers.
+func (e feedEnrichment) rows(rows []any) []any {
+	if e.skipped() {
+		return nil
+	}
+	if e.top > 0 && len(rows) > e.top {
+		return rows[:e.top]
+	}
+	return rows
+}
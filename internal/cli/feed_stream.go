@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// feedStream writes discover feed --stream output as NDJSON: a feed line with
+// the unenriched envelope, one venue line per enriched venue, then a done line.
+type feedStream struct {
+	encoder  *json.Encoder
+	naming   output.KeyNaming
+	enriched int
+	err      error
+}
+
+func newFeedStream(out io.Writer, naming output.KeyNaming) *feedStream {
+	return &feedStream{encoder: json.NewEncoder(out), naming: naming}
+}
+
+func (s *feedStream) write(line map[string]any) {
+	if s.err == nil {
+		s.err = s.encoder.Encode(output.ApplyKeyNaming(line, s.naming))
+	}
+}
+
+func (s *feedStream) feed(cmd *cobra.Command, env output.Envelope) error {
+	env.Warnings = append(env.Warnings, capabilityNoticesFromContext(cmd.Context()).warnings()...)
+	env.Warnings = append(env.Warnings, geocodeNoticesFromContext(cmd.Context()).warnings()...)
+	env.Warnings = append(env.Warnings, payloadAnomalyWarnings(cmd.Context())...)
+	annotateEnvelopeMeta(cmd.Context(), env)
+	annotateDegradation(cmd, env)
+	s.write(map[string]any{"type": "feed", "envelope": output.StampDataDigest(env.WithKeyNaming(s.naming))})
+	return s.err
+}
+
+// venue reports the enriched fields of every feed row with slug.
+func (s *feedStream) venue(slug string, row map[string]any) {
+	s.enriched++
+	promotions := asSlice(row["promotions"])
+	if promotions == nil {
+		promotions = []any{}
+	}
+	line := map[string]any{
+		"type":       "venue",
+		"slug":       slug,
+		"promotions": promotions,
+		"wolt_plus":  asBool(row["wolt_plus"]),
+	}
+	if sources, ok := row[venueRowSourceKey]; ok {
+		line[venueRowSourceKey] = sources
+	}
+	s.write(line)
+}
+
+func (s *feedStream) done(warnings []string, partial bool) error {
+	s.write(map[string]any{
+		"type":            "done",
+		"enriched_venues": s.enriched,
+		"partial":         partial,
+		"warnings":        warnings,
+	})
+	return s.err
+}
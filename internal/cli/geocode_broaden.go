@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+// houseNumberPattern matches a street number, optionally with a letter and an
+// apartment after a slash or dash, as in "12", "12B", or "12/5".
+var houseNumberPattern = regexp.MustCompile(`^\d{1,4}\p{L}?([/-]\d{1,4}\p{L}?)?$`)
+
+// apartmentMarkers introduce the apartment, staircase, or floor part of an
+// address, as in "Apt 5", "as. 12", or "lgh 1101".
+var apartmentMarkers = map[string]bool{
+	"apt": true, "apartment": true, "as": true, "asunto": true, "door": true, "fl": true,
+	"flat": true, "floor": true, "lgh": true, "lok": true, "lokal": true, "m": true,
+	"porras": true, "ste": true, "suite": true, "unit": true,
+}
+
+// geocodeAddress resolves address and, when the geocoder has no match for
+// it, retries with broadenedAddresses in order. It returns the location and
+// the address that matched; a broadened match is also recorded as a
+// geocode_broadened warning for the run.
+func geocodeAddress(ctx context.Context, deps Dependencies, address string) (domain.Location, string, error) {
+	location, err := deps.Location.Get(ctx, address)
+	if err == nil || !errors.Is(err, domain.ErrAddressNotFound) {
+		return location, address, err
+	}
+	variants := broadenedAddresses(address)
+	for _, variant := range variants {
+		location, variantErr := deps.Location.Get(ctx, variant)
+		if variantErr == nil {
+			geocodeNoticesFromContext(ctx).add(address, variant)
+			return location, variant, nil
+		}
+		if !errors.Is(variantErr, domain.ErrAddressNotFound) {
+			return domain.Location{}, "", variantErr
+		}
+	}
+	if len(variants) > 0 {
+		quoted := make([]string, 0, len(variants))
+		for _, variant := range variants {
+			quoted = append(quoted, fmt.Sprintf("%q", variant))
+		}
+		err = fmt.Errorf("%w (also tried %s)", err, strings.Join(quoted, ", "))
+	}
+	return domain.Location{}, "", err
+}
+
+// broadenedAddresses returns simpler forms of address to geocode when the
+// full one has no match: first without the apartment, then without the
+// street number. Forms equal to the address or to an earlier form are left
+// out.
+func broadenedAddresses(address string) []string {
+	parts := []string{}
+	for _, part := range strings.Split(address, ",") {
+		part = strings.Join(strings.Fields(part), " ")
+		if part == "" || isApartmentPart(part) {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	// The last of several parts holds the postal code and city, so the
+	// street number is looked for before it.
+	street, number := -1, -1
+	var tokens []string
+	for idx, part := range parts {
+		if idx > 0 && idx == len(parts)-1 {
+			break
+		}
+		tokens = streetTokens(part)
+		for tokenIdx, token := range tokens {
+			if houseNumberPattern.MatchString(token) {
+				street, number = idx, tokenIdx
+				break
+			}
+		}
+		if street >= 0 {
+			break
+		}
+	}
+
+	candidates := []string{strings.Join(parts, ", ")}
+	if street >= 0 {
+		houseNumber, _, _ := strings.Cut(strings.ReplaceAll(tokens[number], "-", "/"), "/")
+		// "Main Street 12 A 5" keeps the words before the number; "12 Main
+		// Street" has the street name after it.
+		name := tokens[:number]
+		if number == 0 {
+			name = tokens[1:]
+		}
+		withoutNumber := strings.Join(name, " ")
+		withNumber := withoutNumber + " " + houseNumber
+		if number == 0 {
+			withNumber = houseNumber + " " + withoutNumber
+		}
+		candidates = []string{
+			joinAddressParts(parts, street, strings.TrimSpace(withNumber)),
+			joinAddressParts(parts, street, withoutNumber),
+		}
+	}
+
+	seen := map[string]bool{strings.ToLower(strings.Join(strings.Fields(address), " ")): true}
+	variants := []string{}
+	for _, candidate := range candidates {
+		key := strings.ToLower(candidate)
+		if candidate == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		variants = append(variants, candidate)
+	}
+	return variants
+}
+
+// streetTokens splits an address part into words, leaving out apartment
+// markers with the word after them and "#"-prefixed apartment numbers.
+func streetTokens(part string) []string {
+	fields := strings.Fields(part)
+	tokens := make([]string, 0, len(fields))
+	for idx := 0; idx < len(fields); idx++ {
+		if strings.HasPrefix(fields[idx], "#") {
+			continue
+		}
+		if apartmentMarkers[strings.ToLower(strings.TrimSuffix(fields[idx], "."))] && idx > 0 {
+			idx++
+			continue
+		}
+		tokens = append(tokens, fields[idx])
+	}
+	return tokens
+}
+
+func isApartmentPart(part string) bool {
+	fields := strings.Fields(part)
+	if strings.HasPrefix(part, "#") && len(fields) == 1 {
+		return true
+	}
+	return len(fields) == 2 && apartmentMarkers[strings.ToLower(strings.TrimSuffix(fields[0], "."))]
+}
+
+func joinAddressParts(parts []string, street int, replacement string) string {
+	out := make([]string, 0, len(parts))
+	for idx, part := range parts {
+		if idx == street {
+			part = replacement
+		}
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+type geocodeNoticesKey struct{}
+
+// geocodeNotices collects the addresses a run had to broaden before the
+// geocoder matched them.
+type geocodeNotices struct {
+	mu      sync.Mutex
+	entries [][2]string
+}
+
+func withGeocodeNotices(ctx context.Context) (context.Context, *geocodeNotices) {
+	notices := &geocodeNotices{}
+	return context.WithValue(ctx, geocodeNoticesKey{}, notices), notices
+}
+
+func geocodeNoticesFromContext(ctx context.Context) *geocodeNotices {
+	if ctx == nil {
+		return nil
+	}
+	notices, _ := ctx.Value(geocodeNoticesKey{}).(*geocodeNotices)
+	return notices
+}
+
+func (n *geocodeNotices) add(address string, variant string) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, entry := range n.entries {
+		if entry[0] == address {
+			return
+		}
+	}
+	n.entries = append(n.entries, [2]string{address, variant})
+}
+
+func (n *geocodeNotices) warnings() []string {
+	if n == nil {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	out := make([]string, 0, len(n.entries))
+	for _, entry := range n.entries {
+		out = append(out, fmt.Sprintf("geocode_broadened: no match for %q; located %q instead", entry[0], entry[1]))
+	}
+	return out
+}
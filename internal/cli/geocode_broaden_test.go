@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func TestBroadenedAddresses(t *testing.T) {
+	cases := []struct {
+		address string
+		want    []string
+	}{
+		{"Mannerheimintie 12 A 5, 00100 Helsinki", []string{"Mannerheimintie 12, 00100 Helsinki", "Mannerheimintie, 00100 Helsinki"}},
+		{"Floriańska 12/5, 31-042 Kraków", []string{"Floriańska 12, 31-042 Kraków", "Floriańska, 31-042 Kraków"}},
+		{"12 Main Street, Apt 4, London", []string{"12 Main Street, London", "Main Street, London"}},
+		{"Drottninggatan 5 lgh 1101, Stockholm", []string{"Drottninggatan 5, Stockholm", "Drottninggatan, Stockholm"}},
+		{"Kamppi, Helsinki", []string{}},
+		{"00100 Helsinki", []string{}},
+	}
+	for _, tc := range cases {
+		if got := broadenedAddresses(tc.address); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("broadenedAddresses(%q) = %q, want %q", tc.address, got, tc.want)
+		}
+	}
+}
+
+type knownAddresses map[string]domain.Location
+
+func (k knownAddresses) Get(_ context.Context, address string) (domain.Location, error) {
+	if location, ok := k[address]; ok {
+		return location, nil
+	}
+	return domain.Location{}, fmt.Errorf("lookup: %w", domain.ErrAddressNotFound)
+}
+
+func TestGeocodeAddressRetriesBroadenedAddresses(t *testing.T) {
+	deps := Dependencies{Location: knownAddresses{"Mannerheimintie, Helsinki": {Lat: 60.17, Lon: 24.94}}}
+	ctx, notices := withGeocodeNotices(context.Background())
+
+	location, matched, err := geocodeAddress(ctx, deps, "Mannerheimintie 12 B 7, Helsinki")
+	if err != nil || matched != "Mannerheimintie, Helsinki" || location.Lat != 60.17 {
+		t.Fatalf("expected the street-only variant to match, got %v %q %v", location, matched, err)
+	}
+	if warnings := notices.warnings(); len(warnings) != 1 || !strings.HasPrefix(warnings[0], "geocode_broadened: ") {
+		t.Fatalf("expected one geocode_broadened warning, got %v", warnings)
+	}
+
+	_, _, err = geocodeAddress(ctx, deps, "Nowhere 1, Atlantis")
+	if !errors.Is(err, domain.ErrAddressNotFound) || !strings.Contains(err.Error(), `also tried "Nowhere, Atlantis"`) {
+		t.Fatalf("expected a not-found error naming the variants tried, got %v", err)
+	}
+}
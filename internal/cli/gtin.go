@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gtinFields are the item keys assortments carry barcodes under.
+var gtinFields = []string{"barcode_gtin", "gtin", "ean", "barcode", "gtins", "barcodes"}
+
+// parseGTIN validates an EAN-8, UPC-A, EAN-13, or GTIN-14 code, spaces and
+// dashes allowed, and returns it zero-padded to 14 digits for comparison.
+func parseGTIN(value string) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(value))
+	switch len(digits) {
+	case 8, 12, 13, 14:
+	default:
+		return "", fmt.Errorf("--ean must have 8, 12, 13, or 14 digits, got %q", value)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("--ean must contain only digits, got %q", value)
+		}
+	}
+	padded := strings.Repeat("0", 14-len(digits)) + digits
+	if gtinCheckDigit(padded[:13]) != padded[13] {
+		return "", fmt.Errorf("--ean %q has a wrong check digit; check for a typo", value)
+	}
+	return padded, nil
+}
+
+// gtinCheckDigit is the GS1 modulo-10 check digit of the 13 leading digits
+// of a GTIN-14: weights alternate 3 and 1 from the right.
+func gtinCheckDigit(body string) byte {
+	sum := 0
+	for idx := len(body) - 1; idx >= 0; idx-- {
+		digit := int(body[idx] - '0')
+		if (len(body)-1-idx)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// itemGTINs returns the barcodes of an assortment item padded to 14 digits.
+// Values that are not valid codes are skipped.
+func itemGTINs(item map[string]any) []string {
+	codes := []string{}
+	for _, field := range gtinFields {
+		values := asSlice(item[field])
+		if values == nil && item[field] != nil {
+			values = []any{item[field]}
+		}
+		for _, value := range values {
+			text := asString(value)
+			if number, ok := value.(float64); ok {
+				// Codes sent as JSON numbers would print in exponent form.
+				text = strconv.FormatFloat(number, 'f', -1, 64)
+			}
+			if code, err := parseGTIN(text); err == nil {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return dedupeStrings(codes)
+}
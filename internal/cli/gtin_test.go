@@ -0,0 +1,35 @@
+package cli
+
+import "testing"
+
+func TestParseGTINPadsAndChecksDigits(t *testing.T) {
+	cases := map[string]string{
+		"6415600501194":   "06415600501194",
+		"6415-6005 01194": "06415600501194",
+		"96385074":        "00000096385074",
+		"036000291452":    "00036000291452",
+		"10614141000415":  "10614141000415",
+	}
+	for input, want := range cases {
+		got, err := parseGTIN(input)
+		if err != nil || got != want {
+			t.Fatalf("parseGTIN(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"6415600501195", "64156005", "641560050119A", ""} {
+		if _, err := parseGTIN(input); err == nil {
+			t.Fatalf("parseGTIN(%q) expected an error", input)
+		}
+	}
+}
+
+func TestItemGTINsReadsStringsNumbersAndLists(t *testing.T) {
+	item := map[string]any{
+		"barcode_gtin": float64(6415600501194),
+		"gtins":        []any{"96385074", "not-a-code"},
+	}
+	got := itemGTINs(item)
+	if len(got) != 2 || got[0] != "06415600501194" || got[1] != "00000096385074" {
+		t.Fatalf("itemGTINs = %v", got)
+	}
+}
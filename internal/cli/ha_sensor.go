@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const haSensorAnnotation = "wolt_cli_ha_sensor"
+
+// haSensorBuilders map a command's data payload onto a Home Assistant sensor,
+// keyed by the value of haSensorAnnotation.
+var haSensorBuilders = map[string]func(data map[string]any) output.HASensor{
+	"cart":  cartHASensor,
+	"order": orderHASensor,
+}
+
+// enableHASensor lets cmd render --format ha-sensor with the named builder.
+func enableHASensor(cmd *cobra.Command, builder string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[haSensorAnnotation] = builder
+}
+
+// applyHASensorFormat rejects --format ha-sensor on commands without a sensor shape.
+func applyHASensorFormat(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil || !strings.EqualFold(strings.TrimSpace(flag.Value.String()), string(output.FormatHASensor)) {
+		return nil
+	}
+	if _, ok := haSensorBuilders[cmd.Annotations[haSensorAnnotation]]; !ok {
+		return fmt.Errorf("--format ha-sensor is supported by cart show and profile orders show")
+	}
+	return nil
+}
+
+func writeHASensor(cmd *cobra.Command, env output.Envelope, outputPath string) error {
+	var sensor output.HASensor
+	if env.Error != nil {
+		sensor = output.HASensor{
+			State: "error",
+			Attributes: map[string]any{
+				"code":    env.Error["code"],
+				"message": env.Error["message"],
+			},
+		}
+	} else {
+		build := haSensorBuilders[cmd.Annotations[haSensorAnnotation]]
+		if build == nil {
+			return fmt.Errorf("--format ha-sensor is not supported by %s", cmd.CommandPath())
+		}
+		sensor = build(asMap(env.Data))
+	}
+	rendered, err := output.RenderHASensor(sensor)
+	if err != nil {
+		return err
+	}
+	return output.WriteOutput(cmd.OutOrStdout(), rendered, outputPath)
+}
+
+// cartHASensor reports the basket item count as state, 0 for an empty cart.
+func cartHASensor(data map[string]any) output.HASensor {
+	items := []string{}
+	for _, value := range asSlice(data["lines"]) {
+		line := asMap(value)
+		if name := strings.TrimSpace(asString(line["name"])); name != "" {
+			items = append(items, fmt.Sprintf("%dx %s", asInt(line["count"]), name))
+		}
+	}
+	total := asMap(data["total"])
+	return output.HASensor{
+		State: asInt(data["total_items"]),
+		Attributes: map[string]any{
+			"venue_name":   asString(data["venue_name"]),
+			"venue_slug":   asString(data["venue_slug"]),
+			"total":        asString(total["formatted_amount"]),
+			"total_amount": asInt(total["amount"]),
+			"currency":     asString(data["currency"]),
+			"items":        items,
+		},
+	}
+}
+
+// orderHASensor reports the order status as state, for "order is arriving" automations.
+func orderHASensor(data map[string]any) output.HASensor {
+	items := []string{}
+	for _, value := range asSlice(data["items"]) {
+		item := asMap(value)
+		if name := strings.TrimSpace(asString(item["name"])); name != "" {
+			items = append(items, fmt.Sprintf("%dx %s", asInt(item["count"]), name))
+		}
+	}
+	return output.HASensor{
+		State: fallbackString(asString(data["status"]), "unknown"),
+		Attributes: map[string]any{
+			"order_id":        asString(data["order_id"]),
+			"order_number":    asString(data["order_number"]),
+			"venue_name":      asString(asMap(data["venue"])["name"]),
+			"delivery_method": asString(data["delivery_method"]),
+			"delivery_time":   asString(data["delivery_time"]),
+			"creation_time":   asString(data["creation_time"]),
+			"total":           asString(asMap(asMap(data["totals"])["total"])["formatted_amount"]),
+			"items":           items,
+		},
+	}
+}
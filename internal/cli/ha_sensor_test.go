@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func TestOrderHASensorUsesStatusAsState(t *testing.T) {
+	sensor := orderHASensor(map[string]any{
+		"status":   "delivered",
+		"order_id": "order-1",
+		"venue":    map[string]any{"name": "Burger Place"},
+		"totals":   map[string]any{"total": map[string]any{"formatted_amount": "€19.50"}},
+		"items":    []any{map[string]any{"name": "Fries", "count": 2}},
+	})
+	if sensor.State != "delivered" {
+		t.Fatalf("expected delivered state, got %v", sensor.State)
+	}
+	if sensor.Attributes["venue_name"] != "Burger Place" || sensor.Attributes["total"] != "€19.50" {
+		t.Fatalf("unexpected attributes %#v", sensor.Attributes)
+	}
+	if items, _ := sensor.Attributes["items"].([]string); len(items) != 1 || items[0] != "2x Fries" {
+		t.Fatalf("unexpected items %#v", sensor.Attributes["items"])
+	}
+	if orderHASensor(map[string]any{}).State != "unknown" {
+		t.Fatal("expected unknown state without status")
+	}
+}
+
+func TestWriteHASensorRendersErrorsAsErrorState(t *testing.T) {
+	cmd := &cobra.Command{Use: "show"}
+	enableHASensor(cmd, "order")
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	env := output.BuildEnvelope("default", "en", nil, nil, map[string]any{"code": "WOLT_AUTH_REQUIRED", "message": "login"})
+	if err := writeHASensor(cmd, env, ""); err != nil {
+		t.Fatalf("write ha sensor: %v", err)
+	}
+	got := strings.TrimSpace(stdout.String())
+	if got != `{"state":"error","attributes":{"code":"WOLT_AUTH_REQUIRED","message":"login"}}` {
+		t.Fatalf("unexpected output %s", got)
+	}
+}
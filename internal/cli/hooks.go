@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	hookPreCommand  = "pre_command"
+	hookPostCommand = "post_command"
+)
+
+// hookTimeout bounds one hook, so a hung approval step cannot block a
+// command forever.
+const hookTimeout = 30 * time.Second
+
+var hookHTTPClient = &http.Client{Timeout: hookTimeout}
+
+type hookRunKey struct{}
+
+type hookArgsKey struct{}
+
+// hookRun carries what post_command hooks need from the command they follow.
+type hookRun struct {
+	hooks   []domain.Hook
+	command string
+	profile string
+	format  string
+	stdout  lockedBuffer
+}
+
+// hookEvent is the JSON a hook receives on stdin (shell) or as the request
+// body (webhook).
+type hookEvent struct {
+	Hook     string          `json:"hook"`
+	Command  string          `json:"command"`
+	Args     []string        `json:"args"`
+	Profile  string          `json:"profile"`
+	Format   string          `json:"format"`
+	RunID    string          `json:"run_id"`
+	ExitCode *int            `json:"exit_code,omitempty"`
+	Envelope json.RawMessage `json:"envelope,omitempty"`
+	Output   string          `json:"output,omitempty"`
+}
+
+// runPreCommandHooks runs the configured pre_command hooks for cmd and starts
+// capturing stdout for post_command hooks. A failing pre hook is reported as
+// WOLT_HOOK_REJECTED and the command does not run.
+func runPreCommandHooks(cmd *cobra.Command, deps Dependencies) error {
+	if !cmd.HasParent() || deps.Config == nil {
+		return nil
+	}
+	cfg, err := deps.Config.Load(cmd.Context())
+	if err != nil || cfg.Hooks == nil {
+		return nil
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	profileFlag, _ := cmd.Flags().GetString("profile")
+	formatFlag, _ := cmd.Flags().GetString("format")
+	event := hookEvent{
+		Hook:    hookPreCommand,
+		Command: command,
+		Args:    redactSessionArgs(hookArgs(cmd.Context())),
+		Profile: defaultProfileName(profileFlag),
+		Format:  strings.ToLower(fallbackString(strings.TrimSpace(formatFlag), "table")),
+		RunID:   runIDFromContext(cmd.Context()),
+	}
+
+	for _, hook := range matchingHooks(cfg.Hooks.PreCommand, command) {
+		if err := runHook(cmd.Context(), deps, hook, event, cmd.ErrOrStderr()); err != nil {
+			format, formatErr := parseOutputFormat(formatFlag)
+			if formatErr != nil {
+				format = output.FormatTable
+			}
+			locale, _ := cmd.Flags().GetString("locale")
+			outputPath, _ := cmd.Flags().GetString("output")
+			return emitError(cmd, format, event.Profile, locale, outputPath, "WOLT_HOOK_REJECTED", fmt.Sprintf("pre_command hook rejected %s: %v", command, err))
+		}
+	}
+
+	post := matchingHooks(cfg.Hooks.PostCommand, command)
+	if len(post) == 0 {
+		return nil
+	}
+	run := &hookRun{hooks: post, command: command, profile: event.Profile, format: event.Format}
+	cmd.SetOut(io.MultiWriter(cmd.OutOrStdout(), &run.stdout))
+	cmd.SetContext(context.WithValue(cmd.Context(), hookRunKey{}, run))
+	return nil
+}
+
+// runPostCommandHooks hands the exit code and stdout of the executed command
+// to its post_command hooks. Failures are reported on stderr only.
+func runPostCommandHooks(executed *cobra.Command, deps Dependencies, args []string, exitCode int, stderr io.Writer) {
+	if executed == nil || executed.Context() == nil {
+		return
+	}
+	run, _ := executed.Context().Value(hookRunKey{}).(*hookRun)
+	if run == nil {
+		return
+	}
+	event := hookEvent{
+		Hook:     hookPostCommand,
+		Command:  run.command,
+		Args:     redactSessionArgs(args),
+		Profile:  run.profile,
+		Format:   run.format,
+		RunID:    runIDFromContext(executed.Context()),
+		ExitCode: &exitCode,
+	}
+	printed := woltgateway.Redact(run.stdout.String())
+	if json.Valid([]byte(printed)) {
+		event.Envelope = json.RawMessage(printed)
+	} else {
+		event.Output = printed
+	}
+	// The command context may already be cancelled by an interrupt; post hooks
+	// still get their own time budget.
+	ctx := context.WithoutCancel(executed.Context())
+	for _, hook := range run.hooks {
+		if err := runHook(ctx, deps, hook, event, stderr); err != nil {
+			_, _ = fmt.Fprintf(stderr, "post_command hook failed: %v\n", err)
+		}
+	}
+}
+
+// withHookArgs records the arguments of one stage for its hooks.
+func withHookArgs(ctx context.Context, args []string) context.Context {
+	return context.WithValue(ctx, hookArgsKey{}, args)
+}
+
+func hookArgs(ctx context.Context) []string {
+	args, _ := ctx.Value(hookArgsKey{}).([]string)
+	return args
+}
+
+// matchingHooks keeps the hooks that apply to command.
+func matchingHooks(hooks []domain.Hook, command string) []domain.Hook {
+	matched := []domain.Hook{}
+	for _, hook := range hooks {
+		if strings.TrimSpace(hook.Run) == "" && strings.TrimSpace(hook.URL) == "" {
+			continue
+		}
+		if len(hook.Commands) == 0 {
+			matched = append(matched, hook)
+			continue
+		}
+		for _, prefix := range hook.Commands {
+			prefix = strings.Join(strings.Fields(prefix), " ")
+			if command == prefix || strings.HasPrefix(command, prefix+" ") {
+				matched = append(matched, hook)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func runHook(ctx context.Context, deps Dependencies, hook domain.Hook, event hookEvent, stderr io.Writer) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode hook event: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+	if url := strings.TrimSpace(hook.URL); url != "" {
+		return postHookWebhook(ctx, url, body)
+	}
+	return runHookCommand(ctx, deps, hook.Run, event, body, stderr)
+}
+
+// runHookCommand runs a shell snippet with the event on stdin. Its output
+// goes to stderr so stdout keeps only the command's own result.
+func runHookCommand(ctx context.Context, deps Dependencies, script string, event hookEvent, body []byte, stderr io.Writer) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	command := exec.CommandContext(ctx, shell, flag, script)
+	if event.Hook == hookPostCommand {
+		command.Stdin = strings.NewReader(fallbackString(string(event.Envelope), event.Output))
+	} else {
+		command.Stdin = bytes.NewReader(body)
+	}
+	command.Stdout = stderr
+	command.Stderr = stderr
+	command.Env = append(os.Environ(),
+		"WOLT_HOOK="+event.Hook,
+		"WOLT_COMMAND="+event.Command,
+		"WOLT_ARGS="+strings.Join(event.Args, " "),
+		"WOLT_PROFILE="+event.Profile,
+		"WOLT_FORMAT="+event.Format,
+		"WOLT_CLI_VERSION="+resolvedVersion(deps.Version),
+		runIDEnv+"="+event.RunID,
+	)
+	if event.ExitCode != nil {
+		command.Env = append(command.Env, "WOLT_EXIT_CODE="+strconv.Itoa(*event.ExitCode))
+	}
+	return command.Run()
+}
+
+// postHookWebhook sends the event to url; any status outside 2xx fails.
+func postHookWebhook(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := hookHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func hookTestDeps(t *testing.T, hooks domain.Hooks) (Dependencies, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	payload := filepath.Join(dir, "payload.json")
+	if err := os.WriteFile(payload, []byte(`{"data":{"items":[1]}}`), 0o644); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+	deps := Dependencies{
+		Profiles: &testProfiles{profile: domain.Profile{Name: "default"}},
+		Config:   &testConfigManager{cfg: domain.Config{Profiles: []domain.Profile{{Name: "default"}}, Hooks: &hooks}},
+		Version:  "1.1.1",
+	}
+	return deps, []string{"diff", payload, payload, "--format", "json"}
+}
+
+func TestPreCommandHookRejectsCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook snippets in this test use sh")
+	}
+	deps, args := hookTestDeps(t, domain.Hooks{
+		PreCommand:  []domain.Hook{{Run: `echo "blocked $WOLT_COMMAND" >&2; exit 3`, Commands: []string{"diff"}}},
+		PostCommand: []domain.Hook{{Run: "exit 0"}},
+	})
+	var stdout, stderr bytes.Buffer
+	if code := Execute(context.Background(), args, deps, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit 1, got %d\nstderr:\n%s", code, stderr.String())
+	}
+	var envelope map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode envelope: %v\n%s", err, stdout.String())
+	}
+	if code := asString(asMap(envelope["error"])["code"]); code != "WOLT_HOOK_REJECTED" {
+		t.Fatalf("expected WOLT_HOOK_REJECTED, got %v", envelope["error"])
+	}
+	if !strings.Contains(stderr.String(), "blocked diff") {
+		t.Fatalf("expected the hook output on stderr, got %q", stderr.String())
+	}
+}
+
+func TestPostCommandHookReceivesEnvelope(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook snippets in this test use sh")
+	}
+	captured := filepath.Join(t.TempDir(), "captured.json")
+	deps, args := hookTestDeps(t, domain.Hooks{
+		PostCommand: []domain.Hook{
+			{Run: `cat > "` + captured + `"; echo "$WOLT_HOOK $WOLT_COMMAND $WOLT_EXIT_CODE" >> "` + captured + `.env"`},
+			{Run: "exit 1", Commands: []string{"cart"}},
+		},
+	})
+	var stdout, stderr bytes.Buffer
+	if code := Execute(context.Background(), args, deps, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", code, stderr.String())
+	}
+	stdin, err := os.ReadFile(captured)
+	if err != nil {
+		t.Fatalf("read captured stdin: %v", err)
+	}
+	if string(stdin) != stdout.String() {
+		t.Fatalf("expected the hook stdin to be the envelope\nstdin:\n%s\nstdout:\n%s", stdin, stdout.String())
+	}
+	env, err := os.ReadFile(captured + ".env")
+	if err != nil {
+		t.Fatalf("read captured env: %v", err)
+	}
+	if strings.TrimSpace(string(env)) != "post_command diff 0" {
+		t.Fatalf("unexpected hook environment %q", env)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected the cart-only hook to be skipped, got stderr %q", stderr.String())
+	}
+}
+
+func TestWebhookHooks(t *testing.T) {
+	var events []hookEvent
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event hookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("decode webhook body: %v", err)
+		}
+		events = append(events, event)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	deps, args := hookTestDeps(t, domain.Hooks{
+		PreCommand:  []domain.Hook{{URL: server.URL}},
+		PostCommand: []domain.Hook{{URL: server.URL}},
+	})
+	var stdout, stderr bytes.Buffer
+	if code := Execute(context.Background(), append(args, "--wtoken", "secret-token"), deps, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", code, stderr.String())
+	}
+	if len(events) != 2 || events[0].Hook != hookPreCommand || events[1].Hook != hookPostCommand {
+		t.Fatalf("expected a pre and a post event, got %+v", events)
+	}
+	if events[1].ExitCode == nil || *events[1].ExitCode != 0 || len(events[1].Envelope) == 0 {
+		t.Fatalf("expected the post event to carry the exit code and envelope, got %+v", events[1])
+	}
+	if strings.Contains(strings.Join(events[0].Args, " "), "secret-token") {
+		t.Fatalf("expected --wtoken to be redacted, got %v", events[0].Args)
+	}
+
+	status = http.StatusForbidden
+	events = nil
+	stdout.Reset()
+	if code := Execute(context.Background(), args, deps, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected a rejected webhook to stop the command, got exit %d", code)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected no post event after a rejection, got %+v", events)
+	}
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const icsAnnotation = "wolt_cli_ics"
+
+// enableICS marks cmd as able to render --format ics itself.
+func enableICS(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[icsAnnotation] = "true"
+}
+
+// applyICSFormat rejects --format ics on commands without calendar events.
+func applyICSFormat(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil || !strings.EqualFold(strings.TrimSpace(flag.Value.String()), string(output.FormatICS)) {
+		return nil
+	}
+	if cmd.Annotations[icsAnnotation] != "true" {
+		return fmt.Errorf("--format ics is supported by profile favorites hours")
+	}
+	return nil
+}
+
+// writeICSEnvelope renders envelopes that reach the generic machine writer in
+// ics mode. Only errors do; they become X-WOLT-ERROR properties of an empty
+// calendar, and the command still exits non-zero.
+func writeICSEnvelope(cmd *cobra.Command, env output.Envelope, outputPath string) error {
+	if env.Error == nil {
+		return fmt.Errorf("--format ics is not supported by %s", cmd.CommandPath())
+	}
+	properties := [][2]string{{"error", fmt.Sprintf("%s: %s", asString(env.Error["code"]), asString(env.Error["message"]))}}
+	return output.WriteOutput(cmd.OutOrStdout(), output.RenderICS("Wolt", nil, properties, clockNow(cmd.Context())), outputPath)
+}
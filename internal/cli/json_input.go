@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// readJSONInput reads the JSON object passed to flagName from a file, or
+// from stdin when path is "-".
+func readJSONInput(cmd *cobra.Command, flagName string, path string) (map[string]any, error) {
+	path = strings.TrimSpace(path)
+	var raw []byte
+	var err error
+	if path == "-" {
+		raw, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read --%s: %w", flagName, err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(raw, &payload); err != nil || payload == nil {
+		return nil, fmt.Errorf("--%s must contain a JSON object", flagName)
+	}
+	return payload, nil
+}
+
+// mergeJSONInput merges overlay into base in place. Objects merge key by key
+// and any other value replaces the resolved one, so the file only needs the
+// fields it changes. A value whose JSON type differs from the resolved value,
+// or a dotted path listed in pinned, is rejected. It returns the dotted paths
+// that were set, sorted.
+func mergeJSONInput(base map[string]any, overlay map[string]any, pinned ...string) ([]string, error) {
+	pinnedPaths := map[string]struct{}{}
+	for _, path := range pinned {
+		pinnedPaths[path] = struct{}{}
+	}
+	fields := []string{}
+	if err := mergeJSONObject(base, overlay, "", pinnedPaths, &fields); err != nil {
+		return nil, err
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func mergeJSONObject(base map[string]any, overlay map[string]any, prefix string, pinned map[string]struct{}, fields *[]string) error {
+	for key, value := range overlay {
+		path := prefix + key
+		if _, ok := pinned[path]; ok {
+			return fmt.Errorf("%s is resolved by the CLI and cannot be set from JSON input", path)
+		}
+		current, exists := base[key]
+		nested, isObject := value.(map[string]any)
+		if currentObject, ok := current.(map[string]any); ok && isObject {
+			if err := mergeJSONObject(currentObject, nested, path+".", pinned, fields); err != nil {
+				return err
+			}
+			continue
+		}
+		if exists && current != nil && value != nil && jsonKind(current) != jsonKind(value) {
+			return fmt.Errorf("%s must be a JSON %s, got %s", path, jsonKind(current), jsonKind(value))
+		}
+		base[key] = value
+		*fields = append(*fields, path)
+	}
+	return nil
+}
+
+func jsonKind(value any) string {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+// splitBasketLineInput separates the added line's fields from a partial
+// add-to-basket payload. items may hold at most one object, which applies to
+// the line being added.
+func splitBasketLineInput(input map[string]any) (map[string]any, map[string]any, error) {
+	request := map[string]any{}
+	for key, value := range input {
+		request[key] = value
+	}
+	rawItems, ok := request["items"]
+	if !ok {
+		return request, nil, nil
+	}
+	delete(request, "items")
+	items, isArray := rawItems.([]any)
+	if !isArray || len(items) > 1 {
+		return nil, nil, fmt.Errorf("items must be an array with at most one object describing the added line")
+	}
+	if len(items) == 0 {
+		return request, nil, nil
+	}
+	line, isObject := items[0].(map[string]any)
+	if !isObject {
+		return nil, nil, fmt.Errorf("items must be an array with at most one object describing the added line")
+	}
+	return request, line, nil
+}
+
+// checkoutPlanInput accepts either a full checkout request or just its
+// purchase_plan object.
+func checkoutPlanInput(input map[string]any) map[string]any {
+	if _, ok := input["purchase_plan"]; ok {
+		return input
+	}
+	return map[string]any{"purchase_plan": input}
+}
+
+func jsonInputData(path string, fields []string) map[string]any {
+	return map[string]any{"path": strings.TrimSpace(path), "fields": fields}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// checkJSONNaming rejects an unknown --json-naming value before the command runs.
+func checkJSONNaming(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("json-naming")
+	if flag == nil {
+		return nil
+	}
+	_, err := output.ParseKeyNaming(flag.Value.String())
+	return err
+}
+
+// jsonNaming is the key naming of json and yaml output from --json-naming.
+func jsonNaming(cmd *cobra.Command) output.KeyNaming {
+	flag := cmd.Flags().Lookup("json-naming")
+	if flag == nil {
+		return output.KeyNamingSnake
+	}
+	naming, err := output.ParseKeyNaming(flag.Value.String())
+	if err != nil {
+		return output.KeyNamingSnake
+	}
+	return naming
+}
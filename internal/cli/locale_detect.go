@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Values of meta.locale_source.
+const (
+	localeSourceFlag    = "flag"
+	localeSourceProfile = "profile"
+	localeSourceToken   = "token"
+	localeSourceDefault = "default"
+)
+
+type localeSourceKey struct{}
+
+// countryLocales maps Wolt market country codes (ISO 3166-1 alpha-3, as
+// used in venue and account payloads) to the locale shown to local users.
+var countryLocales = map[string]string{
+	"ALB": "sq-AL",
+	"AUT": "de-AT",
+	"AZE": "az-AZ",
+	"BGR": "bg-BG",
+	"CYP": "el-CY",
+	"CZE": "cs-CZ",
+	"DEU": "de-DE",
+	"DNK": "da-DK",
+	"EST": "et-EE",
+	"FIN": "fi-FI",
+	"GEO": "ka-GE",
+	"GRC": "el-GR",
+	"HRV": "hr-HR",
+	"HUN": "hu-HU",
+	"ISR": "he-IL",
+	"JPN": "ja-JP",
+	"KAZ": "kk-KZ",
+	"LTU": "lt-LT",
+	"LUX": "fr-LU",
+	"LVA": "lv-LV",
+	"MLT": "en-MT",
+	"MNE": "sr-ME",
+	"MKD": "mk-MK",
+	"NOR": "nb-NO",
+	"POL": "pl-PL",
+	"ROU": "ro-RO",
+	"SRB": "sr-RS",
+	"SVK": "sk-SK",
+	"SVN": "sl-SI",
+	"SWE": "sv-SE",
+	"UZB": "uz-UZ",
+}
+
+// applyLocaleDefault fills in --locale when it was not given: the profile
+// locale first, then the country in the profile's access token. Without
+// either the flag keeps its en-FI default. The deciding source is kept for
+// meta.locale_source.
+func applyLocaleDefault(cmd *cobra.Command, deps Dependencies) {
+	flag := cmd.Flags().Lookup("locale")
+	if flag == nil {
+		return
+	}
+	source := localeSourceFlag
+	if !flag.Changed {
+		var locale string
+		locale, source = detectProfileLocale(cmd, deps)
+		if locale != "" {
+			_ = cmd.Flags().Set("locale", locale)
+		}
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), localeSourceKey{}, source))
+}
+
+func detectProfileLocale(cmd *cobra.Command, deps Dependencies) (string, string) {
+	if deps.Profiles == nil {
+		return "", localeSourceDefault
+	}
+	profileName := ""
+	if flag := cmd.Flags().Lookup("profile"); flag != nil {
+		profileName = flag.Value.String()
+	}
+	profile, err := deps.Profiles.Find(cmd.Context(), profileName)
+	if err != nil {
+		return "", localeSourceDefault
+	}
+	if locale := strings.TrimSpace(profile.Locale); locale != "" {
+		return locale, localeSourceProfile
+	}
+	if locale := tokenLocale(profile.WToken); locale != "" {
+		return locale, localeSourceToken
+	}
+	return "", localeSourceDefault
+}
+
+// tokenLocale derives a locale from the country claim of a Wolt access
+// token, top level or under "user". A language claim replaces the language
+// part, so an English-speaking FIN account gets en-FI rather than fi-FI.
+func tokenLocale(token string) string {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	claimsRaw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsRaw, &claims); err != nil {
+		return ""
+	}
+	country, language := asString(claims["country"]), asString(claims["language"])
+	if user := asMap(claims["user"]); user != nil {
+		if country == "" {
+			country = asString(user["country"])
+		}
+		if language == "" {
+			language = asString(user["language"])
+		}
+	}
+	locale := countryLocale(country)
+	if locale == "" {
+		return ""
+	}
+	if language = strings.ToLower(strings.TrimSpace(language)); len(language) == 2 {
+		_, region, _ := strings.Cut(locale, "-")
+		locale = language + "-" + region
+	}
+	return locale
+}
+
+// countryLocale accepts alpha-3 or alpha-2 country codes.
+func countryLocale(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if locale, ok := countryLocales[country]; ok {
+		return locale
+	}
+	if len(country) != 2 {
+		return ""
+	}
+	for _, locale := range countryLocales {
+		if strings.HasSuffix(locale, "-"+country) {
+			return locale
+		}
+	}
+	return ""
+}
+
+func localeSourceFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	source, _ := ctx.Value(localeSourceKey{}).(string)
+	return source
+}
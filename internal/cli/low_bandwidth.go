@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/spf13/cobra"
+)
+
+// lowBandwidthPageSize is the page size --low-bandwidth requests from
+// paginated endpoints when the command's own page-size flag is not set.
+const lowBandwidthPageSize = 10
+
+func lowBandwidth(cmd *cobra.Command) bool {
+	enabled, _ := cmd.Flags().GetBool("low-bandwidth")
+	return enabled
+}
+
+// lowBandwidthPageLimit shrinks a defaulted page size in --low-bandwidth runs;
+// an explicit flag value is kept.
+func lowBandwidthPageLimit(cmd *cobra.Command, flagName string, value int) int {
+	if !lowBandwidth(cmd) {
+		return value
+	}
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+		return value
+	}
+	return min(value, lowBandwidthPageSize)
+}
+
+// stripImageFields drops image URLs and their blurhash placeholders from
+// value in place, at any depth.
+func stripImageFields(value any) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			if isImageField(key) {
+				delete(typed, key)
+				continue
+			}
+			stripImageFields(nested)
+		}
+	case []any:
+		for _, nested := range typed {
+			stripImageFields(nested)
+		}
+	}
+}
+
+func isImageField(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range []string{"image", "blurhash", "thumbnail", "logo"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// transferSummary totals the upstream traffic of this run so far.
+func transferSummary(timings *woltgateway.RequestTimings) map[string]any {
+	requests, received := 0, 0
+	for _, timing := range timings.Summary() {
+		requests += timing.Count
+		received += timing.Bytes
+	}
+	return map[string]any{"requests": requests, "bytes_received": received}
+}
+
+// writeTransferSummary prints the run's upstream traffic after a
+// --low-bandwidth run.
+func writeTransferSummary(out io.Writer, timings *woltgateway.RequestTimings) {
+	summary := transferSummary(timings)
+	_, _ = fmt.Fprintf(out, "[low-bandwidth] requests=%d bytes_received=%d\n", summary["requests"], summary["bytes_received"])
+}
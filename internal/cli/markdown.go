@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const markdownAnnotation = "wolt_cli_markdown"
+
+// enableMarkdown marks cmd as able to render --format markdown itself.
+func enableMarkdown(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[markdownAnnotation] = "true"
+}
+
+// applyMarkdownFormat rejects --format markdown on commands without a report.
+func applyMarkdownFormat(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil || !strings.EqualFold(strings.TrimSpace(flag.Value.String()), string(output.FormatMarkdown)) {
+		return nil
+	}
+	if cmd.Annotations[markdownAnnotation] != "true" {
+		return fmt.Errorf("--format markdown is supported by digest, diff, and budget show")
+	}
+	return nil
+}
+
+// writeMarkdownReport renders the tables of a command's table output as a
+// markdown report under heading, with warnings listed at the end.
+func writeMarkdownReport(cmd *cobra.Command, heading string, tableText string, warnings []string, outputPath string) error {
+	return output.WriteOutput(cmd.OutOrStdout(), output.RenderMarkdown(heading, output.ParseTables(tableText), warnings), outputPath)
+}
+
+// writeMarkdownEnvelope renders envelopes that reach the generic machine
+// writer in markdown mode. Only errors do; they become an Error section, and
+// the command still exits non-zero.
+func writeMarkdownEnvelope(cmd *cobra.Command, env output.Envelope, outputPath string) error {
+	if env.Error == nil {
+		return fmt.Errorf("--format markdown is not supported by %s", cmd.CommandPath())
+	}
+	table := output.Table{
+		Title:   "Error",
+		Headers: []string{"Code", "Message"},
+		Rows:    [][]string{{asString(env.Error["code"]), asString(env.Error["message"])}},
+	}
+	return output.WriteOutput(cmd.OutOrStdout(), output.RenderMarkdown("", []output.Table{table}, env.Warnings), outputPath)
+}
@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
+	"github.com/spf13/cobra"
+)
+
+// applyMoneyLocale picks the locale for formatted amounts: an explicit
+// --locale, or one applyLocaleDefault found for the profile. With only the
+// en-FI default, amounts keep the locale-independent format.
+func applyMoneyLocale(cmd *cobra.Command) {
+	locale := ""
+	if flag := cmd.Flags().Lookup("locale"); flag != nil && flag.Changed {
+		locale = flag.Value.String()
+	}
+	money.SetDefaultLocale(strings.TrimSpace(locale))
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const offlineErrorMessage = "offline: this data is not available locally; run the command once online with WOLT_RECORD_DIR set to keep a copy"
+
+type offlineSetter interface {
+	SetOffline(enabled bool)
+}
+
+// applyOfflineMode switches every network-backed dependency on or off for this run.
+func applyOfflineMode(cmd *cobra.Command, deps Dependencies) {
+	offline, _ := cmd.Flags().GetBool("offline")
+	for _, dependency := range []any{deps.Wolt, deps.Location} {
+		if setter, ok := dependency.(offlineSetter); ok {
+			setter.SetOffline(offline)
+		}
+	}
+}
+
+func emitOfflineError(cmd *cobra.Command, format output.Format, profile string, locale string, outputPath string, verbose bool, err error) error {
+	message := offlineErrorMessage
+	if verbose && err != nil {
+		message = err.Error()
+	}
+	return emitError(cmd, format, profile, locale, outputPath, "WOLT_OFFLINE", message)
+}
+
+// locationErrorCode keeps WOLT_OFFLINE distinct from real geocoding failures.
+func locationErrorCode(err error) string {
+	if errors.Is(err, domain.ErrOffline) {
+		return "WOLT_OFFLINE"
+	}
+	return "WOLT_LOCATION_RESOLVE_ERROR"
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+type forbiddenHTTPClient struct {
+	t *testing.T
+}
+
+func (c forbiddenHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.t.Fatalf("unexpected network call in offline mode: %s %s", req.Method, req.URL)
+	return nil, nil
+}
+
+func offlineDeps(t *testing.T, recordDir string) Dependencies {
+	return Dependencies{
+		Wolt: woltgateway.NewClient(
+			woltgateway.WithHTTPClient(forbiddenHTTPClient{t: t}),
+			woltgateway.WithRecordDir(recordDir),
+		),
+		Profiles: &testProfiles{profile: domain.Profile{Name: "default"}},
+		Config:   &testConfigManager{},
+		Version:  "1.1.1",
+	}
+}
+
+func TestOfflineModeFailsWithoutLocalCopy(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Execute(context.Background(), []string{"discover", "feed", "--lat", "60.1", "--lon", "24.9", "--offline", "--format", "json"}, offlineDeps(t, t.TempDir()), &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d\nstderr:\n%s", code, stderr.String())
+	}
+	var envelope map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode envelope: %v\n%s", err, stdout.String())
+	}
+	if code := asString(asMap(envelope["error"])["code"]); code != "WOLT_OFFLINE" {
+		t.Fatalf("expected WOLT_OFFLINE, got %q", code)
+	}
+}
+
+func TestOfflineModeServesRecordedResponses(t *testing.T) {
+	dir := t.TempDir()
+	fixture := woltgateway.Fixture{
+		Method: http.MethodGet,
+		URL:    "https://consumer-api.wolt.com/v1/pages/front",
+		Status: http.StatusOK,
+		Body: json.RawMessage(`{"sections":[{"name":"popular","title":"Popular","items":[
+			{"title":"Burger Place","venue":{"id":"venue-1","slug":"burger-place","name":"Burger Place"}}
+		]}]}`),
+	}
+	raw, _ := json.Marshal(fixture)
+	if err := os.WriteFile(filepath.Join(dir, "GET_consumer-api.wolt.com_v1_pages_front.json"), raw, 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := Execute(context.Background(), []string{"discover", "feed", "--lat", "60.1", "--lon", "24.9", "--fast", "--offline", "--format", "json"}, offlineDeps(t, dir), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", code, stdout.String(), stderr.String())
+	}
+	var envelope map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode envelope: %v\n%s", err, stdout.String())
+	}
+	if len(asSlice(asMap(envelope["data"])["sections"])) == 0 {
+		t.Fatalf("expected sections from the recorded front page, got %v", envelope["data"])
+	}
+}
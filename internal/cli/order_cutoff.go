@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const latestOrderTimeLayout = "15:04"
+
+func addForceFlag(cmd *cobra.Command, force *bool) {
+	cmd.Flags().BoolVar(force, "force", false, "Run even when the profile's latest_order_time (or, for checkout preview, its budget) would refuse.")
+}
+
+// parseLatestOrderTime validates an HH:MM latest_order_time; empty clears it.
+func parseLatestOrderTime(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	parsed, err := time.Parse(latestOrderTimeLayout, value)
+	if err != nil {
+		return "", fmt.Errorf("--latest-order-time must be a local time as HH:MM, for example 21:30")
+	}
+	return parsed.Format(latestOrderTimeLayout), nil
+}
+
+// guardLatestOrderTime refuses a command that fills or prices a basket once
+// the local time is past the profile's latest_order_time, until midnight.
+// --force overrides it; profiles without the setting are never blocked.
+func guardLatestOrderTime(
+	cmd *cobra.Command,
+	deps Dependencies,
+	profileFlag string,
+	force bool,
+	format output.Format,
+	profileName string,
+	locale string,
+	outputPath string,
+) error {
+	if force || deps.Profiles == nil {
+		return nil
+	}
+	profile, err := deps.Profiles.Find(cmd.Context(), profileFlag)
+	if err != nil || strings.TrimSpace(profile.LatestOrderTime) == "" {
+		return nil
+	}
+	cutoff, err := time.Parse(latestOrderTimeLayout, strings.TrimSpace(profile.LatestOrderTime))
+	if err != nil {
+		return nil
+	}
+	now := deps.now()
+	if now.Hour()*60+now.Minute() < cutoff.Hour()*60+cutoff.Minute() {
+		return nil
+	}
+	return emitError(cmd, format, profileName, locale, outputPath, "WOLT_ORDER_CUTOFF",
+		fmt.Sprintf("it is %s, past this profile's latest_order_time %s; pass --force to go ahead anyway",
+			now.Format(latestOrderTimeLayout), cutoff.Format(latestOrderTimeLayout)))
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func TestCartAddRefusedAfterLatestOrderTime(t *testing.T) {
+	deps := Dependencies{
+		Wolt: &testWoltAPI{},
+		Profiles: &testProfiles{profile: domain.Profile{
+			Name:            "default",
+			WToken:          "token",
+			Location:        domain.Location{Lat: 60.17, Lon: 24.94},
+			LatestOrderTime: "21:30",
+		}},
+		Config:  &testConfigManager{path: filepath.Join(t.TempDir(), "config.json")},
+		Version: "1.1.1",
+	}
+	run := func(at time.Time, extra ...string) string {
+		deps.Clock = ClockFunc(func() time.Time { return at })
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := append([]string{"cart", "add", "venue-1", "item-1", "--format", "json"}, extra...)
+		Execute(context.Background(), args, deps, &stdout, &stderr)
+		return stdout.String() + stderr.String()
+	}
+
+	late := time.Date(2026, 3, 1, 22, 5, 0, 0, time.Local)
+	if out := run(late); !strings.Contains(out, `"code": "WOLT_ORDER_CUTOFF"`) || !strings.Contains(out, "it is 22:05") {
+		t.Fatalf("expected WOLT_ORDER_CUTOFF after 21:30, got:\n%s", out)
+	}
+	if out := run(late, "--force"); strings.Contains(out, "WOLT_ORDER_CUTOFF") {
+		t.Fatalf("expected --force to skip the guard, got:\n%s", out)
+	}
+	if out := run(time.Date(2026, 3, 1, 21, 29, 0, 0, time.Local)); strings.Contains(out, "WOLT_ORDER_CUTOFF") {
+		t.Fatalf("expected no guard before 21:30, got:\n%s", out)
+	}
+}
+
+func TestParseLatestOrderTime(t *testing.T) {
+	if value, err := parseLatestOrderTime(" 9:05 "); err != nil || value != "09:05" {
+		t.Fatalf("expected 09:05, got %q err=%v", value, err)
+	}
+	if _, err := parseLatestOrderTime("25:00"); err == nil {
+		t.Fatalf("expected 25:00 to be rejected")
+	}
+	if value, err := parseLatestOrderTime("21:30"); err != nil || value != "21:30" {
+		t.Fatalf("expected 21:30, got %q err=%v", value, err)
+	}
+	if value, err := parseLatestOrderTime(""); err != nil || value != "" {
+		t.Fatalf("expected empty to clear, got %q err=%v", value, err)
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// packUnits maps a pack size unit to its base unit and the factor that
+// converts it there: grams to kg, millilitres to l, and piece counts to pcs.
+var packUnits = map[string]struct {
+	base   string
+	factor float64
+}{
+	"kg":  {"kg", 1},
+	"g":   {"kg", 0.001},
+	"gr":  {"kg", 0.001},
+	"mg":  {"kg", 0.000001},
+	"l":   {"l", 1},
+	"ltr": {"l", 1},
+	"dl":  {"l", 0.1},
+	"cl":  {"l", 0.01},
+	"ml":  {"l", 0.001},
+	"kpl": {"pcs", 1},
+	"pcs": {"pcs", 1},
+	"pc":  {"pcs", 1},
+	"st":  {"pcs", 1},
+	"stk": {"pcs", 1},
+	"szt": {"pcs", 1},
+}
+
+const packUnitPattern = `(kg|gr|g|mg|ltr|l|dl|cl|ml|kpl|pcs|pc|stk|st|szt)`
+
+var (
+	multiPackPattern  = regexp.MustCompile(`(?i)(\d+)\s*[x×*]\s*(\d+(?:[.,]\d+)?)\s*` + packUnitPattern + `\b`)
+	singlePackPattern = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*` + packUnitPattern + `\b`)
+)
+
+// parsePackSize finds a pack size such as "6 x 0,33 l", "500 g", or
+// "10 kpl" in text and returns the total quantity in the base unit: 1.98 l,
+// 0.5 kg, 10 pcs.
+func parsePackSize(text string) (float64, string, bool) {
+	if match := multiPackPattern.FindStringSubmatch(text); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		size, ok := packNumber(match[2])
+		unit := packUnits[strings.ToLower(match[3])]
+		if ok && count > 0 && size > 0 {
+			return roundPackQuantity(float64(count) * size * unit.factor), unit.base, true
+		}
+	}
+	if match := singlePackPattern.FindStringSubmatch(text); match != nil {
+		size, ok := packNumber(match[1])
+		unit := packUnits[strings.ToLower(match[2])]
+		if ok && size > 0 {
+			return roundPackQuantity(size * unit.factor), unit.base, true
+		}
+	}
+	return 0, "", false
+}
+
+func packNumber(text string) (float64, bool) {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	return value, err == nil
+}
+
+func roundPackQuantity(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
+
+// annotatePackSizes sets quantity and unit on item rows whose name or
+// description states a pack size, and price_per_unit:{amount,formatted_amount,unit}
+// from the base price; the fields are null when no pack size is found.
+func annotatePackSizes(rows []any) {
+	for _, value := range rows {
+		row := asMap(value)
+		if row == nil {
+			continue
+		}
+		row["quantity"] = nil
+		row["unit"] = nil
+		row["price_per_unit"] = nil
+		quantity, unit, ok := parsePackSize(asString(row["name"]))
+		if !ok {
+			quantity, unit, ok = parsePackSize(asString(row["description"]))
+		}
+		if !ok {
+			continue
+		}
+		row["quantity"] = quantity
+		row["unit"] = unit
+		price := asMap(row["base_price"])
+		amount, hasPrice := asFloat(price["amount"])
+		if !hasPrice || amount <= 0 {
+			continue
+		}
+		perUnit := int(math.Round(amount / quantity))
+		row["price_per_unit"] = map[string]any{
+			"amount":           perUnit,
+			"formatted_amount": emptyToNil(formatMinorAmount(perUnit, asString(price["currency"]))),
+			"unit":             unit,
+		}
+	}
+}
+
+// unitPriceSortKey is the row's per-unit price, or nil when the pack size
+// is unknown.
+func unitPriceSortKey(row map[string]any) any {
+	perUnit := asMap(row["price_per_unit"])
+	if perUnit == nil {
+		return nil
+	}
+	return map[string]any{"unit": asString(perUnit["unit"]), "amount": asInt(perUnit["amount"])}
+}
+
+// compareUnitPriceSortKeys groups rows by unit and puts rows without a
+// per-unit price last.
+func compareUnitPriceSortKeys(left any, right any) int {
+	leftPrice, rightPrice := asMap(left), asMap(right)
+	switch {
+	case leftPrice == nil && rightPrice == nil:
+		return 0
+	case leftPrice == nil:
+		return 1
+	case rightPrice == nil:
+		return -1
+	case asString(leftPrice["unit"]) != asString(rightPrice["unit"]):
+		return strings.Compare(asString(leftPrice["unit"]), asString(rightPrice["unit"]))
+	default:
+		return cmp.Compare(asInt(leftPrice["amount"]), asInt(rightPrice["amount"]))
+	}
+}
+
+func formatUnitPriceForTable(row map[string]any) string {
+	perUnit := asMap(row["price_per_unit"])
+	if perUnit == nil {
+		return "-"
+	}
+	return fallbackString(asString(perUnit["formatted_amount"]), asString(perUnit["amount"])) + "/" + asString(perUnit["unit"])
+}
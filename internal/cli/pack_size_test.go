@@ -0,0 +1,61 @@
+package cli
+
+import "testing"
+
+func TestParsePackSizeNormalizesToBaseUnits(t *testing.T) {
+	cases := []struct {
+		text     string
+		quantity float64
+		unit     string
+	}{
+		{"Coca-Cola Zero 6 x 0,33 l", 1.98, "l"},
+		{"Oatly Kaurajuoma 1L", 1, "l"},
+		{"Valio juusto 500 g", 0.5, "kg"},
+		{"Banaani 1,2kg", 1.2, "kg"},
+		{"Kananmunat 10 kpl", 10, "pcs"},
+		{"Espresso 33 cl", 0.33, "l"},
+		{"Pepsi Max 4×1.5l", 6, "l"},
+	}
+	for _, tc := range cases {
+		quantity, unit, ok := parsePackSize(tc.text)
+		if !ok || quantity != tc.quantity || unit != tc.unit {
+			t.Fatalf("parsePackSize(%q) = %v %q %v, want %v %q", tc.text, quantity, unit, ok, tc.quantity, tc.unit)
+		}
+	}
+	for _, text := range []string{"Cheeseburger", "Meal for 2", "Gluten free"} {
+		if _, _, ok := parsePackSize(text); ok {
+			t.Fatalf("parsePackSize(%q) expected no pack size", text)
+		}
+	}
+}
+
+func TestSortItemRowsByUnitPrice(t *testing.T) {
+	rows := []any{
+		map[string]any{"name": "Cheeseburger", "base_price": map[string]any{"amount": 500, "currency": "EUR"}},
+		map[string]any{"name": "Milk 1 l", "base_price": map[string]any{"amount": 150, "currency": "EUR"}},
+		map[string]any{"name": "Cheese 500 g", "base_price": map[string]any{"amount": 400, "currency": "EUR"}},
+		map[string]any{"name": "Milk 2 x 1,5 l", "base_price": map[string]any{"amount": 330, "currency": "EUR"}},
+	}
+	annotatePackSizes(rows)
+	sortItemRows(rows, itemRowSortUnitPrice)
+
+	names := []string{}
+	for _, row := range rows {
+		names = append(names, asString(asMap(row)["name"]))
+	}
+	want := []string{"Cheese 500 g", "Milk 2 x 1,5 l", "Milk 1 l", "Cheeseburger"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+	}
+	if perUnit := asMap(asMap(rows[0])["price_per_unit"]); asInt(perUnit["amount"]) != 800 || perUnit["unit"] != "kg" {
+		t.Fatalf("expected 8.00 per kg for cheese, got %v", perUnit)
+	}
+	if key := asMap(asMap(rows[0])["sort_key"]); key["unit"] != "kg" || asInt(key["amount"]) != 800 {
+		t.Fatalf("expected the unit price as sort_key, got %v", asMap(rows[0])["sort_key"])
+	}
+	if asMap(rows[3])["price_per_unit"] != nil {
+		t.Fatalf("expected no unit price without a pack size, got %v", asMap(rows[3])["price_per_unit"])
+	}
+}
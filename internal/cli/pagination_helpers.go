@@ -1,6 +1,70 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultRowLimit caps list commands run without --limit, so an unfiltered
+// marketplace menu does not print tens of thousands of rows.
+const defaultRowLimit = 200
+
+// defaultLimitEnv overrides defaultRowLimit; 0 turns the cap off.
+const defaultLimitEnv = "WOLT_DEFAULT_LIMIT"
+
+func addNoLimitFlag(cmd *cobra.Command, noLimit *bool) {
+	cmd.Flags().BoolVar(noLimit, "no-limit", false, fmt.Sprintf("Return every row instead of the default cap (%d, or %s)", defaultRowLimit, defaultLimitEnv))
+}
+
+// outputLimit returns the limit to paginate with: --limit when given, none
+// under --no-limit, else the default cap. capped reports that the default
+// applies; limit is left alone so fetches sized by --limit are unaffected.
+func outputLimit(limit *int, noLimit bool) (*int, bool, error) {
+	if limit != nil {
+		if noLimit {
+			return nil, false, fmt.Errorf("use either --limit or --no-limit, not both")
+		}
+		return limit, false, nil
+	}
+	if noLimit {
+		return nil, false, nil
+	}
+	rowCap := defaultRowLimit
+	if raw := strings.TrimSpace(os.Getenv(defaultLimitEnv)); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return nil, false, fmt.Errorf("%s must be a whole number >= 0", defaultLimitEnv)
+		}
+		if value == 0 {
+			return nil, false, nil
+		}
+		rowCap = value
+	}
+	return &rowCap, true, nil
+}
+
+// reportDefaultLimit sets data.truncated when the default cap cut rows and
+// says so: as a warning for machine formats, on stderr for tables.
+func reportDefaultLimit(cmd *cobra.Command, format output.Format, data map[string]any, capped bool) []string {
+	_, more := data["next_offset"]
+	truncated := capped && more
+	data["truncated"] = truncated
+	if !truncated {
+		return nil
+	}
+	message := fmt.Sprintf("showing %d of %d rows (default limit %d); pass --limit <n> or --no-limit for more",
+		asInt(data["count"]), asInt(data["total"]), asInt(data["limit"]))
+	if format == output.FormatTable {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), message)
+		return nil
+	}
+	return []string{message}
+}
 
 func resolvePageOffset(limit int, limitSet bool, offset int, offsetSet bool, page int, pageSet bool) (int, error) {
 	if pageSet && offsetSet {
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+type partialFailuresKey struct{}
+
+// partialFailures collects upstream errors that interrupted a run after it had
+// already fetched part of its data. Stages are reported in first-seen order.
+type partialFailures struct {
+	mu     sync.Mutex
+	stages []string
+	counts map[string]int
+	last   map[string]error
+	first  error
+}
+
+func withPartialFailures(ctx context.Context) (context.Context, *partialFailures) {
+	failures := &partialFailures{counts: map[string]int{}, last: map[string]error{}}
+	return context.WithValue(ctx, partialFailuresKey{}, failures), failures
+}
+
+func partialFailuresFromContext(ctx context.Context) *partialFailures {
+	if ctx == nil {
+		return nil
+	}
+	failures, _ := ctx.Value(partialFailuresKey{}).(*partialFailures)
+	return failures
+}
+
+// recordPartialFailure notes a failed request for stage; it is a no-op outside a tracked run.
+func recordPartialFailure(ctx context.Context, stage string, err error) {
+	failures := partialFailuresFromContext(ctx)
+	if failures == nil || err == nil {
+		return
+	}
+	failures.mu.Lock()
+	defer failures.mu.Unlock()
+	if _, seen := failures.counts[stage]; !seen {
+		failures.stages = append(failures.stages, stage)
+	}
+	failures.counts[stage]++
+	failures.last[stage] = err
+	if failures.first == nil {
+		failures.first = err
+	}
+}
+
+func (p *partialFailures) empty() bool {
+	if p == nil {
+		return true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.stages) == 0
+}
+
+func (p *partialFailures) warnings() []string {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]string, 0, len(p.stages))
+	for _, stage := range p.stages {
+		out = append(out, fmt.Sprintf(
+			"partial results: %s failed for %d request(s) (%s)",
+			stage,
+			p.counts[stage],
+			describePartialFailure(p.last[stage]),
+		))
+	}
+	return out
+}
+
+func (p *partialFailures) firstError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.first
+}
+
+func describePartialFailure(err error) string {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "cancelled"
+	}
+	if errors.Is(err, domain.ErrOffline) {
+		return "offline"
+	}
+	if errors.Is(err, woltgateway.ErrUnsupportedInRegion) {
+		return "unsupported_in_region"
+	}
+	var upstreamErr *woltgateway.UpstreamRequestError
+	if errors.As(err, &upstreamErr) && upstreamErr.StatusCode > 0 {
+		return fmt.Sprintf("status %d", upstreamErr.StatusCode)
+	}
+	return woltgateway.ErrUpstream.Error()
+}
+
+func addStrictFlag(cmd *cobra.Command, strict *bool) {
+	cmd.Flags().BoolVar(strict, "strict", false, "Fail instead of returning partial results when an upstream request fails mid-run")
+}
+
+// finishPartialRun marks data as partial and appends failure warnings, or fails
+// the command under --strict.
+func finishPartialRun(
+	cmd *cobra.Command,
+	format output.Format,
+	profile string,
+	locale string,
+	outputPath string,
+	verbose bool,
+	strict bool,
+	data map[string]any,
+	warnings []string,
+) ([]string, error) {
+	failures := partialFailuresFromContext(cmd.Context())
+	if failures.empty() {
+		return warnings, nil
+	}
+	if strict {
+		return warnings, emitUpstreamError(cmd, format, profile, locale, outputPath, verbose, failures.firstError())
+	}
+	data["partial"] = true
+	return append(warnings, failures.warnings()...), nil
+}
+
+func addNoFallbackFlag(cmd *cobra.Command, noFallback *bool) {
+	cmd.Flags().BoolVar(noFallback, "no-fallback", false, "Fail with WOLT_FALLBACK_REFUSED instead of degrading to static venue payloads")
+}
+
+// refuseFallback fails the command under --no-fallback once it has taken a
+// fallback path; reasons are the warnings that path would have returned.
+func refuseFallback(
+	cmd *cobra.Command,
+	format output.Format,
+	profile string,
+	locale string,
+	outputPath string,
+	noFallback bool,
+	reasons []string,
+) error {
+	if !noFallback || len(reasons) == 0 {
+		return nil
+	}
+	return emitError(cmd, format, profile, locale, outputPath, "WOLT_FALLBACK_REFUSED", "--no-fallback: "+strings.Join(reasons, "; "))
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+// payloadAnomalyWarnings turns the response shape mismatches the gateway
+// found during this run into one payload_anomalies warning per endpoint
+// family, so empty output can be told apart from upstream format drift.
+func payloadAnomalyWarnings(ctx context.Context) []string {
+	anomalies := woltgateway.PayloadAnomaliesFromContext(ctx).List()
+	warnings := make([]string, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		parts := []string{}
+		if len(anomaly.Missing) > 0 {
+			parts = append(parts, "missing "+strings.Join(anomaly.Missing, ", "))
+		}
+		if len(anomaly.Unexpected) > 0 {
+			parts = append(parts, "unexpected "+strings.Join(anomaly.Unexpected, ", "))
+		}
+		warnings = append(warnings, fmt.Sprintf("payload_anomalies: %s response %s; the Wolt API may have changed and results can be incomplete", anomaly.Family, strings.Join(parts, "; ")))
+	}
+	return warnings
+}
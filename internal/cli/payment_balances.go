@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+// attachPaymentBalances sets balance on each normalized payment method:
+// {amount, currency, formatted_amount, expires_at}, or null when neither the
+// balances endpoint nor the method payload reports one. Entries from the
+// balances endpoint are matched by method id, then by method type for
+// provider-level balances such as a linked lunch benefit.
+func attachPaymentBalances(
+	ctx context.Context,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	payload map[string]any,
+	methods []any,
+) []string {
+	warnings := []string{}
+	country := paymentCountryFromToken(auth.WToken)
+	if country == "" {
+		country = paymentCountryFromUserMe(asMap(payload["user"]))
+	}
+	balancesPayload, authWarnings, err := invokeWithAuthAutoRefresh(
+		ctx,
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.PaymentBalances(ctx, authCtx, country)
+		},
+	)
+	warnings = append(warnings, authWarnings...)
+	if err != nil {
+		warnings = append(warnings, "balance lookup failed; balances come from the payment methods payload only")
+	}
+
+	byID := map[string]map[string]any{}
+	byType := map[string]map[string]any{}
+	for _, entry := range paymentBalanceEntries(balancesPayload) {
+		balance := paymentBalance(entry)
+		if balance == nil {
+			continue
+		}
+		if id := strings.ToLower(strings.TrimSpace(asString(coalesceAny(entry["method_id"], entry["payment_method_id"], entry["id"])))); id != "" {
+			byID[id] = balance
+		}
+		if methodType := strings.ToLower(strings.TrimSpace(asString(coalesceAny(entry["type"], entry["provider"], entry["method"])))); methodType != "" {
+			byType[methodType] = balance
+		}
+	}
+	rawCandidates := extractProfilePaymentMethodCandidates(asMap(payload["profile"]))
+	rawCandidates = append(rawCandidates, extractLegacyPaymentMethodCandidates(asMap(payload["saved"]))...)
+	for _, raw := range rawCandidates {
+		normalized := normalizePaymentMethod(raw, false)
+		id := strings.ToLower(strings.TrimSpace(asString(normalized["method_id"])))
+		if id == "" {
+			continue
+		}
+		if _, ok := byID[id]; ok {
+			continue
+		}
+		if balance := paymentBalance(raw); balance != nil {
+			byID[id] = balance
+		}
+	}
+
+	for _, value := range methods {
+		method := asMap(value)
+		id := strings.ToLower(strings.TrimSpace(asString(method["method_id"])))
+		balance, ok := byID[id]
+		if !ok || id == "" {
+			balance, ok = byType[strings.ToLower(asString(method["type"]))]
+		}
+		if ok {
+			method["balance"] = balance
+		} else {
+			method["balance"] = nil
+		}
+	}
+	return warnings
+}
+
+func paymentBalanceEntries(payload map[string]any) []map[string]any {
+	source := asSlice(coalesceAny(payload["balances"], payload["results"], payload["methods"]))
+	entries := make([]map[string]any, 0, len(source))
+	for _, value := range source {
+		if entry := asMap(value); entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// paymentBalance reads a stored-value balance given either as minor units or
+// as an object with amount and currency.
+func paymentBalance(entry map[string]any) map[string]any {
+	value := coalesceAny(entry["balance"], entry["remaining_balance"], entry["available_balance"])
+	if value == nil {
+		return nil
+	}
+	object := asMap(value)
+	amount := value
+	currency := entry["currency"]
+	formatted := ""
+	if object != nil {
+		amount = coalesceAny(object["amount"], object["value"])
+		currency = coalesceAny(object["currency"], currency)
+		formatted = asString(coalesceAny(object["formatted_amount"], object["formatted"]))
+		if amount == nil {
+			return nil
+		}
+	}
+	currencyCode := strings.ToUpper(strings.TrimSpace(asString(currency)))
+	if formatted == "" {
+		formatted = formatMinorAmount(asInt(amount), currencyCode)
+	}
+	expires := coalesceAny(entry["expires_at"], entry["expiry_date"], entry["valid_until"])
+	if object != nil {
+		expires = coalesceAny(object["expires_at"], object["valid_until"], expires)
+	}
+	return map[string]any{
+		"amount":           asInt(amount),
+		"currency":         emptyToNil(currencyCode),
+		"formatted_amount": emptyToNil(formatted),
+		"expires_at":       emptyToNil(asString(expires)),
+	}
+}
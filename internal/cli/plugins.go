@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix names external subcommands: wolt-<name> on PATH runs as wolt <name>.
+const pluginPrefix = "wolt-"
+
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// findPlugin returns the plugin name and executable for args when the first
+// argument is not a built-in command. Built-in commands always win.
+func findPlugin(root *cobra.Command, args []string) (string, string, bool) {
+	if len(args) == 0 {
+		return "", "", false
+	}
+	name := strings.TrimSpace(args[0])
+	if !pluginNamePattern.MatchString(name) || name == "help" {
+		return "", "", false
+	}
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return "", "", false
+		}
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", "", false
+	}
+	return name, path, true
+}
+
+// listPlugins returns the names of wolt-<name> executables on PATH, skipping
+// names shadowed by built-in commands.
+func listPlugins(root *cobra.Command) []string {
+	builtin := map[string]struct{}{}
+	for _, cmd := range root.Commands() {
+		builtin[cmd.Name()] = struct{}{}
+	}
+	seen := map[string]struct{}{}
+	names := []string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), pluginPrefix)
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			} else if info, err := entry.Info(); err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			if _, ok := builtin[name]; ok || !pluginNamePattern.MatchString(name) {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runPlugin executes a plugin with args forwarded unchanged. The resolved
+// profile, output format, and locale are passed in WOLT_* variables; the
+// profile's credentials, and WOLT_WTOKEN/WOLT_WRTOKEN from the CLI's own
+// environment, only when it lists the plugin in plugin_auth.
+func runPlugin(ctx context.Context, deps Dependencies, name string, path string, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	command := exec.CommandContext(ctx, path, args...)
+	command.Stdin = stdin
+	command.Stdout = stdout
+	command.Stderr = stderr
+	command.Env = pluginEnv(ctx, deps, name, args)
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+			return exitErr.ExitCode()
+		}
+		_, _ = fmt.Fprintf(stderr, "plugin %s failed: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+func pluginEnv(ctx context.Context, deps Dependencies, name string, args []string) []string {
+	profileFlag := argumentFlagValue(args, "profile")
+	profile := domain.Profile{Name: defaultProfileName(profileFlag)}
+	if deps.Profiles != nil {
+		if found, err := deps.Profiles.Find(ctx, profileFlag); err == nil {
+			profile = found
+		}
+	}
+	format := strings.ToLower(fallbackString(argumentFlagValue(args, "format"), "table"))
+	locale := fallbackString(argumentFlagValue(args, "locale"), profile.Locale)
+	trusted := slices.Contains(profile.PluginAuth, name)
+
+	env := pluginParentEnv(trusted)
+	env = append(env,
+		"WOLT_PLUGIN_NAME="+name,
+		"WOLT_CLI_VERSION="+resolvedVersion(deps.Version),
+		"WOLT_PROFILE="+profile.Name,
+		"WOLT_FORMAT="+format,
+		"WOLT_LOCALE="+locale,
+		"WOLT_PLUGIN_AUTH="+boolToDigit(trusted),
+		runIDEnv+"="+runIDFromContext(ctx),
+	)
+	if executable, err := os.Executable(); err == nil {
+		env = append(env, "WOLT_CLI_PATH="+executable)
+	}
+	if profile.Location != (domain.Location{}) {
+		env = append(env,
+			"WOLT_LAT="+strconv.FormatFloat(profile.Location.Lat, 'f', -1, 64),
+			"WOLT_LON="+strconv.FormatFloat(profile.Location.Lon, 'f', -1, 64),
+		)
+	}
+	if trusted {
+		auth := authContextFromProfile(profile)
+		env = append(env,
+			"WOLT_WTOKEN="+auth.WToken,
+			"WOLT_WRTOKEN="+auth.RefreshToken,
+			"WOLT_COOKIES="+strings.Join(auth.Cookies, "; "),
+		)
+	}
+	return env
+}
+
+// pluginParentEnv is the CLI's own environment for a plugin, without the
+// injected credential variables unless the plugin is trusted with them.
+func pluginParentEnv(trusted bool) []string {
+	env := os.Environ()
+	if trusted {
+		return env
+	}
+	return slices.DeleteFunc(env, func(entry string) bool {
+		name, _, _ := strings.Cut(entry, "=")
+		return name == wtokenEnv || name == wrtokenEnv
+	})
+}
+
+func boolToDigit(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const lockDirEnv = "WOLT_LOCK_DIR"
+
+// profileLockWait is how long a mutating command waits for another one on
+// the same profile before failing with WOLT_LOCKED.
+var profileLockWait = 3 * time.Second
+
+var errProfileLocked = errors.New("profile is locked")
+
+var profileLockNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func addNoLockFlag(cmd *cobra.Command, noLock *bool) {
+	cmd.Flags().BoolVar(noLock, "no-lock", false, "Skip the per-profile lock that keeps concurrent cart mutations from interleaving.")
+}
+
+// profileLockPath is the lock file for profileName in $WOLT_LOCK_DIR, or in
+// a locks directory next to the config file.
+func profileLockPath(deps Dependencies, profileName string) string {
+	dir, err := stateDir(deps, lockDirEnv, "locks")
+	if err != nil {
+		return ""
+	}
+	name := profileLockNameUnsafe.ReplaceAllString(defaultProfileName(profileName), "_")
+	return filepath.Join(dir, name+".lock")
+}
+
+// lockProfile takes the per-profile lock for a mutating command. The returned
+// release func is never nil; with --no-lock or no config path it does nothing.
+func lockProfile(
+	cmd *cobra.Command,
+	deps Dependencies,
+	noLock bool,
+	format output.Format,
+	profileName string,
+	locale string,
+	outputPath string,
+) (func(), error) {
+	path := profileLockPath(deps, profileName)
+	if noLock || path == "" {
+		return func() {}, nil
+	}
+	release, holder, err := lockFile(cmd.Context(), path, profileLockWait)
+	if errors.Is(err, errProfileLocked) {
+		owner := "another wolt command"
+		if holder > 0 {
+			owner = fmt.Sprintf("another wolt command (pid %d)", holder)
+		}
+		return func() {}, emitError(cmd, format, profileName, locale, outputPath, "WOLT_LOCKED",
+			fmt.Sprintf("profile %q is in use by %s; retry when it finishes or pass --no-lock", profileName, owner))
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return func() {}, err
+		}
+		return func() {}, emitError(cmd, format, profileName, locale, outputPath, "WOLT_PROFILE_ERROR",
+			fmt.Sprintf("could not take the profile lock: %v; pass --no-lock to skip it", err))
+	}
+	return release, nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package cli
+
+import (
+	"context"
+	"time"
+)
+
+// lockFile is a no-op where flock is unavailable.
+func lockFile(context.Context, string, time.Duration) (func(), int, error) {
+	return func() {}, 0, nil
+}
@@ -0,0 +1,57 @@
+//go:build linux || darwin
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func TestCartMutationFailsWhileProfileIsLocked(t *testing.T) {
+	previousWait := profileLockWait
+	profileLockWait = 0
+	defer func() { profileLockWait = previousWait }()
+
+	deps := Dependencies{
+		Wolt: &testWoltAPI{},
+		Profiles: &testProfiles{profile: domain.Profile{
+			Name:     "default",
+			WToken:   "token",
+			Location: domain.Location{Lat: 60.17, Lon: 24.94},
+		}},
+		Config:  &testConfigManager{path: filepath.Join(t.TempDir(), "config.json")},
+		Version: "1.1.1",
+	}
+	path := profileLockPath(deps, "default")
+	release, _, err := lockFile(context.Background(), path, 0)
+	if err != nil {
+		t.Fatalf("lock profile: %v", err)
+	}
+
+	run := func(extra ...string) string {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := append([]string{"cart", "clear", "--format", "json"}, extra...)
+		Execute(context.Background(), args, deps, &stdout, &stderr)
+		return stdout.String() + stderr.String()
+	}
+	out := run()
+	if !strings.Contains(out, `"code": "WOLT_LOCKED"`) || !strings.Contains(out, fmt.Sprintf("pid %d", os.Getpid())) {
+		t.Fatalf("expected WOLT_LOCKED naming the holder, got:\n%s", out)
+	}
+	if out := run("--no-lock"); strings.Contains(out, "WOLT_LOCKED") {
+		t.Fatalf("expected --no-lock to skip the lock, got:\n%s", out)
+	}
+
+	release()
+	if out := run(); strings.Contains(out, "WOLT_LOCKED") {
+		t.Fatalf("expected the released lock to be taken, got:\n%s", out)
+	}
+}
@@ -0,0 +1,57 @@
+//go:build linux || darwin
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// lockFile holds an exclusive flock on path, polling until wait elapses. The
+// file records the holder's pid so a contended caller can name it.
+func lockFile(ctx context.Context, path string, wait time.Duration) (func(), int, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, 0, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, 0, err
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			_ = file.Close()
+			return nil, 0, err
+		}
+		if !time.Now().Before(deadline) {
+			raw, _ := os.ReadFile(path)
+			holder, _ := strconv.Atoi(strings.TrimSpace(string(raw)))
+			_ = file.Close()
+			return nil, holder, errProfileLocked
+		}
+		select {
+		case <-ctx.Done():
+			_ = file.Close()
+			return nil, 0, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	if err := file.Truncate(0); err == nil {
+		_, _ = file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	return func() {
+		_ = file.Truncate(0)
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		_ = file.Close()
+	}, 0, nil
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
+	"github.com/spf13/cobra"
+)
+
+// profileOrdersQuery holds the paging, filter, and sort flags shared by
+// `profile orders` and `profile orders list`. Filters and sorting run on the
+// rows of every collected page, not upstream.
+type profileOrdersQuery struct {
+	limit     int
+	pageToken string
+	maxPages  int
+	status    string
+	venue     string
+	minTotal  string
+	maxTotal  string
+	sort      string
+}
+
+func addProfileOrdersQueryFlags(cmd *cobra.Command, query *profileOrdersQuery) {
+	cmd.Flags().IntVar(&query.limit, "limit", profileOrdersDefaultLimit, "Number of orders to return per page (1-50).")
+	cmd.Flags().StringVar(&query.pageToken, "page-token", "", "Pagination token for older orders.")
+	cmd.Flags().IntVar(&query.maxPages, "max-pages", 1, "Follow next_page_token for up to this many pages before filtering.")
+	cmd.Flags().StringVar(&query.status, "status", "", "Filter orders by status (case-insensitive).")
+	cmd.Flags().StringVar(&query.venue, "venue", "", "Keep orders from a venue, by slug or part of its name (case-insensitive).")
+	cmd.Flags().StringVar(&query.minTotal, "min-total", "", "Keep orders whose total is at least this amount, in major units (e.g. 25.00).")
+	cmd.Flags().StringVar(&query.maxTotal, "max-total", "", "Keep orders whose total is at most this amount, in major units.")
+	cmd.Flags().StringVar(&query.sort, "sort", "", "Sort orders by date or total, newest or largest first.")
+}
+
+func (q *profileOrdersQuery) validate() error {
+	if q.maxPages < 1 {
+		return fmt.Errorf("--max-pages must be at least 1")
+	}
+	q.venue = strings.TrimSpace(q.venue)
+	q.minTotal = strings.TrimSpace(q.minTotal)
+	q.maxTotal = strings.TrimSpace(q.maxTotal)
+	q.sort = strings.ToLower(strings.TrimSpace(q.sort))
+	switch q.sort {
+	case "", "date", "total":
+	default:
+		return fmt.Errorf("--sort must be date or total, got %q", q.sort)
+	}
+	// Amounts are parsed again against each order's currency; a two-digit
+	// exponent is enough to reject malformed input upfront.
+	var minimum, maximum int
+	var err error
+	if q.minTotal != "" {
+		if minimum, err = money.ParseMajor(q.minTotal, ""); err != nil {
+			return fmt.Errorf("--min-total: %w", err)
+		}
+	}
+	if q.maxTotal != "" {
+		if maximum, err = money.ParseMajor(q.maxTotal, ""); err != nil {
+			return fmt.Errorf("--max-total: %w", err)
+		}
+	}
+	if q.minTotal != "" && q.maxTotal != "" && minimum > maximum {
+		return fmt.Errorf("--min-total %s is greater than --max-total %s", q.minTotal, q.maxTotal)
+	}
+	return nil
+}
+
+// apply filters and sorts order rows built by extractOrderHistoryOrders.
+// Orders whose total cannot be read are dropped by the total filters and
+// sort after the others; the warning counts them.
+func (q profileOrdersQuery) apply(orders []any) ([]any, []string) {
+	type orderTotal struct {
+		row      map[string]any
+		total    int
+		readable bool
+	}
+	warnings := []string{}
+	unreadable := 0
+	kept := make([]orderTotal, 0, len(orders))
+	for _, raw := range orders {
+		row := asMap(raw)
+		if row == nil {
+			continue
+		}
+		if q.venue != "" && !orderMatchesVenue(row, q.venue) {
+			continue
+		}
+		formatted := asString(row["total_amount"])
+		currency := inferCurrency(formatted)
+		total, readable := orderTotalMinor(formatted, currency)
+		if q.minTotal != "" || q.maxTotal != "" {
+			if !readable {
+				unreadable++
+				continue
+			}
+			if !q.totalInRange(total, currency) {
+				continue
+			}
+		}
+		kept = append(kept, orderTotal{row: row, total: total, readable: readable})
+	}
+	if unreadable > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d order(s) without a readable total were left out by --min-total/--max-total", unreadable))
+	}
+
+	switch q.sort {
+	case "date":
+		sort.SliceStable(kept, func(i, j int) bool {
+			return asInt(kept[i].row["payment_time_ts"]) > asInt(kept[j].row["payment_time_ts"])
+		})
+	case "total":
+		sort.SliceStable(kept, func(i, j int) bool {
+			if kept[i].readable != kept[j].readable {
+				return kept[i].readable
+			}
+			return kept[i].total > kept[j].total
+		})
+	}
+	rows := make([]any, 0, len(kept))
+	for _, order := range kept {
+		rows = append(rows, order.row)
+	}
+	return rows, warnings
+}
+
+func (q profileOrdersQuery) totalInRange(total int, currency string) bool {
+	if q.minTotal != "" {
+		if minimum, err := money.ParseMajor(q.minTotal, currency); err == nil && total < minimum {
+			return false
+		}
+	}
+	if q.maxTotal != "" {
+		if maximum, err := money.ParseMajor(q.maxTotal, currency); err == nil && total > maximum {
+			return false
+		}
+	}
+	return true
+}
+
+func orderMatchesVenue(row map[string]any, venue string) bool {
+	needle := strings.ToLower(venue)
+	if strings.EqualFold(asString(row["venue_slug"]), venue) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(asString(row["venue_name"])), needle)
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const readOnlyErrorMessage = "read-only mode forbids changes to the Wolt account (set by --read-only or the profile's read_only setting)"
+
+type readOnlySetter interface {
+	SetReadOnly(enabled bool)
+}
+
+// applyReadOnlyMode blocks account-changing gateway requests for this run when
+// --read-only is passed or the profile has read_only set. The profile setting
+// cannot be switched off from the command line.
+func applyReadOnlyMode(cmd *cobra.Command, deps Dependencies) {
+	setter, ok := deps.Wolt.(readOnlySetter)
+	if !ok {
+		return
+	}
+	readOnly, _ := cmd.Flags().GetBool("read-only")
+	if !readOnly && deps.Profiles != nil {
+		profileFlag, _ := cmd.Flags().GetString("profile")
+		if profile, err := deps.Profiles.Find(cmd.Context(), profileFlag); err == nil {
+			readOnly = profile.ReadOnly
+		}
+	}
+	setter.SetReadOnly(readOnly)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+func TestReadOnlyModeRefusesAccountChanges(t *testing.T) {
+	cases := []struct {
+		name    string
+		profile domain.Profile
+		args    []string
+	}{
+		{"flag", domain.Profile{Name: "default"}, []string{"raw", "post", "/v1/baskets", "--body", "{}", "--read-only"}},
+		{"profile setting", domain.Profile{Name: "default", ReadOnly: true}, []string{"raw", "post", "/v1/favorites/venue-1", "--body", "{}"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := Dependencies{
+				Wolt:     woltgateway.NewClient(woltgateway.WithHTTPClient(forbiddenHTTPClient{t: t})),
+				Profiles: &testProfiles{profile: tc.profile},
+				Config:   &testConfigManager{},
+				Version:  "1.1.1",
+			}
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{}, tc.args...), "--wtoken", "token", "--format", "json")
+			if code := Execute(context.Background(), args, deps, &stdout, &stderr); code != 1 {
+				t.Fatalf("expected exit 1, got %d\nstderr:\n%s", code, stderr.String())
+			}
+			var envelope map[string]any
+			if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+				t.Fatalf("decode envelope: %v\n%s", err, stdout.String())
+			}
+			if code := asString(asMap(envelope["error"])["code"]); code != "WOLT_READ_ONLY" {
+				t.Fatalf("expected WOLT_READ_ONLY, got %v", envelope["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func addMaxRequestsFlag(cmd *cobra.Command, maxRequests *int) {
+	cmd.Flags().IntVar(maxRequests, "max-requests", 0, "Abort before expensive crawls estimated to exceed N upstream requests (0 = unlimited)")
+}
+
+func validateMaxRequests(maxRequests int) error {
+	if maxRequests < 0 {
+		return fmt.Errorf("--max-requests must be >= 0")
+	}
+	return nil
+}
+
+// checkRequestBudget aborts with suggestions when an operation's estimated request
+// count exceeds --max-requests. A zero budget disables the check.
+func checkRequestBudget(
+	cmd *cobra.Command,
+	format output.Format,
+	profile string,
+	locale string,
+	outputPath string,
+	maxRequests int,
+	estimate int,
+	operation string,
+	suggestions ...string,
+) error {
+	if maxRequests <= 0 || estimate <= maxRequests {
+		return nil
+	}
+	message := fmt.Sprintf(
+		"%s needs an estimated %d upstream requests, above --max-requests %d",
+		operation,
+		estimate,
+		maxRequests,
+	)
+	if len(suggestions) > 0 {
+		message += "; try " + strings.Join(suggestions, ", or ")
+	}
+	return emitError(cmd, format, profile, locale, outputPath, "WOLT_REQUEST_BUDGET_EXCEEDED", message)
+}
+
+// estimateVenueEnrichmentRequests mirrors the fetch budgets of
+// enrichVenueRowsWithDynamicPromotions for the given rows.
+func estimateVenueEnrichmentRequests(rows []any, promotions bool) int {
+	promoted := map[string]struct{}{}
+	needsWoltPlus := map[string]struct{}{}
+	for _, raw := range rows {
+		row := asMap(raw)
+		if row == nil {
+			continue
+		}
+		slug := strings.TrimSpace(asString(row["slug"]))
+		if slug == "" {
+			continue
+		}
+		if promotions && len(asSlice(row["promotions"])) > 0 {
+			promoted[slug] = struct{}{}
+		}
+		if !asBool(row["wolt_plus"]) {
+			needsWoltPlus[slug] = struct{}{}
+		}
+	}
+
+	dynamic := min(len(promoted), dynamicVenuePromotionFetchBudget)
+	promotedNeedingWoltPlus := 0
+	for slug := range promoted {
+		if _, ok := needsWoltPlus[slug]; ok {
+			promotedNeedingWoltPlus++
+		}
+	}
+	static := min(len(needsWoltPlus), promotedNeedingWoltPlus+staticVenueWoltPlusFetchBudget)
+	return dynamic + static
+}
+
+// estimateFullCatalogRequests counts one category request per crawled category plus
+// the item batches needed to hydrate it.
+func estimateFullCatalogRequests(assortmentPayload map[string]any) int {
+	total := 0
+	walkAssortmentCrawlCategories(assortmentPayload, func(_ string, category map[string]any) {
+		total++
+		itemCount := len(asSlice(category["item_ids"]))
+		total += (itemCount + assortmentItemsBatchSize - 1) / assortmentItemsBatchSize
+	})
+	return total
+}
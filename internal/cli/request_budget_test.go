@@ -0,0 +1,43 @@
+package cli
+
+import "testing"
+
+func TestEstimateFullCatalogRequestsCountsHydrationBatches(t *testing.T) {
+	itemIDs := make([]any, assortmentItemsBatchSize+1)
+	for idx := range itemIDs {
+		itemIDs[idx] = "item"
+	}
+	payload := map[string]any{
+		"categories": []any{
+			map[string]any{"slug": "dairy", "item_ids": itemIDs},
+			map[string]any{
+				"slug": "bakery",
+				"subcategories": []any{
+					map[string]any{"slug": "bread"},
+					map[string]any{"slug": "bread"},
+				},
+			},
+		},
+	}
+	// dairy: 1 category + 2 item batches; bread: 1 category; bakery itself is a parent without items.
+	if got := estimateFullCatalogRequests(payload); got != 4 {
+		t.Fatalf("expected 4 requests, got %d", got)
+	}
+}
+
+func TestEstimateVenueEnrichmentRequestsDedupesSlugs(t *testing.T) {
+	rows := []any{
+		map[string]any{"slug": "a", "promotions": []any{"-20%"}},
+		map[string]any{"slug": "a", "promotions": []any{"-20%"}},
+		map[string]any{"slug": "b", "wolt_plus": true},
+		map[string]any{"slug": "c"},
+	}
+	// a: dynamic + static; c: static; b is already Wolt+.
+	if got := estimateVenueEnrichmentRequests(rows, true); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+	// Wolt+ only: static pages for a and c.
+	if got := estimateVenueEnrichmentRequests(rows, false); got != 2 {
+		t.Fatalf("expected 2 Wolt+ requests, got %d", got)
+	}
+}
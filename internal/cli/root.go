@@ -5,7 +5,9 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -14,12 +16,41 @@ var sharedGlobalOptionOrder = []string{
 	"format",
 	"profile",
 	"address",
+	"lat",
+	"lon",
 	"locale",
+	"tz",
+	"time-format",
+	"json-naming",
 	"no-color",
+	"output",
+	"no-pager",
+	"max-rows",
+	"columns",
+	"max-col-width",
+	"col-width",
+	"full-width",
+	"layout",
 	"wtoken",
 	"wrtoken",
+	"wtoken-stdin",
 	"cookie",
+	"auth-preflight",
+	"user-agent",
 	"verbose",
+	"stats",
+	"explain-request",
+	"explain-only",
+	"reveal-secrets",
+	"save-session",
+	"offline",
+	"read-only",
+	"low-bandwidth",
+	"report-degradation",
+	"machine",
+	"meta",
+	"expect",
+	"expect-nonempty",
 }
 
 var sharedGlobalOptionIndex = func() map[string]int {
@@ -51,7 +82,61 @@ func NewRootCommand(deps Dependencies) *cobra.Command {
 			return cmd.Help()
 		},
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			applyRevealSecrets(cmd)
+			if err := applyInjectedCredentials(cmd); err != nil {
+				return err
+			}
 			attachVerboseHTTPTrace(cmd, deps.Wolt)
+			applySaveSession(cmd, deps)
+			applyUserAgent(cmd, deps.Wolt)
+			attachTokenRefreshHandler(cmd, deps)
+			attachCapabilityGate(cmd, deps)
+			applyOfflineMode(cmd, deps)
+			applyReadOnlyMode(cmd, deps)
+			applyLocaleDefault(cmd, deps)
+			applyMoneyLocale(cmd)
+			if err := applyTimeDisplay(cmd, deps); err != nil {
+				return err
+			}
+			if _, err := tableColumnWidths(cmd); err != nil {
+				return err
+			}
+			if err := checkJSONNaming(cmd); err != nil {
+				return err
+			}
+			if err := applySQLiteOutput(cmd); err != nil {
+				return err
+			}
+			if err := applyHASensorFormat(cmd); err != nil {
+				return err
+			}
+			if err := applyBeancountFormat(cmd); err != nil {
+				return err
+			}
+			if err := applyICSFormat(cmd); err != nil {
+				return err
+			}
+			if err := applyMarkdownFormat(cmd); err != nil {
+				return err
+			}
+			if err := applyMachineMode(cmd); err != nil {
+				return err
+			}
+			if err := applyEnvelopeTags(cmd); err != nil {
+				return err
+			}
+			if err := applyExpectations(cmd); err != nil {
+				return err
+			}
+			if err := applyExplainRequest(cmd, deps); err != nil {
+				return err
+			}
+			if err := applyAuthPreflight(cmd, deps); err != nil {
+				return err
+			}
+			if err := runPreCommandHooks(cmd, deps); err != nil {
+				return err
+			}
 			showVersion, _ := cmd.Flags().GetBool("version")
 			if !showVersion {
 				return nil
@@ -74,12 +159,33 @@ func NewRootCommand(deps Dependencies) *cobra.Command {
 	root.AddCommand(newDiscoverCommand(deps))
 	root.AddCommand(newSearchCommand(deps))
 	root.AddCommand(newVenueCommand(deps))
+	root.AddCommand(newChainCommand(deps))
+	root.AddCommand(newMarketCommand(deps))
 	root.AddCommand(newItemCommand(deps))
 	root.AddCommand(newAuthCommand(deps))
+	root.AddCommand(newWhoamiCommand(deps))
 	root.AddCommand(newCartCommand(deps))
 	root.AddCommand(newCheckoutCommand(deps))
 	root.AddCommand(newProfileCommand(deps))
 	root.AddCommand(newConfigureCommand(deps))
+	root.AddCommand(newConfigCommand(deps))
+	root.AddCommand(newSuggestCommand(deps))
+	root.AddCommand(newPickCommand(deps))
+	root.AddCommand(newTrackCommand(deps))
+	root.AddCommand(newNotesCommand(deps))
+	root.AddCommand(newRateCommand(deps))
+	root.AddCommand(newBudgetCommand(deps))
+	root.AddCommand(newDigestCommand(deps))
+	root.AddCommand(newApproveCommand(deps))
+	root.AddCommand(newPlanCommand(deps))
+	root.AddCommand(newScheduleCommand(deps))
+	root.AddCommand(newDebugCommand(deps))
+	root.AddCommand(newRawCommand(deps))
+	root.AddCommand(newStatusCommand(deps))
+	root.AddCommand(newStCommand(deps))
+	root.AddCommand(newTravelCommand(deps))
+	root.AddCommand(newRetryCommand(deps))
+	root.AddCommand(newDiffCommand(deps))
 
 	return root
 }
@@ -104,6 +210,94 @@ func attachVerboseHTTPTrace(cmd *cobra.Command, upstream any) {
 	_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "[verbose] http trace enabled")
 }
 
+type userAgentSetter interface {
+	SetUserAgent(userAgent string)
+}
+
+// applyUserAgent sets --user-agent for this run; an unset flag clears the
+// value a previous in-process run may have left.
+func applyUserAgent(cmd *cobra.Command, upstream any) {
+	setter, ok := upstream.(userAgentSetter)
+	if !ok {
+		return
+	}
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	setter.SetUserAgent(userAgent)
+}
+
+// writeRequestTimingSummary prints per-endpoint latency after a --verbose run.
+func writeRequestTimingSummary(out io.Writer, timings *woltgateway.RequestTimings) {
+	for _, timing := range timings.Summary() {
+		_, _ = fmt.Fprintf(
+			out,
+			"[http] timing %s count=%d p50=%s p95=%s max=%s\n",
+			timing.Family,
+			timing.Count,
+			timing.P50.Round(time.Millisecond),
+			timing.P95.Round(time.Millisecond),
+			timing.Max.Round(time.Millisecond),
+		)
+	}
+}
+
+type requestFamilyIntervalReporter interface {
+	RequestMinIntervals() map[string]time.Duration
+}
+
+type responseCacheReporter interface {
+	ResponseCacheStats() (woltgateway.ResponseCacheStats, bool)
+}
+
+// writeRequestStats prints upstream request counts after a --stats run,
+// followed by the memory cache counters and the request pacing in effect.
+func writeRequestStats(out io.Writer, timings *woltgateway.RequestTimings, upstream any) {
+	summary := timings.Summary()
+	requests, deduplicated, received := 0, 0, 0
+	for _, timing := range summary {
+		requests += timing.Count
+		deduplicated += timing.Deduplicated
+		received += timing.Bytes
+	}
+	_, _ = fmt.Fprintf(out, "[stats] requests=%d deduplicated=%d bytes_received=%d\n", requests, deduplicated, received)
+	for _, timing := range summary {
+		line := fmt.Sprintf("[stats] %s requests=%d deduplicated=%d bytes_received=%d", timing.Family, timing.Count, timing.Deduplicated, timing.Bytes)
+		if timing.CacheHits > 0 || timing.CacheMisses > 0 {
+			line += fmt.Sprintf(" cache_hits=%d cache_misses=%d", timing.CacheHits, timing.CacheMisses)
+		}
+		_, _ = fmt.Fprintln(out, line)
+	}
+	if reporter, ok := upstream.(responseCacheReporter); ok {
+		if stats, enabled := reporter.ResponseCacheStats(); enabled {
+			_, _ = fmt.Fprintf(out, "[stats] memory_cache entries=%d/%d hits=%d misses=%d\n", stats.Entries, stats.Size, stats.Hits, stats.Misses)
+		}
+	}
+	if line := requestPacingLine(upstream); line != "" {
+		_, _ = fmt.Fprintln(out, line)
+	}
+}
+
+// requestPacingLine lists the shared minimum interval and each per-family
+// override, as in "[stats] min_interval default=220ms venue_page_static=100ms".
+func requestPacingLine(upstream any) string {
+	shared, ok := upstream.(requestMinIntervalReporter)
+	if !ok {
+		return ""
+	}
+	parts := []string{"default=" + shared.RequestMinInterval().String()}
+	if reporter, ok := upstream.(requestFamilyIntervalReporter); ok {
+		intervals := reporter.RequestMinIntervals()
+		families := make([]string, 0, len(intervals))
+		for family := range intervals {
+			families = append(families, family)
+		}
+		sort.Strings(families)
+		for _, family := range families {
+			parts = append(parts, family+"="+intervals[family].String())
+		}
+	}
+	return "[stats] min_interval " + strings.Join(parts, " ")
+}
+
 func renderRootHelp(out io.Writer, root *cobra.Command) {
 	_, _ = fmt.Fprintf(out, "%s: %s\n\n", root.Name(), root.Short)
 	_, _ = fmt.Fprintf(out, "usage: %s <command> [options]\n", root.Name())
@@ -118,6 +312,14 @@ func renderRootHelp(out io.Writer, root *cobra.Command) {
 		_, _ = fmt.Fprintf(out, "  %s\n", cmd.Name())
 		_, _ = fmt.Fprintf(out, "    %s\n", cmd.Short)
 	}
+	if plugins := listPlugins(root); len(plugins) > 0 {
+		_, _ = fmt.Fprintln(out)
+		_, _ = fmt.Fprintln(out, "plugins:")
+		for _, name := range plugins {
+			_, _ = fmt.Fprintf(out, "  %s\n", name)
+			_, _ = fmt.Fprintf(out, "    external command %s%s\n", pluginPrefix, name)
+		}
+	}
 
 	_, _ = fmt.Fprintln(out)
 	_, _ = fmt.Fprintln(out, "notes:")
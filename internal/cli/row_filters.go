@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"cmp"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
+	"github.com/spf13/cobra"
 )
 
 type itemRowSort string
@@ -12,6 +17,7 @@ const (
 	itemRowSortRecommended itemRowSort = "recommended"
 	itemRowSortPrice       itemRowSort = "price"
 	itemRowSortName        itemRowSort = "name"
+	itemRowSortUnitPrice   itemRowSort = "unit-price"
 )
 
 type venueRowFilters struct {
@@ -23,10 +29,8 @@ type venueRowFilters struct {
 }
 
 type itemRowFilters struct {
-	MinPriceSet   bool
-	MinPrice      int
-	MaxPriceSet   bool
-	MaxPrice      int
+	MinPrice      priceFlag
+	MaxPrice      priceFlag
 	HideSoldOut   bool
 	DiscountsOnly bool
 }
@@ -55,6 +59,60 @@ func applyVenueRowFilters(rows []any, filters venueRowFilters) []any {
 	return filtered
 }
 
+// priceFlag is a --min-price/--max-price value. Whole numbers keep the
+// historical meaning of minor units (750 = 7.50); a value with a decimal
+// separator, "7.50" or "9,90", is in major units and is converted with the
+// exponent of the currency it is compared against.
+type priceFlag struct {
+	text    string
+	amount  int
+	decimal bool
+	set     bool
+}
+
+func (p *priceFlag) String() string { return p.text }
+
+func (p *priceFlag) Type() string { return "price" }
+
+func (p *priceFlag) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if minor, err := strconv.Atoi(value); err == nil {
+		if minor < 0 {
+			return fmt.Errorf("must be >= 0")
+		}
+		*p = priceFlag{text: value, amount: minor, set: true}
+		return nil
+	}
+	if _, err := money.ParseMajor(value, ""); err != nil {
+		return fmt.Errorf("expected minor units (750) or a decimal amount (7.50 or 7,50)")
+	}
+	*p = priceFlag{text: value, decimal: true, set: true}
+	return nil
+}
+
+// minor returns the limit in minor units of currency.
+func (p priceFlag) minor(currency string) int {
+	if !p.decimal {
+		return p.amount
+	}
+	amount, _ := money.ParseMajor(p.text, currency)
+	return amount
+}
+
+func addItemPriceFlags(cmd *cobra.Command, minPrice *priceFlag, maxPrice *priceFlag) {
+	cmd.Flags().Var(minPrice, "min-price", "Minimum item base price: minor units (750) or a decimal amount (7.50, 7,50)")
+	cmd.Flags().Var(maxPrice, "max-price", "Maximum item base price: minor units (990) or a decimal amount (9.90, 9,90)")
+}
+
+// validatePriceRange compares the limits in a two-digit currency, which is
+// exact when both use the same notation.
+func validatePriceRange(minPrice priceFlag, maxPrice priceFlag) error {
+	if minPrice.set && maxPrice.set && minPrice.minor("") > maxPrice.minor("") {
+		return fmt.Errorf("--min-price cannot be greater than --max-price")
+	}
+	return nil
+}
+
 func applyItemRowFilters(rows []any, filters itemRowFilters) []any {
 	if len(rows) == 0 {
 		return rows
@@ -71,11 +129,13 @@ func applyItemRowFilters(rows []any, filters itemRowFilters) []any {
 		if filters.DiscountsOnly && !itemHasDiscount(row) {
 			continue
 		}
-		amount := asInt(asMap(row["base_price"])["amount"])
-		if filters.MinPriceSet && amount < filters.MinPrice {
+		price := asMap(row["base_price"])
+		amount := asInt(price["amount"])
+		currency := asString(price["currency"])
+		if filters.MinPrice.set && amount < filters.MinPrice.minor(currency) {
 			continue
 		}
-		if filters.MaxPriceSet && amount > filters.MaxPrice {
+		if filters.MaxPrice.set && amount > filters.MaxPrice.minor(currency) {
 			continue
 		}
 		filtered = append(filtered, row)
@@ -104,10 +164,10 @@ func parseItemRowSort(raw string) (itemRowSort, error) {
 		return itemRowSortRecommended, nil
 	}
 	switch value {
-	case itemRowSortRecommended, itemRowSortPrice, itemRowSortName:
+	case itemRowSortRecommended, itemRowSortPrice, itemRowSortName, itemRowSortUnitPrice:
 		return value, nil
 	default:
-		return "", fmt.Errorf("invalid --sort value %q; expected one of: recommended, price, name", raw)
+		return "", fmt.Errorf("invalid --sort value %q; expected one of: recommended, price, name, unit-price", raw)
 	}
 }
 
@@ -115,20 +175,58 @@ func sortItemRows(rows []any, sortMode itemRowSort) {
 	if len(rows) == 0 || sortMode == itemRowSortRecommended {
 		return
 	}
+	switch sortMode {
+	case itemRowSortPrice:
+		sortRowsByKey(rows, "item_id", func(row map[string]any) any {
+			return asInt(asMap(row["base_price"])["amount"])
+		}, compareIntSortKeys)
+	case itemRowSortName:
+		sortRowsByKey(rows, "item_id", rowNameSortKey, compareStringSortKeys)
+	case itemRowSortUnitPrice:
+		sortRowsByKey(rows, "item_id", unitPriceSortKey, compareUnitPriceSortKeys)
+	}
+}
+
+// sortRowsByKey stores each row's extracted key in sort_key and orders rows
+// by it. Equal keys fall back to the lower-cased name and then idField, so
+// the order never depends on the upstream order.
+func sortRowsByKey(rows []any, idField string, key func(map[string]any) any, compare func(left any, right any) int) {
+	for _, rowValue := range rows {
+		if row := asMap(rowValue); row != nil {
+			row["sort_key"] = key(row)
+		}
+	}
 	sort.SliceStable(rows, func(i, j int) bool {
-		left := asMap(rows[i])
-		right := asMap(rows[j])
-		switch sortMode {
-		case itemRowSortPrice:
-			return asInt(asMap(left["base_price"])["amount"]) < asInt(asMap(right["base_price"])["amount"])
-		case itemRowSortName:
-			return strings.ToLower(strings.TrimSpace(asString(left["name"]))) < strings.ToLower(strings.TrimSpace(asString(right["name"])))
-		default:
-			return false
+		left, right := asMap(rows[i]), asMap(rows[j])
+		if order := compare(left["sort_key"], right["sort_key"]); order != 0 {
+			return order < 0
+		}
+		if order := strings.Compare(asString(rowNameSortKey(left)), asString(rowNameSortKey(right))); order != 0 {
+			return order < 0
 		}
+		return asString(left[idField]) < asString(right[idField])
 	})
 }
 
+func rowNameSortKey(row map[string]any) any {
+	return strings.ToLower(strings.TrimSpace(asString(row["name"])))
+}
+
+func compareIntSortKeys(left any, right any) int {
+	return cmp.Compare(asInt(left), asInt(right))
+}
+
+// compareRatingSortKeys puts higher ratings first.
+func compareRatingSortKeys(left any, right any) int {
+	leftRating, _ := asFloat(left)
+	rightRating, _ := asFloat(right)
+	return cmp.Compare(rightRating, leftRating)
+}
+
+func compareStringSortKeys(left any, right any) int {
+	return strings.Compare(asString(left), asString(right))
+}
+
 func venueRowRating(row map[string]any) float64 {
 	if row == nil {
 		return 0
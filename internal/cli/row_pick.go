@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// rowPick holds the row selection flags shared by list commands.
+type rowPick struct {
+	First       bool
+	Interactive bool
+}
+
+// rowPicker describes how a list command labels rows and which identifiers it returns.
+type rowPicker struct {
+	Label       func(map[string]any) string
+	Identifiers func(map[string]any) []string
+}
+
+func addRowPickFlags(cmd *cobra.Command, pick *rowPick, identifiers string) {
+	cmd.Flags().BoolVar(&pick.First, "pick-first", false, "Print only the first row's "+identifiers+" (feeds the next command after --then)")
+	cmd.Flags().BoolVar(&pick.Interactive, "pick", false, "Choose a row interactively (TTY only) and print only its "+identifiers)
+}
+
+func (p rowPick) enabled() bool {
+	return p.First || p.Interactive
+}
+
+var isInteractiveInput = func(in io.Reader) bool {
+	file, ok := in.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// emitPickedRow writes the identifiers of the selected row instead of the full payload.
+// Inside a --then chain the identifiers are handed over to the next stage.
+func emitPickedRow(
+	cmd *cobra.Command,
+	format output.Format,
+	profile string,
+	locale string,
+	outputPath string,
+	pick rowPick,
+	rows []any,
+	picker rowPicker,
+) error {
+	candidates := make([]map[string]any, 0, len(rows))
+	identifiers := make([][]string, 0, len(rows))
+	for _, value := range rows {
+		row := asMap(value)
+		if row == nil {
+			continue
+		}
+		ids := completeIdentifiers(picker.Identifiers(row))
+		if len(ids) == 0 {
+			continue
+		}
+		candidates = append(candidates, row)
+		identifiers = append(identifiers, ids)
+		if pick.First && !pick.Interactive {
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		return emitError(cmd, format, profile, locale, outputPath, "WOLT_NOT_FOUND", "no rows matched; nothing to pick")
+	}
+
+	selected := 0
+	if pick.Interactive && machineMode(cmd) {
+		return emitError(cmd, format, profile, locale, outputPath, "WOLT_INVALID_ARGUMENT", "--pick prompts are disabled in --machine mode; use --pick-first")
+	}
+	if pick.Interactive {
+		if !isInteractiveInput(cmd.InOrStdin()) {
+			return emitError(cmd, format, profile, locale, outputPath, "WOLT_INVALID_ARGUMENT", "--pick requires an interactive terminal; use --pick-first in scripts")
+		}
+		index, err := promptRowSelection(cmd.InOrStdin(), cmd.ErrOrStderr(), candidates, picker.Label)
+		if err != nil {
+			return emitError(cmd, format, profile, locale, outputPath, "WOLT_INVALID_ARGUMENT", err.Error())
+		}
+		selected = index
+	}
+
+	ids := identifiers[selected]
+	if selection := chainSelectionFromContext(cmd.Context()); selection != nil {
+		selection.IDs = ids
+		selection.Picked = true
+		return nil
+	}
+	if machineMode(cmd) {
+		env := output.BuildEnvelope(profile, locale, map[string]any{"identifiers": ids}, nil, nil)
+		return writeMachinePayload(cmd, env, format, outputPath)
+	}
+	return output.WriteOutput(cmd.OutOrStdout(), strings.Join(ids, " "), outputPath)
+}
+
+func promptRowSelection(in io.Reader, out io.Writer, rows []map[string]any, label func(map[string]any) string) (int, error) {
+	for idx, row := range rows {
+		_, _ = fmt.Fprintf(out, "%3d) %s\n", idx+1, label(row))
+	}
+	_, _ = fmt.Fprintf(out, "Pick a row [1-%d]: ", len(rows))
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	answer := strings.TrimSpace(line)
+	if answer == "" {
+		if err != nil && err != io.EOF {
+			return 0, fmt.Errorf("read selection: %w", err)
+		}
+		return 0, fmt.Errorf("selection cancelled")
+	}
+	choice, convErr := strconv.Atoi(answer)
+	if convErr != nil || choice < 1 || choice > len(rows) {
+		return 0, fmt.Errorf("invalid selection %q, expected a number between 1 and %d", answer, len(rows))
+	}
+	return choice - 1, nil
+}
+
+// completeIdentifiers returns trimmed identifiers, or nil when any of them is missing.
+func completeIdentifiers(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			return nil
+		}
+		out = append(out, trimmed)
+	}
+	return out
+}
+
+func venueRowPicker() rowPicker {
+	return rowPicker{
+		Label: func(row map[string]any) string {
+			return fmt.Sprintf("%s (%s)", fallbackString(asString(row["name"]), "-"), fallbackString(asString(row["slug"]), "-"))
+		},
+		Identifiers: func(row map[string]any) []string {
+			return []string{fallbackString(asString(row["slug"]), asString(row["venue_id"]))}
+		},
+	}
+}
+
+func itemRowPicker(venueID func(map[string]any) string) rowPicker {
+	return rowPicker{
+		Label: func(row map[string]any) string {
+			price := asString(asMap(row["base_price"])["formatted_amount"])
+			return fmt.Sprintf("%s  %s", fallbackString(asString(row["name"]), "-"), fallbackString(price, "-"))
+		},
+		Identifiers: func(row map[string]any) []string {
+			return []string{venueID(row), asString(row["item_id"])}
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteIdentifiersRejectsMissingValues(t *testing.T) {
+	if ids := completeIdentifiers([]string{" venue-1 ", "item-1"}); !reflect.DeepEqual(ids, []string{"venue-1", "item-1"}) {
+		t.Fatalf("unexpected identifiers: %#v", ids)
+	}
+	if ids := completeIdentifiers([]string{"venue-1", " "}); ids != nil {
+		t.Fatalf("expected nil for incomplete identifiers, got %#v", ids)
+	}
+}
+
+func TestEmitPickedRowInteractiveSelection(t *testing.T) {
+	restore := isInteractiveInput
+	isInteractiveInput = func(io.Reader) bool { return true }
+	defer func() { isInteractiveInput = restore }()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("2\n"))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetContext(context.Background())
+
+	rows := []any{
+		map[string]any{"name": "Burger One", "slug": "burger-one"},
+		map[string]any{"name": "Burger Two", "slug": "burger-two"},
+	}
+	err := emitPickedRow(cmd, output.FormatTable, "default", "en-FI", "", rowPick{Interactive: true}, rows, venueRowPicker())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "burger-two" {
+		t.Fatalf("expected only picked slug on stdout, got %q", got)
+	}
+	if !strings.Contains(stderr.String(), "2) Burger Two (burger-two)") {
+		t.Fatalf("expected numbered rows on stderr, got %q", stderr.String())
+	}
+}
+
+func TestEmitPickedRowInteractiveRequiresTTY(t *testing.T) {
+	var stdout bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("1\n"))
+	cmd.SetOut(&stdout)
+	cmd.SetContext(context.Background())
+
+	rows := []any{map[string]any{"name": "Burger One", "slug": "burger-one"}}
+	err := emitPickedRow(cmd, output.FormatTable, "default", "en-FI", "", rowPick{Interactive: true}, rows, venueRowPicker())
+	if err == nil {
+		t.Fatalf("expected error without a terminal")
+	}
+	if !strings.Contains(stdout.String(), "--pick-first") {
+		t.Fatalf("expected hint towards --pick-first, got %q", stdout.String())
+	}
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+// searchCity is a city picked with --city, centered where the cities
+// endpoint places it.
+type searchCity struct {
+	name     string
+	slug     string
+	location domain.Location
+}
+
+// resolveSearchCities matches each requested name against the cities Wolt
+// operates in, by name or slug and ignoring case. It returns the matched
+// cities in request order and the names that matched nothing.
+func resolveSearchCities(payload map[string]any, names []string) ([]searchCity, []string) {
+	known := []searchCity{}
+	for _, value := range asSlice(payload["results"]) {
+		city := asMap(value)
+		coordinates := asSlice(asMap(city["location"])["coordinates"])
+		if len(coordinates) < 2 {
+			continue
+		}
+		lon, lonOK := asFloat(coordinates[0])
+		lat, latOK := asFloat(coordinates[1])
+		if !lonOK || !latOK {
+			continue
+		}
+		known = append(known, searchCity{
+			name:     strings.TrimSpace(asString(city["name"])),
+			slug:     strings.TrimSpace(asString(city["slug"])),
+			location: domain.Location{Lat: lat, Lon: lon},
+		})
+	}
+
+	cities := []searchCity{}
+	unknown := []string{}
+	seen := map[string]bool{}
+	for _, name := range dedupeStrings(names) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		matched := false
+		for _, city := range known {
+			if strings.EqualFold(city.name, name) || strings.EqualFold(city.slug, name) {
+				if !seen[city.slug+"|"+city.name] {
+					seen[city.slug+"|"+city.name] = true
+					cities = append(cities, city)
+				}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unknown = append(unknown, name)
+		}
+	}
+	return cities, unknown
+}
+
+// searchCityItems runs the venue listing for every city at once and merges
+// the results in city order. A venue listed in several cities is kept once,
+// under the first; cityByVenue maps venue ids to that city's name. Cities
+// whose listing failed come back as warnings; err is set only when every
+// city failed.
+func searchCityItems(ctx context.Context, deps Dependencies, cities []searchCity) ([]domain.Item, map[string]string, []string, error) {
+	listings := make([][]domain.Item, len(cities))
+	failures := make([]error, len(cities))
+	workers := sync.WaitGroup{}
+	for idx, city := range cities {
+		workers.Go(func() {
+			listings[idx], failures[idx] = deps.Wolt.Items(ctx, city.location)
+		})
+	}
+	workers.Wait()
+
+	items := []domain.Item{}
+	cityByVenue := map[string]string{}
+	warnings := []string{}
+	var lastErr error
+	for idx, city := range cities {
+		if failures[idx] != nil {
+			lastErr = failures[idx]
+			recordPartialFailure(ctx, "city venue search", failures[idx])
+			warnings = append(warnings, fmt.Sprintf("venue search for %s failed: %v", city.name, failures[idx]))
+			continue
+		}
+		for _, item := range listings[idx] {
+			venueID := ""
+			if item.Venue != nil {
+				venueID = domain.NormalizeID(coalesceAny(item.Venue.ID, item.Link.Target))
+			}
+			if venueID != "" {
+				if _, seen := cityByVenue[venueID]; seen {
+					continue
+				}
+				cityByVenue[venueID] = city.name
+			}
+			items = append(items, item)
+		}
+	}
+	if len(warnings) == len(cities) {
+		return nil, nil, nil, lastErr
+	}
+	return items, cityByVenue, warnings, nil
+}
+
+// tagVenueRowsWithCity sets city on each venue row from cityByVenue.
+func tagVenueRowsWithCity(rows []any, cityByVenue map[string]string) {
+	for _, value := range rows {
+		if row := asMap(value); row != nil {
+			row["city"] = emptyToNil(cityByVenue[asString(row["venue_id"])])
+		}
+	}
+}
+
+// annotateCityVenueDistances measures each row from the center of the city
+// it was found in, with annotateVenueDistances' radius rule.
+func annotateCityVenueDistances(rows []any, cities []searchCity, radiusKm float64) ([]any, int) {
+	centers := map[string]domain.Location{}
+	for _, city := range cities {
+		centers[city.name] = city.location
+	}
+	kept := make([]any, 0, len(rows))
+	unknown := 0
+	for _, value := range rows {
+		row := asMap(value)
+		if row == nil {
+			continue
+		}
+		center, ok := centers[asString(row["city"])]
+		if !ok && len(cities) > 0 {
+			center = cities[0].location
+		}
+		annotated, missing := annotateVenueDistances([]any{row}, center, radiusKm)
+		kept = append(kept, annotated...)
+		unknown += missing
+	}
+	return kept, unknown
+}
+
+func searchCitiesData(cities []searchCity) []any {
+	out := make([]any, 0, len(cities))
+	for _, city := range cities {
+		out = append(out, map[string]any{
+			"name": city.name,
+			"slug": emptyToNil(city.slug),
+			"lat":  city.location.Lat,
+			"lon":  city.location.Lon,
+		})
+	}
+	return out
+}
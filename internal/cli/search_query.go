@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// searchClause is one OR branch of a venue search query: an item matches
+// when its name contains every term of All and none of None.
+type searchClause struct {
+	All  []string
+	None []string
+}
+
+// searchQuery is a parsed --query: the OR of its clauses. AND binds tighter
+// than OR, and terms next to each other are ANDed.
+type searchQuery struct {
+	AnyOf []searchClause
+}
+
+type searchToken struct {
+	text     string
+	negated  bool
+	operator bool
+}
+
+// parseSearchQuery parses "oat AND barista OR \"oat drink\" -organic". The
+// second result is false for a plain query without operators, quotes, or
+// exclusions, which is sent upstream unchanged.
+func parseSearchQuery(raw string) (searchQuery, bool, error) {
+	tokens, advanced, err := tokenizeSearchQuery(raw)
+	if err != nil || !advanced {
+		return searchQuery{}, false, err
+	}
+	query := searchQuery{}
+	clause := searchClause{}
+	previousOperator := "OR"
+	for _, token := range tokens {
+		if token.operator {
+			if previousOperator != "" {
+				return searchQuery{}, false, fmt.Errorf("--query: %s must stand between two terms", token.text)
+			}
+			previousOperator = token.text
+			if token.text == "OR" {
+				query.AnyOf = append(query.AnyOf, clause)
+				clause = searchClause{}
+			}
+			continue
+		}
+		previousOperator = ""
+		if token.negated {
+			clause.None = append(clause.None, token.text)
+		} else {
+			clause.All = append(clause.All, token.text)
+		}
+	}
+	if previousOperator != "" {
+		return searchQuery{}, false, fmt.Errorf("--query must not end with %s", previousOperator)
+	}
+	query.AnyOf = append(query.AnyOf, clause)
+	for _, clause := range query.AnyOf {
+		if len(clause.All) == 0 {
+			return searchQuery{}, false, fmt.Errorf("--query: every OR branch needs a term without -")
+		}
+	}
+	return query, true, nil
+}
+
+func tokenizeSearchQuery(raw string) ([]searchToken, bool, error) {
+	tokens := []searchToken{}
+	advanced := false
+	runes := []rune(strings.TrimSpace(raw))
+	for idx := 0; idx < len(runes); {
+		if unicode.IsSpace(runes[idx]) {
+			idx++
+			continue
+		}
+		negated := false
+		if runes[idx] == '-' && idx+1 < len(runes) && !unicode.IsSpace(runes[idx+1]) {
+			negated = true
+			idx++
+		}
+		if runes[idx] == '"' {
+			end := idx + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, false, fmt.Errorf("--query has an unclosed quote")
+			}
+			phrase := strings.Join(strings.Fields(string(runes[idx+1:end])), " ")
+			if phrase == "" {
+				return nil, false, fmt.Errorf("--query has an empty quoted phrase")
+			}
+			tokens = append(tokens, searchToken{text: strings.ToLower(phrase), negated: negated})
+			advanced = true
+			idx = end + 1
+			continue
+		}
+		end := idx
+		for end < len(runes) && !unicode.IsSpace(runes[end]) {
+			end++
+		}
+		word := string(runes[idx:end])
+		idx = end
+		if !negated && (word == "AND" || word == "OR") {
+			tokens = append(tokens, searchToken{text: word, operator: true})
+			advanced = true
+			continue
+		}
+		advanced = advanced || negated
+		tokens = append(tokens, searchToken{text: strings.ToLower(word), negated: negated})
+	}
+	return tokens, advanced, nil
+}
+
+// upstreamQuery is the text sent to the venue search for clause: its longest
+// term, which keeps upstream matching broad while the clause filters the
+// results.
+func (c searchClause) upstreamQuery() string {
+	best := ""
+	for _, term := range c.All {
+		if len([]rune(term)) > len([]rune(best)) {
+			best = term
+		}
+	}
+	return best
+}
+
+// upstreamQueries returns the distinct texts to send upstream, in clause order.
+func (q searchQuery) upstreamQueries() []string {
+	queries := make([]string, 0, len(q.AnyOf))
+	for _, clause := range q.AnyOf {
+		queries = append(queries, clause.upstreamQuery())
+	}
+	return dedupeStrings(queries)
+}
+
+// matches reports whether name satisfies any clause, ignoring case.
+func (q searchQuery) matches(name string) bool {
+	name = strings.ToLower(name)
+	for _, clause := range q.AnyOf {
+		if clause.matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c searchClause) matches(name string) bool {
+	for _, term := range c.All {
+		if !strings.Contains(name, term) {
+			return false
+		}
+	}
+	for _, term := range c.None {
+		if strings.Contains(name, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// data echoes the parsed query so callers can check how it was read.
+func (q searchQuery) data() map[string]any {
+	clauses := make([]any, 0, len(q.AnyOf))
+	for _, clause := range q.AnyOf {
+		clauses = append(clauses, map[string]any{
+			"all_of":         append([]string{}, clause.All...),
+			"none_of":        append([]string{}, clause.None...),
+			"upstream_query": clause.upstreamQuery(),
+		})
+	}
+	return map[string]any{"any_of": clauses}
+}
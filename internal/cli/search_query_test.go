@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchQueryBuildsClausesAndMatchesNames(t *testing.T) {
+	query, advanced, err := parseSearchQuery(`oat AND barista OR "oat drink" -organic`)
+	if err != nil || !advanced {
+		t.Fatalf("parseSearchQuery: advanced=%v err=%v", advanced, err)
+	}
+	want := []searchClause{
+		{All: []string{"oat", "barista"}},
+		{All: []string{"oat drink"}, None: []string{"organic"}},
+	}
+	if !reflect.DeepEqual(query.AnyOf, want) {
+		t.Fatalf("clauses = %+v; want %+v", query.AnyOf, want)
+	}
+	if got := query.upstreamQueries(); !reflect.DeepEqual(got, []string{"barista", "oat drink"}) {
+		t.Fatalf("upstreamQueries = %v", got)
+	}
+	for name, wantMatch := range map[string]bool{
+		"Oatly Barista Edition 1 l": true,
+		"Oat Drink 1 l":             true,
+		"Organic Oat Drink 1 l":     false,
+		"Oat Milk Barista Blend":    true,
+		"Oat Porridge":              false,
+	} {
+		if got := query.matches(name); got != wantMatch {
+			t.Fatalf("matches(%q) = %v; want %v", name, got, wantMatch)
+		}
+	}
+
+	if _, advanced, err := parseSearchQuery("sugar-free oat milk"); err != nil || advanced {
+		t.Fatalf("expected a plain query to stay plain, got advanced=%v err=%v", advanced, err)
+	}
+	for _, raw := range []string{"oat AND", "OR oat", "oat AND OR milk", `"oat`, "-organic", `oat ""`} {
+		if _, _, err := parseSearchQuery(raw); err == nil {
+			t.Fatalf("parseSearchQuery(%q) expected an error", raw)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+)
+
+const (
+	menuNamesCacheFile = "menu-names.json"
+	menuNamesCacheKey  = "names"
+	// menuNamesLimit caps the cached names; the least recently seen go first.
+	menuNamesLimit = 2000
+	// searchSuggestionLimit caps the "did you mean" spellings per search.
+	searchSuggestionLimit = 3
+)
+
+// menuNamesMu serializes read-modify-write cycles of the menu name cache.
+var menuNamesMu sync.Mutex
+
+// rememberMenuNames records the names of menu item rows so later searches can
+// suggest spellings from them. The cache is an aid, so failures are ignored.
+func rememberMenuNames(deps Dependencies, rows []any) {
+	names := []string{}
+	for _, value := range rows {
+		if name := strings.TrimSpace(asString(asMap(value)["name"])); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	menuNamesMu.Lock()
+	defer menuNamesMu.Unlock()
+	file, _ := openCLICache(deps, menuNamesCacheFile)
+	if file == nil {
+		return
+	}
+	now := deps.now().UTC()
+	seen := map[string]time.Time{}
+	file.Get(menuNamesCacheKey, 0, now, &seen)
+	for _, name := range names {
+		seen[name] = now
+	}
+	if len(seen) > menuNamesLimit {
+		keys := make([]string, 0, len(seen))
+		for name := range seen {
+			keys = append(keys, name)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if !seen[keys[i]].Equal(seen[keys[j]]) {
+				return seen[keys[i]].After(seen[keys[j]])
+			}
+			return keys[i] < keys[j]
+		})
+		for _, stale := range keys[menuNamesLimit:] {
+			delete(seen, stale)
+		}
+	}
+	if file.Put(menuNamesCacheKey, seen, now) == nil {
+		_ = file.Save()
+	}
+}
+
+// addSearchSuggestions sets data["suggestions"] when a search for query found
+// no rows, drawing on the venues in catalog, the local venue map, and cached
+// menu names, and returns a "did you mean" warning when there are any.
+func addSearchSuggestions(deps Dependencies, data map[string]any, query string, catalog []domain.Item) []string {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	names := []string{}
+	for _, item := range catalog {
+		names = append(names, item.Title)
+		if item.Venue != nil {
+			names = append(names, item.Venue.Name)
+			names = append(names, item.Venue.Tags...)
+		}
+	}
+	if _, known, _ := loadKnownVenues(deps); len(known) > 0 {
+		for _, venue := range known {
+			names = append(names, venue.Name)
+		}
+	}
+	if file, _ := openCLICache(deps, menuNamesCacheFile); file != nil {
+		cached := map[string]time.Time{}
+		file.Get(menuNamesCacheKey, 0, deps.now(), &cached)
+		for name := range cached {
+			names = append(names, name)
+		}
+	}
+	// Map order must not leak into the ranking of equally close names.
+	sort.Strings(names)
+
+	suggestions := observability.SuggestQueries(query, names, searchSuggestionLimit)
+	data["suggestions"] = suggestions
+	if len(suggestions) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("no results for %q; did you mean: %s?", query, strings.Join(suggestions, ", "))}
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/spf13/cobra"
+)
+
+// sessionSecretFlags carry credentials; their values never reach a session
+// bundle, even with --reveal-secrets.
+var sessionSecretFlags = []string{"--wtoken", "--wrtoken", "--cookie", "--approve-token"}
+
+type sessionRecorderKey struct{}
+
+// sessionRecorder collects what one --save-session run printed and sent.
+type sessionRecorder struct {
+	path      string
+	version   string
+	startedAt time.Time
+	trace     lockedBuffer
+	stdout    lockedBuffer
+	stderr    lockedBuffer
+}
+
+// lockedBuffer is a bytes.Buffer safe for the concurrent writes of request traces.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// applySaveSession starts recording output and the request trace of a
+// --save-session run. The bundle is written by finishSaveSession once the
+// exit code is known.
+func applySaveSession(cmd *cobra.Command, deps Dependencies) {
+	path, _ := cmd.Flags().GetString("save-session")
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return
+	}
+	recorder := &sessionRecorder{
+		path:      path,
+		version:   resolvedVersion(deps.Version),
+		startedAt: deps.now(),
+	}
+	cmd.SetOut(io.MultiWriter(cmd.OutOrStdout(), &recorder.stdout))
+	cmd.SetErr(io.MultiWriter(cmd.ErrOrStderr(), &recorder.stderr))
+	if setter, ok := deps.Wolt.(verboseHTTPTraceSetter); ok {
+		var trace io.Writer = &recorder.trace
+		if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+			trace = io.MultiWriter(cmd.ErrOrStderr(), &recorder.trace)
+		}
+		setter.SetVerboseOutput(trace)
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), sessionRecorderKey{}, recorder))
+}
+
+func sessionRecorderFrom(cmd *cobra.Command) *sessionRecorder {
+	if cmd == nil || cmd.Context() == nil {
+		return nil
+	}
+	recorder, _ := cmd.Context().Value(sessionRecorderKey{}).(*sessionRecorder)
+	return recorder
+}
+
+// finishSaveSession writes the bundle of a --save-session run and detaches
+// the trace buffer from the gateway. Failures are reported on stderr and do
+// not change the command's exit code.
+func finishSaveSession(cmd *cobra.Command, deps Dependencies, args []string, exitCode int, stderr io.Writer) {
+	recorder := sessionRecorderFrom(cmd)
+	if recorder == nil {
+		return
+	}
+	if setter, ok := deps.Wolt.(verboseHTTPTraceSetter); ok {
+		setter.SetVerboseOutput(nil)
+	}
+	if err := recorder.write(cmd, args, exitCode, deps.now()); err != nil {
+		_, _ = fmt.Fprintf(stderr, "save-session: %v\n", err)
+		return
+	}
+	_, _ = fmt.Fprintf(stderr, "session saved to %s (review it before attaching: output may include addresses and order details)\n", recorder.path)
+}
+
+func (r *sessionRecorder) write(cmd *cobra.Command, args []string, exitCode int, finishedAt time.Time) error {
+	invocation := map[string]any{
+		"command":     cmd.CommandPath(),
+		"args":        redactSessionArgs(args),
+		"exit_code":   exitCode,
+		"started_at":  r.startedAt.UTC().Format(time.RFC3339),
+		"finished_at": finishedAt.UTC().Format(time.RFC3339),
+		"duration_ms": finishedAt.Sub(r.startedAt).Milliseconds(),
+	}
+	environment := map[string]any{
+		"wolt_version": r.version,
+		"go_version":   runtime.Version(),
+		"os":           runtime.GOOS,
+		"arch":         runtime.GOARCH,
+		"wolt_env":     sessionEnvNames(),
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"invocation.json", invocation},
+		{"environment.json", environment},
+		{"trace.log", r.trace.String()},
+		{"stdout.txt", r.stdout.String()},
+		{"stderr.txt", r.stderr.String()},
+	}
+	for _, file := range files {
+		text, ok := file.content.(string)
+		if !ok {
+			encoded, err := json.MarshalIndent(file.content, "", "  ")
+			if err != nil {
+				return fmt.Errorf("encode %s: %w", file.name, err)
+			}
+			text = string(encoded) + "\n"
+		}
+		entry, err := writer.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: finishedAt})
+		if err != nil {
+			return fmt.Errorf("add %s: %w", file.name, err)
+		}
+		if _, err := io.WriteString(entry, woltgateway.Redact(text)); err != nil {
+			return fmt.Errorf("add %s: %w", file.name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("finish archive: %w", err)
+	}
+	if err := os.WriteFile(r.path, archive.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// redactSessionArgs replaces credential flag values; the rest is left to
+// woltgateway.Redact when the bundle is written.
+func redactSessionArgs(args []string) []string {
+	redacted := make([]string, 0, len(args))
+	hideNext := false
+	for _, arg := range args {
+		if hideNext {
+			redacted = append(redacted, woltgateway.Redacted)
+			hideNext = false
+			continue
+		}
+		for _, flag := range sessionSecretFlags {
+			if arg == flag {
+				hideNext = true
+				break
+			}
+			if strings.HasPrefix(arg, flag+"=") {
+				arg = flag + "=" + woltgateway.Redacted
+				break
+			}
+		}
+		redacted = append(redacted, arg)
+	}
+	return redacted
+}
+
+// sessionEnvNames lists the WOLT_* variables set for the run, without values.
+func sessionEnvNames() []string {
+	names := []string{}
+	for _, entry := range os.Environ() {
+		name, _, _ := strings.Cut(entry, "=")
+		if strings.HasPrefix(name, "WOLT_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sqliteOutputPrefix     = "sqlite:"
+	sqliteExportAnnotation = "wolt_cli_sqlite_export"
+)
+
+type sqliteOutputKey struct{}
+
+// sqliteExport maps one list row shape onto a typed table.
+type sqliteExport struct {
+	table  output.SQLiteTable
+	values func(row map[string]any) []any
+}
+
+var sqliteVenueExport = sqliteExport{
+	table: output.SQLiteTable{
+		Name: "venues",
+		Key:  []string{"venue_id"},
+		Columns: []output.SQLiteColumn{
+			{Name: "venue_id", Type: "TEXT NOT NULL"},
+			{Name: "slug", Type: "TEXT"},
+			{Name: "name", Type: "TEXT"},
+			{Name: "rating", Type: "REAL"},
+			{Name: "delivery_estimate", Type: "TEXT"},
+			{Name: "delivery_fee", Type: "INTEGER"},
+			{Name: "price_range", Type: "INTEGER"},
+			{Name: "promotions", Type: "TEXT"},
+			{Name: "wolt_plus", Type: "INTEGER"},
+		},
+	},
+	values: func(row map[string]any) []any {
+		return []any{
+			asString(row["venue_id"]),
+			asString(row["slug"]),
+			asString(row["name"]),
+			sqliteNumber(row["rating"]),
+			asString(row["delivery_estimate"]),
+			sqliteInteger(asMap(row["delivery_fee"])["amount"]),
+			sqliteInteger(row["price_range"]),
+			stringsJoin(asSlice(row["promotions"]), "; "),
+			asBool(row["wolt_plus"]),
+		}
+	},
+}
+
+var sqliteItemExport = sqliteExport{
+	table: output.SQLiteTable{
+		Name: "items",
+		Key:  []string{"venue_id", "item_id"},
+		Columns: []output.SQLiteColumn{
+			{Name: "venue_id", Type: "TEXT NOT NULL"},
+			{Name: "item_id", Type: "TEXT NOT NULL"},
+			{Name: "venue_slug", Type: "TEXT"},
+			{Name: "name", Type: "TEXT"},
+			{Name: "price", Type: "INTEGER"},
+			{Name: "original_price", Type: "INTEGER"},
+			{Name: "currency", Type: "TEXT"},
+			{Name: "discounts", Type: "TEXT"},
+			{Name: "is_sold_out", Type: "INTEGER"},
+		},
+	},
+	values: func(row map[string]any) []any {
+		basePrice := asMap(row["base_price"])
+		return []any{
+			asString(row["venue_id"]),
+			asString(row["item_id"]),
+			asString(row["venue_slug"]),
+			asString(row["name"]),
+			sqliteInteger(basePrice["amount"]),
+			sqliteInteger(asMap(row["original_price"])["amount"]),
+			asString(basePrice["currency"]),
+			stringsJoin(asSlice(row["discounts"]), "; "),
+			asBool(row["is_sold_out"]),
+		}
+	},
+}
+
+var sqliteOrderExport = sqliteExport{
+	table: output.SQLiteTable{
+		Name: "orders",
+		Key:  []string{"purchase_id"},
+		Columns: []output.SQLiteColumn{
+			{Name: "purchase_id", Type: "TEXT NOT NULL"},
+			{Name: "received_at", Type: "TEXT"},
+			{Name: "status", Type: "TEXT"},
+			{Name: "venue_name", Type: "TEXT"},
+			{Name: "total_amount", Type: "TEXT"},
+			{Name: "items_summary", Type: "TEXT"},
+			{Name: "payment_time_ts", Type: "INTEGER"},
+		},
+	},
+	values: func(row map[string]any) []any {
+		return []any{
+			asString(row["purchase_id"]),
+			asString(row["received_at"]),
+			asString(row["status"]),
+			asString(row["venue_name"]),
+			asString(row["total_amount"]),
+			asString(row["items_summary"]),
+			sqliteInteger(row["payment_time_ts"]),
+		}
+	},
+}
+
+// hasKey reports whether every key column of values is non-empty.
+func (e sqliteExport) hasKey(values []any) bool {
+	for idx, column := range e.table.Columns {
+		if slices.Contains(e.table.Key, column.Name) && strings.TrimSpace(asString(values[idx])) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// runSQLite feeds script to the sqlite3 shell for dbPath.
+var runSQLite = func(ctx context.Context, dbPath string, script string) error {
+	binary, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return fmt.Errorf("--output sqlite: needs the sqlite3 command on PATH")
+	}
+	var stderr bytes.Buffer
+	command := exec.CommandContext(ctx, binary, "-bail", dbPath)
+	command.Stdin = strings.NewReader(script)
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("sqlite3: %s", message)
+		}
+		return fmt.Errorf("sqlite3: %w", err)
+	}
+	return nil
+}
+
+// enableSQLiteExport marks cmd as able to write its rows with --output sqlite:<path>.
+func enableSQLiteExport(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[sqliteExportAnnotation] = "true"
+}
+
+// applySQLiteOutput moves a sqlite:<path> --output value into the command context so
+// the regular file output path stays empty, and rejects commands without list rows.
+func applySQLiteOutput(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil {
+		return nil
+	}
+	dbPath, ok := strings.CutPrefix(strings.TrimSpace(flag.Value.String()), sqliteOutputPrefix)
+	if !ok {
+		return nil
+	}
+	if cmd.Annotations[sqliteExportAnnotation] != "true" {
+		return fmt.Errorf("--output sqlite: is supported by discover feed, search venues, search items, venue menu, and profile orders")
+	}
+	if strings.TrimSpace(dbPath) == "" {
+		return fmt.Errorf("--output sqlite: needs a database path, for example sqlite:wolt.db")
+	}
+	if err := flag.Value.Set(""); err != nil {
+		return err
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), sqliteOutputKey{}, strings.TrimSpace(dbPath)))
+	return nil
+}
+
+// exportSQLiteRows upserts rows when --output sqlite:<path> is set; otherwise it is a no-op.
+func exportSQLiteRows(cmd *cobra.Command, deps Dependencies, export sqliteExport, rows []any) error {
+	dbPath, _ := cmd.Context().Value(sqliteOutputKey{}).(string)
+	if dbPath == "" {
+		return nil
+	}
+	values := make([][]any, 0, len(rows))
+	for _, value := range rows {
+		row := asMap(value)
+		if row == nil {
+			continue
+		}
+		if rowValues := export.values(row); export.hasKey(rowValues) {
+			values = append(values, rowValues)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return runSQLite(cmd.Context(), dbPath, output.SQLiteUpsertScript(export.table, values, deps.now()))
+}
+
+// sqliteMenuRows copies menu item rows with the venue identity that only the menu
+// envelope carries, so items from different venues share one table.
+func sqliteMenuRows(venueID string, slug string, rows []any) []any {
+	out := make([]any, 0, len(rows))
+	for _, value := range rows {
+		row := asMap(value)
+		if row == nil {
+			continue
+		}
+		copied := make(map[string]any, len(row)+2)
+		for key, field := range row {
+			copied[key] = field
+		}
+		copied["venue_id"] = venueID
+		copied["venue_slug"] = slug
+		out = append(out, copied)
+	}
+	return out
+}
+
+func sqliteInteger(value any) any {
+	if value == nil {
+		return nil
+	}
+	return asInt(value)
+}
+
+func sqliteNumber(value any) any {
+	if number, ok := asFloat(value); ok {
+		return number
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newSQLiteTestCommand(t *testing.T, enabled bool, args ...string) *cobra.Command {
+	t.Helper()
+	var flags globalFlags
+	cmd := &cobra.Command{Use: "test"}
+	addGlobalFlags(cmd, &flags)
+	if enabled {
+		enableSQLiteExport(cmd)
+	}
+	cmd.SetContext(context.Background())
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return cmd
+}
+
+func TestApplySQLiteOutputMovesTargetIntoContext(t *testing.T) {
+	originalRun := runSQLite
+	defer func() { runSQLite = originalRun }()
+	var gotPath, gotScript string
+	runSQLite = func(_ context.Context, dbPath string, script string) error {
+		gotPath, gotScript = dbPath, script
+		return nil
+	}
+
+	cmd := newSQLiteTestCommand(t, true, "--output", "sqlite:wolt.db")
+	if err := applySQLiteOutput(cmd); err != nil {
+		t.Fatalf("apply sqlite output: %v", err)
+	}
+	if value := cmd.Flags().Lookup("output").Value.String(); value != "" {
+		t.Fatalf("expected --output to be cleared, got %q", value)
+	}
+	rows := []any{
+		map[string]any{"venue_id": "v1", "slug": "burger-place", "name": "Burger Place", "rating": 9.1},
+		map[string]any{"slug": "missing-id"},
+	}
+	deps := Dependencies{Clock: ClockFunc(func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) })}
+	if err := exportSQLiteRows(cmd, deps, sqliteVenueExport, rows); err != nil {
+		t.Fatalf("export rows: %v", err)
+	}
+	if gotPath != "wolt.db" {
+		t.Fatalf("expected wolt.db, got %q", gotPath)
+	}
+	if !strings.Contains(gotScript, "'burger-place'") || strings.Contains(gotScript, "missing-id") || !strings.Contains(gotScript, "'2026-03-01T12:00:00Z'") {
+		t.Fatalf("unexpected script:\n%s", gotScript)
+	}
+}
+
+func TestApplySQLiteOutputRejectsUnsupportedCommands(t *testing.T) {
+	cmd := newSQLiteTestCommand(t, false, "--output", "sqlite:wolt.db")
+	err := applySQLiteOutput(cmd)
+	if err == nil || !strings.Contains(err.Error(), "supported by") {
+		t.Fatalf("expected unsupported command error, got %v", err)
+	}
+
+	cmd = newSQLiteTestCommand(t, true, "--output", "sqlite:")
+	if err := applySQLiteOutput(cmd); err == nil {
+		t.Fatal("expected error for empty database path")
+	}
+}
+
+func TestExportSQLiteRowsWithoutTargetIsNoop(t *testing.T) {
+	originalRun := runSQLite
+	defer func() { runSQLite = originalRun }()
+	runSQLite = func(context.Context, string, string) error {
+		t.Fatal("sqlite3 must not run without --output sqlite:")
+		return nil
+	}
+
+	cmd := newSQLiteTestCommand(t, true, "--output", "rows.json")
+	if err := applySQLiteOutput(cmd); err != nil {
+		t.Fatalf("apply sqlite output: %v", err)
+	}
+	if value := cmd.Flags().Lookup("output").Value.String(); value != "rows.json" {
+		t.Fatalf("expected file output to stay, got %q", value)
+	}
+	if err := exportSQLiteRows(cmd, Dependencies{}, sqliteOrderExport, []any{map[string]any{"purchase_id": "p1"}}); err != nil {
+		t.Fatalf("export rows: %v", err)
+	}
+}
+
+func TestSQLiteMenuRowsAddsVenueIdentity(t *testing.T) {
+	rows := []any{map[string]any{"item_id": "i1", "name": "Fries"}}
+	exported := sqliteMenuRows("v1", "burger-place", rows)
+	row := asMap(exported[0])
+	if row["venue_id"] != "v1" || row["venue_slug"] != "burger-place" {
+		t.Fatalf("unexpected row %#v", row)
+	}
+	if _, ok := asMap(rows[0])["venue_id"]; ok {
+		t.Fatal("menu rows must not be mutated")
+	}
+}
@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stateDir is the directory a feature keeps local state in: $envVar when set,
+// else the name directory next to the config file.
+func stateDir(deps Dependencies, envVar string, name string) (string, error) {
+	if dir := strings.TrimSpace(os.Getenv(envVar)); dir != "" {
+		return dir, nil
+	}
+	if deps.Config == nil || strings.TrimSpace(deps.Config.Path()) == "" {
+		return "", fmt.Errorf("%s location is unknown; set %s", name, envVar)
+	}
+	return filepath.Join(filepath.Dir(deps.Config.Path()), name), nil
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStateDirPrefersEnvironmentThenConfigDir(t *testing.T) {
+	t.Setenv("WOLT_TEST_STATE_DIR", "")
+	deps := Dependencies{Config: &testConfigManager{path: filepath.Join("home", ".wolt", "config.json")}}
+	if dir, err := stateDir(deps, "WOLT_TEST_STATE_DIR", "track"); err != nil || dir != filepath.Join("home", ".wolt", "track") {
+		t.Fatalf("expected the directory next to the config file, got %q err=%v", dir, err)
+	}
+	t.Setenv("WOLT_TEST_STATE_DIR", " /tmp/state ")
+	if dir, err := stateDir(deps, "WOLT_TEST_STATE_DIR", "track"); err != nil || dir != "/tmp/state" {
+		t.Fatalf("expected the environment override, got %q err=%v", dir, err)
+	}
+	t.Setenv("WOLT_TEST_STATE_DIR", "")
+	if _, err := stateDir(Dependencies{}, "WOLT_TEST_STATE_DIR", "track"); err == nil || !strings.Contains(err.Error(), "set WOLT_TEST_STATE_DIR") {
+		t.Fatalf("expected an error naming the variable, got %v", err)
+	}
+}
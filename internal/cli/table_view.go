@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+const defaultPager = "less -R"
+
+// terminalHeight reports the row count of the terminal behind out, or 0 when out is not a terminal.
+var terminalHeight = func(out io.Writer) int {
+	file, ok := out.(*os.File)
+	if !ok {
+		return 0
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return 0
+	}
+	if rows := fileTerminalHeight(file); rows > 0 {
+		return rows
+	}
+	if rows, err := strconv.Atoi(os.Getenv("LINES")); err == nil && rows > 0 {
+		return rows
+	}
+	return 24
+}
+
+var runPager = func(command string, out io.Writer, stderr io.Writer, text string) error {
+	fields := strings.Fields(command)
+	pager := exec.Command(fields[0], fields[1:]...)
+	pager.Stdin = strings.NewReader(text + "\n")
+	pager.Stdout = out
+	pager.Stderr = stderr
+	return pager.Run()
+}
+
+const (
+	tableLayoutWide = "wide"
+	tableLayoutLong = "long"
+)
+
+// applyTableView applies the column caps of tableColumnWidths, --columns,
+// --max-col-width, --max-rows and --layout to rendered tables.
+func applyTableView(cmd *cobra.Command, text string) (string, error) {
+	maxRows, _ := cmd.Flags().GetInt("max-rows")
+	maxColWidth, _ := cmd.Flags().GetInt("max-col-width")
+	columnsValue, _ := cmd.Flags().GetString("columns")
+	columns := splitColumnList(columnsValue)
+	layout, _ := cmd.Flags().GetString("layout")
+	layout = strings.ToLower(strings.TrimSpace(layout))
+	switch layout {
+	case "", tableLayoutWide, tableLayoutLong:
+	default:
+		return "", fmt.Errorf("--layout must be one of: %s, %s", tableLayoutWide, tableLayoutLong)
+	}
+	widths, err := tableColumnWidths(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	tables := output.ParseTables(text)
+	capped := false
+	for idx := range tables {
+		var changed bool
+		tables[idx], changed = tables[idx].CapColumns(widths)
+		capped = capped || changed
+	}
+	if !capped && maxRows <= 0 && maxColWidth <= 0 && len(columns) == 0 && layout != tableLayoutLong {
+		return text, nil
+	}
+	if err := checkColumnsExist(tables, columns); err != nil {
+		return "", err
+	}
+	for idx := range tables {
+		tables[idx] = tables[idx].SelectColumns(columns).TruncateCells(maxColWidth).TruncateRows(maxRows)
+	}
+	if layout == tableLayoutLong {
+		return output.RenderLongTables(tables), nil
+	}
+	return output.RenderTables(tables), nil
+}
+
+// tableColumnWidths returns the column caps of this command's table view:
+// the output defaults adjusted by --col-width, or none with --full-width and
+// in formats other than table.
+func tableColumnWidths(cmd *cobra.Command) (map[string]int, error) {
+	var values []string
+	if flag := cmd.Flags().Lookup("col-width"); flag != nil {
+		values, _ = cmd.Flags().GetStringArray("col-width")
+	}
+	overrides, err := output.ParseColumnWidths(values)
+	if err != nil {
+		return nil, err
+	}
+	fullWidth, _ := cmd.Flags().GetBool("full-width")
+	maxColWidth, _ := cmd.Flags().GetInt("max-col-width")
+	if fullWidth && (len(overrides) > 0 || maxColWidth > 0) {
+		return nil, fmt.Errorf("--full-width cannot be combined with --col-width or --max-col-width")
+	}
+	if flag := cmd.Flags().Lookup("format"); flag != nil {
+		if format := strings.TrimSpace(flag.Value.String()); format != "" && !strings.EqualFold(format, string(output.FormatTable)) {
+			fullWidth = true
+		}
+	}
+	return output.ColumnWidths(overrides, fullWidth), nil
+}
+
+func splitColumnList(value string) []string {
+	columns := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			columns = append(columns, trimmed)
+		}
+	}
+	return columns
+}
+
+func checkColumnsExist(tables []output.Table, columns []string) error {
+	for _, column := range columns {
+		found := false
+		available := []string{}
+		for _, table := range tables {
+			if table.ColumnIndex(column) >= 0 {
+				found = true
+				break
+			}
+			available = append(available, table.Headers...)
+		}
+		if !found {
+			return fmt.Errorf("--columns: unknown column %q (available: %s)", column, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// pageTable sends text through $PAGER when stdout is a terminal too short to hold
+// it. It reports false when the caller should write text itself.
+func pageTable(cmd *cobra.Command, text string) bool {
+	if noPager, _ := cmd.Flags().GetBool("no-pager"); noPager {
+		return false
+	}
+	if chainSelectionFromContext(cmd.Context()) != nil {
+		return false
+	}
+	height := terminalHeight(cmd.OutOrStdout())
+	if height <= 0 || strings.Count(text, "\n")+1 < height {
+		return false
+	}
+	command := strings.TrimSpace(os.Getenv("PAGER"))
+	if command == "" {
+		command = defaultPager
+	}
+	if command == "cat" {
+		return false
+	}
+	return runPager(command, cmd.OutOrStdout(), cmd.ErrOrStderr(), text) == nil
+}
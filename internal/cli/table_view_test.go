@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+func newTableViewTestCommand(t *testing.T, args ...string) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+	var flags globalFlags
+	cmd := &cobra.Command{Use: "test"}
+	addGlobalFlags(cmd, &flags)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	return cmd, &stdout
+}
+
+func longTable(rows int) string {
+	body := make([][]string, rows)
+	for idx := range body {
+		body[idx] = []string{"venue", "slug"}
+	}
+	return output.RenderTable("Venues", []string{"Name", "Slug"}, body)
+}
+
+func TestWriteTableTruncatesToMaxRows(t *testing.T) {
+	cmd, stdout := newTableViewTestCommand(t, "--max-rows", "2")
+	if err := writeTable(cmd, longTable(5), ""); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 5 || lines[4] != "... 3 more rows, use --limit/--offset" {
+		t.Fatalf("unexpected truncated output:\n%s", stdout.String())
+	}
+}
+
+func TestWriteTablePagesOnlyWhenTallerThanTerminal(t *testing.T) {
+	originalHeight, originalPager := terminalHeight, runPager
+	defer func() { terminalHeight, runPager = originalHeight, originalPager }()
+	terminalHeight = func(io.Writer) int { return 10 }
+	paged := ""
+	runPager = func(command string, out io.Writer, _ io.Writer, text string) error {
+		paged = command
+		return nil
+	}
+	t.Setenv("PAGER", "more")
+
+	cmd, stdout := newTableViewTestCommand(t)
+	if err := writeTable(cmd, longTable(3), ""); err != nil {
+		t.Fatalf("write short table: %v", err)
+	}
+	if paged != "" || stdout.Len() == 0 {
+		t.Fatalf("short table should be written directly, paged=%q", paged)
+	}
+
+	cmd, stdout = newTableViewTestCommand(t)
+	if err := writeTable(cmd, longTable(20), ""); err != nil {
+		t.Fatalf("write long table: %v", err)
+	}
+	if paged != "more" || stdout.Len() != 0 {
+		t.Fatalf("expected long table to go through $PAGER, paged=%q stdout=%d bytes", paged, stdout.Len())
+	}
+
+	paged = ""
+	cmd, stdout = newTableViewTestCommand(t, "--no-pager")
+	if err := writeTable(cmd, longTable(20), ""); err != nil {
+		t.Fatalf("write long table: %v", err)
+	}
+	if paged != "" || stdout.Len() == 0 {
+		t.Fatalf("--no-pager should write directly, paged=%q", paged)
+	}
+}
+
+func TestWriteTableSelectsAndClampsColumns(t *testing.T) {
+	text := output.RenderTable("Items", []string{"Name", "Price", "Rating"}, [][]string{{"Double Whopper Meal", "12.95 EUR", "9.0"}})
+	cmd, stdout := newTableViewTestCommand(t, "--columns", "rating,name", "--max-col-width", "10")
+	if err := writeTable(cmd, text, ""); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || lines[1] != "Rating\tName" || lines[2] != "9.0\tDouble Wh…" {
+		t.Fatalf("unexpected column view:\n%s", stdout.String())
+	}
+
+	cmd, _ = newTableViewTestCommand(t, "--columns", "calories")
+	err := writeTable(cmd, text, "")
+	if err == nil || !strings.Contains(err.Error(), `unknown column "calories"`) {
+		t.Fatalf("expected unknown column error, got %v", err)
+	}
+}
+
+func TestWriteTableLongLayout(t *testing.T) {
+	cmd, stdout := newTableViewTestCommand(t, "--layout", "long", "--max-rows", "1")
+	if err := writeTable(cmd, longTable(2), ""); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	want := "Venues\nName: venue\nSlug: slug\n... 1 more rows, use --limit/--offset"
+	if got := strings.TrimSpace(stdout.String()); got != want {
+		t.Fatalf("unexpected long layout:\n%s", got)
+	}
+
+	cmd, _ = newTableViewTestCommand(t, "--layout", "tall")
+	if err := writeTable(cmd, longTable(1), ""); err == nil {
+		t.Fatal("expected invalid --layout to fail")
+	}
+}
+
+func TestTableColumnWidthFlags(t *testing.T) {
+	name := "Double Whopper Meal with Large Fries and Coca-Cola Zero"
+	text := output.RenderTable("Items", []string{"Name"}, [][]string{{name}})
+
+	cmd, stdout := newTableViewTestCommand(t, "--col-width", "name=11")
+	if err := writeTable(cmd, text, ""); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); !strings.HasSuffix(got, "\nDoubl…Zero") {
+		t.Fatalf("expected --col-width to cap the name, got %q", got)
+	}
+
+	cmd, stdout = newTableViewTestCommand(t)
+	if err := writeTable(cmd, text, ""); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); strings.HasSuffix(got, name) || len([]rune(got[strings.LastIndex(got, "\n")+1:])) > 40 {
+		t.Fatalf("expected the default cap of 40 on the name, got %q", got)
+	}
+
+	cmd, stdout = newTableViewTestCommand(t, "--full-width")
+	if err := writeTable(cmd, text, ""); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); !strings.HasSuffix(got, name) {
+		t.Fatalf("expected --full-width to keep the name, got %q", got)
+	}
+
+	cmd, _ = newTableViewTestCommand(t, "--full-width", "--max-col-width", "10")
+	if _, err := tableColumnWidths(cmd); err == nil || !strings.Contains(err.Error(), "--full-width cannot be combined") {
+		t.Fatalf("expected --full-width with --max-col-width to fail, got %v", err)
+	}
+	cmd, _ = newTableViewTestCommand(t, "--col-width", "name=wide")
+	if _, err := tableColumnWidths(cmd); err == nil {
+		t.Fatal("expected a non-numeric --col-width to fail")
+	}
+}
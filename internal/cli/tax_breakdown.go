@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+)
+
+// buildTaxBreakdown groups VAT per rate as
+// {source, rates[]:{rate_percent,gross_amount,net_amount,tax_amount}, total_tax}.
+// A breakdown stated in the payload wins (source "payload"); otherwise it is
+// derived from line totals that carry a VAT rate, treating prices as
+// VAT-inclusive (source "items"). It returns nil when neither is present.
+func buildTaxBreakdown(payload map[string]any, items []any, currency string) map[string]any {
+	type rateTotals struct {
+		gross, net, tax int
+		hasGross        bool
+	}
+	totals := map[float64]*rateTotals{}
+	source := "payload"
+
+	breakdown := asMap(payload["payment_breakdown"])
+	for _, value := range asSlice(coalesceAny(payload["tax_breakdown"], payload["vat_breakdown"], payload["taxes"], breakdown["taxes"], breakdown["vat"])) {
+		entry := asMap(value)
+		rate, ok := taxRatePercent(coalesceAny(entry["rate_percent"], entry["rate"], entry["vat_rate"], entry["vat_percentage"], entry["percentage"], entry["tax_rate"]))
+		if !ok {
+			continue
+		}
+		tax, hasTax := taxMinorAmount(coalesceAny(entry["tax_amount"], entry["vat_amount"], entry["amount"]))
+		gross, hasGross := taxMinorAmount(coalesceAny(entry["gross_amount"], entry["total_amount"]))
+		net, hasNet := taxMinorAmount(coalesceAny(entry["net_amount"], entry["taxable_amount"]))
+		if !hasGross && hasNet && hasTax {
+			gross, hasGross = net+tax, true
+		}
+		if !hasTax && hasGross {
+			tax = vatIncluded(gross, rate)
+		}
+		if !hasTax && !hasGross {
+			continue
+		}
+		current := totals[rate]
+		if current == nil {
+			current = &rateTotals{}
+			totals[rate] = current
+		}
+		current.tax += tax
+		if hasGross {
+			current.gross += gross
+			current.net += gross - tax
+			current.hasGross = true
+		}
+	}
+
+	if len(totals) == 0 {
+		source = "items"
+		for _, value := range items {
+			item := asMap(value)
+			rate, ok := taxRatePercent(coalesceAny(item["vat_percentage"], item["vat_rate"], item["tax_rate"], item["tax"]))
+			if !ok {
+				continue
+			}
+			gross, hasGross := taxMinorAmount(coalesceAny(item["end_amount"], item["total_price"], item["line_total"]))
+			if !hasGross {
+				count := max(asInt(item["count"]), 1)
+				price, hasPrice := taxMinorAmount(item["price"])
+				if !hasPrice {
+					continue
+				}
+				gross = price * count
+			}
+			current := totals[rate]
+			if current == nil {
+				current = &rateTotals{hasGross: true}
+				totals[rate] = current
+			}
+			tax := vatIncluded(gross, rate)
+			current.gross += gross
+			current.net += gross - tax
+			current.tax += tax
+		}
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+
+	rates := make([]float64, 0, len(totals))
+	for rate := range totals {
+		rates = append(rates, rate)
+	}
+	slices.Sort(rates)
+	rows := make([]any, 0, len(rates))
+	totalTax := 0
+	for _, rate := range rates {
+		current := totals[rate]
+		totalTax += current.tax
+		row := map[string]any{
+			"rate_percent": rate,
+			"gross_amount": nil,
+			"net_amount":   nil,
+			"tax_amount":   taxAmountValue(current.tax, currency),
+		}
+		if current.hasGross {
+			row["gross_amount"] = taxAmountValue(current.gross, currency)
+			row["net_amount"] = taxAmountValue(current.net, currency)
+		}
+		rows = append(rows, row)
+	}
+	return map[string]any{
+		"source":    source,
+		"rates":     rows,
+		"total_tax": taxAmountValue(totalTax, currency),
+	}
+}
+
+// taxRatePercent reads a VAT rate given as a percentage (14) or a fraction
+// (0.14) and returns it as a percentage rounded to two decimals.
+func taxRatePercent(value any) (float64, bool) {
+	rate, ok := asFloat(value)
+	if !ok {
+		parsed, err := strconv.ParseFloat(asString(value), 64)
+		if err != nil {
+			return 0, false
+		}
+		rate = parsed
+	}
+	if rate < 0 {
+		return 0, false
+	}
+	if rate > 0 && rate < 1 {
+		rate *= 100
+	}
+	return math.Round(rate*100) / 100, true
+}
+
+// taxMinorAmount reads minor units given directly or as an {amount} object.
+func taxMinorAmount(value any) (int, bool) {
+	if object := asMap(value); object != nil {
+		value = object["amount"]
+	}
+	if _, ok := asFloat(value); !ok {
+		return 0, false
+	}
+	return asInt(value), true
+}
+
+// vatIncluded is the VAT contained in a VAT-inclusive gross amount.
+func vatIncluded(gross int, rate float64) int {
+	return int(math.Round(float64(gross) * rate / (100 + rate)))
+}
+
+func taxAmountValue(amount int, currency string) map[string]any {
+	return map[string]any{
+		"amount":           amount,
+		"formatted_amount": emptyToNil(formatMinorAmount(amount, currency)),
+	}
+}
+
+func formatTaxRate(rate any) string {
+	value, _ := asFloat(rate)
+	return strconv.FormatFloat(value, 'f', -1, 64) + "%"
+}
+
+func buildTaxBreakdownTable(breakdown map[string]any) string {
+	rows := [][]string{}
+	for _, value := range asSlice(breakdown["rates"]) {
+		entry := asMap(value)
+		amount := func(key string) string {
+			object := asMap(entry[key])
+			if object == nil {
+				return "-"
+			}
+			return fallbackString(asString(object["formatted_amount"]), asString(object["amount"]))
+		}
+		rows = append(rows, []string{formatTaxRate(entry["rate_percent"]), amount("net_amount"), amount("tax_amount"), amount("gross_amount")})
+	}
+	total := asMap(breakdown["total_tax"])
+	rows = append(rows, []string{"Total", "-", fallbackString(asString(total["formatted_amount"]), asString(total["amount"])), "-"})
+	return output.RenderTable("Tax breakdown", []string{"VAT rate", "Net", "VAT", "Gross"}, rows)
+}
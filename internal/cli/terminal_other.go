@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package cli
+
+import "os"
+
+func fileTerminalHeight(*os.File) int {
+	return 0
+}
@@ -0,0 +1,22 @@
+//go:build linux || darwin
+
+package cli
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+type terminalWindowSize struct {
+	rows, cols, xPixels, yPixels uint16
+}
+
+func fileTerminalHeight(file *os.File) int {
+	var size terminalWindowSize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+	return int(size.rows)
+}
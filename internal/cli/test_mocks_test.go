@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
 	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
@@ -28,6 +29,10 @@ func (m *testWoltAPI) RestaurantByID(context.Context, string) (*domain.Restauran
 	return nil, nil
 }
 
+func (m *testWoltAPI) Cities(context.Context) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
 func (m *testWoltAPI) Search(context.Context, domain.Location, string) (map[string]any, error) {
 	return map[string]any{}, nil
 }
@@ -80,6 +85,14 @@ func (m *testWoltAPI) PaymentMethodsProfile(context.Context, woltgateway.AuthCon
 	return map[string]any{}, nil
 }
 
+func (m *testWoltAPI) PaymentBalances(context.Context, woltgateway.AuthContext, string) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
+func (m *testWoltAPI) CardSetupSession(context.Context, woltgateway.AuthContext, woltgateway.CardSetupOptions) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
 func (m *testWoltAPI) AddressFields(context.Context, domain.Location, string, woltgateway.AuthContext) (map[string]any, error) {
 	return map[string]any{}, nil
 }
@@ -146,6 +159,10 @@ func (m *testWoltAPI) RefreshAccessToken(ctx context.Context, refreshToken strin
 	return woltgateway.TokenRefreshResult{}, nil
 }
 
+func (m *testWoltAPI) Raw(context.Context, string, string, []byte, woltgateway.AuthContext) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+
 type testProfiles struct {
 	profile domain.Profile
 }
@@ -155,10 +172,14 @@ func (m *testProfiles) Find(context.Context, string) (domain.Profile, error) {
 }
 
 type testConfigManager struct {
-	cfg domain.Config
+	cfg  domain.Config
+	path string
 }
 
 func (m *testConfigManager) Path() string {
+	if m.path != "" {
+		return m.path
+	}
 	return "/tmp/test-config.json"
 }
 
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// applyTimeDisplay sets how envelope and table timestamps are written for this
+// run: --tz converts them to an IANA timezone and --time-format picks rfc3339,
+// unix seconds, or a relative phrase. Without either they stay as written.
+func applyTimeDisplay(cmd *cobra.Command, deps Dependencies) error {
+	output.SetTimeDisplay(nil, output.TimeFormatRFC3339, nil)
+	var location *time.Location
+	if flag := cmd.Flags().Lookup("tz"); flag != nil {
+		if name := strings.TrimSpace(flag.Value.String()); name != "" {
+			loaded, err := time.LoadLocation(name)
+			if err != nil {
+				return fmt.Errorf("--tz %q is not a known IANA timezone, for example Europe/Helsinki", name)
+			}
+			location = loaded
+		}
+	}
+	format := output.TimeFormatRFC3339
+	if flag := cmd.Flags().Lookup("time-format"); flag != nil {
+		parsed, err := output.ParseTimeFormat(flag.Value.String())
+		if err != nil {
+			return err
+		}
+		format = parsed
+	}
+	output.SetTimeDisplay(location, format, deps.now)
+	return nil
+}
+
+// displayTimestamp renders an RFC 3339 value for table titles and other text
+// outside table cells, the way cells are rendered.
+func displayTimestamp(value string) string {
+	return fmt.Sprint(output.DisplayTimestamps(value))
+}
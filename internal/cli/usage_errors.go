@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// argumentErrorFormat reports the machine format requested on the command
+// line. It reads the raw arguments because a flag parse error stops cobra
+// before later flags, --format among them, are set.
+func argumentErrorFormat(args []string) (output.Format, bool) {
+	format := ""
+	machine := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		switch {
+		case arg == "--machine" || arg == "--machine=true":
+			machine = true
+		case arg == "--machine=false":
+			machine = false
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case arg == "--format" && i+1 < len(args):
+			i++
+			format = args[i]
+		}
+	}
+	if format == "" && machine {
+		return output.FormatJSON, true
+	}
+	parsed, err := output.ParseFormat(format)
+	if err != nil || (parsed != output.FormatJSON && parsed != output.FormatYAML) {
+		return "", false
+	}
+	return parsed, true
+}
+
+// argumentFlagValue returns the last value given for a string flag in args.
+func argumentFlagValue(args []string, name string) string {
+	value := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if rest, ok := strings.CutPrefix(arg, "--"+name+"="); ok {
+			value = rest
+		} else if arg == "--"+name && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+	}
+	return value
+}
+
+// emitArgumentError writes an error that escaped the command, such as an
+// unknown flag, a missing argument, or a rejected flag value, as an error
+// envelope when --format json|yaml was requested. It reports false when the
+// caller should print the plain message instead.
+func emitArgumentError(cmd *cobra.Command, args []string, message string) bool {
+	format, ok := argumentErrorFormat(args)
+	if !ok || cmd == nil {
+		return false
+	}
+	locale := argumentFlagValue(args, "locale")
+	if locale == "" {
+		locale = "en-FI"
+	}
+	env := output.BuildEnvelope(
+		defaultProfileName(argumentFlagValue(args, "profile")),
+		locale,
+		nil,
+		[]string{},
+		map[string]any{"code": "WOLT_INVALID_ARGUMENT", "message": message},
+	)
+	return writeMachinePayload(cmd, env, format, argumentFlagValue(args, "output")) == nil
+}
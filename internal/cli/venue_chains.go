@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"strings"
+)
+
+// venueBranchSeparators split a venue name into brand and branch, as in
+// "Burger King | Kamppi" or "Hesburger - Itis".
+var venueBranchSeparators = []string{" | ", " - ", " – ", " — ", " @ ", ", "}
+
+// genericVenueWords are single words too common to make a brand on their own:
+// "Pizza Hut" and "Pizza Online" are not one chain.
+var genericVenueWords = map[string]bool{
+	"bar": true, "bistro": true, "burger": true, "cafe": true, "café": true, "deli": true,
+	"grill": true, "kebab": true, "kitchen": true, "pizza": true, "pizzeria": true,
+	"ravintola": true, "restaurant": true, "sushi": true, "thai": true, "the": true,
+}
+
+// venueChainBrands returns the brand of each name, or "" for names that no
+// other name shares a brand with. A brand is the part before a branch
+// separator or else the fewest leading words the name shares with another
+// one; a single generic word does not count.
+func venueChainBrands(names []string) []string {
+	words := make([][]string, len(names))
+	for idx, name := range names {
+		words[idx] = strings.Fields(name)
+	}
+	brands := make([]string, len(names))
+	for idx, name := range names {
+		if brand := separatedVenueBrand(name); brand != "" {
+			brands[idx] = brand
+			continue
+		}
+		shared := 0
+		for other := range names {
+			if other != idx {
+				shared = max(shared, commonLeadingWords(words[idx], words[other]))
+			}
+		}
+		for size := 1; size <= shared; size++ {
+			if size == 1 && (genericVenueWords[strings.ToLower(words[idx][0])] || len([]rune(words[idx][0])) < 3) {
+				continue
+			}
+			brands[idx] = strings.Join(words[idx][:size], " ")
+			break
+		}
+	}
+
+	counts := map[string]int{}
+	for _, brand := range brands {
+		if brand != "" {
+			counts[strings.ToLower(brand)]++
+		}
+	}
+	for idx, brand := range brands {
+		if counts[strings.ToLower(brand)] < 2 {
+			brands[idx] = ""
+		}
+	}
+	return brands
+}
+
+func separatedVenueBrand(name string) string {
+	for _, separator := range venueBranchSeparators {
+		if brand, _, ok := strings.Cut(name, separator); ok && strings.TrimSpace(brand) != "" {
+			return strings.TrimSpace(brand)
+		}
+	}
+	return ""
+}
+
+func commonLeadingWords(left []string, right []string) int {
+	count := 0
+	for count < len(left) && count < len(right) && strings.EqualFold(left[count], right[count]) {
+		count++
+	}
+	return count
+}
+
+// venueMatchesBrand reports whether name belongs to brand: it is the brand
+// itself or starts with it followed by a space or branch separator.
+func venueMatchesBrand(name string, brand string) bool {
+	name, brand = strings.ToLower(strings.TrimSpace(name)), strings.ToLower(strings.TrimSpace(brand))
+	if brand == "" || !strings.HasPrefix(name, brand) {
+		return false
+	}
+	rest := name[len(brand):]
+	return rest == "" || strings.HasPrefix(rest, " ")
+}
+
+// groupVenueChains collapses the branches of each chain into one row, kept at
+// the position of its first branch. The row is the nearest branch (or the
+// cheapest to deliver from when distances are unknown) with a chain object
+// naming the brand, the branch count, and the cheapest branch. It returns the
+// rows and the number of chains collapsed.
+func groupVenueChains(rows []any) ([]any, int) {
+	names := make([]string, len(rows))
+	for idx, value := range rows {
+		names[idx] = asString(asMap(value)["name"])
+	}
+	brands := venueChainBrands(names)
+
+	branches := map[string][]map[string]any{}
+	for idx, value := range rows {
+		if brand := strings.ToLower(brands[idx]); brand != "" {
+			branches[brand] = append(branches[brand], asMap(value))
+		}
+	}
+	out := make([]any, 0, len(rows))
+	emitted := map[string]bool{}
+	for idx, value := range rows {
+		key := strings.ToLower(brands[idx])
+		if key == "" {
+			out = append(out, value)
+			continue
+		}
+		if emitted[key] {
+			continue
+		}
+		emitted[key] = true
+		out = append(out, collapseVenueChain(brands[idx], branches[key]))
+	}
+	return out, len(emitted)
+}
+
+func collapseVenueChain(brand string, branches []map[string]any) map[string]any {
+	nearest, cheapest := -1, 0
+	for idx, branch := range branches {
+		if distance, ok := asFloat(branch["distance_km"]); ok {
+			if nearest < 0 {
+				nearest = idx
+			} else if best, _ := asFloat(branches[nearest]["distance_km"]); distance < best {
+				nearest = idx
+			}
+		}
+		if venueDeliveryFee(branch) < venueDeliveryFee(branches[cheapest]) {
+			cheapest = idx
+		}
+	}
+	selected, selectedBy := cheapest, "cheapest"
+	if nearest >= 0 {
+		selected, selectedBy = nearest, "nearest"
+	}
+
+	slugs := make([]any, 0, len(branches))
+	for _, branch := range branches {
+		slugs = append(slugs, fallbackString(asString(branch["slug"]), asString(branch["venue_id"])))
+	}
+	row := maps.Clone(branches[selected])
+	row["chain"] = map[string]any{
+		"brand":        brand,
+		"branch_count": len(branches),
+		"selected_by":  selectedBy,
+		"cheapest": map[string]any{
+			"venue_id":     asString(branches[cheapest]["venue_id"]),
+			"slug":         asString(branches[cheapest]["slug"]),
+			"name":         asString(branches[cheapest]["name"]),
+			"delivery_fee": branches[cheapest]["delivery_fee"],
+		},
+		"branches": slugs,
+	}
+	return row
+}
+
+// venueDeliveryFee is a row's delivery fee in minor units; rows without one
+// sort after every priced row.
+func venueDeliveryFee(row map[string]any) int {
+	fee := asMap(row["delivery_fee"])
+	if fee == nil || fee["amount"] == nil {
+		return math.MaxInt
+	}
+	return asInt(fee["amount"])
+}
+
+// chainRowLabel marks a collapsed chain row in tables, for example
+// " (+3 branches)".
+func chainRowLabel(row map[string]any) string {
+	chain := asMap(row["chain"])
+	if chain == nil {
+		return ""
+	}
+	others := asInt(chain["branch_count"]) - 1
+	if others == 1 {
+		return " (+1 branch)"
+	}
+	return fmt.Sprintf(" (+%d branches)", others)
+}
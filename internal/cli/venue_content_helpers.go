@@ -49,6 +49,8 @@ func loadVenueContentPayloads(
 		if err != nil {
 			if page == 0 {
 				warnings = append(warnings, "venue content endpoint unavailable")
+			} else {
+				recordPartialFailure(ctx, "venue content pagination", err)
 			}
 			break
 		}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+const earthRadiusKm = 6371.0
+
+// distanceKm is the great-circle distance between two points.
+func distanceKm(from domain.Location, to domain.Location) float64 {
+	lat1, lat2 := from.Lat*math.Pi/180, to.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (to.Lon - from.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// annotateVenueDistances sets distance_km on venue rows, rounded to 10 m,
+// and drops rows farther than radiusKm when radiusKm is positive. Rows
+// without coordinates get a null distance and are dropped by a radius; the
+// second result counts them.
+func annotateVenueDistances(rows []any, from domain.Location, radiusKm float64) ([]any, int) {
+	kept := make([]any, 0, len(rows))
+	unknown := 0
+	for _, rowValue := range rows {
+		row := asMap(rowValue)
+		if row == nil {
+			continue
+		}
+		lat, latOK := asFloat(row["latitude"])
+		lon, lonOK := asFloat(row["longitude"])
+		if !latOK || !lonOK {
+			unknown++
+			row["distance_km"] = nil
+			if radiusKm <= 0 {
+				kept = append(kept, row)
+			}
+			continue
+		}
+		distance := math.Round(distanceKm(from, domain.Location{Lat: lat, Lon: lon})*100) / 100
+		row["distance_km"] = distance
+		if radiusKm > 0 && distance > radiusKm {
+			continue
+		}
+		kept = append(kept, row)
+	}
+	return kept, unknown
+}
+
+func formatDistanceForTable(row map[string]any) string {
+	distance, ok := asFloat(row["distance_km"])
+	if !ok {
+		return "-"
+	}
+	return strconv.FormatFloat(distance, 'f', 1, 64) + " km"
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/cache"
+)
+
+const (
+	knownVenuesCacheFile = "venues.json"
+	knownVenuesCacheKey  = "venues"
+	// knownVenuesSinceKey records when first_seen tracking started; venues
+	// already known then carry that time rather than a real first sighting.
+	knownVenuesSinceKey = "first_seen_since"
+	// knownVenuesLimit caps the map; the least recently seen venues go first.
+	knownVenuesLimit = 1000
+)
+
+// knownVenue is one row of the local venue id/slug map.
+type knownVenue struct {
+	VenueID  string    `json:"venue_id"`
+	Slug     string    `json:"slug"`
+	Name     string    `json:"name,omitempty"`
+	City     string    `json:"city,omitempty"`
+	LastSeen time.Time `json:"last_seen"`
+	// FirstSeen is when the venue first showed up in any command.
+	FirstSeen time.Time `json:"first_seen,omitzero"`
+}
+
+// knownVenuesMu serializes read-modify-write cycles from concurrent workers.
+var knownVenuesMu sync.Mutex
+
+// loadKnownVenues returns the venue map keyed by venue id. A corrupt file is
+// returned empty together with the error.
+func loadKnownVenues(deps Dependencies) (*cache.File, map[string]knownVenue, error) {
+	file, err := openCLICache(deps, knownVenuesCacheFile)
+	if file == nil {
+		return nil, map[string]knownVenue{}, err
+	}
+	venues := map[string]knownVenue{}
+	file.Get(knownVenuesCacheKey, 0, deps.now(), &venues)
+	return file, venues, err
+}
+
+// rememberVenues records venues seen by a command. The map is a lookup aid,
+// so failures to read or write it are ignored.
+func rememberVenues(deps Dependencies, venues ...knownVenue) {
+	now := deps.now().UTC()
+	fresh := make([]knownVenue, 0, len(venues))
+	for _, venue := range venues {
+		venue.VenueID = strings.ToLower(strings.TrimSpace(venue.VenueID))
+		venue.Slug = strings.TrimSpace(venue.Slug)
+		if !woltVenueIDPattern.MatchString(venue.VenueID) || venue.Slug == "" || woltVenueIDPattern.MatchString(venue.Slug) {
+			continue
+		}
+		venue.Name = strings.TrimSpace(venue.Name)
+		venue.City = strings.TrimSpace(venue.City)
+		venue.LastSeen = now
+		fresh = append(fresh, venue)
+	}
+	if len(fresh) == 0 {
+		return
+	}
+
+	knownVenuesMu.Lock()
+	defer knownVenuesMu.Unlock()
+	file, known, _ := loadKnownVenues(deps)
+	if file == nil {
+		return
+	}
+	since, tracked := knownVenuesSince(file, now)
+	if !tracked {
+		since = now
+		_ = file.Put(knownVenuesSinceKey, since, now)
+	}
+	for _, venue := range fresh {
+		previous, seen := known[venue.VenueID]
+		switch {
+		case !seen:
+			venue.FirstSeen = now
+		case previous.FirstSeen.IsZero():
+			venue.FirstSeen = since
+		default:
+			venue.FirstSeen = previous.FirstSeen
+		}
+		if venue.Name == "" {
+			venue.Name = previous.Name
+		}
+		if venue.City == "" {
+			venue.City = previous.City
+		}
+		// A slug belongs to one venue; drop the stale owner when it moved.
+		for id, other := range known {
+			if id != venue.VenueID && other.Slug == venue.Slug {
+				delete(known, id)
+			}
+		}
+		known[venue.VenueID] = venue
+	}
+	if len(known) > knownVenuesLimit {
+		rows := sortedKnownVenues(known)
+		for _, stale := range rows[knownVenuesLimit:] {
+			delete(known, stale.VenueID)
+		}
+	}
+	if file.Put(knownVenuesCacheKey, known, now) == nil {
+		_ = file.Save()
+	}
+}
+
+// knownVenuesSince returns when first_seen tracking started, if it has.
+func knownVenuesSince(file *cache.File, now time.Time) (time.Time, bool) {
+	var since time.Time
+	ok := file.Get(knownVenuesSinceKey, 0, now, &since)
+	return since, ok && !since.IsZero()
+}
+
+// rememberItemVenues records the venues behind catalog or front-page items.
+func rememberItemVenues(deps Dependencies, items []domain.Item, city string) {
+	venues := make([]knownVenue, 0, len(items))
+	for _, item := range items {
+		if item.Venue == nil {
+			continue
+		}
+		venues = append(venues, knownVenue{
+			VenueID: domain.NormalizeID(coalesceAny(item.Venue.ID, item.Link.Target)),
+			Slug:    item.Venue.Slug,
+			Name:    fallbackString(strings.TrimSpace(item.Title), item.Venue.Name),
+			City:    fallbackString(strings.TrimSpace(item.Venue.City), city),
+		})
+	}
+	rememberVenues(deps, venues...)
+}
+
+// knownVenueByID returns the mapped venue for a venue id.
+func knownVenueByID(deps Dependencies, venueID string) (knownVenue, bool) {
+	_, known, _ := loadKnownVenues(deps)
+	venue, ok := known[strings.ToLower(strings.TrimSpace(venueID))]
+	return venue, ok
+}
+
+// knownVenueBySlug returns the mapped venue for a slug.
+func knownVenueBySlug(deps Dependencies, slug string) (knownVenue, bool) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return knownVenue{}, false
+	}
+	_, known, _ := loadKnownVenues(deps)
+	for _, venue := range known {
+		if venue.Slug == slug {
+			return venue, true
+		}
+	}
+	return knownVenue{}, false
+}
+
+// venueIDForSlug returns the venue id for slug from the local map, reading
+// the static venue page only for venues not seen before. When both fail the
+// slug itself is returned with a warning, as the callers did before.
+func venueIDForSlug(ctx context.Context, deps Dependencies, slug string) (string, []string) {
+	if venue, ok := knownVenueBySlug(deps, slug); ok {
+		return venue.VenueID, nil
+	}
+	payload, err := deps.Wolt.VenuePageStatic(ctx, slug)
+	if err != nil {
+		return strings.TrimSpace(slug), []string{"venue static page endpoint unavailable"}
+	}
+	venueID := strings.TrimSpace(venueIDFromPayload(payload))
+	if venueID == "" {
+		return strings.TrimSpace(slug), nil
+	}
+	venue := asMap(coalesceAny(payload["venue"], payload["venue_raw"]))
+	rememberVenues(deps, knownVenue{
+		VenueID: venueID,
+		Slug:    slug,
+		Name:    asString(coalesceAny(venue["name"], payload["name"])),
+		City:    asString(venue["city"]),
+	})
+	return venueID, nil
+}
+
+// sortedKnownVenues orders the map by most recently seen, then slug.
+func sortedKnownVenues(known map[string]knownVenue) []knownVenue {
+	rows := make([]knownVenue, 0, len(known))
+	for _, venue := range known {
+		rows = append(rows, venue)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].LastSeen.Equal(rows[j].LastSeen) {
+			return rows[i].LastSeen.After(rows[j].LastSeen)
+		}
+		return rows[i].Slug < rows[j].Slug
+	})
+	return rows
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+)
+
+var venueNowLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// parseVenueNow reads --now as wall-clock time in the venue timezone loc, so
+// "2026-02-16T19:00" means 19:00 where the venue is. Values with an RFC 3339
+// offset keep it. An empty value is now.
+func parseVenueNow(value string, loc *time.Location, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return now.In(loc), nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.In(loc), nil
+	}
+	for _, layout := range venueNowLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--now must be YYYY-MM-DDTHH:MM (venue local time) or RFC 3339")
+}
+
+// filterVenueRowsOpenAt keeps venue rows whose opening hours cover --now in
+// each venue's own timezone and records the compared time as open_checked_at.
+// Venue details are loaded concurrently; venues whose details cannot be read
+// are dropped with a warning.
+func filterVenueRowsOpenAt(ctx context.Context, deps Dependencies, rows []any, nowValue string) ([]any, []string) {
+	venueIDs := make([]string, 0, len(rows))
+	for _, value := range rows {
+		venueIDs = append(venueIDs, asString(asMap(value)["venue_id"]))
+	}
+	restaurants := loadRestaurants(ctx, deps, venueIDs)
+
+	filtered := make([]any, 0, len(rows))
+	warnings := []string{}
+	for _, value := range rows {
+		row := asMap(value)
+		venueID := strings.TrimSpace(asString(row["venue_id"]))
+		if venueID == "" {
+			continue
+		}
+		lookup := restaurants[venueID]
+		if lookup.err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipped %s: %s", fallbackString(asString(row["slug"]), venueID), restaurantUnavailableReason(lookup.err)))
+			continue
+		}
+		restaurant := lookup.restaurant
+		loc, _, err := observability.VenueLocation(restaurant, "")
+		if err != nil {
+			loc = time.UTC
+		}
+		at, err := parseVenueNow(nowValue, loc, deps.now())
+		if err != nil || !observability.VenueOpenAt(restaurant, at) {
+			continue
+		}
+		row["open_checked_at"] = at.Format(time.RFC3339)
+		filtered = append(filtered, row)
+	}
+	return filtered, warnings
+}
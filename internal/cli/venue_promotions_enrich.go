@@ -29,16 +29,27 @@ func enrichVenueSearchRowsWithDynamicPromotions(
 	auth woltgateway.AuthContext,
 ) {
 	rows := asSlice(data["items"])
-	enrichVenueRowsWithDynamicPromotions(ctx, deps, rows, location, auth)
+	enrichVenueRowsWithDynamicPromotions(ctx, deps, rows, location, auth, true, nil)
 }
 
+// enrichDiscoverFeedRowsWithDynamicPromotions enriches the feed rows that
+// enrichment selects; see feedEnrichment.
 func enrichDiscoverFeedRowsWithDynamicPromotions(
 	ctx context.Context,
 	deps Dependencies,
 	data map[string]any,
 	location *domain.Location,
 	auth woltgateway.AuthContext,
+	enrichment feedEnrichment,
+	onEnriched venueEnrichedFunc,
 ) {
+	if enrichment.skipped() {
+		return
+	}
+	if enrichment.top > 0 {
+		enrichVenueRowsWithDynamicPromotions(ctx, deps, enrichment.rows(discoverFeedVenueRows(data)), location, auth, enrichment.promotions(), onEnriched)
+		return
+	}
 	sectionsItems := make([][]any, 0, len(asSlice(data["sections"])))
 	for _, sectionValue := range asSlice(data["sections"]) {
 		section := asMap(sectionValue)
@@ -61,15 +72,24 @@ func enrichDiscoverFeedRowsWithDynamicPromotions(
 			break
 		}
 	}
-	enrichVenueRowsWithDynamicPromotions(ctx, deps, rows, location, auth)
+	enrichVenueRowsWithDynamicPromotions(ctx, deps, rows, location, auth, enrichment.promotions(), onEnriched)
 }
 
+// venueEnrichedFunc is called with a venue slug and its first row each time
+// enrichment changes the rows of that venue.
+type venueEnrichedFunc func(slug string, row map[string]any)
+
+// enrichVenueRowsWithDynamicPromotions adds promotion labels from dynamic
+// venue pages and Wolt+ status from static ones. Without promotions only the
+// Wolt+ lookups run.
 func enrichVenueRowsWithDynamicPromotions(
 	ctx context.Context,
 	deps Dependencies,
 	rows []any,
 	location *domain.Location,
 	auth woltgateway.AuthContext,
+	promotions bool,
+	onEnriched venueEnrichedFunc,
 ) {
 	if len(rows) == 0 {
 		return
@@ -182,6 +202,9 @@ func enrichVenueRowsWithDynamicPromotions(
 						&lastDynamicRequestAt,
 					)
 				}
+				if err != nil {
+					recordPartialFailure(ctx, "promotion enrichment", err)
+				}
 				if err == nil && len(payload) > 0 {
 					labels = observability.ExtractVenuePromotionLabels(payload)
 					cachedLabels[slug] = labels
@@ -207,6 +230,7 @@ func enrichVenueRowsWithDynamicPromotions(
 			if wait > 0 {
 				select {
 				case <-ctx.Done():
+					recordPartialFailure(ctx, "Wolt+ enrichment", ctx.Err())
 					return false
 				case <-time.After(wait):
 				}
@@ -214,6 +238,9 @@ func enrichVenueRowsWithDynamicPromotions(
 		}
 		payload, err := deps.Wolt.VenuePageStatic(ctx, slug)
 		lastStaticRequestAt = time.Now()
+		if err != nil {
+			recordPartialFailure(ctx, "Wolt+ enrichment", err)
+		}
 		if err != nil || len(payload) == 0 {
 			return false
 		}
@@ -222,16 +249,31 @@ func enrichVenueRowsWithDynamicPromotions(
 		return isWoltPlus
 	}
 
+	if !promotions {
+		// The static pass below still resolves Wolt+ for every candidate.
+		primary, secondary = nil, nil
+	}
 	for _, entry := range primary {
 		labels := resolveLabels(entry.slug)
+		changed := false
 		for _, row := range entry.info.rows {
 			if len(labels) > 0 {
+				before := len(asSlice(row["promotions"]))
 				row["promotions"] = mergeVenuePromotionLabels(asSlice(row["promotions"]), labels)
+				if len(asSlice(row["promotions"])) > before {
+					addVenueRowSource(row, "promotions", "venue_page_dynamic")
+				}
+				changed = true
 			}
 			if !asBool(row["wolt_plus"]) && resolveWoltPlus(entry.slug) {
 				row["wolt_plus"] = true
+				setVenueRowSource(row, "wolt_plus", "venue_page_static")
+				changed = true
 			}
 		}
+		if changed && onEnriched != nil {
+			onEnriched(entry.slug, entry.info.rows[0])
+		}
 	}
 
 	secondaryLimit := len(secondary)
@@ -241,14 +283,25 @@ func enrichVenueRowsWithDynamicPromotions(
 	for i := 0; i < secondaryLimit; i++ {
 		entry := secondary[i]
 		labels := resolveLabels(entry.slug)
+		changed := false
 		for _, row := range entry.info.rows {
 			if len(labels) > 0 {
+				before := len(asSlice(row["promotions"]))
 				row["promotions"] = mergeVenuePromotionLabels(asSlice(row["promotions"]), labels)
+				if len(asSlice(row["promotions"])) > before {
+					addVenueRowSource(row, "promotions", "venue_page_dynamic")
+				}
+				changed = true
 			}
 			if !asBool(row["wolt_plus"]) && resolveWoltPlus(entry.slug) {
 				row["wolt_plus"] = true
+				setVenueRowSource(row, "wolt_plus", "venue_page_static")
+				changed = true
 			}
 		}
+		if changed && onEnriched != nil {
+			onEnriched(entry.slug, entry.info.rows[0])
+		}
 	}
 
 	staticCandidates := make([]candidate, 0, len(slugInfos))
@@ -285,6 +338,10 @@ func enrichVenueRowsWithDynamicPromotions(
 		}
 		for _, row := range entry.info.rows {
 			row["wolt_plus"] = true
+			setVenueRowSource(row, "wolt_plus", "venue_page_static")
+		}
+		if onEnriched != nil {
+			onEnriched(entry.slug, entry.info.rows[0])
 		}
 	}
 }
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+	"github.com/spf13/cobra"
+)
+
+// shareLinkResolver is implemented by gateways that can follow shortened share links.
+type shareLinkResolver interface {
+	ResolveShareLink(ctx context.Context, link string) (string, error)
+}
+
+// venueArgumentError marks venue references that cannot be turned into a slug,
+// as opposed to upstream failures while resolving them.
+type venueArgumentError struct {
+	message string
+}
+
+func (e *venueArgumentError) Error() string {
+	return e.message
+}
+
+// resolveVenueSlugArgument resolves a venue command argument and emits the
+// error envelope when that fails.
+func resolveVenueSlugArgument(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	format output.Format,
+	raw string,
+) (string, error) {
+	slug, err := resolveVenueSlug(cmd.Context(), deps, raw)
+	if err == nil {
+		return slug, nil
+	}
+	profile := defaultProfileName(flags.Profile)
+	var argErr *venueArgumentError
+	if errors.As(err, &argErr) {
+		return "", emitError(cmd, format, profile, flags.Locale, flags.Output, "WOLT_INVALID_ARGUMENT", err.Error())
+	}
+	return "", emitUpstreamError(cmd, format, profile, flags.Locale, flags.Output, flags.Verbose, err)
+}
+
+// resolveVenueSlug accepts a venue slug, a wolt.com venue URL, a shortened
+// share link, or a 24-character venue id, and returns the venue slug. Venue
+// ids already in the local venue map skip the restaurant lookup.
+func resolveVenueSlug(ctx context.Context, deps Dependencies, raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", &venueArgumentError{message: "venue slug is required"}
+	}
+	if isAbsoluteURL(value) {
+		return resolveVenueLinkSlug(ctx, deps, value)
+	}
+	if woltVenueIDPattern.MatchString(value) {
+		if venue, ok := knownVenueByID(deps, value); ok {
+			return venue.Slug, nil
+		}
+		restaurant, err := deps.Wolt.RestaurantByID(ctx, strings.ToLower(value))
+		if err != nil {
+			return "", err
+		}
+		if restaurant == nil || strings.TrimSpace(restaurant.Slug) == "" {
+			return "", &venueArgumentError{message: fmt.Sprintf("venue id %q did not resolve to a slug", value)}
+		}
+		rememberVenues(deps, knownVenue{
+			VenueID: value,
+			Slug:    restaurant.Slug,
+			Name:    restaurantName(restaurant, ""),
+			City:    restaurant.City,
+		})
+		return strings.TrimSpace(restaurant.Slug), nil
+	}
+	return venueSlugFromInput(value), nil
+}
+
+// resolveVenueLinkSlug reads the slug from a wolt.com venue URL, following the
+// link first when it is a share link without a venue path.
+func resolveVenueLinkSlug(ctx context.Context, deps Dependencies, link string) (string, error) {
+	if slug, ok := venueSlugFromURL(link); ok {
+		return slug, nil
+	}
+	resolver, ok := deps.Wolt.(shareLinkResolver)
+	if !ok {
+		return "", &venueArgumentError{message: fmt.Sprintf("cannot follow share link %q; pass the wolt.com venue URL or slug", link)}
+	}
+	landed, err := resolver.ResolveShareLink(ctx, link)
+	if err != nil {
+		return "", err
+	}
+	if slug, ok := venueSlugFromURL(landed); ok {
+		return slug, nil
+	}
+	return "", &venueArgumentError{message: fmt.Sprintf("link %q does not point to a Wolt venue", link)}
+}
+
+// venueSlugFromURL returns the segment after /restaurant/ or /venue/ in a URL path.
+func venueSlugFromURL(rawURL string) (string, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.FieldsFunc(parsed.Path, func(char rune) bool {
+		return char == '/'
+	})
+	for i := 0; i < len(parts)-1; i++ {
+		if strings.EqualFold(parts[i], "restaurant") || strings.EqualFold(parts[i], "venue") {
+			return strings.TrimSpace(parts[i+1]), true
+		}
+	}
+	return "", false
+}
+
+func isAbsoluteURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.Scheme != "" && strings.TrimSpace(parsed.Host) != ""
+}
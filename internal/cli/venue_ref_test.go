@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+type venueRefTestAPI struct {
+	testWoltAPI
+	landed string
+}
+
+func (m *venueRefTestAPI) RestaurantByID(_ context.Context, venueID string) (*domain.Restaurant, error) {
+	if venueID == "5a8426f188b5de000b8857bb" {
+		return &domain.Restaurant{ID: venueID, Slug: "rioni-espoo"}, nil
+	}
+	return &domain.Restaurant{ID: venueID}, nil
+}
+
+func (m *venueRefTestAPI) ResolveShareLink(context.Context, string) (string, error) {
+	return m.landed, nil
+}
+
+func TestResolveVenueSlugAcceptsLinksAndIDs(t *testing.T) {
+	deps := Dependencies{Wolt: &venueRefTestAPI{landed: "https://wolt.com/en/fin/espoo/venue/rioni-espoo?utm_source=share"}}
+	cases := map[string]string{
+		"rioni-espoo": "rioni-espoo",
+		"https://wolt.com/en/fin/espoo/restaurant/rioni-espoo": "rioni-espoo",
+		"https://wolt.app.link/AbCd123":                        "rioni-espoo",
+		"5A8426F188B5DE000B8857BB":                             "rioni-espoo",
+	}
+	for input, expected := range cases {
+		got, err := resolveVenueSlug(context.Background(), deps, input)
+		if err != nil || got != expected {
+			t.Fatalf("expected slug %q from %q, got %q err=%v", expected, input, got, err)
+		}
+	}
+
+	var argErr *venueArgumentError
+	if _, err := resolveVenueSlug(context.Background(), deps, "aaaaaaaaaaaaaaaaaaaaaaaa"); !errors.As(err, &argErr) {
+		t.Fatalf("expected argument error for an unknown venue id, got %v", err)
+	}
+	deps.Wolt = &venueRefTestAPI{landed: "https://wolt.com/en/discovery"}
+	if _, err := resolveVenueSlug(context.Background(), deps, "https://wolt.app.link/AbCd123"); !errors.As(err, &argErr) {
+		t.Fatalf("expected argument error for a share link without a venue, got %v", err)
+	}
+	deps.Wolt = &testWoltAPI{}
+	if _, err := resolveVenueSlug(context.Background(), deps, "https://wolt.app.link/AbCd123"); !errors.As(err, &argErr) {
+		t.Fatalf("expected argument error when the gateway cannot follow links, got %v", err)
+	}
+}
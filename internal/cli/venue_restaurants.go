@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+const (
+	restaurantCacheFile = "restaurants.json"
+	// restaurantCacheTTL keeps venue details, and with them opening hours,
+	// for repeated bulk lookups without serving a stale week.
+	restaurantCacheTTL = time.Hour
+	// restaurantLookupConcurrency caps venue detail requests in flight.
+	restaurantLookupConcurrency = 4
+)
+
+type restaurantLookup struct {
+	restaurant *domain.Restaurant
+	err        error
+}
+
+// loadRestaurants returns the details of every venue in venueIDs, fetching
+// those not cached within restaurantCacheTTL with a small worker pool. Each
+// id gets an entry; failed lookups carry their error so callers can skip the
+// venue alone.
+func loadRestaurants(ctx context.Context, deps Dependencies, venueIDs []string) map[string]restaurantLookup {
+	results := make(map[string]restaurantLookup, len(venueIDs))
+	file, _ := openCLICache(deps, restaurantCacheFile)
+	trimmed := make([]string, 0, len(venueIDs))
+	for _, venueID := range venueIDs {
+		trimmed = append(trimmed, strings.TrimSpace(venueID))
+	}
+	pending := []string{}
+	for _, venueID := range dedupeStrings(trimmed) {
+		if file != nil {
+			var cached domain.Restaurant
+			if file.Get(venueID, restaurantCacheTTL, deps.now(), &cached) {
+				results[venueID] = restaurantLookup{restaurant: &cached}
+				continue
+			}
+		}
+		pending = append(pending, venueID)
+	}
+	if len(pending) == 0 {
+		return results
+	}
+
+	fetched := make([]restaurantLookup, len(pending))
+	jobs := make(chan int)
+	workers := sync.WaitGroup{}
+	for range min(restaurantLookupConcurrency, len(pending)) {
+		workers.Go(func() {
+			for idx := range jobs {
+				restaurant, err := deps.Wolt.RestaurantByID(ctx, pending[idx])
+				if err == nil && restaurant == nil {
+					err = fmt.Errorf("%w: empty venue details", woltgateway.ErrUpstream)
+				}
+				fetched[idx] = restaurantLookup{restaurant: restaurant, err: err}
+			}
+		})
+	}
+	for idx := range pending {
+		jobs <- idx
+	}
+	close(jobs)
+	workers.Wait()
+
+	stored := false
+	for idx, venueID := range pending {
+		results[venueID] = fetched[idx]
+		if file != nil && fetched[idx].err == nil && file.Put(venueID, fetched[idx].restaurant, deps.now()) == nil {
+			stored = true
+		}
+	}
+	if stored {
+		_ = file.Save()
+	}
+	return results
+}
+
+// restaurantUnavailableReason says why a venue's details are missing: 404
+// and 410 mean the venue itself is gone, anything else that the lookup failed.
+func restaurantUnavailableReason(err error) string {
+	var upstreamErr *woltgateway.UpstreamRequestError
+	if errors.As(err, &upstreamErr) && isRecoverableRestaurantError(err) {
+		return fmt.Sprintf("opening hours unavailable (venue details answered %d)", upstreamErr.StatusCode)
+	}
+	return "opening hours unavailable"
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mekedron/wolt-cli/internal/service/output"
+)
+
+// venueRowSourceKey holds, under --verbose in JSON and YAML, the endpoint
+// family that supplied each enriched venue field.
+const venueRowSourceKey = "_source"
+
+// annotateVenueRowSources records that the listed fields of each row came
+// from the discovery front page. Enrichment updates the record as it
+// replaces or extends a field; rows without it are left alone.
+func annotateVenueRowSources(rows []any, verbose bool, format output.Format) {
+	if !verbose || format == output.FormatTable {
+		return
+	}
+	for _, rowValue := range rows {
+		if row := asMap(rowValue); row != nil {
+			row[venueRowSourceKey] = map[string]any{
+				"delivery_fee": "front_page",
+				"promotions":   "front_page",
+				"wolt_plus":    "front_page",
+			}
+		}
+	}
+}
+
+// setVenueRowSource records that family replaced field.
+func setVenueRowSource(row map[string]any, field string, family string) {
+	if sources := asMap(row[venueRowSourceKey]); sources != nil {
+		sources[field] = family
+	}
+}
+
+// addVenueRowSource records that family extended field. Fields built from
+// several payloads list them in request order, joined by "+".
+func addVenueRowSource(row map[string]any, field string, family string) {
+	sources := asMap(row[venueRowSourceKey])
+	if sources == nil {
+		return
+	}
+	current := asString(sources[field])
+	if current == "" {
+		sources[field] = family
+		return
+	}
+	for _, existing := range strings.Split(current, "+") {
+		if existing == family {
+			return
+		}
+	}
+	sources[field] = current + "+" + family
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/observability"
+	"github.com/spf13/cobra"
+)
+
+// woltPlusFreeDeliveryMinimums is the item subtotal, in minor units, from
+// which Wolt+ advertises free delivery at participating venues. Orders in
+// other currencies are not checked.
+var woltPlusFreeDeliveryMinimums = map[string]int{
+	"EUR": 1500,
+	"SEK": 15000,
+	"NOK": 15000,
+	"DKK": 10000,
+	"PLN": 4000,
+}
+
+// woltPlusOrder is what the Wolt+ check reads from a checkout preview or an
+// order detail.
+type woltPlusOrder struct {
+	venueWoltPlus bool
+	subtotal      int
+	deliveryFee   int
+	currency      string
+}
+
+// woltPlusBenefitWarnings returns a wolt_plus_benefit_missed warning when a
+// delivery fee was charged on an order the subscription should have covered:
+// the venue is marked Wolt+, the subtotal reaches the minimum, and the account
+// is a subscriber. The account is looked up only once the order qualifies; a
+// failed lookup skips the check.
+func woltPlusBenefitWarnings(
+	cmd *cobra.Command,
+	deps Dependencies,
+	flags globalFlags,
+	auth *woltgateway.AuthContext,
+	order woltPlusOrder,
+) []string {
+	currency := strings.ToUpper(strings.TrimSpace(order.currency))
+	minimum, ok := woltPlusFreeDeliveryMinimums[currency]
+	if !ok || !order.venueWoltPlus || order.deliveryFee <= 0 || order.subtotal < minimum {
+		return nil
+	}
+	payload, warnings, err := invokeWithAuthAutoRefresh(
+		cmd.Context(),
+		deps,
+		flags,
+		auth,
+		func(authCtx woltgateway.AuthContext) (map[string]any, error) {
+			return deps.Wolt.UserMe(cmd.Context(), authCtx)
+		},
+	)
+	if err != nil {
+		return nil
+	}
+	if subscriber, _ := extractWoltPlusSubscriber(payload); !subscriber {
+		return warnings
+	}
+	return append(warnings, fmt.Sprintf(
+		"wolt_plus_benefit_missed: a %s delivery fee was charged on a %s order at a Wolt+ venue; Wolt+ covers delivery from %s",
+		formatMinorAmount(order.deliveryFee, currency),
+		formatMinorAmount(order.subtotal, currency),
+		formatMinorAmount(minimum, currency),
+	))
+}
+
+// checkoutDeliveryFee reads the delivery fee of a checkout preview from its
+// price fields, or else from a checkout row labelled as delivery.
+func checkoutDeliveryFee(payload map[string]any) int {
+	breakdown := asMap(payload["payment_breakdown"])
+	for _, value := range []any{
+		payload["delivery_price"],
+		payload["delivery_fee"],
+		breakdown["delivery_fee"],
+		breakdown["delivery"],
+	} {
+		if fee, ok := value.(map[string]any); ok {
+			value = fee["amount"]
+		}
+		if fee := asInt(value); fee > 0 {
+			return fee
+		}
+	}
+	for _, value := range asSlice(payload["checkout_rows"]) {
+		row := asMap(value)
+		if asString(row["template"]) != "amount_row" || !strings.Contains(strings.ToLower(asString(row["label"])), "delivery") {
+			continue
+		}
+		if fee, ok := checkoutRowAmount(row); ok && fee > 0 {
+			return fee
+		}
+	}
+	return 0
+}
+
+// checkoutWoltPlusOrder describes a checkout preview for the Wolt+ check.
+func checkoutWoltPlusOrder(basket map[string]any, payload map[string]any, currency string) woltPlusOrder {
+	return woltPlusOrder{
+		venueWoltPlus: observability.ExtractVenueWoltPlus(map[string]any{"venue": asMap(basket["venue"])}),
+		subtotal:      basketSubtotal(basket),
+		deliveryFee:   checkoutDeliveryFee(payload),
+		currency:      currency,
+	}
+}
+
+// orderDetailWoltPlusOrder describes an order history detail for the Wolt+
+// check.
+func orderDetailWoltPlusOrder(payload map[string]any) woltPlusOrder {
+	return woltPlusOrder{
+		venueWoltPlus: observability.ExtractVenueWoltPlus(payload),
+		subtotal:      asInt(payload["items_price"]),
+		deliveryFee:   asInt(payload["delivery_price"]),
+		currency:      fallbackString(strings.TrimSpace(asString(payload["currency"])), "EUR"),
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// corporatePaymentTypes are the payment method types Wolt uses for Wolt at
+// Work company accounts.
+var corporatePaymentTypes = map[string]bool{
+	"business":     true,
+	"company":      true,
+	"corporate":    true,
+	"wolt_at_work": true,
+	"woltatwork":   true,
+	"work":         true,
+}
+
+// corporatePolicy describes the Wolt at Work account behind a payment method:
+// {company_id, company_name, policy_name, budget, allowed_times}. It is nil
+// for personal methods. budget is {amount, remaining, currency, period} or
+// null; allowed_times lists {days, start, end} windows, empty when ordering is
+// not restricted by time.
+func corporatePolicy(method map[string]any, methodType string) map[string]any {
+	company := asMap(coalesceAny(method["corporate"], method["company"], method["organization"], nestedMapValue(method, "corporate", "company", "organization")))
+	if company == nil && !corporatePaymentTypes[strings.ToLower(strings.TrimSpace(methodType))] {
+		return nil
+	}
+	policy := asMap(coalesceAny(method["policy"], company["policy"], nestedMapValue(method, "policy")))
+	var budget any
+	if limit := asMap(coalesceAny(policy["budget"], policy["spending_limit"], policy["allowance"])); limit != nil {
+		amount := asInt(coalesceAny(limit["amount"], limit["limit"]))
+		remaining := amount
+		if value := coalesceAny(limit["remaining"], limit["remaining_amount"]); value != nil {
+			remaining = asInt(coalesceAny(asMap(value)["amount"], value))
+		}
+		budget = map[string]any{
+			"amount":    amount,
+			"remaining": remaining,
+			"currency":  emptyToNil(strings.ToUpper(strings.TrimSpace(asString(limit["currency"])))),
+			"period":    emptyToNil(strings.ToLower(strings.TrimSpace(asString(limit["period"])))),
+		}
+	}
+	windows := []any{}
+	for _, value := range asSlice(coalesceAny(policy["allowed_times"], policy["delivery_times"], policy["time_restrictions"])) {
+		window := asMap(value)
+		start := strings.TrimSpace(asString(coalesceAny(window["start"], window["from"])))
+		end := strings.TrimSpace(asString(coalesceAny(window["end"], window["to"])))
+		if start == "" || end == "" {
+			continue
+		}
+		days := []any{}
+		for _, day := range asSlice(coalesceAny(window["days"], window["weekdays"])) {
+			if weekday, ok := parseWorkWeekday(asString(day)); ok {
+				days = append(days, strings.ToLower(weekday.String()[:3]))
+			}
+		}
+		windows = append(windows, map[string]any{"days": days, "start": start, "end": end})
+	}
+	return map[string]any{
+		"company_id":    emptyToNil(strings.TrimSpace(asString(coalesceAny(company["id"], company["company_id"], method["company_id"])))),
+		"company_name":  emptyToNil(strings.TrimSpace(asString(coalesceAny(company["name"], company["company_name"], method["company_name"])))),
+		"policy_name":   emptyToNil(strings.TrimSpace(asString(coalesceAny(policy["name"], policy["title"])))),
+		"budget":        budget,
+		"allowed_times": windows,
+	}
+}
+
+// parseWorkWeekday reads a policy day as a name ("mon", "Monday") or an ISO
+// number (1 is Monday, 7 Sunday).
+func parseWorkWeekday(value string) (time.Weekday, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if number, err := strconv.Atoi(value); err == nil && number >= 1 && number <= 7 {
+		return time.Weekday(number % 7), true
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if len(value) >= 3 && strings.HasPrefix(strings.ToLower(day.String()), value) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// workPaymentMethod returns the first normalized payment method backed by a
+// Wolt at Work account that can be used at checkout.
+func workPaymentMethod(methods []any) map[string]any {
+	for _, value := range methods {
+		method := asMap(value)
+		if asMap(method["corporate"]) != nil && asBool(coalesceAny(method["is_available_for_checkout"], true)) {
+			return method
+		}
+	}
+	return nil
+}
+
+// checkWorkPolicy echoes the company policy of method for a payable amount at
+// now and lists what the order would break: a budget with less remaining than
+// payable, or a time outside every allowed window. Checks that cannot be made,
+// such as a budget in another currency, are reported as null.
+func checkWorkPolicy(method map[string]any, payable int, currency string, now time.Time) (map[string]any, []string) {
+	corporate := asMap(method["corporate"])
+	data := map[string]any{
+		"method_id":            emptyToNil(asString(method["method_id"])),
+		"label":                asString(method["label"]),
+		"company_name":         corporate["company_name"],
+		"policy_name":          corporate["policy_name"],
+		"budget":               corporate["budget"],
+		"allowed_times":        corporate["allowed_times"],
+		"within_budget":        nil,
+		"within_allowed_times": nil,
+	}
+	violations := []string{}
+	if budget := asMap(corporate["budget"]); budget != nil {
+		budgetCurrency := asString(budget["currency"])
+		if budgetCurrency == "" || strings.EqualFold(budgetCurrency, currency) {
+			remaining := asInt(budget["remaining"])
+			data["within_budget"] = payable <= remaining
+			if payable > remaining {
+				violations = append(violations, fmt.Sprintf(
+					"the order total %s is over the %s company budget left",
+					formatMinorAmount(payable, currency),
+					formatMinorAmount(remaining, currency),
+				))
+			}
+		}
+	}
+	if windows := asSlice(corporate["allowed_times"]); len(windows) > 0 {
+		within := false
+		for _, value := range windows {
+			within = within || inWorkWindow(asMap(value), now)
+		}
+		data["within_allowed_times"] = within
+		if !within {
+			violations = append(violations, fmt.Sprintf("the company policy does not allow orders on %s at %s", now.Weekday(), now.Format("15:04")))
+		}
+	}
+	return data, violations
+}
+
+// inWorkWindow reports whether now falls in a {days, start, end} window; a
+// window whose end is before its start runs past midnight, and one without
+// days applies every day.
+func inWorkWindow(window map[string]any, now time.Time) bool {
+	start, startErr := time.Parse("15:04", asString(window["start"]))
+	end, endErr := time.Parse("15:04", asString(window["end"]))
+	if startErr != nil || endErr != nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	from, to := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+	day := now.Weekday()
+	inTime := minute >= from && minute < to
+	if to <= from {
+		inTime = minute >= from || minute < to
+		if minute < to {
+			day = (day + 6) % 7
+		}
+	}
+	if !inTime {
+		return false
+	}
+	days := asSlice(window["days"])
+	if len(days) == 0 {
+		return true
+	}
+	for _, value := range days {
+		if weekday, ok := parseWorkWeekday(asString(value)); ok && weekday == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInWorkWindowHandlesDaysAndMidnight(t *testing.T) {
+	weekdays := map[string]any{"days": []any{"1", "2", "3", "4", "5"}, "start": "10:30", "end": "14:00"}
+	late := map[string]any{"days": []any{"fri"}, "start": "22:00", "end": "02:00"}
+	cases := []struct {
+		name   string
+		window map[string]any
+		at     time.Time
+		want   bool
+	}{
+		{"weekday lunch", weekdays, time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC), true},
+		{"weekday at end", weekdays, time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC), false},
+		{"saturday lunch", weekdays, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), false},
+		{"friday night", late, time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC), true},
+		{"after midnight counts as friday", late, time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC), true},
+		{"thursday night", late, time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC), false},
+	}
+	for _, tc := range cases {
+		if got := inWorkWindow(tc.window, tc.at); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCheckWorkPolicySkipsBudgetInOtherCurrency(t *testing.T) {
+	method := map[string]any{
+		"label": "Acme lunch",
+		"corporate": corporatePolicy(map[string]any{
+			"company": map[string]any{"name": "Acme AB"},
+			"policy":  map[string]any{"budget": map[string]any{"amount": 10000, "currency": "SEK"}},
+		}, "card"),
+	}
+	data, violations := checkWorkPolicy(method, 20000, "EUR", time.Now())
+	if len(violations) != 0 || data["within_budget"] != nil || data["within_allowed_times"] != nil {
+		t.Fatalf("expected no checks for a SEK budget on a EUR order, got %v %v", data, violations)
+	}
+	data, violations = checkWorkPolicy(method, 20000, "SEK", time.Now())
+	if len(violations) != 1 || data["within_budget"] != false {
+		t.Fatalf("expected the SEK order over budget, got %v %v", data, violations)
+	}
+}
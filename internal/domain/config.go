@@ -2,16 +2,85 @@ package domain
 
 // Profile stores user location settings.
 type Profile struct {
-	Name          string   `json:"name"`
-	IsDefault     bool     `json:"is_default"`
-	Location      Location `json:"location"`
-	WToken        string   `json:"wtoken,omitempty"`
-	WRefreshToken string   `json:"wrefresh_token,omitempty"`
-	Cookies       []string `json:"cookies,omitempty"`
-	WoltAddressID string   `json:"wolt_address_id,omitempty"`
+	Name               string                `json:"name"`
+	IsDefault          bool                  `json:"is_default"`
+	Location           Location              `json:"location"`
+	WToken             string                `json:"wtoken,omitempty"`
+	WRefreshToken      string                `json:"wrefresh_token,omitempty"`
+	Cookies            []string              `json:"cookies,omitempty"`
+	WoltAddressID      string                `json:"wolt_address_id,omitempty"`
+	DefaultTipPercent  float64               `json:"default_tip_percent,omitempty"`
+	AutoApplyBestPromo bool                  `json:"auto_apply_best_promo,omitempty"`
+	Locale             string                `json:"locale,omitempty"`
+	LatestOrderTime    string                `json:"latest_order_time,omitempty"`
+	ReadOnly           bool                  `json:"read_only,omitempty"`
+	Budget             *Budget               `json:"budget,omitempty"`
+	Approval           *ApprovalPolicy       `json:"approval,omitempty"`
+	AllowedCountries   []string              `json:"allowed_countries,omitempty"`
+	MealPresets        map[string]MealPreset `json:"meal_presets,omitempty"`
+	Travel             *TravelState          `json:"travel,omitempty"`
+	PluginAuth         []string              `json:"plugin_auth,omitempty"`
+	EnrichmentMode     string                `json:"enrichment_mode,omitempty"`
+}
+
+// TravelState marks a temporary profile created by `wolt travel set`. Its
+// Location replaces the Wolt account address until `wolt travel clear`.
+type TravelState struct {
+	Address         string `json:"address"`
+	Country         string `json:"country,omitempty"`
+	Currency        string `json:"currency,omitempty"`
+	PreviousDefault string `json:"previous_default,omitempty"`
+	SetAt           string `json:"set_at"`
+}
+
+// Budget caps spend per calendar period. Amount is in minor units of
+// Currency; Period is "month" or "week".
+type Budget struct {
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+	Period   string `json:"period"`
+}
+
+// ApprovalPolicy requires approval for cart and checkout commands whose
+// basket total exceeds Threshold minor units of Currency.
+type ApprovalPolicy struct {
+	Threshold int    `json:"threshold"`
+	Currency  string `json:"currency"`
+}
+
+// MealPreset overrides a discover meal preset: the local time window it
+// covers and the venue tags it keeps.
+type MealPreset struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Hook is one step run around commands: a shell snippet (Run) or a webhook
+// that receives a JSON POST (URL). Commands limits it to command paths such
+// as "cart" or "checkout preview"; empty means every command.
+type Hook struct {
+	Run      string   `json:"run,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// Hooks holds the steps run before and after commands. A failing pre_command
+// hook stops the command; post_command failures are only reported.
+type Hooks struct {
+	PreCommand  []Hook `json:"pre_command,omitempty"`
+	PostCommand []Hook `json:"post_command,omitempty"`
+}
+
+// HTTPSettings tunes upstream requests. MinIntervalMS paces endpoint
+// families (as named by --stats) or hosts on their own, in milliseconds.
+type HTTPSettings struct {
+	MinIntervalMS map[string]int `json:"min_interval_ms,omitempty"`
 }
 
 // Config stores all local profiles.
 type Config struct {
-	Profiles []Profile `json:"profiles"`
+	Profiles []Profile     `json:"profiles"`
+	Hooks    *Hooks        `json:"hooks,omitempty"`
+	HTTP     *HTTPSettings `json:"http,omitempty"`
 }
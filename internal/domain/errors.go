@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+// ErrOffline is returned when offline mode forbids a network call and no local copy exists.
+var ErrOffline = errors.New("offline: data is not available locally")
+
+// ErrAddressNotFound is returned when the geocoder has no match for an address.
+var ErrAddressNotFound = errors.New("no location matches the address")
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
 )
 
 // NormalizeID normalizes mixed payload id values.
@@ -23,6 +25,22 @@ func NormalizeID(v any) string {
 	}
 }
 
+// FillVenueCity sets city on section venues whose payload leaves it empty.
+// Front-page venues carry the city only once, at the page level.
+func FillVenueCity(sections []Section, city string) {
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return
+	}
+	for _, section := range sections {
+		for _, item := range section.Items {
+			if item.Venue != nil && strings.TrimSpace(item.Venue.City) == "" {
+				item.Venue.City = city
+			}
+		}
+	}
+}
+
 func capitalizeWords(values []string) []string {
 	out := make([]string, 0, len(values))
 	for _, v := range values {
@@ -69,7 +87,7 @@ func (v Venue) FormatDeliveryPrice() string {
 	if !v.Delivers {
 		return "(No delivery)"
 	}
-	return fmt.Sprintf("%.2f %s", float64(*v.DeliveryPriceInt)/100, v.Currency)
+	return money.Decimal(*v.DeliveryPriceInt, v.Currency) + " " + v.Currency
 }
 
 // FormatRating renders venue rating.
@@ -25,25 +25,28 @@ type Badge struct {
 
 // Venue stores discovery item venue details.
 type Venue struct {
-	ID               any      `json:"id"`
-	Slug             string   `json:"slug"`
-	Name             string   `json:"name"`
-	Address          string   `json:"address"`
-	Badges           []Badge  `json:"badges"`
-	Promotions       []any    `json:"promotions"`
-	Country          string   `json:"country"`
-	Currency         string   `json:"currency"`
-	Delivers         bool     `json:"delivers"`
-	DeliveryPriceInt *int     `json:"delivery_price_int"`
-	EstimateRange    string   `json:"estimate_range"`
-	Estimate         float64  `json:"estimate"`
-	Icon             string   `json:"icon"`
-	Online           *bool    `json:"online"`
-	ProductLine      string   `json:"product_line"`
-	ShowWoltPlus     bool     `json:"show_wolt_plus"`
-	Tags             []string `json:"tags"`
-	Rating           *Rating  `json:"rating"`
-	PriceRange       int      `json:"price_range"`
+	ID               any       `json:"id"`
+	Slug             string    `json:"slug"`
+	Name             string    `json:"name"`
+	Address          string    `json:"address"`
+	City             string    `json:"city"`
+	Location         []float64 `json:"location"`
+	PublicURL        string    `json:"public_url"`
+	Badges           []Badge   `json:"badges"`
+	Promotions       []any     `json:"promotions"`
+	Country          string    `json:"country"`
+	Currency         string    `json:"currency"`
+	Delivers         bool      `json:"delivers"`
+	DeliveryPriceInt *int      `json:"delivery_price_int"`
+	EstimateRange    string    `json:"estimate_range"`
+	Estimate         float64   `json:"estimate"`
+	Icon             string    `json:"icon"`
+	Online           *bool     `json:"online"`
+	ProductLine      string    `json:"product_line"`
+	ShowWoltPlus     bool      `json:"show_wolt_plus"`
+	Tags             []string  `json:"tags"`
+	Rating           *Rating   `json:"rating"`
+	PriceRange       int       `json:"price_range"`
 }
 
 // Link stores item link metadata.
@@ -53,17 +56,34 @@ type Link struct {
 
 // Item stores discovery items and menu placeholders.
 type Item struct {
-	Title   string `json:"title"`
-	TrackID string `json:"track_id"`
-	Link    Link   `json:"link"`
-	Venue   *Venue `json:"venue"`
+	Title           string `json:"title"`
+	TrackID         string `json:"track_id"`
+	Link            Link   `json:"link"`
+	Venue           *Venue `json:"venue"`
+	IsAdvertisement bool   `json:"is_advertisement"`
+	IsSponsored     bool   `json:"is_sponsored"`
+}
+
+// IsAd reports whether the item is a paid placement.
+func (i Item) IsAd() bool {
+	return i.IsAdvertisement || i.IsSponsored
+}
+
+// SectionLink stores the "see all" link of a front-page section.
+type SectionLink struct {
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Title  string `json:"title"`
 }
 
 // Section stores front-page sections.
 type Section struct {
-	Name  string `json:"name"`
-	Title string `json:"title"`
-	Items []Item `json:"items"`
+	Name        string      `json:"name"`
+	Title       string      `json:"title"`
+	Subtitle    string      `json:"subtitle"`
+	Description string      `json:"description"`
+	Link        SectionLink `json:"link"`
+	Items       []Item      `json:"items"`
 }
 
 // Translation stores localized text fields.
@@ -115,4 +135,17 @@ type Restaurant struct {
 	OpeningTimes          map[string][]Times `json:"opening_times"`
 	DeliveryMethods       []string           `json:"delivery_methods"`
 	TimezoneName          string             `json:"timezone_name"`
+	PreorderEnabled       bool               `json:"preorder_enabled"`
+	PreorderOnly          bool               `json:"preorder_only"`
+	PreorderTimes         PreorderTimes      `json:"preorder_times"`
+}
+
+// PreorderTimes stores weekly preorder windows and booking limits.
+type PreorderTimes struct {
+	Delivery          map[string][]Times `json:"delivery"`
+	Takeaway          map[string][]Times `json:"takeaway"`
+	MaximumDays       int                `json:"maximum_days"`
+	MinimumTimeLimit  int                `json:"minimum_time_limit"`
+	MinimumTimeLimits map[string]int     `json:"minimum_time_limits"`
+	TimeStep          int                `json:"time_step"`
 }
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
@@ -23,6 +24,7 @@ var ErrLocationLookup = errors.New("error when trying to get location")
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	offline    atomic.Bool
 }
 
 type coordinate float64
@@ -60,8 +62,16 @@ func NewClient() *Client {
 	}
 }
 
+// SetOffline makes Get fail with domain.ErrOffline instead of calling Nominatim.
+func (c *Client) SetOffline(enabled bool) {
+	c.offline.Store(enabled)
+}
+
 // Get resolves an address using OSM Nominatim.
 func (c *Client) Get(ctx context.Context, address string) (domain.Location, error) {
+	if c.offline.Load() {
+		return domain.Location{}, fmt.Errorf("%w: %w (geocoding %q needs the network; pass --lat/--lon)", ErrLocationLookup, domain.ErrOffline, address)
+	}
 	query := url.Values{}
 	query.Set("q", address)
 	query.Set("format", "json")
@@ -89,7 +99,7 @@ func (c *Client) Get(ctx context.Context, address string) (domain.Location, erro
 		return domain.Location{}, fmt.Errorf("%w: %v", ErrLocationLookup, err)
 	}
 	if len(payload) == 0 {
-		return domain.Location{}, ErrLocationLookup
+		return domain.Location{}, fmt.Errorf("%w: %w", ErrLocationLookup, domain.ErrAddressNotFound)
 	}
 	return domain.Location{
 		Lat: float64(payload[0].Lat),
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -61,3 +63,11 @@ func TestGetReturnsLookupErrorOnInvalidCoordinates(t *testing.T) {
 		t.Fatalf("expected ErrLocationLookup, got %v", err)
 	}
 }
+
+func TestGetReportsAddressNotFoundOnEmptyResults(t *testing.T) {
+	client := newTestClient(t, `[]`, http.StatusOK)
+	_, err := client.Get(context.Background(), "Nowhere 1")
+	if !errors.Is(err, ErrLocationLookup) || !errors.Is(err, domain.ErrAddressNotFound) {
+		t.Fatalf("expected ErrLocationLookup wrapping ErrAddressNotFound, got %v", err)
+	}
+}
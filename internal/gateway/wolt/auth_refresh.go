@@ -0,0 +1,114 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TokenRefreshHandler receives the tokens that replaced previous after an
+// upstream 401, so callers can persist them.
+type TokenRefreshHandler func(ctx context.Context, previous AuthContext, result TokenRefreshResult)
+
+// SetTokenRefreshHandler registers the callback run after every automatic
+// token refresh.
+func (c *Client) SetTokenRefreshHandler(handler TokenRefreshHandler) {
+	c.authM.Lock()
+	c.tokenRefreshed = handler
+	c.authM.Unlock()
+}
+
+// RotatedAuth returns the credentials that replaced auth after automatic
+// refreshes during this client's lifetime.
+func (c *Client) RotatedAuth(auth AuthContext) (AuthContext, bool) {
+	c.authM.Lock()
+	defer c.authM.Unlock()
+	latest := c.latestAuthLocked(auth)
+	return latest, latest.WToken != auth.WToken
+}
+
+// latestAuthLocked follows recorded rotations from auth's access token.
+func (c *Client) latestAuthLocked(auth AuthContext) AuthContext {
+	for range len(c.rotated) {
+		next, ok := c.rotated[strings.TrimSpace(auth.WToken)]
+		if !ok {
+			break
+		}
+		next.Cookies = auth.Cookies
+		auth = next
+	}
+	return auth
+}
+
+// doAuthorized runs send with the newest credentials known for auth. When the
+// request fails with 401 and a refresh token is available, the access token is
+// refreshed once and send is retried with it.
+func (c *Client) doAuthorized(ctx context.Context, auth AuthContext, send func(AuthContext) error) error {
+	c.authM.Lock()
+	auth = c.latestAuthLocked(auth)
+	c.authM.Unlock()
+
+	err := send(auth)
+	var upstreamErr *UpstreamRequestError
+	if !errors.As(err, &upstreamErr) || upstreamErr.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+	refreshed, refreshErr := c.refreshAfterUnauthorized(ctx, auth)
+	if refreshErr != nil {
+		return fmt.Errorf("%w: automatic token refresh failed: %v", err, refreshErr)
+	}
+	if refreshed.WToken == auth.WToken {
+		return err
+	}
+	return send(refreshed)
+}
+
+// refreshAfterUnauthorized exchanges auth's refresh token for a new access
+// token. Concurrent callers holding the same stale token share one refresh.
+func (c *Client) refreshAfterUnauthorized(ctx context.Context, auth AuthContext) (AuthContext, error) {
+	c.authM.Lock()
+	defer c.authM.Unlock()
+	if latest := c.latestAuthLocked(auth); latest.WToken != auth.WToken {
+		return latest, nil
+	}
+	if strings.TrimSpace(auth.RefreshToken) == "" {
+		return auth, nil
+	}
+	result, err := c.RefreshAccessToken(ctx, auth.RefreshToken, auth)
+	if err != nil {
+		return auth, err
+	}
+	refreshed := auth
+	refreshed.WToken = strings.TrimSpace(result.AccessToken)
+	refreshed.RefreshToken = strings.TrimSpace(result.RefreshToken)
+	if c.rotated == nil {
+		c.rotated = map[string]AuthContext{}
+	}
+	c.rotated[strings.TrimSpace(auth.WToken)] = refreshed
+	if c.tokenRefreshed != nil {
+		c.tokenRefreshed(ctx, auth, result)
+	}
+	return refreshed, nil
+}
+
+// doAuthJSONRequest is doJSONRequest for endpoints that take account credentials.
+func (c *Client) doAuthJSONRequest(
+	ctx context.Context,
+	method string,
+	rawURL string,
+	params url.Values,
+	body any,
+	extra map[string]string,
+	auth AuthContext,
+) (map[string]any, error) {
+	var payload map[string]any
+	err := c.doAuthorized(ctx, auth, func(auth AuthContext) error {
+		var err error
+		payload, err = c.doJSONRequest(ctx, method, rawURL, params, body, c.headers(extra, &auth))
+		return err
+	})
+	return payload, err
+}
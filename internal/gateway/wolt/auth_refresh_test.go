@@ -0,0 +1,96 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// tokenHTTPClient accepts one access token and issues it from the token endpoint.
+type tokenHTTPClient struct {
+	validToken    string
+	refreshCalls  int
+	authorization []string
+}
+
+func (c *tokenHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, `{"count":1}`
+	if strings.Contains(req.URL.Path, "/oauth2/token") {
+		c.refreshCalls++
+		body = `{"access_token":"` + c.validToken + `","refresh_token":"rt-2"}`
+	} else {
+		c.authorization = append(c.authorization, req.Header.Get("Authorization"))
+		if req.Header.Get("Authorization") != "Bearer "+c.validToken {
+			status, body = http.StatusUnauthorized, `{"error":"expired"}`
+		}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func newTokenTestClient(httpClient HTTPClient) *Client {
+	return NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{
+			BasketCount: "https://example.test/v1/baskets/count",
+			AccessToken: "https://example.test/oauth2/token",
+			RawBase:     "https://example.test",
+		}),
+	)
+}
+
+func TestAuthenticatedCallRefreshesOnceOn401(t *testing.T) {
+	httpClient := &tokenHTTPClient{validToken: "fresh"}
+	client := newTokenTestClient(httpClient)
+	var persisted []TokenRefreshResult
+	client.SetTokenRefreshHandler(func(_ context.Context, previous AuthContext, result TokenRefreshResult) {
+		if previous.WToken != "stale" {
+			t.Errorf("expected the stale token as previous, got %q", previous.WToken)
+		}
+		persisted = append(persisted, result)
+	})
+	stale := AuthContext{WToken: "stale", RefreshToken: "rt-1"}
+
+	if _, err := client.BasketCount(context.Background(), stale); err != nil {
+		t.Fatalf("expected retry after refresh to succeed, got %v", err)
+	}
+	// A later call holding the same stale credentials reuses the rotated token.
+	if _, err := client.BasketCount(context.Background(), stale); err != nil {
+		t.Fatalf("expected rotated token to be reused, got %v", err)
+	}
+	if httpClient.refreshCalls != 1 || len(persisted) != 1 || persisted[0].RefreshToken != "rt-2" {
+		t.Fatalf("expected one refresh handed to the handler, got %d calls and %v", httpClient.refreshCalls, persisted)
+	}
+	want := []string{"Bearer stale", "Bearer fresh", "Bearer fresh"}
+	if strings.Join(httpClient.authorization, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected authorization sequence %v", httpClient.authorization)
+	}
+	rotated, changed := client.RotatedAuth(stale)
+	if !changed || rotated.WToken != "fresh" || rotated.RefreshToken != "rt-2" {
+		t.Fatalf("expected rotated credentials, got %+v (changed=%v)", rotated, changed)
+	}
+}
+
+func TestAuthenticatedCallWithoutRefreshTokenReturns401(t *testing.T) {
+	httpClient := &tokenHTTPClient{validToken: "fresh"}
+	client := newTokenTestClient(httpClient)
+
+	_, err := client.Raw(context.Background(), http.MethodGet, "/v1/baskets/count", nil, AuthContext{WToken: "stale"})
+	var upstreamErr *UpstreamRequestError
+	if !errors.As(err, &upstreamErr) || upstreamErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the 401 to surface, got %v", err)
+	}
+	if httpClient.refreshCalls != 0 || len(httpClient.authorization) != 1 {
+		t.Fatalf("expected a single attempt without refresh, got %d refreshes and %v", httpClient.refreshCalls, httpClient.authorization)
+	}
+	if _, changed := client.RotatedAuth(AuthContext{WToken: "stale"}); changed {
+		t.Fatal("expected no rotation without a refresh token")
+	}
+}
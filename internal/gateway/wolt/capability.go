@@ -0,0 +1,76 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrUnsupportedInRegion marks a request that was not sent because its
+// endpoint family answered 410 Gone for this region before.
+var ErrUnsupportedInRegion = errors.New("endpoint is not available in this region")
+
+// CapabilityHandler is called when a request to family is refused upfront
+// (detected false) or the endpoint answers 410 Gone (detected true).
+type CapabilityHandler func(ctx context.Context, family string, detected bool)
+
+// SetUnsupportedFamilies replaces the endpoint families that are refused
+// without a request. Families are the names used by request timings.
+func (c *Client) SetUnsupportedFamilies(families []string) {
+	c.capabilityM.Lock()
+	defer c.capabilityM.Unlock()
+	c.unsupported = map[string]bool{}
+	for _, family := range families {
+		c.unsupported[family] = true
+	}
+}
+
+// SetCapabilityHandler registers the callback for refused and newly detected
+// unsupported families.
+func (c *Client) SetCapabilityHandler(handler CapabilityHandler) {
+	c.capabilityM.Lock()
+	c.capabilityChanged = handler
+	c.capabilityM.Unlock()
+}
+
+// checkCapability refuses requests to families marked unsupported. The error
+// carries status 410 so fallbacks written for a live 410 still apply.
+func (c *Client) checkCapability(ctx context.Context, method string, rawURL string) error {
+	family, ok := c.configuredFamily(rawURL)
+	if !ok {
+		return nil
+	}
+	c.capabilityM.Lock()
+	refused, handler := c.unsupported[family], c.capabilityChanged
+	c.capabilityM.Unlock()
+	if !refused {
+		return nil
+	}
+	if handler != nil {
+		handler(ctx, family, false)
+	}
+	c.tracef("[http] skip %s %s unsupported_in_region family=%s", method, rawURL, family)
+	return &UpstreamRequestError{Method: method, URL: rawURL, StatusCode: http.StatusGone, Cause: ErrUnsupportedInRegion}
+}
+
+// observeCapability marks family unsupported after a 410 Gone answer.
+func (c *Client) observeCapability(ctx context.Context, rawURL string, statusCode int) {
+	if statusCode != http.StatusGone {
+		return
+	}
+	family, ok := c.configuredFamily(rawURL)
+	if !ok {
+		return
+	}
+	c.capabilityM.Lock()
+	if c.unsupported == nil {
+		c.unsupported = map[string]bool{}
+	}
+	known := c.unsupported[family]
+	c.unsupported[family] = true
+	handler := c.capabilityChanged
+	c.capabilityM.Unlock()
+	if !known && handler != nil {
+		handler(ctx, family, true)
+	}
+}
@@ -0,0 +1,53 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGoneEndpointIsRefusedUpfrontAfterDetection(t *testing.T) {
+	httpClient := &captureHTTPClient{statusCode: http.StatusGone, responseBody: `{"error":"gone"}`}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{
+			PaymentMethods: "https://example.test/v3/user/me/payment_methods",
+			BasketCount:    "https://example.test/baskets/count",
+		}),
+	)
+	type event struct {
+		family   string
+		detected bool
+	}
+	var events []event
+	client.SetCapabilityHandler(func(_ context.Context, family string, detected bool) {
+		events = append(events, event{family, detected})
+	})
+
+	auth := AuthContext{WToken: "token"}
+	if _, err := client.PaymentMethods(context.Background(), auth); errors.Is(err, ErrUnsupportedInRegion) {
+		t.Fatalf("expected the first 410 to come from upstream, got %v", err)
+	}
+	_, err := client.PaymentMethods(context.Background(), auth)
+	var upstreamErr *UpstreamRequestError
+	if !errors.Is(err, ErrUnsupportedInRegion) || !errors.As(err, &upstreamErr) || upstreamErr.StatusCode != http.StatusGone {
+		t.Fatalf("expected a refused request with status 410, got %v", err)
+	}
+	if httpClient.doCalls != 1 {
+		t.Fatalf("expected one upstream call, got %d", httpClient.doCalls)
+	}
+	want := []event{{"payment_methods", true}, {"payment_methods", false}}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+
+	httpClient.statusCode = http.StatusOK
+	if _, err := client.BasketCount(context.Background(), auth); err != nil {
+		t.Fatalf("expected other families to stay available, got %v", err)
+	}
+	client.SetUnsupportedFamilies(nil)
+	if _, err := client.PaymentMethods(context.Background(), auth); err != nil {
+		t.Fatalf("expected cleared family to be requested again, got %v", err)
+	}
+}
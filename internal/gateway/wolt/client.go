@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
@@ -27,9 +28,12 @@ const (
 	defaultVenueContentAPIURL   = "https://consumer-api.wolt.com/consumer-api/venue-content-api/v3/web/venue-content/slug/"
 	defaultVenueItemAPIURL      = "https://restaurant-api.wolt.com/order-xp/web/v1/pages/venue/"
 	defaultRestaurantAPIURL     = "https://restaurant-api.wolt.com/v3/venues/"
+	defaultCitiesAPIURL         = "https://restaurant-api.wolt.com/v1/cities"
 	defaultUserMeAPIURL         = "https://restaurant-api.wolt.com/v1/user/me"
 	defaultPaymentMethodsAPIURL = "https://restaurant-api.wolt.com/v3/user/me/payment_methods"
 	defaultPaymentProfileAPIURL = "https://payment-service.wolt.com/v1/payment-methods/profile"
+	defaultCardSetupAPIURL      = "https://payment-service.wolt.com/v1/payment-methods/card/setup-session"
+	defaultPaymentBalancesURL   = "https://payment-service.wolt.com/v1/payment-methods/balances"
 	defaultAddressFieldsAPIURL  = "https://restaurant-api.wolt.com/v1/consumer-api/address-fields"
 	defaultDeliveryInfoAPIURL   = "https://restaurant-api.wolt.com/v2/delivery/info"
 	defaultOrderHistoryAPIURL   = "https://consumer-api.wolt.com/order-tracking-api/v1/order_history/"
@@ -41,6 +45,7 @@ const (
 	defaultBasketBulkDeleteURL  = "https://consumer-api.wolt.com/order-xp/v1/baskets/bulk/delete"
 	defaultCheckoutAPIURL       = "https://consumer-api.wolt.com/order-xp/web/v2/pages/checkout"
 	defaultAccessTokenAPIURL    = "https://authentication.wolt.com/v1/wauth2/access_token"
+	defaultRawBaseURL           = "https://consumer-api.wolt.com"
 	defaultPlatformHeader       = "Web"
 	defaultClientVersionHeader  = "1.16.79"
 	defaultSessionIDHeader      = "no-analytics-consent"
@@ -92,9 +97,12 @@ type Endpoints struct {
 	VenueContent     string
 	VenueItem        string
 	Restaurant       string
+	Cities           string
 	UserMe           string
 	PaymentMethods   string
 	PaymentProfile   string
+	CardSetup        string
+	PaymentBalances  string
 	AddressFields    string
 	DeliveryInfo     string
 	OrderHistory     string
@@ -106,19 +114,36 @@ type Endpoints struct {
 	BasketBulkDelete string
 	Checkout         string
 	AccessToken      string
+	RawBase          string
 }
 
 // Client queries Wolt public endpoints.
 type Client struct {
-	httpClient     HTTPClient
-	endpoints      Endpoints
-	locale         string
-	webClientID    string
-	minRequestGap  time.Duration
-	requestWindowM sync.Mutex
-	nextRequestAt  time.Time
-	verboseOutput  io.Writer
-	verboseOutputM sync.RWMutex
+	httpClient        HTTPClient
+	endpoints         Endpoints
+	locale            string
+	webClientID       string
+	minRequestGap     time.Duration
+	familyMinGaps     map[string]time.Duration
+	requestWindowM    sync.Mutex
+	nextRequestAt     map[string]time.Time
+	verboseOutput     io.Writer
+	verboseOutputM    sync.RWMutex
+	recordDir         string
+	offline           atomic.Bool
+	fixtureRecorder   atomic.Pointer[RecordingHTTPClient]
+	readOnly          atomic.Bool
+	authM             sync.Mutex
+	rotated           map[string]AuthContext
+	tokenRefreshed    TokenRefreshHandler
+	capabilityM       sync.Mutex
+	unsupported       map[string]bool
+	capabilityChanged CapabilityHandler
+	flights           flightGroup
+	cache             *responseCache
+	clientHeadersM    sync.RWMutex
+	clientHeaders     map[string]string
+	userAgent         string
 }
 
 // Option applies Client options.
@@ -175,9 +200,12 @@ func NewClient(opts ...Option) *Client {
 			VenueContent:     defaultVenueContentAPIURL,
 			VenueItem:        defaultVenueItemAPIURL,
 			Restaurant:       defaultRestaurantAPIURL,
+			Cities:           defaultCitiesAPIURL,
 			UserMe:           defaultUserMeAPIURL,
 			PaymentMethods:   defaultPaymentMethodsAPIURL,
 			PaymentProfile:   defaultPaymentProfileAPIURL,
+			CardSetup:        defaultCardSetupAPIURL,
+			PaymentBalances:  defaultPaymentBalancesURL,
 			AddressFields:    defaultAddressFieldsAPIURL,
 			DeliveryInfo:     defaultDeliveryInfoAPIURL,
 			OrderHistory:     defaultOrderHistoryAPIURL,
@@ -189,6 +217,7 @@ func NewClient(opts ...Option) *Client {
 			BasketBulkDelete: defaultBasketBulkDeleteURL,
 			Checkout:         defaultCheckoutAPIURL,
 			AccessToken:      defaultAccessTokenAPIURL,
+			RawBase:          defaultRawBaseURL,
 		},
 		locale:      "en",
 		webClientID: generateWebClientID(),
@@ -199,6 +228,38 @@ func NewClient(opts ...Option) *Client {
 	return c
 }
 
+// SetOffline forbids network calls; requests are answered only from fixtures in
+// the record directory and fail with domain.ErrOffline otherwise.
+func (c *Client) SetOffline(enabled bool) {
+	c.offline.Store(enabled)
+}
+
+// SetFixtureRecording records the responses of later requests into dir, as
+// WithRecordDir does, until it is called again with an empty dir.
+func (c *Client) SetFixtureRecording(dir string) {
+	if strings.TrimSpace(dir) == "" {
+		c.fixtureRecorder.Store(nil)
+		return
+	}
+	c.fixtureRecorder.Store(NewRecordingHTTPClient(c.httpClient, dir))
+}
+
+// EndpointFamily names the endpoint family a request URL belongs to, as used
+// by --stats and per-family pacing.
+func (c *Client) EndpointFamily(rawURL string) string {
+	return c.endpointFamily(rawURL)
+}
+
+func (c *Client) transport() HTTPClient {
+	if c.offline.Load() {
+		return NewOfflineHTTPClient(c.recordDir)
+	}
+	if recorder := c.fixtureRecorder.Load(); recorder != nil {
+		return recorder
+	}
+	return c.httpClient
+}
+
 // SetVerboseOutput sets destination for verbose HTTP request trace lines.
 func (c *Client) SetVerboseOutput(out io.Writer) {
 	c.verboseOutputM.Lock()
@@ -206,6 +267,11 @@ func (c *Client) SetVerboseOutput(out io.Writer) {
 	c.verboseOutputM.Unlock()
 }
 
+// RequestMinInterval reports the minimum delay kept between upstream calls.
+func (c *Client) RequestMinInterval() time.Duration {
+	return c.minRequestGap
+}
+
 func (c *Client) headers(extra map[string]string, auth *AuthContext) map[string]string {
 	headers := map[string]string{
 		"app-language":        c.locale,
@@ -217,7 +283,16 @@ func (c *Client) headers(extra map[string]string, auth *AuthContext) map[string]
 	if strings.TrimSpace(c.webClientID) != "" {
 		headers["x-wolt-web-clientid"] = c.webClientID
 	}
+	for name, value := range c.customHeaders() {
+		for existing := range headers {
+			if strings.EqualFold(existing, name) {
+				delete(headers, existing)
+			}
+		}
+		headers[name] = value
+	}
 	if auth != nil {
+		RememberAuthSecrets(*auth)
 		token := strings.TrimSpace(auth.WToken)
 		if token != "" {
 			headers["Authorization"] = "Bearer " + token
@@ -247,40 +322,123 @@ func (c *Client) doJSONRequest(ctx context.Context, method, rawURL string, param
 		rawURL = rawURL + "?" + params.Encode()
 	}
 
-	var bodyReader io.Reader
-	bodyBytes := 0
+	var requestBody []byte
 	if body != nil {
 		payload, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshal request body: %w", err)
 		}
-		bodyBytes = len(payload)
-		bodyReader = bytes.NewReader(payload)
+		requestBody = payload
+	}
+
+	payload := map[string]any{}
+	err := c.doPayloadRequest(ctx, method, rawURL, requestBody, headers, func(raw []byte) error {
+		return json.Unmarshal(raw, &payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// doPayloadRequest sends one rate-limited request and hands a non-empty 2xx body to decode.
+// A GET without body that is already in flight with the same headers waits for
+// that request instead of sending its own, and one the memory cache holds is
+// not sent at all.
+func (c *Client) doPayloadRequest(
+	ctx context.Context,
+	method string,
+	rawURL string,
+	requestBody []byte,
+	headers map[string]string,
+	decode func(raw []byte) error,
+) error {
+	if err := c.checkReadOnly(method, rawURL); err != nil {
+		return err
+	}
+	if err := c.checkCapability(ctx, method, rawURL); err != nil {
+		return err
+	}
+	fetch := func() ([]byte, int, error) {
+		return c.fetchPayload(ctx, method, rawURL, requestBody, headers)
+	}
+	var rawResponse []byte
+	var statusCode int
+	var err error
+	if method == http.MethodGet && requestBody == nil {
+		key, family := flightKey(method, rawURL, headers), c.endpointFamily(rawURL)
+		if raw, code, ok := c.cache.get(key, family); ok {
+			requestTimingsFromContext(ctx).observeCache(family, true)
+			c.tracef("[http] == %s %s served from the memory cache", method, rawURL)
+			rawResponse, statusCode = raw, code
+		} else {
+			if c.cache.covers(family) {
+				requestTimingsFromContext(ctx).observeCache(family, false)
+			}
+			var shared bool
+			rawResponse, statusCode, shared, err = c.flights.do(ctx, key, fetch)
+			if shared {
+				requestTimingsFromContext(ctx).observeShared(family)
+				c.tracef("[http] == %s %s shared with a concurrent identical request", method, rawURL)
+			} else if err == nil {
+				c.cache.put(key, family, rawResponse, statusCode)
+			}
+		}
+	} else {
+		rawResponse, statusCode, err = fetch()
+	}
+	if err != nil || len(rawResponse) == 0 {
+		return err
+	}
+
+	if err := decode(rawResponse); err != nil {
+		return &UpstreamRequestError{
+			Method:     method,
+			URL:        rawURL,
+			StatusCode: statusCode,
+			Body:       string(rawResponse),
+			Cause:      fmt.Errorf("decode response body: %w", err),
+		}
+	}
+	return nil
+}
+
+// fetchPayload sends the request and returns the body of a 2xx response.
+func (c *Client) fetchPayload(
+	ctx context.Context,
+	method string,
+	rawURL string,
+	requestBody []byte,
+	headers map[string]string,
+) ([]byte, int, error) {
+	var bodyReader io.Reader
+	if requestBody != nil {
+		bodyReader = bytes.NewReader(requestBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, rawURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("build request: %w", err)
+		return nil, 0, fmt.Errorf("build request: %w", err)
 	}
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
-	if err := c.waitForRequestSlot(ctx); err != nil {
-		return nil, err
+	if err := c.waitForRequestSlot(ctx, rawURL); err != nil {
+		return nil, 0, err
 	}
 
 	startedAt := time.Now()
-	c.traceRequestStart(method, rawURL, bodyBytes)
+	c.traceRequestStart(method, rawURL, len(requestBody))
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.transport().Do(req)
 	if err != nil {
 		upstreamErr := &UpstreamRequestError{
 			Method: method,
 			URL:    rawURL,
 			Cause:  err,
 		}
-		c.traceRequestDone(method, rawURL, 0, 0, startedAt, upstreamErr)
-		return nil, upstreamErr
+		c.traceRequestDone(ctx, method, rawURL, 0, 0, startedAt, upstreamErr)
+		return nil, 0, upstreamErr
 	}
 	defer func() {
 		_ = res.Body.Close()
@@ -294,40 +452,24 @@ func (c *Client) doJSONRequest(ctx context.Context, method, rawURL string, param
 			StatusCode: res.StatusCode,
 			Cause:      fmt.Errorf("read response body: %w", err),
 		}
-		c.traceRequestDone(method, rawURL, res.StatusCode, 0, startedAt, upstreamErr)
-		return nil, upstreamErr
+		c.traceRequestDone(ctx, method, rawURL, res.StatusCode, 0, startedAt, upstreamErr)
+		return nil, res.StatusCode, upstreamErr
 	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		c.observeCapability(ctx, rawURL, res.StatusCode)
 		upstreamErr := &UpstreamRequestError{
 			Method:     method,
 			URL:        rawURL,
 			StatusCode: res.StatusCode,
 			Body:       string(rawResponse),
 		}
-		c.traceRequestDone(method, rawURL, res.StatusCode, len(rawResponse), startedAt, upstreamErr)
-		return nil, upstreamErr
-	}
-	if len(rawResponse) == 0 {
-		c.traceRequestDone(method, rawURL, res.StatusCode, 0, startedAt, nil)
-		return map[string]any{}, nil
+		c.traceRequestDone(ctx, method, rawURL, res.StatusCode, len(rawResponse), startedAt, upstreamErr)
+		return nil, res.StatusCode, upstreamErr
 	}
 
-	var payload map[string]any
-	if err := json.Unmarshal(rawResponse, &payload); err != nil {
-		upstreamErr := &UpstreamRequestError{
-			Method:     method,
-			URL:        rawURL,
-			StatusCode: res.StatusCode,
-			Body:       string(rawResponse),
-			Cause:      fmt.Errorf("decode response body: %w", err),
-		}
-		c.traceRequestDone(method, rawURL, res.StatusCode, len(rawResponse), startedAt, upstreamErr)
-		return nil, upstreamErr
-	}
-
-	c.traceRequestDone(method, rawURL, res.StatusCode, len(rawResponse), startedAt, nil)
-	return payload, nil
+	c.traceRequestDone(ctx, method, rawURL, res.StatusCode, len(rawResponse), startedAt, nil)
+	return rawResponse, res.StatusCode, nil
 }
 
 func (c *Client) doRequest(
@@ -337,6 +479,12 @@ func (c *Client) doRequest(
 	body io.Reader,
 	headers map[string]string,
 ) (*http.Response, error) {
+	if err := c.checkReadOnly(method, rawURL); err != nil {
+		return nil, err
+	}
+	if err := c.checkCapability(ctx, method, rawURL); err != nil {
+		return nil, err
+	}
 	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("build request: %w", err)
@@ -344,7 +492,7 @@ func (c *Client) doRequest(
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
-	if err := c.waitForRequestSlot(ctx); err != nil {
+	if err := c.waitForRequestSlot(ctx, rawURL); err != nil {
 		return nil, err
 	}
 
@@ -355,30 +503,33 @@ func (c *Client) doRequest(
 	startedAt := time.Now()
 	c.traceRequestStart(method, rawURL, bodyBytes)
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.transport().Do(req)
 	if err != nil {
 		upstreamErr := &UpstreamRequestError{
 			Method: method,
 			URL:    rawURL,
 			Cause:  err,
 		}
-		c.traceRequestDone(method, rawURL, 0, 0, startedAt, upstreamErr)
+		c.traceRequestDone(ctx, method, rawURL, 0, 0, startedAt, upstreamErr)
 		return nil, upstreamErr
 	}
-	c.traceRequestDone(method, rawURL, res.StatusCode, 0, startedAt, nil)
+	c.observeCapability(ctx, rawURL, res.StatusCode)
+	c.traceRequestDone(ctx, method, rawURL, res.StatusCode, 0, startedAt, nil)
 	return res, nil
 }
 
 func (c *Client) traceRequestStart(method, rawURL string, bodyBytes int) {
 	if bodyBytes > 0 {
-		c.tracef("[http] -> %s %s body_bytes=%d", method, rawURL, bodyBytes)
+		c.tracef("[http] -> %s %s body_bytes=%d%s", method, rawURL, bodyBytes, c.customHeadersTrace())
 		return
 	}
-	c.tracef("[http] -> %s %s", method, rawURL)
+	c.tracef("[http] -> %s %s%s", method, rawURL, c.customHeadersTrace())
 }
 
-func (c *Client) traceRequestDone(method, rawURL string, statusCode int, responseBytes int, startedAt time.Time, reqErr error) {
-	duration := time.Since(startedAt).Round(time.Millisecond)
+func (c *Client) traceRequestDone(ctx context.Context, method, rawURL string, statusCode int, responseBytes int, startedAt time.Time, reqErr error) {
+	elapsed := time.Since(startedAt)
+	requestTimingsFromContext(ctx).observe(c.endpointFamily(rawURL), elapsed, responseBytes)
+	duration := elapsed.Round(time.Millisecond)
 	if reqErr != nil {
 		c.tracef("[http] <- %s %s error=%v duration=%s", method, rawURL, reqErr, duration)
 		return
@@ -393,28 +544,6 @@ func (c *Client) traceRequestDone(method, rawURL string, statusCode int, respons
 	)
 }
 
-func (c *Client) waitForRequestSlot(ctx context.Context) error {
-	interval := c.minRequestGap
-	if interval <= 0 {
-		return nil
-	}
-	for {
-		c.requestWindowM.Lock()
-		wait := time.Until(c.nextRequestAt)
-		if wait <= 0 {
-			c.nextRequestAt = time.Now().Add(interval)
-			c.requestWindowM.Unlock()
-			return nil
-		}
-		c.requestWindowM.Unlock()
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(wait):
-		}
-	}
-}
-
 func (c *Client) tracef(format string, args ...any) {
 	c.verboseOutputM.RLock()
 	out := c.verboseOutput
@@ -422,7 +551,7 @@ func (c *Client) tracef(format string, args ...any) {
 	if out == nil {
 		return
 	}
-	_, _ = fmt.Fprintf(out, format+"\n", args...)
+	_, _ = fmt.Fprintln(out, Redact(fmt.Sprintf(format, args...)))
 }
 
 func decodeResponsePayload(method string, rawURL string, statusCode int, rawResponse []byte) (map[string]any, error) {
@@ -518,7 +647,12 @@ func (c *Client) frontPage(ctx context.Context, location domain.Location) (map[s
 	params := url.Values{}
 	params.Set("lat", fmt.Sprintf("%f", location.Lat))
 	params.Set("lon", fmt.Sprintf("%f", location.Lon))
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.ConsumerFront, params, nil, c.headers(nil, nil))
+	payload, err := c.doJSONRequest(ctx, http.MethodGet, c.endpoints.ConsumerFront, params, nil, c.headers(nil, nil))
+	if err != nil {
+		return nil, err
+	}
+	c.checkPayloadShape(ctx, "front_page", payload)
+	return payload, nil
 }
 
 // FrontPage returns the raw discovery page payload.
@@ -540,6 +674,7 @@ func (c *Client) Sections(ctx context.Context, location domain.Location) ([]doma
 	if err != nil {
 		return nil, fmt.Errorf("decode sections: %w", err)
 	}
+	domain.FillVenueCity(sections, payloadString(payload, "city"))
 	return sections, nil
 }
 
@@ -591,6 +726,11 @@ func (c *Client) RestaurantByID(ctx context.Context, venueID string) (*domain.Re
 	return c.restaurant(ctx, venueID)
 }
 
+// Cities returns the cities Wolt operates in, with their center coordinates.
+func (c *Client) Cities(ctx context.Context) (map[string]any, error) {
+	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.Cities, nil, nil, c.headers(nil, nil))
+}
+
 // Search returns raw search endpoint payload.
 func (c *Client) Search(ctx context.Context, location domain.Location, query string) (map[string]any, error) {
 	body := map[string]any{
@@ -630,19 +770,25 @@ func (c *Client) VenuePageDynamic(ctx context.Context, slug string, options Venu
 		}
 		params.Set("selected_delivery_method", selectedDeliveryMethod)
 	}
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodGet,
 		endpoint+slug+"/dynamic/",
 		params,
 		nil,
-		c.headers(nil, &options.Auth),
+		nil,
+		options.Auth,
 	)
 }
 
 // AssortmentByVenueSlug returns full assortment payload for one venue slug.
 func (c *Client) AssortmentByVenueSlug(ctx context.Context, slug string) (map[string]any, error) {
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.Assortment+slug+"/assortment", nil, nil, c.headers(nil, nil))
+	payload, err := c.doJSONRequest(ctx, http.MethodGet, c.endpoints.Assortment+slug+"/assortment", nil, nil, c.headers(nil, nil))
+	if err != nil {
+		return nil, err
+	}
+	c.checkPayloadShape(ctx, "assortment", payload)
+	return payload, nil
 }
 
 // AssortmentCategoryByVenueSlug returns one category assortment payload by category slug.
@@ -660,7 +806,7 @@ func (c *Client) AssortmentCategoryByVenueSlug(
 		params.Set("language", lang)
 	}
 	endpoint := c.endpoints.Assortment + slug + "/assortment/categories/slug/" + url.PathEscape(strings.TrimSpace(categorySlug))
-	return c.doJSONRequest(ctx, http.MethodGet, endpoint, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, endpoint, params, nil, nil, auth)
 }
 
 // AssortmentItemsByVenueSlug returns detailed item payload for selected assortment item ids.
@@ -682,13 +828,14 @@ func (c *Client) AssortmentItemsByVenueSlug(
 		"item_ids": ids,
 	}
 	endpoint := c.endpoints.Assortment + slug + "/assortment/items"
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodPost,
 		endpoint,
 		nil,
 		body,
-		c.headers(map[string]string{"Content-Type": "application/json"}, &auth),
+		map[string]string{"Content-Type": "application/json"},
+		auth,
 	)
 }
 
@@ -710,13 +857,14 @@ func (c *Client) AssortmentItemsSearchByVenueSlug(
 		"q": strings.TrimSpace(query),
 	}
 	endpoint := c.endpoints.Assortment + slug + "/assortment/items/search"
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodPost,
 		endpoint,
 		params,
 		body,
-		c.headers(map[string]string{"Content-Type": "application/json"}, &auth),
+		map[string]string{"Content-Type": "application/json"},
+		auth,
 	)
 }
 
@@ -726,7 +874,7 @@ func (c *Client) VenueContentByVenueSlug(ctx context.Context, slug string, nextP
 	if token := strings.TrimSpace(nextPageToken); token != "" {
 		params.Set("next_page_token", token)
 	}
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.VenueContent+slug, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.VenueContent+slug, params, nil, nil, auth)
 }
 
 // VenueItemPage returns single item payload from a venue.
@@ -751,12 +899,12 @@ func (c *Client) ItemBySlug(ctx context.Context, location domain.Location, slug
 
 // UserMe returns authenticated user details.
 func (c *Client) UserMe(ctx context.Context, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.UserMe, nil, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.UserMe, nil, nil, nil, auth)
 }
 
 // PaymentMethods returns payment methods available for the authenticated user.
 func (c *Client) PaymentMethods(ctx context.Context, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.PaymentMethods, nil, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.PaymentMethods, nil, nil, nil, auth)
 }
 
 // PaymentMethodsProfile returns checkout payment options shown in web profile.
@@ -776,7 +924,31 @@ func (c *Client) PaymentMethodsProfile(ctx context.Context, auth AuthContext, op
 	} else {
 		params.Set("is_ftu", "false")
 	}
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.PaymentProfile, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.PaymentProfile, params, nil, nil, auth)
+}
+
+// PaymentBalances returns stored-value balances (gift cards, Wolt credits, and
+// linked benefit providers) for the authenticated account.
+func (c *Client) PaymentBalances(ctx context.Context, auth AuthContext, country string) (map[string]any, error) {
+	params := url.Values{}
+	if country = strings.ToUpper(strings.TrimSpace(country)); country != "" {
+		params.Set("country", country)
+	}
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.PaymentBalances, params, nil, nil, auth)
+}
+
+// CardSetupSession starts a tokenized card setup with the payments provider.
+// The response carries the browser URL where the card is entered and 3-D
+// Secure is completed; the card is saved to the account once that finishes.
+func (c *Client) CardSetupSession(ctx context.Context, auth AuthContext, options CardSetupOptions) (map[string]any, error) {
+	body := map[string]any{}
+	if country := strings.ToUpper(strings.TrimSpace(options.Country)); country != "" {
+		body["country"] = country
+	}
+	if returnURL := strings.TrimSpace(options.ReturnURL); returnURL != "" {
+		body["return_url"] = returnURL
+	}
+	return c.doAuthJSONRequest(ctx, http.MethodPost, c.endpoints.CardSetup, nil, body, nil, auth)
 }
 
 // AddressFields returns address form field metadata for a location.
@@ -789,30 +961,31 @@ func (c *Client) AddressFields(ctx context.Context, location domain.Location, la
 		lang = c.locale
 	}
 	params.Set("language", lang)
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.AddressFields, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.AddressFields, params, nil, nil, auth)
 }
 
 // DeliveryInfoList returns saved delivery addresses from Wolt account.
 func (c *Client) DeliveryInfoList(ctx context.Context, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.DeliveryInfo, nil, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.DeliveryInfo, nil, nil, nil, auth)
 }
 
 // DeliveryInfoCreate creates a new saved delivery address in Wolt account.
 func (c *Client) DeliveryInfoCreate(ctx context.Context, payload map[string]any, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodPost,
 		c.endpoints.DeliveryInfo,
 		nil,
 		payload,
-		c.headers(map[string]string{"Content-Type": "application/json"}, &auth),
+		map[string]string{"Content-Type": "application/json"},
+		auth,
 	)
 }
 
 // DeliveryInfoDelete removes a saved delivery address by id.
 func (c *Client) DeliveryInfoDelete(ctx context.Context, addressID string, auth AuthContext) (map[string]any, error) {
 	endpoint := strings.TrimRight(c.endpoints.DeliveryInfo, "/") + "/" + strings.TrimSpace(addressID)
-	return c.doJSONRequest(ctx, http.MethodDelete, endpoint, nil, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodDelete, endpoint, nil, nil, nil, auth)
 }
 
 // OrderHistory returns paginated account order history.
@@ -827,7 +1000,7 @@ func (c *Client) OrderHistory(ctx context.Context, auth AuthContext, options Ord
 		params.Set("page_token", pageToken)
 	}
 	endpoint := strings.TrimRight(c.endpoints.OrderHistory, "/") + "/"
-	return c.doJSONRequest(ctx, http.MethodGet, endpoint, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, endpoint, params, nil, nil, auth)
 }
 
 // OrderHistoryPurchase returns detailed payload for one purchase id.
@@ -839,7 +1012,7 @@ func (c *Client) OrderHistoryPurchase(ctx context.Context, purchaseID string, au
 	params := url.Values{}
 	params.Set("tips_use_percentage", "true")
 	endpoint := strings.TrimRight(c.endpoints.OrderHistory, "/") + "/purchase/" + url.PathEscape(trimmedID)
-	return c.doJSONRequest(ctx, http.MethodGet, endpoint, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, endpoint, params, nil, nil, auth)
 }
 
 // FavoriteVenues returns account favourite venues list page payload.
@@ -847,7 +1020,7 @@ func (c *Client) FavoriteVenues(ctx context.Context, location domain.Location, a
 	params := url.Values{}
 	params.Set("lat", fmt.Sprintf("%f", location.Lat))
 	params.Set("lon", fmt.Sprintf("%f", location.Lon))
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.FavoritesPage, params, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.FavoritesPage, params, nil, nil, auth)
 }
 
 // FavoriteVenueAdd marks one venue as favourite for the authenticated account.
@@ -857,7 +1030,7 @@ func (c *Client) FavoriteVenueAdd(ctx context.Context, venueID string, auth Auth
 		return nil, fmt.Errorf("venue id is required")
 	}
 	endpoint := strings.TrimRight(c.endpoints.FavoriteVenue, "/") + "/" + trimmedID
-	return c.doJSONRequest(ctx, http.MethodPut, endpoint, nil, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodPut, endpoint, nil, nil, nil, auth)
 }
 
 // FavoriteVenueRemove removes one venue from favourites for the authenticated account.
@@ -867,12 +1040,17 @@ func (c *Client) FavoriteVenueRemove(ctx context.Context, venueID string, auth A
 		return nil, fmt.Errorf("venue id is required")
 	}
 	endpoint := strings.TrimRight(c.endpoints.FavoriteVenue, "/") + "/" + trimmedID
-	return c.doJSONRequest(ctx, http.MethodDelete, endpoint, nil, nil, c.headers(nil, &auth))
+	return c.doAuthJSONRequest(ctx, http.MethodDelete, endpoint, nil, nil, nil, auth)
 }
 
 // BasketCount returns total basket count.
 func (c *Client) BasketCount(ctx context.Context, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.BasketCount, nil, nil, c.headers(nil, &auth))
+	payload, err := c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.BasketCount, nil, nil, nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	c.checkPayloadShape(ctx, "basket_count", payload)
+	return payload, nil
 }
 
 // BasketsPage returns full basket page payload and totals.
@@ -880,18 +1058,24 @@ func (c *Client) BasketsPage(ctx context.Context, location domain.Location, auth
 	params := url.Values{}
 	params.Set("lat", fmt.Sprintf("%f", location.Lat))
 	params.Set("lon", fmt.Sprintf("%f", location.Lon))
-	return c.doJSONRequest(ctx, http.MethodGet, c.endpoints.BasketsPage, params, nil, c.headers(nil, &auth))
+	payload, err := c.doAuthJSONRequest(ctx, http.MethodGet, c.endpoints.BasketsPage, params, nil, nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	c.checkPayloadShape(ctx, "baskets_page", payload)
+	return payload, nil
 }
 
 // AddToBasket adds a menu item payload to basket.
 func (c *Client) AddToBasket(ctx context.Context, payload map[string]any, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodPost,
 		c.endpoints.Basket,
 		nil,
 		payload,
-		c.headers(map[string]string{"Content-Type": "application/json"}, &auth),
+		map[string]string{"Content-Type": "application/json"},
+		auth,
 	)
 }
 
@@ -905,25 +1089,27 @@ func (c *Client) DeleteBaskets(ctx context.Context, basketIDs []string, auth Aut
 		}
 		ids = append(ids, trimmed)
 	}
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodPost,
 		c.endpoints.BasketBulkDelete,
 		nil,
 		map[string]any{"ids": ids},
-		c.headers(map[string]string{"Content-Type": "application/json"}, &auth),
+		map[string]string{"Content-Type": "application/json"},
+		auth,
 	)
 }
 
 // CheckoutPreview returns checkout projection payload.
 func (c *Client) CheckoutPreview(ctx context.Context, payload map[string]any, auth AuthContext) (map[string]any, error) {
-	return c.doJSONRequest(
+	return c.doAuthJSONRequest(
 		ctx,
 		http.MethodPost,
 		c.endpoints.Checkout,
 		nil,
 		payload,
-		c.headers(map[string]string{"Content-Type": "application/json"}, &auth),
+		map[string]string{"Content-Type": "application/json"},
+		auth,
 	)
 }
 
@@ -933,6 +1119,8 @@ func (c *Client) RefreshAccessToken(ctx context.Context, refreshToken string, au
 	if refreshToken == "" {
 		return TokenRefreshResult{}, fmt.Errorf("refresh token is required")
 	}
+	RememberAuthSecrets(auth)
+	RememberSecrets(refreshToken)
 
 	form := url.Values{}
 	form.Set("grant_type", "refresh_token")
@@ -986,6 +1174,7 @@ func (c *Client) RefreshAccessToken(ctx context.Context, refreshToken string, au
 	if strings.TrimSpace(resolvedRefreshToken) == "" {
 		resolvedRefreshToken = refreshToken
 	}
+	RememberSecrets(accessToken, resolvedRefreshToken)
 	expiresIn := payloadInt(payload, "expires_in", "expiresIn")
 	if data, ok := payload["data"].(map[string]any); ok && expiresIn <= 0 {
 		expiresIn = payloadInt(data, "expires_in", "expiresIn")
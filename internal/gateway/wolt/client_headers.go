@@ -0,0 +1,80 @@
+package wolt
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ParseClientHeaders parses WOLT_CLIENT_HEADERS: comma-separated name=value
+// pairs such as "platform=Android,client-version=6.1.0". Credentials are
+// refused because they come from the profile.
+func ParseClientHeaders(raw string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t:") {
+			return nil, fmt.Errorf("client header %q must look like name=value", strings.TrimSpace(pair))
+		}
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Cookie":
+			return nil, fmt.Errorf("client header %s is not allowed; credentials come from the profile", name)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// WithClientHeaders adds headers to every request, overriding the default
+// platform and client version headers with the same name.
+func WithClientHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.clientHeaders = headers
+	}
+}
+
+// SetUserAgent sets the User-Agent header for later requests; an empty value
+// keeps the configured or default one.
+func (c *Client) SetUserAgent(userAgent string) {
+	c.clientHeadersM.Lock()
+	c.userAgent = strings.TrimSpace(userAgent)
+	c.clientHeadersM.Unlock()
+}
+
+// customHeaders returns the configured client headers and user agent.
+func (c *Client) customHeaders() map[string]string {
+	c.clientHeadersM.RLock()
+	defer c.clientHeadersM.RUnlock()
+	headers := make(map[string]string, len(c.clientHeaders)+1)
+	for name, value := range c.clientHeaders {
+		headers[name] = value
+	}
+	if c.userAgent != "" {
+		for name := range headers {
+			if strings.EqualFold(name, "User-Agent") {
+				delete(headers, name)
+			}
+		}
+		headers["User-Agent"] = c.userAgent
+	}
+	return headers
+}
+
+// customHeadersTrace lists the custom headers for the request trace.
+func (c *Client) customHeadersTrace() string {
+	headers := c.customHeaders()
+	if len(headers) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(headers))
+	for name, value := range headers {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return " headers=" + strings.Join(pairs, ",")
+}
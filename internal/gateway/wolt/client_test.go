@@ -168,6 +168,45 @@ func TestVerboseTraceLogsRequestAndResponse(t *testing.T) {
 	}
 }
 
+func TestClientHeadersOverrideDefaultsAndAppearInTrace(t *testing.T) {
+	headers, err := ParseClientHeaders("Platform=Android, client-version=6.1.0,User-Agent=from-env")
+	if err != nil {
+		t.Fatalf("parse client headers: %v", err)
+	}
+	httpClient := &captureHTTPClient{responseBody: `{"categories":[]}`}
+	trace := &bytes.Buffer{}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithVerboseOutput(trace),
+		WithClientHeaders(headers),
+		WithEndpoints(Endpoints{
+			Assortment: "https://example.test/consumer-assortment/v1/venues/slug/",
+		}),
+	)
+	client.SetUserAgent("wolt-bot/2.0")
+
+	if _, err := client.AssortmentByVenueSlug(context.Background(), "market"); err != nil {
+		t.Fatalf("assortment call returned error: %v", err)
+	}
+	request := httpClient.request
+	if request.Header.Get("platform") != "Android" || request.Header.Get("client-version") != "6.1.0" || request.Header.Get("User-Agent") != "wolt-bot/2.0" {
+		t.Fatalf("expected custom headers on the request, got %v", request.Header)
+	}
+	if request.Header.Get("clientversionnumber") != defaultClientVersionHeader {
+		t.Fatalf("expected untouched defaults to stay, got %v", request.Header)
+	}
+	if !strings.Contains(trace.String(), "headers=Platform=Android,User-Agent=wolt-bot/2.0,client-version=6.1.0") {
+		t.Fatalf("expected custom headers in the trace, got:\n%s", trace.String())
+	}
+
+	if _, err := ParseClientHeaders("authorization=Bearer x"); err == nil {
+		t.Fatalf("expected credentials to be refused")
+	}
+	if _, err := ParseClientHeaders("platform"); err == nil {
+		t.Fatalf("expected a pair without = to be refused")
+	}
+}
+
 func TestAssortmentItemsSearchByVenueSlugUsesSearchEndpoint(t *testing.T) {
 	httpClient := &captureHTTPClient{}
 	client := NewClient(
@@ -262,6 +301,50 @@ func TestRequestMinIntervalHonorsContextDeadline(t *testing.T) {
 	}
 }
 
+func TestFamilyMinIntervalsPaceFamiliesOnTheirOwn(t *testing.T) {
+	intervals, err := ParseFamilyMinIntervals("payment_methods=0, example.test=3600000")
+	if err != nil {
+		t.Fatalf("parse family min intervals: %v", err)
+	}
+	httpClient := &captureHTTPClient{}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithRequestMinInterval(time.Hour),
+		WithFamilyMinIntervals(intervals),
+		WithEndpoints(Endpoints{
+			PaymentMethods: "https://example.test/v3/user/me/payment_methods",
+			UserMe:         "https://example.test/v1/user/me",
+		}),
+	)
+
+	for range 3 {
+		if _, err := client.PaymentMethods(context.Background(), AuthContext{WToken: "jwt-token"}); err != nil {
+			t.Fatalf("payment methods returned error: %v", err)
+		}
+	}
+	if _, err := client.UserMe(context.Background(), AuthContext{WToken: "jwt-token"}); err != nil {
+		t.Fatalf("user me returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := client.UserMe(ctx, AuthContext{WToken: "jwt-token"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the host interval to hold back the second user call, got %v", err)
+	}
+	if httpClient.doCalls != 4 {
+		t.Fatalf("expected an unpaced family and one paced host call, got %d calls", httpClient.doCalls)
+	}
+	if got := client.RequestMinIntervals()["payment_methods"]; got != 0 {
+		t.Fatalf("expected the reported family interval, got %s", got)
+	}
+
+	if _, err := ParseFamilyMinIntervals("assortment"); err == nil {
+		t.Fatalf("expected a pair without = to be refused")
+	}
+	if _, err := ParseFamilyMinIntervals("assortment=-5"); err == nil {
+		t.Fatalf("expected a negative interval to be refused")
+	}
+}
+
 func TestPaymentMethodsProfileSetsQueryAndHeaders(t *testing.T) {
 	httpClient := &captureHTTPClient{}
 	client := NewClient(
@@ -340,6 +423,33 @@ func TestDeleteBasketsPostsBulkDeletePayload(t *testing.T) {
 	}
 }
 
+func TestCardSetupSessionPostsCountry(t *testing.T) {
+	httpClient := &captureHTTPClient{}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{
+			CardSetup: "https://example.test/v1/payment-methods/card/setup-session",
+		}),
+	)
+
+	_, err := client.CardSetupSession(context.Background(), AuthContext{WToken: "jwt-token"}, CardSetupOptions{Country: "fin"})
+	if err != nil {
+		t.Fatalf("card setup session returned error: %v", err)
+	}
+	if httpClient.request == nil {
+		t.Fatal("expected request to be captured")
+	}
+	if got := httpClient.request.Method; got != http.MethodPost {
+		t.Fatalf("expected POST request, got %s", got)
+	}
+	if got := httpClient.request.Header.Get("Authorization"); got != "Bearer jwt-token" {
+		t.Fatalf("expected authorization bearer token, got %q", got)
+	}
+	if httpClient.requestBody != `{"country":"FIN"}` {
+		t.Fatalf("expected the country in the body, got %s", httpClient.requestBody)
+	}
+}
+
 func TestFavoriteVenuesUsesLocationQuery(t *testing.T) {
 	httpClient := &captureHTTPClient{}
 	client := NewClient(
@@ -468,6 +578,24 @@ func TestOrderHistoryPurchaseUsesExpectedURL(t *testing.T) {
 	}
 }
 
+func TestCitiesUsesExpectedURL(t *testing.T) {
+	httpClient := &captureHTTPClient{}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{Cities: "https://example.test/v1/cities"}),
+	)
+
+	if _, err := client.Cities(context.Background()); err != nil {
+		t.Fatalf("cities returned error: %v", err)
+	}
+	if got := httpClient.request.Method; got != http.MethodGet {
+		t.Fatalf("expected GET request, got %s", got)
+	}
+	if got := httpClient.request.URL.String(); got != "https://example.test/v1/cities" {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+}
+
 func TestAssortmentByVenueSlugUsesExpectedURL(t *testing.T) {
 	httpClient := &captureHTTPClient{}
 	client := NewClient(
@@ -600,3 +728,23 @@ func TestVenueContentByVenueSlugUsesExpectedURL(t *testing.T) {
 		t.Fatalf("unexpected URL: %s", got)
 	}
 }
+
+func TestResolveShareLinkScansLandingPage(t *testing.T) {
+	httpClient := &captureHTTPClient{
+		responseBody: `<html><script>location.href="https://wolt.com/en/fin/espoo/restaurant/rioni-espoo?share=1"</script></html>`,
+	}
+	client := NewClient(WithHTTPClient(httpClient))
+
+	resolved, err := client.ResolveShareLink(context.Background(), "https://wolt.app.link/AbCd123")
+	if err != nil {
+		t.Fatalf("resolve share link returned error: %v", err)
+	}
+	if resolved != "https://wolt.com/en/fin/espoo/restaurant/rioni-espoo" {
+		t.Fatalf("unexpected resolved url %q", resolved)
+	}
+
+	httpClient.statusCode = http.StatusNotFound
+	if _, err := client.ResolveShareLink(context.Background(), "https://wolt.app.link/missing"); err == nil {
+		t.Fatal("expected error for a dead share link")
+	}
+}
@@ -16,6 +16,7 @@ type UpstreamRequestError struct {
 	Cause      error
 }
 
+// Error describes the failed request with credentials redacted.
 func (e *UpstreamRequestError) Error() string {
 	parts := []string{ErrUpstream.Error()}
 	if e.StatusCode > 0 {
@@ -32,11 +33,14 @@ func (e *UpstreamRequestError) Error() string {
 	if e.Cause != nil {
 		parts = append(parts, fmt.Sprintf("cause=%v", e.Cause))
 	}
-	return strings.Join(parts, "; ")
+	return Redact(strings.Join(parts, "; "))
 }
 
-func (e *UpstreamRequestError) Unwrap() error {
-	return ErrUpstream
+func (e *UpstreamRequestError) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{ErrUpstream}
+	}
+	return []error{ErrUpstream, e.Cause}
 }
 
 func compactBodyPreview(body string) string {
@@ -2,6 +2,7 @@ package wolt
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
@@ -28,6 +29,7 @@ type API interface {
 	Sections(ctx context.Context, location domain.Location) ([]domain.Section, error)
 	Items(ctx context.Context, location domain.Location) ([]domain.Item, error)
 	RestaurantByID(ctx context.Context, venueID string) (*domain.Restaurant, error)
+	Cities(ctx context.Context) (map[string]any, error)
 	Search(ctx context.Context, location domain.Location, query string) (map[string]any, error)
 	VenuePageStatic(ctx context.Context, slug string) (map[string]any, error)
 	VenuePageDynamic(ctx context.Context, slug string, options VenuePageDynamicOptions) (map[string]any, error)
@@ -41,6 +43,8 @@ type API interface {
 	UserMe(ctx context.Context, auth AuthContext) (map[string]any, error)
 	PaymentMethods(ctx context.Context, auth AuthContext) (map[string]any, error)
 	PaymentMethodsProfile(ctx context.Context, auth AuthContext, options PaymentMethodsProfileOptions) (map[string]any, error)
+	PaymentBalances(ctx context.Context, auth AuthContext, country string) (map[string]any, error)
+	CardSetupSession(ctx context.Context, auth AuthContext, options CardSetupOptions) (map[string]any, error)
 	AddressFields(ctx context.Context, location domain.Location, language string, auth AuthContext) (map[string]any, error)
 	DeliveryInfoList(ctx context.Context, auth AuthContext) (map[string]any, error)
 	DeliveryInfoCreate(ctx context.Context, payload map[string]any, auth AuthContext) (map[string]any, error)
@@ -56,6 +60,7 @@ type API interface {
 	DeleteBaskets(ctx context.Context, basketIDs []string, auth AuthContext) (map[string]any, error)
 	CheckoutPreview(ctx context.Context, payload map[string]any, auth AuthContext) (map[string]any, error)
 	RefreshAccessToken(ctx context.Context, refreshToken string, auth AuthContext) (TokenRefreshResult, error)
+	Raw(ctx context.Context, method string, target string, body []byte, auth AuthContext) (json.RawMessage, error)
 }
 
 // VenuePageDynamicOptions controls optional request context for dynamic venue page calls.
@@ -72,6 +77,12 @@ type PaymentMethodsProfileOptions struct {
 	IsFTU            bool
 }
 
+// CardSetupOptions controls the card setup session request.
+type CardSetupOptions struct {
+	Country   string
+	ReturnURL string
+}
+
 // TokenRefreshResult stores rotated access/refresh credentials.
 type TokenRefreshResult struct {
 	AccessToken  string
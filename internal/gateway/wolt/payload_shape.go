@@ -0,0 +1,194 @@
+package wolt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type payloadAnomaliesKey struct{}
+
+// PayloadAnomaly lists what a response of one endpoint family lacked or
+// carried in an unexpected type, compared with the shape the CLI reads.
+// Paths use "[]" for array elements, as in "sections[].items".
+type PayloadAnomaly struct {
+	Family     string
+	Missing    []string
+	Unexpected []string
+}
+
+// PayloadAnomalies collects shape mismatches of checked responses for one
+// command run.
+type PayloadAnomalies struct {
+	mu        sync.Mutex
+	anomalies []PayloadAnomaly
+}
+
+// WithPayloadAnomalies makes checked responses fetched with the returned
+// context report shape mismatches into anomalies.
+func WithPayloadAnomalies(ctx context.Context, anomalies *PayloadAnomalies) context.Context {
+	return context.WithValue(ctx, payloadAnomaliesKey{}, anomalies)
+}
+
+// PayloadAnomaliesFromContext returns the collector attached with
+// WithPayloadAnomalies, or nil.
+func PayloadAnomaliesFromContext(ctx context.Context) *PayloadAnomalies {
+	if ctx == nil {
+		return nil
+	}
+	anomalies, _ := ctx.Value(payloadAnomaliesKey{}).(*PayloadAnomalies)
+	return anomalies
+}
+
+// List returns one anomaly per family in first-seen order, with the paths of
+// repeated responses merged.
+func (a *PayloadAnomalies) List() []PayloadAnomaly {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]PayloadAnomaly, 0, len(a.anomalies))
+	for _, anomaly := range a.anomalies {
+		out = append(out, PayloadAnomaly{
+			Family:     anomaly.Family,
+			Missing:    slices.Clone(anomaly.Missing),
+			Unexpected: slices.Clone(anomaly.Unexpected),
+		})
+	}
+	return out
+}
+
+func (a *PayloadAnomalies) add(anomaly PayloadAnomaly) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for idx := range a.anomalies {
+		if a.anomalies[idx].Family != anomaly.Family {
+			continue
+		}
+		a.anomalies[idx].Missing = mergePaths(a.anomalies[idx].Missing, anomaly.Missing)
+		a.anomalies[idx].Unexpected = mergePaths(a.anomalies[idx].Unexpected, anomaly.Unexpected)
+		return
+	}
+	a.anomalies = append(a.anomalies, anomaly)
+}
+
+func mergePaths(known []string, added []string) []string {
+	for _, path := range added {
+		if !slices.Contains(known, path) {
+			known = append(known, path)
+		}
+	}
+	return known
+}
+
+// payloadField is one key the CLI reads from a response. Optional fields are
+// only checked for their type when present.
+type payloadField struct {
+	path     string
+	kind     string
+	required bool
+}
+
+// payloadShapes are the fields of critical responses whose absence turns
+// into empty output downstream rather than an error.
+var payloadShapes = map[string][]payloadField{
+	"front_page": {
+		{path: "sections", kind: "array", required: true},
+		{path: "sections[].items", kind: "array"},
+		{path: "sections[].items[].venue", kind: "object"},
+		{path: "city", kind: "string"},
+	},
+	"assortment": {
+		{path: "items", kind: "array", required: true},
+		{path: "categories", kind: "array", required: true},
+		{path: "items[].price", kind: "number"},
+	},
+	"baskets_page": {
+		{path: "baskets", kind: "array", required: true},
+		{path: "baskets[].items", kind: "array", required: true},
+		{path: "baskets[].venue", kind: "object"},
+	},
+	"basket_count": {
+		{path: "count", kind: "number", required: true},
+	},
+}
+
+// checkPayloadShape compares payload with the known shape of family and
+// reports mismatches to the run's collector and the verbose trace.
+func (c *Client) checkPayloadShape(ctx context.Context, family string, payload map[string]any) {
+	fields, ok := payloadShapes[family]
+	if !ok {
+		return
+	}
+	anomaly := PayloadAnomaly{Family: family}
+	for _, field := range fields {
+		missing, unexpected := checkPayloadField(payload, strings.Split(field.path, "."), field)
+		if missing {
+			anomaly.Missing = append(anomaly.Missing, field.path)
+		}
+		if unexpected != "" {
+			anomaly.Unexpected = append(anomaly.Unexpected, fmt.Sprintf("%s (%s, expected %s)", field.path, unexpected, field.kind))
+		}
+	}
+	if len(anomaly.Missing) == 0 && len(anomaly.Unexpected) == 0 {
+		return
+	}
+	c.tracef("[http] payload anomaly family=%s missing=%v unexpected=%v", family, anomaly.Missing, anomaly.Unexpected)
+	PayloadAnomaliesFromContext(ctx).add(anomaly)
+}
+
+// checkPayloadField walks segments through node. Array segments ("items[]")
+// check every element; a field counts as missing when any element lacks it.
+// unexpected names the first JSON type found instead of field.kind.
+func checkPayloadField(node map[string]any, segments []string, field payloadField) (missing bool, unexpected string) {
+	key, isArray := strings.CutSuffix(segments[0], "[]")
+	value, present := node[key]
+	if !present || value == nil {
+		// Missing parents are reported by their own field.
+		return field.required && len(segments) == 1, ""
+	}
+	if !isArray {
+		if kind := jsonKind(value); kind != field.kind {
+			return false, kind
+		}
+		return false, ""
+	}
+	elements, ok := value.([]any)
+	if !ok {
+		return false, ""
+	}
+	for _, element := range elements {
+		child, ok := element.(map[string]any)
+		if !ok {
+			continue
+		}
+		elementMissing, elementUnexpected := checkPayloadField(child, segments[1:], field)
+		missing = missing || elementMissing
+		if unexpected == "" {
+			unexpected = elementUnexpected
+		}
+	}
+	return missing, unexpected
+}
+
+func jsonKind(value any) string {
+	switch value.(type) {
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case string:
+		return "string"
+	case float64, int, int64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,66 @@
+package wolt
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func TestCheckedResponsesReportPayloadAnomalies(t *testing.T) {
+	httpClient := &captureHTTPClient{}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{
+			ConsumerFront: "https://example.test/v1/pages/front",
+			BasketsPage:   "https://example.test/baskets/page",
+			BasketCount:   "https://example.test/baskets/count",
+		}),
+	)
+	anomalies := &PayloadAnomalies{}
+	ctx := WithPayloadAnomalies(context.Background(), anomalies)
+
+	httpClient.responseBody = `{"sections":{"popular":[]},"city":7}`
+	if _, err := client.FrontPage(ctx, domain.Location{Lat: 1, Lon: 2}); err != nil {
+		t.Fatalf("front page returned error: %v", err)
+	}
+	httpClient.responseBody = `{"baskets":[{"venue":{"id":"v1"}},{"items":[],"venue":"v2"}]}`
+	if _, err := client.BasketsPage(ctx, domain.Location{Lat: 1, Lon: 2}, AuthContext{WToken: "token"}); err != nil {
+		t.Fatalf("baskets page returned error: %v", err)
+	}
+	httpClient.responseBody = `{"basket_count":1}`
+	if _, err := client.BasketCount(ctx, AuthContext{WToken: "token"}); err != nil {
+		t.Fatalf("basket count returned error: %v", err)
+	}
+	httpClient.responseBody = `{"count":2}`
+	if _, err := client.BasketCount(ctx, AuthContext{WToken: "token"}); err != nil {
+		t.Fatalf("basket count returned error: %v", err)
+	}
+
+	want := []PayloadAnomaly{
+		{Family: "front_page", Unexpected: []string{"sections (object, expected array)", "city (number, expected string)"}},
+		{Family: "baskets_page", Missing: []string{"baskets[].items"}, Unexpected: []string{"baskets[].venue (string, expected object)"}},
+		{Family: "basket_count", Missing: []string{"count"}},
+	}
+	if got := anomalies.List(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected anomalies:\n got %+v\nwant %+v", got, want)
+	}
+
+	// Without a collector the check stays silent.
+	httpClient.responseBody = `{}`
+	if _, err := client.FrontPage(context.Background(), domain.Location{Lat: 1, Lon: 2}); err != nil {
+		t.Fatalf("front page returned error: %v", err)
+	}
+}
+
+func TestPayloadAnomaliesMergeRepeatedFamilies(t *testing.T) {
+	anomalies := &PayloadAnomalies{}
+	anomalies.add(PayloadAnomaly{Family: "assortment", Missing: []string{"items"}})
+	anomalies.add(PayloadAnomaly{Family: "assortment", Missing: []string{"items", "categories"}})
+
+	want := []PayloadAnomaly{{Family: "assortment", Missing: []string{"items", "categories"}}}
+	if got := anomalies.List(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected merged paths, got %+v", got)
+	}
+}
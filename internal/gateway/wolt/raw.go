@@ -0,0 +1,60 @@
+package wolt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrRawTarget reports a raw request target outside the Wolt API hosts.
+var ErrRawTarget = errors.New("raw requests are limited to https://*.wolt.com")
+
+// Raw sends an arbitrary request through the client's auth headers and rate
+// limiting and returns the upstream JSON body untouched. target is either a path
+// resolved against Endpoints.RawBase or an absolute https URL on a wolt.com host.
+func (c *Client) Raw(ctx context.Context, method string, target string, body []byte, auth AuthContext) (json.RawMessage, error) {
+	rawURL, err := c.resolveRawURL(target)
+	if err != nil {
+		return nil, err
+	}
+	var extra map[string]string
+	if body != nil {
+		extra = map[string]string{"Content-Type": "application/json"}
+	}
+	var payload json.RawMessage
+	err = c.doAuthorized(ctx, auth, func(auth AuthContext) error {
+		return c.doPayloadRequest(ctx, strings.ToUpper(method), rawURL, body, c.headers(extra, &auth), func(raw []byte) error {
+			if !json.Valid(raw) {
+				return errors.New("response is not JSON")
+			}
+			payload = append(json.RawMessage(nil), raw...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (c *Client) resolveRawURL(target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", fmt.Errorf("%w: empty target", ErrRawTarget)
+	}
+	if !strings.Contains(target, "://") {
+		return strings.TrimRight(c.endpoints.RawBase, "/") + "/" + strings.TrimLeft(target, "/"), nil
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRawTarget, err)
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if parsed.Scheme != "https" || (host != "wolt.com" && !strings.HasSuffix(host, ".wolt.com")) {
+		return "", fmt.Errorf("%w: got %s", ErrRawTarget, target)
+	}
+	return parsed.String(), nil
+}
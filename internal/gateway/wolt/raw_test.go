@@ -0,0 +1,47 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRawReturnsBodyVerbatimWithAuthHeaders(t *testing.T) {
+	httpClient := &captureHTTPClient{responseBody: `{"b":1,  "a":[2.50]}`}
+	client := NewClient(WithHTTPClient(httpClient))
+
+	payload, err := client.Raw(context.Background(), "post", "order-xp/v1/baskets/count", []byte(`{"x":1}`), AuthContext{WToken: "token-1"})
+	if err != nil {
+		t.Fatalf("raw returned error: %v", err)
+	}
+	if string(payload) != `{"b":1,  "a":[2.50]}` {
+		t.Fatalf("expected untouched body, got %s", payload)
+	}
+	if got := httpClient.request.URL.String(); got != "https://consumer-api.wolt.com/order-xp/v1/baskets/count" {
+		t.Fatalf("unexpected url %s", got)
+	}
+	if httpClient.request.Method != "POST" || httpClient.requestBody != `{"x":1}` {
+		t.Fatalf("unexpected request %s %s", httpClient.request.Method, httpClient.requestBody)
+	}
+	if httpClient.request.Header.Get("Authorization") != "Bearer token-1" || httpClient.request.Header.Get("platform") != defaultPlatformHeader {
+		t.Fatalf("expected shared auth and platform headers, got %v", httpClient.request.Header)
+	}
+}
+
+func TestRawRejectsNonWoltHosts(t *testing.T) {
+	httpClient := &captureHTTPClient{}
+	client := NewClient(WithHTTPClient(httpClient))
+
+	for _, target := range []string{"https://example.com/v1", "http://consumer-api.wolt.com/v1", "https://wolt.com.example.net/v1"} {
+		if _, err := client.Raw(context.Background(), "GET", target, nil, AuthContext{WToken: "token-1"}); !errors.Is(err, ErrRawTarget) {
+			t.Fatalf("%s: expected ErrRawTarget, got %v", target, err)
+		}
+	}
+	if httpClient.doCalls != 0 {
+		t.Fatalf("expected no upstream calls, got %d", httpClient.doCalls)
+	}
+
+	if _, err := client.Raw(context.Background(), "GET", "https://restaurant-api.wolt.com/v1/user/me", nil, AuthContext{}); err != nil {
+		t.Fatalf("expected wolt.com host to be accepted: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package wolt
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly marks a request that was not sent because read-only mode forbids
+// changes to the account.
+var ErrReadOnly = errors.New("read-only mode forbids this request")
+
+// readOnlyPostFamilies are endpoint families that read through POST: search
+// queries, assortment item lookups, checkout pricing, and token refresh.
+var readOnlyPostFamilies = map[string]bool{
+	"search":       true,
+	"assortment":   true,
+	"checkout":     true,
+	"access_token": true,
+}
+
+// SetReadOnly refuses requests that would change the account (baskets,
+// addresses, favorites, payment methods) with ErrReadOnly when enabled.
+func (c *Client) SetReadOnly(enabled bool) {
+	c.readOnly.Store(enabled)
+}
+
+// checkReadOnly refuses mutating requests in read-only mode. Anything other
+// than GET, HEAD, and OPTIONS mutates unless its family is a known POST read.
+func (c *Client) checkReadOnly(method string, rawURL string) error {
+	if !c.readOnly.Load() {
+		return nil
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	if family, ok := c.configuredFamily(rawURL); ok && readOnlyPostFamilies[family] {
+		return nil
+	}
+	c.tracef("[http] skip %s %s read_only", method, rawURL)
+	return fmt.Errorf("%w: %s %s", ErrReadOnly, method, rawURL)
+}
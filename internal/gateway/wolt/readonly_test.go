@@ -0,0 +1,48 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestReadOnlyRefusesMutationsButAllowsPostReads(t *testing.T) {
+	httpClient := &captureHTTPClient{statusCode: http.StatusOK, responseBody: `{}`}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{
+			Basket:        "https://example.test/baskets",
+			FavoriteVenue: "https://example.test/favorites/",
+			Checkout:      "https://example.test/checkout",
+			BasketCount:   "https://example.test/baskets/count",
+			RawBase:       "https://example.test",
+		}),
+	)
+	client.SetReadOnly(true)
+	auth := AuthContext{WToken: "token"}
+
+	if _, err := client.AddToBasket(context.Background(), map[string]any{"items": []any{}}, auth); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected basket add to be refused, got %v", err)
+	}
+	if _, err := client.FavoriteVenueAdd(context.Background(), "venue-1", auth); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected favorite add to be refused, got %v", err)
+	}
+	if _, err := client.Raw(context.Background(), "delete", "/v1/anything", nil, auth); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected raw DELETE to be refused, got %v", err)
+	}
+	if httpClient.doCalls != 0 {
+		t.Fatalf("expected refused requests to stay local, got %d calls", httpClient.doCalls)
+	}
+
+	if _, err := client.CheckoutPreview(context.Background(), map[string]any{}, auth); err != nil {
+		t.Fatalf("expected checkout preview to stay available, got %v", err)
+	}
+	if _, err := client.BasketCount(context.Background(), auth); err != nil {
+		t.Fatalf("expected reads to stay available, got %v", err)
+	}
+	client.SetReadOnly(false)
+	if _, err := client.AddToBasket(context.Background(), map[string]any{"items": []any{}}, auth); err != nil {
+		t.Fatalf("expected basket add after leaving read-only mode, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package wolt
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces credentials in error messages and request traces.
+const Redacted = "<redacted>"
+
+// minSecretLength keeps short cookie values from redacting ordinary words.
+const minSecretLength = 8
+
+var (
+	redactM        sync.RWMutex
+	revealSecrets  bool
+	knownSecrets   = map[string]struct{}{}
+	secretPatterns = []struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;\\]+`), "${1}" + Redacted},
+		{regexp.MustCompile(`eyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]*`), Redacted},
+		{regexp.MustCompile(`(?i)(\\?"(?:access_token|refresh_token|id_token|token|wtoken|__wtoken|__wrtoken|accessToken|refreshToken|authorization|cookie|password)\\?"\s*:\s*\\?")(?:[^"\\]|\\[^"])*`), "${1}" + Redacted},
+		{regexp.MustCompile(`(?i)((?:^|[?&;\s])(?:access_token|refresh_token|id_token|token|wtoken|__wtoken|__wrtoken)=)[^&;\s"\\]+`), "${1}" + Redacted},
+		{regexp.MustCompile(`(?i)((?:authorization|cookie):\s*)[^\n]+`), "${1}" + Redacted},
+	}
+)
+
+// SetRevealSecrets turns redaction off, for explicit --reveal-secrets runs.
+func SetRevealSecrets(reveal bool) {
+	redactM.Lock()
+	revealSecrets = reveal
+	redactM.Unlock()
+}
+
+// RememberSecrets registers credential values that Redact removes verbatim,
+// covering opaque refresh tokens no pattern recognizes.
+func RememberSecrets(values ...string) {
+	redactM.Lock()
+	defer redactM.Unlock()
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) > len("Bearer ") && strings.EqualFold(value[:len("Bearer ")], "Bearer ") {
+			value = strings.TrimSpace(value[len("Bearer "):])
+		}
+		if len(value) >= minSecretLength {
+			knownSecrets[value] = struct{}{}
+		}
+	}
+}
+
+// Redact removes bearer tokens, JWTs, token fields, cookies, and remembered
+// credential values from text unless SetRevealSecrets(true) is in effect.
+func Redact(text string) string {
+	redactM.RLock()
+	defer redactM.RUnlock()
+	if revealSecrets || text == "" {
+		return text
+	}
+	for secret := range knownSecrets {
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	for _, rule := range secretPatterns {
+		text = rule.pattern.ReplaceAllString(text, rule.replacement)
+	}
+	return text
+}
+
+// RememberAuthSecrets registers the tokens and cookie values of auth.
+func RememberAuthSecrets(auth AuthContext) {
+	RememberSecrets(auth.WToken, auth.RefreshToken)
+	for _, header := range auth.Cookies {
+		// Cookies are remembered by value; their names are not secret.
+		for _, cookie := range strings.Split(header, ";") {
+			if _, value, ok := strings.Cut(cookie, "="); ok {
+				RememberSecrets(value)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package wolt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactRemovesCredentialShapes(t *testing.T) {
+	RememberSecrets("opaque-refresh-remembered")
+	cases := map[string]string{
+		"Authorization: Bearer abc.def.ghi":                             "Authorization: <redacted>",
+		"header Bearer raw-token-value end":                             "header Bearer <redacted> end",
+		`{"access_token":"tok-1","name":"Burger"}`:                      `{"access_token":"<redacted>","name":"Burger"}`,
+		`body="{\"refresh_token\":\"tok-2\"}"`:                          `body="{\"refresh_token\":\"<redacted>\"}"`,
+		"cookie __wtoken=abc123; __wrtoken=def456; lang=en":             "cookie __wtoken=<redacted>; __wrtoken=<redacted>; lang=en",
+		"jwt eyJhbGciOiJI.eyJzdWIiOiIx.c2lnbmF0 in text":                "jwt <redacted> in text",
+		"GET https://example.test/path?token=secret-value&limit=5":      "GET https://example.test/path?token=<redacted>&limit=5",
+		"echoed opaque-refresh-remembered back":                         "echoed <redacted> back",
+		"GET https://consumer-api.wolt.com/order-xp/web/v1/pages/venue": "GET https://consumer-api.wolt.com/order-xp/web/v1/pages/venue",
+	}
+	for input, want := range cases {
+		if got := Redact(input); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSetRevealSecretsDisablesRedaction(t *testing.T) {
+	SetRevealSecrets(true)
+	defer SetRevealSecrets(false)
+	input := `{"access_token":"tok-1"}`
+	if got := Redact(input); got != input {
+		t.Fatalf("expected revealed text unchanged, got %q", got)
+	}
+}
+
+func TestUpstreamErrorRedactsEchoedTokens(t *testing.T) {
+	err := &UpstreamRequestError{
+		Method:     "POST",
+		URL:        "https://example.test/oauth2/token",
+		StatusCode: 400,
+		Body:       `{"error":"invalid_grant","refresh_token":"leaked-refresh-token"}`,
+	}
+	if message := err.Error(); strings.Contains(message, "leaked-refresh-token") || !strings.Contains(message, Redacted) {
+		t.Fatalf("expected the refresh token to be redacted, got %q", message)
+	}
+}
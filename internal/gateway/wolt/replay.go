@@ -0,0 +1,219 @@
+package wolt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+// Fixture is one recorded upstream exchange. Request headers and query strings
+// are never stored, and the body is scrubbed before it reaches disk.
+type Fixture struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// scrubbedFixtureKeys lists response keys whose values are replaced when recording.
+var scrubbedFixtureKeys = map[string]struct{}{
+	"access_token":  {},
+	"refresh_token": {},
+	"token":         {},
+	"wtoken":        {},
+	"email":         {},
+	"phone":         {},
+	"phone_number":  {},
+	"first_name":    {},
+	"last_name":     {},
+	"full_name":     {},
+	"street":        {},
+	"apartment":     {},
+	"entrance":      {},
+	"door_code":     {},
+	"comment":       {},
+	"card_number":   {},
+	"last4":         {},
+	"iban":          {},
+}
+
+const scrubbedFixtureValue = "<redacted>"
+
+// FixtureName returns the file name a request is recorded under: method, host and
+// path, with the query string dropped.
+func FixtureName(req *http.Request) string {
+	key := req.Method + "_" + req.URL.Host + req.URL.Path
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return strings.Trim(b.String(), "_") + ".json"
+}
+
+// RecordingHTTPClient forwards requests and saves sanitized JSON responses as fixtures.
+type RecordingHTTPClient struct {
+	next HTTPClient
+	dir  string
+	mu   sync.Mutex
+}
+
+// NewRecordingHTTPClient wraps next and records its responses into dir.
+func NewRecordingHTTPClient(next HTTPClient, dir string) *RecordingHTTPClient {
+	return &RecordingHTTPClient{next: next, dir: dir}
+}
+
+// WithRecordDir records sanitized upstream responses into dir. It wraps the HTTP
+// client configured so far, so it must follow WithHTTPClient.
+func WithRecordDir(dir string) Option {
+	return func(c *Client) {
+		if strings.TrimSpace(dir) == "" {
+			return
+		}
+		c.recordDir = dir
+		c.httpClient = NewRecordingHTTPClient(c.httpClient, dir)
+	}
+}
+
+// Do implements HTTPClient.
+func (r *RecordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	res, err := r.next.Do(req)
+	if err != nil {
+		return res, err
+	}
+	raw, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(raw))
+	if err != nil {
+		return res, nil
+	}
+	// Recording is best effort: a fixture that cannot be written never fails the command.
+	_ = r.save(req, res.StatusCode, raw)
+	return res, nil
+}
+
+func (r *RecordingHTTPClient) save(req *http.Request, status int, raw []byte) error {
+	body, err := ScrubFixtureBody(raw)
+	if err != nil {
+		return err
+	}
+	fixture := Fixture{
+		Method: req.Method,
+		URL:    req.URL.Scheme + "://" + req.URL.Host + req.URL.Path,
+		Status: status,
+		Body:   body,
+	}
+	payload, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, FixtureName(req)), append(payload, '\n'), 0o600)
+}
+
+// ScrubFixtureBody redacts credentials and personal data from a JSON response body.
+func ScrubFixtureBody(raw []byte) (json.RawMessage, error) {
+	var payload any
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("fixture body is not JSON: %w", err)
+	}
+	scrubbed, err := json.Marshal(scrubFixtureValue(payload))
+	if err != nil {
+		return nil, err
+	}
+	return scrubbed, nil
+}
+
+func scrubFixtureValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, inner := range typed {
+			if _, secret := scrubbedFixtureKeys[strings.ToLower(key)]; secret {
+				if inner != nil {
+					typed[key] = scrubbedFixtureValue
+				}
+				continue
+			}
+			typed[key] = scrubFixtureValue(inner)
+		}
+		return typed
+	case []any:
+		for idx, inner := range typed {
+			typed[idx] = scrubFixtureValue(inner)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// ReplayHTTPClient answers requests from recorded fixtures and returns 404 for
+// anything that was not recorded.
+type ReplayHTTPClient struct {
+	dir     string
+	offline bool
+}
+
+// NewReplayHTTPClient serves fixtures recorded into dir.
+func NewReplayHTTPClient(dir string) *ReplayHTTPClient {
+	return &ReplayHTTPClient{dir: dir}
+}
+
+// NewOfflineHTTPClient serves fixtures recorded into dir and fails unrecorded
+// requests with domain.ErrOffline instead of answering 404.
+func NewOfflineHTTPClient(dir string) *ReplayHTTPClient {
+	return &ReplayHTTPClient{dir: dir, offline: true}
+}
+
+// Do implements HTTPClient.
+func (r *ReplayHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if r.offline && strings.TrimSpace(r.dir) == "" {
+		return nil, fmt.Errorf("%w: %s %s (no record directory)", domain.ErrOffline, req.Method, req.URL.Path)
+	}
+	raw, err := os.ReadFile(filepath.Join(r.dir, FixtureName(req)))
+	if os.IsNotExist(err) && r.offline {
+		return nil, fmt.Errorf("%w: %s %s", domain.ErrOffline, req.Method, req.URL.Path)
+	}
+	if os.IsNotExist(err) {
+		return replayResponse(req, http.StatusNotFound, []byte(`{"error":"no recorded fixture"}`)), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(raw, &fixture); err != nil {
+		return nil, fmt.Errorf("decode fixture %s: %w", FixtureName(req), err)
+	}
+	status := fixture.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return replayResponse(req, status, fixture.Body), nil
+}
+
+func replayResponse(req *http.Request, status int, body []byte) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Header:     header,
+		Request:    req,
+	}
+}
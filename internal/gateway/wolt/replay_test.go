@@ -0,0 +1,89 @@
+package wolt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordThenReplayRoundTripsScrubbedFixture(t *testing.T) {
+	dir := t.TempDir()
+	live := &captureHTTPClient{responseBody: `{"user":{"email":"jane@example.com","first_name":"Jane","country":"FIN"}}`}
+	recorder := NewClient(
+		WithHTTPClient(live),
+		WithRecordDir(dir),
+		WithEndpoints(Endpoints{UserMe: "https://example.test/v1/user/me"}),
+	)
+	if _, err := recorder.UserMe(context.Background(), AuthContext{WToken: "secret-token"}); err != nil {
+		t.Fatalf("record user me: %v", err)
+	}
+
+	fixture, err := os.ReadFile(filepath.Join(dir, "GET_example.test_v1_user_me.json"))
+	if err != nil {
+		t.Fatalf("read recorded fixture: %v", err)
+	}
+	for _, leaked := range []string{"jane@example.com", "Jane", "secret-token"} {
+		if strings.Contains(string(fixture), leaked) {
+			t.Fatalf("fixture leaks %q:\n%s", leaked, fixture)
+		}
+	}
+
+	replayer := NewClient(
+		WithHTTPClient(NewReplayHTTPClient(dir)),
+		WithEndpoints(Endpoints{UserMe: "https://example.test/v1/user/me"}),
+	)
+	payload, err := replayer.UserMe(context.Background(), AuthContext{WToken: "other-token"})
+	if err != nil {
+		t.Fatalf("replay user me: %v", err)
+	}
+	user, _ := payload["user"].(map[string]any)
+	if user["country"] != "FIN" || user["email"] != scrubbedFixtureValue {
+		t.Fatalf("unexpected replayed payload: %#v", payload)
+	}
+}
+
+func TestReplayReturnsNotFoundForUnrecordedRequest(t *testing.T) {
+	client := NewClient(
+		WithHTTPClient(NewReplayHTTPClient(t.TempDir())),
+		WithEndpoints(Endpoints{UserMe: "https://example.test/v1/user/me"}),
+	)
+	_, err := client.UserMe(context.Background(), AuthContext{WToken: "token"})
+	var upstreamErr *UpstreamRequestError
+	if err == nil || !errors.As(err, &upstreamErr) || upstreamErr.StatusCode != 404 {
+		t.Fatalf("expected 404 upstream error, got %v", err)
+	}
+}
+
+func TestSetFixtureRecordingRecordsUntilCleared(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(
+		WithHTTPClient(&captureHTTPClient{responseBody: `{"user":{"country":"FIN"}}`}),
+		WithEndpoints(Endpoints{UserMe: "https://example.test/v1/user/me"}),
+	)
+	fixture := filepath.Join(dir, "GET_example.test_v1_user_me.json")
+
+	client.SetFixtureRecording(dir)
+	if _, err := client.UserMe(context.Background(), AuthContext{WToken: "token"}); err != nil {
+		t.Fatalf("user me: %v", err)
+	}
+	if _, err := os.Stat(fixture); err != nil {
+		t.Fatalf("expected a recorded fixture: %v", err)
+	}
+	if family := client.EndpointFamily("https://example.test/v1/user/me"); family != "user_me" {
+		t.Fatalf("expected user_me family, got %q", family)
+	}
+
+	client.SetFixtureRecording("")
+	if err := os.Remove(fixture); err != nil {
+		t.Fatalf("remove fixture: %v", err)
+	}
+	if _, err := client.UserMe(context.Background(), AuthContext{WToken: "token"}); err != nil {
+		t.Fatalf("user me: %v", err)
+	}
+	if _, err := os.Stat(fixture); !os.IsNotExist(err) {
+		t.Fatalf("expected no fixture after recording was cleared, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package wolt
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseFamilyMinIntervals parses WOLT_HTTP_FAMILY_MIN_INTERVAL_MS:
+// comma-separated family=milliseconds pairs such as
+// "venue_page_static=100,consumer-api.wolt.com=300". A family is an endpoint
+// family as printed by --stats or an upstream host.
+func ParseFamilyMinIntervals(raw string) (map[string]time.Duration, error) {
+	return parseFamilyMilliseconds(raw, "min interval")
+}
+
+// ParseFamilyCacheTTLs parses WOLT_MEMORY_CACHE_TTL_MS in the same
+// family=milliseconds form, as in "venue_page_static=60000,search=30000".
+func ParseFamilyCacheTTLs(raw string) (map[string]time.Duration, error) {
+	return parseFamilyMilliseconds(raw, "cache TTL")
+}
+
+func parseFamilyMilliseconds(raw string, setting string) (map[string]time.Duration, error) {
+	intervals := map[string]time.Duration{}
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		family, value, ok := strings.Cut(pair, "=")
+		family = strings.TrimSpace(family)
+		if !ok || family == "" {
+			return nil, fmt.Errorf("%s %q must look like family=milliseconds", setting, strings.TrimSpace(pair))
+		}
+		ms, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("%s of %s must be a non-negative number of milliseconds, got %q", setting, family, strings.TrimSpace(value))
+		}
+		intervals[family] = time.Duration(ms) * time.Millisecond
+	}
+	return intervals, nil
+}
+
+// WithFamilyMinIntervals paces the listed endpoint families or hosts on their
+// own, each with its own minimum delay between calls. Other requests keep
+// sharing the WithRequestMinInterval window.
+func WithFamilyMinIntervals(intervals map[string]time.Duration) Option {
+	return func(c *Client) {
+		c.familyMinGaps = map[string]time.Duration{}
+		for family, interval := range intervals {
+			c.familyMinGaps[family] = max(interval, 0)
+		}
+	}
+}
+
+// RequestMinIntervals reports the per-family minimum delays set with
+// WithFamilyMinIntervals.
+func (c *Client) RequestMinIntervals() map[string]time.Duration {
+	return maps.Clone(c.familyMinGaps)
+}
+
+// requestPacing returns the window rawURL is paced in and its interval: the
+// window of its endpoint family or host when one is configured, else the
+// shared window.
+func (c *Client) requestPacing(rawURL string) (string, time.Duration) {
+	if len(c.familyMinGaps) > 0 {
+		if family, ok := c.configuredFamily(rawURL); ok {
+			if interval, ok := c.familyMinGaps[family]; ok {
+				return family, interval
+			}
+		}
+		if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+			if interval, ok := c.familyMinGaps[parsed.Host]; ok {
+				return parsed.Host, interval
+			}
+		}
+	}
+	return "", c.minRequestGap
+}
+
+// waitForRequestSlot blocks until the pacing window of rawURL allows another
+// request.
+func (c *Client) waitForRequestSlot(ctx context.Context, rawURL string) error {
+	window, interval := c.requestPacing(rawURL)
+	if interval <= 0 {
+		return nil
+	}
+	for {
+		c.requestWindowM.Lock()
+		wait := time.Until(c.nextRequestAt[window])
+		if wait <= 0 {
+			if c.nextRequestAt == nil {
+				c.nextRequestAt = map[string]time.Time{}
+			}
+			c.nextRequestAt[window] = time.Now().Add(interval)
+			c.requestWindowM.Unlock()
+			return nil
+		}
+		c.requestWindowM.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package wolt
+
+import (
+	"container/list"
+	"maps"
+	"sync"
+	"time"
+)
+
+// DefaultResponseCacheTTLs is how long WithResponseCache keeps a response of
+// each endpoint family. Only venue, menu, and city data is listed: baskets,
+// account, and checkout responses change with every mutation and are never
+// cached.
+var DefaultResponseCacheTTLs = map[string]time.Duration{
+	"venue_page_static": 10 * time.Minute,
+	"restaurant":        10 * time.Minute,
+	"assortment":        5 * time.Minute,
+	"venue_content":     5 * time.Minute,
+	"venue_item":        5 * time.Minute,
+	"cities":            time.Hour,
+}
+
+// ResponseCacheStats counts memory cache lookups over the client's lifetime.
+type ResponseCacheStats struct {
+	Size    int
+	Entries int
+	Hits    int
+	Misses  int
+}
+
+// responseCache keeps raw 2xx GET responses in memory, least recently used
+// first out once size entries are stored. A nil cache stores nothing.
+type responseCache struct {
+	mu      sync.Mutex
+	size    int
+	ttls    map[string]time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	hits    int
+	misses  int
+	now     func() time.Time
+}
+
+type cachedResponse struct {
+	key        string
+	raw        []byte
+	statusCode int
+	expiresAt  time.Time
+}
+
+// WithResponseCache keeps up to size GET responses in memory so that repeated
+// lookups in one process (--then chains, retry, streams) skip the network.
+// ttls overrides DefaultResponseCacheTTLs family by family; a zero TTL turns
+// caching off for that family. A size below 1 disables the cache.
+func WithResponseCache(size int, ttls map[string]time.Duration) Option {
+	return func(c *Client) {
+		if size < 1 {
+			c.cache = nil
+			return
+		}
+		merged := maps.Clone(DefaultResponseCacheTTLs)
+		maps.Copy(merged, ttls)
+		c.cache = &responseCache{
+			size:    size,
+			ttls:    merged,
+			order:   list.New(),
+			entries: map[string]*list.Element{},
+			now:     time.Now,
+		}
+	}
+}
+
+// ResponseCacheStats reports the memory cache set with WithResponseCache;
+// ok is false when the cache is off.
+func (c *Client) ResponseCacheStats() (ResponseCacheStats, bool) {
+	if c.cache == nil {
+		return ResponseCacheStats{}, false
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	return ResponseCacheStats{Size: c.cache.size, Entries: c.cache.order.Len(), Hits: c.cache.hits, Misses: c.cache.misses}, true
+}
+
+// covers reports whether responses of family are cached.
+func (r *responseCache) covers(family string) bool {
+	return r != nil && r.ttls[family] > 0
+}
+
+// get returns the stored response for key and counts the lookup; expired
+// entries are dropped.
+func (r *responseCache) get(key string, family string) ([]byte, int, bool) {
+	if !r.covers(family) {
+		return nil, 0, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if element, ok := r.entries[key]; ok {
+		entry := element.Value.(*cachedResponse)
+		if r.now().Before(entry.expiresAt) {
+			r.order.MoveToFront(element)
+			r.hits++
+			return entry.raw, entry.statusCode, true
+		}
+		r.order.Remove(element)
+		delete(r.entries, key)
+	}
+	r.misses++
+	return nil, 0, false
+}
+
+func (r *responseCache) put(key string, family string, raw []byte, statusCode int) {
+	if !r.covers(family) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := &cachedResponse{key: key, raw: raw, statusCode: statusCode, expiresAt: r.now().Add(r.ttls[family])}
+	if element, ok := r.entries[key]; ok {
+		element.Value = entry
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[key] = r.order.PushFront(entry)
+	for r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
@@ -0,0 +1,90 @@
+package wolt
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheServesRepeatedStaticLookups(t *testing.T) {
+	httpClient := &captureHTTPClient{responseBody: `{"venue":{"slug":"burger-place"}}`}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{VenuePage: "https://example.test/venue/slug/", Checkout: "https://example.test/checkout"}),
+		WithResponseCache(2, nil),
+	)
+	timings := &RequestTimings{}
+	ctx := WithRequestTimings(context.Background(), timings)
+
+	for range 3 {
+		payload, err := client.VenuePageStatic(ctx, "burger-place")
+		if err != nil || payload["venue"] == nil {
+			t.Fatalf("unexpected payload %v, err %v", payload, err)
+		}
+	}
+	if httpClient.doCalls != 1 {
+		t.Fatalf("expected one upstream call, got %d", httpClient.doCalls)
+	}
+	summary := timings.Summary()
+	if len(summary) != 1 || summary[0].Count != 1 || summary[0].CacheHits != 2 || summary[0].CacheMisses != 1 {
+		t.Fatalf("expected one request, two hits, and one miss, got %+v", summary)
+	}
+
+	// Checkout is not a cached family.
+	for range 2 {
+		if _, err := client.CheckoutPreview(ctx, map[string]any{}, AuthContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if httpClient.doCalls != 3 {
+		t.Fatalf("expected checkout requests to bypass the cache, got %d calls", httpClient.doCalls)
+	}
+
+	stats, ok := client.ResponseCacheStats()
+	if !ok || stats.Size != 2 || stats.Entries != 1 || stats.Hits != 2 || stats.Misses != 1 {
+		t.Fatalf("unexpected cache stats %+v (enabled %v)", stats, ok)
+	}
+}
+
+func TestResponseCacheEvictsLeastRecentlyUsedAndExpires(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	httpClient := &captureHTTPClient{}
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithEndpoints(Endpoints{VenuePage: "https://example.test/venue/slug/"}),
+		WithResponseCache(2, map[string]time.Duration{"venue_page_static": time.Minute}),
+	)
+	client.cache.now = func() time.Time { return now }
+	fetch := func(slug string) {
+		t.Helper()
+		if _, err := client.VenuePageStatic(context.Background(), slug); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	fetch("a")
+	fetch("b")
+	fetch("a")
+	fetch("c") // evicts b, the least recently used
+	if httpClient.doCalls != 3 {
+		t.Fatalf("expected a to be served from the cache, got %d calls", httpClient.doCalls)
+	}
+	fetch("a")
+	fetch("b")
+	if httpClient.doCalls != 4 {
+		t.Fatalf("expected only the evicted b to be fetched again, got %d calls", httpClient.doCalls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	fetch("a")
+	if httpClient.doCalls != 5 {
+		t.Fatalf("expected an expired entry to be fetched again, got %d calls", httpClient.doCalls)
+	}
+}
+
+func TestResponseCacheIsOffByDefault(t *testing.T) {
+	client := NewClient(WithResponseCache(0, nil))
+	if _, ok := client.ResponseCacheStats(); ok {
+		t.Fatal("expected no cache for size 0")
+	}
+}
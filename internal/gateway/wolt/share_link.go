@@ -0,0 +1,43 @@
+package wolt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+)
+
+// maxShareLinkBody caps how much of a share landing page is scanned for a venue URL.
+const maxShareLinkBody = 512 << 10
+
+var shareLinkVenueURLPattern = regexp.MustCompile(`https://wolt\.com/[A-Za-z0-9/_-]*/(?:restaurant|venue)/[A-Za-z0-9_-]+`)
+
+// ResolveShareLink follows a shortened share link and returns the URL it lands
+// on. Landing pages that redirect with JavaScript instead of HTTP are scanned
+// for the first wolt.com venue URL.
+func (c *Client) ResolveShareLink(ctx context.Context, link string) (string, error) {
+	res, err := c.doRequest(ctx, http.MethodGet, link, nil, map[string]string{"accept": "text/html"})
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return "", &UpstreamRequestError{Method: http.MethodGet, URL: link, StatusCode: res.StatusCode}
+	}
+	landed := link
+	if res.Request != nil && res.Request.URL != nil {
+		landed = res.Request.URL.String()
+	}
+	if shareLinkVenueURLPattern.MatchString(landed) {
+		return landed, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxShareLinkBody))
+	if err == nil {
+		if match := shareLinkVenueURLPattern.Find(body); match != nil {
+			return string(match), nil
+		}
+	}
+	return landed, nil
+}
@@ -0,0 +1,69 @@
+package wolt
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// flightGroup collapses concurrent identical GET requests into one upstream
+// call. Only requests in flight are shared; a finished response is not reused.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done       chan struct{}
+	raw        []byte
+	statusCode int
+	err        error
+}
+
+// do runs fetch for key unless an identical call is already running, in
+// which case it waits for that call and reports shared. A waiter whose ctx
+// ends stops waiting and returns ctx.Err(); the call itself keeps running for
+// the others. Callers decode the returned bytes themselves so they never share
+// decoded values.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func() ([]byte, int, error)) ([]byte, int, bool, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, 0, false, ctx.Err()
+		}
+		return call.raw, call.statusCode, true, call.err
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.raw, call.statusCode, call.err = fetch()
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.raw, call.statusCode, false, call.err
+}
+
+// flightKey identifies a request by method, URL, and headers, so requests
+// with different credentials or locale are never shared.
+func flightKey(method string, rawURL string, headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var key strings.Builder
+	key.WriteString(method + " " + rawURL)
+	for _, name := range names {
+		key.WriteString("\n" + strings.ToLower(name) + ":" + headers[name])
+	}
+	return key.String()
+}
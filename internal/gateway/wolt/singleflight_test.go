@@ -0,0 +1,126 @@
+package wolt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// gateHTTPClient holds every response until release is closed.
+type gateHTTPClient struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (c *gateHTTPClient) Do(*http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	c.once.Do(func() { close(c.started) })
+	<-c.release
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"venue":{"slug":"burger-place"}}`)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestConcurrentIdenticalRequestsShareOneUpstreamCall(t *testing.T) {
+	transport := &gateHTTPClient{started: make(chan struct{}), release: make(chan struct{})}
+	client := NewClient(
+		WithHTTPClient(transport),
+		WithEndpoints(Endpoints{VenuePage: "https://example.test/venue/slug/"}),
+	)
+	timings := &RequestTimings{}
+	ctx := WithRequestTimings(context.Background(), timings)
+
+	payloads := make([]map[string]any, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		payloads[0], _ = client.VenuePageStatic(ctx, "burger-place")
+	}()
+	<-transport.started
+	go func() {
+		defer wg.Done()
+		payloads[1], _ = client.VenuePageStatic(ctx, "burger-place")
+	}()
+	// Give the second request time to join the one in flight.
+	time.Sleep(20 * time.Millisecond)
+	close(transport.release)
+	wg.Wait()
+
+	if calls := transport.calls.Load(); calls != 1 {
+		t.Fatalf("expected one upstream call, got %d", calls)
+	}
+	if payloads[0] == nil || payloads[1] == nil {
+		t.Fatalf("expected both callers to get the payload, got %v", payloads)
+	}
+	payloads[0]["venue"] = nil
+	if payloads[1]["venue"] == nil {
+		t.Fatalf("expected each caller to decode its own payload")
+	}
+	summary := timings.Summary()
+	if len(summary) != 1 || summary[0].Count != 1 || summary[0].Deduplicated != 1 {
+		t.Fatalf("expected one request and one deduplicated request, got %+v", summary)
+	}
+
+	if _, err := client.VenuePageStatic(ctx, "burger-place"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls := transport.calls.Load(); calls != 2 {
+		t.Fatalf("expected a finished response not to be reused, got %d calls", calls)
+	}
+}
+
+func TestFlightWaiterReturnsWhenItsContextEnds(t *testing.T) {
+	var group flightGroup
+	started, release := make(chan struct{}), make(chan struct{})
+	go func() {
+		_, _, _, _ = group.do(context.Background(), "key", func() ([]byte, int, error) {
+			close(started)
+			<-release
+			return []byte("{}"), http.StatusOK, nil
+		})
+	}()
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, _, shared, err := group.do(ctx, "key", func() ([]byte, int, error) {
+			t.Error("expected the waiter to join the call in flight")
+			return nil, 0, nil
+		})
+		if shared {
+			t.Error("expected a cancelled waiter not to report a shared response")
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the cancelled waiter to return before the call finished")
+	}
+}
+
+func TestFlightKeySeparatesHeaders(t *testing.T) {
+	base := flightKey(http.MethodGet, "https://example.test/a", map[string]string{"Authorization": "Bearer a", "app-language": "en"})
+	if base == flightKey(http.MethodGet, "https://example.test/a", map[string]string{"Authorization": "Bearer b", "app-language": "en"}) {
+		t.Fatalf("expected different credentials to use different keys")
+	}
+	if base != flightKey(http.MethodGet, "https://example.test/a", map[string]string{"app-language": "en", "Authorization": "Bearer a"}) {
+		t.Fatalf("expected the key not to depend on header order")
+	}
+}
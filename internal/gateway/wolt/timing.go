@@ -0,0 +1,208 @@
+package wolt
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type requestTimingsKey struct{}
+
+// RequestTimings collects upstream latency per endpoint family for one command run.
+type RequestTimings struct {
+	mu       sync.Mutex
+	families []string
+	samples  map[string][]time.Duration
+	shared   map[string]int
+	bytes    map[string]int
+	hits     map[string]int
+	misses   map[string]int
+}
+
+// EndpointTiming summarizes the latency of one endpoint family. Deduplicated
+// counts requests that waited for an identical one in flight instead of
+// being sent; they are not part of Count. CacheHits counts requests answered
+// from the memory cache, also left out of Count, and CacheMisses the cacheable
+// ones it did not hold. Bytes is the size of the response bodies received.
+type EndpointTiming struct {
+	Family       string
+	Count        int
+	Deduplicated int
+	CacheHits    int
+	CacheMisses  int
+	Bytes        int
+	P50          time.Duration
+	P95          time.Duration
+	Max          time.Duration
+}
+
+// WithRequestTimings makes every request sent with the returned context record its latency into timings.
+func WithRequestTimings(ctx context.Context, timings *RequestTimings) context.Context {
+	return context.WithValue(ctx, requestTimingsKey{}, timings)
+}
+
+func requestTimingsFromContext(ctx context.Context) *RequestTimings {
+	if ctx == nil {
+		return nil
+	}
+	timings, _ := ctx.Value(requestTimingsKey{}).(*RequestTimings)
+	return timings
+}
+
+// RequestTimingsFromContext returns the timings attached with
+// WithRequestTimings, or nil.
+func RequestTimingsFromContext(ctx context.Context) *RequestTimings {
+	return requestTimingsFromContext(ctx)
+}
+
+func (t *RequestTimings) observe(family string, elapsed time.Duration, responseBytes int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.samples == nil {
+		t.samples = map[string][]time.Duration{}
+		t.bytes = map[string]int{}
+	}
+	t.track(family)
+	t.samples[family] = append(t.samples[family], elapsed)
+	t.bytes[family] += responseBytes
+}
+
+func (t *RequestTimings) observeShared(family string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.shared == nil {
+		t.shared = map[string]int{}
+	}
+	t.track(family)
+	t.shared[family]++
+}
+
+// observeCache counts a memory cache lookup for family.
+func (t *RequestTimings) observeCache(family string, hit bool) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.hits == nil {
+		t.hits = map[string]int{}
+		t.misses = map[string]int{}
+	}
+	t.track(family)
+	if hit {
+		t.hits[family]++
+	} else {
+		t.misses[family]++
+	}
+}
+
+// track lists family in first-seen order; callers hold t.mu.
+func (t *RequestTimings) track(family string) {
+	if _, seen := t.samples[family]; seen || t.shared[family] > 0 || t.hits[family] > 0 || t.misses[family] > 0 {
+		return
+	}
+	t.families = append(t.families, family)
+}
+
+// Summary returns per-family statistics, slowest p95 first.
+func (t *RequestTimings) Summary() []EndpointTiming {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]EndpointTiming, 0, len(t.families))
+	for _, family := range t.families {
+		sorted := slices.Clone(t.samples[family])
+		slices.Sort(sorted)
+		timing := EndpointTiming{
+			Family:       family,
+			Count:        len(sorted),
+			Deduplicated: t.shared[family],
+			CacheHits:    t.hits[family],
+			CacheMisses:  t.misses[family],
+			Bytes:        t.bytes[family],
+		}
+		if len(sorted) > 0 {
+			timing.P50 = nearestRank(sorted, 50)
+			timing.P95 = nearestRank(sorted, 95)
+			timing.Max = sorted[len(sorted)-1]
+		}
+		out = append(out, timing)
+	}
+	slices.SortStableFunc(out, func(a, b EndpointTiming) int {
+		return cmp.Compare(b.P95, a.P95)
+	})
+	return out
+}
+
+func nearestRank(sorted []time.Duration, percentile int) time.Duration {
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// endpointFamily names the configured endpoint rawURL belongs to, so requests that
+// differ only by slug, id, or query are grouped together.
+func (c *Client) endpointFamily(rawURL string) string {
+	if family, ok := c.configuredFamily(rawURL); ok {
+		return family
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "other"
+	}
+	return parsed.Host
+}
+
+// configuredFamily names the configured endpoint with the longest base that
+// prefixes rawURL.
+func (c *Client) configuredFamily(rawURL string) (string, bool) {
+	families := []struct {
+		name string
+		base string
+	}{
+		{"front_page", c.endpoints.ConsumerFront},
+		{"search", c.endpoints.SearchPage},
+		{"venue_page_static", c.endpoints.VenuePage},
+		{"venue_page_dynamic", c.endpoints.VenuePageDynamic},
+		{"assortment", c.endpoints.Assortment},
+		{"venue_content", c.endpoints.VenueContent},
+		{"venue_item", c.endpoints.VenueItem},
+		{"restaurant", c.endpoints.Restaurant},
+		{"cities", c.endpoints.Cities},
+		{"user_me", c.endpoints.UserMe},
+		{"payment_methods", c.endpoints.PaymentMethods},
+		{"payment_profile", c.endpoints.PaymentProfile},
+		{"address_fields", c.endpoints.AddressFields},
+		{"delivery_info", c.endpoints.DeliveryInfo},
+		{"order_history", c.endpoints.OrderHistory},
+		{"favorites_page", c.endpoints.FavoritesPage},
+		{"favorite_venue", c.endpoints.FavoriteVenue},
+		{"basket_count", c.endpoints.BasketCount},
+		{"baskets_page", c.endpoints.BasketsPage},
+		{"basket_bulk_delete", c.endpoints.BasketBulkDelete},
+		{"basket", c.endpoints.Basket},
+		{"checkout", c.endpoints.Checkout},
+		{"access_token", c.endpoints.AccessToken},
+	}
+	best, bestLen := "", 0
+	for _, family := range families {
+		if family.base != "" && strings.HasPrefix(rawURL, family.base) && len(family.base) > bestLen {
+			best, bestLen = family.name, len(family.base)
+		}
+	}
+	return best, best != ""
+}
@@ -0,0 +1,66 @@
+package wolt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+func TestRequestTimingsGroupByEndpointFamily(t *testing.T) {
+	client := NewClient(
+		WithHTTPClient(&captureHTTPClient{}),
+		WithEndpoints(Endpoints{
+			ConsumerFront:    "https://example.test/v1/pages/front",
+			VenuePageDynamic: "https://example.test/venue/slug/",
+			BasketCount:      "https://example.test/baskets/count",
+			Basket:           "https://example.test/baskets",
+		}),
+	)
+	timings := &RequestTimings{}
+	ctx := WithRequestTimings(context.Background(), timings)
+
+	_, _ = client.FrontPage(ctx, domain.Location{Lat: 1, Lon: 2})
+	_, _ = client.VenuePageDynamic(ctx, "a", VenuePageDynamicOptions{})
+	_, _ = client.VenuePageDynamic(ctx, "b", VenuePageDynamicOptions{})
+	_, _ = client.BasketCount(ctx, AuthContext{WToken: "token"})
+	_, _ = client.FrontPage(context.Background(), domain.Location{Lat: 1, Lon: 2})
+
+	counts := map[string]int{}
+	for _, timing := range timings.Summary() {
+		counts[timing.Family] = timing.Count
+		if timing.P50 > timing.P95 || timing.P95 > timing.Max {
+			t.Fatalf("percentiles out of order: %+v", timing)
+		}
+	}
+	want := map[string]int{"front_page": 1, "venue_page_dynamic": 2, "basket_count": 1}
+	if len(counts) != len(want) {
+		t.Fatalf("unexpected families: %v", counts)
+	}
+	for family, count := range want {
+		if counts[family] != count {
+			t.Fatalf("expected %d %s requests, got %v", count, family, counts)
+		}
+	}
+}
+
+func TestRequestTimingsSummaryPercentiles(t *testing.T) {
+	timings := &RequestTimings{}
+	for i := 1; i <= 20; i++ {
+		timings.observe("slow", time.Duration(i)*10*time.Millisecond, 100)
+	}
+	timings.observe("fast", time.Millisecond, 7)
+
+	summary := timings.Summary()
+	if len(summary) != 2 || summary[0].Family != "slow" {
+		t.Fatalf("expected slowest family first, got %+v", summary)
+	}
+	slow := summary[0]
+	if slow.P50 != 100*time.Millisecond || slow.P95 != 190*time.Millisecond || slow.Max != 200*time.Millisecond {
+		t.Fatalf("unexpected percentiles: %+v", slow)
+	}
+	if slow.Bytes != 2000 || summary[1].Bytes != 7 {
+		t.Fatalf("expected response bytes summed per family, got %+v", summary)
+	}
+}
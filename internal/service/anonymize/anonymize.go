@@ -0,0 +1,279 @@
+// Package anonymize replaces personal data in recorded Wolt payloads with
+// stand-ins of the same shape, so the payloads can be shared as fixtures.
+package anonymize
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Category names one kind of personal data.
+type Category string
+
+const (
+	UserIDs     Category = "user_ids"
+	Names       Category = "names"
+	Addresses   Category = "addresses"
+	Phones      Category = "phones"
+	Emails      Category = "emails"
+	Coordinates Category = "coordinates"
+)
+
+// Categories lists every category in report order.
+var Categories = []Category{UserIDs, Names, Addresses, Phones, Emails, Coordinates}
+
+// jitterDegrees bounds how far coordinates move, about 500 m at Nordic latitudes.
+const jitterDegrees = 0.005
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	digitPattern = regexp.MustCompile(`[0-9]`)
+)
+
+// keyCategories maps normalized keys (lower case, without '_' and '-') to
+// the category of their value.
+var keyCategories = map[string]Category{
+	"userid":               UserIDs,
+	"customerid":           UserIDs,
+	"consumerid":           UserIDs,
+	"accountid":            UserIDs,
+	"corporateuserid":      UserIDs,
+	"firstname":            Names,
+	"lastname":             Names,
+	"fullname":             Names,
+	"givenname":            Names,
+	"familyname":           Names,
+	"recipientname":        Names,
+	"nickname":             Names,
+	"address":              Addresses,
+	"streetaddress":        Addresses,
+	"street":               Addresses,
+	"addressline":          Addresses,
+	"addressline1":         Addresses,
+	"addressline2":         Addresses,
+	"formattedaddress":     Addresses,
+	"apartment":            Addresses,
+	"entrance":             Addresses,
+	"floor":                Addresses,
+	"doorcode":             Addresses,
+	"postcode":             Addresses,
+	"postalcode":           Addresses,
+	"zipcode":              Addresses,
+	"otheraddressdetails":  Addresses,
+	"instructions":         Addresses,
+	"deliveryinstructions": Addresses,
+	"phone":                Phones,
+	"phonenumber":          Phones,
+	"mobile":               Phones,
+	"msisdn":               Phones,
+	"email":                Emails,
+	"emailaddress":         Emails,
+	"lat":                  Coordinates,
+	"latitude":             Coordinates,
+	"lon":                  Coordinates,
+	"lng":                  Coordinates,
+	"longitude":            Coordinates,
+	"coordinates":          Coordinates,
+}
+
+// userContainers are object keys whose id and name fields belong to a person.
+var userContainers = map[string]bool{"user": true, "customer": true, "consumer": true, "recipient": true}
+
+// Anonymizer rewrites payloads. Equal inputs map to equal stand-ins within
+// one Anonymizer, so ids that link records still link after rewriting.
+type Anonymizer struct {
+	salt   string
+	counts map[Category]int
+}
+
+// New returns an Anonymizer whose stand-ins derive from salt. A random salt
+// keeps stand-ins from being reversed by hashing guessed values.
+func New(salt string) *Anonymizer {
+	return &Anonymizer{salt: salt, counts: map[Category]int{}}
+}
+
+// Counts reports how many values of each category were replaced.
+func (a *Anonymizer) Counts() map[Category]int {
+	counts := make(map[Category]int, len(Categories))
+	for _, category := range Categories {
+		counts[category] = a.counts[category]
+	}
+	return counts
+}
+
+// Payload rewrites a decoded JSON value in place and returns it. Numbers
+// should be decoded as json.Number so large ids keep their digits.
+func (a *Anonymizer) Payload(value any) any {
+	return a.walk("", "", value)
+}
+
+// URL rewrites the query values of rawURL the way Payload rewrites object
+// values under the same key, so lat and lon parameters are jittered too.
+// Paths and other parameters are kept.
+func (a *Anonymizer) URL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL
+	}
+	query := parsed.Query()
+	for key, values := range query {
+		for i, value := range values {
+			if replaced, ok := a.walk("", key, value).(string); ok {
+				values[i] = replaced
+			}
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+func (a *Anonymizer) walk(parent string, key string, value any) any {
+	category, ok := keyCategories[normalizeKey(key)]
+	if !ok && userContainers[normalizeKey(parent)] {
+		switch normalizeKey(key) {
+		case "id", "oid", "$oid":
+			category, ok = UserIDs, true
+		case "name":
+			category, ok = Names, true
+		}
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		// Children see this key as their container, so "user":{"id":...} is
+		// recognized; an id object such as "_id":{"$oid":...} keeps the
+		// container of its parent.
+		container := key
+		if normalizeKey(key) == "id" {
+			container = parent
+		}
+		for nested, child := range typed {
+			typed[nested] = a.walk(container, nested, child)
+		}
+		return typed
+	case []any:
+		for i, child := range typed {
+			if ok && category == Coordinates {
+				typed[i] = a.replace(Coordinates, child)
+				continue
+			}
+			typed[i] = a.walk(parent, key, child)
+		}
+		return typed
+	}
+	if ok {
+		return a.replace(category, value)
+	}
+	if text, isString := value.(string); isString && emailPattern.MatchString(text) {
+		a.counts[Emails]++
+		return emailPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return "user-" + a.token(match, 8) + "@example.com"
+		})
+	}
+	return value
+}
+
+func (a *Anonymizer) replace(category Category, value any) any {
+	switch typed := value.(type) {
+	case nil, bool:
+		return value
+	case string:
+		if strings.TrimSpace(typed) == "" {
+			return value
+		}
+		a.counts[category]++
+		return a.replaceString(category, typed)
+	case json.Number:
+		a.counts[category]++
+		if category == Coordinates {
+			number, err := typed.Float64()
+			if err != nil {
+				return value
+			}
+			return json.Number(strconv.FormatFloat(a.jitter(typed.String(), number), 'f', 6, 64))
+		}
+		return json.Number(a.number(typed.String()))
+	case float64:
+		a.counts[category]++
+		if category == Coordinates {
+			return a.jitter(strconv.FormatFloat(typed, 'g', -1, 64), typed)
+		}
+		number, _ := strconv.ParseFloat(a.number(strconv.FormatFloat(typed, 'f', -1, 64)), 64)
+		return number
+	}
+	return value
+}
+
+func (a *Anonymizer) replaceString(category Category, value string) string {
+	switch category {
+	case Emails:
+		return "user-" + a.token(value, 8) + "@example.com"
+	case Phones:
+		return digitPattern.ReplaceAllString(value, "0")
+	case Coordinates:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return value
+		}
+		return strconv.FormatFloat(a.jitter(value, number), 'f', 6, 64)
+	case Names:
+		return "Name " + a.token(value, 6)
+	case Addresses:
+		if digitPattern.MatchString(value) && !strings.ContainsAny(value, " ,") {
+			return a.digits(value)
+		}
+		return "Address " + a.token(value, 6)
+	default:
+		return "user-" + a.token(value, 12)
+	}
+}
+
+// token is a stable hex stand-in for value.
+func (a *Anonymizer) token(value string, length int) string {
+	sum := sha256.Sum256([]byte(a.salt + "\x00" + value))
+	return hex.EncodeToString(sum[:])[:length]
+}
+
+// digits replaces each digit of value with a stable pseudo-random digit,
+// keeping length and separators.
+func (a *Anonymizer) digits(value string) string {
+	sum := sha256.Sum256([]byte(a.salt + "\x00" + value))
+	index := 0
+	return digitPattern.ReplaceAllStringFunc(value, func(string) string {
+		digit := sum[index%len(sum)] % 10
+		index++
+		return strconv.Itoa(int(digit))
+	})
+}
+
+// number is digits for a JSON number, without a leading zero.
+func (a *Anonymizer) number(value string) string {
+	replaced := []byte(a.digits(value))
+	start := 0
+	if len(replaced) > 0 && replaced[0] == '-' {
+		start = 1
+	}
+	if len(replaced) > start+1 && replaced[start] == '0' && replaced[start+1] != '.' {
+		replaced[start] = '1'
+	}
+	return string(replaced)
+}
+
+// jitter moves a coordinate by a stable offset of up to jitterDegrees.
+func (a *Anonymizer) jitter(raw string, value float64) float64 {
+	sum := sha256.Sum256([]byte(a.salt + "\x00" + raw))
+	fraction := float64(binary.BigEndian.Uint64(sum[:8]))/float64(math.MaxUint64)*2 - 1
+	return math.Round((value+fraction*jitterDegrees)*1e6) / 1e6
+}
+
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "_", "")
+	return strings.ReplaceAll(key, "-", "")
+}
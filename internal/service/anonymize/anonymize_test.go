@@ -0,0 +1,92 @@
+package anonymize_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/service/anonymize"
+)
+
+const userPayload = `{
+	"user": {"_id": {"$oid": "5f1c2b3a4d5e6f7a8b9c0d1e"}, "name": "Jane Doe", "email": "jane@example.org", "phone_number": "+358 40 7654321"},
+	"delivery_location": {"street": "Mannerheimintie 1 A 5", "post_code": "00100", "coordinates": {"type": "Point", "coordinates": [24.9384, 60.1699]}},
+	"orders": [{"purchase_id": "p-1", "user_id": "5f1c2b3a4d5e6f7a8b9c0d1e", "venue": {"name": "Burger Place", "lat": 60.17, "lon": 24.94}}],
+	"comment": "call me at jane@example.org",
+	"count": 12345678901234567
+}`
+
+func decode(t *testing.T, payload string) any {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return value
+}
+
+func TestPayloadReplacesPersonalDataAndKeepsStructure(t *testing.T) {
+	anonymizer := anonymize.New("salt")
+	value := anonymizer.Payload(decode(t, userPayload))
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	for _, personal := range []string{"5f1c2b3a4d5e6f7a8b9c0d1e", "Jane Doe", "jane@example.org", "7654321", "Mannerheimintie", "00100", "24.9384", "60.1699"} {
+		if bytes.Contains(encoded, []byte(personal)) {
+			t.Fatalf("expected %q to be replaced, got %s", personal, encoded)
+		}
+	}
+	for _, kept := range []string{`"purchase_id":"p-1"`, `"name":"Burger Place"`, `"type":"Point"`, `"count":12345678901234567`} {
+		if !bytes.Contains(encoded, []byte(kept)) {
+			t.Fatalf("expected %s to stay, got %s", kept, encoded)
+		}
+	}
+
+	root := value.(map[string]any)
+	userID := root["user"].(map[string]any)["_id"].(map[string]any)["$oid"]
+	orderUserID := root["orders"].([]any)[0].(map[string]any)["user_id"]
+	if userID != orderUserID {
+		t.Fatalf("expected the same user id to map to the same stand-in, got %v and %v", userID, orderUserID)
+	}
+	lat, _ := root["orders"].([]any)[0].(map[string]any)["venue"].(map[string]any)["lat"].(json.Number).Float64()
+	if lat == 60.17 || lat < 60.165 || lat > 60.175 {
+		t.Fatalf("expected the latitude to move by at most 0.005, got %v", lat)
+	}
+	if phone := root["user"].(map[string]any)["phone_number"]; phone != "+000 00 0000000" {
+		t.Fatalf("expected the phone digits to be zeroed, got %v", phone)
+	}
+
+	counts := anonymizer.Counts()
+	if counts[anonymize.UserIDs] != 2 || counts[anonymize.Emails] != 2 || counts[anonymize.Coordinates] != 4 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+}
+
+func TestURLJittersCoordinateQueryValues(t *testing.T) {
+	anonymizer := anonymize.New("salt")
+	rewritten := anonymizer.URL("https://consumer-api.wolt.com/v1/pages/front?lat=60.169900&lon=24.938400&language=en")
+	if strings.Contains(rewritten, "60.169900") || strings.Contains(rewritten, "24.938400") {
+		t.Fatalf("expected the coordinates to be replaced, got %s", rewritten)
+	}
+	if !strings.HasPrefix(rewritten, "https://consumer-api.wolt.com/v1/pages/front?") || !strings.Contains(rewritten, "language=en") {
+		t.Fatalf("expected the path and other parameters to stay, got %s", rewritten)
+	}
+	if counts := anonymizer.Counts(); counts[anonymize.Coordinates] != 2 {
+		t.Fatalf("expected two coordinates replaced, got %v", counts)
+	}
+	if plain := "https://restaurant-api.wolt.com/v1/pages/search"; anonymizer.URL(plain) != plain {
+		t.Fatalf("expected a URL without a query to stay, got %s", anonymizer.URL(plain))
+	}
+}
+
+func TestPayloadStandInsDependOnSalt(t *testing.T) {
+	first := anonymize.New("one").Payload(decode(t, `{"email":"jane@example.org"}`))
+	second := anonymize.New("two").Payload(decode(t, `{"email":"jane@example.org"}`))
+	if first.(map[string]any)["email"] == second.(map[string]any)["email"] {
+		t.Fatalf("expected different salts to give different stand-ins, got %v", first)
+	}
+}
@@ -0,0 +1,146 @@
+// Package approval issues and redeems single-use approval tokens for cart and
+// checkout commands whose total exceeds a profile's approval threshold.
+package approval
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	fileName    = "approvals.json"
+	tokenPrefix = "wa_"
+)
+
+var (
+	// ErrUnknownToken is returned for tokens that were never issued or were
+	// already redeemed.
+	ErrUnknownToken = errors.New("approval token is unknown or already used")
+	// ErrExpired is returned for tokens past their expiry.
+	ErrExpired = errors.New("approval token has expired")
+	// ErrNotCovered is returned when the token was issued for another profile,
+	// a smaller amount, or another currency.
+	ErrNotCovered = errors.New("approval token does not cover this total")
+)
+
+// Approval allows one command of Profile with a total of at most Amount minor
+// units of Currency until ExpiresAt. Only a hash of the token is stored.
+type Approval struct {
+	TokenHash string    `json:"token_hash"`
+	Profile   string    `json:"profile"`
+	Amount    int       `json:"amount"`
+	Currency  string    `json:"currency"`
+	Note      string    `json:"note,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Store keeps outstanding approvals in one JSON file.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Issue records approval under a new random token and returns the token.
+// Expired approvals are dropped on the way.
+func (s *Store) Issue(approval Approval) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	token := tokenPrefix + hex.EncodeToString(raw)
+	all, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	approval.TokenHash = hashToken(token)
+	all = append(pending(all, approval.IssuedAt), approval)
+	if err := s.write(all); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Redeem consumes token for a command of profile totalling amount minor units
+// of currency. A token is removed once redeemed, so it works only once.
+func (s *Store) Redeem(token string, profile string, amount int, currency string, now time.Time) (Approval, error) {
+	all, err := s.read()
+	if err != nil {
+		return Approval{}, err
+	}
+	hash := hashToken(strings.TrimSpace(token))
+	for i, approval := range all {
+		if approval.TokenHash != hash {
+			continue
+		}
+		if !now.Before(approval.ExpiresAt) {
+			return Approval{}, fmt.Errorf("%w at %s", ErrExpired, approval.ExpiresAt.Format(time.RFC3339))
+		}
+		if approval.Profile != profile || !strings.EqualFold(approval.Currency, currency) || amount > approval.Amount {
+			return Approval{}, ErrNotCovered
+		}
+		remaining := append(all[:i:i], all[i+1:]...)
+		if err := s.write(pending(remaining, now)); err != nil {
+			return Approval{}, err
+		}
+		return approval, nil
+	}
+	return Approval{}, ErrUnknownToken
+}
+
+// pending keeps approvals that have not expired at now.
+func pending(all []Approval, now time.Time) []Approval {
+	kept := make([]Approval, 0, len(all))
+	for _, approval := range all {
+		if now.Before(approval.ExpiresAt) {
+			kept = append(kept, approval)
+		}
+	}
+	return kept
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Store) read() ([]Approval, error) {
+	payload, err := os.ReadFile(filepath.Join(s.dir, fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Approval{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read approvals: %w", err)
+	}
+	all := []Approval{}
+	if err := json.Unmarshal(payload, &all); err != nil {
+		return nil, fmt.Errorf("decode approvals: %w", err)
+	}
+	return all, nil
+}
+
+func (s *Store) write(all []Approval) error {
+	payload, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode approvals: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create approvals directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, fileName), append(payload, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write approvals: %w", err)
+	}
+	return nil
+}
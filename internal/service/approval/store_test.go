@@ -0,0 +1,45 @@
+package approval_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/approval"
+)
+
+func TestRedeemChecksCoverageAndUsesTokenOnce(t *testing.T) {
+	dir := t.TempDir()
+	store := approval.NewStore(dir)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token, err := store.Issue(approval.Approval{Profile: "default", Amount: 6000, Currency: "EUR", IssuedAt: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	saved, err := os.ReadFile(filepath.Join(dir, "approvals.json"))
+	if err != nil || strings.Contains(string(saved), token) {
+		t.Fatalf("expected only a hash of the token on disk, got %s (err=%v)", saved, err)
+	}
+
+	for name, attempt := range map[string]func() error{
+		"other profile":  func() error { _, err := store.Redeem(token, "work", 5000, "EUR", now); return err },
+		"higher total":   func() error { _, err := store.Redeem(token, "default", 6001, "EUR", now); return err },
+		"other currency": func() error { _, err := store.Redeem(token, "default", 5000, "SEK", now); return err },
+	} {
+		if err := attempt(); !errors.Is(err, approval.ErrNotCovered) {
+			t.Fatalf("%s: expected ErrNotCovered, got %v", name, err)
+		}
+	}
+	if _, err := store.Redeem(token, "default", 6000, "eur", now.Add(time.Hour)); !errors.Is(err, approval.ErrExpired) {
+		t.Fatalf("expected ErrExpired at the expiry, got %v", err)
+	}
+	if _, err := store.Redeem(token, "default", 6000, "EUR", now); err != nil {
+		t.Fatalf("expected the token to cover 6000 EUR, got %v", err)
+	}
+	if _, err := store.Redeem(token, "default", 6000, "EUR", now); !errors.Is(err, approval.ErrUnknownToken) {
+		t.Fatalf("expected a redeemed token to be unknown, got %v", err)
+	}
+}
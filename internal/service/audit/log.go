@@ -0,0 +1,121 @@
+// Package audit keeps an append-only local log of checkout and order actions
+// with the expense metadata Wolt does not store.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is one logged action.
+type Entry struct {
+	RecordedAt  time.Time `json:"recorded_at"`
+	Command     string    `json:"command"`
+	PurchaseID  string    `json:"purchase_id,omitempty"`
+	BasketID    string    `json:"basket_id,omitempty"`
+	VenueID     string    `json:"venue_id,omitempty"`
+	ExpenseCode string    `json:"expense_code,omitempty"`
+	CostCenter  string    `json:"cost_center,omitempty"`
+}
+
+// ExpenseTags is the expense metadata attached to one purchase.
+type ExpenseTags struct {
+	ExpenseCode string
+	CostCenter  string
+}
+
+// Log is a JSON Lines file with one Entry per line.
+type Log struct {
+	path string
+}
+
+// NewLog creates a log stored at path.
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the log file path.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append adds entry to the end of the log.
+func (l *Log) Append(entry Entry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("create audit directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	if _, err := file.Write(append(payload, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return file.Close()
+}
+
+// Entries reads the log in write order. A missing file is an empty log.
+func (l *Log) Entries() ([]Entry, error) {
+	file, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("decode audit log %s line %d: %w", l.path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// PurchaseTags returns the expense tags per purchase ID. Later entries replace
+// earlier values field by field, so re-tagging only the cost center keeps the
+// expense code.
+func (l *Log) PurchaseTags() (map[string]ExpenseTags, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]ExpenseTags{}
+	for _, entry := range entries {
+		if entry.PurchaseID == "" {
+			continue
+		}
+		current := tags[entry.PurchaseID]
+		if entry.ExpenseCode != "" {
+			current.ExpenseCode = entry.ExpenseCode
+		}
+		if entry.CostCenter != "" {
+			current.CostCenter = entry.CostCenter
+		}
+		tags[entry.PurchaseID] = current
+	}
+	return tags, nil
+}
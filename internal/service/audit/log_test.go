@@ -0,0 +1,52 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/audit"
+)
+
+func TestPurchaseTagsMergeLaterEntries(t *testing.T) {
+	log := audit.NewLog(filepath.Join(t.TempDir(), "nested", "audit.jsonl"))
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []audit.Entry{
+		{RecordedAt: now, Command: "checkout preview", BasketID: "basket-1", ExpenseCode: "TRAVEL"},
+		{RecordedAt: now, Command: "profile orders show", PurchaseID: "order-1", ExpenseCode: "MEALS", CostCenter: "CC-1"},
+		{RecordedAt: now, Command: "profile orders show", PurchaseID: "order-1", CostCenter: "CC-2"},
+	}
+	for _, entry := range entries {
+		if err := log.Append(entry); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	read, err := log.Entries()
+	if err != nil || len(read) != 3 || read[0].BasketID != "basket-1" {
+		t.Fatalf("unexpected entries %#v err=%v", read, err)
+	}
+	tags, err := log.PurchaseTags()
+	if err != nil {
+		t.Fatalf("purchase tags: %v", err)
+	}
+	if len(tags) != 1 || tags["order-1"] != (audit.ExpenseTags{ExpenseCode: "MEALS", CostCenter: "CC-2"}) {
+		t.Fatalf("unexpected tags %#v", tags)
+	}
+}
+
+func TestEntriesReportsCorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := os.WriteFile(path, []byte("{\"command\":\"x\"}\nnot json\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := audit.NewLog(path).Entries(); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected line 2 decode error, got %v", err)
+	}
+	missing, err := audit.NewLog(filepath.Join(t.TempDir(), "missing.jsonl")).Entries()
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("expected empty log for a missing file, got %#v err=%v", missing, err)
+	}
+}
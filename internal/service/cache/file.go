@@ -0,0 +1,102 @@
+// Package cache persists small JSON values between CLI runs.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// File is a JSON key/value cache stored in one file. Changes stay in memory
+// until Save.
+type File struct {
+	path    string
+	entries map[string]entry
+	dirty   bool
+}
+
+// Open loads the cache at path. A missing file is an empty cache; an unreadable
+// or corrupt one is an error so callers can decide to start fresh.
+func Open(path string) (*File, error) {
+	file := &File{path: path, entries: map[string]entry{}}
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, fmt.Errorf("read cache: %w", err)
+	}
+	if err := json.Unmarshal(payload, &file.entries); err != nil {
+		file.entries = map[string]entry{}
+		return file, fmt.Errorf("decode cache %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Path returns the cache file path.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Get decodes the value stored under key into out. It reports false when the
+// key is missing, older than maxAge (0 means no limit), or does not decode.
+func (f *File) Get(key string, maxAge time.Duration, now time.Time, out any) bool {
+	stored, ok := f.entries[key]
+	if !ok {
+		return false
+	}
+	if maxAge > 0 && now.Sub(stored.StoredAt) > maxAge {
+		return false
+	}
+	return json.Unmarshal(stored.Value, out) == nil
+}
+
+// Put stores value under key.
+func (f *File) Put(key string, value any, now time.Time) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encode cache value: %w", err)
+	}
+	f.entries[key] = entry{StoredAt: now.UTC(), Value: encoded}
+	f.dirty = true
+	return nil
+}
+
+// Save writes pending changes, replacing the file atomically.
+func (f *File) Save() error {
+	if !f.dirty {
+		return nil
+	}
+	payload, err := json.MarshalIndent(f.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(append(payload, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	f.dirty = false
+	return nil
+}
@@ -0,0 +1,58 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/cache"
+)
+
+func TestFileRoundTripAndExpiry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "lines.json")
+	file, err := cache.Open(path)
+	if err != nil {
+		t.Fatalf("open missing cache: %v", err)
+	}
+	stored := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := file.Put("venue-1/item-1", map[string]string{"category_id": "cat-1"}, stored); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := file.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	reopened, err := cache.Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	var value map[string]string
+	if !reopened.Get("venue-1/item-1", time.Hour, stored.Add(30*time.Minute), &value) || value["category_id"] != "cat-1" {
+		t.Fatalf("expected fresh hit, got %v", value)
+	}
+	if reopened.Get("venue-1/item-1", time.Hour, stored.Add(2*time.Hour), &value) {
+		t.Fatal("expected expired entry to miss")
+	}
+	if !reopened.Get("venue-1/item-1", 0, stored.Add(1000*time.Hour), &value) {
+		t.Fatal("expected maxAge 0 to disable expiry")
+	}
+	if reopened.Get("venue-1/item-2", 0, stored, &value) {
+		t.Fatal("expected missing key to miss")
+	}
+}
+
+func TestOpenCorruptFileStartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lines.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := cache.Open(path)
+	if err == nil {
+		t.Fatal("expected decode error")
+	}
+	var value any
+	if file == nil || file.Get("anything", 0, time.Now(), &value) {
+		t.Fatal("expected an empty usable cache")
+	}
+}
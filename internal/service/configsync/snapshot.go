@@ -0,0 +1,126 @@
+// Package configsync merges the shareable parts of a wolt configuration with
+// a copy kept in a shared location.
+package configsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FormatVersion is the version of the shared file layout.
+const FormatVersion = 1
+
+// Snapshot maps entry keys, such as "profile/home", to their JSON values.
+type Snapshot map[string]json.RawMessage
+
+type sharedFile struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Entries   Snapshot  `json:"entries"`
+}
+
+// Read loads a snapshot from path. A missing file is an empty snapshot.
+func Read(path string) (Snapshot, error) {
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var file sharedFile
+	if err := json.Unmarshal(payload, &file); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	if file.Version > FormatVersion {
+		return nil, fmt.Errorf("%s has format version %d; update wolt to read it", path, file.Version)
+	}
+	if file.Entries == nil {
+		file.Entries = Snapshot{}
+	}
+	return file.Entries, nil
+}
+
+// Write stores snapshot at path, creating parent directories.
+func Write(path string, snapshot Snapshot, now time.Time) error {
+	payload, err := json.MarshalIndent(sharedFile{Version: FormatVersion, UpdatedAt: now.UTC(), Entries: snapshot}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(payload, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Result is the outcome of a three-way merge.
+type Result struct {
+	Merged Snapshot
+	// Pulled lists keys whose local value changes, Pushed keys whose shared
+	// value changes, and Conflicts keys both sides changed since the base;
+	// conflicts keep the local value.
+	Pulled    []string
+	Pushed    []string
+	Conflicts []string
+}
+
+// Merge combines local and remote using base, the snapshot of the previous
+// sync. A side that left an entry as it was in base takes the other side's
+// value, including a removal; entries changed on both sides keep local.
+func Merge(base Snapshot, local Snapshot, remote Snapshot) Result {
+	result := Result{Merged: Snapshot{}, Pulled: []string{}, Pushed: []string{}, Conflicts: []string{}}
+	keys := map[string]struct{}{}
+	for _, snapshot := range []Snapshot{base, local, remote} {
+		for key := range snapshot {
+			keys[key] = struct{}{}
+		}
+	}
+	ordered := make([]string, 0, len(keys))
+	for key := range keys {
+		ordered = append(ordered, key)
+	}
+	sort.Strings(ordered)
+
+	for _, key := range ordered {
+		baseValue, localValue, remoteValue := base[key], local[key], remote[key]
+		merged := localValue
+		switch {
+		case sameValue(localValue, remoteValue):
+		case sameValue(localValue, baseValue):
+			merged = remoteValue
+		case sameValue(remoteValue, baseValue):
+		default:
+			result.Conflicts = append(result.Conflicts, key)
+		}
+		if merged != nil {
+			result.Merged[key] = merged
+		}
+		if !sameValue(merged, localValue) {
+			result.Pulled = append(result.Pulled, key)
+		}
+		if !sameValue(merged, remoteValue) {
+			result.Pushed = append(result.Pushed, key)
+		}
+	}
+	return result
+}
+
+func sameValue(left json.RawMessage, right json.RawMessage) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	var leftBuf, rightBuf bytes.Buffer
+	if json.Compact(&leftBuf, left) != nil || json.Compact(&rightBuf, right) != nil {
+		return bytes.Equal(left, right)
+	}
+	return bytes.Equal(leftBuf.Bytes(), rightBuf.Bytes())
+}
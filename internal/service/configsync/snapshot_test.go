@@ -0,0 +1,57 @@
+package configsync_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/configsync"
+)
+
+func TestMergeTakesOneSidedChangesAndKeepsLocalOnConflict(t *testing.T) {
+	raw := func(value string) json.RawMessage { return json.RawMessage(value) }
+	base := configsync.Snapshot{"a": raw(`1`), "b": raw(`1`), "c": raw(`1`), "d": raw(`1`)}
+	local := configsync.Snapshot{"a": raw(`2`), "b": raw(`1`), "c": raw(`3`), "e": raw(`1`)}
+	remote := configsync.Snapshot{"a": raw(`1`), "b": raw(`2`), "c": raw(`4`), "d": raw(`1`), "f": raw(`1`)}
+
+	result := configsync.Merge(base, local, remote)
+	want := configsync.Snapshot{"a": raw(`2`), "b": raw(`2`), "c": raw(`3`), "e": raw(`1`), "f": raw(`1`)}
+	if !reflect.DeepEqual(result.Merged, want) {
+		t.Fatalf("unexpected merge %s", mustMarshal(t, result.Merged))
+	}
+	if !reflect.DeepEqual(result.Conflicts, []string{"c"}) {
+		t.Fatalf("expected c to conflict, got %v", result.Conflicts)
+	}
+	if !reflect.DeepEqual(result.Pulled, []string{"b", "f"}) || !reflect.DeepEqual(result.Pushed, []string{"a", "c", "d", "e"}) {
+		t.Fatalf("unexpected pulled %v / pushed %v", result.Pulled, result.Pushed)
+	}
+}
+
+func TestWriteThenReadRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared", "wolt-shared.json")
+	if snapshot, err := configsync.Read(path); err != nil || len(snapshot) != 0 {
+		t.Fatalf("expected a missing file to read as empty, got %v err=%v", snapshot, err)
+	}
+	snapshot := configsync.Snapshot{"profile/home": json.RawMessage(`{"name":"home"}`)}
+	if err := configsync.Write(path, snapshot, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	read, err := configsync.Read(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if merged := configsync.Merge(snapshot, snapshot, read); len(merged.Pulled) != 0 || len(merged.Pushed) != 0 {
+		t.Fatalf("expected the written snapshot to read back unchanged, got %s", mustMarshal(t, read))
+	}
+}
+
+func mustMarshal(t *testing.T, value any) string {
+	t.Helper()
+	payload, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(payload)
+}
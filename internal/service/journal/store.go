@@ -0,0 +1,183 @@
+// Package journal keeps personal venue notes and order ratings per profile.
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	notesFileName   = "notes.json"
+	ratingsFileName = "ratings.json"
+)
+
+// Note is a personal note and tags for one venue.
+type Note struct {
+	Text      string    `json:"text,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Empty reports whether the note has neither text nor tags.
+func (n Note) Empty() bool {
+	return strings.TrimSpace(n.Text) == "" && len(n.Tags) == 0
+}
+
+// Rating is a personal score for one order.
+type Rating struct {
+	PurchaseID string    `json:"purchase_id"`
+	VenueID    string    `json:"venue_id,omitempty"`
+	VenueName  string    `json:"venue_name,omitempty"`
+	Score      int       `json:"score"`
+	Note       string    `json:"note,omitempty"`
+	RatedAt    time.Time `json:"rated_at"`
+}
+
+// Store reads and writes journal files in one directory. Notes are kept in a
+// single JSON file keyed by profile name, then by venue slug; ratings likewise,
+// keyed by profile name, then by purchase id.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Notes returns the notes of profile keyed by lower-case venue slug.
+func (s *Store) Notes(profile string) (map[string]Note, error) {
+	all, err := s.readNotes()
+	if err != nil {
+		return nil, err
+	}
+	notes := all[profile]
+	if notes == nil {
+		notes = map[string]Note{}
+	}
+	return notes, nil
+}
+
+// SetNote stores note for the venue slug in profile, replacing any previous
+// one. An empty note removes the entry.
+func (s *Store) SetNote(profile string, slug string, note Note) error {
+	all, err := s.readNotes()
+	if err != nil {
+		return err
+	}
+	key := NoteKey(slug)
+	if all[profile] == nil {
+		all[profile] = map[string]Note{}
+	}
+	if note.Empty() {
+		delete(all[profile], key)
+		if len(all[profile]) == 0 {
+			delete(all, profile)
+		}
+	} else {
+		all[profile][key] = note
+	}
+	return s.write(notesFileName, "notes", all)
+}
+
+// AllNotes returns every profile's notes keyed by profile name, then by venue
+// slug.
+func (s *Store) AllNotes() (map[string]map[string]Note, error) {
+	return s.readNotes()
+}
+
+// ReplaceNotes overwrites all notes with all; empty notes are dropped.
+func (s *Store) ReplaceNotes(all map[string]map[string]Note) error {
+	kept := map[string]map[string]Note{}
+	for profile, notes := range all {
+		for slug, note := range notes {
+			if note.Empty() {
+				continue
+			}
+			if kept[profile] == nil {
+				kept[profile] = map[string]Note{}
+			}
+			kept[profile][NoteKey(slug)] = note
+		}
+	}
+	return s.write(notesFileName, "notes", kept)
+}
+
+// Ratings returns the ratings of profile keyed by purchase id.
+func (s *Store) Ratings(profile string) (map[string]Rating, error) {
+	all := map[string]map[string]Rating{}
+	if err := s.read(ratingsFileName, "ratings", &all); err != nil {
+		return nil, err
+	}
+	ratings := all[profile]
+	if ratings == nil {
+		ratings = map[string]Rating{}
+	}
+	return ratings, nil
+}
+
+// SetRating stores rating in profile, replacing an earlier rating of the same
+// purchase.
+func (s *Store) SetRating(profile string, rating Rating) error {
+	all := map[string]map[string]Rating{}
+	if err := s.read(ratingsFileName, "ratings", &all); err != nil {
+		return err
+	}
+	if all[profile] == nil {
+		all[profile] = map[string]Rating{}
+	}
+	all[profile][strings.TrimSpace(rating.PurchaseID)] = rating
+	return s.write(ratingsFileName, "ratings", all)
+}
+
+// NoteKey normalizes a venue slug for note lookups.
+func NoteKey(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
+
+func (s *Store) readNotes() (map[string]map[string]Note, error) {
+	all := map[string]map[string]Note{}
+	if err := s.read(notesFileName, "notes", &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// read decodes a journal file into out; a missing file leaves out untouched.
+func (s *Store) read(name string, label string, out any) error {
+	payload, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", label, err)
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("decode %s: %w", label, err)
+	}
+	return nil
+}
+
+func (s *Store) write(name string, label string, value any) error {
+	payload, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", label, err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create journal directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, name), append(payload, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", label, err)
+	}
+	return nil
+}
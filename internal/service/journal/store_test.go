@@ -0,0 +1,80 @@
+package journal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/journal"
+)
+
+func TestSetNoteKeepsProfilesApartAndRemovesEmptyNotes(t *testing.T) {
+	store := journal.NewStore(t.TempDir())
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := store.SetNote("default", "Ramen-Place", journal.Note{Text: "great tonkotsu", Tags: []string{"ramen"}, UpdatedAt: at}); err != nil {
+		t.Fatalf("set note: %v", err)
+	}
+	if err := store.SetNote("work", "ramen-place", journal.Note{Text: "slow at lunch", UpdatedAt: at}); err != nil {
+		t.Fatalf("set note: %v", err)
+	}
+
+	notes, err := store.Notes("default")
+	if err != nil || len(notes) != 1 {
+		t.Fatalf("expected one default note, got %#v err=%v", notes, err)
+	}
+	if note := notes["ramen-place"]; note.Text != "great tonkotsu" || len(note.Tags) != 1 || !note.UpdatedAt.Equal(at) {
+		t.Fatalf("unexpected note %#v", note)
+	}
+
+	if err := store.SetNote("default", "ramen-place", journal.Note{}); err != nil {
+		t.Fatalf("clear note: %v", err)
+	}
+	if notes, _ := store.Notes("default"); len(notes) != 0 {
+		t.Fatalf("expected the empty note to remove the entry, got %#v", notes)
+	}
+	if notes, _ := store.Notes("work"); notes["ramen-place"].Text != "slow at lunch" {
+		t.Fatalf("expected the work profile note to stay, got %#v", notes)
+	}
+}
+
+func TestSetRatingReplacesEarlierScoreForPurchase(t *testing.T) {
+	store := journal.NewStore(t.TempDir())
+	at := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	if err := store.SetRating("default", journal.Rating{PurchaseID: "p-1", VenueID: "venue-1", Score: 6, RatedAt: at}); err != nil {
+		t.Fatalf("set rating: %v", err)
+	}
+	if err := store.SetRating("default", journal.Rating{PurchaseID: "p-1", VenueID: "venue-1", Score: 9, Note: "fast, hot", RatedAt: at}); err != nil {
+		t.Fatalf("set rating: %v", err)
+	}
+
+	ratings, err := store.Ratings("default")
+	if err != nil || len(ratings) != 1 {
+		t.Fatalf("expected one rating, got %#v err=%v", ratings, err)
+	}
+	if rating := ratings["p-1"]; rating.Score != 9 || rating.Note != "fast, hot" {
+		t.Fatalf("expected the second rating to replace the first, got %#v", rating)
+	}
+	if ratings, _ := store.Ratings("work"); len(ratings) != 0 {
+		t.Fatalf("expected no ratings for another profile, got %#v", ratings)
+	}
+}
+
+func TestReplaceNotesDropsEmptyNotesAndNormalizesSlugs(t *testing.T) {
+	store := journal.NewStore(t.TempDir())
+	if err := store.SetNote("default", "old-place", journal.Note{Text: "gone"}); err != nil {
+		t.Fatalf("set note: %v", err)
+	}
+	err := store.ReplaceNotes(map[string]map[string]journal.Note{
+		"default": {"Ramen-Place": {Text: "great tonkotsu"}, "empty-place": {}},
+		"work":    {"empty-place": {}},
+	})
+	if err != nil {
+		t.Fatalf("replace notes: %v", err)
+	}
+	all, err := store.AllNotes()
+	if err != nil {
+		t.Fatalf("all notes: %v", err)
+	}
+	if len(all) != 1 || len(all["default"]) != 1 || all["default"]["ramen-place"].Text != "great tonkotsu" {
+		t.Fatalf("expected only the non-empty note under its normalized slug, got %#v", all)
+	}
+}
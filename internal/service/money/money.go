@@ -0,0 +1,257 @@
+// Package money formats minor-unit amounts for display.
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// style describes how one language writes amounts.
+type style struct {
+	decimal     string
+	group       string
+	symbolFirst bool
+}
+
+var languageStyles = map[string]style{
+	"en": {decimal: ".", group: ",", symbolFirst: true},
+	"ja": {decimal: ".", group: ",", symbolFirst: true},
+	"he": {decimal: ".", group: ",", symbolFirst: true},
+	"de": {decimal: ",", group: ".", symbolFirst: false},
+	"da": {decimal: ",", group: ".", symbolFirst: false},
+	"el": {decimal: ",", group: ".", symbolFirst: false},
+	"hr": {decimal: ",", group: ".", symbolFirst: false},
+	"sl": {decimal: ",", group: ".", symbolFirst: false},
+	"sr": {decimal: ",", group: ".", symbolFirst: false},
+	"ro": {decimal: ",", group: ".", symbolFirst: false},
+	"fi": {decimal: ",", group: " ", symbolFirst: false},
+	"sv": {decimal: ",", group: " ", symbolFirst: false},
+	"nb": {decimal: ",", group: " ", symbolFirst: false},
+	"no": {decimal: ",", group: " ", symbolFirst: false},
+	"et": {decimal: ",", group: " ", symbolFirst: false},
+	"lv": {decimal: ",", group: " ", symbolFirst: false},
+	"lt": {decimal: ",", group: " ", symbolFirst: false},
+	"pl": {decimal: ",", group: " ", symbolFirst: false},
+	"cs": {decimal: ",", group: " ", symbolFirst: false},
+	"sk": {decimal: ",", group: " ", symbolFirst: false},
+	"hu": {decimal: ",", group: " ", symbolFirst: false},
+	"fr": {decimal: ",", group: " ", symbolFirst: false},
+	"ka": {decimal: ",", group: " ", symbolFirst: false},
+	"is": {decimal: ",", group: ".", symbolFirst: false},
+}
+
+// globalSymbols are used in every locale.
+var globalSymbols = map[string]string{
+	"EUR": "€",
+	"USD": "$",
+	"GBP": "£",
+	"JPY": "¥",
+	"ILS": "₪",
+	"GEL": "₾",
+}
+
+// localSymbols are only used in the currency's own languages; elsewhere the
+// ISO code is clearer.
+var localSymbols = map[string]map[string]string{
+	"PLN": {"pl": "zł"},
+	"SEK": {"sv": "kr"},
+	"NOK": {"nb": "kr", "no": "kr"},
+	"DKK": {"da": "kr."},
+	"ISK": {"is": "kr"},
+	"CZK": {"cs": "Kč"},
+	"HUF": {"hu": "Ft"},
+	"RON": {"ro": "lei"},
+	"RSD": {"sr": "din"},
+}
+
+// exponents lists ISO 4217 currencies whose minor unit is not 1/100.
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+var (
+	defaultLocaleMu sync.RWMutex
+	defaultLocale   string
+)
+
+// SetDefaultLocale sets the locale used by callers that format amounts without
+// one of their own. An empty locale keeps each caller's legacy format.
+func SetDefaultLocale(locale string) {
+	defaultLocaleMu.Lock()
+	defaultLocale = strings.TrimSpace(locale)
+	defaultLocaleMu.Unlock()
+}
+
+// DefaultLocale returns the locale set with SetDefaultLocale.
+func DefaultLocale() string {
+	defaultLocaleMu.RLock()
+	defer defaultLocaleMu.RUnlock()
+	return defaultLocale
+}
+
+// Exponent returns the number of minor-unit digits for currency: 0 for JPY,
+// 3 for KWD, and 2 for everything else, including unknown codes.
+func Exponent(currency string) int {
+	if exponent, ok := exponents[strings.ToUpper(strings.TrimSpace(currency))]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Decimal renders a minor-unit amount as a plain major-unit number with a
+// "." separator, for example 1234 JPY as "1234" and 1234 EUR as "12.34".
+func Decimal(minor int, currency string) string {
+	whole, fraction, negative := split(minor, Exponent(currency))
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+// ParseMajor converts a non-negative major-unit amount such as "7.50",
+// "9,90", or "1 234,50" to minor units of currency. Either "." or "," may be
+// the decimal separator; when both appear the last one is, and the other
+// groups digits. Extra fraction digits are rounded half up.
+func ParseMajor(text string, currency string) (int, error) {
+	cleaned := strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(strings.TrimSpace(text))
+	decimal := ""
+	lastDot, lastComma := strings.LastIndex(cleaned, "."), strings.LastIndex(cleaned, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal = "."
+		if lastComma > lastDot {
+			decimal = ","
+		}
+	case lastDot >= 0 && strings.Count(cleaned, ".") == 1:
+		decimal = "."
+	case lastComma >= 0 && strings.Count(cleaned, ",") == 1:
+		decimal = ","
+	}
+	if decimal != "" && strings.Count(cleaned, decimal) != 1 {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+	whole, fraction := cleaned, ""
+	if decimal != "" {
+		index := strings.LastIndex(cleaned, decimal)
+		whole, fraction = cleaned[:index], cleaned[index+1:]
+	}
+	whole = strings.NewReplacer(".", "", ",", "").Replace(whole)
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || !isDigits(fraction) || !strings.ContainsAny(cleaned, "0123456789") {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+	exponent := Exponent(currency)
+	roundUp := len(fraction) > exponent && fraction[exponent] >= '5'
+	fraction = (fraction + strings.Repeat("0", exponent))[:exponent]
+	minor, err := strconv.Atoi(whole + fraction)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+	if roundUp {
+		minor++
+	}
+	return minor, nil
+}
+
+func isDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// split divides the absolute amount into whole and zero-padded fraction digits.
+func split(minor int, exponent int) (string, string, bool) {
+	negative := minor < 0
+	if negative {
+		minor = -minor
+	}
+	if exponent == 0 {
+		return fmt.Sprintf("%d", minor), "", negative
+	}
+	unit := 1
+	for range exponent {
+		unit *= 10
+	}
+	return fmt.Sprintf("%d", minor/unit), fmt.Sprintf("%0*d", exponent, minor%unit), negative
+}
+
+// Format renders a minor-unit amount for a BCP-47 locale such as fi-FI:
+// symbol placement, decimal separator, and digit grouping follow the locale's
+// language, falling back to English conventions.
+func Format(minor int, currency string, locale string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	language := localeLanguage(locale)
+	st, ok := languageStyles[language]
+	if !ok {
+		st = languageStyles["en"]
+	}
+
+	whole, fraction, negative := split(minor, Exponent(currency))
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	number := groupDigits(whole, st.group)
+	if fraction != "" {
+		number += st.decimal + fraction
+	}
+
+	symbol, isSymbol := currencySymbol(currency, language)
+	switch {
+	case symbol == "":
+		return sign + number
+	case st.symbolFirst && isSymbol:
+		return sign + symbol + number
+	case st.symbolFirst:
+		return sign + symbol + " " + number
+	default:
+		return sign + number + " " + symbol
+	}
+}
+
+func currencySymbol(currency string, language string) (string, bool) {
+	if symbol, ok := globalSymbols[currency]; ok {
+		return symbol, true
+	}
+	if symbol, ok := localSymbols[currency][language]; ok {
+		return symbol, true
+	}
+	return currency, false
+}
+
+func localeLanguage(locale string) string {
+	locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+	language, _, _ := strings.Cut(locale, "-")
+	return strings.ToLower(language)
+}
+
+func groupDigits(digits string, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
+)
+
+func TestFormatFollowsLocale(t *testing.T) {
+	cases := []struct {
+		minor    int
+		currency string
+		locale   string
+		expected string
+	}{
+		{123456, "EUR", "en-FI", "€1,234.56"},
+		{123456, "EUR", "fi-FI", "1 234,56 €"},
+		{123456, "EUR", "de-DE", "1.234,56 €"},
+		{1000, "PLN", "pl-PL", "10,00 zł"},
+		{1000, "PLN", "en", "PLN 10.00"},
+		{1000, "SEK", "fi_FI", "10,00 SEK"},
+		{-250, "USD", "", "-$2.50"},
+		{599, "", "fi-FI", "5,99"},
+		{1500, "JPY", "en", "¥1,500"},
+		{1234, "KWD", "en", "KWD 1.234"},
+		{250000, "ISK", "is-IS", "250.000 kr"},
+	}
+	for _, tc := range cases {
+		if got := money.Format(tc.minor, tc.currency, tc.locale); got != tc.expected {
+			t.Fatalf("Format(%d, %q, %q) = %q, want %q", tc.minor, tc.currency, tc.locale, got, tc.expected)
+		}
+	}
+}
+
+func TestDecimalUsesCurrencyExponent(t *testing.T) {
+	cases := []struct {
+		minor    int
+		currency string
+		expected string
+	}{
+		{1234, "EUR", "12.34"},
+		{5, "eur", "0.05"},
+		{1234, "JPY", "1234"},
+		{1234, "KWD", "1.234"},
+		{-7, "BHD", "-0.007"},
+		{1234, "", "12.34"},
+	}
+	for _, tc := range cases {
+		if got := money.Decimal(tc.minor, tc.currency); got != tc.expected {
+			t.Fatalf("Decimal(%d, %q) = %q, want %q", tc.minor, tc.currency, got, tc.expected)
+		}
+	}
+}
+
+func TestParseMajorAcceptsBothSeparators(t *testing.T) {
+	cases := []struct {
+		text     string
+		currency string
+		expected int
+	}{
+		{"7.50", "EUR", 750},
+		{"9,90", "EUR", 990},
+		{"7", "EUR", 700},
+		{"1 234,50", "EUR", 123450},
+		{"1,234.50", "EUR", 123450},
+		{"1.234,5", "EUR", 123450},
+		{".5", "EUR", 50},
+		{"2.999", "EUR", 300},
+		{"1500", "JPY", 1500},
+		{"7,5", "JPY", 8},
+		{"1.234", "KWD", 1234},
+	}
+	for _, tc := range cases {
+		got, err := money.ParseMajor(tc.text, tc.currency)
+		if err != nil || got != tc.expected {
+			t.Fatalf("ParseMajor(%q, %q) = %d, %v, want %d", tc.text, tc.currency, got, err, tc.expected)
+		}
+	}
+	for _, text := range []string{"", "abc", "-1.00", "1.2.3,4,5", ","} {
+		if _, err := money.ParseMajor(text, "EUR"); err == nil {
+			t.Fatalf("ParseMajor(%q) expected an error", text)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package observability
+
+import (
+	"strings"
+)
+
+// VenueCarousel names a highlighted item carousel on a venue page.
+type VenueCarousel string
+
+const (
+	// VenueCarouselPopular is the "popular items" / "most ordered" carousel.
+	VenueCarouselPopular VenueCarousel = "popular"
+	// VenueCarouselRecommended is the personalised "recommended for you" carousel.
+	VenueCarouselRecommended VenueCarousel = "recommended"
+)
+
+// venueCarouselKeywords lists fragments matched against a section's id, slug, name,
+// title or template to recognise each carousel.
+var venueCarouselKeywords = map[VenueCarousel][]string{
+	VenueCarouselPopular:     {"popular", "most_ordered", "most ordered", "bestseller", "best_seller", "top_sellers"},
+	VenueCarouselRecommended: {"recommended", "recommendation", "for_you", "for you", "personalized", "personalised"},
+}
+
+var venueCarouselNameKeys = []string{"id", "slug", "name", "title", "template", "type", "section_type", "carousel_type"}
+
+// BuildVenueCarousel returns the items of one venue page carousel in carousel order,
+// using the same row shape as BuildVenueMenu. Carousels that only list item IDs
+// are resolved against the menu items found in the other payloads.
+func BuildVenueCarousel(
+	venueID string,
+	payloads []map[string]any,
+	carousel VenueCarousel,
+	includeOptions bool,
+	limit *int,
+) (map[string]any, []string) {
+	section := findVenueCarouselSection(payloads, carousel)
+	data := map[string]any{
+		"venue_id": venueID,
+		"carousel": string(carousel),
+		"title":    nil,
+		"items":    []map[string]any{},
+	}
+	if section == nil {
+		return data, []string{"venue page has no " + string(carousel) + " carousel"}
+	}
+	data["title"] = emptyToNil(strings.TrimSpace(firstNonEmptyString(section, "title", "name")))
+
+	menu, _ := BuildVenueMenu(venueID, append([]map[string]any{section}, payloads...), "", includeOptions, nil)
+	data["wolt_plus"] = menu["wolt_plus"]
+	rowsByID := map[string]map[string]any{}
+	menuRows, _ := menu["items"].([]map[string]any)
+	for _, row := range menuRows {
+		rowsByID[stringFromAny(row["item_id"])] = row
+	}
+
+	rows := []map[string]any{}
+	missing := 0
+	for _, itemID := range venueCarouselItemIDs(section) {
+		row, ok := rowsByID[itemID]
+		if !ok {
+			missing++
+			continue
+		}
+		rows = append(rows, row)
+	}
+	rows = limitSlice(rows, limit)
+	data["items"] = rows
+
+	warnings := []string{}
+	if missing > 0 {
+		warnings = append(warnings, "some carousel items were not found in the venue menu")
+	}
+	if len(rows) == 0 {
+		warnings = append(warnings, "venue "+string(carousel)+" carousel is empty")
+	}
+	return data, warnings
+}
+
+func findVenueCarouselSection(payloads []map[string]any, carousel VenueCarousel) map[string]any {
+	keywords := venueCarouselKeywords[carousel]
+	for _, payload := range payloads {
+		for _, obj := range walkObjects(payload) {
+			if toSlice(obj["items"]) == nil && toSlice(obj["item_ids"]) == nil {
+				continue
+			}
+			for _, key := range venueCarouselNameKeys {
+				value := strings.ToLower(strings.TrimSpace(stringFromAny(obj[key])))
+				if value == "" {
+					continue
+				}
+				for _, keyword := range keywords {
+					if strings.Contains(value, keyword) {
+						return obj
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func venueCarouselItemIDs(section map[string]any) []string {
+	ids := []string{}
+	seen := map[string]struct{}{}
+	add := func(itemID string) {
+		itemID = strings.TrimSpace(itemID)
+		if itemID == "" {
+			return
+		}
+		if _, ok := seen[itemID]; ok {
+			return
+		}
+		seen[itemID] = struct{}{}
+		ids = append(ids, itemID)
+	}
+	for _, item := range toSlice(section["items"]) {
+		if typed := toMap(item); typed != nil {
+			add(stringFromAny(coalesce(typed["item_id"], typed["id"])))
+		} else {
+			add(stringFromAny(item))
+		}
+	}
+	for _, itemID := range toSlice(section["item_ids"]) {
+		add(stringFromAny(itemID))
+	}
+	return ids
+}
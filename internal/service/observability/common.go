@@ -1,15 +1,17 @@
 package observability
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
+	"github.com/mekedron/wolt-cli/internal/service/money"
 )
 
 var slugPattern = regexp.MustCompile(`[^a-zA-Z0-9]+`)
 
+var venueCityPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 func slugify(text string) string {
 	normalized := slugPattern.ReplaceAllString(strings.ToLower(text), "-")
 	normalized = strings.Trim(normalized, "-")
@@ -23,7 +25,11 @@ func formatAmount(amount *int, currency string) *string {
 	if amount == nil || strings.TrimSpace(currency) == "" {
 		return nil
 	}
-	v := fmt.Sprintf("%s %.2f", currency, float64(*amount)/100)
+	if locale := money.DefaultLocale(); locale != "" {
+		v := money.Format(*amount, currency, locale)
+		return &v
+	}
+	v := currency + " " + money.Decimal(*amount, currency)
 	return &v
 }
 
@@ -2,6 +2,8 @@ package observability
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -13,8 +15,8 @@ func walkObjects(node any) []map[string]any {
 		switch v := value.(type) {
 		case map[string]any:
 			objects = append(objects, v)
-			for _, nested := range v {
-				walk(nested)
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				walk(v[key])
 			}
 		case []any:
 			for _, nested := range v {
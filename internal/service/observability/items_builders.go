@@ -1,6 +1,7 @@
 package observability
 
 import (
+	"cmp"
 	"math"
 	"sort"
 	"strings"
@@ -301,16 +302,12 @@ func BuildItemSearchResult(
 		}
 	}
 
-	switch sortMode {
-	case ItemSortPrice:
-		sort.SliceStable(menuItems, func(i, j int) bool {
-			left := intValue(toMap(menuItems[i]["base_price"])["amount"])
-			right := intValue(toMap(menuItems[j]["base_price"])["amount"])
-			return left < right
-		})
-	case ItemSortName:
+	if sortMode != ItemSortRelevance {
+		for _, item := range menuItems {
+			item["sort_key"] = itemSortKey(item, sortMode)
+		}
 		sort.SliceStable(menuItems, func(i, j int) bool {
-			return strings.ToLower(stringFromAny(menuItems[i]["name"])) < strings.ToLower(stringFromAny(menuItems[j]["name"]))
+			return itemSortLess(menuItems[i], menuItems[j])
 		})
 	}
 
@@ -395,3 +392,30 @@ func BuildItemDetail(itemID string, venueID string, payload map[string]any, incl
 	}
 	return data, warnings
 }
+
+// itemSortKey is the base price in minor units for price sorting, or the
+// lower-cased name for name sorting.
+func itemSortKey(item map[string]any, sortMode ItemSort) any {
+	if sortMode == ItemSortPrice {
+		return intValue(toMap(item["base_price"])["amount"])
+	}
+	return strings.ToLower(stringFromAny(item["name"]))
+}
+
+// itemSortLess orders by sort_key, then by case-insensitive name and item_id.
+func itemSortLess(left map[string]any, right map[string]any) bool {
+	var order int
+	switch leftKey := left["sort_key"].(type) {
+	case int:
+		order = cmp.Compare(leftKey, intValue(right["sort_key"]))
+	case string:
+		order = strings.Compare(leftKey, stringFromAny(right["sort_key"]))
+	}
+	if order != 0 {
+		return order < 0
+	}
+	if order := strings.Compare(strings.ToLower(stringFromAny(left["name"])), strings.ToLower(stringFromAny(right["name"]))); order != 0 {
+		return order < 0
+	}
+	return stringFromAny(left["item_id"]) < stringFromAny(right["item_id"])
+}
@@ -3,6 +3,7 @@ package observability_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
 	"github.com/mekedron/wolt-cli/internal/service/observability"
@@ -56,6 +57,49 @@ func TestBuildDiscoveryFeed(t *testing.T) {
 	if firstItem["wolt_plus"] != true {
 		t.Fatalf("expected wolt_plus true, got %v", firstItem["wolt_plus"])
 	}
+	if firstItem["is_ad"] != false {
+		t.Fatalf("expected is_ad false, got %v", firstItem["is_ad"])
+	}
+
+	section.Items[0].IsAdvertisement = true
+	data = observability.BuildDiscoveryFeed([]domain.Section{section}, "Krakow", nil, false)
+	firstItem = asMap(t, asSlice(t, asMap(t, asSlice(t, data["sections"])[0])["items"])[0])
+	if firstItem["is_ad"] != true {
+		t.Fatalf("expected is_advertisement to surface as is_ad, got %v", firstItem["is_ad"])
+	}
+}
+
+func TestVenueRowsIncludeCoordinatesAndPublicURL(t *testing.T) {
+	sections := []domain.Section{{
+		Name: "popular",
+		Items: []domain.Item{
+			{Title: "Burger Place", Venue: &domain.Venue{ID: "1", Slug: "burger-place", Country: "POL", Location: []float64{19.94, 50.06}}},
+			{Title: "Corner Shop", Venue: &domain.Venue{ID: "2", Slug: "corner-shop", Country: "POL", City: "krakow", ProductLine: "grocery"}},
+			{Title: "Cafe", Venue: &domain.Venue{ID: "3", Slug: "cafe", PublicURL: "https://wolt.com/pl/pol/krakow/restaurant/cafe"}},
+		},
+	}}
+	domain.FillVenueCity(sections, "krakow")
+
+	items := asSlice(t, asMap(t, asSlice(t, observability.BuildDiscoveryFeed(sections, "Kraków", nil, false)["sections"])[0])["items"])
+	first, second, third := asMap(t, items[0]), asMap(t, items[1]), asMap(t, items[2])
+	if first["latitude"] != 50.06 || first["longitude"] != 19.94 {
+		t.Fatalf("expected [lon, lat] location to map to latitude/longitude, got %v", first)
+	}
+	if first["public_url"] != "https://wolt.com/en/pol/krakow/restaurant/burger-place" {
+		t.Fatalf("unexpected restaurant public_url %v", first["public_url"])
+	}
+	if second["public_url"] != "https://wolt.com/en/pol/krakow/venue/corner-shop" || second["latitude"] != nil {
+		t.Fatalf("unexpected retail row %v", second)
+	}
+	if third["public_url"] != "https://wolt.com/pl/pol/krakow/restaurant/cafe" {
+		t.Fatalf("expected payload public_url to win, got %v", third["public_url"])
+	}
+
+	data, _ := observability.BuildVenueSearchResult([]domain.Item{sections[0].Items[0]}, "", observability.VenueSortRecommended, nil, "", false, false, nil, 0)
+	row := asMap(t, asSlice(t, data["items"])[0])
+	if row["latitude"] != 50.06 || row["public_url"] != first["public_url"] {
+		t.Fatalf("expected search rows to carry the same fields, got %v", row)
+	}
 }
 
 func TestBuildVenueSearchResultFiltersQuery(t *testing.T) {
@@ -74,6 +118,33 @@ func TestBuildVenueSearchResultFiltersQuery(t *testing.T) {
 	}
 }
 
+func TestBuildVenueSearchResultBreaksRatingTiesByName(t *testing.T) {
+	items := []domain.Item{
+		{Title: "Zeta Grill", Link: domain.Link{Target: "1"}, Venue: &domain.Venue{ID: "1", Slug: "zeta-grill", Rating: &domain.Rating{Score: 9.2}}},
+		{Title: "Alpha Grill", Link: domain.Link{Target: "2"}, Venue: &domain.Venue{ID: "2", Slug: "alpha-grill", Rating: &domain.Rating{Score: 9.2}}},
+		{Title: "Best Grill", Link: domain.Link{Target: "3"}, Venue: &domain.Venue{ID: "3", Slug: "best-grill", Rating: &domain.Rating{Score: 9.6}}},
+		{Title: "New Grill", Link: domain.Link{Target: "4"}, Venue: &domain.Venue{ID: "4", Slug: "new-grill"}},
+	}
+
+	data, _ := observability.BuildVenueSearchResult(items, "", observability.VenueSortRating, nil, "", false, false, nil, 0)
+	rows := asSlice(t, data["items"])
+	got := []string{}
+	for _, row := range rows {
+		got = append(got, asMap(t, row)["slug"].(string))
+	}
+	if strings.Join(got, ",") != "best-grill,alpha-grill,zeta-grill,new-grill" {
+		t.Fatalf("expected rating order with name tie-break, got %v", got)
+	}
+	if asMap(t, rows[1])["sort_key"] != 9.2 || asMap(t, rows[3])["sort_key"] != 0.0 {
+		t.Fatalf("expected rating sort keys, got %v and %v", asMap(t, rows[1])["sort_key"], asMap(t, rows[3])["sort_key"])
+	}
+
+	data, _ = observability.BuildVenueSearchResult(items, "", observability.VenueSortRecommended, nil, "", false, false, nil, 0)
+	if _, ok := asMap(t, asSlice(t, data["items"])[0])["sort_key"]; ok {
+		t.Fatalf("expected no sort_key for recommended order")
+	}
+}
+
 func TestBuildItemSearchResultFallback(t *testing.T) {
 	fallback := []domain.Item{
 		{
@@ -172,6 +243,32 @@ func TestBuildVenueDetailIncludesTags(t *testing.T) {
 	}
 }
 
+func TestBuildVenueDetailReportsCompleteness(t *testing.T) {
+	item := &domain.Item{
+		Title: "Burger Place",
+		Link:  domain.Link{Target: "venue-1"},
+		Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place", Currency: "PLN", DeliveryPriceInt: intPtr(500), Rating: &domain.Rating{Score: 9.2}},
+	}
+	restaurant := &domain.Restaurant{ID: "venue-1", Slug: "burger-place", Address: "Street 1", Currency: "PLN", DeliveryMethods: []string{"homedelivery"}}
+
+	data, _, err := observability.BuildVenueDetail(item, restaurant, map[string]struct{}{"hours": {}, "fees": {}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	completeness := asMap(t, data["completeness"])
+	sections := asMap(t, completeness["sections"])
+	if sections["address"] != "restaurant" || sections["rating"] != "catalog" || sections["delivery_fee"] != "catalog" {
+		t.Fatalf("unexpected section sources: %v", sections)
+	}
+	missing := asSlice(t, completeness["missing"])
+	if len(missing) != 2 || missing[0] != "opening_windows" || missing[1] != "order_minimum" {
+		t.Fatalf("expected opening_windows and order_minimum missing, got %v", missing)
+	}
+	if completeness["score"] != 0.75 {
+		t.Fatalf("expected score 0.75 for 6 of 8 sections, got %v", completeness["score"])
+	}
+}
+
 func TestBuildItemDetailIncludesUpsell(t *testing.T) {
 	payload := map[string]any{
 		"item_id":       "item-1",
@@ -519,6 +616,43 @@ func TestBuildDiscoveryFeedWoltPlusOnlyFilter(t *testing.T) {
 	}
 }
 
+func TestBuildSectionListKeepsSectionMetadata(t *testing.T) {
+	sections := []domain.Section{
+		{
+			Name:     "quickest-delivery-venues",
+			Title:    "Fastest delivery",
+			Subtitle: "Under 20 minutes",
+			Link:     domain.SectionLink{Target: "quickest-delivery-venues:krakow", Type: "venue-page", Title: "See all"},
+			Items: []domain.Item{
+				{Title: "Wolt Plus Venue", Link: domain.Link{Target: "venue-1"}, Venue: &domain.Venue{ID: "venue-1", Slug: "venue-one", Icon: "wolt-plus"}},
+				{Title: "Regular Venue", Link: domain.Link{Target: "venue-2"}, Venue: &domain.Venue{ID: "venue-2", Slug: "venue-two"}},
+			},
+		},
+		{Name: "banners", Description: "Offers this week"},
+	}
+
+	data := observability.BuildSectionList(sections)
+	rows := asSlice(t, data["sections"])
+	if data["count"] != 2 || len(rows) != 2 {
+		t.Fatalf("expected two sections, got %v", data)
+	}
+	first := asMap(t, rows[0])
+	seeAll := asMap(t, first["see_all"])
+	if first["subtitle"] != "Under 20 minutes" || first["total_items"] != 2 || seeAll["target"] != "quickest-delivery-venues:krakow" || seeAll["type"] != "venue-page" {
+		t.Fatalf("unexpected section metadata: %v", first)
+	}
+	second := asMap(t, rows[1])
+	if second["title"] != "banners" || second["subtitle"] != "Offers this week" || second["see_all"] != nil || second["total_items"] != 0 {
+		t.Fatalf("expected name, description, and no link for the bare section, got %v", second)
+	}
+
+	feed := observability.BuildDiscoveryFeed(sections, "Krakow", nil, true)
+	feedSection := asMap(t, asSlice(t, feed["sections"])[0])
+	if len(asSlice(t, feedSection["items"])) != 1 || feedSection["total_items"] != 2 || feedSection["subtitle"] != "Under 20 minutes" {
+		t.Fatalf("expected the feed to count items before the Wolt+ filter, got %v", feedSection)
+	}
+}
+
 func TestBuildVenueMenuDetectsWoltPlusFromBadges(t *testing.T) {
 	payload := map[string]any{
 		"venue": map[string]any{
@@ -539,6 +673,110 @@ func TestBuildVenueMenuDetectsWoltPlusFromBadges(t *testing.T) {
 	}
 }
 
+func TestBuildVenueSlotsAppliesLeadTimeAndVenueStep(t *testing.T) {
+	clock := func(hour, minute int) domain.Times {
+		return domain.Times{Value: map[string]int64{"$date": int64((hour*60 + minute) * 60 * 1000)}}
+	}
+	window := func(open, close domain.Times) []domain.Times {
+		open.Type, close.Type = "open", "close"
+		return []domain.Times{open, close}
+	}
+	restaurant := &domain.Restaurant{
+		ID:              "venue-1",
+		TimezoneName:    "Europe/Warsaw",
+		PreorderEnabled: true,
+		PreorderTimes: domain.PreorderTimes{
+			Delivery:          map[string][]domain.Times{"monday": window(clock(13, 45), clock(15, 0))},
+			Takeaway:          map[string][]domain.Times{"monday": window(clock(22, 0), clock(1, 0))},
+			MinimumTimeLimits: map[string]int{"delivery": 3600, "takeaway": 2400},
+			TimeStep:          900,
+		},
+	}
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	opts := observability.VenueSlotOptions{Now: time.Date(2026, 3, 2, 13, 0, 0, 0, warsaw), Days: 1}
+
+	data, err := observability.BuildVenueSlots(restaurant, opts)
+	if err != nil {
+		t.Fatalf("BuildVenueSlots returned error: %v", err)
+	}
+	slots := asSlice(t, data["slots"])
+	if len(slots) != 16 || intValue(data["interval_minutes"]) != 15 {
+		t.Fatalf("expected 4 delivery and 12 pickup slots of 15m, got %d slots of %v", len(slots), data["interval_minutes"])
+	}
+	if first := asMap(t, slots[0]); first["mode"] != "delivery" || first["start"] != "2026-03-02T14:00:00+01:00" {
+		t.Fatalf("expected first slot after the one-hour lead time, got %v", first)
+	}
+	if last := asMap(t, slots[len(slots)-1]); last["mode"] != "pickup" || last["end"] != "2026-03-03T01:00:00+01:00" {
+		t.Fatalf("expected the overnight pickup window to end after midnight, got %v", last)
+	}
+
+	opts.Mode = "delivery"
+	opts.Interval = 30 * time.Minute
+	data, _ = observability.BuildVenueSlots(restaurant, opts)
+	if slots := asSlice(t, data["slots"]); len(slots) != 1 || asMap(t, slots[0])["start"] != "2026-03-02T14:15:00+01:00" {
+		t.Fatalf("expected one 30m delivery slot aligned to the window open, got %v", slots)
+	}
+
+	restaurant.PreorderEnabled = false
+	data, _ = observability.BuildVenueSlots(restaurant, opts)
+	if slots := asSlice(t, data["slots"]); len(slots) != 0 || data["preorder_enabled"] != false {
+		t.Fatalf("expected no slots when preorders are disabled, got %v", data)
+	}
+}
+
+func TestBuildVenueHoursComparesNowInVenueTimezone(t *testing.T) {
+	clock := func(kind string, hour, minute int) domain.Times {
+		return domain.Times{Type: kind, Value: map[string]int64{"$date": int64((hour*60 + minute) * 60 * 1000)}}
+	}
+	restaurant := &domain.Restaurant{
+		ID:           "venue-1",
+		TimezoneName: "Europe/Helsinki",
+		OpeningTimes: map[string][]domain.Times{
+			"monday":  {clock("open", 10, 0), clock("close", 21, 0)},
+			"tuesday": {clock("open", 18, 0), clock("close", 2, 0)},
+		},
+	}
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// 18:30 UTC is 20:30 in Helsinki, still inside Monday's window.
+	data, err := observability.BuildVenueHours(restaurant, "", time.Date(2026, 2, 16, 18, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BuildVenueHours returned error: %v", err)
+	}
+	if data["open_now"] != true || data["now"] != "2026-02-16T20:30:00+02:00" {
+		t.Fatalf("expected open at 20:30 venue time, got open_now=%v now=%v", data["open_now"], data["now"])
+	}
+	data, _ = observability.BuildVenueHours(restaurant, "", time.Date(2026, 2, 16, 19, 30, 0, 0, time.UTC))
+	if data["open_now"] != false {
+		t.Fatalf("expected closed at 21:30 venue time, got %v", data["open_now"])
+	}
+	if !observability.VenueOpenAt(restaurant, time.Date(2026, 2, 18, 1, 30, 0, 0, helsinki)) {
+		t.Fatal("expected Tuesday's overnight window to cover early Wednesday")
+	}
+	openings := observability.VenueOpenings(restaurant, time.Date(2026, 2, 16, 12, 0, 0, 0, helsinki), 2)
+	if len(openings) != 2 || openings[1][0].Format(time.RFC3339) != "2026-02-17T18:00:00+02:00" || openings[1][1].Format(time.RFC3339) != "2026-02-18T02:00:00+02:00" {
+		t.Fatalf("expected Monday and Tuesday openings, got %v", openings)
+	}
+	openings = observability.VenueOpenings(restaurant, time.Date(2026, 2, 18, 1, 0, 0, 0, helsinki), 1)
+	if len(openings) != 1 || openings[0][0].Day() != 17 {
+		t.Fatalf("expected Tuesday's overnight window on Wednesday, got %v", openings)
+	}
+	if _, err := observability.BuildVenueHours(restaurant, "Mars/Olympus", time.Now()); err == nil {
+		t.Fatal("expected an unknown timezone error")
+	}
+
+	data, _ = observability.BuildVenueHours(&domain.Restaurant{ID: "venue-2"}, "", time.Now())
+	if data["open_now"] != nil || data["timezone"] != "UTC" {
+		t.Fatalf("expected null open_now without opening times, got %v", data)
+	}
+}
+
 func asMap(t *testing.T, value any) map[string]any {
 	t.Helper()
 	m, ok := value.(map[string]any)
@@ -584,3 +822,51 @@ func intValue(v any) int {
 func intPtr(v int) *int {
 	return &v
 }
+
+func TestBuildVenueCarouselKeepsCarouselOrderAndMenuRows(t *testing.T) {
+	assortment := map[string]any{
+		"items": []any{
+			map[string]any{"id": "fries", "name": "Fries", "price": 399, "currency": "EUR"},
+			map[string]any{"id": "shake", "name": "Shake", "price": 450, "currency": "EUR"},
+		},
+	}
+	content := map[string]any{
+		"sections": []any{
+			map[string]any{"slug": "most-ordered", "title": "Most ordered", "item_ids": []any{"shake", "fries", "gone"}},
+			map[string]any{"slug": "drinks", "name": "Drinks", "items": []any{map[string]any{"id": "shake"}}},
+		},
+	}
+
+	data, warnings := observability.BuildVenueCarousel("venue-1", []map[string]any{content, assortment}, observability.VenueCarouselPopular, false, nil)
+	items := data["items"].([]map[string]any)
+	if len(items) != 2 || items[0]["item_id"] != "shake" || items[1]["item_id"] != "fries" {
+		t.Fatalf("unexpected carousel rows: %#v", items)
+	}
+	if data["title"] != "Most ordered" || len(warnings) != 1 {
+		t.Fatalf("unexpected carousel metadata %v / warnings %v", data["title"], warnings)
+	}
+
+	_, warnings = observability.BuildVenueCarousel("venue-1", []map[string]any{content, assortment}, observability.VenueCarouselRecommended, false, nil)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "no recommended carousel") {
+		t.Fatalf("expected missing carousel warning, got %v", warnings)
+	}
+}
+
+func TestSuggestQueriesCorrectsWordsFromKnownNames(t *testing.T) {
+	names := []string{"Pizza Hut", "Pizza Express", "Poke Bowl Bar", "Thai Garden"}
+
+	got := observability.SuggestQueries("pizzza", names, 3)
+	if len(got) == 0 || got[0] != "pizza" {
+		t.Fatalf("expected pizza first, got %v", got)
+	}
+	got = observability.SuggestQueries("poek bowl", names, 3)
+	if len(got) == 0 || got[0] != "poke bowl" {
+		t.Fatalf("expected the misspelled word corrected in place, got %v", got)
+	}
+	if got := observability.SuggestQueries("pizza", names, 3); len(got) != 0 {
+		t.Fatalf("expected no suggestion for a known word, got %v", got)
+	}
+	if got := observability.SuggestQueries("tea", names, 3); len(got) != 0 {
+		t.Fatalf("expected short words to allow one edit only, got %v", got)
+	}
+}
@@ -248,3 +248,21 @@ func labelsFromAny(value any) []string {
 		return nil
 	}
 }
+
+// ExtractBannerLabels returns unique labels of a banners list, taken from each
+// banner's own text or, failing that, from its discount.
+func ExtractBannerLabels(banners any) []string {
+	out := []string{}
+	for _, rawBanner := range toSlice(banners) {
+		banner := toMap(rawBanner)
+		if banner == nil {
+			continue
+		}
+		label := promotionLabelFromMap(banner)
+		if label == "" {
+			label = promotionLabelFromMap(toMap(banner["discount"]))
+		}
+		out = mergeStringLabels(out, []string{label})
+	}
+	return out
+}
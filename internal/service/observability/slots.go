@@ -0,0 +1,170 @@
+package observability
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/domain"
+)
+
+// VenueSlotOptions selects the preorder slots BuildVenueSlots lists. Zero Days
+// and Interval fall back to the venue's maximum_days and time_step.
+type VenueSlotOptions struct {
+	// Timezone overrides the venue timezone used to lay out days and render times.
+	Timezone string
+	// Now plus the venue's minimum lead time is the earliest slot start.
+	Now time.Time
+	// Date limits slots to one YYYY-MM-DD day; when empty, Days days from Now are listed.
+	Date     string
+	Days     int
+	Interval time.Duration
+	// Mode is "delivery", "pickup", or empty for both.
+	Mode string
+}
+
+const (
+	defaultPreorderDays     = 7
+	defaultPreorderInterval = 15 * time.Minute
+)
+
+// BuildVenueSlots splits the venue's weekly preorder windows into bookable
+// slots in the venue timezone.
+func BuildVenueSlots(restaurant *domain.Restaurant, opts VenueSlotOptions) (map[string]any, error) {
+	loc, timezone, err := VenueLocation(restaurant, opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	preorder := restaurant.PreorderTimes
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Duration(preorder.TimeStep) * time.Second
+	}
+	if interval <= 0 {
+		interval = defaultPreorderInterval
+	}
+
+	now := opts.Now.In(loc)
+	days := []time.Time{}
+	if date := strings.TrimSpace(opts.Date); date != "" {
+		day, err := time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("--date must be YYYY-MM-DD")
+		}
+		days = append(days, day)
+	} else {
+		count := opts.Days
+		if count <= 0 {
+			count = preorder.MaximumDays
+		}
+		if count <= 0 {
+			count = defaultPreorderDays
+		}
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+		for offset := range count {
+			days = append(days, today.AddDate(0, 0, offset))
+		}
+	}
+
+	modes := []struct {
+		name    string
+		limit   string
+		windows map[string][]domain.Times
+	}{
+		{"delivery", "delivery", preorder.Delivery},
+		{"pickup", "takeaway", preorder.Takeaway},
+	}
+	slots := []any{}
+	if restaurant.PreorderEnabled {
+		for _, day := range days {
+			weekday := strings.ToLower(day.Weekday().String())
+			for _, mode := range modes {
+				if opts.Mode != "" && opts.Mode != mode.name {
+					continue
+				}
+				lead, ok := preorder.MinimumTimeLimits[mode.limit]
+				if !ok {
+					lead = preorder.MinimumTimeLimit
+				}
+				earliest := now.Add(time.Duration(lead) * time.Second)
+				for _, window := range weeklyWindows(mode.windows[weekday]) {
+					end := day.Add(window[1])
+					for start := day.Add(window[0]); !start.Add(interval).After(end); start = start.Add(interval) {
+						if start.Before(earliest) {
+							continue
+						}
+						slots = append(slots, map[string]any{
+							"mode":  mode.name,
+							"date":  start.Format("2006-01-02"),
+							"start": start.Format(time.RFC3339),
+							"end":   start.Add(interval).Format(time.RFC3339),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	var date any
+	if strings.TrimSpace(opts.Date) != "" {
+		date = strings.TrimSpace(opts.Date)
+	}
+	return map[string]any{
+		"venue_id":         domain.NormalizeID(restaurant.ID),
+		"timezone":         timezone,
+		"preorder_enabled": restaurant.PreorderEnabled,
+		"preorder_only":    restaurant.PreorderOnly,
+		"date":             date,
+		"interval_minutes": int(interval / time.Minute),
+		"slots":            slots,
+	}, nil
+}
+
+// VenueLocation loads the override timezone, else the venue's own, else UTC.
+// It also returns the resolved timezone name.
+func VenueLocation(restaurant *domain.Restaurant, override string) (*time.Location, string, error) {
+	timezone := strings.TrimSpace(override)
+	if timezone == "" && restaurant != nil {
+		timezone = strings.TrimSpace(restaurant.TimezoneName)
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, timezone, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	return loc, timezone, nil
+}
+
+// weeklyWindows pairs open/close entries into offsets from midnight. A close
+// at or before its open runs past midnight.
+func weeklyWindows(values []domain.Times) [][2]time.Duration {
+	const day = 24 * time.Hour
+	windows := [][2]time.Duration{}
+	var open *time.Duration
+	for _, value := range values {
+		ms, ok := value.Value["$date"]
+		if !ok {
+			continue
+		}
+		offset := (time.Duration(ms) * time.Millisecond) % day
+		if offset < 0 {
+			offset += day
+		}
+		switch strings.ToLower(value.Type) {
+		case "open":
+			open = &offset
+		case "close":
+			if open == nil {
+				continue
+			}
+			if offset <= *open {
+				offset += day
+			}
+			windows = append(windows, [2]time.Duration{*open, offset})
+			open = nil
+		}
+	}
+	return windows
+}
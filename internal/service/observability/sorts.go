@@ -14,6 +14,9 @@ const (
 	VenueSortRating        VenueSort = "rating"
 	VenueSortDeliveryPrice VenueSort = "delivery_price"
 	VenueSortDeliveryTime  VenueSort = "delivery_time"
+	// VenueSortMyRating orders by the caller's own ratings, which live outside
+	// the discovery payload; BuildVenueSearchResult keeps recommended order.
+	VenueSortMyRating VenueSort = "my_rating"
 )
 
 // ParseVenueSort parses venue sort value.
@@ -23,7 +26,7 @@ func ParseVenueSort(value string) (VenueSort, error) {
 		return VenueSortRecommended, nil
 	}
 	switch v {
-	case VenueSortRecommended, VenueSortDistance, VenueSortRating, VenueSortDeliveryPrice, VenueSortDeliveryTime:
+	case VenueSortRecommended, VenueSortDistance, VenueSortRating, VenueSortDeliveryPrice, VenueSortDeliveryTime, VenueSortMyRating:
 		return v, nil
 	default:
 		return "", fmt.Errorf("invalid venue sort %q", value)
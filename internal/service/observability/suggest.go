@@ -0,0 +1,162 @@
+package observability
+
+import (
+	"sort"
+	"strings"
+)
+
+// SuggestQueries returns up to limit spellings of query built from words in
+// names, for searches that found nothing. Each query word that is not a known
+// word is replaced by the closest known word within a few edits; names close
+// to the whole query are suggested as they are. Closer matches come first,
+// then words that occur in more names.
+func SuggestQueries(query string, names []string, limit int) []string {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 || limit <= 0 {
+		return []string{}
+	}
+	frequency := map[string]int{}
+	for _, name := range names {
+		seen := map[string]bool{}
+		for _, word := range suggestionWords(name) {
+			if !seen[word] {
+				frequency[word]++
+				seen[word] = true
+			}
+		}
+	}
+
+	suggestions := []string{}
+	seen := map[string]bool{strings.Join(words, " "): true}
+	add := func(value string) {
+		if !seen[value] && len(suggestions) < limit {
+			seen[value] = true
+			suggestions = append(suggestions, value)
+		}
+	}
+
+	alternatives := make([][]string, len(words))
+	for idx, word := range words {
+		if frequency[word] > 0 {
+			alternatives[idx] = []string{word}
+			continue
+		}
+		alternatives[idx] = closestWords(word, frequency)
+		if len(alternatives[idx]) == 0 {
+			alternatives[idx] = []string{word}
+		}
+	}
+	// The n-th suggestion takes each word's n-th closest spelling, or its best.
+	for rank := range limit {
+		corrected := make([]string, len(words))
+		for idx, options := range alternatives {
+			corrected[idx] = options[min(rank, len(options)-1)]
+		}
+		add(strings.Join(corrected, " "))
+	}
+
+	if len(words) > 1 {
+		whole := strings.Join(words, " ")
+		matches := []suggestionMatch{}
+		for _, name := range names {
+			lowered := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+			if distance := editDistance(whole, lowered); distance <= suggestionMaxEdits(whole) {
+				matches = append(matches, suggestionMatch{value: lowered, distance: distance})
+			}
+		}
+		sortSuggestionMatches(matches)
+		for _, match := range matches {
+			add(match.value)
+		}
+	}
+	return suggestions
+}
+
+type suggestionMatch struct {
+	value     string
+	distance  int
+	frequency int
+}
+
+func closestWords(word string, frequency map[string]int) []string {
+	matches := []suggestionMatch{}
+	maxEdits := suggestionMaxEdits(word)
+	for candidate, count := range frequency {
+		if distance := editDistance(word, candidate); distance <= maxEdits {
+			matches = append(matches, suggestionMatch{value: candidate, distance: distance, frequency: count})
+		}
+	}
+	sortSuggestionMatches(matches)
+	out := make([]string, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, match.value)
+	}
+	return out
+}
+
+func sortSuggestionMatches(matches []suggestionMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		if matches[i].frequency != matches[j].frequency {
+			return matches[i].frequency > matches[j].frequency
+		}
+		return matches[i].value < matches[j].value
+	})
+}
+
+// suggestionMaxEdits allows one edit in short words and up to three in long
+// ones, so "pizzza" finds "pizza" but "tea" does not become "thai".
+func suggestionMaxEdits(value string) int {
+	switch length := len([]rune(value)); {
+	case length <= 4:
+		return 1
+	case length <= 8:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// suggestionWords splits a name into lower-cased words of letters and digits,
+// skipping words too short to be worth correcting to.
+func suggestionWords(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) >= 3 {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+// editDistance is the optimal string alignment distance between a and b in
+// runes: Levenshtein edits plus swaps of adjacent runes, the most common typo.
+func editDistance(a string, b string) int {
+	left, right := []rune(a), []rune(b)
+	rows := make([][]int, len(left)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(right)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(left); i++ {
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && left[i-1] == right[j-2] && left[i-2] == right[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(left)][len(right)]
+}
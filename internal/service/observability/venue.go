@@ -1,9 +1,12 @@
 package observability
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/domain"
 )
@@ -53,6 +56,7 @@ func BuildDiscoveryFeed(sections []domain.Section, city string, limit *int, wolt
 			if woltPlusOnly && !isWoltPlus {
 				continue
 			}
+			latitude, longitude := venueCoordinates(item.Venue)
 			var ratingValue any
 			if item.Venue.Rating != nil {
 				ratingValue = item.Venue.Rating.Score
@@ -65,6 +69,9 @@ func BuildDiscoveryFeed(sections []domain.Section, city string, limit *int, wolt
 				"venue_id":          domain.NormalizeID(coalesce(item.Venue.ID, item.Link.Target)),
 				"slug":              item.Venue.Slug,
 				"name":              item.Title,
+				"latitude":          latitude,
+				"longitude":         longitude,
+				"public_url":        venuePublicURL(item.Venue),
 				"rating":            ratingValue,
 				"delivery_estimate": item.Venue.FormatEstimateRange(),
 				"delivery_fee":      deliveryFeeMap(item.Venue.DeliveryPriceInt, item.Venue.Currency),
@@ -72,20 +79,15 @@ func BuildDiscoveryFeed(sections []domain.Section, city string, limit *int, wolt
 				"price_range_scale": priceRangeScale(item.Venue.PriceRange),
 				"promotions":        venuePromotionTexts(item.Venue),
 				"wolt_plus":         isWoltPlus,
+				"is_ad":             item.IsAd(),
 			})
 		}
 		if woltPlusOnly && len(rows) == 0 {
 			continue
 		}
-		title := section.Title
-		if title == "" {
-			title = section.Name
-		}
-		sectionRows = append(sectionRows, map[string]any{
-			"name":  section.Name,
-			"title": title,
-			"items": rows,
-		})
+		sectionRow := sectionMetadata(section)
+		sectionRow["items"] = rows
+		sectionRows = append(sectionRows, sectionRow)
 	}
 
 	resolvedCity := strings.TrimSpace(city)
@@ -96,6 +98,43 @@ func BuildDiscoveryFeed(sections []domain.Section, city string, limit *int, wolt
 	return map[string]any{"city": resolvedCity, "wolt_plus_only": woltPlusOnly, "sections": sectionRows}
 }
 
+// BuildSectionList lists front-page sections without their venue rows.
+func BuildSectionList(sections []domain.Section) map[string]any {
+	rows := make([]map[string]any, 0, len(sections))
+	for _, section := range sections {
+		rows = append(rows, sectionMetadata(section))
+	}
+	return map[string]any{"sections": rows, "count": len(rows)}
+}
+
+// sectionMetadata describes a section as upstream sent it: total_items
+// counts its items before any filter, and see_all is null without a link.
+func sectionMetadata(section domain.Section) map[string]any {
+	title := section.Title
+	if title == "" {
+		title = section.Name
+	}
+	subtitle := section.Subtitle
+	if strings.TrimSpace(subtitle) == "" {
+		subtitle = section.Description
+	}
+	var seeAll any
+	if target := strings.TrimSpace(section.Link.Target); target != "" {
+		seeAll = map[string]any{
+			"target": target,
+			"type":   emptyToNil(section.Link.Type),
+			"title":  emptyToNil(section.Link.Title),
+		}
+	}
+	return map[string]any{
+		"name":        section.Name,
+		"title":       title,
+		"subtitle":    emptyToNil(subtitle),
+		"see_all":     seeAll,
+		"total_items": len(section.Items),
+	}
+}
+
 // BuildCategoryList extracts category slugs from section tags.
 func BuildCategoryList(sections []domain.Section) map[string]any {
 	categories := map[string]map[string]string{}
@@ -120,7 +159,10 @@ func BuildCategoryList(sections []domain.Section) map[string]any {
 		rows = append(rows, value)
 	}
 	sort.Slice(rows, func(i, j int) bool {
-		return rows[i]["name"] < rows[j]["name"]
+		if rows[i]["name"] != rows[j]["name"] {
+			return rows[i]["name"] < rows[j]["name"]
+		}
+		return rows[i]["slug"] < rows[j]["slug"]
 	})
 	return map[string]any{"categories": rows}
 }
@@ -164,6 +206,9 @@ func BuildVenueSearchResult(
 	warnings := []string{}
 	loweredQuery := strings.ToLower(strings.TrimSpace(query))
 	loweredCategory := strings.ToLower(strings.TrimSpace(category))
+	if sortMode == VenueSortMyRating {
+		sortMode = VenueSortRecommended
+	}
 
 	filtered := make([]domain.Item, 0, len(items))
 	for _, item := range items {
@@ -239,42 +284,14 @@ func BuildVenueSearchResult(
 		filtered = out
 	}
 
-	switch sortMode {
-	case VenueSortDistance:
+	if sortMode == VenueSortDistance {
 		warnings = append(warnings, "distance sort is approximated with delivery estimate in basic mode")
+	}
+	if sortMode != VenueSortRecommended {
 		sort.SliceStable(filtered, func(i, j int) bool {
-			return filtered[i].Venue.Estimate < filtered[j].Venue.Estimate
-		})
-	case VenueSortRating:
-		sort.SliceStable(filtered, func(i, j int) bool {
-			left := 0.0
-			right := 0.0
-			if filtered[i].Venue.Rating != nil {
-				left = filtered[i].Venue.Rating.Score
-			}
-			if filtered[j].Venue.Rating != nil {
-				right = filtered[j].Venue.Rating.Score
-			}
-			return left > right
-		})
-	case VenueSortDeliveryPrice:
-		sort.SliceStable(filtered, func(i, j int) bool {
-			left := 0
-			right := 0
-			if filtered[i].Venue.DeliveryPriceInt != nil {
-				left = *filtered[i].Venue.DeliveryPriceInt
-			}
-			if filtered[j].Venue.DeliveryPriceInt != nil {
-				right = *filtered[j].Venue.DeliveryPriceInt
-			}
-			return left < right
-		})
-	case VenueSortDeliveryTime:
-		sort.SliceStable(filtered, func(i, j int) bool {
-			return filtered[i].Venue.Estimate < filtered[j].Venue.Estimate
+			return venueSortLess(filtered[i], filtered[j], sortMode)
 		})
 	}
-
 	total := len(filtered)
 	if offset > 0 {
 		if offset >= len(filtered) {
@@ -295,11 +312,15 @@ func BuildVenueSearchResult(
 		if item.Venue.PriceRange > 0 {
 			priceRangeValue = item.Venue.PriceRange
 		}
+		latitude, longitude := venueCoordinates(item.Venue)
 		rows = append(rows, map[string]any{
 			"venue_id":          domain.NormalizeID(coalesce(item.Venue.ID, item.Link.Target)),
 			"slug":              item.Venue.Slug,
 			"name":              item.Title,
 			"address":           item.Venue.Address,
+			"latitude":          latitude,
+			"longitude":         longitude,
+			"public_url":        venuePublicURL(item.Venue),
 			"rating":            ratingValue,
 			"delivery_estimate": item.Venue.FormatEstimateRange(),
 			"delivery_fee":      deliveryFeeMap(item.Venue.DeliveryPriceInt, item.Venue.Currency),
@@ -308,6 +329,9 @@ func BuildVenueSearchResult(
 			"promotions":        venuePromotionTexts(item.Venue),
 			"wolt_plus":         venueWoltPlus(item.Venue),
 		})
+		if sortMode != VenueSortRecommended {
+			rows[len(rows)-1]["sort_key"] = venueSortKey(item, sortMode)
+		}
 	}
 
 	return map[string]any{
@@ -317,6 +341,37 @@ func BuildVenueSearchResult(
 	}, warnings
 }
 
+// venueCoordinates reads the GeoJSON-ordered [lon, lat] venue location; both
+// values are nil when the payload has none.
+func venueCoordinates(venue *domain.Venue) (any, any) {
+	if venue == nil || len(venue.Location) != 2 {
+		return nil, nil
+	}
+	return venue.Location[1], venue.Location[0]
+}
+
+// venuePublicURL returns the venue's wolt.com page. Front-page venues carry no
+// URL, so it is built from country, city, and slug when the city is a slug.
+func venuePublicURL(venue *domain.Venue) any {
+	if venue == nil {
+		return nil
+	}
+	if value := strings.TrimSpace(venue.PublicURL); value != "" {
+		return value
+	}
+	country := strings.ToLower(strings.TrimSpace(venue.Country))
+	city := strings.ToLower(strings.TrimSpace(venue.City))
+	slug := strings.TrimSpace(venue.Slug)
+	if country == "" || slug == "" || !venueCityPattern.MatchString(city) {
+		return nil
+	}
+	segment := "restaurant"
+	if productLine := strings.TrimSpace(venue.ProductLine); productLine != "" && productLine != "restaurant" {
+		segment = "venue"
+	}
+	return fmt.Sprintf("https://wolt.com/en/%s/%s/%s/%s", country, city, segment, slug)
+}
+
 func priceRangeScale(level int) string {
 	if level <= 0 {
 		return "-"
@@ -443,10 +498,20 @@ func BuildVenueDetail(item *domain.Item, restaurant *domain.Restaurant, include
 	warnings := []string{"order minimum is unavailable in basic mode and returned as null"}
 
 	var ratingValue any
+	ratingSource := VenueSourceRestaurant
 	if restaurant.Rating != nil {
 		ratingValue = restaurant.Rating.Score
 	} else if item.Venue.Rating != nil {
 		ratingValue = item.Venue.Rating.Score
+		ratingSource = VenueSourceCatalog
+	}
+	sources := map[string]string{
+		"name":             VenueSourceCatalog,
+		"address":          VenueSourceRestaurant,
+		"currency":         VenueSourceRestaurant,
+		"rating":           ratingSource,
+		"delivery_methods": VenueSourceRestaurant,
+		"order_minimum":    VenueSourceRestaurant,
 	}
 
 	data := map[string]any{
@@ -465,24 +530,92 @@ func BuildVenueDetail(item *domain.Item, restaurant *domain.Restaurant, include
 
 	if _, ok := include["hours"]; ok {
 		data["opening_windows"] = openingWindows(restaurant)
+		sources["opening_windows"] = VenueSourceRestaurant
 	}
 	if _, ok := include["tags"]; ok {
 		data["tags"] = restaurant.FoodTags
-	}
-	if _, ok := include["rating"]; ok && restaurant.Rating != nil {
-		data["rating_details"] = map[string]any{
-			"score":  restaurant.Rating.Score,
-			"text":   restaurant.Rating.Text,
-			"volume": restaurant.Rating.Volume,
+		sources["tags"] = VenueSourceRestaurant
+	}
+	if _, ok := include["rating"]; ok {
+		if restaurant.Rating != nil {
+			data["rating_details"] = map[string]any{
+				"score":  restaurant.Rating.Score,
+				"text":   restaurant.Rating.Text,
+				"volume": restaurant.Rating.Volume,
+			}
 		}
+		sources["rating_details"] = VenueSourceRestaurant
 	}
 	if _, ok := include["fees"]; ok {
 		data["delivery_fee"] = deliveryFeeMap(item.Venue.DeliveryPriceInt, item.Venue.Currency)
+		sources["delivery_fee"] = VenueSourceCatalog
 	}
+	data["completeness"] = VenueCompleteness(data, sources)
 
 	return data, warnings, nil
 }
 
+// Sources named in venue detail completeness.
+const (
+	VenueSourceRestaurant = "restaurant"
+	VenueSourceCatalog    = "catalog"
+	VenueSourceStaticPage = "static_page"
+)
+
+// VenueCompleteness reports, for each venue detail section in sources, the
+// endpoint it was read from, or lists it as missing when data holds no value
+// for it. score is the resolved share of the sections, rounded to two places.
+func VenueCompleteness(data map[string]any, sources map[string]string) map[string]any {
+	resolved := map[string]any{}
+	missing := []string{}
+	for section, source := range sources {
+		if emptyDetailValue(data[section]) {
+			missing = append(missing, section)
+			continue
+		}
+		resolved[section] = source
+	}
+	sort.Strings(missing)
+	score := 1.0
+	if len(sources) > 0 {
+		score = math.Round(float64(len(resolved))/float64(len(sources))*100) / 100
+	}
+	return map[string]any{
+		"score":    score,
+		"sections": resolved,
+		"missing":  missing,
+	}
+}
+
+// emptyDetailValue treats nil, blank strings, empty lists, opening windows
+// without any open time, and money maps without an amount as missing.
+func emptyDetailValue(value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(typed) == ""
+	case []any:
+		return len(typed) == 0
+	case []string:
+		return len(typed) == 0
+	case []map[string]string:
+		for _, window := range typed {
+			if open := window["open"]; open != "" && open != "-" {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		if amount, ok := typed["amount"]; ok {
+			return amount == nil
+		}
+		return len(typed) == 0
+	default:
+		return false
+	}
+}
+
 func stringValue(v any) string {
 	if v == nil {
 		return ""
@@ -493,19 +626,140 @@ func stringValue(v any) string {
 	return ""
 }
 
-// BuildVenueHours renders venue opening windows.
-func BuildVenueHours(restaurant *domain.Restaurant, timezone string) map[string]any {
-	resolvedTimezone := strings.TrimSpace(timezone)
-	if resolvedTimezone == "" {
-		resolvedTimezone = strings.TrimSpace(restaurant.TimezoneName)
-	}
-	if resolvedTimezone == "" {
-		resolvedTimezone = "UTC"
+// BuildVenueHours renders venue opening windows and whether the venue is open
+// at now, compared in the venue timezone. open_now is null when the venue
+// publishes no opening times; closes_at is set while it is open and opens_at
+// while it is closed.
+func BuildVenueHours(restaurant *domain.Restaurant, timezone string, now time.Time) (map[string]any, error) {
+	loc, resolvedTimezone, err := VenueLocation(restaurant, timezone)
+	if err != nil {
+		return nil, err
+	}
+	now = now.In(loc)
+	var openNow, opensAt, closesAt any
+	if len(restaurant.OpeningTimes) > 0 {
+		openNow = VenueOpenAt(restaurant, now)
+		if at, open, ok := venueNextChange(restaurant, now); ok && open {
+			opensAt = at.Format(time.RFC3339)
+		} else if ok {
+			closesAt = at.Format(time.RFC3339)
+		}
 	}
 	return map[string]any{
 		"venue_id":         domain.NormalizeID(restaurant.ID),
 		"timezone":         resolvedTimezone,
+		"now":              now.Format(time.RFC3339),
+		"open_now":         openNow,
+		"opens_at":         opensAt,
+		"closes_at":        closesAt,
 		"opening_windows":  openingWindows(restaurant),
 		"delivery_windows": []any{},
+	}, nil
+}
+
+// VenueOpenAt reports whether the venue's weekly opening times cover at, read
+// as wall-clock time in at's location. A window that runs past midnight also
+// covers the early hours of the next day.
+func VenueOpenAt(restaurant *domain.Restaurant, at time.Time) bool {
+	midnight := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	for _, day := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		weekday := strings.ToLower(day.Weekday().String())
+		for _, window := range weeklyWindows(restaurant.OpeningTimes[weekday]) {
+			if !at.Before(day.Add(window[0])) && at.Before(day.Add(window[1])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// VenueOpenings lays the venue's weekly opening times out as concrete windows
+// for days days starting at from's midnight, in from's location. A window
+// from the day before that runs past midnight is included as well.
+func VenueOpenings(restaurant *domain.Restaurant, from time.Time, days int) [][2]time.Time {
+	midnight := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	openings := [][2]time.Time{}
+	for offset := -1; offset < days; offset++ {
+		day := midnight.AddDate(0, 0, offset)
+		weekday := strings.ToLower(day.Weekday().String())
+		for _, window := range weeklyWindows(restaurant.OpeningTimes[weekday]) {
+			start, end := day.Add(window[0]), day.Add(window[1])
+			if offset < 0 && !end.After(midnight) {
+				continue
+			}
+			openings = append(openings, [2]time.Time{start, end})
+		}
+	}
+	return openings
+}
+
+// venueNextChange finds when the venue next opens or closes after now, within
+// a week. opens is true for an opening. Windows that touch or overlap are
+// read as one, so a venue open around the clock never closes.
+func venueNextChange(restaurant *domain.Restaurant, now time.Time) (time.Time, bool, bool) {
+	openings := VenueOpenings(restaurant, now, 8)
+	sort.SliceStable(openings, func(i, j int) bool { return openings[i][0].Before(openings[j][0]) })
+	for i, window := range openings {
+		if !now.Before(window[0]) && now.Before(window[1]) {
+			end := window[1]
+			for _, next := range openings[i+1:] {
+				if next[0].After(end) {
+					break
+				}
+				if next[1].After(end) {
+					end = next[1]
+				}
+			}
+			if !end.Before(openings[len(openings)-1][1]) {
+				return time.Time{}, false, false
+			}
+			return end, false, true
+		}
+		if window[0].After(now) {
+			return window[0], true, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// venueSortKey is the value a venue is ordered by: the rating score, the
+// delivery fee in minor units, or the delivery estimate in minutes. Missing
+// values count as zero.
+func venueSortKey(item domain.Item, sortMode VenueSort) any {
+	switch sortMode {
+	case VenueSortRating:
+		if item.Venue.Rating != nil {
+			return item.Venue.Rating.Score
+		}
+		return 0.0
+	case VenueSortDeliveryPrice:
+		if item.Venue.DeliveryPriceInt != nil {
+			return *item.Venue.DeliveryPriceInt
+		}
+		return 0
+	default:
+		return item.Venue.Estimate
+	}
+}
+
+// venueSortLess orders by sort key, highest rating first and lowest fee or
+// estimate first, then by case-insensitive name and slug.
+func venueSortLess(left domain.Item, right domain.Item, sortMode VenueSort) bool {
+	var order int
+	switch leftKey := venueSortKey(left, sortMode).(type) {
+	case int:
+		order = cmp.Compare(leftKey, venueSortKey(right, sortMode).(int))
+	case float64:
+		order = cmp.Compare(leftKey, venueSortKey(right, sortMode).(float64))
+		if sortMode == VenueSortRating {
+			order = -order
+		}
+	}
+	if order != 0 {
+		return order < 0
+	}
+	if order := strings.Compare(strings.ToLower(left.Title), strings.ToLower(right.Title)); order != 0 {
+		return order < 0
 	}
+	return left.Venue.Slug < right.Venue.Slug
 }
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/money"
+)
+
+var beancountAccountPattern = regexp.MustCompile(`^(Assets|Liabilities|Equity|Income|Expenses)(:[A-Z0-9][A-Za-z0-9-]*)+$`)
+
+// BeancountTransaction is one completed transaction with two postings: Amount
+// (minor units) to Account, balanced by an elided posting to FundingAccount.
+type BeancountTransaction struct {
+	Date           time.Time
+	Payee          string
+	Narration      string
+	Tags           []string
+	Meta           [][2]string
+	Account        string
+	FundingAccount string
+	Amount         int
+	Currency       string
+}
+
+// ValidBeancountAccount reports whether name is a Beancount account such as
+// Expenses:Food:Delivery.
+func ValidBeancountAccount(name string) bool {
+	return beancountAccountPattern.MatchString(name)
+}
+
+// RenderBeancount renders transactions as Beancount entries separated by blank
+// lines. Comments go first, each prefixed with "; ".
+func RenderBeancount(transactions []BeancountTransaction, comments []string) string {
+	var b strings.Builder
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "; %s\n", strings.ReplaceAll(comment, "\n", " "))
+	}
+	for idx, txn := range transactions {
+		if idx > 0 || len(comments) > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s * %s %s", txn.Date.Format("2006-01-02"), beancountString(txn.Payee), beancountString(txn.Narration))
+		for _, tag := range txn.Tags {
+			b.WriteString(" #" + tag)
+		}
+		b.WriteString("\n")
+		for _, meta := range txn.Meta {
+			fmt.Fprintf(&b, "  %s: %s\n", meta[0], beancountString(meta[1]))
+		}
+		fmt.Fprintf(&b, "  %s  %s %s\n", txn.Account, money.Decimal(txn.Amount, txn.Currency), txn.Currency)
+		fmt.Fprintf(&b, "  %s\n", txn.FundingAccount)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func beancountString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(value) + `"`
+}
@@ -0,0 +1,25 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// HASensor is the object a Home Assistant command_line sensor reads: State
+// becomes the entity state and Attributes are picked up via json_attributes.
+type HASensor struct {
+	State      any            `json:"state"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// RenderHASensor renders sensor as a single JSON line.
+func RenderHASensor(sensor HASensor) (string, error) {
+	if sensor.Attributes == nil {
+		sensor.Attributes = map[string]any{}
+	}
+	bytes, err := json.Marshal(sensor)
+	if err != nil {
+		return "", fmt.Errorf("marshal ha-sensor: %w", err)
+	}
+	return string(bytes), nil
+}
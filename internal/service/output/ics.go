@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+// ICSEvent is one calendar event. Start and End are written in UTC, so the
+// calendar needs no VTIMEZONE blocks.
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+}
+
+// RenderICS renders events as an iCalendar (RFC 5545) VCALENDAR named name,
+// stamped with stamp. Properties are prefixed with X-WOLT- so errors can ride
+// along in a calendar that still imports.
+func RenderICS(name string, events []ICSEvent, properties [][2]string, stamp time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//wolt-cli//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:" + icsText(name),
+	}
+	for _, property := range properties {
+		lines = append(lines, "X-WOLT-"+strings.ToUpper(property[0])+":"+icsText(property[1]))
+	}
+	for _, event := range events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+icsText(event.UID),
+			"DTSTAMP:"+stamp.UTC().Format(icsTimeLayout),
+			"DTSTART:"+event.Start.UTC().Format(icsTimeLayout),
+			"DTEND:"+event.End.UTC().Format(icsTimeLayout),
+			"SUMMARY:"+icsText(event.Summary),
+		)
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+icsText(event.Description))
+		}
+		if event.Location != "" {
+			lines = append(lines, "LOCATION:"+icsText(event.Location))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+func icsText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// foldICSLine splits content lines longer than 75 octets, continuing each
+// piece on a line that starts with a space, without cutting a UTF-8 sequence.
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		fmt.Fprintf(&b, "%s\r\n ", line[:cut])
+		line = line[cut:]
+		// Continuation lines spend one octet on the leading space.
+		width = limit - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"strings"
+)
+
+// RenderMarkdown renders tables as a GitHub-flavored markdown document: a
+// level-one heading, then one level-two heading per table title followed by
+// its note and a pipe table. Tables without rows read "None." and warnings
+// close the document as a bullet list.
+func RenderMarkdown(heading string, tables []Table, warnings []string) string {
+	blocks := []string{}
+	if heading != "" {
+		blocks = append(blocks, "# "+markdownInline(heading))
+	}
+	active := TimeDisplayActive()
+	for _, table := range tables {
+		if table.Title != "" {
+			blocks = append(blocks, "## "+markdownInline(table.Title))
+		}
+		if table.Note != "" {
+			blocks = append(blocks, markdownInline(table.Note))
+		}
+		if len(table.Rows) == 0 || len(table.Headers) == 0 {
+			blocks = append(blocks, "_None._")
+			continue
+		}
+		lines := []string{markdownRow(table.Headers), markdownRow(markdownRule(len(table.Headers)))}
+		for _, row := range table.Rows {
+			cells := make([]string, len(table.Headers))
+			for i := range cells {
+				if i < len(row) {
+					cells[i] = row[i]
+				}
+				if active {
+					cells[i] = displayTimestampCell(cells[i])
+				}
+			}
+			lines = append(lines, markdownRow(cells))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	if len(warnings) > 0 {
+		items := make([]string, 0, len(warnings))
+		for _, warning := range warnings {
+			items = append(items, "- "+markdownInline(warning))
+		}
+		blocks = append(blocks, "## Warnings", strings.Join(items, "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func markdownRule(columns int) []string {
+	rule := make([]string, columns)
+	for i := range rule {
+		rule[i] = "---"
+	}
+	return rule
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(markdownInline(cell), "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// markdownInline keeps a value on one line so it cannot break a heading or a
+// table row.
+func markdownInline(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// KeyNaming selects how JSON and YAML object keys are written.
+type KeyNaming string
+
+const (
+	KeyNamingSnake KeyNaming = "snake"
+	KeyNamingCamel KeyNaming = "camel"
+)
+
+// ParseKeyNaming validates a --json-naming value; empty means snake.
+func ParseKeyNaming(value string) (KeyNaming, error) {
+	switch KeyNaming(strings.ToLower(strings.TrimSpace(value))) {
+	case "", KeyNamingSnake:
+		return KeyNamingSnake, nil
+	case KeyNamingCamel:
+		return KeyNamingCamel, nil
+	}
+	return "", fmt.Errorf("unsupported json naming %q; use snake or camel", value)
+}
+
+// snakeKeyPattern matches the keys the CLI writes in snake_case. Keys that
+// carry upstream identifiers or user values rarely fit it and stay as they are.
+var snakeKeyPattern = regexp.MustCompile(`^_?[a-z][a-z0-9]*(_[a-z0-9]+)+$`)
+
+// WithKeyNaming returns env set to be rendered with naming.
+func (env Envelope) WithKeyNaming(naming KeyNaming) Envelope {
+	env.keyNaming = naming
+	return env
+}
+
+// ApplyKeyNaming returns value with object keys renamed for naming. With
+// snake naming value is returned unchanged.
+func ApplyKeyNaming(value any, naming KeyNaming) any {
+	if naming != KeyNamingCamel {
+		return value
+	}
+	return camelKeys(value)
+}
+
+func applyEnvelopeKeyNaming(env Envelope) Envelope {
+	if env.keyNaming != KeyNamingCamel {
+		return env
+	}
+	return camelEnvelope(env)
+}
+
+func camelEnvelope(env Envelope) Envelope {
+	if env.Meta != nil {
+		meta := camelKeys(env.Meta).(map[string]any)
+		// --meta tags are the caller's own keys.
+		if tags, ok := env.Meta["tags"]; ok {
+			meta["tags"] = tags
+		}
+		env.Meta = meta
+	}
+	if env.Error != nil {
+		env.Error = camelKeys(env.Error).(map[string]any)
+	}
+	env.Data = camelKeys(env.Data)
+	return env
+}
+
+func camelKeys(value any) any {
+	switch typed := value.(type) {
+	case nil, string, bool, int, int64, float64:
+		return value
+	case Envelope:
+		return camelEnvelope(typed)
+	case map[string]any:
+		if typed == nil {
+			return typed
+		}
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[camelKey(key)] = camelKeys(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = camelKeys(item)
+		}
+		return out
+	case []map[string]any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = camelKeys(item)
+		}
+		return out
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Pointer:
+		// Typed values are renamed through their JSON form.
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+		var decoded any
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return value
+		}
+		return camelKeys(decoded)
+	}
+	return value
+}
+
+// camelKey turns "delivery_fee" into "deliveryFee"; a leading underscore, as
+// in "_source", is kept.
+func camelKey(key string) string {
+	if !snakeKeyPattern.MatchString(key) {
+		return key
+	}
+	prefix := ""
+	if strings.HasPrefix(key, "_") {
+		prefix, key = "_", key[1:]
+	}
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return prefix + strings.Join(parts, "")
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -20,6 +21,15 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatYAML  Format = "yaml"
+	// FormatHASensor is the Home Assistant command-line sensor shape; only some
+	// commands can map their data onto it.
+	FormatHASensor Format = "ha-sensor"
+	// FormatBeancount is plain-text accounting entries; only order export renders it.
+	FormatBeancount Format = "beancount"
+	// FormatICS is an iCalendar file; only favourite venue hours render it.
+	FormatICS Format = "ics"
+	// FormatMarkdown is a GitHub-flavored markdown report; only digest renders it.
+	FormatMarkdown Format = "markdown"
 )
 
 // ParseFormat validates format values.
@@ -31,6 +41,14 @@ func ParseFormat(v string) (Format, error) {
 		return FormatJSON, nil
 	case FormatYAML:
 		return FormatYAML, nil
+	case FormatHASensor:
+		return FormatHASensor, nil
+	case FormatBeancount:
+		return FormatBeancount, nil
+	case FormatICS:
+		return FormatICS, nil
+	case FormatMarkdown:
+		return FormatMarkdown, nil
 	default:
 		return "", fmt.Errorf("unsupported format %q", v)
 	}
@@ -50,9 +68,12 @@ type Envelope struct {
 	Data     any            `json:"data" yaml:"data"`
 	Warnings []string       `json:"warnings" yaml:"warnings"`
 	Error    map[string]any `json:"error,omitempty" yaml:"error,omitempty"`
+	// keyNaming is how RenderPayload names keys; see WithKeyNaming.
+	keyNaming KeyNaming
 }
 
-// BuildEnvelope constructs a response envelope.
+// BuildEnvelope constructs a response envelope. Timestamps in data are
+// rendered as set with SetTimeDisplay.
 func BuildEnvelope(profile, locale string, data any, warnings []string, errPayload map[string]any) Envelope {
 	env := Envelope{
 		Meta: map[string]any{
@@ -61,7 +82,7 @@ func BuildEnvelope(profile, locale string, data any, warnings []string, errPaylo
 			"profile":      profile,
 			"locale":       locale,
 		},
-		Data:     data,
+		Data:     DisplayTimestamps(data),
 		Warnings: warnings,
 		Error:    errPayload,
 	}
@@ -71,8 +92,40 @@ func BuildEnvelope(profile, locale string, data any, warnings []string, errPaylo
 	return env
 }
 
-// RenderPayload renders payload in json/yaml format.
+// StampDataDigest returns env with meta.data_digest set over data as it will
+// be emitted, after key renaming, so it must run once data is final.
+func StampDataDigest(env Envelope) Envelope {
+	digest := DataDigest(ApplyKeyNaming(env.Data, env.keyNaming))
+	if digest == "" || env.Meta == nil {
+		return env
+	}
+	meta := make(map[string]any, len(env.Meta)+1)
+	for key, value := range env.Meta {
+		meta[key] = value
+	}
+	meta["data_digest"] = digest
+	env.Meta = meta
+	return env
+}
+
+// DataDigest returns a sha256 digest of the canonical JSON encoding of data.
+// Map keys are sorted by encoding/json, so identical data always yields the same digest.
+func DataDigest(data any) string {
+	if data == nil {
+		return ""
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// RenderPayload renders payload in json/yaml format, with keys named as set
+// with WithKeyNaming and meta.data_digest stamped over the rendered data.
 func RenderPayload(payload Envelope, format Format) (string, error) {
+	payload = applyEnvelopeKeyNaming(StampDataDigest(payload))
 	switch format {
 	case FormatJSON:
 		bytes, err := json.MarshalIndent(payload, "", "  ")
@@ -104,7 +157,8 @@ func WriteOutput(w io.Writer, text string, outputPath string) error {
 	return nil
 }
 
-// RenderTable renders plain text tables.
+// RenderTable renders plain text tables. Cells holding an RFC 3339
+// timestamp are rendered as set with SetTimeDisplay.
 func RenderTable(title string, headers []string, rows [][]string) string {
 	var b strings.Builder
 	if title != "" {
@@ -115,7 +169,15 @@ func RenderTable(title string, headers []string, rows [][]string) string {
 		b.WriteString(strings.Join(headers, "\t"))
 		b.WriteByte('\n')
 	}
+	active := TimeDisplayActive()
 	for _, row := range rows {
+		if active {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = displayTimestampCell(cell)
+			}
+			row = cells
+		}
 		b.WriteString(strings.Join(row, "\t"))
 		b.WriteByte('\n')
 	}
@@ -1,8 +1,10 @@
 package output_test
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/service/output"
 )
@@ -47,3 +49,334 @@ func TestRenderPayload(t *testing.T) {
 		t.Fatalf("expected yaml payload to include profile, got %s", yamlPayload)
 	}
 }
+
+func TestDataDigestIsStableAcrossMapOrder(t *testing.T) {
+	first := map[string]any{"b": []any{1, 2}, "a": map[string]any{"y": true, "x": "v"}}
+	second := map[string]any{"a": map[string]any{"x": "v", "y": true}, "b": []any{1, 2}}
+	digest := output.DataDigest(first)
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Fatalf("expected sha256 digest, got %q", digest)
+	}
+	if digest != output.DataDigest(second) {
+		t.Fatalf("expected identical digests for identical data")
+	}
+	if digest == output.DataDigest(map[string]any{"b": []any{2, 1}}) {
+		t.Fatalf("expected row order to change the digest")
+	}
+
+	env := output.StampDataDigest(output.BuildEnvelope("default", "en-FI", first, nil, nil))
+	if env.Meta["data_digest"] != digest {
+		t.Fatalf("expected envelope data_digest %q, got %v", digest, env.Meta["data_digest"])
+	}
+	if _, ok := output.StampDataDigest(output.BuildEnvelope("default", "en-FI", nil, nil, nil)).Meta["data_digest"]; ok {
+		t.Fatalf("expected no data_digest for error envelopes")
+	}
+}
+
+func TestRenderedDataDigestMatchesEmittedData(t *testing.T) {
+	env := output.BuildEnvelope("default", "en-FI", map[string]any{"venue_id": "v1", "delivery_fee": map[string]any{"amount_minor": 390}}, nil, nil).WithKeyNaming(output.KeyNamingCamel)
+	rendered, err := output.RenderPayload(env, output.FormatJSON)
+	if err != nil {
+		t.Fatalf("render json failed: %v", err)
+	}
+	var emitted struct {
+		Meta map[string]any `json:"meta"`
+		Data any            `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(rendered), &emitted); err != nil {
+		t.Fatalf("decode rendered payload: %v", err)
+	}
+	if !strings.Contains(rendered, `"venueId"`) {
+		t.Fatalf("expected camelCase data, got %s", rendered)
+	}
+	if got, want := emitted.Meta["dataDigest"], output.DataDigest(emitted.Data); got != want {
+		t.Fatalf("expected the digest of the emitted data %q, got %v", want, got)
+	}
+	if _, ok := env.Meta["data_digest"]; ok {
+		t.Fatalf("expected RenderPayload to leave the caller's meta untouched")
+	}
+}
+
+func TestRenderPayloadIsByteIdenticalForIdenticalData(t *testing.T) {
+	env := output.BuildEnvelope("default", "en-FI", map[string]any{"z": 1, "a": []any{"x"}, "m": map[string]any{"k2": 2, "k1": 1}}, nil, nil)
+	for _, format := range []output.Format{output.FormatJSON, output.FormatYAML} {
+		first, err := output.RenderPayload(env, format)
+		if err != nil {
+			t.Fatalf("render %s failed: %v", format, err)
+		}
+		for range 5 {
+			again, _ := output.RenderPayload(env, format)
+			if again != first {
+				t.Fatalf("expected byte-identical %s output", format)
+			}
+		}
+		if strings.Index(first, "k1") > strings.Index(first, "k2") {
+			t.Fatalf("expected sorted keys in %s output:\n%s", format, first)
+		}
+	}
+}
+
+func TestParseTablesRoundTripsRenderedBlocks(t *testing.T) {
+	text := output.RenderTable("Venues", []string{"Name", "Slug"}, [][]string{{"A", "a"}, {"B", "b"}}) +
+		"\n\n" + output.RenderTable("Empty", []string{"Field"}, nil)
+
+	tables := output.ParseTables(text)
+	if len(tables) != 2 || len(tables[0].Rows) != 2 || len(tables[1].Rows) != 0 {
+		t.Fatalf("unexpected tables: %#v", tables)
+	}
+	if got := output.RenderTables(tables); got != text {
+		t.Fatalf("round trip changed text:\n%s\n---\n%s", text, got)
+	}
+}
+
+func TestTruncateRowsAddsHint(t *testing.T) {
+	table := output.Table{Title: "Venues", Headers: []string{"Name"}, Rows: [][]string{{"A"}, {"B"}, {"C"}}}
+	truncated := table.TruncateRows(1)
+	if len(truncated.Rows) != 1 || truncated.Note != "... 2 more rows, use --limit/--offset" {
+		t.Fatalf("unexpected truncation: %#v", truncated)
+	}
+	if same := table.TruncateRows(0); len(same.Rows) != 3 || same.Note != "" {
+		t.Fatalf("expected no truncation for 0, got %#v", same)
+	}
+}
+
+func TestSelectColumnsKeepsRequestedOrder(t *testing.T) {
+	table := output.Table{
+		Title:   "Items",
+		Headers: []string{"Item ID", "Name", "Price", "Rating"},
+		Rows:    [][]string{{"i1", "Whopper", "7.95 EUR", "9.1"}},
+	}
+	selected := table.SelectColumns([]string{"price", "item-id"})
+	if strings.Join(selected.Headers, ",") != "Price,Item ID" || strings.Join(selected.Rows[0], ",") != "7.95 EUR,i1" {
+		t.Fatalf("unexpected selection: %#v", selected)
+	}
+	if same := table.SelectColumns([]string{"missing"}); len(same.Headers) != 4 {
+		t.Fatalf("expected tables without a match to stay unchanged, got %#v", same)
+	}
+}
+
+func TestTruncateCellsMarksCut(t *testing.T) {
+	table := output.Table{Headers: []string{"Name"}, Rows: [][]string{{"Kana Hampurilainen"}, {"Short"}}}
+	truncated := table.TruncateCells(8)
+	if truncated.Rows[0][0] != "Kana Ha…" || truncated.Rows[1][0] != "Short" {
+		t.Fatalf("unexpected truncation: %#v", truncated.Rows)
+	}
+	if table.Rows[0][0] != "Kana Hampurilainen" {
+		t.Fatal("expected the original table to stay untouched")
+	}
+}
+
+func TestCapColumnsUsesMiddleEllipsis(t *testing.T) {
+	promo := "Free delivery on orders over 20 EUR with Wolt+ this weekend only"
+	table := output.Table{Title: "Venues", Headers: []string{"Name", "Promotions"}, Rows: [][]string{{"Kana Hampurilainen", promo}}}
+
+	capped, changed := table.CapColumns(output.ColumnWidths(map[string]int{"Name": 9}, false))
+	if !changed || capped.Rows[0][0] != "Kana…inen" {
+		t.Fatalf("expected the name cut in the middle, got %q", capped.Rows[0][0])
+	}
+	if cell := capped.Rows[0][1]; len([]rune(cell)) > 48 || !strings.HasPrefix(cell, "Free delivery") || !strings.HasSuffix(cell, "weekend only") {
+		t.Fatalf("expected the default promotions cap of 48, got %q", cell)
+	}
+	if table.Rows[0][0] != "Kana Hampurilainen" {
+		t.Fatal("expected the original table to stay untouched")
+	}
+
+	if full, changed := table.CapColumns(output.ColumnWidths(map[string]int{"Name": 9}, true)); changed || full.Rows[0][1] != promo {
+		t.Fatalf("expected full width cells, got %#v", full.Rows)
+	}
+	if lifted, _ := table.CapColumns(output.ColumnWidths(map[string]int{"promotions": 0}, false)); lifted.Rows[0][1] != promo {
+		t.Fatalf("expected a 0 width to lift the cap, got %q", lifted.Rows[0][1])
+	}
+	if _, err := output.ParseColumnWidths([]string{"name"}); err == nil {
+		t.Fatal("expected a value without a width to fail")
+	}
+}
+
+func TestRenderLongTablesAlignsKeys(t *testing.T) {
+	table := output.Table{
+		Title:   "Items",
+		Headers: []string{"Name", "Price"},
+		Rows:    [][]string{{"Whopper", "7.95 EUR"}, {"Fries", ""}},
+		Note:    "... 1 more rows, use --limit/--offset",
+	}
+	want := "Items\nName:  Whopper\nPrice: 7.95 EUR\n\nName:  Fries\nPrice:\n... 1 more rows, use --limit/--offset"
+	if got := output.RenderLongTables([]output.Table{table}); got != want {
+		t.Fatalf("unexpected long layout:\n%s", got)
+	}
+}
+
+func TestSQLiteUpsertScript(t *testing.T) {
+	table := output.SQLiteTable{
+		Name: "venues",
+		Key:  []string{"venue_id"},
+		Columns: []output.SQLiteColumn{
+			{Name: "venue_id", Type: "TEXT NOT NULL"},
+			{Name: "name", Type: "TEXT"},
+			{Name: "rating", Type: "REAL"},
+			{Name: "wolt_plus", Type: "INTEGER"},
+		},
+	}
+	seenAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	script := output.SQLiteUpsertScript(table, [][]any{{"v1", "Mama's Kitchen", 9.2, true}, {"v2", nil, nil, false}}, seenAt)
+
+	for _, want := range []string{
+		"BEGIN;\nCREATE TABLE IF NOT EXISTS venues (",
+		"first_seen_at TEXT NOT NULL,\n  last_seen_at TEXT NOT NULL,\n  PRIMARY KEY (venue_id)",
+		"VALUES ('v1', 'Mama''s Kitchen', 9.2, 1, '2026-03-01T12:00:00Z', '2026-03-01T12:00:00Z')",
+		"VALUES ('v2', NULL, NULL, 0, ",
+		"ON CONFLICT (venue_id) DO UPDATE SET name = excluded.name, rating = excluded.rating, wolt_plus = excluded.wolt_plus, last_seen_at = excluded.last_seen_at;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Fatalf("expected script to contain %q:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "first_seen_at = excluded") {
+		t.Fatalf("first_seen_at must keep its original value:\n%s", script)
+	}
+	if !strings.HasSuffix(script, "COMMIT;\n") {
+		t.Fatalf("expected script to end with COMMIT:\n%s", script)
+	}
+}
+
+func TestRenderBeancount(t *testing.T) {
+	if !output.ValidBeancountAccount("Expenses:Food:Delivery") || output.ValidBeancountAccount("Food:Delivery") || output.ValidBeancountAccount("Expenses:food") {
+		t.Fatalf("unexpected account validation")
+	}
+	text := output.RenderBeancount([]output.BeancountTransaction{{
+		Date:           time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC),
+		Payee:          `Burger "Joint"`,
+		Narration:      "2x Burger",
+		Tags:           []string{"wolt", "delivery-fee"},
+		Meta:           [][2]string{{"purchase_id", "purchase-2"}},
+		Account:        "Expenses:Food:Delivery",
+		FundingAccount: "Liabilities:CreditCard",
+		Amount:         2405,
+		Currency:       "EUR",
+	}}, []string{"exported"})
+	expected := strings.Join([]string{
+		"; exported",
+		"",
+		`2026-02-20 * "Burger \"Joint\"" "2x Burger" #wolt #delivery-fee`,
+		`  purchase_id: "purchase-2"`,
+		"  Expenses:Food:Delivery  24.05 EUR",
+		"  Liabilities:CreditCard",
+	}, "\n")
+	if text != expected {
+		t.Fatalf("unexpected beancount output:\n%s", text)
+	}
+}
+
+func TestRenderICS(t *testing.T) {
+	text := output.RenderICS("Favourites", []output.ICSEvent{{
+		UID:         "venue-1-1@wolt-cli",
+		Summary:     "Burger, Place open",
+		Description: strings.Repeat("long description ", 6),
+		Start:       time.Date(2026, 2, 16, 10, 0, 0, 0, time.FixedZone("EET", 2*3600)),
+		End:         time.Date(2026, 2, 16, 21, 0, 0, 0, time.UTC),
+	}}, [][2]string{{"warning", "one; two"}}, time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC))
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"X-WR-CALNAME:Favourites",
+		`X-WOLT-WARNING:one\; two`,
+		"DTSTAMP:20260216T090000Z",
+		"DTSTART:20260216T080000Z",
+		"DTEND:20260216T210000Z",
+		`SUMMARY:Burger\, Place open`,
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(text, line+"\r\n") {
+			t.Fatalf("expected line %q in:\n%s", line, text)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("expected folded lines of at most 75 octets, got %q", line)
+		}
+	}
+	if !strings.Contains(text, "descrip\r\n tion") {
+		t.Fatalf("expected the long description to be folded:\n%s", text)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	text := output.RenderMarkdown("Wolt digest", []output.Table{
+		{Title: "Orders", Headers: []string{"Venue", "Total"}, Rows: [][]string{{"Fish | Chips", "€9.00"}}},
+		{Title: "Expiring credits", Headers: []string{"Method", "Expires"}},
+	}, []string{"partial\nresults"})
+
+	want := "# Wolt digest\n\n" +
+		"## Orders\n\n" +
+		"| Venue | Total |\n| --- | --- |\n| Fish \\| Chips | €9.00 |\n\n" +
+		"## Expiring credits\n\n_None._\n\n" +
+		"## Warnings\n\n- partial results"
+	if text != want {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestDisplayTimestampsFollowsTimezoneAndFormat(t *testing.T) {
+	now := time.Date(2026, 2, 16, 17, 0, 0, 0, time.UTC)
+	defer output.SetTimeDisplay(nil, output.TimeFormatRFC3339, nil)
+	data := map[string]any{
+		"closes_at": "2026-02-16T19:15:00Z",
+		"rows":      []any{map[string]any{"seen": "2026-02-13T12:00:00+02:00", "name": "2026-02-16"}},
+	}
+
+	if got := output.DisplayTimestamps(data); got.(map[string]any)["closes_at"] != "2026-02-16T19:15:00Z" {
+		t.Fatalf("expected timestamps unchanged by default, got %v", got)
+	}
+
+	helsinki, _ := time.LoadLocation("Europe/Helsinki")
+	output.SetTimeDisplay(helsinki, output.TimeFormatRFC3339, func() time.Time { return now })
+	if got := output.DisplayTimestamps(data).(map[string]any); got["closes_at"] != "2026-02-16T21:15:00+02:00" {
+		t.Fatalf("expected Helsinki time, got %v", got["closes_at"])
+	}
+
+	output.SetTimeDisplay(nil, output.TimeFormatRelative, func() time.Time { return now })
+	got := output.DisplayTimestamps(data).(map[string]any)
+	row := got["rows"].([]any)[0].(map[string]any)
+	if got["closes_at"] != "in 2h 15m" || row["seen"] != "3d 7h ago" || row["name"] != "2026-02-16" {
+		t.Fatalf("unexpected relative timestamps %v", got)
+	}
+	if data["closes_at"] != "2026-02-16T19:15:00Z" {
+		t.Fatalf("expected the input to stay untouched, got %v", data["closes_at"])
+	}
+
+	output.SetTimeDisplay(nil, output.TimeFormatUnix, nil)
+	table := output.RenderTable("Hours", []string{"Closes"}, [][]string{{"2026-02-16T19:15:00Z"}})
+	if !strings.HasSuffix(table, "\n1771269300") {
+		t.Fatalf("expected unix seconds in the table cell, got %q", table)
+	}
+	if _, err := output.ParseTimeFormat("epoch"); err == nil {
+		t.Fatal("expected an unknown time format to fail")
+	}
+}
+
+func TestRenderPayloadCamelCasesKeys(t *testing.T) {
+	data := map[string]any{
+		"delivery_fee": map[string]any{"formatted_amount": "€2.90"},
+		"items":        []any{map[string]any{"venue_id": "v1", "_source": map[string]any{"wolt_plus": "front_page"}}},
+		"by_profile":   map[string]any{"work-laptop": 1, "5f3a": 2},
+	}
+	env := output.BuildEnvelope("default", "en-FI", data, nil, map[string]any{"code": "X", "hint_url": "u"}).WithKeyNaming(output.KeyNamingCamel)
+	rendered, err := output.RenderPayload(env, output.FormatJSON)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{`"deliveryFee"`, `"formattedAmount"`, `"venueId"`, `"_source"`, `"woltPlus"`, `"requestId"`, `"hintUrl"`, `"work-laptop"`, `"5f3a"`} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected %s in camelCase output:\n%s", want, rendered)
+		}
+	}
+	if strings.Contains(rendered, "_id") || strings.Contains(rendered, "delivery_fee") {
+		t.Fatalf("expected no snake_case keys left:\n%s", rendered)
+	}
+	if env.Data.(map[string]any)["delivery_fee"] == nil {
+		t.Fatalf("expected rendering to leave the envelope untouched")
+	}
+
+	if _, err := output.ParseKeyNaming("kebab"); err == nil {
+		t.Fatalf("expected kebab naming to be rejected")
+	}
+}
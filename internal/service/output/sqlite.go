@@ -0,0 +1,99 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLiteColumn is one typed column of an export table. Type is a SQLite type
+// name such as TEXT, INTEGER or REAL.
+type SQLiteColumn struct {
+	Name string
+	Type string
+}
+
+// SQLiteTable describes an export table keyed by Key columns.
+type SQLiteTable struct {
+	Name    string
+	Columns []SQLiteColumn
+	Key     []string
+}
+
+// SQLiteUpsertScript returns a transaction that creates table when missing and
+// upserts rows, one value per column in Columns order. Every row gets
+// first_seen_at on insert and last_seen_at on each write.
+func SQLiteUpsertScript(table SQLiteTable, rows [][]any, seenAt time.Time) string {
+	names := make([]string, 0, len(table.Columns)+2)
+	definitions := make([]string, 0, len(table.Columns)+3)
+	for _, column := range table.Columns {
+		names = append(names, column.Name)
+		definitions = append(definitions, column.Name+" "+column.Type)
+	}
+	names = append(names, "first_seen_at", "last_seen_at")
+	definitions = append(definitions, "first_seen_at TEXT NOT NULL", "last_seen_at TEXT NOT NULL")
+	definitions = append(definitions, "PRIMARY KEY ("+strings.Join(table.Key, ", ")+")")
+
+	updates := []string{}
+	for _, column := range table.Columns {
+		if !slices.Contains(table.Key, column.Name) {
+			updates = append(updates, column.Name+" = excluded."+column.Name)
+		}
+	}
+	updates = append(updates, "last_seen_at = excluded.last_seen_at")
+
+	var b strings.Builder
+	b.WriteString("BEGIN;\n")
+	fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n  %s\n);\n", table.Name, strings.Join(definitions, ",\n  "))
+	seen := sqliteLiteral(seenAt.UTC().Format(time.RFC3339))
+	for _, row := range rows {
+		values := make([]string, 0, len(names))
+		for idx := range table.Columns {
+			var value any
+			if idx < len(row) {
+				value = row[idx]
+			}
+			values = append(values, sqliteLiteral(value))
+		}
+		values = append(values, seen, seen)
+		fmt.Fprintf(
+			&b,
+			"INSERT INTO %s (%s) VALUES (%s)\n  ON CONFLICT (%s) DO UPDATE SET %s;\n",
+			table.Name,
+			strings.Join(names, ", "),
+			strings.Join(values, ", "),
+			strings.Join(table.Key, ", "),
+			strings.Join(updates, ", "),
+		)
+	}
+	b.WriteString("COMMIT;\n")
+	return b.String()
+}
+
+func sqliteLiteral(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if typed {
+			return "1"
+		}
+		return "0"
+	case int:
+		return strconv.Itoa(typed)
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			return "NULL"
+		}
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case string:
+		return "'" + strings.ReplaceAll(typed, "'", "''") + "'"
+	default:
+		return sqliteLiteral(fmt.Sprint(typed))
+	}
+}
@@ -0,0 +1,168 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table is the structured form of one RenderTable block.
+type Table struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+	Note    string
+}
+
+// ParseTables splits text produced by RenderTable calls joined with blank lines back
+// into tables. Blocks are a title line, a tab-separated header line and one
+// tab-separated line per row.
+func ParseTables(text string) []Table {
+	blocks := strings.Split(strings.TrimRight(text, "\n"), "\n\n")
+	tables := make([]Table, 0, len(blocks))
+	for _, block := range blocks {
+		lines := strings.Split(block, "\n")
+		table := Table{Title: lines[0]}
+		if len(lines) > 1 {
+			table.Headers = strings.Split(lines[1], "\t")
+		}
+		for _, line := range lines[min(2, len(lines)):] {
+			table.Rows = append(table.Rows, strings.Split(line, "\t"))
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// RenderTables renders tables in RenderTable format, separated by blank lines.
+func RenderTables(tables []Table) string {
+	blocks := make([]string, 0, len(tables))
+	for _, table := range tables {
+		block := RenderTable(table.Title, table.Headers, table.Rows)
+		if table.Note != "" {
+			block += "\n" + table.Note
+		}
+		blocks = append(blocks, block)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// RenderLongTables renders every row as a vertical block of aligned "Header: value"
+// lines under the table title, with a blank line between rows.
+func RenderLongTables(tables []Table) string {
+	blocks := make([]string, 0, len(tables))
+	for _, table := range tables {
+		width := 0
+		for _, header := range table.Headers {
+			width = max(width, utf8.RuneCountInString(header))
+		}
+		lines := []string{table.Title}
+		for idx, row := range table.Rows {
+			if idx > 0 {
+				lines = append(lines, "")
+			}
+			for col, header := range table.Headers {
+				value := ""
+				if col < len(row) {
+					value = row[col]
+				}
+				padding := strings.Repeat(" ", width-utf8.RuneCountInString(header))
+				lines = append(lines, strings.TrimRight(header+":"+padding+" "+value, " "))
+			}
+		}
+		if table.Note != "" {
+			lines = append(lines, table.Note)
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// TruncateRows keeps the first maxRows rows and notes how many were cut. A
+// non-positive maxRows keeps every row.
+func (t Table) TruncateRows(maxRows int) Table {
+	if maxRows <= 0 || len(t.Rows) <= maxRows {
+		return t
+	}
+	hidden := len(t.Rows) - maxRows
+	t.Rows = t.Rows[:maxRows]
+	t.Note = fmt.Sprintf("... %d more rows, use --limit/--offset", hidden)
+	return t
+}
+
+// ColumnIndex returns the header index matching name, or -1. Names match headers
+// case-insensitively with spaces, dashes and underscores treated alike.
+func (t Table) ColumnIndex(name string) int {
+	want := normalizeColumnName(name)
+	for idx, header := range t.Headers {
+		if normalizeColumnName(header) == want {
+			return idx
+		}
+	}
+	return -1
+}
+
+// SelectColumns keeps the named columns in the given order. Tables without any of
+// them are returned unchanged so summary blocks survive a selection aimed at rows.
+func (t Table) SelectColumns(names []string) Table {
+	indexes := make([]int, 0, len(names))
+	for _, name := range names {
+		if idx := t.ColumnIndex(name); idx >= 0 {
+			indexes = append(indexes, idx)
+		}
+	}
+	if len(indexes) == 0 {
+		return t
+	}
+	pick := func(cells []string) []string {
+		out := make([]string, 0, len(indexes))
+		for _, idx := range indexes {
+			if idx < len(cells) {
+				out = append(out, cells[idx])
+			} else {
+				out = append(out, "")
+			}
+		}
+		return out
+	}
+	selected := t
+	selected.Headers = pick(t.Headers)
+	selected.Rows = make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		selected.Rows = append(selected.Rows, pick(row))
+	}
+	return selected
+}
+
+// TruncateCells shortens cells longer than width runes, marking the cut with an ellipsis.
+func (t Table) TruncateCells(width int) Table {
+	if width <= 0 {
+		return t
+	}
+	truncated := t
+	truncated.Rows = make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for idx, cell := range row {
+			cells[idx] = truncateCell(cell, width)
+		}
+		truncated.Rows = append(truncated.Rows, cells)
+	}
+	return truncated
+}
+
+func truncateCell(cell string, width int) string {
+	if utf8.RuneCountInString(cell) <= width {
+		return cell
+	}
+	runes := []rune(cell)
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func normalizeColumnName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
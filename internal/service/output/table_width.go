@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultColumnWidths caps the table columns that most often hold long free
+// text. Keys are normalized header names, as ColumnIndex compares them.
+var DefaultColumnWidths = map[string]int{
+	"name":       40,
+	"promotions": 48,
+	"discounts":  48,
+	"top_items":  48,
+}
+
+// ColumnWidths returns the per-column maximum widths for a table view:
+// DefaultColumnWidths with overrides on top, where a width of 0 lifts the cap
+// of that column. fullWidth turns every cap off.
+func ColumnWidths(overrides map[string]int, fullWidth bool) map[string]int {
+	if fullWidth {
+		return map[string]int{}
+	}
+	widths := maps.Clone(DefaultColumnWidths)
+	for name, width := range overrides {
+		widths[normalizeColumnName(name)] = width
+	}
+	return widths
+}
+
+// ParseColumnWidths reads --col-width values of the form column=width.
+func ParseColumnWidths(values []string) (map[string]int, error) {
+	widths := map[string]int{}
+	for _, value := range values {
+		name, raw, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("--col-width %q must look like column=width, for example promotions=30", value)
+		}
+		width, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || width < 0 {
+			return nil, fmt.Errorf("--col-width %s must be a non-negative number of characters, got %q", name, strings.TrimSpace(raw))
+		}
+		widths[normalizeColumnName(name)] = width
+	}
+	return widths, nil
+}
+
+// CapColumns shortens cells wider than their column's cap in widths, as
+// ColumnWidths returns them, with EllipsizeMiddle. It reports whether any cell
+// changed.
+func (t Table) CapColumns(widths map[string]int) (Table, bool) {
+	caps := make([]int, len(t.Headers))
+	capped := false
+	for idx, header := range t.Headers {
+		if width := widths[normalizeColumnName(header)]; width > 0 {
+			caps[idx] = width
+			capped = true
+		}
+	}
+	if !capped {
+		return t, false
+	}
+	changed := false
+	out := t
+	out.Rows = make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for idx, cell := range row {
+			if idx < len(caps) {
+				if short := EllipsizeMiddle(cell, caps[idx]); short != cell {
+					cell, changed = short, true
+				}
+			}
+			cells[idx] = cell
+		}
+		out.Rows = append(out.Rows, cells)
+	}
+	return out, changed
+}
+
+// EllipsizeMiddle shortens cell to width runes by replacing its middle with an
+// ellipsis, so both the start and the end of the text stay readable.
+func EllipsizeMiddle(cell string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(cell) <= width {
+		return cell
+	}
+	if width == 1 {
+		return "…"
+	}
+	runes := []rune(cell)
+	head := width / 2
+	tail := width - 1 - head
+	return strings.TrimRight(string(runes[:head]), " ") + "…" + strings.TrimLeft(string(runes[len(runes)-tail:]), " ")
+}
@@ -0,0 +1,178 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeFormat selects how timestamps in envelopes and tables are written.
+type TimeFormat string
+
+const (
+	TimeFormatRFC3339  TimeFormat = "rfc3339"
+	TimeFormatUnix     TimeFormat = "unix"
+	TimeFormatRelative TimeFormat = "relative"
+)
+
+// ParseTimeFormat validates a --time-format value; empty means rfc3339.
+func ParseTimeFormat(value string) (TimeFormat, error) {
+	switch TimeFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", TimeFormatRFC3339:
+		return TimeFormatRFC3339, nil
+	case TimeFormatUnix:
+		return TimeFormatUnix, nil
+	case TimeFormatRelative:
+		return TimeFormatRelative, nil
+	}
+	return "", fmt.Errorf("unsupported time format %q; use rfc3339, unix, or relative", value)
+}
+
+var (
+	timeDisplayMu       sync.RWMutex
+	timeDisplayLocation *time.Location
+	timeDisplayFormat   = TimeFormatRFC3339
+	timeDisplayNow      = time.Now
+)
+
+// SetTimeDisplay sets the timezone and format for RFC 3339 timestamps in
+// envelope data and table cells. A nil location with rfc3339 keeps them as
+// each command wrote them. now is the reference for relative times; nil
+// means time.Now.
+func SetTimeDisplay(location *time.Location, format TimeFormat, now func() time.Time) {
+	timeDisplayMu.Lock()
+	defer timeDisplayMu.Unlock()
+	timeDisplayLocation = location
+	timeDisplayFormat = format
+	if timeDisplayFormat == "" {
+		timeDisplayFormat = TimeFormatRFC3339
+	}
+	timeDisplayNow = now
+	if timeDisplayNow == nil {
+		timeDisplayNow = time.Now
+	}
+}
+
+// TimeDisplayActive reports whether SetTimeDisplay changes timestamps.
+func TimeDisplayActive() bool {
+	timeDisplayMu.RLock()
+	defer timeDisplayMu.RUnlock()
+	return timeDisplayLocation != nil || timeDisplayFormat != TimeFormatRFC3339
+}
+
+// DisplayTime renders t in the configured timezone and format: an RFC 3339
+// string, unix seconds, or a relative phrase such as "in 2h 15m".
+func DisplayTime(t time.Time) any {
+	timeDisplayMu.RLock()
+	location, format, now := timeDisplayLocation, timeDisplayFormat, timeDisplayNow
+	timeDisplayMu.RUnlock()
+	if location != nil {
+		t = t.In(location)
+	}
+	switch format {
+	case TimeFormatUnix:
+		return t.Unix()
+	case TimeFormatRelative:
+		return relativeTime(t.Sub(now()))
+	}
+	return t.Format(time.RFC3339)
+}
+
+// DisplayTimestamps returns a copy of value in which every string that parses
+// as an RFC 3339 timestamp is rendered with DisplayTime. Other values are kept.
+func DisplayTimestamps(value any) any {
+	if !TimeDisplayActive() {
+		return value
+	}
+	return displayTimestamps(value)
+}
+
+func displayTimestamps(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[key] = displayTimestamps(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = displayTimestamps(item)
+		}
+		return out
+	case []map[string]any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = displayTimestamps(item)
+		}
+		return out
+	case string:
+		if parsed, ok := parseTimestamp(typed); ok {
+			return DisplayTime(parsed)
+		}
+	}
+	return value
+}
+
+// displayTimestampCell renders a table cell holding exactly one RFC 3339
+// timestamp; any other cell is returned unchanged.
+func displayTimestampCell(cell string) string {
+	parsed, ok := parseTimestamp(cell)
+	if !ok {
+		return cell
+	}
+	switch rendered := DisplayTime(parsed).(type) {
+	case int64:
+		return strconv.FormatInt(rendered, 10)
+	case string:
+		return rendered
+	}
+	return cell
+}
+
+func parseTimestamp(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	// The cheapest checks first: RFC 3339 needs a date, a T, and a zone.
+	if len(value) < len("2006-01-02T15:04:05Z") || value[4] != '-' || value[10] != 'T' {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// relativeTime writes d as its largest unit and the next one when non-zero:
+// "in 2h 15m", "3d ago", or "now" within a minute.
+func relativeTime(d time.Duration) string {
+	future := d >= 0
+	if !future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "now"
+	}
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute}
+	labels := []string{"d", "h", "m"}
+	text := ""
+	for i, unit := range units {
+		if d < unit {
+			continue
+		}
+		text = fmt.Sprintf("%d%s", d/unit, labels[i])
+		if i+1 < len(units) {
+			if rest := (d % unit) / units[i+1]; rest > 0 {
+				text += fmt.Sprintf(" %d%s", rest, labels[i+1])
+			}
+		}
+		break
+	}
+	if future {
+		return "in " + text
+	}
+	return text + " ago"
+}
@@ -0,0 +1,219 @@
+// Package schedule renders recurring-job definitions for systemd user timers,
+// launchd agents, and cron.
+package schedule
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Backend is a scheduler that runs recurring jobs.
+type Backend string
+
+const (
+	BackendSystemd Backend = "systemd"
+	BackendLaunchd Backend = "launchd"
+	BackendCron    Backend = "cron"
+)
+
+// MinInterval is the shortest supported schedule.
+const MinInterval = time.Minute
+
+// ParseBackend validates backend names.
+func ParseBackend(value string) (Backend, error) {
+	switch backend := Backend(strings.ToLower(strings.TrimSpace(value))); backend {
+	case BackendSystemd, BackendLaunchd, BackendCron:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("--backend must be one of: systemd, launchd, cron")
+	}
+}
+
+// DefaultBackend picks the native scheduler for goos.
+func DefaultBackend(goos string) Backend {
+	switch goos {
+	case "darwin":
+		return BackendLaunchd
+	case "linux":
+		return BackendSystemd
+	default:
+		return BackendCron
+	}
+}
+
+// Job is one recurring command.
+type Job struct {
+	// Name identifies the job in unit file names and labels, for example "track-run".
+	Name string
+	// Args is the full command line, executable first.
+	Args  []string
+	Every time.Duration
+}
+
+// File is one definition file to write.
+type File struct {
+	Path    string
+	Content string
+}
+
+// Plan is what installing a job takes: files to write and the command that
+// activates them. Cron has no file; Line holds the crontab entry instead.
+type Plan struct {
+	Backend  Backend
+	Files    []File
+	Line     string
+	Activate string
+}
+
+// FormatInterval renders every without zero units, for example 30m or 1h30m.
+func FormatInterval(every time.Duration) string {
+	text := strings.TrimSuffix(every.String(), "0s")
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// JobName derives a file-name-safe job name from command words.
+func JobName(words []string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.Join(words, "-")) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+		case r == '_' || r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// Build renders job for backend. configDir is the user config directory that
+// holds systemd/user; homeDir holds Library/LaunchAgents.
+func Build(backend Backend, job Job, configDir string, homeDir string) (Plan, error) {
+	if job.Every < MinInterval {
+		return Plan{}, fmt.Errorf("--every must be at least %s", MinInterval)
+	}
+	if job.Every%time.Minute != 0 {
+		return Plan{}, fmt.Errorf("--every must be a whole number of minutes")
+	}
+	switch backend {
+	case BackendSystemd:
+		return buildSystemd(job, configDir), nil
+	case BackendLaunchd:
+		return buildLaunchd(job, homeDir), nil
+	case BackendCron:
+		return buildCron(job)
+	default:
+		return Plan{}, fmt.Errorf("unsupported backend %q", backend)
+	}
+}
+
+func buildSystemd(job Job, configDir string) Plan {
+	unit := "wolt-" + job.Name
+	dir := filepath.Join(configDir, "systemd", "user")
+	seconds := int(job.Every / time.Second)
+	service := fmt.Sprintf(
+		"[Unit]\nDescription=wolt %s\n\n[Service]\nType=oneshot\nExecStart=%s\n",
+		strings.Join(job.Args[1:], " "),
+		systemdCommandLine(job.Args),
+	)
+	timer := fmt.Sprintf(
+		"[Unit]\nDescription=Run wolt %s every %s\n\n[Timer]\nOnBootSec=%ds\nOnUnitActiveSec=%ds\nPersistent=true\n\n[Install]\nWantedBy=timers.target\n",
+		strings.Join(job.Args[1:], " "),
+		FormatInterval(job.Every),
+		seconds,
+		seconds,
+	)
+	return Plan{
+		Backend: BackendSystemd,
+		Files: []File{
+			{Path: filepath.Join(dir, unit+".service"), Content: service},
+			{Path: filepath.Join(dir, unit+".timer"), Content: timer},
+		},
+		Activate: fmt.Sprintf("systemctl --user daemon-reload && systemctl --user enable --now %s.timer", unit),
+	}
+}
+
+func buildLaunchd(job Job, homeDir string) Plan {
+	label := "com.wolt-cli." + job.Name
+	path := filepath.Join(homeDir, "Library", "LaunchAgents", label+".plist")
+	var args strings.Builder
+	for _, arg := range job.Args {
+		fmt.Fprintf(&args, "    <string>%s</string>\n", xmlEscape(arg))
+	}
+	content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+  <key>Label</key>
+  <string>%s</string>
+  <key>ProgramArguments</key>
+  <array>
+%s  </array>
+  <key>StartInterval</key>
+  <integer>%d</integer>
+  <key>RunAtLoad</key>
+  <true/>
+</dict>
+</plist>
+`, label, args.String(), int(job.Every/time.Second))
+	return Plan{
+		Backend:  BackendLaunchd,
+		Files:    []File{{Path: path, Content: content}},
+		Activate: fmt.Sprintf("launchctl load -w %s", shellQuote(path)),
+	}
+}
+
+func buildCron(job Job) (Plan, error) {
+	minutes := int(job.Every / time.Minute)
+	var spec string
+	switch {
+	case minutes < 60 && 60%minutes == 0:
+		spec = fmt.Sprintf("*/%d * * * *", minutes)
+	case minutes%60 == 0 && minutes/60 < 24 && 24%(minutes/60) == 0:
+		spec = fmt.Sprintf("0 */%d * * *", minutes/60)
+	case minutes == 24*60:
+		spec = "0 0 * * *"
+	default:
+		return Plan{}, fmt.Errorf("cron cannot repeat every %s; use minutes dividing an hour, hours dividing a day, or 24h", job.Every)
+	}
+	quoted := make([]string, 0, len(job.Args))
+	for _, arg := range job.Args {
+		// cron turns a bare % into a newline, even inside quotes.
+		quoted = append(quoted, strings.ReplaceAll(shellQuote(arg), "%", `\%`))
+	}
+	return Plan{
+		Backend:  BackendCron,
+		Line:     spec + " " + strings.Join(quoted, " "),
+		Activate: "crontab -e",
+	}, nil
+}
+
+// systemdCommandLine quotes args for ExecStart, which splits on whitespace and
+// honors double quotes.
+func systemdCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\"'\\$%") {
+			quoted = append(quoted, arg)
+			continue
+		}
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$", "%", "%%").Replace(arg)
+		quoted = append(quoted, `"`+escaped+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func shellQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n'\"\\$`!*?;&|<>()[]{}#~%") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func xmlEscape(value string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(value)
+}
@@ -0,0 +1,96 @@
+package schedule_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/schedule"
+)
+
+func TestBuildSystemdWritesServiceAndTimer(t *testing.T) {
+	job := schedule.Job{Name: "track-run", Args: []string{"/usr/local/bin/wolt", "track", "run", "--profile", "my work"}, Every: 30 * time.Minute}
+	plan, err := schedule.Build(schedule.BackendSystemd, job, "/home/me/.config", "/home/me")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(plan.Files) != 2 {
+		t.Fatalf("expected service and timer, got %#v", plan.Files)
+	}
+	service, timer := plan.Files[0], plan.Files[1]
+	if service.Path != filepath.Join("/home/me/.config", "systemd", "user", "wolt-track-run.service") {
+		t.Fatalf("unexpected service path %q", service.Path)
+	}
+	if !strings.Contains(service.Content, `ExecStart=/usr/local/bin/wolt track run --profile "my work"`) {
+		t.Fatalf("unexpected service:\n%s", service.Content)
+	}
+	if !strings.Contains(timer.Content, "OnUnitActiveSec=1800s") || !strings.Contains(timer.Content, "every 30m\n") {
+		t.Fatalf("unexpected timer:\n%s", timer.Content)
+	}
+	if !strings.HasSuffix(plan.Activate, "wolt-track-run.timer") {
+		t.Fatalf("unexpected activate command %q", plan.Activate)
+	}
+}
+
+func TestBuildLaunchdUsesStartInterval(t *testing.T) {
+	job := schedule.Job{Name: "track-run", Args: []string{"/usr/local/bin/wolt", "track", "run"}, Every: 90 * time.Minute}
+	plan, err := schedule.Build(schedule.BackendLaunchd, job, "", "/Users/me")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	file := plan.Files[0]
+	if file.Path != filepath.Join("/Users/me", "Library", "LaunchAgents", "com.wolt-cli.track-run.plist") {
+		t.Fatalf("unexpected plist path %q", file.Path)
+	}
+	if !strings.Contains(file.Content, "<integer>5400</integer>") || !strings.Contains(file.Content, "<string>track</string>") {
+		t.Fatalf("unexpected plist:\n%s", file.Content)
+	}
+}
+
+func TestBuildCron(t *testing.T) {
+	args := []string{"/usr/local/bin/wolt", "search", "items", "--query", "50% off"}
+	cases := map[time.Duration]string{
+		15 * time.Minute: "*/15 * * * * ",
+		2 * time.Hour:    "0 */2 * * * ",
+		24 * time.Hour:   "0 0 * * * ",
+	}
+	for every, prefix := range cases {
+		plan, err := schedule.Build(schedule.BackendCron, schedule.Job{Name: "search", Args: args, Every: every}, "", "")
+		if err != nil {
+			t.Fatalf("build %s: %v", every, err)
+		}
+		if !strings.HasPrefix(plan.Line, prefix) || !strings.HasSuffix(plan.Line, `'50\% off'`) {
+			t.Fatalf("unexpected cron line for %s: %q", every, plan.Line)
+		}
+		if len(plan.Files) != 0 {
+			t.Fatalf("cron must not write files, got %#v", plan.Files)
+		}
+	}
+	if _, err := schedule.Build(schedule.BackendCron, schedule.Job{Name: "search", Args: args, Every: 45 * time.Minute}, "", ""); err == nil {
+		t.Fatal("expected error for an interval cron cannot express")
+	}
+}
+
+func TestBuildRejectsShortOrFractionalIntervals(t *testing.T) {
+	for _, every := range []time.Duration{30 * time.Second, 90 * time.Second} {
+		job := schedule.Job{Name: "x", Args: []string{"wolt", "track", "run"}, Every: every}
+		if _, err := schedule.Build(schedule.BackendSystemd, job, "/c", "/h"); err == nil {
+			t.Fatalf("expected error for %s", every)
+		}
+	}
+}
+
+func TestFormatIntervalAndJobName(t *testing.T) {
+	for every, want := range map[time.Duration]string{30 * time.Minute: "30m", 2 * time.Hour: "2h", 90 * time.Minute: "1h30m"} {
+		if got := schedule.FormatInterval(every); got != want {
+			t.Fatalf("FormatInterval(%s) = %q, want %q", every, got, want)
+		}
+	}
+	if got := schedule.JobName([]string{"Track", "run"}); got != "track-run" {
+		t.Fatalf("unexpected job name %q", got)
+	}
+	if got := schedule.JobName([]string{"price watch!"}); got != "price-watch" {
+		t.Fatalf("unexpected job name %q", got)
+	}
+}
@@ -0,0 +1,250 @@
+// Package track keeps the local price-tracking watchlist and its observation log.
+package track
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	targetsFileName      = "targets.json"
+	observationsFileName = "observations.csv"
+)
+
+var observationColumns = []string{
+	"observed_at",
+	"venue_slug",
+	"item_id",
+	"name",
+	"amount",
+	"currency",
+	"original_amount",
+	"discounts",
+}
+
+// Target is one tracked venue item.
+type Target struct {
+	VenueSlug string    `json:"venue_slug"`
+	ItemID    string    `json:"item_id"`
+	Name      string    `json:"name"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// Observation is one recorded price reading. OriginalAmount is 0 when the item
+// was not discounted.
+type Observation struct {
+	ObservedAt     time.Time
+	VenueSlug      string
+	ItemID         string
+	Name           string
+	Amount         int
+	Currency       string
+	OriginalAmount int
+	Discounts      []string
+}
+
+// Store reads and writes tracking data in one directory: the watchlist as JSON
+// and observations as an append-only CSV that spreadsheets can open directly.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// ObservationsPath returns the CSV observation log path.
+func (s *Store) ObservationsPath() string {
+	return filepath.Join(s.dir, observationsFileName)
+}
+
+// Targets returns the watchlist in insertion order.
+func (s *Store) Targets() ([]Target, error) {
+	payload, err := os.ReadFile(filepath.Join(s.dir, targetsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Target{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read tracked items: %w", err)
+	}
+	targets := []Target{}
+	if err := json.Unmarshal(payload, &targets); err != nil {
+		return nil, fmt.Errorf("decode tracked items: %w", err)
+	}
+	return targets, nil
+}
+
+// AddTarget appends target to the watchlist. It reports false when the venue
+// item is already tracked.
+func (s *Store) AddTarget(target Target) (bool, error) {
+	targets, err := s.Targets()
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range targets {
+		if existing.VenueSlug == target.VenueSlug && existing.ItemID == target.ItemID {
+			return false, nil
+		}
+	}
+	targets = append(targets, target)
+	payload, err := json.MarshalIndent(targets, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("encode tracked items: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return false, fmt.Errorf("create track directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, targetsFileName), append(payload, '\n'), 0o644); err != nil {
+		return false, fmt.Errorf("write tracked items: %w", err)
+	}
+	return true, nil
+}
+
+// Append adds observations to the CSV log, writing the header on first use.
+func (s *Store) Append(observations []Observation) error {
+	if len(observations) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create track directory: %w", err)
+	}
+	file, err := os.OpenFile(s.ObservationsPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open observations: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat observations: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	if info.Size() == 0 {
+		if err := writer.Write(observationColumns); err != nil {
+			return fmt.Errorf("write observations: %w", err)
+		}
+	}
+	for _, observation := range observations {
+		record := []string{
+			observation.ObservedAt.UTC().Format(time.RFC3339),
+			observation.VenueSlug,
+			observation.ItemID,
+			observation.Name,
+			strconv.Itoa(observation.Amount),
+			observation.Currency,
+			strconv.Itoa(observation.OriginalAmount),
+			strings.Join(observation.Discounts, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("write observations: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write observations: %w", err)
+	}
+	return nil
+}
+
+// Observations returns the recorded readings for itemID, oldest first.
+func (s *Store) Observations(itemID string) ([]Observation, error) {
+	file, err := os.Open(s.ObservationsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return []Observation{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open observations: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = len(observationColumns)
+	observations := []Observation{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read observations: %w", err)
+		}
+		if line == 1 || record[2] != itemID {
+			continue
+		}
+		observation, err := parseObservation(record)
+		if err != nil {
+			return nil, fmt.Errorf("read observations line %d: %w", line, err)
+		}
+		observations = append(observations, observation)
+	}
+	return observations, nil
+}
+
+func parseObservation(record []string) (Observation, error) {
+	observedAt, err := time.Parse(time.RFC3339, record[0])
+	if err != nil {
+		return Observation{}, err
+	}
+	amount, err := strconv.Atoi(record[4])
+	if err != nil {
+		return Observation{}, err
+	}
+	originalAmount, err := strconv.Atoi(record[6])
+	if err != nil {
+		return Observation{}, err
+	}
+	discounts := []string{}
+	for _, label := range strings.Split(record[7], ";") {
+		if trimmed := strings.TrimSpace(label); trimmed != "" {
+			discounts = append(discounts, trimmed)
+		}
+	}
+	return Observation{
+		ObservedAt:     observedAt,
+		VenueSlug:      record[1],
+		ItemID:         record[2],
+		Name:           record[3],
+		Amount:         amount,
+		Currency:       record[5],
+		OriginalAmount: originalAmount,
+		Discounts:      discounts,
+	}, nil
+}
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as one block character each, scaled between their
+// minimum and maximum. A flat series renders at the lowest tick.
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+	var b strings.Builder
+	for _, value := range values {
+		idx := 0
+		if high > low {
+			idx = (value - low) * (len(sparkTicks) - 1) / (high - low)
+		}
+		b.WriteRune(sparkTicks[idx])
+	}
+	return b.String()
+}
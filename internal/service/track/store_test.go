@@ -0,0 +1,71 @@
+package track_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/service/track"
+)
+
+func TestAddTargetSkipsDuplicates(t *testing.T) {
+	store := track.NewStore(t.TempDir())
+	target := track.Target{VenueSlug: "burger-place", ItemID: "item-1", Name: "Fries"}
+	if added, err := store.AddTarget(target); err != nil || !added {
+		t.Fatalf("expected first add to succeed, added=%v err=%v", added, err)
+	}
+	if added, err := store.AddTarget(target); err != nil || added {
+		t.Fatalf("expected duplicate add to be skipped, added=%v err=%v", added, err)
+	}
+	targets, err := store.Targets()
+	if err != nil || len(targets) != 1 || targets[0].Name != "Fries" {
+		t.Fatalf("unexpected targets %#v err=%v", targets, err)
+	}
+}
+
+func TestObservationsRoundTripThroughCSV(t *testing.T) {
+	store := track.NewStore(t.TempDir())
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := []track.Observation{
+		{ObservedAt: day, VenueSlug: "burger-place", ItemID: "item-1", Name: "Fries, large", Amount: 599, Currency: "EUR"},
+		{ObservedAt: day, VenueSlug: "burger-place", ItemID: "item-2", Name: "Shake", Amount: 450, Currency: "EUR"},
+	}
+	second := []track.Observation{
+		{ObservedAt: day.Add(24 * time.Hour), VenueSlug: "burger-place", ItemID: "item-1", Name: "Fries, large", Amount: 419, Currency: "EUR", OriginalAmount: 599, Discounts: []string{"-30%", "Wolt+"}},
+	}
+	if err := store.Append(first); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := store.Append(second); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	raw, err := os.ReadFile(store.ObservationsPath())
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if strings.Count(string(raw), "observed_at,") != 1 {
+		t.Fatalf("expected a single header row:\n%s", raw)
+	}
+
+	observations, err := store.Observations("item-1")
+	if err != nil {
+		t.Fatalf("observations: %v", err)
+	}
+	if len(observations) != 2 || observations[1].Amount != 419 || observations[1].OriginalAmount != 599 {
+		t.Fatalf("unexpected observations %#v", observations)
+	}
+	if observations[0].Name != "Fries, large" || strings.Join(observations[1].Discounts, "|") != "-30%|Wolt+" {
+		t.Fatalf("expected quoted fields to survive, got %#v", observations)
+	}
+}
+
+func TestSparklineScalesBetweenMinAndMax(t *testing.T) {
+	if got := track.Sparkline([]int{100, 200, 150, 100}); got != "▁█▄▁" {
+		t.Fatalf("unexpected sparkline %q", got)
+	}
+	if got := track.Sparkline([]int{5, 5}); got != "▁▁" {
+		t.Fatalf("expected flat sparkline, got %q", got)
+	}
+}
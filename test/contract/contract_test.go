@@ -0,0 +1,124 @@
+//go:build contract
+
+package contract_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/cli"
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+// Contract tests replay sanitized fixtures recorded from the live API (see
+// WOLT_RECORD_DIR) through the real gateway and command parsing code, so a
+// payload shape change upstream shows up here rather than in production.
+
+type fixedProfiles struct{}
+
+func (fixedProfiles) Find(context.Context, string) (domain.Profile, error) {
+	return domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 50.06, Lon: 19.94}}, nil
+}
+
+type fixedLocation struct{}
+
+func (fixedLocation) Get(context.Context, string) (domain.Location, error) {
+	return domain.Location{Lat: 50.06, Lon: 19.94}, nil
+}
+
+type emptyConfig struct{}
+
+func (emptyConfig) Path() string { return "/tmp/wolt-contract-config.json" }
+
+func (emptyConfig) Load(context.Context) (domain.Config, error) {
+	return domain.Config{}, errors.New("not found")
+}
+
+func (emptyConfig) Save(context.Context, domain.Config) error { return nil }
+
+func replayDeps() cli.Dependencies {
+	return cli.Dependencies{
+		Wolt: woltgateway.NewClient(
+			woltgateway.WithHTTPClient(woltgateway.NewReplayHTTPClient(filepath.Join("testdata", "fixtures"))),
+		),
+		Profiles: fixedProfiles{},
+		Location: fixedLocation{},
+		Config:   emptyConfig{},
+		Version:  "contract",
+	}
+}
+
+func TestCommandsParseRecordedFixtures(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     []string
+		nonEmpty []string
+		// partialOK marks commands whose optional enrichment calls are not recorded.
+		partialOK bool
+	}{
+		{name: "discover feed", args: []string{"discover", "feed", "--fast"}, nonEmpty: []string{"sections"}},
+		{name: "discover categories", args: []string{"discover", "categories"}, nonEmpty: []string{"categories"}},
+		{name: "search venues", args: []string{"search", "venues", "--query", "pizza"}, nonEmpty: []string{"items"}, partialOK: true},
+		{name: "venue hours", args: []string{"venue", "hours", "kfc-krakow-florianska-103102"}, nonEmpty: []string{"opening_windows"}},
+		{name: "venue resolve", args: []string{"venue", "resolve", "61cb1965f3fae657fa00f9c7"}, nonEmpty: []string{"slug", "public_url"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{}, tc.args...), "--address", "Florianska 1, Krakow", "--format", "json")
+			if code := cli.Execute(context.Background(), args, replayDeps(), &stdout, &stderr); code != 0 {
+				t.Fatalf("exit %d\nstdout:\n%s\nstderr:\n%s", code, stdout.String(), stderr.String())
+			}
+			var envelope map[string]any
+			if err := json.Unmarshal(stdout.Bytes(), &envelope); err != nil {
+				t.Fatalf("decode envelope: %v\n%s", err, stdout.String())
+			}
+			data, _ := envelope["data"].(map[string]any)
+			for _, key := range tc.nonEmpty {
+				if isEmptyValue(data[key]) {
+					t.Fatalf("expected non-empty data.%s parsed from fixtures, got %#v", key, data[key])
+				}
+			}
+			if tc.partialOK {
+				return
+			}
+			for _, warning := range asStrings(envelope["warnings"]) {
+				if strings.HasPrefix(warning, "partial results:") {
+					t.Fatalf("unexpected partial result against fixtures: %s", warning)
+				}
+			}
+		})
+	}
+}
+
+func isEmptyValue(value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(typed) == 0
+	case map[string]any:
+		return len(typed) == 0
+	case string:
+		return typed == ""
+	default:
+		return false
+	}
+}
+
+func asStrings(value any) []string {
+	items, _ := value.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, ok := item.(string); ok {
+			out = append(out, text)
+		}
+	}
+	return out
+}
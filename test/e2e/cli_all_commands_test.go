@@ -3,7 +3,12 @@ package e2e_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -248,6 +253,70 @@ func TestDiscoverFeedMergesDynamicPromotions(t *testing.T) {
 	}
 }
 
+func partialEnrichmentDeps() cli.Dependencies {
+	venue := buildVenue("venue-1", "plus-venue", "Plus Street")
+	sections := []domain.Section{
+		{
+			Name:  "popular",
+			Title: "Popular",
+			Items: []domain.Item{
+				{Title: "Plus Venue", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: venue},
+			},
+		},
+	}
+	return cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Krakow"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return sections, nil
+			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 503}
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue_raw": map[string]any{}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+}
+
+func TestDiscoverFeedReturnsPartialResultsOnEnrichmentFailure(t *testing.T) {
+	exitCode, out := runCLIWithDeps(t, partialEnrichmentDeps(), "discover", "feed", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["partial"] != true {
+		t.Fatalf("expected partial=true, got %v", data["partial"])
+	}
+	sections := asSlicePayload(t, data["sections"])
+	if len(asSlicePayload(t, asMapPayload(t, sections[0])["items"])) != 1 {
+		t.Fatalf("expected fetched rows to be kept, got %v", sections)
+	}
+	warnings := asSlicePayload(t, payload["warnings"])
+	if !containsStringPayload(warnings, "partial results: promotion enrichment failed for 1 request(s) (status 503)") {
+		t.Fatalf("expected partial failure warning, got %v", warnings)
+	}
+}
+
+func TestDiscoverFeedStrictFailsOnEnrichmentFailure(t *testing.T) {
+	exitCode, out := runCLIWithDeps(t, partialEnrichmentDeps(), "discover", "feed", "--strict", "--format", "json")
+	if exitCode != 1 {
+		t.Fatalf("expected exit 1, got %d\noutput:\n%s", exitCode, out)
+	}
+	errorPayload := asMapPayload(t, mustJSON(t, out)["error"])
+	if errorPayload["code"] != "WOLT_UPSTREAM_ERROR" {
+		t.Fatalf("expected WOLT_UPSTREAM_ERROR, got %v", errorPayload["code"])
+	}
+}
+
 func TestDiscoverFeedEnrichesWoltPlusFromStaticVenue(t *testing.T) {
 	venue := buildVenue("venue-1", "plus-venue", "Plus Street")
 	venue.ShowWoltPlus = false
@@ -445,6 +514,323 @@ func TestDiscoverFeedFastSkipsVenueEnrichment(t *testing.T) {
 	}
 }
 
+func TestDiscoverFeedEnrichSelectsEnrichedRows(t *testing.T) {
+	first := buildVenue("venue-1", "first-venue", "First Street")
+	first.ShowWoltPlus = false
+	second := buildVenue("venue-2", "second-venue", "Second Street")
+	second.ShowWoltPlus = false
+	sections := []domain.Section{
+		{
+			Name:  "popular",
+			Title: "Popular",
+			Items: []domain.Item{
+				{Title: "First Venue", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: first},
+				{Title: "Second Venue", TrackID: "2", Link: domain.Link{Target: "venue-2"}, Venue: second},
+			},
+		},
+	}
+	dynamicCalls := 0
+	staticSlugs := []string{}
+
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Krakow"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return sections, nil
+			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				dynamicCalls++
+				return map[string]any{"venue_raw": map[string]any{}}, nil
+			},
+			venuePageStaticFunc: func(_ context.Context, slug string) (map[string]any, error) {
+				staticSlugs = append(staticSlugs, slug)
+				return map[string]any{"venue_raw": map[string]any{"is_wolt_plus": true}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--enrich", "wolt-plus-only", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if dynamicCalls != 0 {
+		t.Fatalf("expected no dynamic calls with --enrich wolt-plus-only, got %d", dynamicCalls)
+	}
+	if len(staticSlugs) == 0 {
+		t.Fatalf("expected static Wolt+ lookups with --enrich wolt-plus-only")
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["enrichment_mode"] != "wolt-plus-only" {
+		t.Fatalf("expected enrichment_mode wolt-plus-only, got %v", data["enrichment_mode"])
+	}
+
+	staticSlugs = nil
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--enrich", "top:1", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if len(staticSlugs) != 1 || staticSlugs[0] != "first-venue" {
+		t.Fatalf("expected only the first row enriched with --enrich top:1, got %v", staticSlugs)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	if data["enrichment_mode"] != "top:1" {
+		t.Fatalf("expected enrichment_mode top:1, got %v", data["enrichment_mode"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--enrich", "top:0", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "--enrich must be") {
+		t.Fatalf("expected --enrich top:0 to be rejected, got %d\noutput:\n%s", exitCode, out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--enrich", "top:1", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "--fast cannot be combined") {
+		t.Fatalf("expected --fast with --enrich top:1 to be rejected, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestDiscoverFeedUsesProfileEnrichmentModeUnlessEnrichIsPassed(t *testing.T) {
+	venue := buildVenue("venue-1", "first-venue", "First Street")
+	venue.ShowWoltPlus = false
+	sections := []domain.Section{
+		{
+			Name:  "popular",
+			Title: "Popular",
+			Items: []domain.Item{{Title: "First Venue", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: venue}},
+		},
+	}
+	enrichCalls := 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Krakow"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return sections, nil
+			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				enrichCalls++
+				return map[string]any{"venue_raw": map[string]any{}}, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				enrichCalls++
+				return map[string]any{"venue_raw": map[string]any{}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, EnrichmentMode: "fast"}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if enrichCalls != 0 {
+		t.Fatalf("expected profile enrichment_mode fast to skip enrichment, got %d calls", enrichCalls)
+	}
+	if mode := asMapPayload(t, mustJSON(t, out)["data"])["enrichment_mode"]; mode != "fast" {
+		t.Fatalf("expected enrichment_mode fast, got %v", mode)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--enrich", "full", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if enrichCalls == 0 {
+		t.Fatalf("expected --enrich full to override the profile enrichment_mode")
+	}
+	if mode := asMapPayload(t, mustJSON(t, out)["data"])["enrichment_mode"]; mode != "full" {
+		t.Fatalf("expected enrichment_mode full, got %v", mode)
+	}
+
+	deps.Profiles = &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, EnrichmentMode: "sometimes"}}
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected an invalid profile enrichment_mode to be rejected, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestDiscoverFeedStreamEmitsFeedThenVenuePatches(t *testing.T) {
+	venue := buildVenue("venue-1", "promo-venue", "Promo Street")
+	sections := []domain.Section{
+		{
+			Name:  "popular",
+			Title: "Popular",
+			Items: []domain.Item{
+				{Title: "Promo Venue", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: venue},
+			},
+		},
+	}
+
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Krakow"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return sections, nil
+			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				return map[string]any{
+					"venue_raw": map[string]any{
+						"discounts": []any{
+							map[string]any{"description": map[string]any{"title": "40% off selected items"}},
+						},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--stream", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected feed, venue, and done lines, got %d:\n%s", len(lines), out)
+	}
+	feed := mustJSON(t, lines[0])
+	data := asMapPayload(t, asMapPayload(t, feed["envelope"])["data"])
+	if feed["type"] != "feed" || data["enrichment_mode"] != "stream" {
+		t.Fatalf("expected unenriched feed line first, got %v", feed)
+	}
+	items := asSlicePayload(t, asMapPayload(t, asSlicePayload(t, data["sections"])[0])["items"])
+	if containsStringPayload(asSlicePayload(t, asMapPayload(t, items[0])["promotions"]), "40% off selected items") {
+		t.Fatalf("expected feed line before promotion enrichment, got %v", items[0])
+	}
+	patch := mustJSON(t, lines[1])
+	if patch["type"] != "venue" || patch["slug"] != "promo-venue" || !containsStringPayload(asSlicePayload(t, patch["promotions"]), "40% off selected items") {
+		t.Fatalf("expected promotion patch for promo-venue, got %v", patch)
+	}
+	done := mustJSON(t, lines[2])
+	if done["type"] != "done" || asIntPayload(done["enriched_venues"]) != 1 || done["partial"] != false {
+		t.Fatalf("unexpected done line: %v", done)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--stream", "--format", "yaml")
+	if exitCode == 0 || !strings.Contains(out, "--stream requires --format json") {
+		t.Fatalf("expected --stream to require JSON, got %d\n%s", exitCode, out)
+	}
+}
+
+func TestDiscoverFeedAppliesDefaultLimitUnlessNoLimit(t *testing.T) {
+	t.Setenv("WOLT_DEFAULT_LIMIT", "2")
+	items := []domain.Item{}
+	for _, slug := range []string{"alpha", "bravo", "charlie"} {
+		items = append(items, domain.Item{Title: slug, TrackID: slug, Link: domain.Link{Target: slug + "-id"}, Venue: buildVenue(slug+"-id", slug, "Main Street")})
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return []domain.Section{{Name: "popular", Title: "Popular", Items: items}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["truncated"] != true || asIntPayload(data["count"]) != 2 || asIntPayload(data["total"]) != 3 || asIntPayload(data["limit"]) != 2 {
+		t.Fatalf("expected the default limit to cut the feed to 2 of 3 rows, got %v", data)
+	}
+	if !containsStringPayload(asSlicePayload(t, payload["warnings"]), "showing 2 of 3 rows (default limit 2); pass --limit <n> or --no-limit for more") {
+		t.Fatalf("expected a default limit warning, got %v", payload["warnings"])
+	}
+
+	for _, args := range [][]string{{"--no-limit"}, {"--limit", "5"}} {
+		exitCode, out = runCLIWithDeps(t, deps, append([]string{"discover", "feed", "--fast", "--format", "json"}, args...)...)
+		if exitCode != 0 {
+			t.Fatalf("%v: expected exit 0, got %d\noutput:\n%s", args, exitCode, out)
+		}
+		if data := asMapPayload(t, mustJSON(t, out)["data"]); data["truncated"] != false || asIntPayload(data["count"]) != 3 {
+			t.Fatalf("%v: expected every row without truncation, got %v", args, data)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--limit", "1", "--no-limit", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "use either --limit or --no-limit") {
+		t.Fatalf("expected --limit and --no-limit to conflict, got %d\n%s", exitCode, out)
+	}
+}
+
+func TestDiscoverFeedVerboseRecordsFieldSources(t *testing.T) {
+	venue := buildVenue("venue-1", "promo-venue", "Promo Street")
+	venue.ShowWoltPlus = false
+	sections := []domain.Section{{
+		Name:  "popular",
+		Title: "Popular",
+		Items: []domain.Item{{Title: "Promo Venue", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: venue}},
+	}}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Krakow"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return sections, nil
+			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				return map[string]any{
+					"venue_raw": map[string]any{
+						"discounts": []any{
+							map[string]any{"description": map[string]any{"title": "40% off selected items"}},
+						},
+					},
+				}, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue_raw": map[string]any{"is_wolt_plus": true}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	feedRow := func(out string) map[string]any {
+		data := asMapPayload(t, mustJSON(t, out)["data"])
+		return asMapPayload(t, asSlicePayload(t, asMapPayload(t, asSlicePayload(t, data["sections"])[0])["items"])[0])
+	}
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--verbose", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	sources := asMapPayload(t, feedRow(out)["_source"])
+	if sources["promotions"] != "front_page+venue_page_dynamic" || sources["wolt_plus"] != "venue_page_static" || sources["delivery_fee"] != "front_page" {
+		t.Fatalf("unexpected field sources: %v", sources)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if _, ok := feedRow(out)["_source"]; ok {
+		t.Fatalf("expected no _source without --verbose")
+	}
+}
+
 func TestDiscoverFeedUsesFrontPayloadSectionsWithoutFallbackCall(t *testing.T) {
 	sectionsCalls := 0
 
@@ -572,31 +958,466 @@ func TestDiscoverFeedSupportsQuerySortAndPage(t *testing.T) {
 	}
 }
 
-func TestSearchVenuesWithoutQueryListsRestaurants(t *testing.T) {
+func TestDiscoverFeedAndSearchApplyExcludeFilters(t *testing.T) {
+	pizza := buildVenue("venue-1", "pizza-place", "Street 1")
+	pizza.Tags = []string{"Pizza"}
+	burger := buildVenue("venue-2", "burger-place", "Street 2")
+	sushi := buildVenue("venue-3", "sushi-place", "Street 3")
+	sushi.Tags = []string{"sushi"}
+	sponsored := buildVenue("venue-4", "sponsored-place", "Street 4")
 	items := []domain.Item{
-		{Title: "Burger Place", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "burger-place", "Burger Street")},
-		{Title: "Sushi Place", TrackID: "2", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "sushi-place", "Sushi Street")},
+		{Title: "Pizza Place", Link: domain.Link{Target: "venue-1"}, Venue: pizza},
+		{Title: "Burger Place", Link: domain.Link{Target: "venue-2"}, Venue: burger},
+		{Title: "Sushi Place", Link: domain.Link{Target: "venue-3"}, Venue: sushi},
+	}
+	sections := []domain.Section{
+		{Name: "popular", Title: "Popular", Items: items},
+		{Name: "ads", Title: "Sponsored", Items: []domain.Item{{Title: "Sponsored Place", Link: domain.Link{Target: "venue-4"}, Venue: sponsored}}},
 	}
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": sections}, nil
+			},
 			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
 				return items, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--exclude-section", "ads", "--exclude-tag", "pizza", "--exclude-venue", "BURGER-place", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
 	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if asIntPayload(data["total"]) != 2 {
-		t.Fatalf("expected total 2, got %v", data["total"])
+	sectionRows := asSlicePayload(t, asMapPayload(t, payload["data"])["sections"])
+	feedItems := asSlicePayload(t, asMapPayload(t, sectionRows[0])["items"])
+	if len(sectionRows) != 1 || len(feedItems) != 1 || asMapPayload(t, feedItems[0])["slug"] != "sushi-place" {
+		t.Fatalf("expected only the sushi venue to remain, got %v", sectionRows)
+	}
+	warnings := asSlicePayload(t, payload["warnings"])
+	for _, expected := range []string{"--exclude-venue removed 1 venue(s)", "--exclude-tag removed 1 venue(s)", "--exclude-section removed 1 section(s)"} {
+		if !containsStringPayload(warnings, expected) {
+			t.Fatalf("expected warning %q, got %v", expected, warnings)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--exclude-tag", "sushi", "--exclude-venue", "venue-1", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload = mustJSON(t, out)
+	rows := asSlicePayload(t, asMapPayload(t, payload["data"])["items"])
+	if len(rows) != 1 || asMapPayload(t, rows[0])["slug"] != "burger-place" {
+		t.Fatalf("expected only the burger venue to remain, got %v", rows)
+	}
+	if !containsStringPayload(asSlicePayload(t, payload["warnings"]), "--exclude-tag removed 1 venue(s)") {
+		t.Fatalf("expected tag exclusion warning, got %v", payload["warnings"])
+	}
+}
+
+func TestSearchVenuesNearAddressFiltersByRadiusWithoutProfile(t *testing.T) {
+	nearby := buildVenue("venue-1", "close-place", "Street 1")
+	nearby.Location = []float64{19, 50.01}
+	far := buildVenue("venue-2", "far-place", "Street 2")
+	far.Location = []float64{19, 50.1}
+	unplaced := buildVenue("venue-3", "unplaced-place", "Street 3")
+	items := []domain.Item{
+		{Title: "Close Place", Link: domain.Link{Target: "venue-1"}, Venue: nearby},
+		{Title: "Far Place", Link: domain.Link{Target: "venue-2"}, Venue: far},
+		{Title: "Unplaced Place", Link: domain.Link{Target: "venue-3"}, Venue: unplaced},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+		},
+		Profiles: &mockProfiles{err: errors.New("profile not found")},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--near", "Rynek Glowny 1, Krakow", "--radius-km", "5", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	rows := asSlicePayload(t, data["items"])
+	if len(rows) != 1 || asMapPayload(t, rows[0])["slug"] != "close-place" || asMapPayload(t, rows[0])["distance_km"] != 1.11 {
+		t.Fatalf("expected only close-place at 1.11 km, got %v", rows)
+	}
+	near := asMapPayload(t, data["near"])
+	if near["address"] != "Rynek Glowny 1, Krakow" || near["lat"] != float64(50) || data["radius_km"] != float64(5) {
+		t.Fatalf("expected near and radius_km in data, got %v and %v", near, data["radius_km"])
+	}
+	if !containsStringPayload(asSlicePayload(t, payload["warnings"]), "--radius-km skipped 1 venue(s) without coordinates") {
+		t.Fatalf("expected a warning for the venue without coordinates, got %v", payload["warnings"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--near", "Rynek Glowny 1, Krakow", "--address", "Florianska 1", "--format", "json")
+	if exitCode != 1 || asMapPayload(t, mustJSON(t, out)["error"])["code"] != "WOLT_INVALID_ARGUMENT" {
+		t.Fatalf("expected --near with --address to fail, got %d\n%s", exitCode, out)
+	}
+}
+
+func TestSearchVenuesGroupChainsAndChainBranches(t *testing.T) {
+	venue := func(id, slug string, lat float64, fee int) *domain.Venue {
+		v := buildVenue(id, slug, "Street "+id)
+		v.Location = []float64{19, lat}
+		v.DeliveryPriceInt = intPtr(fee)
+		return v
+	}
+	items := []domain.Item{
+		{Title: "Burger King Kamppi", Link: domain.Link{Target: "bk-1"}, Venue: venue("bk-1", "burger-king-kamppi", 50.05, 190)},
+		{Title: "Pizza Online", Link: domain.Link{Target: "pizza-1"}, Venue: venue("pizza-1", "pizza-online", 50.02, 300)},
+		{Title: "Burger King | Itis", Link: domain.Link{Target: "bk-2"}, Venue: venue("bk-2", "burger-king-itis", 50.01, 490)},
+		{Title: "Pizza Hut", Link: domain.Link{Target: "pizza-2"}, Venue: venue("pizza-2", "pizza-hut", 50.03, 300)},
+		{Title: "Burger King Iso Omena Drive", Link: domain.Link{Target: "bk-3"}, Venue: venue("bk-3", "burger-king-iso-omena", 50.2, 0)},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 50, Lon: 19}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--group-chains", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	rows := asSlicePayload(t, data["items"])
+	if len(rows) != 3 || asIntPayload(data["chains"]) != 1 {
+		t.Fatalf("expected the three Burger King branches collapsed next to both pizza venues, got %v", rows)
+	}
+	chainRow := asMapPayload(t, rows[0])
+	for _, row := range rows {
+		if asMapPayload(t, row)["chain"] != nil {
+			chainRow = asMapPayload(t, row)
+		}
+	}
+	chain := asMapPayload(t, chainRow["chain"])
+	if chainRow["slug"] != "burger-king-itis" || chain["brand"] != "Burger King" || asIntPayload(chain["branch_count"]) != 3 || chain["selected_by"] != "nearest" {
+		t.Fatalf("expected the nearest branch to represent the chain, got %v", chainRow)
+	}
+	if asMapPayload(t, chain["cheapest"])["slug"] != "burger-king-iso-omena" {
+		t.Fatalf("expected the free-delivery branch as cheapest, got %v", chain["cheapest"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "chain", "branches", "burger king", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	branches := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["branches"])
+	slugs := []string{}
+	for _, branch := range branches {
+		slugs = append(slugs, asStringPayload(asMapPayload(t, branch)["slug"]))
+	}
+	if strings.Join(slugs, ",") != "burger-king-itis,burger-king-kamppi,burger-king-iso-omena" {
+		t.Fatalf("expected every Burger King branch nearest first, got %v", slugs)
+	}
+}
+
+func TestSearchVenuesAcrossCitiesMergesTaggedRows(t *testing.T) {
+	var mu sync.Mutex
+	searched := []float64{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			citiesFunc: func(context.Context) (map[string]any, error) {
+				return map[string]any{"results": []any{
+					map[string]any{"name": "Helsinki", "slug": "helsinki", "location": map[string]any{"coordinates": []any{24.94, 60.17}}},
+					map[string]any{"name": "Espoo", "slug": "espoo", "location": map[string]any{"coordinates": []any{24.65, 60.2}}},
+				}}, nil
+			},
+			itemsFunc: func(_ context.Context, location domain.Location) ([]domain.Item, error) {
+				mu.Lock()
+				searched = append(searched, location.Lon)
+				mu.Unlock()
+				if location.Lon == 24.65 {
+					return []domain.Item{
+						{Title: "Espoo Sushi", Link: domain.Link{Target: "espoo-1"}, Venue: buildVenue("espoo-1", "espoo-sushi", "Tapiola 1")},
+						{Title: "Shared Deli", Link: domain.Link{Target: "shared-1"}, Venue: buildVenue("shared-1", "shared-deli", "Border 1")},
+					}, nil
+				}
+				return []domain.Item{
+					{Title: "Helsinki Ramen", Link: domain.Link{Target: "hki-1"}, Venue: buildVenue("hki-1", "helsinki-ramen", "Kamppi 1")},
+					{Title: "Shared Deli", Link: domain.Link{Target: "shared-1"}, Venue: buildVenue("shared-1", "shared-deli", "Border 1")},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--city", "espoo", "--city", "Helsinki", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if len(searched) != 2 {
+		t.Fatalf("expected one venue search per city, got %v", searched)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if cities := asSlicePayload(t, data["cities"]); len(cities) != 2 || asMapPayload(t, cities[0])["name"] != "Espoo" {
+		t.Fatalf("expected both cities in request order, got %v", data["cities"])
+	}
+	cityBySlug := map[string]any{}
+	for _, row := range asSlicePayload(t, data["items"]) {
+		venue := asMapPayload(t, row)
+		cityBySlug[asStringPayload(venue["slug"])] = venue["city"]
+	}
+	want := map[string]any{"espoo-sushi": "Espoo", "shared-deli": "Espoo", "helsinki-ramen": "Helsinki"}
+	if len(cityBySlug) != len(want) {
+		t.Fatalf("expected the shared venue once, got %v", cityBySlug)
+	}
+	for slug, city := range want {
+		if cityBySlug[slug] != city {
+			t.Fatalf("expected %s tagged %v, got %v", slug, city, cityBySlug)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--city", "Atlantis", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") || !strings.Contains(out, "Atlantis") {
+		t.Fatalf("expected an unknown city to be rejected, got %d\n%s", exitCode, out)
+	}
+}
+
+func TestDiscoverFeedMarksAndDropsAds(t *testing.T) {
+	sections := []domain.Section{{
+		Name:  "popular",
+		Title: "Popular",
+		Items: []domain.Item{
+			{Title: "Sponsored Place", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "sponsored-place", "Street 1"), IsAdvertisement: true},
+			{Title: "Organic Place", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "organic-place", "Street 2")},
+		},
+	}}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": sections}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	items := asSlicePayload(t, asMapPayload(t, asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["sections"])[0])["items"])
+	if len(items) != 2 || asMapPayload(t, items[0])["is_ad"] != true || asMapPayload(t, items[1])["is_ad"] != false {
+		t.Fatalf("expected the sponsored row flagged with is_ad, got %v", items)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--no-ads", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	items = asSlicePayload(t, asMapPayload(t, asSlicePayload(t, asMapPayload(t, payload["data"])["sections"])[0])["items"])
+	if len(items) != 1 || asMapPayload(t, items[0])["slug"] != "organic-place" {
+		t.Fatalf("expected --no-ads to drop the sponsored row, got %v", items)
+	}
+	if !containsStringPayload(asSlicePayload(t, payload["warnings"]), "--no-ads removed 1 sponsored venue(s)") {
+		t.Fatalf("expected --no-ads warning, got %v", payload["warnings"])
+	}
+}
+
+func TestFeedAndSearchAnnotateDeliveryFeeTrend(t *testing.T) {
+	venue := buildVenue("venue-1", "burger-place", "Street 1")
+	items := []domain.Item{{Title: "Burger Place", Link: domain.Link{Target: "venue-1"}, Venue: venue}}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": []domain.Section{{Name: "popular", Title: "Popular", Items: items}}}, nil
+			},
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+	feedRow := func() map[string]any {
+		t.Helper()
+		exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--format", "json")
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+		}
+		sections := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["sections"])
+		return asMapPayload(t, asSlicePayload(t, asMapPayload(t, sections[0])["items"])[0])
+	}
+
+	if row := feedRow(); row["fee_trend"] != nil {
+		t.Fatalf("expected no trend on the first sighting, got %v", row["fee_trend"])
+	}
+	if row := feedRow(); row["fee_trend"] != "same" || asIntPayload(asMapPayload(t, row["previous_delivery_fee"])["amount"]) != 1000 {
+		t.Fatalf("expected an unchanged fee to be marked same, got %v", row)
+	}
+
+	venue.DeliveryPriceInt = intPtr(1500)
+	row := feedRow()
+	if row["fee_trend"] != "up" || asIntPayload(asMapPayload(t, row["previous_delivery_fee"])["amount"]) != 1000 || row["fee_changed_at"] == nil {
+		t.Fatalf("expected the fee jump to be flagged, got %v", row)
+	}
+
+	// Search shares the history; the jump stays visible until the fee moves again.
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	searchRow := asMapPayload(t, asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["items"])[0])
+	if searchRow["fee_trend"] != "up" {
+		t.Fatalf("expected search rows to carry the fee trend, got %v", searchRow)
+	}
+}
+
+func TestDiscoverFeedOnlyNewVenues(t *testing.T) {
+	items := []domain.Item{{Title: "Burger Place", Link: domain.Link{Target: "5a8426f188b5de000b8857bb"}, Venue: buildVenue("5a8426f188b5de000b8857bb", "burger-place", "Street 1")}}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": []domain.Section{{Name: "popular", Title: "Popular", Items: items}}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+	newVenues := func() map[string]any {
+		t.Helper()
+		exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--only-new-venues", "--new-days", "3", "--format", "json")
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+		}
+		return mustJSON(t, out)
+	}
+
+	payload := newVenues()
+	if sections := asSlicePayload(t, asMapPayload(t, payload["data"])["sections"]); len(sections) != 0 {
+		t.Fatalf("expected no new venues on the first tracked run, got %v", sections)
+	}
+	if !strings.Contains(fmt.Sprint(payload["warnings"]), "first-seen tracking started") {
+		t.Fatalf("expected a tracking-start warning, got %v", payload["warnings"])
+	}
+
+	items = append(items, domain.Item{Title: "Sushi Place", Link: domain.Link{Target: "5a8426f188b5de000b8857cc"}, Venue: buildVenue("5a8426f188b5de000b8857cc", "sushi-place", "Street 2")})
+	data := asMapPayload(t, newVenues()["data"])
+	sections := asSlicePayload(t, data["sections"])
+	if len(sections) != 1 {
+		t.Fatalf("expected one section with the new venue, got %v", sections)
+	}
+	rows := asSlicePayload(t, asMapPayload(t, sections[0])["items"])
+	if len(rows) != 1 || asMapPayload(t, rows[0])["slug"] != "sushi-place" || asMapPayload(t, rows[0])["first_seen"] == nil || asIntPayload(data["new_within_days"]) != 3 {
+		t.Fatalf("expected only sushi-place with first_seen, got %v", data)
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--new-days", "3", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "--new-days requires --only-new-venues") {
+		t.Fatalf("expected --new-days alone to be rejected, got %d:\n%s", exitCode, out)
+	}
+}
+
+func TestFeedAndSearchAnnotateOpenBasketMinimum(t *testing.T) {
+	items := []domain.Item{
+		{Title: "Burger Place", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "burger-place", "Street 1")},
+		{Title: "Sushi Place", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "sushi-place", "Street 2")},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": []domain.Section{{Name: "popular", Title: "Popular", Items: items}}}, nil
+			},
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"baskets": []any{
+					map[string]any{
+						"id":            "basket-1",
+						"venue":         map[string]any{"id": "venue-1", "slug": "burger-place", "order_minimum": 1500},
+						"total":         "€12.00",
+						"order_minimum": nil,
+						"items":         []any{map[string]any{"id": "item-1", "count": 2, "price": 600}},
+					},
+				}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, WToken: "token"}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "feed", "--fast", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	rows := asSlicePayload(t, asMapPayload(t, asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["sections"])[0])["items"])
+	basket := asMapPayload(t, asMapPayload(t, rows[0])["basket"])
+	if asIntPayload(asMapPayload(t, basket["subtotal"])["amount"]) != 1200 || basket["minimum_met"] != false {
+		t.Fatalf("expected the basket subtotal below the minimum, got %v", basket)
+	}
+	if asIntPayload(asMapPayload(t, basket["amount_to_minimum"])["amount"]) != 300 {
+		t.Fatalf("expected 300 missing to the minimum, got %v", basket["amount_to_minimum"])
+	}
+	if asMapPayload(t, rows[1])["basket"] != nil {
+		t.Fatalf("expected venues without a basket to stay unannotated, got %v", rows[1])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--format", "table")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if !strings.Contains(out, "Burger Place (basket €12.00, €3.00 to minimum)") {
+		t.Fatalf("expected the table to show the basket state, got:\n%s", out)
+	}
+}
+
+func TestSearchVenuesWithoutQueryListsRestaurants(t *testing.T) {
+	items := []domain.Item{
+		{Title: "Burger Place", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "burger-place", "Burger Street")},
+		{Title: "Sushi Place", TrackID: "2", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "sushi-place", "Sushi Street")},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if asIntPayload(data["total"]) != 2 {
+		t.Fatalf("expected total 2, got %v", data["total"])
 	}
 	rows := asSlicePayload(t, data["items"])
 	if len(rows) != 2 {
@@ -615,6 +1436,59 @@ func TestSearchVenuesWithoutQueryListsRestaurants(t *testing.T) {
 	}
 }
 
+func TestRateFeedsMyRatingSortInSearch(t *testing.T) {
+	t.Setenv("WOLT_JOURNAL_DIR", t.TempDir())
+	items := []domain.Item{
+		{Title: "Burger Place", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "burger-place", "Burger Street")},
+		{Title: "Sushi Place", TrackID: "2", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "sushi-place", "Sushi Street")},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+			orderHistoryShowFn: func(_ context.Context, purchaseID string, _ woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"order_id": purchaseID, "venue_id": "venue-2", "venue_name": "Sushi Place"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "rate", "purchase-1", "--score", "11", "--wtoken", "token", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected an out-of-range score to fail, got:\n%s", out)
+	}
+	for _, args := range [][]string{
+		{"rate", "purchase-1", "--score", "9", "--note", "fast, hot"},
+		{"rate", "purchase-2", "--score", "6"},
+	} {
+		exitCode, out = runCLIWithDeps(t, deps, append(args, "--wtoken", "token", "--format", "json")...)
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+		}
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["my_rating"] != 7.5 || asIntPayload(data["my_rating_count"]) != 2 || data["submitted"] != false {
+		t.Fatalf("expected a local-only average of 7.5 over 2 ratings, got %v", data)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--sort", "my_rating", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	rows := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["items"])
+	first := asMapPayload(t, rows[0])
+	if first["slug"] != "sushi-place" || first["my_rating"] != 7.5 || first["sort_key"] != 7.5 {
+		t.Fatalf("expected the rated venue first, got %v", rows)
+	}
+	if _, ok := asMapPayload(t, rows[1])["my_rating"]; ok {
+		t.Fatalf("expected no my_rating on an unrated venue, got %v", rows[1])
+	}
+}
+
 func TestSearchVenuesSupportsPageAndFilters(t *testing.T) {
 	venueA := buildVenue("venue-a", "venue-a", "Street A")
 	venueA.Rating = &domain.Rating{Score: 8.6}
@@ -725,17 +1599,83 @@ func TestSearchVenuesMergesDynamicPromotions(t *testing.T) {
 	}
 }
 
-func TestSearchVenuesTableIncludesSlug(t *testing.T) {
-	items := []domain.Item{
-		{Title: "Groceries One", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "groceries-one", "Grocery Street")},
+func TestPickChoosesReproduciblyFromFilteredVenues(t *testing.T) {
+	items := []domain.Item{}
+	for idx, score := range []float64{9.4, 9.0, 8.8, 7.2} {
+		id := fmt.Sprintf("venue-%d", idx+1)
+		venue := buildVenue(id, fmt.Sprintf("sushi-%d", idx+1), "Street")
+		venue.Tags = []string{"sushi"}
+		venue.Rating = &domain.Rating{Score: score}
+		items = append(items, domain.Item{Title: fmt.Sprintf("Sushi %d", idx+1), Link: domain.Link{Target: id}, Venue: venue})
 	}
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
 			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
 				return items, nil
 			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				return map[string]any{"items": []any{
+					map[string]any{"id": "item-1", "name": "Salmon Nigiri", "price": 690},
+					map[string]any{"id": "item-2", "name": "Omakase Box", "price": 3490},
+				}}, nil
+			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+	args := []string{"pick", "--category", "sushi", "--min-rating", "8.5", "--seed", "42", "--with-item", "--max-item-price", "1000", "--format", "json"}
+
+	exitCode, out := runCLIWithDeps(t, deps, args...)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if asIntPayload(data["candidates"]) != 3 || asIntPayload(data["seed"]) != 42 {
+		t.Fatalf("expected three rated candidates and seed 42, got %v", data)
+	}
+	slug := asMapPayload(t, data["venue"])["slug"]
+	if slug == "sushi-4" {
+		t.Fatalf("expected the low-rated venue to be filtered out, got %v", slug)
+	}
+	if item := asMapPayload(t, data["item"]); item["item_id"] != "item-1" || asIntPayload(data["item_candidates"]) != 1 {
+		t.Fatalf("expected the only item under the price cap, got %v", data)
+	}
+	for range 3 {
+		_, again := runCLIWithDeps(t, deps, args...)
+		if repeat := asMapPayload(t, asMapPayload(t, mustJSON(t, again)["data"])["venue"])["slug"]; repeat != slug {
+			t.Fatalf("expected seed 42 to pick %v again, got %v", slug, repeat)
+		}
+	}
+
+	seeded := deps
+	seeded.Random = cli.SeedFunc(func() int64 { return 42 })
+	exitCode, out = runCLIWithDeps(t, seeded, "pick", "--category", "sushi", "--min-rating", "8.5", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); asIntPayload(data["seed"]) != 42 || asMapPayload(t, data["venue"])["slug"] != slug {
+		t.Fatalf("expected the injected seed to repeat the --seed 42 pick, got %v", data)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "pick", "--min-rating", "9.9", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_NOT_FOUND") {
+		t.Fatalf("expected no candidates error, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestSearchVenuesTableIncludesSlug(t *testing.T) {
+	items := []domain.Item{
+		{Title: "Groceries One", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "groceries-one", "Grocery Street")},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
@@ -753,6 +1693,56 @@ func TestSearchVenuesTableIncludesSlug(t *testing.T) {
 	}
 }
 
+func TestSearchVenuesOpenNowAtUsesVenueHours(t *testing.T) {
+	items := []domain.Item{
+		{Title: "Late Burger", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "late-burger", "Burger Street")},
+		{Title: "Early Burger", TrackID: "2", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "early-burger", "Burger Street")},
+	}
+	clock := func(kind string, hour int) domain.Times {
+		return domain.Times{Type: kind, Value: map[string]int64{"$date": int64(hour * 60 * 60 * 1000)}}
+	}
+	restaurants := map[string]*domain.Restaurant{
+		"venue-1": {ID: "venue-1", TimezoneName: "Europe/Helsinki", OpeningTimes: map[string][]domain.Times{"monday": {clock("open", 17), clock("close", 23)}}},
+		"venue-2": {ID: "venue-2", TimezoneName: "Europe/Helsinki", OpeningTimes: map[string][]domain.Times{"monday": {clock("open", 8), clock("close", 15)}}},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return items, nil
+			},
+			restaurantByIDFunc: func(_ context.Context, venueID string) (*domain.Restaurant, error) {
+				return restaurants[venueID], nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--open-now", "--now", "2026-02-16T19:00", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	rows := asSlicePayload(t, data["items"])
+	if len(rows) != 1 {
+		t.Fatalf("expected only the evening venue, got %v", rows)
+	}
+	row := asMapPayload(t, rows[0])
+	if row["slug"] != "late-burger" || row["open_checked_at"] != "2026-02-16T19:00:00+02:00" {
+		t.Fatalf("unexpected open venue row: %v", row)
+	}
+	if data["now"] != "2026-02-16T19:00" {
+		t.Fatalf("expected the --now value in data.now, got %v", data["now"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--now", "2026-02-16T19:00", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected --now without --open-now to fail, got:\n%s", out)
+	}
+}
+
 func TestSearchItemsSupportsPageAndFilters(t *testing.T) {
 	searchPayload := map[string]any{
 		"venue": map[string]any{
@@ -828,6 +1818,181 @@ func TestSearchItemsSupportsPageAndFilters(t *testing.T) {
 	}
 }
 
+func TestSearchAllRanksVenuesAndItemsTogether(t *testing.T) {
+	venues := []domain.Item{
+		{Title: "Burger Place", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: buildVenue("venue-1", "burger-place", "Street 1")},
+		{Title: "Burger Town", TrackID: "2", Link: domain.Link{Target: "venue-2"}, Venue: buildVenue("venue-2", "burger-town", "Street 2")},
+		{Title: "Sushi Bar", TrackID: "3", Link: domain.Link{Target: "venue-3"}, Venue: buildVenue("venue-3", "sushi-bar", "Street 3")},
+	}
+	venues[2].Venue.Tags = []string{"sushi"}
+	searchErr := error(nil)
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return venues, nil
+			},
+			searchFunc: func(context.Context, domain.Location, string) (map[string]any, error) {
+				if searchErr != nil {
+					return nil, searchErr
+				}
+				return map[string]any{
+					"venue": map[string]any{"currency": "EUR"},
+					"items": []any{
+						map[string]any{"id": "item-a", "name": "Double Burger", "price": 1290, "venue_id": "venue-2", "venue_slug": "burger-town"},
+						map[string]any{"id": "item-b", "name": "Burger Fries", "price": 490, "venue_id": "venue-2", "venue_slug": "burger-town"},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "all", "--query", "burger", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if asIntPayload(data["venue_count"]) != 2 || asIntPayload(data["item_count"]) != 2 {
+		t.Fatalf("expected two venues and two items, got %v", data)
+	}
+	rows := asSlicePayload(t, data["items"])
+	if len(rows) != 4 {
+		t.Fatalf("expected four merged rows, got %d", len(rows))
+	}
+	first := asMapPayload(t, rows[0])
+	if first["type"] != "venue" || first["slug"] != "burger-town" || asIntPayload(first["matching_items"]) != 2 {
+		t.Fatalf("expected the venue selling both matching items first, got %v", first)
+	}
+	types := map[string]int{}
+	previous := 10.0
+	for _, value := range rows {
+		row := asMapPayload(t, value)
+		types[asStringPayload(row["type"])]++
+		score := row["score"].(float64)
+		if score > previous {
+			t.Fatalf("expected rows ordered by score, got %v after %v", score, previous)
+		}
+		previous = score
+	}
+	if types["venue"] != 2 || types["item"] != 2 {
+		t.Fatalf("expected rows tagged venue and item, got %v", types)
+	}
+
+	searchErr = woltgateway.ErrUpstream
+	exitCode, out = runCLIWithDeps(t, deps, "search", "all", "--query", "burger", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected venues-only partial result, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["partial"] != true || asIntPayload(data["item_count"]) != 0 {
+		t.Fatalf("expected a partial venues-only result, got %v", data)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "search", "all", "--query", "burger", "--strict", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected --strict to fail when item search fails, got:\n%s", out)
+	}
+}
+
+func TestSearchSuggestsSpellingsOnEmptyResults(t *testing.T) {
+	venue := buildVenue("venue-1", "burger-place", "Street 1")
+	venue.Tags = []string{"american"}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return []domain.Item{{Title: "Burger Place", TrackID: "1", Link: domain.Link{Target: "venue-1"}, Venue: venue}}, nil
+			},
+			searchFunc: func(context.Context, domain.Location, string) (map[string]any, error) {
+				return map[string]any{
+					"venue": map[string]any{"currency": "EUR"},
+					"items": []any{
+						map[string]any{"id": "item-a", "name": "Salmon Poke", "price": 1290, "venue_id": "venue-1"},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--query", "burgr", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	suggestions := asSlicePayload(t, asMapPayload(t, payload["data"])["suggestions"])
+	if len(suggestions) == 0 || suggestions[0] != "burger" {
+		t.Fatalf("expected burger suggested for burgr, got %v", suggestions)
+	}
+	if !strings.Contains(out, `did you mean: burger`) {
+		t.Fatalf("expected a did-you-mean warning, got:\n%s", out)
+	}
+
+	// Item names seen by one search feed suggestions for the next.
+	if exitCode, out := runCLIWithDeps(t, deps, "search", "items", "--query", "poke", "--format", "json"); exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "search", "items", "--query", "salmn", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	suggestions = asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["suggestions"])
+	if !containsStringPayload(suggestions, "salmon") {
+		t.Fatalf("expected salmon suggested from cached menu names, got %v", suggestions)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--query", "burger", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if _, ok := asMapPayload(t, mustJSON(t, out)["data"])["suggestions"]; ok {
+		t.Fatalf("expected no suggestions when the search has results, got:\n%s", out)
+	}
+}
+
+func TestSearchItemsAcceptsDecimalPriceLimits(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return []domain.Item{}, nil
+			},
+			searchFunc: func(context.Context, domain.Location, string) (map[string]any, error) {
+				return map[string]any{
+					"venue": map[string]any{"currency": "EUR"},
+					"items": []any{
+						map[string]any{"id": "item-a", "name": "Alpha Burger", "price": 700},
+						map[string]any{"id": "item-b", "name": "Beta Burger", "price": 850},
+						map[string]any{"id": "item-c", "name": "Gamma Burger", "price": 1000},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	for _, limits := range [][]string{{"7.50", "9,90"}, {"750", "990"}} {
+		exitCode, out := runCLIWithDeps(t, deps, "search", "items", "--query", "burger", "--min-price", limits[0], "--max-price", limits[1], "--format", "json")
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0 for %v, got %d\noutput:\n%s", limits, exitCode, out)
+		}
+		items := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["items"])
+		if len(items) != 1 || asMapPayload(t, items[0])["name"] != "Beta Burger" {
+			t.Fatalf("expected only Beta Burger within %v, got %v", limits, items)
+		}
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "items", "--query", "burger", "--max-price", "nine", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected an invalid price to be rejected, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
 func TestVenueMenuJSON(t *testing.T) {
 	staticPayload := map[string]any{
 		"venue": map[string]any{
@@ -1238,6 +2403,65 @@ func TestVenueMenuPartialRequiresCategoryOrSearch(t *testing.T) {
 	}
 }
 
+func TestVenueMenuFullCatalogStopsAboveMaxRequests(t *testing.T) {
+	categoryCalls := 0
+	staticPayload := map[string]any{
+		"venue": map[string]any{
+			"id": "venue-1",
+		},
+	}
+	assortmentPayload := map[string]any{
+		"loading_strategy": "partial",
+		"categories": []any{
+			map[string]any{"id": "cat-main", "name": "Main", "slug": "main", "item_ids": []any{}},
+			map[string]any{"id": "cat-dairy", "name": "Dairy", "slug": "dairy", "item_ids": []any{}},
+			map[string]any{"id": "cat-bakery", "name": "Bakery", "slug": "bakery", "item_ids": []any{}},
+		},
+	}
+
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return staticPayload, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return assortmentPayload, nil
+			},
+			assortmentCategoryFn: func(context.Context, string, string, string, woltgateway.AuthContext) (map[string]any, error) {
+				categoryCalls++
+				return map[string]any{}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(
+		t,
+		deps,
+		"venue", "menu", "wolt-market-niittari",
+		"--full-catalog",
+		"--max-requests", "2",
+		"--format", "json",
+	)
+	if exitCode != 1 {
+		t.Fatalf("expected exit 1, got %d\noutput:\n%s", exitCode, out)
+	}
+	if categoryCalls != 0 {
+		t.Fatalf("expected the crawl to abort before category requests, got %d", categoryCalls)
+	}
+	errorPayload := asMapPayload(t, mustJSON(t, out)["error"])
+	if errorPayload["code"] != "WOLT_REQUEST_BUDGET_EXCEEDED" {
+		t.Fatalf("expected WOLT_REQUEST_BUDGET_EXCEEDED, got %v", errorPayload["code"])
+	}
+	message, _ := errorPayload["message"].(string)
+	if !strings.Contains(message, "estimated 3 upstream requests") || !strings.Contains(message, "--category <slug>") {
+		t.Fatalf("expected estimate and --category suggestion, got %q", message)
+	}
+}
+
 func TestVenueSearchScopedByVenue(t *testing.T) {
 	searchCalls := 0
 	searchQuery := ""
@@ -1370,6 +2594,62 @@ func TestVenueSearchScopedByVenue(t *testing.T) {
 	}
 }
 
+func TestVenueSearchEvaluatesAndOrAndExclusionsOnUpstreamResults(t *testing.T) {
+	item := func(id string, name string) map[string]any {
+		return map[string]any{"id": id, "name": name, "price": map[string]any{"amount": 299, "currency": "EUR"}}
+	}
+	results := map[string][]any{
+		"barista": {item("oatly-barista", "Oatly Barista Edition 1 l"), item("milk-barista", "Valio Barista Milk 1 l")},
+		"oat drink": {
+			item("oat-drink", "Oat Drink 1 l"),
+			item("organic-oat-drink", "Organic Oat Drink 1 l"),
+			item("oatly-barista", "Oatly Barista Edition 1 l"),
+		},
+	}
+	queries := []string{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1"}}, nil
+			},
+			assortmentItemsSearchFn: func(_ context.Context, _ string, query string, _ string, _ woltgateway.AuthContext) (map[string]any, error) {
+				queries = append(queries, query)
+				return map[string]any{"items": results[query]}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "search", "wolt-market-niittari", "--query", `oat AND barista OR "oat drink" -organic`, "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if strings.Join(queries, "|") != "barista|oat drink" {
+		t.Fatalf("expected one upstream search per OR branch with its longest term, got %v", queries)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	ids := []string{}
+	for _, row := range asSlicePayload(t, data["items"]) {
+		ids = append(ids, asStringPayload(asMapPayload(t, row)["item_id"]))
+	}
+	if strings.Join(ids, ",") != "oatly-barista,oat-drink" || asIntPayload(data["total"]) != 2 {
+		t.Fatalf("expected matches of either branch once each, got %v (total %v)", ids, data["total"])
+	}
+	clauses := asSlicePayload(t, asMapPayload(t, data["parsed_query"])["any_of"])
+	second := asMapPayload(t, clauses[1])
+	if len(clauses) != 2 || asSlicePayload(t, second["none_of"])[0] != "organic" || second["upstream_query"] != "oat drink" {
+		t.Fatalf("expected the parsed query echoed, got %v", data["parsed_query"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "search", "wolt-market-niittari", "--query", "oat AND", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "must not end with AND") {
+		t.Fatalf("expected a dangling operator to be rejected, got exit %d\noutput:\n%s", exitCode, out)
+	}
+}
+
 func TestVenueSearchFillsCurrencyAndDerivedDiscount(t *testing.T) {
 	staticPayload := map[string]any{
 		"venue": map[string]any{
@@ -1534,41 +2814,233 @@ func TestVenueMenuCategoryLoadsSelectedCategory(t *testing.T) {
 	}
 }
 
-func TestVenueMenuTableShowsRows(t *testing.T) {
-	staticPayload := map[string]any{
-		"venue": map[string]any{
-			"id":             "venue-1",
-			"show_wolt_plus": true,
-		},
-	}
-	assortmentPayload := map[string]any{
-		"categories": []any{
-			map[string]any{
-				"name":     "sides",
-				"item_ids": []any{"item-1"},
-			},
-		},
-		"items": []any{
-			map[string]any{
-				"id":    "item-1",
-				"name":  "Fries",
-				"price": 599,
-				"promotions": []any{
-					map[string]any{"text": "2 for 1"},
-				},
-			},
-		},
-	}
-
+func TestMarketAislesListsTopLevelAislesWithBanners(t *testing.T) {
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
 			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
-				return staticPayload, nil
+				return map[string]any{
+					"venue": map[string]any{
+						"id":      "venue-1",
+						"banners": []any{map[string]any{"formatted_text": "Free delivery over 30 €"}},
+					},
+				}, nil
 			},
 			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
-				return assortmentPayload, nil
-			},
-		},
+				return map[string]any{
+					"loading_strategy": "partial",
+					"categories": []any{
+						map[string]any{
+							"id":      "cat-dairy",
+							"name":    "Dairy",
+							"slug":    "dairy",
+							"banners": []any{map[string]any{"discount": map[string]any{"title": "-20% on cheese"}}},
+							"subcategories": []any{
+								map[string]any{"id": "cat-milk", "name": "Milk", "slug": "milk"},
+								map[string]any{"id": "cat-cheese", "name": "Cheese", "slug": "cheese"},
+							},
+						},
+						map[string]any{"id": "cat-bakery", "name": "Bakery", "slug": "bakery", "item_ids": []any{"item-9"}},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "market", "aisles", "wolt-market-niittari", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if banners := asSlicePayload(t, data["banners"]); len(banners) != 1 || banners[0] != "Free delivery over 30 €" {
+		t.Fatalf("expected the venue banner, got %v", data["banners"])
+	}
+	aisles := asSlicePayload(t, data["aisles"])
+	if len(aisles) != 2 {
+		t.Fatalf("expected 2 top-level aisles, got %d", len(aisles))
+	}
+	dairy := asMapPayload(t, aisles[0])
+	if dairy["slug"] != "dairy" || asIntPayload(dairy["subcategory_count"]) != 2 || dairy["item_count"] != nil {
+		t.Fatalf("expected dairy with 2 subaisles and no item count yet, got %v", dairy)
+	}
+	if banners := asSlicePayload(t, dairy["banners"]); len(banners) != 1 || banners[0] != "-20% on cheese" {
+		t.Fatalf("expected the aisle banner, got %v", dairy["banners"])
+	}
+	if bakery := asMapPayload(t, aisles[1]); asIntPayload(bakery["item_count"]) != 1 {
+		t.Fatalf("expected bakery item_count 1, got %v", bakery["item_count"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "market", "aisles", "wolt-market-niittari")
+	if exitCode != 0 || !strings.Contains(out, "Market aisles: wolt-market-niittari (partial)") || !strings.Contains(out, "-20% on cheese") {
+		t.Fatalf("expected aisles table, got exit %d\n%s", exitCode, out)
+	}
+}
+
+func TestMarketAisleHydratesOnlyTheRequestedPage(t *testing.T) {
+	categoryCalls := []string{}
+	hydrated := [][]string{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1"}}, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{
+					"loading_strategy": "partial",
+					"categories": []any{
+						map[string]any{
+							"id":   "cat-dairy",
+							"name": "Dairy",
+							"slug": "dairy",
+							"subcategories": []any{
+								map[string]any{"id": "cat-milk", "name": "Milk", "slug": "milk"},
+								map[string]any{"id": "cat-cheese", "name": "Cheese", "slug": "cheese"},
+							},
+						},
+					},
+				}, nil
+			},
+			assortmentCategoryFn: func(_ context.Context, _ string, categorySlug string, _ string, _ woltgateway.AuthContext) (map[string]any, error) {
+				categoryCalls = append(categoryCalls, categorySlug)
+				itemIDs := map[string][]any{"milk": {"milk-1", "milk-2"}, "cheese": {"cheese-1", "cheese-2"}}[categorySlug]
+				return map[string]any{"categories": []any{map[string]any{"slug": categorySlug, "item_ids": itemIDs}}}, nil
+			},
+			assortmentItemsFn: func(_ context.Context, _ string, itemIDs []string, _ woltgateway.AuthContext) (map[string]any, error) {
+				hydrated = append(hydrated, itemIDs)
+				items := []any{}
+				for _, itemID := range itemIDs {
+					items = append(items, map[string]any{"id": itemID, "name": "Item " + itemID, "price": 199})
+				}
+				return map[string]any{"items": items}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "market", "aisle", "wolt-market-niittari", "dairy", "--limit", "2", "--page", "2", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if strings.Join(categoryCalls, ",") != "milk,cheese" {
+		t.Fatalf("expected the aisle's leaf categories to be read, got %v", categoryCalls)
+	}
+	if len(hydrated) != 1 || strings.Join(hydrated[0], ",") != "cheese-1,cheese-2" {
+		t.Fatalf("expected only the second page to be hydrated, got %v", hydrated)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if asIntPayload(data["total"]) != 4 || asIntPayload(data["offset"]) != 2 || asIntPayload(data["count"]) != 2 {
+		t.Fatalf("expected page 2 of 4 items, got total=%v offset=%v count=%v", data["total"], data["offset"], data["count"])
+	}
+	items := asSlicePayload(t, data["items"])
+	if first := asMapPayload(t, items[0]); first["item_id"] != "cheese-1" {
+		t.Fatalf("expected cheese-1 first, got %v", first["item_id"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "market", "aisle", "wolt-market-niittari", "frozen", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_NOT_FOUND") || !strings.Contains(out, "wolt market aisles wolt-market-niittari") {
+		t.Fatalf("expected WOLT_NOT_FOUND for an unknown aisle, got exit %d\n%s", exitCode, out)
+	}
+}
+
+func TestMarketLookupFindsItemByBarcode(t *testing.T) {
+	searchQueries := []string{}
+	searchItems := []any{
+		map[string]any{"id": "milk-1", "name": "Valio milk 1 l", "price": 139, "barcode_gtin": "6408430000012"},
+		map[string]any{"id": "milk-2", "name": "Arla milk 1 l", "price": 129, "barcode_gtin": "6415600501194"},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1"}}, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{
+					"loading_strategy": "partial",
+					"currency":         "EUR",
+					"categories":       []any{map[string]any{"id": "cat-dairy", "name": "Dairy", "slug": "dairy"}},
+				}, nil
+			},
+			assortmentItemsSearchFn: func(_ context.Context, _ string, query string, _ string, _ woltgateway.AuthContext) (map[string]any, error) {
+				searchQueries = append(searchQueries, query)
+				return map[string]any{"items": searchItems}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "market", "lookup", "wolt-market-niittari", "--ean", "6415600501194", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if len(searchQueries) != 1 || searchQueries[0] != "6415600501194" {
+		t.Fatalf("expected one item search by the code, got %v", searchQueries)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["match_source"] != "search" || data["gtin"] != "06415600501194" {
+		t.Fatalf("expected a search match for the padded gtin, got %v", data)
+	}
+	item := asMapPayload(t, data["item"])
+	price := asMapPayload(t, item["base_price"])
+	if item["item_id"] != "milk-2" || asIntPayload(price["amount"]) != 129 || price["currency"] != "EUR" {
+		t.Fatalf("expected milk-2 at 1.29 EUR, got %v", item)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "market", "lookup", "wolt-market-niittari", "--ean", "6415600501195", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") || !strings.Contains(out, "check digit") {
+		t.Fatalf("expected a check digit error, got exit %d\n%s", exitCode, out)
+	}
+
+	searchItems = []any{map[string]any{"id": "milk-1", "name": "Valio milk 1 l", "price": 139}}
+	exitCode, out = runCLIWithDeps(t, deps, "market", "lookup", "wolt-market-niittari", "--ean", "6415600501194", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_NOT_FOUND") || !strings.Contains(out, "carries no barcodes") {
+		t.Fatalf("expected WOLT_NOT_FOUND explaining the missing barcodes, got exit %d\n%s", exitCode, out)
+	}
+}
+
+func TestVenueMenuTableShowsRows(t *testing.T) {
+	staticPayload := map[string]any{
+		"venue": map[string]any{
+			"id":             "venue-1",
+			"show_wolt_plus": true,
+		},
+	}
+	assortmentPayload := map[string]any{
+		"categories": []any{
+			map[string]any{
+				"name":     "sides",
+				"item_ids": []any{"item-1"},
+			},
+		},
+		"items": []any{
+			map[string]any{
+				"id":    "item-1",
+				"name":  "Fries",
+				"price": 599,
+				"promotions": []any{
+					map[string]any{"text": "2 for 1"},
+				},
+			},
+		},
+	}
+
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return staticPayload, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return assortmentPayload, nil
+			},
+		},
 		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
@@ -1604,11 +3076,482 @@ func TestVenueHoursJSON(t *testing.T) {
 	}
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
-				return venueItem, nil
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return venueItem, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return restaurant, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--timezone", "Europe/Helsinki", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["timezone"] != "Europe/Helsinki" {
+		t.Fatalf("expected timezone override Europe/Helsinki, got %v", data["timezone"])
+	}
+	windows := asSlicePayload(t, data["opening_windows"])
+	if len(windows) != 7 {
+		t.Fatalf("expected 7 opening windows, got %d", len(windows))
+	}
+	first := asMapPayload(t, windows[0])
+	if first["day"] != "monday" || first["open"] != "10:00" || first["close"] != "20:45" {
+		t.Fatalf("unexpected monday window: %v", first)
+	}
+}
+
+func TestVenueHoursNowComparesInVenueTimezone(t *testing.T) {
+	venueItem := &domain.Item{
+		Title: "Burger Place",
+		Link:  domain.Link{Target: "venue-1"},
+		Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place"},
+	}
+	restaurant := &domain.Restaurant{
+		ID:           "venue-1",
+		TimezoneName: "Europe/Helsinki",
+		OpeningTimes: map[string][]domain.Times{
+			"monday": {
+				{Type: "open", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC).UnixMilli()}},
+				{Type: "close", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 20, 45, 0, 0, time.UTC).UnixMilli()}},
+			},
+		},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return venueItem, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return restaurant, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--now", "2026-02-16T19:00", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["open_now"] != true || data["now"] != "2026-02-16T19:00:00+02:00" {
+		t.Fatalf("expected open at 19:00 Helsinki time, got open_now=%v now=%v", data["open_now"], data["now"])
+	}
+	if data["closes_at"] != "2026-02-16T20:45:00+02:00" || data["opens_at"] != nil {
+		t.Fatalf("expected closes_at 20:45 while open, got closes_at=%v opens_at=%v", data["closes_at"], data["opens_at"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--now", "2026-02-16T19:00", "--tz", "Asia/Tokyo", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["now"] != "2026-02-17T02:00:00+09:00" || data["closes_at"] != "2026-02-17T03:45:00+09:00" {
+		t.Fatalf("expected --tz to convert timestamps to Tokyo time, got now=%v closes_at=%v", data["now"], data["closes_at"])
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--now", "2026-02-16T19:00", "--time-format", "unix", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); asIntPayload(data["now"]) != 1771261200 || asStringPayload(data["timezone"]) != "Europe/Helsinki" {
+		t.Fatalf("expected unix seconds for now, got now=%v timezone=%v", data["now"], data["timezone"])
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--tz", "Mars/Base", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected an unknown --tz to fail, got:\n%s", out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--now", "2026-02-16T21:00", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["open_now"] != false || data["opens_at"] != "2026-02-23T10:00:00+02:00" {
+		t.Fatalf("expected closed at 21:00 until next Monday, got open_now=%v opens_at=%v", data["open_now"], data["opens_at"])
+	}
+
+	clocked := deps
+	clocked.Clock = cli.ClockFunc(func() time.Time { return time.Date(2026, 2, 16, 17, 0, 0, 0, time.UTC) })
+	exitCode, out = runCLIWithDeps(t, clocked, "venue", "hours", "burger-place", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["open_now"] != true || data["now"] != "2026-02-16T19:00:00+02:00" {
+		t.Fatalf("expected the injected clock to stand in for --now, got open_now=%v now=%v", data["open_now"], data["now"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--now", "tonight", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected invalid --now to fail, got:\n%s", out)
+	}
+	if code := asMapPayload(t, mustJSON(t, out)["error"])["code"]; code != "WOLT_INVALID_ARGUMENT" {
+		t.Fatalf("expected WOLT_INVALID_ARGUMENT, got %v", code)
+	}
+}
+
+func TestInjectedClockStampsStatusNotesAndRelativeTimes(t *testing.T) {
+	t.Setenv("WOLT_JOURNAL_DIR", t.TempDir())
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				time.Sleep(5 * time.Millisecond)
+				return map[string]any{}, nil
+			},
+			searchFunc: func(context.Context, domain.Location, string) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			itemBySlugFunc: func(_ context.Context, _ domain.Location, slug string) (*domain.Item, error) {
+				return &domain.Item{Title: slug, Link: domain.Link{Target: "venue-1"}, Venue: &domain.Venue{ID: "venue-1", Slug: slug}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+	pinned := time.Date(2026, 2, 16, 17, 0, 0, 0, time.UTC)
+	deps.Clock = cli.ClockFunc(func() time.Time { return pinned })
+
+	exitCode, out := runCLIWithDeps(t, deps, "status", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["checked_at"] != "2026-02-16T17:00:00Z" {
+		t.Fatalf("expected status to stamp the injected clock, got checked_at=%v", data["checked_at"])
+	}
+	for _, value := range asSlicePayload(t, data["endpoints"]) {
+		if endpoint := asMapPayload(t, value); endpoint["name"] == "front_page" && asIntPayload(endpoint["latency_ms"]) < 5 {
+			t.Fatalf("expected latency measured on the wall clock, got %v", endpoint["latency_ms"])
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "notes", "set", "burger-place", "great fries", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["updated_at"] != "2026-02-16T17:00:00Z" {
+		t.Fatalf("expected notes to stamp the injected clock, got updated_at=%v", data["updated_at"])
+	}
+
+	pinned = pinned.Add(3 * time.Hour)
+	exitCode, out = runCLIWithDeps(t, deps, "notes", "show", "burger-place", "--time-format", "relative", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["updated_at"] != "3h ago" {
+		t.Fatalf("expected relative times against the injected clock, got updated_at=%v", data["updated_at"])
+	}
+}
+
+func TestVenueHoursAcceptsLatLon(t *testing.T) {
+	seen := domain.Location{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(_ context.Context, location domain.Location, _ string) (*domain.Item, error) {
+				seen = location
+				return &domain.Item{Title: "Burger Place", Link: domain.Link{Target: "venue-1"}, Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place"}}, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return &domain.Restaurant{ID: "venue-1", TimezoneName: "UTC"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--lat", "60.17", "--lon", "24.94", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seen.Lat != 60.17 || seen.Lon != 24.94 {
+		t.Fatalf("expected --lat/--lon to reach the venue lookup, got %+v", seen)
+	}
+
+	for _, args := range [][]string{
+		{"venue", "hours", "burger-place", "--lat", "60.17", "--format", "json"},
+		{"venue", "hours", "burger-place", "--lat", "60.17", "--lon", "24.94", "--address", "Kamppi", "--format", "json"},
+	} {
+		exitCode, out = runCLIWithDeps(t, deps, args...)
+		if exitCode == 0 {
+			t.Fatalf("expected %v to fail, got:\n%s", args, out)
+		}
+		if code := asMapPayload(t, mustJSON(t, out)["error"])["code"]; code != "WOLT_INVALID_ARGUMENT" {
+			t.Fatalf("expected WOLT_INVALID_ARGUMENT for %v, got %v", args, code)
+		}
+	}
+}
+
+func TestVenueHoursFallbackStaticWhenItemLookupFails(t *testing.T) {
+	restaurant := &domain.Restaurant{
+		ID:           "venue-1",
+		TimezoneName: "UTC",
+		OpeningTimes: map[string][]domain.Times{
+			"monday": {
+				{Type: "open", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC).UnixMilli()}},
+				{Type: "close", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 20, 45, 0, 0, time.UTC).UnixMilli()}},
+			},
+		},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 404}
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{
+					"venue": map[string]any{
+						"id":   "venue-1",
+						"slug": "burger-place",
+						"name": "Burger Place",
+					},
+				}, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return restaurant, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["venue_id"] != "venue-1" {
+		t.Fatalf("expected venue_id venue-1, got %v", data["venue_id"])
+	}
+}
+
+func TestVenueHoursFallbackWhenRestaurantEndpointGone(t *testing.T) {
+	venueItem := &domain.Item{
+		Title: "Burger Place",
+		Link:  domain.Link{Target: "venue-1"},
+		Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place"},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return venueItem, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{
+					"venue": map[string]any{
+						"id":   "venue-1",
+						"slug": "burger-place",
+					},
+				}, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 410}
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--timezone", "Europe/Helsinki", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["venue_id"] != "venue-1" {
+		t.Fatalf("expected venue_id venue-1, got %v", data["venue_id"])
+	}
+	if data["timezone"] != "Europe/Helsinki" {
+		t.Fatalf("expected timezone override Europe/Helsinki, got %v", data["timezone"])
+	}
+}
+
+func TestVenueNoFallbackRefusesStaticPayloads(t *testing.T) {
+	venueItem := &domain.Item{
+		Title: "Burger Place",
+		Link:  domain.Link{Target: "venue-1"},
+		Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place"},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return venueItem, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1", "slug": "burger-place"}}, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 410}
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	for _, args := range [][]string{
+		{"venue", "show", "burger-place"},
+		{"venue", "hours", "burger-place"},
+	} {
+		exitCode, out := runCLIWithDeps(t, deps, append(args, "--no-fallback", "--format", "json")...)
+		if exitCode == 0 {
+			t.Fatalf("%v: expected --no-fallback to fail, got:\n%s", args, out)
+		}
+		errPayload := asMapPayload(t, mustJSON(t, out)["error"])
+		if errPayload["code"] != "WOLT_FALLBACK_REFUSED" || !strings.Contains(asStringPayload(errPayload["message"]), "restaurant detail endpoint unavailable") {
+			t.Fatalf("%v: unexpected error: %v", args, errPayload)
+		}
+
+		exitCode, out = runCLIWithDeps(t, deps, append(args, "--format", "json")...)
+		if exitCode != 0 {
+			t.Fatalf("%v: expected fallback without --no-fallback, got %d\noutput:\n%s", args, exitCode, out)
+		}
+		if args[1] == "show" {
+			completeness := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["completeness"])
+			if sections := asMapPayload(t, completeness["sections"]); sections["name"] != "catalog" || sections["slug"] != nil {
+				t.Fatalf("unexpected fallback completeness sections: %v", sections)
+			}
+			if !containsStringPayload(asSlicePayload(t, completeness["missing"]), "delivery_methods") {
+				t.Fatalf("expected delivery_methods to be missing in fallback mode, got %v", completeness["missing"])
+			}
+		}
+	}
+}
+
+func TestReportDegradationCountsFallbackPaths(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 503}
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1", "slug": "burger-place", "name": "Burger Place"}}, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 410}
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "show", "burger-place", "--report-degradation", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	degradation := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["meta"])["degradation"])
+	if degradation["degraded"] != true || asIntPayload(degradation["fallback_count"]) != 2 {
+		t.Fatalf("unexpected degradation summary: %v", degradation)
+	}
+	paths := []string{}
+	for _, value := range asSlicePayload(t, degradation["fallbacks"]) {
+		fallback := asMapPayload(t, value)
+		if asIntPayload(fallback["count"]) != 1 || fallback["to"] != "static venue page" {
+			t.Fatalf("unexpected fallback: %v", fallback)
+		}
+		paths = append(paths, asStringPayload(fallback["path"]))
+	}
+	if strings.Join(paths, ",") != "venue_catalog_static,restaurant_static" {
+		t.Fatalf("unexpected fallback paths: %v", paths)
+	}
+
+	_, out = runCLIWithDeps(t, deps, "venue", "show", "burger-place", "--format", "json")
+	if _, ok := asMapPayload(t, mustJSON(t, out)["meta"])["degradation"]; ok {
+		t.Fatalf("expected no degradation summary without --report-degradation:\n%s", out)
+	}
+
+	_, out = runCLIWithDeps(t, deps, "venue", "show", "burger-place", "--report-degradation")
+	if !strings.Contains(out, "[degradation] fallbacks=2 venue_catalog_static=1 restaurant_static=1") {
+		t.Fatalf("expected a degradation line after table output, got:\n%s", out)
+	}
+}
+
+func TestVenueShowBulkFetchesSlugList(t *testing.T) {
+	var mu sync.Mutex
+	restaurantCalls := 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(_ context.Context, _ domain.Location, slug string) (*domain.Item, error) {
+				if slug == "missing-place" {
+					return nil, &woltgateway.UpstreamRequestError{StatusCode: 404}
+				}
+				venueID := "venue-" + slug
+				return &domain.Item{Title: slug, Link: domain.Link{Target: venueID}, Venue: &domain.Venue{ID: venueID, Slug: slug}}, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 404}
+			},
+			restaurantByIDFunc: func(_ context.Context, venueID string) (*domain.Restaurant, error) {
+				mu.Lock()
+				restaurantCalls++
+				mu.Unlock()
+				return &domain.Restaurant{ID: venueID, Address: "Street 1", Currency: "EUR"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+	slugsFile := filepath.Join(t.TempDir(), "venues.txt")
+	if err := os.WriteFile(slugsFile, []byte("# directory\nburger-place\n\npizza-place  # evening\nburger-place\n"), 0o644); err != nil {
+		t.Fatalf("write slugs file: %v", err)
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "show", "--slugs-file", slugsFile, "--slug", "missing-place", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	venues := asSlicePayload(t, data["venues"])
+	if len(venues) != 2 || asIntPayload(data["requested"]) != 3 || restaurantCalls != 2 {
+		t.Fatalf("expected 2 of 3 venues from 2 restaurant calls, got %v (calls %d)", data, restaurantCalls)
+	}
+	if asMapPayload(t, venues[0])["venue_id"] != "venue-burger-place" || asMapPayload(t, venues[1])["venue_id"] != "venue-pizza-place" {
+		t.Fatalf("expected venues in input order, got %v", venues)
+	}
+	failures := asSlicePayload(t, data["errors"])
+	if len(failures) != 1 || asMapPayload(t, failures[0])["slug"] != "missing-place" || data["partial"] != true {
+		t.Fatalf("expected missing-place as a partial failure, got %v", data)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "show", "burger-place", "--slug", "pizza-place", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected a slug argument with --slug to fail, got:\n%s", out)
+	}
+}
+
+func TestNotesAnnotateVenueShow(t *testing.T) {
+	t.Setenv("WOLT_JOURNAL_DIR", t.TempDir())
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemBySlugFunc: func(_ context.Context, _ domain.Location, slug string) (*domain.Item, error) {
+				venueID := "venue-" + slug
+				return &domain.Item{Title: slug, Link: domain.Link{Target: venueID}, Venue: &domain.Venue{ID: venueID, Slug: slug}}, nil
 			},
-			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
-				return restaurant, nil
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return nil, &woltgateway.UpstreamRequestError{StatusCode: 404}
+			},
+			restaurantByIDFunc: func(_ context.Context, venueID string) (*domain.Restaurant, error) {
+				return &domain.Restaurant{ID: venueID, Address: "Street 1", Currency: "EUR"}, nil
 			},
 		},
 		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
@@ -1617,111 +3560,147 @@ func TestVenueHoursJSON(t *testing.T) {
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--timezone", "Europe/Helsinki", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "notes", "set", "burger-place", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected notes set without a note or --tag to fail, got:\n%s", out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "notes", "set", "burger-place", "great fries", "--tag", "Burger, late-night", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["timezone"] != "Europe/Helsinki" {
-		t.Fatalf("expected timezone override Europe/Helsinki, got %v", data["timezone"])
+	exitCode, out = runCLIWithDeps(t, deps, "notes", "set", "burger-place", "--tag", "burger", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	windows := asSlicePayload(t, data["opening_windows"])
-	if len(windows) != 7 {
-		t.Fatalf("expected 7 opening windows, got %d", len(windows))
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["my_note"] != "great fries" || len(asSlicePayload(t, data["my_tags"])) != 1 {
+		t.Fatalf("expected the tag-only update to keep the note text, got %v", data)
 	}
-	first := asMapPayload(t, windows[0])
-	if first["day"] != "monday" || first["open"] != "10:00" || first["close"] != "20:45" {
-		t.Fatalf("unexpected monday window: %v", first)
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "show", "--slug", "burger-place", "--slug", "pizza-place", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	venues := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["venues"])
+	if len(venues) != 2 {
+		t.Fatalf("expected two venues, got %v", venues)
+	}
+	burger := asMapPayload(t, venues[0])
+	if burger["my_note"] != "great fries" || !containsStringPayload(asSlicePayload(t, burger["my_tags"]), "burger") {
+		t.Fatalf("expected burger-place to carry the note, got %v", burger)
+	}
+	if _, ok := asMapPayload(t, venues[1])["my_note"]; ok {
+		t.Fatalf("expected pizza-place without a note, got %v", venues[1])
 	}
-}
 
-func TestVenueHoursFallbackStaticWhenItemLookupFails(t *testing.T) {
-	restaurant := &domain.Restaurant{
-		ID:           "venue-1",
-		TimezoneName: "UTC",
-		OpeningTimes: map[string][]domain.Times{
-			"monday": {
-				{Type: "open", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC).UnixMilli()}},
-				{Type: "close", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 20, 45, 0, 0, time.UTC).UnixMilli()}},
-			},
-		},
+	exitCode, out = runCLIWithDeps(t, deps, "notes", "show", "pizza-place", "--format", "json")
+	if exitCode != 0 || !strings.Contains(out, "no note for pizza-place") {
+		t.Fatalf("expected a missing-note warning, got %d\noutput:\n%s", exitCode, out)
 	}
+}
+
+func TestVenueResolveMapsIDToSlug(t *testing.T) {
+	requested := ""
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
-				return nil, &woltgateway.UpstreamRequestError{StatusCode: 404}
-			},
-			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
-				return map[string]any{
-					"venue": map[string]any{
-						"id":   "venue-1",
-						"slug": "burger-place",
-						"name": "Burger Place",
-					},
+			restaurantByIDFunc: func(_ context.Context, venueID string) (*domain.Restaurant, error) {
+				requested = venueID
+				return &domain.Restaurant{
+					ID:   venueID,
+					Slug: "burger-place",
+					Name: []domain.Translation{{Lang: "fi", Value: "Purilaispaikka"}, {Lang: "en", Value: "Burger Place"}},
 				}, nil
 			},
-			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
-				return restaurant, nil
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"public_url": "https://wolt.com/en/fin/helsinki/restaurant/burger-place"}}, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "resolve", "5A8426F188B5DE000B8857BB", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["venue_id"] != "venue-1" {
-		t.Fatalf("expected venue_id venue-1, got %v", data["venue_id"])
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if requested != "5a8426f188b5de000b8857bb" || data["slug"] != "burger-place" || data["name"] != "Burger Place" {
+		t.Fatalf("unexpected resolve data %v (requested %q)", data, requested)
+	}
+	if data["public_url"] != "https://wolt.com/en/fin/helsinki/restaurant/burger-place" {
+		t.Fatalf("expected public_url from the static page, got %v", data["public_url"])
 	}
-}
 
-func TestVenueHoursFallbackWhenRestaurantEndpointGone(t *testing.T) {
-	venueItem := &domain.Item{
-		Title: "Burger Place",
-		Link:  domain.Link{Target: "venue-1"},
-		Venue: &domain.Venue{ID: "venue-1", Slug: "burger-place"},
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "resolve", "burger-place", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected invalid argument for a slug, got %d\n%s", exitCode, out)
 	}
+}
+
+func TestVenueKnownMapSkipsRepeatLookups(t *testing.T) {
+	const venueID = "5a8426f188b5de000b8857bb"
+	restaurantCalls := 0
+	staticCalls := 0
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
-				return venueItem, nil
+			restaurantByIDFunc: func(_ context.Context, id string) (*domain.Restaurant, error) {
+				restaurantCalls++
+				return &domain.Restaurant{
+					ID:        id,
+					Slug:      "burger-place",
+					Name:      []domain.Translation{{Lang: "en", Value: "Burger Place"}},
+					City:      "Helsinki",
+					PublicURL: "https://wolt.com/en/fin/helsinki/restaurant/burger-place",
+				}, nil
 			},
 			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
-				return map[string]any{
-					"venue": map[string]any{
-						"id":   "venue-1",
-						"slug": "burger-place",
-					},
-				}, nil
+				staticCalls++
+				return map[string]any{"venue": map[string]any{"id": venueID}}, nil
 			},
-			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
-				return nil, &woltgateway.UpstreamRequestError{StatusCode: 410}
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"categories": []any{}}, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "venue", "hours", "burger-place", "--timezone", "Europe/Helsinki", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "resolve", venueID, "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["venue_id"] != "venue-1" {
-		t.Fatalf("expected venue_id venue-1, got %v", data["venue_id"])
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "categories", venueID, "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if data["timezone"] != "Europe/Helsinki" {
-		t.Fatalf("expected timezone override Europe/Helsinki, got %v", data["timezone"])
+	if restaurantCalls != 1 || staticCalls != 0 {
+		t.Fatalf("expected the venue map to answer the second lookup, got %d restaurant and %d static calls", restaurantCalls, staticCalls)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["venue_id"] != venueID {
+		t.Fatalf("expected venue id from the venue map, got %v", data["venue_id"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "known", "helsinki", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	venues := asSlicePayload(t, data["venues"])
+	if asIntPayload(data["count"]) != 1 || len(venues) != 1 {
+		t.Fatalf("expected one known venue, got %v", data)
+	}
+	venue := asMapPayload(t, venues[0])
+	if venue["venue_id"] != venueID || venue["slug"] != "burger-place" || venue["name"] != "Burger Place" || venue["city"] != "Helsinki" {
+		t.Fatalf("unexpected known venue %v", venue)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "venue", "known", "sushi", "--format", "json")
+	if exitCode != 0 || asIntPayload(asMapPayload(t, mustJSON(t, out)["data"])["count"]) != 0 {
+		t.Fatalf("expected no match for an unrelated query, got %d\n%s", exitCode, out)
 	}
 }
 
@@ -1836,6 +3815,52 @@ func TestItemShowFailsWhenItemMissingInVenue(t *testing.T) {
 	}
 }
 
+func TestItemShowBatchSharesOneAssortmentFetch(t *testing.T) {
+	assortmentCalls := 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1"}}, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				assortmentCalls++
+				return map[string]any{
+					"items": []any{
+						map[string]any{"id": "item-1", "name": "Combo", "price": 1299},
+						map[string]any{"id": "item-2", "name": "Fries", "price": 399},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 0, Lon: 0}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "item", "show", "burger-place", "--item-id", "item-1", "--item-id", "item-2", "--item-id", "item-missing", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if assortmentCalls != 1 {
+		t.Fatalf("expected one assortment fetch for the batch, got %d", assortmentCalls)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	items := asSlicePayload(t, data["items"])
+	if len(items) != 2 || asMapPayload(t, items[0])["item_id"] != "item-1" || asMapPayload(t, items[1])["item_id"] != "item-2" {
+		t.Fatalf("expected item-1 and item-2 in order, got %v", items)
+	}
+	failures := asSlicePayload(t, data["errors"])
+	if len(failures) != 1 || asMapPayload(t, failures[0])["item_id"] != "item-missing" {
+		t.Fatalf("expected item-missing under errors, got %v", failures)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "item", "show", "burger-place", "item-1", "--item-id", "item-2", "--format", "json")
+	if exitCode == 0 {
+		t.Fatalf("expected an item-id argument with --item-id to fail, got:\n%s", out)
+	}
+}
+
 func TestConfigureCommandSavesProfile(t *testing.T) {
 	cfg := &recordingConfig{loadErr: errors.New("config not found")}
 	loc := &recordingLocation{location: domain.Location{Lat: 60.1699, Lon: 24.9384}}
@@ -1979,6 +4004,158 @@ func TestConfigureCommandUpdatesAuthWithoutAddress(t *testing.T) {
 	}
 }
 
+func TestConfigureCommandUpdatesProfileSettings(t *testing.T) {
+	cfg := &recordingConfig{loadCfg: domain.Config{Profiles: []domain.Profile{{Name: "default", IsDefault: true, WToken: "token"}}}}
+	deps := cli.Dependencies{
+		Wolt:     &mockWolt{},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &recordingLocation{},
+		Config:   cfg,
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--default-tip-percent", "12.5", "--auto-apply-best-promo", "--locale", "fi-FI", "--machine")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if action := asMapPayload(t, mustJSON(t, out)["data"])["action"]; action != "settings_updated" {
+		t.Fatalf("expected settings_updated action, got %v", action)
+	}
+	saved := cfg.saved.Profiles[0]
+	if saved.DefaultTipPercent != 12.5 || !saved.AutoApplyBestPromo || saved.Locale != "fi-FI" || saved.WToken != "token" {
+		t.Fatalf("unexpected saved profile %+v", saved)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--default-tip-percent", "150")
+	if exitCode != 1 || !strings.Contains(out, "--default-tip-percent must be between 0 and 100") {
+		t.Fatalf("expected tip percent range error, got %d\noutput:\n%s", exitCode, out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--locale", "not a locale")
+	if exitCode != 1 || !strings.Contains(out, "--locale must be a BCP-47 tag") {
+		t.Fatalf("expected locale validation error, got %d\noutput:\n%s", exitCode, out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--enrichment-mode", "none")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if saved := cfg.saved.Profiles[0]; saved.EnrichmentMode != "fast" {
+		t.Fatalf("expected enrichment mode stored as fast, got %q", saved.EnrichmentMode)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--enrichment-mode", "top:0")
+	if exitCode != 1 || !strings.Contains(out, "--enrichment-mode must be") {
+		t.Fatalf("expected enrichment mode validation error, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestConfigureCommandStoresMealPresets(t *testing.T) {
+	cfg := &recordingConfig{loadCfg: domain.Config{Profiles: []domain.Profile{{
+		Name:        "default",
+		IsDefault:   true,
+		MealPresets: map[string]domain.MealPreset{"late": {From: "23:00", To: "03:00"}},
+	}}}}
+	deps := cli.Dependencies{
+		Wolt:     &mockWolt{},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &recordingLocation{},
+		Config:   cfg,
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--meal-preset", "breakfast=07:30-10:00,Bakery,smoothie bowl", "--meal-preset", "late=", "--machine")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	saved := cfg.saved.Profiles[0].MealPresets
+	breakfast := saved["breakfast"]
+	if len(saved) != 1 || breakfast.From != "07:30" || breakfast.To != "10:00" || strings.Join(breakfast.Tags, ",") != "bakery,smoothie-bowl" {
+		t.Fatalf("unexpected saved meal presets %+v", saved)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "configure", "--profile-name", "default", "--meal-preset", "brunch=10:00")
+	if exitCode != 1 || !strings.Contains(out, "HH:MM-HH:MM window") {
+		t.Fatalf("expected meal preset window error, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestDiscoverMealPresetKeepsTaggedOpenVenues(t *testing.T) {
+	cafe := buildVenue("venue-1", "morning-cafe", "Street 1")
+	cafe.Tags = []string{"Cafe"}
+	closedCafe := buildVenue("venue-2", "closed-cafe", "Street 2")
+	closedCafe.Tags = []string{"cafe"}
+	closedCafe.Online = boolPtr(false)
+	burger := buildVenue("venue-3", "burger-place", "Street 3")
+	sections := []domain.Section{{
+		Name:  "popular",
+		Title: "Popular",
+		Items: []domain.Item{
+			{Title: "Morning Cafe", Link: domain.Link{Target: "venue-1"}, Venue: cafe},
+			{Title: "Closed Cafe", Link: domain.Link{Target: "venue-2"}, Venue: closedCafe},
+			{Title: "Burger Place", Link: domain.Link{Target: "venue-3"}, Venue: burger},
+		},
+	}}
+	profiles := &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}}
+	var hoursLookups atomic.Int32
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": sections}, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				hoursLookups.Add(1)
+				return nil, errors.New("hours unavailable")
+			},
+		},
+		Profiles: profiles,
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "discover", "breakfast", "--now", "2026-02-16T08:00", "--fast", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	meal := asMapPayload(t, data["meal"])
+	if meal["name"] != "breakfast" || meal["in_window"] != true || meal["open_check"] != "online" {
+		t.Fatalf("unexpected meal metadata %v", meal)
+	}
+	items := asSlicePayload(t, asMapPayload(t, asSlicePayload(t, data["sections"])[0])["items"])
+	if len(items) != 1 || asMapPayload(t, items[0])["slug"] != "morning-cafe" {
+		t.Fatalf("expected only the open cafe, got %v", items)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "now", "--now", "2026-02-16T13:00", "--fast", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	if name := asMapPayload(t, data["meal"])["name"]; name != "lunch" {
+		t.Fatalf("expected now to pick lunch at 13:00, got %v", name)
+	}
+
+	// A profile window that has not started yet checks opening hours instead.
+	profiles.profile.MealPresets = map[string]domain.MealPreset{"breakfast": {From: "09:00", To: "11:00"}}
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--meal", "breakfast", "--now", "2026-02-16T08:00", "--fast", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	meal = asMapPayload(t, asMapPayload(t, payload["data"])["meal"])
+	if meal["open_check"] != "opening_hours" || !strings.HasPrefix(asStringPayload(meal["opens_at"]), "2026-02-16T09:00") {
+		t.Fatalf("expected an opening-hours check at 09:00, got %v", meal)
+	}
+	if hoursLookups.Load() != 2 || !strings.Contains(out, "opening hours unavailable") {
+		t.Fatalf("expected both cafes to be looked up and skipped, got %d lookups\noutput:\n%s", hoursLookups.Load(), out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "discover", "feed", "--meal", "supper", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected invalid meal error, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
 func containsStringPayload(values []any, expected string) bool {
 	for _, raw := range values {
 		if strings.TrimSpace(asStringPayload(raw)) == strings.TrimSpace(expected) {
@@ -1990,3 +4167,129 @@ func containsStringPayload(values []any, expected string) bool {
 
 var _ cli.ConfigManager = (*recordingConfig)(nil)
 var _ cli.LocationResolver = (*recordingLocation)(nil)
+
+func TestStatusSeparatesOutageFromBrokenToken(t *testing.T) {
+	unauthorized := func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+		return nil, &woltgateway.UpstreamRequestError{StatusCode: 401}
+	}
+	wolt := &mockWolt{
+		frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+			return map[string]any{}, nil
+		},
+		searchFunc: func(context.Context, domain.Location, string) (map[string]any, error) {
+			return map[string]any{}, nil
+		},
+		userMeFunc:         unauthorized,
+		basketCountFunc:    unauthorized,
+		paymentMethodsFunc: unauthorized,
+		orderHistoryFunc: func(context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions) (map[string]any, error) {
+			return nil, &woltgateway.UpstreamRequestError{StatusCode: 401}
+		},
+	}
+	deps := cli.Dependencies{
+		Wolt:     wolt,
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "status", "--wtoken", "token", "--format", "json", "--fail-on-error")
+	if exitCode != 1 {
+		t.Fatalf("expected exit 1 with --fail-on-error, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["diagnosis"] != "auth_failed" {
+		t.Fatalf("expected auth_failed diagnosis, got %v", data["diagnosis"])
+	}
+	statuses := map[string]string{}
+	for _, value := range asSlicePayload(t, data["endpoints"]) {
+		endpoint := asMapPayload(t, value)
+		statuses[asStringPayload(endpoint["name"])] = asStringPayload(endpoint["status"])
+	}
+	if statuses["front_page"] != "ok" || statuses["user_me"] != "auth_error" || statuses["venue_page_static"] != "skipped" {
+		t.Fatalf("unexpected endpoint statuses: %v", statuses)
+	}
+
+	wolt.frontPageFunc = func(context.Context, domain.Location) (map[string]any, error) {
+		return nil, &woltgateway.UpstreamRequestError{StatusCode: 503}
+	}
+	wolt.searchFunc = func(context.Context, domain.Location, string) (map[string]any, error) {
+		return nil, &woltgateway.UpstreamRequestError{StatusCode: 503}
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "status", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0 without --fail-on-error, got %d\noutput:\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	if data["diagnosis"] != "wolt_unreachable" {
+		t.Fatalf("expected wolt_unreachable diagnosis, got %v\noutput:\n%s", data["diagnosis"], out)
+	}
+}
+
+func TestExplainRequestReportsResolvedInputs(t *testing.T) {
+	itemsCalls := 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				itemsCalls++
+				return []domain.Item{}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "home", IsDefault: true, WToken: "test-token"}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "search", "venues", "--query", "burger", "--explain-only", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\n%s", exitCode, out)
+	}
+	if itemsCalls != 0 {
+		t.Fatalf("expected --explain-only to stop before any venue request, got %d calls", itemsCalls)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["command"] != "search venues" {
+		t.Fatalf("expected the command path, got %v", data["command"])
+	}
+	profile := asMapPayload(t, data["profile"])
+	if profile["name"] != "home" || profile["source"] != "default" {
+		t.Fatalf("expected the default profile, got %v", profile)
+	}
+	location := asMapPayload(t, data["location"])
+	if location["source"] != "account" || location["lat"] != 60.1699 || location["wolt_address_id"] != nil {
+		t.Fatalf("expected coordinates from the Wolt account, got %v", location)
+	}
+	auth := asMapPayload(t, data["auth"])
+	if auth["authenticated"] != true || auth["source"] != "profile" {
+		t.Fatalf("expected profile credentials, got %v", auth)
+	}
+	locale := asMapPayload(t, data["locale"])
+	if locale["locale"] != "en-FI" || locale["source"] != "default" || locale["language"] != "en" {
+		t.Fatalf("expected the default locale, got %v", locale)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--query", "burger", "--explain-only", "--address", "Kamppi", "--locale", "fi-FI", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	location = asMapPayload(t, data["location"])
+	if location["source"] != "address" || location["address"] != "Kamppi" || location["lat"] != float64(50) {
+		t.Fatalf("expected geocoded --address coordinates, got %v", location)
+	}
+	if asMapPayload(t, data["locale"])["source"] != "flag" {
+		t.Fatalf("expected the locale from the flag, got %v", data["locale"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "search", "venues", "--query", "burger", "--explain-request", "--lat=60.2", "--lon=24.9", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\n%s", exitCode, out)
+	}
+	if itemsCalls != 1 {
+		t.Fatalf("expected --explain-request to run the command, got %d venue calls", itemsCalls)
+	}
+	if !strings.Contains(out, "[explain] Location: 60.200000,24.900000 (flags)") || !strings.Contains(out, "[explain] Profile: home (default)") {
+		t.Fatalf("expected explain lines on stderr, got:\n%s", out)
+	}
+}
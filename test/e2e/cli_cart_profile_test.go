@@ -1,14 +1,24 @@
 package e2e_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mekedron/wolt-cli/internal/cli"
+	"github.com/mekedron/wolt-cli/internal/config"
 	"github.com/mekedron/wolt-cli/internal/domain"
 	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/mekedron/wolt-cli/internal/service/journal"
+	"github.com/mekedron/wolt-cli/internal/service/output"
 )
 
 func TestAuthStatusJSONWithToken(t *testing.T) {
@@ -277,6 +287,58 @@ func TestAuthStatusAutoRefreshesExpiredTokenAndPersistsProfile(t *testing.T) {
 	}
 }
 
+func TestAuthPreflightFailsExpiredTokenBeforeAnyRequestAndRefreshRotatesIt(t *testing.T) {
+	expired := "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1700000000}`)) + ".sig"
+	fresh := "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":4102444800}`)) + ".sig"
+	profile := domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}, WToken: expired, WRefreshToken: "refresh-old"}
+	cfg := &recordingConfig{loadCfg: domain.Config{Profiles: []domain.Profile{profile}}}
+	basketCalls := 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				basketCalls++
+				return map[string]any{"baskets": []any{}}, nil
+			},
+			refreshAccessTokenFn: func(_ context.Context, refreshToken string, _ woltgateway.AuthContext) (woltgateway.TokenRefreshResult, error) {
+				if refreshToken != "refresh-old" {
+					t.Fatalf("expected refresh token refresh-old, got %q", refreshToken)
+				}
+				return woltgateway.TokenRefreshResult{AccessToken: fresh, RefreshToken: "refresh-new"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: profile},
+		Location: &mockLocation{},
+		Config:   cfg,
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "cart", "show", "--auth-preflight", "--format", "json")
+	if exitCode == 0 || basketCalls != 0 {
+		t.Fatalf("expected the preflight to fail before any request, got exit %d after %d calls\noutput:\n%s", exitCode, basketCalls, out)
+	}
+	failure := asMapPayload(t, mustJSON(t, out)["error"])
+	if failure["code"] != "WOLT_AUTH_REQUIRED" || !strings.Contains(asStringPayload(failure["message"]), "wolt auth refresh") {
+		t.Fatalf("expected WOLT_AUTH_REQUIRED with a refresh hint, got %v", failure)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "addresses", "use", "address-1", "--auth-preflight", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected commands that only touch the local profile to skip the preflight, got exit %d\noutput:\n%s", exitCode, out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "auth", "refresh", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["refreshed"] != true || data["saved_to_profile"] != true || data["session_expires_at"] != "2100-01-01T00:00:00Z" {
+		t.Fatalf("expected a saved refresh with the new expiry, got %v", data)
+	}
+	if cfg.saved == nil || cfg.saved.Profiles[0].WToken != fresh || cfg.saved.Profiles[0].WRefreshToken != "refresh-new" {
+		t.Fatalf("expected the rotated tokens in the profile, got %+v", cfg.saved)
+	}
+}
+
 func TestCartShowJSON(t *testing.T) {
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
@@ -319,6 +381,53 @@ func TestCartShowJSON(t *testing.T) {
 	}
 }
 
+func TestCartShowHASensor(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(_ context.Context, _ domain.Location, _ woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€17.00",
+							"venue": map[string]any{"id": "venue-1", "name": "Burger Place", "slug": "burger-place"},
+							"items": []any{
+								map[string]any{"id": "line-1", "name": "Classics set", "count": 2, "price": 850, "options": []any{}},
+							},
+							"telemetry": map[string]any{"basket_total": 1700},
+						},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "cart", "show", "--wtoken", "token", "--format", "ha-sensor")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	if _, ok := payload["meta"]; ok {
+		t.Fatalf("ha-sensor output must not be wrapped in an envelope: %s", out)
+	}
+	if asIntPayload(payload["state"]) != 2 {
+		t.Fatalf("expected state 2, got %v", payload["state"])
+	}
+	attributes := asMapPayload(t, payload["attributes"])
+	if attributes["venue_name"] != "Burger Place" || asIntPayload(attributes["total_amount"]) != 1700 {
+		t.Fatalf("unexpected attributes: %v", attributes)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "cart", "count", "--wtoken", "token", "--format", "ha-sensor")
+	if exitCode == 0 || !strings.Contains(out, "--format ha-sensor is supported by") {
+		t.Fatalf("expected unsupported command error, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
 func TestCartShowTableWithDetails(t *testing.T) {
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
@@ -644,6 +753,73 @@ func TestCartAddMergesWhenVenueArgIsSlug(t *testing.T) {
 	}
 }
 
+func TestCartUpdateSetsSubstitutionAndKeepsOtherLines(t *testing.T) {
+	seenAddPayload := map[string]any{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			addToBasketFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenAddPayload = payload
+				return map[string]any{"id": "basket-1", "venue_id": "venue-1"}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€5.00",
+							"venue": map[string]any{"id": "venue-1"},
+							"items": []any{
+								map[string]any{
+									"id": "milk", "name": "Milk 1 l", "count": 1, "price": 150, "options": []any{},
+									"substitution_settings": map[string]any{"is_allowed": true, "note": "Any brand"},
+								},
+								map[string]any{
+									"id": "bread", "name": "Bread", "count": 1, "price": 350, "options": []any{},
+									"substitution_settings": map[string]any{"is_allowed": true, "note": "Rye only"},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "cart", "update", "milk", "--count", "2", "--no-substitution", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	items := asSlicePayload(t, seenAddPayload["items"])
+	if len(items) != 2 {
+		t.Fatalf("expected both lines re-sent, got %d", len(items))
+	}
+	milk := asMapPayload(t, items[0])
+	if asIntPayload(milk["count"]) != 2 {
+		t.Fatalf("expected milk count 2, got %v", milk["count"])
+	}
+	settings := asMapPayload(t, milk["substitution_settings"])
+	if settings["is_allowed"] != false || settings["note"] != "Any brand" {
+		t.Fatalf("expected substitution disabled with note kept, got %v", settings)
+	}
+	bread := asMapPayload(t, asMapPayload(t, items[1])["substitution_settings"])
+	if bread["is_allowed"] != true || bread["note"] != "Rye only" {
+		t.Fatalf("expected untouched line to keep its settings, got %v", bread)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["mutation"] != "update" || asMapPayload(t, data["substitution"])["allowed"] != false {
+		t.Fatalf("unexpected update data: %v", data)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "cart", "update", "milk", "--wtoken", "token", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected WOLT_INVALID_ARGUMENT without changes, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
 func TestCartRemoveJSON(t *testing.T) {
 	seenPayload := map[string]any{}
 	deps := cli.Dependencies{
@@ -803,6 +979,7 @@ func TestCartClearJSON(t *testing.T) {
 }
 
 func TestCheckoutPreviewJSON(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
 	seenPayload := map[string]any{}
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
@@ -936,8 +1113,168 @@ func TestCheckoutPreviewJSON(t *testing.T) {
 	}
 }
 
-func TestCheckoutPreviewUsesVenuePayloadCategoryFallback(t *testing.T) {
-	seenPayload := map[string]any{}
+func TestCheckoutPreviewEnforcesMonthlyBudget(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	deps := goldenDeps()
+	wolt := deps.Wolt.(*mockWolt)
+	wolt.orderHistoryFunc = func(context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions) (map[string]any, error) {
+		return map[string]any{"orders": []any{
+			map[string]any{"purchase_id": "p-now", "status": "delivered", "total_amount": "€15.38", "payment_time_ts": time.Now().UnixMilli()},
+			map[string]any{"purchase_id": "p-rejected", "status": "rejected", "total_amount": "€99.00", "payment_time_ts": time.Now().UnixMilli()},
+			map[string]any{"purchase_id": "p-old", "status": "delivered", "total_amount": "€50.00", "payment_time_ts": time.Now().AddDate(0, -2, 0).UnixMilli()},
+		}}, nil
+	}
+	withBudget := func(amount int) {
+		deps.Profiles = &mockProfiles{profile: domain.Profile{
+			Name: "default", IsDefault: true, WToken: "token", Location: domain.Location{Lat: 60.1, Lon: 24.9},
+			Budget: &domain.Budget{Amount: amount, Currency: "EUR", Period: "month"},
+		}}
+	}
+
+	// 15.38 spent plus the 18.19 preview is over a 30.00 budget.
+	withBudget(3000)
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_BUDGET_EXCEEDED") {
+		t.Fatalf("expected WOLT_BUDGET_EXCEEDED, got %d\noutput:\n%s", exitCode, out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--force", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected --force to preview anyway, got %d\noutput:\n%s", exitCode, out)
+	}
+	budget := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["budget"])
+	if asIntPayload(asMapPayload(t, budget["spent"])["amount"]) != 1538 || asIntPayload(budget["orders"]) != 1 {
+		t.Fatalf("expected only this month's delivered order as spend, got %v", budget)
+	}
+	if asIntPayload(asMapPayload(t, budget["remaining"])["amount"]) != 1462 || asIntPayload(asMapPayload(t, budget["remaining_after_checkout"])["amount"]) != -357 {
+		t.Fatalf("unexpected remaining budget %v", budget)
+	}
+
+	withBudget(4000)
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--format", "json")
+	if exitCode != 0 || !strings.Contains(out, "month spend is at 83% of the €40.00 budget") {
+		t.Fatalf("expected an 80%% warning, got %d\noutput:\n%s", exitCode, out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "budget", "show", "--format", "markdown")
+	if exitCode != 0 || !strings.Contains(out, "# Wolt budget\n\n## Budget\n\n| Field | Value |") || !strings.Contains(out, "| Remaining | €24.62 |") {
+		t.Fatalf("expected the budget as a markdown table, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestCartAndCheckoutRefuseVenuesOutsideAllowedCountries(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	deps := goldenDeps()
+	wolt := deps.Wolt.(*mockWolt)
+	wolt.restaurantByIDFunc = func(_ context.Context, id string) (*domain.Restaurant, error) {
+		return &domain.Restaurant{ID: id, Slug: "tallinn-burger", Country: "EST"}, nil
+	}
+	withAllowed := func(countries ...string) {
+		deps.Profiles = &mockProfiles{profile: domain.Profile{
+			Name: "default", IsDefault: true, WToken: "token", Location: domain.Location{Lat: 60.1, Lon: 24.9},
+			AllowedCountries: countries,
+		}}
+	}
+
+	withAllowed("FIN")
+	for _, args := range [][]string{
+		{"cart", "add", "venue-1", "item-1", "--format", "json"},
+		{"checkout", "preview", "--format", "json"},
+	} {
+		exitCode, out := runCLIWithDeps(t, deps, args...)
+		if exitCode == 0 {
+			t.Fatalf("expected %v to be refused, got:\n%s", args, out)
+		}
+		failure := asMapPayload(t, mustJSON(t, out)["error"])
+		if failure["code"] != "WOLT_COUNTRY_NOT_ALLOWED" || !strings.Contains(asStringPayload(failure["message"]), "venue is in EST") {
+			t.Fatalf("expected WOLT_COUNTRY_NOT_ALLOWED for %v, got %v", args, failure)
+		}
+	}
+
+	withAllowed("FIN", "EST")
+	exitCode, out := runCLIWithDeps(t, deps, "cart", "add", "venue-1", "item-1", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected an allowed country to pass, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestDigestSummarizesOrdersVenuesDiscountsAndCredits(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	deps := goldenDeps()
+	wolt := deps.Wolt.(*mockWolt)
+	wolt.orderHistoryFunc = func(context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions) (map[string]any, error) {
+		return map[string]any{"orders": []any{
+			map[string]any{"purchase_id": "p-recent", "status": "delivered", "venue_name": "Burger Place", "total_amount": "€15.38", "payment_time_ts": time.Now().AddDate(0, 0, -2).UnixMilli()},
+			map[string]any{"purchase_id": "p-cancelled", "status": "cancelled", "venue_name": "Burger Place", "total_amount": "€9.00", "payment_time_ts": time.Now().AddDate(0, 0, -1).UnixMilli()},
+			map[string]any{"purchase_id": "p-old", "status": "delivered", "venue_name": "Burger Place", "total_amount": "€50.00", "payment_time_ts": time.Now().AddDate(0, 0, -30).UnixMilli()},
+		}}, nil
+	}
+	wolt.venuePageDynamicFunc = func(_ context.Context, slug string, _ woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+		return map[string]any{"venue": map[string]any{"slug": slug, "promotions": []any{map[string]any{"text": "-20% on burgers"}}}}, nil
+	}
+	wolt.paymentMethodsFunc = func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+		return map[string]any{"methods": []any{
+			map[string]any{"id": "card-1", "type": "card", "name": "Visa"},
+			map[string]any{"id": "gift-soon", "type": "gift_card", "name": "Birthday gift card", "balance": map[string]any{"amount": 1000, "currency": "EUR"}, "expires_at": time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+			map[string]any{"id": "gift-later", "type": "gift_card", "name": "Holiday gift card", "balance": map[string]any{"amount": 2500, "currency": "EUR"}, "expires_at": time.Now().AddDate(0, 3, 0).Format("2006-01-02")},
+		}}, nil
+	}
+	wolt.paymentBalancesFunc = func(context.Context, woltgateway.AuthContext, string) (map[string]any, error) {
+		return map[string]any{"balances": []any{}}, nil
+	}
+	items := []domain.Item{{Title: "Burger Place", Link: domain.Link{Target: "5a8426f188b5de000b8857bb"}, Venue: buildVenue("5a8426f188b5de000b8857bb", "burger-place", "Street 1")}}
+	wolt.frontPageFunc = func(context.Context, domain.Location) (map[string]any, error) {
+		return map[string]any{"city_data": map[string]any{"name": "Helsinki"}, "sections": []domain.Section{{Name: "popular", Title: "Popular", Items: items}}}, nil
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "digest", "--since", "7d", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if asIntPayload(data["order_count"]) != 2 || len(asSlicePayload(t, data["orders"])) != 2 {
+		t.Fatalf("expected the two orders of the last week, got %v", data["orders"])
+	}
+	spend := asSlicePayload(t, data["spend"])
+	if len(spend) != 1 || asMapPayload(t, spend[0])["currency"] != "EUR" || asIntPayload(asMapPayload(t, spend[0])["amount"]) != 1538 {
+		t.Fatalf("expected €15.38 of spend without the cancelled order, got %v", spend)
+	}
+	discounts := asSlicePayload(t, data["favorite_discounts"])
+	if len(discounts) != 1 || asMapPayload(t, discounts[0])["slug"] != "burger-place" || !strings.Contains(fmt.Sprint(discounts[0]), "-20% on burgers") {
+		t.Fatalf("expected the favourite promotion, got %v", discounts)
+	}
+	credits := asSlicePayload(t, data["expiring_credits"])
+	if len(credits) != 1 || asMapPayload(t, credits[0])["method_id"] != "gift-soon" || asIntPayload(asMapPayload(t, credits[0])["amount"]) != 1000 {
+		t.Fatalf("expected only the gift card expiring within 30 days, got %v", credits)
+	}
+	if venues := asSlicePayload(t, data["new_venues"]); len(venues) != 0 || !strings.Contains(fmt.Sprint(payload["warnings"]), "first-seen tracking started") {
+		t.Fatalf("expected no new venues on the first tracked run, got %v (warnings %v)", venues, payload["warnings"])
+	}
+
+	items = append(items, domain.Item{Title: "Sushi Place", Link: domain.Link{Target: "5a8426f188b5de000b8857cc"}, Venue: buildVenue("5a8426f188b5de000b8857cc", "sushi-place", "Street 2")})
+	exitCode, out = runCLIWithDeps(t, deps, "digest", "--format", "markdown")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	for _, want := range []string{"# Wolt digest", "| New venues | 1 |", "| Sushi Place | sushi-place |", "| Birthday gift card | gift_card | €10.00 |"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in the markdown digest, got:\n%s", want, out)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "digest", "--since", "7x", "--format", "json")
+	if exitCode == 0 || asStringPayload(asMapPayload(t, mustJSON(t, out)["error"])["code"]) != "WOLT_INVALID_ARGUMENT" {
+		t.Fatalf("expected an invalid --since to be rejected, got %d:\n%s", exitCode, out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "cart", "show", "--format", "markdown")
+	if exitCode == 0 || !strings.Contains(out, "--format markdown is supported by digest") {
+		t.Fatalf("expected --format markdown outside digest to be rejected, got %d:\n%s", exitCode, out)
+	}
+}
+
+func TestCheckoutPreviewCachesLineMetadata(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	itemPageCalls := 0
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
 			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
@@ -946,13 +1283,15 @@ func TestCheckoutPreviewUsesVenuePayloadCategoryFallback(t *testing.T) {
 						map[string]any{
 							"id":    "basket-1",
 							"total": "€17.00",
-							"venue": map[string]any{"id": "venue-1", "country": "FIN", "slug": "venue-1-slug"},
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
 							"items": []any{
 								map[string]any{
-									"id":      "item-1",
-									"count":   1,
-									"price":   1700,
-									"options": []any{},
+									"id":    "item-1",
+									"count": 1,
+									"price": 1700,
+									"options": []any{
+										map[string]any{"id": "group-1", "values": []any{map[string]any{"id": "value-1", "count": 1}}},
+									},
 								},
 							},
 						},
@@ -960,27 +1299,26 @@ func TestCheckoutPreviewUsesVenuePayloadCategoryFallback(t *testing.T) {
 				}, nil
 			},
 			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				itemPageCalls++
 				return map[string]any{
-					"id":   "item-1",
-					"name": "Fallback Burger",
-				}, nil
-			},
-			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
-				return map[string]any{
-					"categories": []any{
+					"sections": []any{
 						map[string]any{
-							"id":       "cat-fallback",
-							"item_ids": []any{"item-1"},
+							"categories": []any{map[string]any{"id": "cat-1", "item_ids": []any{"item-1"}}},
+							"options": []any{
+								map[string]any{"id": "group-1", "values": []any{map[string]any{"id": "value-1", "price": 150}}},
+							},
 						},
 					},
 				}, nil
 			},
 			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
-				seenPayload = payload
-				return map[string]any{
-					"payable_amount": 1700,
-					"checkout_rows":  []any{},
-				}, nil
+				plan := asMapPayload(t, payload["purchase_plan"])
+				item := asMapPayload(t, asSlicePayload(t, plan["menu_items"])[0])
+				value := asMapPayload(t, asSlicePayload(t, asMapPayload(t, asSlicePayload(t, item["options"])[0])["values"])[0])
+				if item["category_id"] != "cat-1" || asIntPayload(value["price"]) != 150 {
+					t.Fatalf("unexpected menu item %v", item)
+				}
+				return map[string]any{"payable_amount": 1850}, nil
 			},
 		},
 		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
@@ -989,23 +1327,44 @@ func TestCheckoutPreviewUsesVenuePayloadCategoryFallback(t *testing.T) {
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
-	if exitCode != 0 {
-		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	sources := func(out string) []string {
+		data := asMapPayload(t, mustJSON(t, out)["data"])
+		result := []string{}
+		for _, line := range asSlicePayload(t, data["line_resolution"]) {
+			result = append(result, asStringPayload(asMapPayload(t, line)["resolution_source"]))
+		}
+		return result
+	}
+	run := func(extra ...string) string {
+		t.Helper()
+		args := append([]string{"checkout", "preview", "--wtoken", "token", "--format", "json", "--machine", "--verbose"}, extra...)
+		exitCode, out := runCLIWithDeps(t, deps, args...)
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+		}
+		return out
 	}
-	plan := asMapPayload(t, seenPayload["purchase_plan"])
-	menuItems := asSlicePayload(t, plan["menu_items"])
-	if len(menuItems) != 1 {
-		t.Fatalf("expected one menu item, got %d", len(menuItems))
+
+	if got := sources(run()); len(got) != 1 || got[0] != "live" {
+		t.Fatalf("expected live resolution on first run, got %v", got)
 	}
-	firstItem := asMapPayload(t, menuItems[0])
-	if firstItem["category_id"] != "cat-fallback" {
-		t.Fatalf("expected fallback category_id cat-fallback, got %v", firstItem["category_id"])
+	if got := sources(run()); len(got) != 1 || got[0] != "cache" {
+		t.Fatalf("expected cached resolution on second run, got %v", got)
+	}
+	if itemPageCalls != 1 {
+		t.Fatalf("expected one item page request before --refresh, got %d", itemPageCalls)
+	}
+	if got := sources(run("--refresh")); len(got) != 1 || got[0] != "live" {
+		t.Fatalf("expected live resolution with --refresh, got %v", got)
+	}
+	if itemPageCalls != 2 {
+		t.Fatalf("expected --refresh to request the item page again, got %d calls", itemPageCalls)
 	}
 }
 
-func TestCheckoutPreviewFallsBackCategoryToItemID(t *testing.T) {
-	seenPayload := map[string]any{}
+func TestCheckoutPreviewValidatesSelectedOptionValues(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	checkoutCalls := 0
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
 			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
@@ -1013,14 +1372,20 @@ func TestCheckoutPreviewFallsBackCategoryToItemID(t *testing.T) {
 					"baskets": []any{
 						map[string]any{
 							"id":    "basket-1",
-							"total": "€17.00",
+							"total": "€20.00",
 							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
 							"items": []any{
 								map[string]any{
-									"id":      "693f837c465e0fe77eef4630",
-									"count":   1,
-									"price":   1700,
-									"options": []any{},
+									"id":    "item-1",
+									"name":  "Burger",
+									"count": 1,
+									"price": 1700,
+									"options": []any{
+										map[string]any{"id": "group-1", "values": []any{
+											map[string]any{"id": "value-1", "name": "Cheese", "count": 1, "price": 100},
+											map[string]any{"id": "value-2", "name": "Bacon", "count": 1, "price": 200},
+										}},
+									},
 								},
 							},
 						},
@@ -1029,15 +1394,19 @@ func TestCheckoutPreviewFallsBackCategoryToItemID(t *testing.T) {
 			},
 			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
 				return map[string]any{
-					"id": "693f837c465e0fe77eef4630",
+					"sections": []any{
+						map[string]any{
+							"categories": []any{map[string]any{"id": "cat-1", "item_ids": []any{"item-1"}}},
+							"options": []any{
+								map[string]any{"id": "group-1", "values": []any{map[string]any{"id": "value-1", "price": 150}}},
+							},
+						},
+					},
 				}, nil
 			},
-			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
-				seenPayload = payload
-				return map[string]any{
-					"payable_amount": 1700,
-					"checkout_rows":  []any{},
-				}, nil
+			checkoutPreviewFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				checkoutCalls++
+				return map[string]any{"payable_amount": 1850}, nil
 			},
 		},
 		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
@@ -1046,80 +1415,106 @@ func TestCheckoutPreviewFallsBackCategoryToItemID(t *testing.T) {
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json", "--machine")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-
-	plan := asMapPayload(t, seenPayload["purchase_plan"])
-	menuItems := asSlicePayload(t, plan["menu_items"])
-	if len(menuItems) != 1 {
-		t.Fatalf("expected one menu item, got %d", len(menuItems))
+	payload := mustJSON(t, out)
+	issues := asSlicePayload(t, asMapPayload(t, payload["data"])["option_issues"])
+	if len(issues) != 2 {
+		t.Fatalf("expected two option issues, got %v", issues)
 	}
-	firstItem := asMapPayload(t, menuItems[0])
-	if firstItem["category_id"] != "693f837c465e0fe77eef4630" {
-		t.Fatalf("expected category fallback to item id, got %v", firstItem["category_id"])
+	changed, gone := asMapPayload(t, issues[0]), asMapPayload(t, issues[1])
+	if changed["issue"] != "price_changed" || asIntPayload(changed["basket_price"]) != 100 || asIntPayload(changed["current_price"]) != 150 {
+		t.Fatalf("expected value-1 price change from 100 to 150, got %v", changed)
+	}
+	if gone["issue"] != "unavailable" || gone["value_id"] != "value-2" || gone["spec_source"] != "live" {
+		t.Fatalf("expected value-2 unavailable per the live spec, got %v", gone)
+	}
+	warnings := ""
+	for _, warning := range asSlicePayload(t, payload["warnings"]) {
+		warnings += asStringPayload(warning) + "\n"
+	}
+	if !strings.Contains(warnings, "option_price_changed: Burger option Cheese was") ||
+		!strings.Contains(warnings, "option_unavailable: Burger option Bacon is no longer offered\n") {
+		t.Fatalf("expected option warnings, got %s", warnings)
 	}
 
-	payload := mustJSON(t, out)
-	warnings := asSlicePayload(t, payload["warnings"])
-	found := false
-	for _, warning := range warnings {
-		if strings.Contains(asStringPayload(warning), "falling back to item id") {
-			found = true
-			break
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json", "--machine")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload = mustJSON(t, out)
+	for _, issue := range asSlicePayload(t, asMapPayload(t, payload["data"])["option_issues"]) {
+		if source := asMapPayload(t, issue)["spec_source"]; source != "cache" {
+			t.Fatalf("expected issues from the cached spec to say so, got spec_source=%v", source)
 		}
 	}
-	if !found {
-		t.Fatalf("expected fallback warning, got %v", warnings)
+	warnings = ""
+	for _, warning := range asSlicePayload(t, payload["warnings"]) {
+		warnings += asStringPayload(warning) + "\n"
+	}
+	if !strings.Contains(warnings, "option_unavailable: Burger option Bacon is no longer offered (per the cached item spec; --refresh checks the live one)") {
+		t.Fatalf("expected the warning to name the cached spec, got %s", warnings)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--strict", "--wtoken", "token", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_OPTION_UNAVAILABLE") {
+		t.Fatalf("expected WOLT_OPTION_UNAVAILABLE under --strict, got %d\noutput:\n%s", exitCode, out)
+	}
+	if checkoutCalls != 2 {
+		t.Fatalf("expected --strict to fail before the checkout request, got %d calls", checkoutCalls)
 	}
 }
 
-func TestCheckoutPreviewMultipleBasketsSelectionWarning(t *testing.T) {
+func TestCheckoutPreviewReconcilesBasketWithAddedPrices(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	basketPrice := 0
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{
+					"name":  "Classics set",
+					"price": map[string]any{"amount": 1700, "currency": "EUR"},
+					"sections": []any{
+						map[string]any{"categories": []any{map[string]any{"id": "cat-1", "item_ids": []any{"item-1"}}}},
+					},
+				}, nil
+			},
+			addToBasketFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				basketPrice = 1700
+				return map[string]any{"id": "basket-1", "venue_id": "venue-1"}, nil
+			},
+			basketCountFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"count": 1}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				if basketPrice == 0 {
+					return map[string]any{"baskets": []any{}}, nil
+				}
 				return map[string]any{
 					"baskets": []any{
 						map[string]any{
 							"id":    "basket-1",
-							"total": "€17.00",
+							"total": "€19.00",
 							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
 							"items": []any{
-								map[string]any{"id": "item-1", "count": 1, "price": 1700, "options": []any{}},
-							},
-						},
-						map[string]any{
-							"id":    "basket-2",
-							"total": "€12.00",
-							"venue": map[string]any{"id": "venue-2", "country": "FIN"},
-							"items": []any{
-								map[string]any{"id": "item-2", "count": 1, "price": 1200, "options": []any{}},
+								map[string]any{"id": "item-1", "name": "Classics set", "count": 1, "price": basketPrice, "options": []any{}},
 							},
 						},
 					},
 				}, nil
 			},
-			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+			checkoutPreviewFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
 				return map[string]any{
-					"sections": []any{
-						map[string]any{
-							"categories": []any{
-								map[string]any{
-									"id":       "cat-1",
-									"item_ids": []any{"item-1", "item-2"},
-								},
-							},
-						},
+					"payable_amount": 2041,
+					"checkout_rows": []any{
+						map[string]any{"template": "amount_row", "label": "Item subtotal", "amount": map[string]any{"amount": 1900}},
+						map[string]any{"template": "amount_row", "label": "Delivery fee", "amount": map[string]any{"formatted_amount": "€1.90"}},
+						map[string]any{"template": "amount_row", "label": "Discount", "amount": map[string]any{"formatted_amount": "-€0.50"}},
 					},
 				}, nil
 			},
-			checkoutPreviewFunc: func(_ context.Context, _ map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
-				return map[string]any{
-					"payable_amount": 1700,
-					"checkout_rows":  []any{},
-				}, nil
-			},
 		},
 		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
 		Location: &mockLocation{},
@@ -1127,244 +1522,1606 @@ func TestCheckoutPreviewMultipleBasketsSelectionWarning(t *testing.T) {
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "cart", "add", "venue-1", "item-1", "--wtoken", "token", "--format", "json")
 	if exitCode != 0 {
-		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+		t.Fatalf("expected cart add exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	basketPrice = 1900
+
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json", "--machine")
+	if exitCode != 0 {
+		t.Fatalf("expected checkout preview exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
 	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["basket_id"] != "basket-1" {
-		t.Fatalf("expected first basket to be selected, got %v", data["basket_id"])
+	reconciliation := asMapPayload(t, asMapPayload(t, payload["data"])["reconciliation"])
+	line := asMapPayload(t, asSlicePayload(t, reconciliation["lines"])[0])
+	if line["price_changed"] != true ||
+		asIntPayload(asMapPayload(t, line["added_unit_price"])["amount"]) != 1700 ||
+		asIntPayload(asMapPayload(t, line["unit_price"])["amount"]) != 1900 {
+		t.Fatalf("expected item-1 flagged as changed from 1700 to 1900, got %v", line)
 	}
-	selection := asMapPayload(t, data["selection"])
-	if asIntPayload(selection["basket_count"]) != 2 {
-		t.Fatalf("expected basket_count 2, got %v", selection["basket_count"])
+	if asIntPayload(reconciliation["changed_lines"]) != 1 {
+		t.Fatalf("expected one changed line, got %v", reconciliation["changed_lines"])
 	}
-	if selection["selection_mode"] != "first-available" {
-		t.Fatalf("expected selection_mode first-available, got %v", selection["selection_mode"])
+	adjustments := asSlicePayload(t, reconciliation["adjustments"])
+	if len(adjustments) != 2 {
+		t.Fatalf("expected delivery fee and discount adjustments, got %v", adjustments)
+	}
+	fee, discount := asMapPayload(t, adjustments[0]), asMapPayload(t, adjustments[1])
+	if fee["kind"] != "fee" || asIntPayload(asMapPayload(t, fee["amount"])["amount"]) != 190 {
+		t.Fatalf("expected delivery fee adjustment of 190, got %v", fee)
+	}
+	if discount["kind"] != "discount" || asIntPayload(asMapPayload(t, discount["amount"])["amount"]) != -50 {
+		t.Fatalf("expected discount adjustment of -50, got %v", discount)
+	}
+	if asIntPayload(asMapPayload(t, reconciliation["expected_total"])["amount"]) != 2040 ||
+		asIntPayload(asMapPayload(t, reconciliation["rounding"])["amount"]) != 1 {
+		t.Fatalf("expected total 2040 with rounding 1, got %v", reconciliation)
 	}
-	warnings := asSlicePayload(t, payload["warnings"])
 	found := false
-	for _, warning := range warnings {
-		if strings.Contains(asStringPayload(warning), "multiple baskets found") {
-			found = true
-			break
-		}
+	for _, warning := range asSlicePayload(t, payload["warnings"]) {
+		found = found || strings.HasPrefix(asStringPayload(warning), "price_changed: Classics set was")
 	}
 	if !found {
-		t.Fatalf("expected warning for multiple baskets selection, got %v", warnings)
+		t.Fatalf("expected price_changed warning, got %v", payload["warnings"])
 	}
 }
 
-func TestProfileAddressesJSON(t *testing.T) {
-	cfg := &recordingConfig{
-		loadCfg: domain.Config{
-			Profiles: []domain.Profile{
-				{
-					Name:      "default",
-					IsDefault: true,
-					Location:  domain.Location{Lat: 60.1484, Lon: 24.6913},
-					WToken:    "token",
-				},
-			},
-		},
-	}
+func TestCheckoutPreviewMergesPlanJSON(t *testing.T) {
+	var seenPlan map[string]any
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			deliveryInfoListFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
 				return map[string]any{
-					"results": []any{
+					"baskets": []any{
 						map[string]any{
-							"id":         "addr-1",
-							"label_type": "other",
-							"location": map[string]any{
-								"address": "Iivisniemenkatu 2",
+							"id":    "basket-1",
+							"total": "€17.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
+							"items": []any{
+								map[string]any{"id": "item-1", "count": 1, "price": 1700, "category_id": "cat-1"},
 							},
 						},
 					},
 				}, nil
 			},
+			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenPlan = asMapPayload(t, payload["purchase_plan"])
+				return map[string]any{"payable_amount": 1700}, nil
+			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1484, Lon: 24.6913}, WToken: "token"}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
 		Location: &mockLocation{},
-		Config:   cfg,
+		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "profile", "addresses", "--format", "json", "--wtoken", "token")
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	writePlan := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(planPath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePlan(`{"delivery_method": "takeaway", "use_credits_and_tokens": true, "venue": {"currency": "SEK"}}`)
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--plan-json", planPath, "--wtoken", "token", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	addresses := asSlicePayload(t, data["addresses"])
-	if len(addresses) != 1 {
-		t.Fatalf("expected 1 address, got %d", len(addresses))
+	venue := asMapPayload(t, seenPlan["venue"])
+	if seenPlan["delivery_method"] != "takeaway" || seenPlan["use_credits_and_tokens"] != true || venue["currency"] != "SEK" || venue["id"] != "venue-1" {
+		t.Fatalf("expected plan fields merged over the resolved plan, got %v", seenPlan)
 	}
-	if !strings.Contains(out, "profile_default_address_id") {
-		t.Fatalf("expected profile_default_address_id in payload")
+	if len(asSlicePayload(t, seenPlan["menu_items"])) != 1 {
+		t.Fatalf("expected resolved menu items to be kept, got %v", seenPlan["menu_items"])
 	}
-}
-
-func TestProfilePaymentsRequiresAuth(t *testing.T) {
-	deps := cli.Dependencies{
-		Wolt:     &mockWolt{},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60, Lon: 24}}},
-		Location: &mockLocation{},
-		Config:   &mockConfig{},
-		Version:  "1.1.1",
+	input := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["json_input"])
+	if fields := asSlicePayload(t, input["fields"]); len(fields) != 3 || fields[0] != "purchase_plan.delivery_method" {
+		t.Fatalf("expected merged fields to be reported, got %v", input)
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "profile", "payments", "--format", "json")
-	if exitCode != 1 {
-		t.Fatalf("expected exit 1, got %d\noutput:\n%s", exitCode, out)
-	}
-	payload := mustJSON(t, out)
-	errPayload := asMapPayload(t, payload["error"])
-	if errPayload["code"] != "WOLT_AUTH_REQUIRED" {
-		t.Fatalf("expected WOLT_AUTH_REQUIRED, got %v", errPayload["code"])
+	for _, plan := range []string{`{"venue": {"id": "other"}}`, `{"courier_tip": "lots"}`, `[1, 2]`} {
+		writePlan(plan)
+		exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--plan-json", planPath, "--wtoken", "token", "--format", "json")
+		if exitCode == 0 {
+			t.Fatalf("expected %s to be rejected, got:\n%s", plan, out)
+		}
+		if code := asMapPayload(t, mustJSON(t, out)["error"])["code"]; code != "WOLT_INVALID_ARGUMENT" {
+			t.Fatalf("expected WOLT_INVALID_ARGUMENT for %s, got %v", plan, code)
+		}
 	}
 }
 
-func TestProfileFavoritesListJSON(t *testing.T) {
-	seenLocation := domain.Location{}
+func TestCheckoutPreviewFormatsAmountsForLocale(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			favoriteVenuesFunc: func(_ context.Context, location domain.Location, _ woltgateway.AuthContext) (map[string]any, error) {
-				seenLocation = location
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
 				return map[string]any{
-					"sections": []any{
+					"baskets": []any{
 						map[string]any{
-							"items": []any{
-								map[string]any{
-									"title": "Rioni Espoo",
-									"link":  map[string]any{"target": "https://wolt.com/en/fin/espoo/restaurant/rioni-espoo"},
-									"venue": map[string]any{
-										"id":        "5a8426f188b5de000b8857bb",
-										"slug":      "rioni-espoo",
-										"name":      "Rioni Espoo",
-										"address":   "Espoonlahdenkatu 8",
-										"favourite": true,
-										"rating":    map[string]any{"score": 9.0},
-									},
-								},
-							},
+							"id":    "basket-1",
+							"total": "€1234.56",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
+							"items": []any{map[string]any{"id": "item-1", "count": 1, "price": 123456, "category_id": "cat-1"}},
 						},
 					},
 				}, nil
 			},
+			checkoutPreviewFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"payable_amount": 123456}, nil
+			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Profiles: &mockProfiles{profile: domain.Profile{
+			Name:      "default",
+			IsDefault: true,
+			Location:  domain.Location{Lat: 60.1, Lon: 24.9},
+			Locale:    "fi-FI",
+		}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(t, deps, "profile", "favorites", "--wtoken", "token", "--format", "json")
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--tip", "150", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if seenLocation.Lat != 60.14889 || seenLocation.Lon != 24.6911577 {
-		t.Fatalf("expected profile location to be forwarded, got %+v", seenLocation)
-	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if asIntPayload(data["count"]) != 1 {
-		t.Fatalf("expected favorites count 1, got %v", data["count"])
+	tip := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["applied_tip"])
+	if asIntPayload(tip["amount"]) != 150 || tip["formatted_amount"] != "1,50 €" {
+		t.Fatalf("expected profile locale formatting with the raw amount kept, got %v", tip)
 	}
-	rows := asSlicePayload(t, data["favorites"])
-	if len(rows) != 1 {
-		t.Fatalf("expected one favorite row, got %d", len(rows))
+
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--tip", "150", "--locale", "en-US", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	first := asMapPayload(t, rows[0])
-	if first["venue_id"] != "5a8426f188b5de000b8857bb" {
-		t.Fatalf("unexpected venue id: %v", first["venue_id"])
+	if tip := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["applied_tip"]); tip["formatted_amount"] != "€1.50" {
+		t.Fatalf("expected --locale to override the profile locale, got %v", tip)
 	}
 }
 
-func TestProfileFavoritesAddBySlugJSON(t *testing.T) {
-	seenVenueID := ""
+func TestLocaleDetectedFromProfileTokenCountry(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":4102444800,"user":{"country":"FIN"}}`))
+	profile := domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}, WToken: "eyJhbGciOiJIUzI1NiJ9." + claims + ".sig"}
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			venuePageStaticFunc: func(_ context.Context, slug string) (map[string]any, error) {
-				if slug != "rioni-espoo" {
-					t.Fatalf("expected slug rioni-espoo, got %q", slug)
-				}
-				return map[string]any{
-					"venue": map[string]any{
-						"id":   "5a8426f188b5de000b8857bb",
-						"slug": "rioni-espoo",
-						"name": "Rioni Espoo",
-					},
-				}, nil
-			},
-			favoriteVenueAddFn: func(_ context.Context, venueID string, auth woltgateway.AuthContext) (map[string]any, error) {
-				seenVenueID = venueID
-				if auth.WToken != "token" {
-					t.Fatalf("expected token to be forwarded")
-				}
-				return map[string]any{}, nil
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"user": map[string]any{"_id": map[string]any{"$oid": "user-1"}}}, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Profiles: &mockProfiles{profile: profile},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(
-		t,
-		deps,
-		"profile",
-		"favorites",
-		"add",
-		"https://wolt.com/en/fin/espoo/restaurant/rioni-espoo",
-		"--wtoken",
-		"token",
-		"--format",
-		"json",
-	)
+	cases := []struct {
+		name   string
+		args   []string
+		locale string
+		source string
+	}{
+		{name: "token", locale: "fi-FI", source: "token"},
+		{name: "flag", args: []string{"--locale", "sv-SE"}, locale: "sv-SE", source: "flag"},
+	}
+	for _, tc := range cases {
+		exitCode, out := runCLIWithDeps(t, deps, append([]string{"auth", "status", "--format", "json"}, tc.args...)...)
+		if exitCode != 0 {
+			t.Fatalf("%s: expected exit 0, got %d\noutput:\n%s", tc.name, exitCode, out)
+		}
+		meta := asMapPayload(t, mustJSON(t, out)["meta"])
+		if meta["locale"] != tc.locale || meta["locale_source"] != tc.source {
+			t.Fatalf("%s: expected locale %s from %s, got %v from %v", tc.name, tc.locale, tc.source, meta["locale"], meta["locale_source"])
+		}
+	}
+
+	profile.Locale = "en-GB"
+	deps.Profiles = &mockProfiles{profile: profile}
+	exitCode, out := runCLIWithDeps(t, deps, "auth", "status", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if seenVenueID != "5a8426f188b5de000b8857bb" {
-		t.Fatalf("expected venue id 5a8426f188b5de000b8857bb, got %q", seenVenueID)
+	if meta := asMapPayload(t, mustJSON(t, out)["meta"]); meta["locale"] != "en-GB" || meta["locale_source"] != "profile" {
+		t.Fatalf("expected the profile locale to win over the token, got %v", meta)
 	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["action"] != "add" {
-		t.Fatalf("expected action add, got %v", data["action"])
+
+	profile.Locale, profile.WToken = "", ""
+	deps.Profiles = &mockProfiles{profile: profile}
+	exitCode, out = runCLIWithDeps(t, deps, "auth", "status", "--wtoken", "abc.def.ghi", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if !asBoolPayload(data["is_favorite"]) {
-		t.Fatalf("expected is_favorite=true, got %v", data["is_favorite"])
+	if meta := asMapPayload(t, mustJSON(t, out)["meta"]); meta["locale"] != "en-FI" || meta["locale_source"] != "default" {
+		t.Fatalf("expected the en-FI default without a country, got %v", meta)
 	}
 }
 
-func TestProfileFavoritesRemoveByIDJSON(t *testing.T) {
-	seenVenueID := ""
+func TestCheckoutPreviewAppliesProfileTipAndBestPromo(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	previews := []map[string]any{}
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			favoriteVenueRemFn: func(_ context.Context, venueID string, auth woltgateway.AuthContext) (map[string]any, error) {
-				seenVenueID = venueID
-				if auth.WToken != "token" {
-					t.Fatalf("expected token to be forwarded")
-				}
-				return map[string]any{}, nil
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€34.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
+							"items": []any{
+								map[string]any{"id": "item-1", "count": 2, "price": 1700, "category_id": "cat-1"},
+							},
+						},
+					},
+				}, nil
+			},
+			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				plan := asMapPayload(t, payload["purchase_plan"])
+				previews = append(previews, map[string]any{
+					"tip":   plan["courier_tip"],
+					"promo": append([]any{}, asSlicePayload(t, plan["use_promo_discount_ids"])...),
+				})
+				return map[string]any{
+					"payable_amount": 3400,
+					"offers": map[string]any{
+						"applied": []any{},
+						"selectable": []any{
+							map[string]any{"id": "promo-small", "title": "1 EUR off", "discount_amount": map[string]any{"amount": 100}},
+							map[string]any{"id": "wolt-plus", "title": "Wolt+ free delivery", "discount_amount": map[string]any{"amount": 290}},
+						},
+					},
+				}, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Profiles: &mockProfiles{profile: domain.Profile{
+			Name:               "default",
+			IsDefault:          true,
+			Location:           domain.Location{Lat: 60.1, Lon: 24.9},
+			DefaultTipPercent:  10,
+			AutoApplyBestPromo: true,
+		}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(
-		t,
-		deps,
-		"profile",
-		"favorites",
-		"remove",
-		"5a8426f188b5de000b8857bb",
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	tip := asMapPayload(t, data["applied_tip"])
+	if asIntPayload(tip["amount"]) != 340 || tip["source"] != "profile" || tip["formatted_amount"] != "€3.40" {
+		t.Fatalf("unexpected applied_tip %v", tip)
+	}
+	promo := asMapPayload(t, data["applied_promo"])
+	if promo["id"] != "wolt-plus" || promo["source"] != "profile" || asIntPayload(promo["savings"]) != 290 {
+		t.Fatalf("unexpected applied_promo %v", promo)
+	}
+	if len(previews) != 2 || asIntPayload(previews[0]["tip"]) != 340 {
+		t.Fatalf("expected a preview with the profile tip and one with the promo, got %v", previews)
+	}
+	if promos := asSlicePayload(t, previews[1]["promo"]); len(promos) != 1 || promos[0] != "wolt-plus" {
+		t.Fatalf("expected best offer in the second preview, got %v", promos)
+	}
+
+	previews = nil
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json", "--tip", "0", "--promo-code", "mine")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	if tip := asMapPayload(t, data["applied_tip"]); asIntPayload(tip["amount"]) != 0 || tip["source"] != "flag" {
+		t.Fatalf("expected --tip to override the profile default, got %v", tip)
+	}
+	if promo := asMapPayload(t, data["applied_promo"]); promo["id"] != "mine" || promo["source"] != "flag" {
+		t.Fatalf("expected --promo-code to win, got %v", promo)
+	}
+	if len(previews) != 1 {
+		t.Fatalf("expected a single preview with --promo-code, got %d", len(previews))
+	}
+}
+
+func TestCheckoutPreviewUsesVenuePayloadCategoryFallback(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	seenPayload := map[string]any{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€17.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN", "slug": "venue-1-slug"},
+							"items": []any{
+								map[string]any{
+									"id":      "item-1",
+									"count":   1,
+									"price":   1700,
+									"options": []any{},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{
+					"id":   "item-1",
+					"name": "Fallback Burger",
+				}, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{
+					"categories": []any{
+						map[string]any{
+							"id":       "cat-fallback",
+							"item_ids": []any{"item-1"},
+						},
+					},
+				}, nil
+			},
+			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenPayload = payload
+				return map[string]any{
+					"payable_amount": 1700,
+					"checkout_rows":  []any{},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	plan := asMapPayload(t, seenPayload["purchase_plan"])
+	menuItems := asSlicePayload(t, plan["menu_items"])
+	if len(menuItems) != 1 {
+		t.Fatalf("expected one menu item, got %d", len(menuItems))
+	}
+	firstItem := asMapPayload(t, menuItems[0])
+	if firstItem["category_id"] != "cat-fallback" {
+		t.Fatalf("expected fallback category_id cat-fallback, got %v", firstItem["category_id"])
+	}
+}
+
+func TestWoltPlusBenefitMissedWarnsWhenSubscriberPaysDelivery(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	subscriber := true
+	userMeCalls := 0
+	subtotal := 2400
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				userMeCalls++
+				return map[string]any{"user": map[string]any{"is_wolt_plus_subscriber": subscriber}}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€24.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN", "show_wolt_plus": true},
+							"items": []any{map[string]any{"id": "693f837c465e0fe77eef4630", "count": 1, "price": subtotal, "options": []any{}}},
+						},
+					},
+				}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{"id": "693f837c465e0fe77eef4630"}, nil
+			},
+			checkoutPreviewFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"payable_amount": subtotal + 290,
+					"checkout_rows": []any{
+						map[string]any{"template": "amount_row", "label": "Delivery", "amount": map[string]any{"formatted_amount": "€2.90"}},
+					},
+				}, nil
+			},
+			orderHistoryShowFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"order_id": "purchase-1", "currency": "EUR", "wolt_plus": true, "items_price": subtotal, "delivery_price": 290}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	for _, args := range [][]string{
+		{"checkout", "preview", "--wtoken", "token", "--format", "json"},
+		{"profile", "orders", "show", "purchase-1", "--wtoken", "token", "--format", "json"},
+	} {
+		exitCode, out := runCLIWithDeps(t, deps, args...)
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0 for %v, got %d\noutput:\n%s", args, exitCode, out)
+		}
+		warnings := fmt.Sprint(mustJSON(t, out)["warnings"])
+		if !strings.Contains(warnings, "wolt_plus_benefit_missed: a €2.90 delivery fee was charged on a €24.00 order") {
+			t.Fatalf("expected wolt_plus_benefit_missed for %v, got %s", args, warnings)
+		}
+	}
+
+	subscriber = false
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 || strings.Contains(out, "wolt_plus_benefit_missed") {
+		t.Fatalf("expected no warning without a subscription, got %d\n%s", exitCode, out)
+	}
+
+	subscriber, subtotal, userMeCalls = true, 1200, 0
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "orders", "show", "purchase-1", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 || strings.Contains(out, "wolt_plus_benefit_missed") || userMeCalls != 0 {
+		t.Fatalf("expected orders below the minimum to skip the check and the account lookup, got %d calls\n%s", userMeCalls, out)
+	}
+}
+
+func TestCheckoutPreviewFallsBackCategoryToItemID(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	seenPayload := map[string]any{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€17.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
+							"items": []any{
+								map[string]any{
+									"id":      "693f837c465e0fe77eef4630",
+									"count":   1,
+									"price":   1700,
+									"options": []any{},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{
+					"id": "693f837c465e0fe77eef4630",
+				}, nil
+			},
+			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenPayload = payload
+				return map[string]any{
+					"payable_amount": 1700,
+					"checkout_rows":  []any{},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+
+	plan := asMapPayload(t, seenPayload["purchase_plan"])
+	menuItems := asSlicePayload(t, plan["menu_items"])
+	if len(menuItems) != 1 {
+		t.Fatalf("expected one menu item, got %d", len(menuItems))
+	}
+	firstItem := asMapPayload(t, menuItems[0])
+	if firstItem["category_id"] != "693f837c465e0fe77eef4630" {
+		t.Fatalf("expected category fallback to item id, got %v", firstItem["category_id"])
+	}
+
+	payload := mustJSON(t, out)
+	warnings := asSlicePayload(t, payload["warnings"])
+	found := false
+	for _, warning := range warnings {
+		if strings.Contains(asStringPayload(warning), "falling back to item id") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("expected fallback warning, got %v", warnings)
+	}
+}
+
+func TestCheckoutPreviewPayWithSplit(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	newDeps := func(country string) cli.Dependencies {
+		return cli.Dependencies{
+			Wolt: &mockWolt{
+				basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+					return map[string]any{
+						"baskets": []any{
+							map[string]any{
+								"id":    "basket-1",
+								"total": "€17.00",
+								"venue": map[string]any{"id": "venue-1", "country": country},
+								"items": []any{
+									map[string]any{"id": "item-1", "count": 1, "price": 1700, "options": []any{}},
+								},
+							},
+						},
+					}, nil
+				},
+				venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+					return map[string]any{"id": "item-1", "category_id": "cat-1"}, nil
+				},
+				checkoutPreviewFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+					return map[string]any{"payable_amount": 1700, "checkout_rows": []any{}}, nil
+				},
+			},
+			Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+			Location: &mockLocation{},
+			Config:   &mockConfig{},
+			Version:  "1.1.1",
+		}
+	}
+
+	exitCode, out := runCLIWithDeps(t, newDeps("FIN"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--pay-with", "edenred:1300", "--pay-with", "card:rest")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	split := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["payment_split"])
+	methods := asSlicePayload(t, split["methods"])
+	if split["country"] != "FIN" || len(methods) != 2 {
+		t.Fatalf("unexpected payment split: %v", split)
+	}
+	want := map[string]float64{"edenred": 1300, "card": 400}
+	for _, value := range methods {
+		method := asMapPayload(t, value)
+		if got := asMapPayload(t, method["amount"])["amount"]; got != want[asStringPayload(method["method"])] {
+			t.Fatalf("unexpected amount for %v: %v", method["method"], got)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, newDeps("FIN"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--pay-with", "edenred:1300")
+	if exitCode != 1 || !strings.Contains(out, "unpaid") {
+		t.Fatalf("expected unpaid remainder error, got %d\noutput:\n%s", exitCode, out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, newDeps("SWE"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--pay-with", "edenred:1300", "--pay-with", "card:rest")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_SPLIT_NOT_ALLOWED") {
+		t.Fatalf("expected WOLT_SPLIT_NOT_ALLOWED, got %d\noutput:\n%s", exitCode, out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, newDeps("FIN"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--pay-with", "card:rest", "--pay-with", "edenred:rest")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected WOLT_INVALID_ARGUMENT for two rest entries, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestCheckoutPreviewSendsGiftRecipient(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	var seenPlan map[string]any
+	newDeps := func(country string) cli.Dependencies {
+		return cli.Dependencies{
+			Wolt: &mockWolt{
+				basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+					return map[string]any{
+						"baskets": []any{
+							map[string]any{
+								"id":    "basket-1",
+								"total": "€17.00",
+								"venue": map[string]any{"id": "venue-1", "country": country},
+								"items": []any{
+									map[string]any{"id": "item-1", "count": 1, "price": 1700, "options": []any{}},
+								},
+							},
+						},
+					}, nil
+				},
+				venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+					return map[string]any{"id": "item-1", "category_id": "cat-1"}, nil
+				},
+				checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+					seenPlan = asMapPayload(t, payload["purchase_plan"])
+					return map[string]any{"payable_amount": 1700, "checkout_rows": []any{}}, nil
+				},
+			},
+			Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+			Location: &mockLocation{},
+			Config:   &mockConfig{},
+			Version:  "1.1.1",
+		}
+	}
+
+	exitCode, out := runCLIWithDeps(t, newDeps("FIN"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--gift", "--recipient-name", "Aino Virtanen", "--recipient-phone", "+358 40 123 4567", "--gift-message", "Happy birthday!")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	gift := asMapPayload(t, seenPlan["gift"])
+	if gift["is_gift"] != true || gift["recipient_name"] != "Aino Virtanen" ||
+		gift["recipient_phone_number"] != "+358401234567" || gift["message"] != "Happy birthday!" {
+		t.Fatalf("unexpected gift request fields: %v", gift)
+	}
+	data := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["gift"])
+	if data["recipient_phone"] != "+358401234567" || data["country"] != "FIN" {
+		t.Fatalf("unexpected gift data: %v", data)
+	}
+
+	seenPlan = nil
+	exitCode, out = runCLIWithDeps(t, newDeps("FIN"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--gift", "--recipient-name", "Aino Virtanen")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_GIFT_NOT_ALLOWED") || !strings.Contains(out, "--recipient-phone") {
+		t.Fatalf("expected WOLT_GIFT_NOT_ALLOWED for a missing phone, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenPlan != nil {
+		t.Fatalf("expected the gift check to fail before the checkout request")
+	}
+
+	exitCode, out = runCLIWithDeps(t, newDeps("FIN"), "checkout", "preview", "--wtoken", "token", "--format", "json",
+		"--recipient-name", "Aino Virtanen")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected WOLT_INVALID_ARGUMENT without --gift, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestCheckoutPreviewWorkAccountChecksCompanyPolicy(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local)
+	var seenPlan map[string]any
+	workMethod := map[string]any{
+		"id":      "work-1",
+		"type":    "wolt_at_work",
+		"name":    "Acme lunch",
+		"company": map[string]any{"id": "co-1", "name": "Acme Oy"},
+		"policy": map[string]any{
+			"name":          "Lunch",
+			"budget":        map[string]any{"amount": 2000, "remaining": 1500, "currency": "EUR", "period": "day"},
+			"allowed_times": []any{map[string]any{"days": []any{"mon", "tue", "wed", "thu", "fri"}, "start": "10:30", "end": "14:00"}},
+		},
+	}
+	methods := []any{map[string]any{"id": "card-1", "type": "card", "name": "Visa **** 1111"}, workMethod}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€12.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
+							"items": []any{
+								map[string]any{"id": "item-1", "count": 1, "price": 1200, "options": []any{}},
+							},
+						},
+					},
+				}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{"id": "item-1", "category_id": "cat-1"}, nil
+			},
+			paymentMethodsFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"methods": methods}, nil
+			},
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"user": map[string]any{"country": "FIN"}}, nil
+			},
+			checkoutPreviewFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenPlan = asMapPayload(t, payload["purchase_plan"])
+				return map[string]any{"payable_amount": 1200, "checkout_rows": []any{}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Clock:    cli.ClockFunc(func() time.Time { return now }),
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "payments", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected profile payments exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	listed := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["methods"])
+	if asMapPayload(t, listed[0])["corporate"] != nil {
+		t.Fatalf("expected the card to be personal, got %v", listed[0])
+	}
+	corporate := asMapPayload(t, asMapPayload(t, listed[1])["corporate"])
+	if corporate["company_name"] != "Acme Oy" || corporate["policy_name"] != "Lunch" ||
+		asIntPayload(asMapPayload(t, corporate["budget"])["remaining"]) != 1500 {
+		t.Fatalf("unexpected corporate policy: %v", corporate)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--work", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payment := asMapPayload(t, asSlicePayload(t, seenPlan["payment_methods"])[0])
+	if payment["id"] != "work-1" || payment["type"] != "wolt_at_work" {
+		t.Fatalf("expected the work method in the request, got %v", payment)
+	}
+	work := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["work"])
+	if work["within_budget"] != true || work["within_allowed_times"] != true || work["company_name"] != "Acme Oy" {
+		t.Fatalf("unexpected work data: %v", work)
+	}
+
+	now = time.Date(2026, 10, 14, 19, 0, 0, 0, time.Local)
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--work", "--wtoken", "token", "--format", "json")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_WORK_POLICY_VIOLATION") {
+		t.Fatalf("expected WOLT_WORK_POLICY_VIOLATION after hours, got %d\noutput:\n%s", exitCode, out)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--work", "--force", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 || !strings.Contains(out, "work_policy: the company policy does not allow orders on Wednesday at 19:00") {
+		t.Fatalf("expected --force to preview with a work_policy warning, got %d\noutput:\n%s", exitCode, out)
+	}
+
+	methods = methods[:1]
+	exitCode, out = runCLIWithDeps(t, deps, "checkout", "preview", "--work", "--wtoken", "token", "--format", "json")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_NOT_FOUND") {
+		t.Fatalf("expected WOLT_NOT_FOUND without a work method, got %d\noutput:\n%s", exitCode, out)
+	}
+}
+
+func TestCheckoutPreviewMultipleBasketsSelectionWarning(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"total": "€17.00",
+							"venue": map[string]any{"id": "venue-1", "country": "FIN"},
+							"items": []any{
+								map[string]any{"id": "item-1", "count": 1, "price": 1700, "options": []any{}},
+							},
+						},
+						map[string]any{
+							"id":    "basket-2",
+							"total": "€12.00",
+							"venue": map[string]any{"id": "venue-2", "country": "FIN"},
+							"items": []any{
+								map[string]any{"id": "item-2", "count": 1, "price": 1200, "options": []any{}},
+							},
+						},
+					},
+				}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{
+					"sections": []any{
+						map[string]any{
+							"categories": []any{
+								map[string]any{
+									"id":       "cat-1",
+									"item_ids": []any{"item-1", "item-2"},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			checkoutPreviewFunc: func(_ context.Context, _ map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"payable_amount": 1700,
+					"checkout_rows":  []any{},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "checkout", "preview", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["basket_id"] != "basket-1" {
+		t.Fatalf("expected first basket to be selected, got %v", data["basket_id"])
+	}
+	selection := asMapPayload(t, data["selection"])
+	if asIntPayload(selection["basket_count"]) != 2 {
+		t.Fatalf("expected basket_count 2, got %v", selection["basket_count"])
+	}
+	if selection["selection_mode"] != "first-available" {
+		t.Fatalf("expected selection_mode first-available, got %v", selection["selection_mode"])
+	}
+	warnings := asSlicePayload(t, payload["warnings"])
+	found := false
+	for _, warning := range warnings {
+		if strings.Contains(asStringPayload(warning), "multiple baskets found") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("expected warning for multiple baskets selection, got %v", warnings)
+	}
+}
+
+func TestProfileAddressesJSON(t *testing.T) {
+	cfg := &recordingConfig{
+		loadCfg: domain.Config{
+			Profiles: []domain.Profile{
+				{
+					Name:      "default",
+					IsDefault: true,
+					Location:  domain.Location{Lat: 60.1484, Lon: 24.6913},
+					WToken:    "token",
+				},
+			},
+		},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			deliveryInfoListFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"results": []any{
+						map[string]any{
+							"id":         "addr-1",
+							"label_type": "other",
+							"location": map[string]any{
+								"address": "Iivisniemenkatu 2",
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1484, Lon: 24.6913}, WToken: "token"}},
+		Location: &mockLocation{},
+		Config:   cfg,
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "addresses", "--format", "json", "--wtoken", "token")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	addresses := asSlicePayload(t, data["addresses"])
+	if len(addresses) != 1 {
+		t.Fatalf("expected 1 address, got %d", len(addresses))
+	}
+	if !strings.Contains(out, "profile_default_address_id") {
+		t.Fatalf("expected profile_default_address_id in payload")
+	}
+}
+
+func TestProfilePaymentsRequiresAuth(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt:     &mockWolt{},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60, Lon: 24}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "payments", "--format", "json")
+	if exitCode != 1 {
+		t.Fatalf("expected exit 1, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	errPayload := asMapPayload(t, payload["error"])
+	if errPayload["code"] != "WOLT_AUTH_REQUIRED" {
+		t.Fatalf("expected WOLT_AUTH_REQUIRED, got %v", errPayload["code"])
+	}
+}
+
+func TestProfilePaymentsIncludeBalances(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			paymentMethodsFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"methods": []any{
+					map[string]any{"id": "card-1", "type": "card", "name": "Visa **** 1111"},
+					map[string]any{"id": "gift-1", "type": "gift_card", "name": "Wolt gift card", "balance": map[string]any{"amount": 2500, "currency": "EUR"}, "expires_at": "2026-12-31"},
+					map[string]any{"id": "edenred", "type": "edenred", "name": "Edenred"},
+				}}, nil
+			},
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"user": map[string]any{"country": "FIN"}}, nil
+			},
+			paymentBalancesFunc: func(_ context.Context, _ woltgateway.AuthContext, country string) (map[string]any, error) {
+				if country != "FIN" {
+					t.Errorf("expected country FIN, got %q", country)
+				}
+				return map[string]any{"balances": []any{
+					map[string]any{"provider": "edenred", "balance": 1240, "currency": "EUR", "valid_until": "2026-11-30"},
+				}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, WToken: "token"}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "payments", "--include-balances", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	methods := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["methods"])
+	if len(methods) != 3 {
+		t.Fatalf("expected three methods, got %v", methods)
+	}
+	if balance := asMapPayload(t, methods[0])["balance"]; balance != nil {
+		t.Fatalf("expected no balance for a card, got %v", balance)
+	}
+	gift := asMapPayload(t, asMapPayload(t, methods[1])["balance"])
+	if asIntPayload(gift["amount"]) != 2500 || gift["expires_at"] != "2026-12-31" {
+		t.Fatalf("expected the gift card balance from the method payload, got %v", gift)
+	}
+	benefit := asMapPayload(t, asMapPayload(t, methods[2])["balance"])
+	if asIntPayload(benefit["amount"]) != 1240 || benefit["currency"] != "EUR" || benefit["expires_at"] != "2026-11-30" {
+		t.Fatalf("expected the provider balance from the balances endpoint, got %v", benefit)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "payments", "--format", "json")
+	if exitCode != 0 || strings.Contains(out, `"balance"`) {
+		t.Fatalf("expected balances only with --include-balances, got:\n%s", out)
+	}
+}
+
+func TestProfilePaymentsAddCardWaitsForNewCard(t *testing.T) {
+	polls := 0
+	seenCountry := ""
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			paymentMethodsFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				polls++
+				methods := []any{map[string]any{"id": "card-1", "type": "card", "name": "Visa **** 1111"}}
+				if polls > 2 {
+					methods = append(methods, map[string]any{"id": "card-2", "type": "card", "name": "Mastercard **** 4444"})
+				}
+				return map[string]any{"methods": methods}, nil
+			},
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"user": map[string]any{"country": "FIN"}}, nil
+			},
+			cardSetupFunc: func(_ context.Context, _ woltgateway.AuthContext, options woltgateway.CardSetupOptions) (map[string]any, error) {
+				seenCountry = options.Country
+				return map[string]any{"session": map[string]any{"id": "setup-1", "url": "https://payments.example/setup/setup-1"}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, WToken: "token"}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := cli.Execute(context.Background(), []string{"profile", "payments", "add-card", "--interval", "1ms", "--format", "json"}, deps, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s%s", exitCode, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "https://payments.example/setup/setup-1") {
+		t.Fatalf("expected the setup URL on stderr, got %q", stderr.String())
+	}
+	if seenCountry != "FIN" {
+		t.Fatalf("expected the account country to be sent, got %q", seenCountry)
+	}
+	data := asMapPayload(t, mustJSON(t, stdout.String())["data"])
+	card := asMapPayload(t, data["card"])
+	if data["status"] != "added" || data["session_id"] != "setup-1" || card["method_id"] != "card-2" {
+		t.Fatalf("expected the new card to be reported, got %v", data)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	polls = 0
+	deps.Wolt.(*mockWolt).paymentMethodsFunc = func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+		return map[string]any{"methods": []any{}}, nil
+	}
+	exitCode = cli.Execute(context.Background(), []string{"profile", "payments", "add-card", "--interval", "1ms", "--timeout", "5ms", "--format", "json"}, deps, &stdout, &stderr)
+	if exitCode != 1 {
+		t.Fatalf("expected exit 1 on timeout, got %d", exitCode)
+	}
+	if code := asMapPayload(t, mustJSON(t, stdout.String())["error"])["code"]; code != "WOLT_CARD_SETUP_PENDING" {
+		t.Fatalf("expected WOLT_CARD_SETUP_PENDING, got %v", code)
+	}
+}
+
+func TestProfileFavoritesListJSON(t *testing.T) {
+	seenLocation := domain.Location{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			favoriteVenuesFunc: func(_ context.Context, location domain.Location, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenLocation = location
+				return map[string]any{
+					"sections": []any{
+						map[string]any{
+							"items": []any{
+								map[string]any{
+									"title": "Rioni Espoo",
+									"link":  map[string]any{"target": "https://wolt.com/en/fin/espoo/restaurant/rioni-espoo"},
+									"venue": map[string]any{
+										"id":        "5a8426f188b5de000b8857bb",
+										"slug":      "rioni-espoo",
+										"name":      "Rioni Espoo",
+										"address":   "Espoonlahdenkatu 8",
+										"favourite": true,
+										"rating":    map[string]any{"score": 9.0},
+									},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "favorites", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenLocation.Lat != 60.14889 || seenLocation.Lon != 24.6911577 {
+		t.Fatalf("expected profile location to be forwarded, got %+v", seenLocation)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if asIntPayload(data["count"]) != 1 {
+		t.Fatalf("expected favorites count 1, got %v", data["count"])
+	}
+	rows := asSlicePayload(t, data["favorites"])
+	if len(rows) != 1 {
+		t.Fatalf("expected one favorite row, got %d", len(rows))
+	}
+	first := asMapPayload(t, rows[0])
+	if first["venue_id"] != "5a8426f188b5de000b8857bb" {
+		t.Fatalf("unexpected venue id: %v", first["venue_id"])
+	}
+}
+
+func TestProfileFavoritesHoursJSONAndICS(t *testing.T) {
+	favorite := func(id string, slug string, name string) map[string]any {
+		return map[string]any{"title": name, "venue": map[string]any{"id": id, "slug": slug, "name": name, "favourite": true}}
+	}
+	clock := func(kind string, hour int) domain.Times {
+		return domain.Times{Type: kind, Value: map[string]int64{"$date": int64(hour * 3600 * 1000)}}
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			favoriteVenuesFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"sections": []any{map[string]any{"items": []any{
+					favorite("5a8426f188b5de000b8857bb", "rioni-espoo", "Rioni Espoo"),
+					favorite("5a8426f188b5de000b8857cc", "burger-place", "Burger Place"),
+				}}}}, nil
+			},
+			restaurantByIDFunc: func(_ context.Context, venueID string) (*domain.Restaurant, error) {
+				if venueID == "5a8426f188b5de000b8857cc" {
+					return nil, errors.New("upstream unavailable")
+				}
+				return &domain.Restaurant{
+					ID:           venueID,
+					TimezoneName: "Europe/Helsinki",
+					OpeningTimes: map[string][]domain.Times{"monday": {clock("open", 10), clock("close", 22)}},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "favorites", "hours", "--wtoken", "token", "--now", "2026-02-16T19:00", "--days", "1", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if asIntPayload(data["count"]) != 1 || asIntPayload(data["open_now_count"]) != 1 || asIntPayload(data["requested"]) != 2 || data["partial"] != true {
+		t.Fatalf("unexpected favorites hours summary: %v", data)
+	}
+	row := asMapPayload(t, asSlicePayload(t, data["favorites"])[0])
+	openings := asSlicePayload(t, row["openings"])
+	if row["slug"] != "rioni-espoo" || len(openings) != 1 || asMapPayload(t, openings[0])["start"] != "2026-02-16T10:00:00+02:00" {
+		t.Fatalf("unexpected favorite hours row: %v", row)
+	}
+	if failed := asSlicePayload(t, data["errors"]); len(failed) != 1 || asMapPayload(t, failed[0])["slug"] != "burger-place" {
+		t.Fatalf("expected burger-place under errors, got %v", data["errors"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "favorites", "hours", "--wtoken", "token", "--now", "2026-02-16T19:00", "--days", "1", "--format", "ics")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	for _, line := range []string{"BEGIN:VCALENDAR", "SUMMARY:Rioni Espoo open", "DTSTART:20260216T080000Z", "DTEND:20260216T200000Z", "X-WOLT-WARNING:"} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("expected %q in the calendar:\n%s", line, out)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "favorites", "list", "--wtoken", "token", "--format", "ics")
+	if exitCode == 0 || !strings.Contains(out, "--format ics is supported by profile favorites hours") {
+		t.Fatalf("expected --format ics to be refused outside favorites hours, got %d:\n%s", exitCode, out)
+	}
+}
+
+func TestProfileFavoritesHoursLoadsVenuesConcurrentlyAndCaches(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	items := []any{}
+	for idx := range 6 {
+		id := fmt.Sprintf("venue-%d", idx)
+		items = append(items, map[string]any{"title": id, "venue": map[string]any{"id": id, "slug": id, "name": id, "favourite": true}})
+	}
+	var mu sync.Mutex
+	calls := map[string]int{}
+	inFlight, maxInFlight := 0, 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			favoriteVenuesFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"sections": []any{map[string]any{"items": items}}}, nil
+			},
+			restaurantByIDFunc: func(_ context.Context, venueID string) (*domain.Restaurant, error) {
+				mu.Lock()
+				calls[venueID]++
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+				time.Sleep(20 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				if venueID == "venue-3" {
+					return nil, &woltgateway.UpstreamRequestError{StatusCode: 404}
+				}
+				return &domain.Restaurant{ID: venueID, TimezoneName: "Europe/Helsinki"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "favorites", "hours", "--wtoken", "token", "--strict", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected a venue that is gone not to fail --strict, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if asIntPayload(data["count"]) != 5 || data["partial"] != nil {
+		t.Fatalf("expected five venues and no partial marker, got %v", data)
+	}
+	if failed := asSlicePayload(t, data["errors"]); len(failed) != 1 || asMapPayload(t, failed[0])["slug"] != "venue-3" {
+		t.Fatalf("expected venue-3 under errors, got %v", data["errors"])
+	}
+	if maxInFlight < 2 {
+		t.Fatalf("expected venue details to load concurrently, got at most %d in flight", maxInFlight)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "favorites", "hours", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if calls["venue-0"] != 1 || calls["venue-3"] != 2 {
+		t.Fatalf("expected cached venues to be reused and failures retried, got %v", calls)
+	}
+}
+
+func TestProfileFavoritesAddBySlugJSON(t *testing.T) {
+	seenVenueID := ""
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(_ context.Context, slug string) (map[string]any, error) {
+				if slug != "rioni-espoo" {
+					t.Fatalf("expected slug rioni-espoo, got %q", slug)
+				}
+				return map[string]any{
+					"venue": map[string]any{
+						"id":   "5a8426f188b5de000b8857bb",
+						"slug": "rioni-espoo",
+						"name": "Rioni Espoo",
+					},
+				}, nil
+			},
+			favoriteVenueAddFn: func(_ context.Context, venueID string, auth woltgateway.AuthContext) (map[string]any, error) {
+				seenVenueID = venueID
+				if auth.WToken != "token" {
+					t.Fatalf("expected token to be forwarded")
+				}
+				return map[string]any{}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(
+		t,
+		deps,
+		"profile",
+		"favorites",
+		"add",
+		"https://wolt.com/en/fin/espoo/restaurant/rioni-espoo",
+		"--wtoken",
+		"token",
+		"--format",
+		"json",
+	)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenVenueID != "5a8426f188b5de000b8857bb" {
+		t.Fatalf("expected venue id 5a8426f188b5de000b8857bb, got %q", seenVenueID)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["action"] != "add" {
+		t.Fatalf("expected action add, got %v", data["action"])
+	}
+	if !asBoolPayload(data["is_favorite"]) {
+		t.Fatalf("expected is_favorite=true, got %v", data["is_favorite"])
+	}
+}
+
+func TestProfileFavoritesRemoveByIDJSON(t *testing.T) {
+	seenVenueID := ""
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			favoriteVenueRemFn: func(_ context.Context, venueID string, auth woltgateway.AuthContext) (map[string]any, error) {
+				seenVenueID = venueID
+				if auth.WToken != "token" {
+					t.Fatalf("expected token to be forwarded")
+				}
+				return map[string]any{}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(
+		t,
+		deps,
+		"profile",
+		"favorites",
+		"remove",
+		"5a8426f188b5de000b8857bb",
+		"--wtoken",
+		"token",
+		"--format",
+		"json",
+	)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenVenueID != "5a8426f188b5de000b8857bb" {
+		t.Fatalf("expected venue id 5a8426f188b5de000b8857bb, got %q", seenVenueID)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["action"] != "remove" {
+		t.Fatalf("expected action remove, got %v", data["action"])
+	}
+	if asBoolPayload(data["is_favorite"]) {
+		t.Fatalf("expected is_favorite=false, got %v", data["is_favorite"])
+	}
+}
+
+func TestProfileOrdersListJSON(t *testing.T) {
+	seenLimit := 0
+	seenPageToken := ""
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryFunc: func(_ context.Context, auth woltgateway.AuthContext, options woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				if auth.WToken != "token" {
+					t.Fatalf("expected token to be forwarded")
+				}
+				seenLimit = options.Limit
+				seenPageToken = options.PageToken
+				return map[string]any{
+					"orders": []any{
+						map[string]any{
+							"purchase_id":     "purchase-1",
+							"received_at":     "15/02/2026, 10:06",
+							"status":          "delivered",
+							"venue_name":      "Burger King Iso Omena",
+							"total_amount":    "€15.38",
+							"is_active":       false,
+							"payment_time_ts": 1771142803530,
+							"items": []any{
+								map[string]any{"name": "LONG CHICKEN®"},
+								map[string]any{"name": "WHOPPER® Jr."},
+							},
+						},
+						map[string]any{
+							"purchase_id":     "purchase-2",
+							"received_at":     "03/02/2026, 11:07",
+							"status":          "deferred_payment_failed",
+							"venue_name":      "KFC Iso Omena",
+							"total_amount":    "--",
+							"is_active":       false,
+							"payment_time_ts": 1770109674437,
+							"items":           "Twister Lunchbox",
+						},
+					},
+					"next_page_token": "2025-12-03T14:40:50.585Z",
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(
+		t,
+		deps,
+		"profile",
+		"orders",
+		"--wtoken",
+		"token",
+		"--limit",
+		"25",
+		"--status",
+		"delivered",
+		"--format",
+		"json",
+	)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenLimit != 25 {
+		t.Fatalf("expected limit 25, got %d", seenLimit)
+	}
+	if seenPageToken != "" {
+		t.Fatalf("expected empty page token on first request, got %q", seenPageToken)
+	}
+
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if asIntPayload(data["count"]) != 1 {
+		t.Fatalf("expected one filtered order, got %v", data["count"])
+	}
+	if asStringPayload(data["next_page_token"]) != "2025-12-03T14:40:50.585Z" {
+		t.Fatalf("expected next_page_token in payload, got %v", data["next_page_token"])
+	}
+	orders := asSlicePayload(t, data["orders"])
+	if len(orders) != 1 {
+		t.Fatalf("expected one order in payload, got %d", len(orders))
+	}
+	first := asMapPayload(t, orders[0])
+	if first["purchase_id"] != "purchase-1" {
+		t.Fatalf("unexpected purchase id: %v", first["purchase_id"])
+	}
+	if first["status"] != "delivered" {
+		t.Fatalf("unexpected order status: %v", first["status"])
+	}
+	if first["items_summary"] != "LONG CHICKEN®, WHOPPER® Jr." {
+		t.Fatalf("unexpected items summary: %v", first["items_summary"])
+	}
+}
+
+func TestProfileOrdersFiltersByVenueAndTotalAcrossPagesAndSorts(t *testing.T) {
+	seenTokens := []string{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryFunc: func(_ context.Context, _ woltgateway.AuthContext, options woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				seenTokens = append(seenTokens, options.PageToken)
+				if options.PageToken == "" {
+					return map[string]any{
+						"orders": []any{
+							map[string]any{"purchase_id": "p-1", "venue_name": "Sushi Bar Kamppi", "venue_slug": "sushi-bar-kamppi", "total_amount": "€24.90", "payment_time_ts": 1774000000000},
+							map[string]any{"purchase_id": "p-2", "venue_name": "Burger King", "venue_slug": "burger-king", "total_amount": "€61.00", "payment_time_ts": 1773000000000},
+						},
+						"next_page_token": "page-2",
+					}, nil
+				}
+				return map[string]any{
+					"orders": []any{
+						map[string]any{"purchase_id": "p-3", "venue_name": "Sushi Bar Kamppi", "venue_slug": "sushi-bar-kamppi", "total_amount": "€86.40", "payment_time_ts": 1772000000000},
+						map[string]any{"purchase_id": "p-4", "venue_name": "Sushi Bar Kamppi", "venue_slug": "sushi-bar-kamppi", "total_amount": "--", "payment_time_ts": 1771000000000},
+					},
+					"next_page_token": "page-3",
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "orders", "--wtoken", "token", "--max-pages", "2", "--venue", "sushi", "--min-total", "20", "--sort", "total", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if len(seenTokens) != 2 || seenTokens[1] != "page-2" {
+		t.Fatalf("expected two pages following next_page_token, got %v", seenTokens)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	orders := asSlicePayload(t, data["orders"])
+	if len(orders) != 2 || asMapPayload(t, orders[0])["purchase_id"] != "p-3" || asMapPayload(t, orders[1])["purchase_id"] != "p-1" {
+		t.Fatalf("expected sushi orders over 20 sorted by total, got %v", orders)
+	}
+	if asIntPayload(data["pages"]) != 2 || asStringPayload(data["next_page_token"]) != "page-3" || data["sort"] != "total" || data["venue_filter"] != "sushi" {
+		t.Fatalf("unexpected paging or filter echo: %v", data)
+	}
+	if !strings.Contains(fmt.Sprint(payload["warnings"]), "1 order(s) without a readable total") {
+		t.Fatalf("expected a warning for the unreadable total, got %v", payload["warnings"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "orders", "list", "--wtoken", "token", "--venue", "burger-king", "--max-total", "50", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if count := asIntPayload(asMapPayload(t, mustJSON(t, out)["data"])["count"]); count != 0 {
+		t.Fatalf("expected the 61.00 burger order to exceed --max-total, got %d orders", count)
+	}
+
+	for _, args := range [][]string{
+		{"--sort", "venue"},
+		{"--min-total", "30", "--max-total", "10"},
+		{"--min-total", "abc"},
+	} {
+		exitCode, out = runCLIWithDeps(t, deps, append([]string{"profile", "orders", "--wtoken", "token", "--format", "json"}, args...)...)
+		if exitCode == 0 || !strings.Contains(out, "WOLT_INVALID_ARGUMENT") {
+			t.Fatalf("expected WOLT_INVALID_ARGUMENT for %v, got %d\n%s", args, exitCode, out)
+		}
+	}
+}
+
+func TestProfileOrdersLowBandwidthDropsImagesAndShrinksPages(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	seenLimits := []int{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryFunc: func(_ context.Context, _ woltgateway.AuthContext, options woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				seenLimits = append(seenLimits, options.Limit)
+				return map[string]any{
+					"orders": []any{
+						map[string]any{
+							"purchase_id":         "purchase-1",
+							"status":              "delivered",
+							"venue_name":          "Burger King Iso Omena",
+							"main_image":          "https://imageproxy.wolt.com/order.jpg",
+							"main_image_blurhash": "LEHV6nWB2yk8",
+						},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	var stdout, stderr bytes.Buffer
+	exitCode := cli.Execute(context.Background(), []string{"profile", "orders", "--wtoken", "token", "--low-bandwidth", "--format", "json"}, deps, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s%s", exitCode, stdout.String(), stderr.String())
+	}
+	payload := mustJSON(t, stdout.String())
+	order := asMapPayload(t, asSlicePayload(t, asMapPayload(t, payload["data"])["orders"])[0])
+	if _, ok := order["main_image"]; ok {
+		t.Fatalf("expected image fields to be dropped, got %v", order)
+	}
+	if _, ok := order["main_image_blurhash"]; ok {
+		t.Fatalf("expected blurhash to be dropped, got %v", order)
+	}
+	transfer := asMapPayload(t, asMapPayload(t, payload["meta"])["transfer"])
+	if _, ok := transfer["bytes_received"]; !ok {
+		t.Fatalf("expected meta.transfer.bytes_received, got %v", transfer)
+	}
+	if digest := asMapPayload(t, payload["meta"])["data_digest"]; digest != output.DataDigest(payload["data"]) {
+		t.Fatalf("expected data_digest to hash the stripped data, got %v", digest)
+	}
+	if !strings.Contains(stderr.String(), "[low-bandwidth] requests=") {
+		t.Fatalf("expected a transfer summary on stderr, got %q", stderr.String())
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "orders", "--wtoken", "token", "--low-bandwidth", "--limit", "30", "--format", "yaml")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if len(seenLimits) != 2 || seenLimits[0] != 10 || seenLimits[1] != 30 {
+		t.Fatalf("expected a page size of 10 by default and an explicit --limit kept, got %v", seenLimits)
+	}
+}
+
+func TestProfileOrdersShowJSON(t *testing.T) {
+	seenPurchaseID := ""
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryShowFn: func(_ context.Context, purchaseID string, auth woltgateway.AuthContext) (map[string]any, error) {
+				seenPurchaseID = purchaseID
+				if auth.WToken != "token" {
+					t.Fatalf("expected token to be forwarded")
+				}
+				return map[string]any{
+					"order_id":           "purchase-1",
+					"order_number":       "413",
+					"status":             "delivered",
+					"creation_time":      "15/02/2026, 10:06",
+					"delivery_time":      "15/02/2026, 10:37",
+					"delivery_method":    "homedelivery",
+					"currency":           "EUR",
+					"venue_id":           "5bbdcb7d556891000bf66ed8",
+					"venue_name":         "Burger King Iso Omena",
+					"venue_full_address": "Piispansilta 11, 02230, Espoo",
+					"venue_phone":        "+358403546482",
+					"venue_country":      "FIN",
+					"venue_product_line": "restaurant",
+					"total_price":        1538,
+					"items_price":        2395,
+					"delivery_price":     101,
+					"service_fee":        101,
+					"subtotal":           1538,
+					"credits":            0,
+					"tokens":             0,
+					"items": []any{
+						map[string]any{
+							"id":         "item-1",
+							"name":       "LONG CHICKEN®",
+							"count":      1,
+							"price":      825,
+							"end_amount": 825,
+							"options":    []any{},
+						},
+					},
+					"payments": []any{
+						map[string]any{
+							"name":         "Edenred",
+							"amount":       1538,
+							"payment_time": "15/02/2026, 10:06",
+							"method": map[string]any{
+								"type":     "edenred",
+								"id":       "payment-1",
+								"provider": "edenred",
+							},
+						},
+					},
+					"delivery_location": map[string]any{
+						"alias":  "Home",
+						"street": "Iivisniemenkatu 2, 36",
+						"city":   "Espoo",
+					},
+					"delivery_comment": "Leave order at the door",
+					"discounts": []any{
+						map[string]any{"title": "Discounts", "amount": 958},
+					},
+					"surcharges": []any{},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(
+		t,
+		deps,
+		"profile",
+		"orders",
+		"show",
+		"purchase-1",
 		"--wtoken",
 		"token",
 		"--format",
@@ -1373,224 +3130,930 @@ func TestProfileFavoritesRemoveByIDJSON(t *testing.T) {
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if seenVenueID != "5a8426f188b5de000b8857bb" {
-		t.Fatalf("expected venue id 5a8426f188b5de000b8857bb, got %q", seenVenueID)
+	if seenPurchaseID != "purchase-1" {
+		t.Fatalf("expected purchase id purchase-1, got %q", seenPurchaseID)
+	}
+
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["order_id"] != "purchase-1" {
+		t.Fatalf("expected order_id purchase-1, got %v", data["order_id"])
+	}
+	totals := asMapPayload(t, data["totals"])
+	total := asMapPayload(t, totals["total"])
+	if asIntPayload(total["amount"]) != 1538 {
+		t.Fatalf("expected total amount 1538, got %v", total["amount"])
+	}
+	if asStringPayload(total["formatted_amount"]) != "€15.38" {
+		t.Fatalf("expected formatted total €15.38, got %v", total["formatted_amount"])
+	}
+	items := asSlicePayload(t, data["items"])
+	if len(items) != 1 {
+		t.Fatalf("expected one item in detailed order payload, got %d", len(items))
+	}
+}
+
+func TestProfileOrdersShowTaxBreakdownGroupsRates(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryShowFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"order_id": "purchase-1",
+					"currency": "EUR",
+					"items": []any{
+						map[string]any{"name": "Burger", "count": 2, "end_amount": 2280, "vat_percentage": 14},
+						map[string]any{"name": "Fries", "count": 1, "end_amount": 570, "vat_percentage": 14},
+						map[string]any{"name": "Beer", "count": 1, "end_amount": 753, "vat_rate": 0.255},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "orders", "show", "purchase-1", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	breakdown := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["tax_breakdown"])
+	rates := asSlicePayload(t, breakdown["rates"])
+	if breakdown["source"] != "items" || len(rates) != 2 {
+		t.Fatalf("expected two item-derived rates, got %v", breakdown)
+	}
+	food, alcohol := asMapPayload(t, rates[0]), asMapPayload(t, rates[1])
+	if food["rate_percent"] != float64(14) || asMapPayload(t, food["tax_amount"])["amount"] != float64(350) {
+		t.Fatalf("expected 14%% VAT of 3.50 on 28.50, got %v", food)
+	}
+	if alcohol["rate_percent"] != 25.5 || asMapPayload(t, alcohol["tax_amount"])["amount"] != float64(153) {
+		t.Fatalf("expected 25.5%% VAT of 1.53 on 7.53, got %v", alcohol)
+	}
+	if asMapPayload(t, breakdown["total_tax"])["amount"] != float64(503) {
+		t.Fatalf("expected total tax 5.03, got %v", breakdown["total_tax"])
+	}
+}
+
+func TestProfileOrdersShowAttributesDiscountsToLines(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryShowFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"order_id": "purchase-1",
+					"currency": "EUR",
+					"items": []any{
+						map[string]any{"id": "burger", "name": "Burger", "count": 2, "price": 1200, "end_amount": 1800},
+						map[string]any{"id": "fries", "name": "Fries", "count": 1, "price": 600, "end_amount": 400},
+						map[string]any{
+							"id": "shake", "name": "Shake", "count": 1, "price": 500, "end_amount": 400,
+							"discounts": []any{map[string]any{"campaign_id": "shake-tuesday", "title": "Shake Tuesday", "amount": 100}},
+						},
+						map[string]any{"id": "water", "name": "Water", "count": 1, "price": 200, "end_amount": 200},
+					},
+					"discounts": []any{
+						map[string]any{"campaign_id": "menu-deal", "title": "Menu deal", "amount": 1000, "items": []any{"burger", "fries"}},
+						map[string]any{"id": "free-delivery", "title": "Free delivery", "amount": 190},
+					},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "orders", "show", "purchase-1", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	items := asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["items"])
+	applied := func(idx int) []any {
+		return asSlicePayload(t, asMapPayload(t, items[idx])["applied_discounts"])
+	}
+	burger, fries := applied(0), applied(1)
+	if len(burger) != 1 || len(fries) != 1 {
+		t.Fatalf("expected the menu deal on burger and fries, got %v / %v", burger, fries)
+	}
+	burgerShare, friesShare := asMapPayload(t, burger[0]), asMapPayload(t, fries[0])
+	if burgerShare["campaign_id"] != "menu-deal" || burgerShare["source"] != "order_split" || asMapPayload(t, burgerShare["amount"])["amount"] != float64(800) {
+		t.Fatalf("expected 8.00 of the menu deal on the 24.00 burger line, got %v", burgerShare)
+	}
+	if asMapPayload(t, friesShare["amount"])["amount"] != float64(200) {
+		t.Fatalf("expected the 2.00 remainder on fries, got %v", friesShare)
+	}
+	shake := applied(2)
+	if len(shake) != 1 || asMapPayload(t, shake[0])["source"] != "line" || asMapPayload(t, shake[0])["title"] != "Shake Tuesday" {
+		t.Fatalf("expected the line-level shake discount, got %v", shake)
+	}
+	if len(applied(3)) != 0 {
+		t.Fatalf("expected no discount on water; free delivery names no items, got %v", applied(3))
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "orders", "show", "purchase-1", "--wtoken", "token")
+	if exitCode != 0 || !strings.Contains(out, "Menu deal (-€8.00)") {
+		t.Fatalf("expected discount rows under the items in the table, got %d\n%s", exitCode, out)
+	}
+}
+
+func TestProfileOrdersExportIncludesExpenseTags(t *testing.T) {
+	t.Setenv("WOLT_AUDIT_DIR", t.TempDir())
+	paid := func(day int, month time.Month) int64 {
+		return time.Date(2026, month, day, 12, 0, 0, 0, time.Local).UnixMilli()
+	}
+	pages := map[string]map[string]any{
+		"": {
+			"orders": []any{
+				map[string]any{"purchase_id": "purchase-3", "status": "delivered", "payment_time_ts": paid(2, time.March)},
+				map[string]any{"purchase_id": "purchase-2", "status": "delivered", "payment_time_ts": paid(20, time.February)},
+			},
+			"next_page_token": "page-2",
+		},
+		"page-2": {
+			"orders": []any{
+				map[string]any{"purchase_id": "purchase-1", "status": "delivered", "payment_time_ts": paid(5, time.February)},
+				map[string]any{"purchase_id": "purchase-0", "status": "delivered", "payment_time_ts": paid(20, time.January)},
+			},
+			"next_page_token": "page-3",
+		},
+	}
+	requested := []string{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryFunc: func(_ context.Context, _ woltgateway.AuthContext, options woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				requested = append(requested, options.PageToken)
+				return pages[options.PageToken], nil
+			},
+			orderHistoryShowFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"order_id": "purchase-2", "status": "delivered"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "orders", "show", "purchase-2", "--wtoken", "token", "--expense-code", "MEALS", "--cost-center", "CC-7", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if expense := asMapPayload(t, asMapPayload(t, mustJSON(t, out)["data"])["expense"]); expense["expense_code"] != "MEALS" || expense["cost_center"] != "CC-7" {
+		t.Fatalf("expected recorded expense tags on show, got %v", expense)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "orders", "export", "--wtoken", "token", "--since", "2026-02-01", "--until", "2026-02-28", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if strings.Join(requested, ",") != ",page-2" {
+		t.Fatalf("expected paging to stop once orders predate --since, got %q", requested)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	orders := asSlicePayload(t, data["orders"])
+	if len(orders) != 2 || asIntPayload(data["pages"]) != 2 || data["truncated"] != false {
+		t.Fatalf("expected two February orders from two pages, got %v", data)
+	}
+	tagged, untagged := asMapPayload(t, orders[0]), asMapPayload(t, orders[1])
+	if tagged["purchase_id"] != "purchase-2" || tagged["expense_code"] != "MEALS" || tagged["cost_center"] != "CC-7" {
+		t.Fatalf("expected tagged purchase-2, got %v", tagged)
+	}
+	if untagged["purchase_id"] != "purchase-1" || untagged["expense_code"] != nil {
+		t.Fatalf("expected untagged purchase-1, got %v", untagged)
+	}
+}
+
+func TestProfileOrdersExportBeancount(t *testing.T) {
+	t.Setenv("WOLT_AUDIT_DIR", t.TempDir())
+	paidAt := time.Date(2026, time.February, 20, 12, 0, 0, 0, time.Local).UnixMilli()
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryFunc: func(context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				return map[string]any{"orders": []any{
+					map[string]any{"purchase_id": "purchase-2", "status": "delivered", "venue_name": "Burger Joint", "items": "2x Burger", "payment_time_ts": paidAt},
+					map[string]any{"purchase_id": "purchase-1", "status": "rejected", "venue_name": "Taco Place", "payment_time_ts": paidAt - 1000},
+				}}, nil
+			},
+			orderHistoryShowFn: func(_ context.Context, purchaseID string, _ woltgateway.AuthContext) (map[string]any, error) {
+				if purchaseID == "purchase-1" {
+					return map[string]any{"order_id": purchaseID, "currency": "EUR", "total_price": 0}, nil
+				}
+				return map[string]any{
+					"order_id":       purchaseID,
+					"venue_name":     "Burger Joint",
+					"currency":       "EUR",
+					"delivery_price": 290,
+					"total_price":    2405,
+					"discounts":      []any{map[string]any{"title": "Welcome", "amount": 300}},
+				}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "profile", "orders", "export", "--wtoken", "token", "--format", "beancount", "--account", "Expenses:Food:Takeout")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	for _, want := range []string{
+		"; skipped purchase-1: no paid total",
+		`2026-02-20 * "Burger Joint" "2x Burger" #wolt #delivery-fee #discount`,
+		`  purchase_id: "purchase-2"`,
+		"  Expenses:Food:Takeout  24.05 EUR",
+		"  Liabilities:CreditCard",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in beancount output:\n%s", want, out)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "orders", "export", "--wtoken", "token", "--format", "beancount", "--account", "food")
+	if exitCode == 0 || !strings.Contains(out, "; error WOLT_INVALID_ARGUMENT") {
+		t.Fatalf("expected invalid account error comment, got %d\n%s", exitCode, out)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "profile", "orders", "list", "--wtoken", "token", "--format", "beancount")
+	if exitCode == 0 || !strings.Contains(out, "--format beancount is supported by profile orders export") {
+		t.Fatalf("expected beancount rejection on orders list, got %d\n%s", exitCode, out)
+	}
+}
+
+func asBoolPayload(value any) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
+
+func TestVenueSearchPickFirstThenCartAdd(t *testing.T) {
+	seenAddPayload := map[string]any{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1"}}, nil
+			},
+			assortmentItemsSearchFn: func(context.Context, string, string, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"items": []any{
+						map[string]any{"id": "item-1", "name": "Cola 0.5L", "price": map[string]any{"amount": 249, "currency": "EUR"}},
+						map[string]any{"id": "item-2", "name": "Cola Zero", "price": map[string]any{"amount": 259, "currency": "EUR"}},
+					},
+				}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{"name": "Cola 0.5L", "price": map[string]any{"amount": 249, "currency": "EUR"}}, nil
+			},
+			addToBasketFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenAddPayload = payload
+				return map[string]any{"id": "basket-1", "venue_id": "venue-1"}, nil
+			},
+			basketCountFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"count": 2}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"baskets": []any{}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(
+		t,
+		deps,
+		"venue", "search", "shop", "--query", "cola", "--pick-first",
+		"--then",
+		"cart", "add", "--count", "2", "--wtoken", "token", "--format", "json",
+	)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenAddPayload["venue_id"] != "venue-1" {
+		t.Fatalf("expected picked venue-1 in add payload, got %+v", seenAddPayload)
+	}
+	items := asSlicePayload(t, seenAddPayload["items"])
+	line := asMapPayload(t, items[len(items)-1])
+	if line["id"] != "item-1" || asIntPayload(line["count"]) != 2 {
+		t.Fatalf("expected picked item-1 x2, got %+v", line)
+	}
+}
+
+func TestVenueShopMatchesListAndAppliesBatch(t *testing.T) {
+	catalog := map[string][]any{
+		"milk": {
+			map[string]any{"id": "item-oat", "name": "Oatly Oat Drink 1 l", "price": map[string]any{"amount": 229, "currency": "EUR"}},
+			map[string]any{"id": "item-milk", "name": "Valio Milk 1 l", "price": map[string]any{"amount": 149, "currency": "EUR"}},
+		},
+		"eggs": {
+			map[string]any{"id": "item-eggs", "name": "Eggs 10 pcs", "price": map[string]any{"amount": 329, "currency": "EUR"}, "is_sold_out": true},
+		},
+		"caviar": {},
+	}
+	seenAddPayload := map[string]any{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1", "currency": "EUR"}}, nil
+			},
+			assortmentItemsSearchFn: func(_ context.Context, _ string, query string, _ string, _ woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"items": catalog[query]}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"baskets": []any{
+						map[string]any{
+							"id":    "basket-1",
+							"venue": map[string]any{"id": "venue-1"},
+							"items": []any{map[string]any{"id": "item-milk", "count": 1, "price": 149, "options": []any{}}},
+						},
+					},
+				}, nil
+			},
+			addToBasketFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenAddPayload = payload
+				return map[string]any{"id": "basket-1", "venue_id": "venue-1"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+	list := filepath.Join(t.TempDir(), "groceries.txt")
+	if err := os.WriteFile(list, []byte("2 x milk\neggs\ncaviar # treat\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "venue", "shop", "market", "--list", list, "--apply", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	lines := asSlicePayload(t, data["lines"])
+	milk := asMapPayload(t, lines[0])
+	if milk["status"] != "matched" || asMapPayload(t, milk["match"])["item_id"] != "item-milk" || asIntPayload(milk["count"]) != 2 {
+		t.Fatalf("expected milk to match Valio Milk x2, got %v", milk)
+	}
+	if asMapPayload(t, lines[1])["status"] != "sold_out" || asMapPayload(t, lines[2])["status"] != "missing" {
+		t.Fatalf("expected sold-out eggs and missing caviar, got %v", lines)
+	}
+	if missing := asSlicePayload(t, data["missing"]); len(missing) != 2 || data["applied"] != true {
+		t.Fatalf("expected two misses and an applied cart, got %v", data)
+	}
+	items := asSlicePayload(t, seenAddPayload["items"])
+	if len(items) != 1 || asIntPayload(asMapPayload(t, items[0])["count"]) != 3 {
+		t.Fatalf("expected the milk line merged into the existing one (1+2), got %v", items)
+	}
+}
+
+func TestItemReorderResolvesCurrentItemAndAddsAfterYes(t *testing.T) {
+	seenPurchase := ""
+	seenAddPayload := map[string]any{}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			orderHistoryFunc: func(context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				return map[string]any{"orders": []any{
+					map[string]any{"purchase_id": "p-2", "venue_name": "Pizza Place", "items": "Margherita"},
+					map[string]any{"purchase_id": "p-1", "venue_name": "Roastery", "venue_slug": "roastery", "items": "Coffee Beans 1 kg, Milk"},
+					map[string]any{"purchase_id": "p-0", "venue_name": "Roastery", "venue_slug": "roastery", "items": "Coffee Beans 500 g"},
+				}}, nil
+			},
+			orderHistoryShowFn: func(_ context.Context, purchaseID string, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenPurchase = purchaseID
+				return map[string]any{
+					"venue_id":   "venue-1",
+					"venue_name": "Roastery",
+					"items": []any{
+						map[string]any{"id": "old-beans", "name": "Coffee Beans 1 kg", "count": 2, "price": 1290},
+						map[string]any{"id": "milk", "name": "Milk", "count": 1, "price": 149},
+					},
+				}, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1", "currency": "EUR"}}, nil
+			},
+			assortmentItemsSearchFn: func(context.Context, string, string, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"items": []any{
+					map[string]any{"id": "beans-decaf", "name": "Decaf Coffee Beans 1 kg", "price": map[string]any{"amount": 1390, "currency": "EUR"}},
+					map[string]any{"id": "beans-new", "name": "Coffee Beans 1 kg", "price": map[string]any{"amount": 1350, "currency": "EUR"}},
+				}}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"baskets": []any{}}, nil
+			},
+			addToBasketFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenAddPayload = payload
+				return map[string]any{"id": "basket-1"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "item", "reorder", "coffee beans", "--wtoken", "token", "--machine")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if seenPurchase != "p-1" || data["added"] != false || len(seenAddPayload) != 0 {
+		t.Fatalf("expected the newest matching order and no add without --yes, got purchase %q data %v", seenPurchase, data)
+	}
+	if !strings.Contains(out, "pass --yes") {
+		t.Fatalf("expected a --yes hint, got %s", out)
+	}
+	match := asMapPayload(t, data["match"])
+	if match["item_id"] != "beans-new" || data["match_kind"] != "name" || asIntPayload(data["count"]) != 2 {
+		t.Fatalf("expected the current Coffee Beans 1 kg matched by name with the original count, got %v", data)
+	}
+	if matches := asSlicePayload(t, data["matches"]); len(matches) != 2 {
+		t.Fatalf("expected both matching order lines listed, got %v", matches)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "item", "reorder", "coffee beans", "--yes", "--count", "1", "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	items := asSlicePayload(t, seenAddPayload["items"])
+	if data["added"] != true || len(items) != 1 || asMapPayload(t, items[0])["id"] != "beans-new" || asIntPayload(asMapPayload(t, items[0])["count"]) != 1 {
+		t.Fatalf("expected one beans-new line added, got %v / %v", data, seenAddPayload)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "item", "reorder", "tea", "--wtoken", "token", "--format", "json")
+	if exitCode == 0 || !strings.Contains(out, "WOLT_NOT_FOUND") {
+		t.Fatalf("expected WOLT_NOT_FOUND for a fragment in no order, got exit %d\n%s", exitCode, out)
+	}
+}
+
+func TestCartExportWritesOrderFileThatCartImportAddsByName(t *testing.T) {
+	seenAddPayload := map[string]any{}
+	basket := map[string]any{
+		"id":    "basket-1",
+		"total": "€21.00",
+		"venue": map[string]any{"id": "venue-1", "name": "Burger Place", "slug": "burger-place"},
+		"items": []any{
+			map[string]any{"id": "burger", "name": "Burger", "count": 2, "price": 900, "options": []any{
+				map[string]any{"id": "group-size", "values": []any{map[string]any{"id": "size-large", "count": 1, "price": 100}}},
+			}},
+			map[string]any{"id": "old-fries", "name": "Fries", "count": 1, "price": 300, "options": []any{}},
+		},
+	}
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"baskets": []any{basket}}, nil
+			},
+			venueItemPageFunc: func(_ context.Context, _ string, itemID string) (map[string]any, error) {
+				if itemID != "burger" {
+					return nil, errors.New("item not found")
+				}
+				return map[string]any{
+					"name":  "Burger",
+					"price": map[string]any{"amount": 900, "currency": "EUR"},
+					"option_groups": []any{map[string]any{"id": "group-size", "name": "Size", "values": []any{
+						map[string]any{"id": "size-regular", "name": "Regular", "price": 0},
+						map[string]any{"id": "size-large", "name": "Large", "price": 100},
+					}}},
+				}, nil
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1", "currency": "EUR"}}, nil
+			},
+			assortmentItemsSearchFn: func(context.Context, string, string, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"items": []any{
+					map[string]any{"id": "fries-new", "name": "Fries", "price": map[string]any{"amount": 350, "currency": "EUR"}},
+				}}, nil
+			},
+			addToBasketFunc: func(_ context.Context, payload map[string]any, _ woltgateway.AuthContext) (map[string]any, error) {
+				seenAddPayload = payload
+				return map[string]any{"id": "basket-2"}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	orderPath := filepath.Join(t.TempDir(), "order.yaml")
+	exitCode, out := runCLIWithDeps(t, deps, "cart", "export", "--wtoken", "token", "--output", orderPath)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	written, err := os.ReadFile(orderPath)
+	if err != nil {
+		t.Fatalf("read order file: %v", err)
+	}
+	for _, want := range []string{"slug: burger-place", "name: Burger", "count: 2", "- Size=Large", "item_id: old-fries"} {
+		if !strings.Contains(string(written), want) {
+			t.Fatalf("expected %q in the order file, got:\n%s", want, written)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "cart", "import", orderPath, "--dry-run", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	lines := asSlicePayload(t, data["lines"])
+	if data["added"] != false || len(seenAddPayload) != 0 || asIntPayload(data["matched"]) != 2 {
+		t.Fatalf("expected a dry run matching both lines without adding, got %v", data)
+	}
+	if status := asMapPayload(t, lines[1])["status"]; status != "matched_by_name" {
+		t.Fatalf("expected the gone fries id matched by name, got %v", status)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "cart", "import", orderPath, "--wtoken", "token", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); data["added"] != true {
+		t.Fatalf("expected the lines added, got %v", data)
+	}
+	var burger, fries map[string]any
+	for _, value := range asSlicePayload(t, seenAddPayload["items"]) {
+		line := asMapPayload(t, value)
+		switch line["id"] {
+		case "burger":
+			if burger == nil && len(asSlicePayload(t, line["options"])) > 0 {
+				burger = line
+			}
+		case "fries-new":
+			fries = line
+		}
+	}
+	if burger == nil || fries == nil || asIntPayload(fries["count"]) != 1 {
+		t.Fatalf("expected the burger with options and fries-new added, got %v", seenAddPayload["items"])
+	}
+	option := asMapPayload(t, asSlicePayload(t, burger["options"])[0])
+	value := asMapPayload(t, asSlicePayload(t, option["values"])[0])
+	if option["id"] != "group-size" || value["id"] != "size-large" {
+		t.Fatalf("expected Size=Large resolved to its ids, got %v", option)
+	}
+
+	missingOption := filepath.Join(t.TempDir(), "order.yaml")
+	if err := os.WriteFile(missingOption, []byte("venue:\n  slug: burger-place\nitems:\n  - name: Burger\n    item_id: burger\n    options: [Size=Huge]\n"), 0o600); err != nil {
+		t.Fatalf("write order file: %v", err)
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "cart", "import", missingOption, "--dry-run", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	line := asMapPayload(t, asSlicePayload(t, asMapPayload(t, mustJSON(t, out)["data"])["lines"])[0])
+	if line["status"] != "option_missing" {
+		t.Fatalf("expected an unknown option value reported, got %v", line)
+	}
+}
+
+func TestThenRequiresPickedRow(t *testing.T) {
+	exitCode, out := runCLI(t, "--version", "--then", "cart", "show")
+	if exitCode != 2 {
+		t.Fatalf("expected exit 2, got %d\noutput:\n%s", exitCode, out)
+	}
+	if !strings.Contains(out, "--pick-first") {
+		t.Fatalf("expected --pick-first hint, got %s", out)
+	}
+}
+
+func TestTravelSetAndClearSwitchDefaultProfile(t *testing.T) {
+	config := &recordingConfig{loadCfg: domain.Config{Profiles: []domain.Profile{
+		{Name: "home", IsDefault: true, WToken: "token", WRefreshToken: "refresh"},
+		{Name: "work"},
+	}}}
+	var seenLocation domain.Location
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			sectionsFunc: func(_ context.Context, location domain.Location) ([]domain.Section, error) {
+				seenLocation = location
+				return []domain.Section{{Name: "popular", Items: []domain.Item{
+					{Title: "Curry 36", Venue: &domain.Venue{ID: "venue-1", Slug: "curry-36", Country: "DEU", Currency: "EUR"}},
+				}}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "home", IsDefault: true, WToken: "token", WRefreshToken: "refresh"}},
+		Location: &recordingLocation{location: domain.Location{Lat: 52.52, Lon: 13.405}},
+		Config:   config,
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "travel", "set", "Berlin, Germany", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if seenLocation.Lat != 52.52 {
+		t.Fatalf("expected coverage check at the geocoded destination, got %+v", seenLocation)
 	}
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["action"] != "remove" {
-		t.Fatalf("expected action remove, got %v", data["action"])
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["profile"] != "travel" || data["country"] != "DEU" || data["previous_default"] != "home" || data["authenticated"] != true {
+		t.Fatalf("unexpected travel set data: %v", data)
 	}
-	if asBoolPayload(data["is_favorite"]) {
-		t.Fatalf("expected is_favorite=false, got %v", data["is_favorite"])
+	saved := *config.saved
+	if len(saved.Profiles) != 3 || saved.Profiles[0].IsDefault || !saved.Profiles[2].IsDefault {
+		t.Fatalf("expected travel profile appended as the only default, got %+v", saved.Profiles)
+	}
+	travel := saved.Profiles[2]
+	if travel.Travel == nil || travel.Location.Lat != 52.52 || travel.WToken != "token" {
+		t.Fatalf("expected travel profile with location and copied credentials, got %+v", travel)
+	}
+
+	travel.WToken, travel.WRefreshToken = "rotated", "rotated-refresh"
+	saved.Profiles[2] = travel
+	config.loadCfg = saved
+	exitCode, out = runCLIWithDeps(t, deps, "travel", "clear", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	if data["cleared"] != true || data["default_profile"] != "home" {
+		t.Fatalf("unexpected travel clear data: %v", data)
+	}
+	restored := *config.saved
+	if len(restored.Profiles) != 2 || !restored.Profiles[0].IsDefault || restored.Profiles[0].WToken != "rotated" {
+		t.Fatalf("expected home restored as default with rotated tokens, got %+v", restored.Profiles)
+	}
+
+	deps.Wolt = &mockWolt{
+		sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+			return []domain.Section{}, nil
+		},
+	}
+	exitCode, out = runCLIWithDeps(t, deps, "travel", "set", "Nowhere", "--format", "json")
+	if exitCode != 1 || !strings.Contains(out, "WOLT_NOT_COVERED") {
+		t.Fatalf("expected WOLT_NOT_COVERED, got %d\noutput:\n%s", exitCode, out)
 	}
 }
 
-func TestProfileOrdersListJSON(t *testing.T) {
-	seenLimit := 0
-	seenPageToken := ""
+func TestWhoamiMergesAccountAndProfileDetails(t *testing.T) {
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			orderHistoryFunc: func(_ context.Context, auth woltgateway.AuthContext, options woltgateway.OrderHistoryOptions) (map[string]any, error) {
-				if auth.WToken != "token" {
-					t.Fatalf("expected token to be forwarded")
-				}
-				seenLimit = options.Limit
-				seenPageToken = options.PageToken
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
 				return map[string]any{
-					"orders": []any{
-						map[string]any{
-							"purchase_id":     "purchase-1",
-							"received_at":     "15/02/2026, 10:06",
-							"status":          "delivered",
-							"venue_name":      "Burger King Iso Omena",
-							"total_amount":    "€15.38",
-							"is_active":       false,
-							"payment_time_ts": 1771142803530,
-							"items": []any{
-								map[string]any{"name": "LONG CHICKEN®"},
-								map[string]any{"name": "WHOPPER® Jr."},
-							},
-						},
-						map[string]any{
-							"purchase_id":     "purchase-2",
-							"received_at":     "03/02/2026, 11:07",
-							"status":          "deferred_payment_failed",
-							"venue_name":      "KFC Iso Omena",
-							"total_amount":    "--",
-							"is_active":       false,
-							"payment_time_ts": 1770109674437,
-							"items":           "Twister Lunchbox",
-						},
+					"user": map[string]any{
+						"_id":                     map[string]any{"$oid": "user-1"},
+						"name":                    map[string]any{"first_name": "Anna", "last_name": "Virtanen"},
+						"country":                 "FIN",
+						"is_wolt_plus_subscriber": true,
+					},
+				}, nil
+			},
+			deliveryInfoListFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"results": []any{
+						map[string]any{"id": "addr-1", "label_type": "home", "location": map[string]any{"address": "Iivisniemenkatu 2"}},
+						map[string]any{"id": "addr-2", "label_type": "work", "location": map[string]any{"address": "Mannerheimintie 1"}},
 					},
-					"next_page_token": "2025-12-03T14:40:50.585Z",
 				}, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "work", Location: domain.Location{Lat: 60.1, Lon: 24.9}, WToken: "token", WoltAddressID: "addr-2"}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(
-		t,
-		deps,
-		"profile",
-		"orders",
-		"--wtoken",
-		"token",
-		"--limit",
-		"25",
-		"--status",
-		"delivered",
-		"--format",
-		"json",
-	)
+	exitCode, out := runCLIWithDeps(t, deps, "whoami", "--profile", "work", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if seenLimit != 25 {
-		t.Fatalf("expected limit 25, got %d", seenLimit)
-	}
-	if seenPageToken != "" {
-		t.Fatalf("expected empty page token on first request, got %q", seenPageToken)
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if data["profile"] != "work" || data["user_id"] != "user-1" || data["name"] != "Anna Virtanen" || data["country"] != "FIN" {
+		t.Fatalf("unexpected identity fields: %v", data)
 	}
-
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if asIntPayload(data["count"]) != 1 {
-		t.Fatalf("expected one filtered order, got %v", data["count"])
+	if !asBoolPayload(data["authenticated"]) || !asBoolPayload(data["wolt_plus_subscriber"]) {
+		t.Fatalf("expected authenticated Wolt+ account, got %v", data)
 	}
-	if asStringPayload(data["next_page_token"]) != "2025-12-03T14:40:50.585Z" {
-		t.Fatalf("expected next_page_token in payload, got %v", data["next_page_token"])
+	address := asMapPayload(t, data["default_address"])
+	if address["address_id"] != "addr-2" || address["label"] != "work" {
+		t.Fatalf("expected the profile's address addr-2, got %v", address)
 	}
-	orders := asSlicePayload(t, data["orders"])
-	if len(orders) != 1 {
-		t.Fatalf("expected one order in payload, got %d", len(orders))
+}
+
+func TestWhoamiWithoutCredentialsSkipsRequests(t *testing.T) {
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return nil, errors.New("unexpected request")
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
 	}
-	first := asMapPayload(t, orders[0])
-	if first["purchase_id"] != "purchase-1" {
-		t.Fatalf("unexpected purchase id: %v", first["purchase_id"])
+
+	exitCode, out := runCLIWithDeps(t, deps, "whoami", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if first["status"] != "delivered" {
-		t.Fatalf("unexpected order status: %v", first["status"])
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if asBoolPayload(data["authenticated"]) || data["profile"] != "default" || data["user_id"] != nil {
+		t.Fatalf("expected an unauthenticated default profile, got %v", data)
 	}
-	if first["items_summary"] != "LONG CHICKEN®, WHOPPER® Jr." {
-		t.Fatalf("unexpected items summary: %v", first["items_summary"])
+	if !strings.Contains(out, "no auth credentials provided") {
+		t.Fatalf("expected missing-credentials warning, got %s", out)
 	}
 }
 
-func TestProfileOrdersShowJSON(t *testing.T) {
-	seenPurchaseID := ""
+func TestStCachesBasketSummaryBetweenPromptCalls(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	calls := 0
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{
-			orderHistoryShowFn: func(_ context.Context, purchaseID string, auth woltgateway.AuthContext) (map[string]any, error) {
-				seenPurchaseID = purchaseID
-				if auth.WToken != "token" {
-					t.Fatalf("expected token to be forwarded")
-				}
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				calls++
 				return map[string]any{
-					"order_id":           "purchase-1",
-					"order_number":       "413",
-					"status":             "delivered",
-					"creation_time":      "15/02/2026, 10:06",
-					"delivery_time":      "15/02/2026, 10:37",
-					"delivery_method":    "homedelivery",
-					"currency":           "EUR",
-					"venue_id":           "5bbdcb7d556891000bf66ed8",
-					"venue_name":         "Burger King Iso Omena",
-					"venue_full_address": "Piispansilta 11, 02230, Espoo",
-					"venue_phone":        "+358403546482",
-					"venue_country":      "FIN",
-					"venue_product_line": "restaurant",
-					"total_price":        1538,
-					"items_price":        2395,
-					"delivery_price":     101,
-					"service_fee":        101,
-					"subtotal":           1538,
-					"credits":            0,
-					"tokens":             0,
-					"items": []any{
-						map[string]any{
-							"id":         "item-1",
-							"name":       "LONG CHICKEN®",
-							"count":      1,
-							"price":      825,
-							"end_amount": 825,
-							"options":    []any{},
-						},
-					},
-					"payments": []any{
-						map[string]any{
-							"name":         "Edenred",
-							"amount":       1538,
-							"payment_time": "15/02/2026, 10:06",
-							"method": map[string]any{
-								"type":     "edenred",
-								"id":       "payment-1",
-								"provider": "edenred",
-							},
-						},
-					},
-					"delivery_location": map[string]any{
-						"alias":  "Home",
-						"street": "Iivisniemenkatu 2, 36",
-						"city":   "Espoo",
-					},
-					"delivery_comment": "Leave order at the door",
-					"discounts": []any{
-						map[string]any{"title": "Discounts", "amount": 958},
+					"baskets": []any{
+						map[string]any{"id": "b1", "total": "€12.40", "items": []any{map[string]any{"id": "i1", "count": 2, "price": 620}}},
+						map[string]any{"id": "b2", "total": "€6.00", "items": []any{map[string]any{"id": "i2", "count": 1, "price": 600}}},
+						map[string]any{"id": "b3", "items": []any{}},
 					},
-					"surcharges": []any{},
 				}, nil
 			},
 		},
-		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.14889, Lon: 24.6911577}}},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}, WToken: "token"}},
 		Location: &mockLocation{},
 		Config:   &mockConfig{},
 		Version:  "1.1.1",
 	}
 
-	exitCode, out := runCLIWithDeps(
-		t,
-		deps,
-		"profile",
-		"orders",
-		"show",
-		"purchase-1",
-		"--wtoken",
-		"token",
-		"--format",
-		"json",
-	)
+	exitCode, out := runCLIWithDeps(t, deps, "st", "--format", "json")
 	if exitCode != 0 {
 		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	if seenPurchaseID != "purchase-1" {
-		t.Fatalf("expected purchase id purchase-1, got %q", seenPurchaseID)
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if asIntPayload(data["basket_count"]) != 2 || asIntPayload(data["item_count"]) != 3 {
+		t.Fatalf("expected 2 baskets with 3 items, got %v", data)
+	}
+	if asIntPayload(asMapPayload(t, data["total"])["amount"]) != 1840 || asBoolPayload(data["cached"]) {
+		t.Fatalf("expected a fresh total of 1840, got %v", data)
 	}
 
-	payload := mustJSON(t, out)
-	data := asMapPayload(t, payload["data"])
-	if data["order_id"] != "purchase-1" {
-		t.Fatalf("expected order_id purchase-1, got %v", data["order_id"])
+	exitCode, out = runCLIWithDeps(t, deps, "st", "--prompt")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
 	}
-	totals := asMapPayload(t, data["totals"])
-	total := asMapPayload(t, totals["total"])
-	if asIntPayload(total["amount"]) != 1538 {
-		t.Fatalf("expected total amount 1538, got %v", total["amount"])
+	if strings.TrimSpace(out) != "default 2 carts €18.40" {
+		t.Fatalf("unexpected prompt line %q", out)
 	}
-	if asStringPayload(total["formatted_amount"]) != "€15.38" {
-		t.Fatalf("expected formatted total €15.38, got %v", total["formatted_amount"])
+	if calls != 1 {
+		t.Fatalf("expected the second call to use the cache, got %d requests", calls)
 	}
-	items := asSlicePayload(t, data["items"])
-	if len(items) != 1 {
-		t.Fatalf("expected one item in detailed order payload, got %d", len(items))
+}
+
+func TestStSWRServesExpiredSummaryOnceThenRefreshes(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	deps := cli.Dependencies{
+		Wolt: &mockWolt{
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				calls++
+				items := []any{map[string]any{"id": "i1", "count": calls, "price": 500}}
+				return map[string]any{"baskets": []any{map[string]any{"id": "b1", "total": "€5.00", "items": items}}}, nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "default", IsDefault: true, Location: domain.Location{Lat: 60.1, Lon: 24.9}, WToken: "token"}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+		Clock:    cli.ClockFunc(func() time.Time { return now }),
+	}
+
+	if exitCode, out := runCLIWithDeps(t, deps, "st", "--swr", "--format", "json"); exitCode != 0 || calls != 1 {
+		t.Fatalf("expected the first call to fetch, got exit %d and %d requests\noutput:\n%s", exitCode, calls, out)
+	}
+	now = now.Add(143 * time.Second)
+	exitCode, out := runCLIWithDeps(t, deps, "st", "--swr", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data := asMapPayload(t, mustJSON(t, out)["data"])
+	if calls != 1 || data["stale"] != true || data["age"] != "143s" || asIntPayload(data["item_count"]) != 1 {
+		t.Fatalf("expected the expired summary served stale without a request, got %d requests and %v", calls, data)
+	}
+
+	exitCode, out = runCLIWithDeps(t, deps, "st", "--swr", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	data = asMapPayload(t, mustJSON(t, out)["data"])
+	if calls != 2 || data["stale"] != nil || asIntPayload(data["item_count"]) != 2 {
+		t.Fatalf("expected the call after a stale reply to refresh, got %d requests and %v", calls, data)
+	}
+
+	now = now.Add(10 * time.Minute)
+	if _, out := runCLIWithDeps(t, deps, "st", "--format", "json"); calls != 3 {
+		t.Fatalf("expected an expired summary to be fetched without --swr, got %d requests\noutput:\n%s", calls, out)
 	}
 }
 
-func asBoolPayload(value any) bool {
-	b, ok := value.(bool)
-	return ok && b
+func TestConfigSyncSharesProfilesAndNotesWithoutSecrets(t *testing.T) {
+	shared := t.TempDir()
+	machine := func(name string, cfg domain.Config) (cli.Dependencies, string) {
+		dir := t.TempDir()
+		t.Setenv("WOLT_CONFIG_PATH", filepath.Join(dir, "config.json"))
+		store, err := config.NewStore()
+		if err != nil {
+			t.Fatalf("config store for %s: %v", name, err)
+		}
+		if err := store.Save(context.Background(), cfg); err != nil {
+			t.Fatalf("save %s config: %v", name, err)
+		}
+		return cli.Dependencies{
+			Wolt:     &mockWolt{},
+			Profiles: &mockProfiles{profile: cfg.Profiles[0]},
+			Location: &mockLocation{},
+			Config:   store,
+			Version:  "1.1.1",
+		}, dir
+	}
+	sync := func(deps cli.Dependencies, dir string) map[string]any {
+		t.Helper()
+		t.Setenv("WOLT_JOURNAL_DIR", filepath.Join(dir, "journal"))
+		t.Setenv("WOLT_SYNC_DIR", filepath.Join(dir, "sync"))
+		exitCode, out := runCLIWithDeps(t, deps, "config", "sync", "--remote", shared, "--format", "json")
+		if exitCode != 0 {
+			t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+		}
+		return mustJSON(t, out)
+	}
+
+	alice, aliceDir := machine("alice", domain.Config{Profiles: []domain.Profile{{
+		Name: "home", IsDefault: true, WToken: "alice-secret", WoltAddressID: "addr-alice",
+		Location: domain.Location{Lat: 60.17, Lon: 24.94},
+		Budget:   &domain.Budget{Amount: 30000, Currency: "EUR", Period: "month"},
+	}}})
+	if err := journal.NewStore(filepath.Join(aliceDir, "journal")).SetNote("home", "ramen-place", journal.Note{Text: "ask for extra egg"}); err != nil {
+		t.Fatalf("seed note: %v", err)
+	}
+	bob, bobDir := machine("bob", domain.Config{Profiles: []domain.Profile{{
+		Name: "work", IsDefault: true, WToken: "bob-secret", Location: domain.Location{Lat: 60.2, Lon: 24.8},
+	}}})
+
+	data := asMapPayload(t, sync(alice, aliceDir)["data"])
+	if data["kind"] != "path" || len(asSlicePayload(t, data["pushed"])) != 2 {
+		t.Fatalf("expected alice to push her profile and note, got %v", data)
+	}
+	payload, err := os.ReadFile(filepath.Join(shared, "wolt-shared.json"))
+	if err != nil {
+		t.Fatalf("read shared file: %v", err)
+	}
+	for _, secret := range []string{"alice-secret", "addr-alice", "is_default"} {
+		if strings.Contains(string(payload), secret) {
+			t.Fatalf("shared file leaked %q:\n%s", secret, payload)
+		}
+	}
+
+	data = asMapPayload(t, sync(bob, bobDir)["data"])
+	pulled := asSlicePayload(t, data["pulled"])
+	if !containsStringPayload(pulled, "profile/home") || !containsStringPayload(pulled, "note/home/ramen-place") {
+		t.Fatalf("expected bob to pull alice's profile and note, got %v", data)
+	}
+	if !containsStringPayload(asSlicePayload(t, data["pushed"]), "profile/work") {
+		t.Fatalf("expected bob to push his profile, got %v", data)
+	}
+	bobCfg, err := bob.Config.Load(context.Background())
+	if err != nil || len(bobCfg.Profiles) != 2 {
+		t.Fatalf("expected bob to have two profiles, got %+v err=%v", bobCfg, err)
+	}
+	home := bobCfg.Profiles[1]
+	if home.Name != "home" || home.IsDefault || home.WToken != "" || home.Budget == nil || home.Budget.Amount != 30000 {
+		t.Fatalf("expected home without credentials or default marker, got %+v", home)
+	}
+	if bobCfg.Profiles[0].WToken != "bob-secret" || !bobCfg.Profiles[0].IsDefault {
+		t.Fatalf("expected bob's own profile untouched, got %+v", bobCfg.Profiles[0])
+	}
+	notes, err := journal.NewStore(filepath.Join(bobDir, "journal")).Notes("home")
+	if err != nil || notes["ramen-place"].Text != "ask for extra egg" {
+		t.Fatalf("expected the shared note in bob's journal, got %v err=%v", notes, err)
+	}
+
+	// Both sides edit the same note: the local value wins and is reported.
+	if err := journal.NewStore(filepath.Join(aliceDir, "journal")).SetNote("home", "ramen-place", journal.Note{Text: "closed on mondays"}); err != nil {
+		t.Fatalf("edit alice note: %v", err)
+	}
+	if err := journal.NewStore(filepath.Join(bobDir, "journal")).SetNote("home", "ramen-place", journal.Note{Text: "try the spicy miso"}); err != nil {
+		t.Fatalf("edit bob note: %v", err)
+	}
+	sync(bob, bobDir)
+	env := sync(alice, aliceDir)
+	data = asMapPayload(t, env["data"])
+	if !containsStringPayload(asSlicePayload(t, data["conflicts"]), "note/home/ramen-place") || len(asSlicePayload(t, env["warnings"])) == 0 {
+		t.Fatalf("expected alice to report the note conflict, got %v", env)
+	}
+	if !containsStringPayload(asSlicePayload(t, data["pulled"]), "profile/work") {
+		t.Fatalf("expected alice to pull bob's profile, got %v", data)
+	}
+	notes, err = journal.NewStore(filepath.Join(aliceDir, "journal")).Notes("home")
+	if err != nil || notes["ramen-place"].Text != "closed on mondays" {
+		t.Fatalf("expected alice to keep her note on conflict, got %v err=%v", notes, err)
+	}
 }
@@ -0,0 +1,502 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekedron/wolt-cli/internal/cli"
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite golden snapshots under testdata/golden")
+
+// goldenCases lists one JSON invocation per leaf command; TestGoldenCoversEveryCommand
+// fails when a new command is added without a case here.
+var goldenCases = []struct {
+	name string
+	args []string
+}{
+	{"auth_status", []string{"auth", "status"}},
+	{"auth_refresh", []string{"auth", "refresh", "--wrtoken", "refresh-token"}},
+	{"whoami", []string{"whoami"}},
+	{"st", []string{"st"}},
+	{"profile_status", []string{"profile", "status"}},
+	{"cart_show", []string{"cart", "show"}},
+	{"cart_add", []string{"cart", "add", "venue-1", "item-1"}},
+	{"cart_count", []string{"cart", "count"}},
+	{"cart_remove", []string{"cart", "remove", "item-1"}},
+	{"cart_update", []string{"cart", "update", "item-1", "--no-substitution", "--substitution-note", "Any brand is fine"}},
+	{"cart_clear", []string{"cart", "clear"}},
+	{"cart_export", []string{"cart", "export"}},
+	{"cart_import", []string{"cart", "import", "testdata/cart_order.yaml"}},
+	{"checkout_preview", []string{"checkout", "preview"}},
+	{"configure", []string{"configure", "--profile-name", "golden", "--wtoken", "token", "--overwrite", "--machine"}},
+	{"digest", []string{"digest", "--since", "2w"}},
+	{"diff", []string{"diff", "testdata/diff_old.json", "testdata/diff_new.json", "--path", "data.items"}},
+	{"debug_parse", []string{"debug", "parse", "--payload", "../integration/testdata/wolt/sections.json", "--kind", "front"}},
+	{"debug_anonymize", []string{"debug", "anonymize", "../integration/testdata/wolt/sections.json", "--out", os.DevNull, "--seed", "golden"}},
+	// The mock client cannot record, so the snapshot pins the refusal.
+	{"debug_fixture", []string{"debug", "fixture", "--machine", "search", "items", "--query", "fries"}},
+	{"discover_feed", []string{"discover", "feed"}},
+	{"discover_categories", []string{"discover", "categories"}},
+	{"discover_sections", []string{"discover", "sections"}},
+	{"discover_breakfast", []string{"discover", "breakfast", "--now", "2026-02-16T08:00"}},
+	{"discover_lunch", []string{"discover", "lunch", "--now", "2026-02-16T12:00"}},
+	{"discover_dinner", []string{"discover", "dinner", "--now", "2026-02-16T19:00"}},
+	{"discover_now", []string{"discover", "now", "--now", "2026-02-16T19:00"}},
+	{"pick", []string{"pick", "--seed", "1", "--with-item"}},
+	{"profile_show", []string{"profile", "show"}},
+	{"profile_payments", []string{"profile", "payments"}},
+	{"profile_payments_add_card", []string{"profile", "payments", "add-card", "--no-wait"}},
+	{"profile_addresses", []string{"profile", "addresses"}},
+	{"profile_addresses_links", []string{"profile", "addresses", "links", "addr-1"}},
+	{"profile_addresses_add", []string{"profile", "addresses", "add", "--address", "Street 1", "--lat", "60.1", "--lon", "24.9", "--type", "other"}},
+	{"profile_addresses_remove", []string{"profile", "addresses", "remove", "addr-1"}},
+	{"profile_addresses_use", []string{"profile", "addresses", "use", "addr-1"}},
+	{"profile_addresses_update", []string{"profile", "addresses", "update", "addr-1", "--address", "Street 2", "--lat", "60.1", "--lon", "24.9"}},
+	{"profile_favorites", []string{"profile", "favorites"}},
+	{"profile_favorites_list", []string{"profile", "favorites", "list"}},
+	{"profile_favorites_add", []string{"profile", "favorites", "add", "venue-1"}},
+	{"profile_favorites_remove", []string{"profile", "favorites", "remove", "venue-1"}},
+	{"profile_favorites_hours", []string{"profile", "favorites", "hours"}},
+	{"profile_orders", []string{"profile", "orders"}},
+	{"profile_orders_list", []string{"profile", "orders", "list"}},
+	// profile_orders_show tags the purchase that profile_orders_export then reads back.
+	{"profile_orders_show", []string{"profile", "orders", "show", "purchase-1", "--expense-code", "MEALS", "--cost-center", "CC-1"}},
+	{"profile_orders_export", []string{"profile", "orders", "export"}},
+	{"raw_get", []string{"raw", "get", "/v1/pages/front?lat=60.1&lon=24.9"}},
+	{"raw_post", []string{"raw", "post", "/order-xp/v1/baskets/count", "--body", `{"venue_id":"venue-1"}`}},
+	{"plan_multi", []string{"plan", "multi", "--need", "fries,burger"}},
+	{"suggest", []string{"suggest"}},
+	{"search_venues", []string{"search", "venues", "--query", "burger"}},
+	{"search_items", []string{"search", "items", "--query", "fries"}},
+	{"search_all", []string{"search", "all", "--query", "burger"}},
+	{"chain_branches", []string{"chain", "branches", "Burger Place"}},
+	{"track_add", []string{"track", "add", "burger-place", "item-1"}},
+	{"track_run", []string{"track", "run"}},
+	{"track_chart", []string{"track", "chart", "item-1"}},
+	// notes cases share the journal store: set, then show.
+	{"notes_set", []string{"notes", "set", "ramen-place", "great tonkotsu", "--tag", "ramen"}},
+	{"notes_show", []string{"notes", "show", "ramen-place"}},
+	{"rate", []string{"rate", "purchase-1", "--score", "9", "--note", "fast, hot"}},
+	{"budget_set", []string{"budget", "set", "200", "EUR", "--period", "month"}},
+	{"budget_show", []string{"budget", "show"}},
+	{"budget_clear", []string{"budget", "clear"}},
+	{"approve", []string{"approve", "40", "EUR", "--ttl", "1h"}},
+	{"config_sync", []string{"config", "sync", "--remote", "shared/wolt-shared.json", "--dry-run"}},
+	{"status", []string{"status", "--venue", "burger-place"}},
+	{"travel_set", []string{"travel", "set", "Berlin, Germany"}},
+	{"travel_clear", []string{"travel", "clear"}},
+	{"schedule_install", []string{"schedule", "install", "--command", "track run", "--every", "30m", "--backend", "systemd", "--dry-run"}},
+	{"venue_show", []string{"venue", "show", "burger-place"}},
+	{"venue_categories", []string{"venue", "categories", "burger-place"}},
+	{"venue_menu", []string{"venue", "menu", "burger-place"}},
+	{"venue_search", []string{"venue", "search", "burger-place", "--query", "fries"}},
+	{"venue_search_boolean", []string{"venue", "search", "burger-place", "--query", "fries OR burger -cheese"}},
+	{"venue_shop", []string{"venue", "shop", "burger-place", "--list", "testdata/shopping_list.txt", "--apply"}},
+	{"venue_hours", []string{"venue", "hours", "burger-place"}},
+	{"venue_slots", []string{"venue", "slots", "burger-place", "--date", "2099-01-05"}},
+	{"venue_resolve", []string{"venue", "resolve", "5a8426f188b5de000b8857bb"}},
+	{"venue_known", []string{"venue", "known"}},
+	{"venue_popular", []string{"venue", "popular", "burger-place"}},
+	{"venue_recommendations", []string{"venue", "recommendations", "burger-place"}},
+	{"market_aisles", []string{"market", "aisles", "burger-place"}},
+	{"market_aisle", []string{"market", "aisle", "burger-place", "sides"}},
+	{"market_lookup", []string{"market", "lookup", "burger-place", "--ean", "6415600501194"}},
+	{"item_show", []string{"item", "show", "burger-place", "item-1"}},
+	{"item_options", []string{"item", "options", "burger-place", "item-1"}},
+	{"item_reorder", []string{"item", "reorder", "Fries", "--yes"}},
+}
+
+func TestGoldenCoversEveryCommand(t *testing.T) {
+	covered := map[string]struct{}{}
+	for _, tc := range goldenCases {
+		path := []string{}
+		for _, arg := range tc.args {
+			if strings.HasPrefix(arg, "-") {
+				break
+			}
+			path = append(path, arg)
+		}
+		covered[strings.Join(path, " ")] = struct{}{}
+	}
+	root := cli.NewRootCommand(goldenDeps())
+	for _, path := range leafCommandPaths(root, nil) {
+		found := false
+		for key := range covered {
+			if key == strings.Join(path, " ") || strings.HasPrefix(key, strings.Join(path, " ")+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("command %q has no golden case; add one to goldenCases", strings.Join(path, " "))
+		}
+	}
+}
+
+func TestGoldenCommandDataShapes(t *testing.T) {
+	// track cases run in order against one store: add, then run, then chart.
+	t.Setenv("WOLT_TRACK_DIR", t.TempDir())
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	t.Setenv("WOLT_AUDIT_DIR", t.TempDir())
+	t.Setenv("WOLT_JOURNAL_DIR", t.TempDir())
+	t.Setenv("WOLT_SYNC_DIR", t.TempDir())
+	t.Setenv("WOLT_APPROVAL_DIR", t.TempDir())
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := tc.args
+			if !containsString(args, "--machine") {
+				args = append(append([]string{}, args...), "--format", "json")
+			}
+			_, out := runCLIWithDeps(t, goldenDeps(), args...)
+			payload := mustJSON(t, out)
+			snapshot := map[string]any{"data": payloadShape(payload["data"])}
+			if errorPayload, ok := payload["error"].(map[string]any); ok {
+				snapshot["error_code"] = errorPayload["code"]
+			}
+			assertGolden(t, tc.name, snapshot)
+		})
+	}
+}
+
+// assertGolden compares value with testdata/golden/<name>.json, rewriting it under -update-golden.
+func assertGolden(t *testing.T, name string, value any) {
+	t.Helper()
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal snapshot: %v", err)
+	}
+	encoded = append(encoded, '\n')
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, encoded, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s (run go test ./test/e2e -update-golden to create it): %v", path, err)
+	}
+	if string(expected) != string(encoded) {
+		t.Fatalf("payload shape changed for %s (rerun with -update-golden if intended)\nwant:\n%s\ngot:\n%s", name, expected, encoded)
+	}
+}
+
+// payloadShape reduces a decoded JSON value to its field names and value types.
+// Array element shapes are merged so optional fields across rows are all recorded.
+func payloadShape(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		shape := map[string]any{}
+		for key, nested := range typed {
+			shape[key] = payloadShape(nested)
+		}
+		return shape
+	case []any:
+		if len(typed) == 0 {
+			return []any{}
+		}
+		var merged any
+		for _, nested := range typed {
+			merged = mergeShapes(merged, payloadShape(nested))
+		}
+		return []any{merged}
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case nil:
+		return "null"
+	default:
+		return "unknown"
+	}
+}
+
+func mergeShapes(left any, right any) any {
+	if left == nil {
+		return right
+	}
+	leftMap, leftIsMap := left.(map[string]any)
+	rightMap, rightIsMap := right.(map[string]any)
+	if leftIsMap && rightIsMap {
+		for key, value := range rightMap {
+			leftMap[key] = mergeShapes(leftMap[key], value)
+		}
+		return leftMap
+	}
+	leftText, leftIsText := left.(string)
+	rightText, rightIsText := right.(string)
+	if leftIsText && rightIsText && leftText != rightText {
+		types := strings.Split(leftText, "|")
+		if !containsString(types, rightText) {
+			types = append(types, rightText)
+			sort.Strings(types)
+		}
+		return strings.Join(types, "|")
+	}
+	return left
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// goldenDeps serves one representative upstream payload per endpoint.
+func goldenDeps() cli.Dependencies {
+	venue := buildVenue("venue-1", "burger-place", "Street 1")
+	venue.Country = "FIN"
+	venue.City = "helsinki"
+	venue.Location = []float64{24.94, 60.17}
+	venueItem := domain.Item{Title: "Burger Place", TrackID: "track-1", Link: domain.Link{Target: "venue-1"}, Venue: venue}
+	menuItem := map[string]any{
+		"id":           "item-1",
+		"name":         "Fries",
+		"price":        599,
+		"promotions":   []any{map[string]any{"text": "2 for 1"}},
+		"options":      []any{map[string]any{"option_id": "opt-1"}},
+		"barcode_gtin": "6415600501194",
+	}
+	optionGroup := map[string]any{
+		"id":   "opt-1",
+		"name": "Size",
+		"values": []any{
+			map[string]any{"id": "size-l", "name": "Large", "price": 100},
+		},
+	}
+	basket := map[string]any{
+		"id":    "basket-1",
+		"total": "€17.00",
+		"venue": map[string]any{"id": "venue-1", "slug": "burger-place", "name": "Burger Place"},
+		"items": []any{
+			map[string]any{"id": "item-1", "name": "Fries", "count": 1, "price": 599, "options": []any{}},
+		},
+		"telemetry": map[string]any{"basket_total": 599},
+	}
+	address := map[string]any{
+		"id":         "addr-1",
+		"label_type": "other",
+		"location": map[string]any{
+			"address":     "Street 1",
+			"coordinates": map[string]any{"coordinates": []any{24.9, 60.1}},
+		},
+	}
+
+	return cli.Dependencies{
+		Wolt: &mockWolt{
+			frontPageFunc: func(context.Context, domain.Location) (map[string]any, error) {
+				return map[string]any{"city_data": map[string]any{"name": "Helsinki"}}, nil
+			},
+			sectionsFunc: func(context.Context, domain.Location) ([]domain.Section, error) {
+				return []domain.Section{{Name: "popular", Title: "Popular", Items: []domain.Item{venueItem}}}, nil
+			},
+			itemsFunc: func(context.Context, domain.Location) ([]domain.Item, error) {
+				return []domain.Item{venueItem}, nil
+			},
+			itemBySlugFunc: func(context.Context, domain.Location, string) (*domain.Item, error) {
+				return &venueItem, nil
+			},
+			restaurantByIDFunc: func(context.Context, string) (*domain.Restaurant, error) {
+				return &domain.Restaurant{
+					ID:           "venue-1",
+					Slug:         "burger-place",
+					Name:         []domain.Translation{{Lang: "en", Value: "Burger Place"}},
+					PublicURL:    "https://wolt.com/en/fin/helsinki/restaurant/burger-place",
+					City:         "Helsinki",
+					TimezoneName: "UTC",
+					OpeningTimes: map[string][]domain.Times{
+						"monday": {
+							{Type: "open", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC).UnixMilli()}},
+							{Type: "close", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 20, 0, 0, 0, time.UTC).UnixMilli()}},
+						},
+					},
+					PreorderEnabled: true,
+					PreorderTimes: domain.PreorderTimes{
+						Delivery: map[string][]domain.Times{
+							"monday": {
+								{Type: "open", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 11, 0, 0, 0, time.UTC).UnixMilli()}},
+								{Type: "close", Value: map[string]int64{"$date": time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC).UnixMilli()}},
+							},
+						},
+						TimeStep: 900,
+					},
+				}, nil
+			},
+			searchFunc: func(context.Context, domain.Location, string) (map[string]any, error) {
+				return nil, woltgateway.ErrUpstream
+			},
+			venuePageStaticFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{"venue": map[string]any{"id": "venue-1", "slug": "burger-place", "name": "Burger Place", "show_wolt_plus": true}}, nil
+			},
+			venuePageDynamicFunc: func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			assortmentBySlugFunc: func(context.Context, string) (map[string]any, error) {
+				return map[string]any{
+					"categories": []any{
+						map[string]any{"id": "cat-1", "name": "Sides", "slug": "sides", "item_ids": []any{"item-1"}},
+					},
+					"items":   []any{menuItem},
+					"options": []any{optionGroup},
+				}, nil
+			},
+			venueContentBySlugFn: func(context.Context, string, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"sections": []any{
+						map[string]any{"slug": "most-popular", "name": "Popular", "items": []any{map[string]any{"id": "item-1", "name": "Fries"}}},
+						map[string]any{"slug": "recommended-for-you", "name": "Recommended for you", "item_ids": []any{"item-1"}},
+					},
+				}, nil
+			},
+			assortmentItemsSearchFn: func(context.Context, string, string, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"items": []any{menuItem}}, nil
+			},
+			venueItemPageFunc: func(context.Context, string, string) (map[string]any, error) {
+				return map[string]any{
+					"id":            "item-1",
+					"name":          "Fries",
+					"price":         map[string]any{"amount": 599, "currency": "EUR"},
+					"option_groups": []any{optionGroup},
+				}, nil
+			},
+			refreshAccessTokenFn: func(context.Context, string, woltgateway.AuthContext) (woltgateway.TokenRefreshResult, error) {
+				return woltgateway.TokenRefreshResult{AccessToken: "rotated-token", RefreshToken: "refresh-new", ExpiresIn: 1800}, nil
+			},
+			userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"user": map[string]any{
+						"_id":                     map[string]any{"$oid": "user-1"},
+						"country":                 "FIN",
+						"first_name":              "Test",
+						"is_wolt_plus_subscriber": true,
+					},
+				}, nil
+			},
+			paymentMethodsFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"methods": []any{map[string]any{"id": "card-1", "type": "card", "name": "Visa"}}}, nil
+			},
+			paymentProfileFunc: func(context.Context, woltgateway.AuthContext, woltgateway.PaymentMethodsProfileOptions) (map[string]any, error) {
+				return map[string]any{"methods": []any{map[string]any{"id": "card-1", "type": "card", "name": "Visa"}}}, nil
+			},
+			cardSetupFunc: func(context.Context, woltgateway.AuthContext, woltgateway.CardSetupOptions) (map[string]any, error) {
+				return map[string]any{"session_id": "setup-1", "url": "https://payments.example/setup/setup-1"}, nil
+			},
+			addressFieldsFunc: func(context.Context, domain.Location, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			deliveryInfoListFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"results": []any{address}}, nil
+			},
+			deliveryInfoCreateFn: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				return address, nil
+			},
+			deliveryInfoDeleteFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			orderHistoryFunc: func(context.Context, woltgateway.AuthContext, woltgateway.OrderHistoryOptions) (map[string]any, error) {
+				return map[string]any{
+					"orders": []any{
+						map[string]any{
+							"purchase_id":  "purchase-1",
+							"received_at":  "15/02/2026, 10:06",
+							"status":       "delivered",
+							"venue_name":   "Burger Place",
+							"total_amount": "€15.38",
+							"items":        []any{map[string]any{"name": "Fries"}},
+						},
+					},
+				}, nil
+			},
+			orderHistoryShowFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"order_id":    "purchase-1",
+					"status":      "delivered",
+					"currency":    "EUR",
+					"venue_id":    "venue-1",
+					"venue_name":  "Burger Place",
+					"total_price": 599,
+					"items": []any{
+						map[string]any{"id": "item-1", "name": "Fries", "count": 1, "price": 599, "end_amount": 599, "vat_percentage": 14, "options": []any{}},
+					},
+				}, nil
+			},
+			favoriteVenuesFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"sections": []any{
+						map[string]any{
+							"items": []any{
+								map[string]any{
+									"title": "Burger Place",
+									"venue": map[string]any{"id": "venue-1", "slug": "burger-place", "name": "Burger Place", "favourite": true},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			favoriteVenueAddFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			favoriteVenueRemFn: func(context.Context, string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			basketCountFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"count": 1}, nil
+			},
+			basketsPageFunc: func(context.Context, domain.Location, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"baskets": []any{basket}}, nil
+			},
+			addToBasketFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{"id": "basket-1", "venue_id": "venue-1"}, nil
+			},
+			deleteBasketsFunc: func(context.Context, []string, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{}, nil
+			},
+			checkoutPreviewFunc: func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error) {
+				return map[string]any{
+					"payable_amount": 1819,
+					"payment_breakdown": map[string]any{
+						"total": map[string]any{"formatted_amount": "€18.19"},
+						"taxes": []any{
+							map[string]any{"rate": 14, "amount": 223, "gross_amount": 1819},
+						},
+					},
+					"delivery_configs": []any{},
+					"offers":           map[string]any{"selectable": []any{}, "applied": []any{}},
+					"tip_config":       map[string]any{"min_amount": 50},
+				}, nil
+			},
+			rawFunc: func(context.Context, string, string, []byte, woltgateway.AuthContext) (json.RawMessage, error) {
+				return json.RawMessage(`{"sections":[{"name":"popular","items":[]}],"expires_in_seconds":60}`), nil
+			},
+		},
+		Profiles: &mockProfiles{profile: domain.Profile{
+			Name: "default", IsDefault: true, WToken: "token", Location: domain.Location{Lat: 60.1, Lon: 24.9},
+			Budget: &domain.Budget{Amount: 20000, Currency: "EUR", Period: "month"},
+		}},
+		Location: &mockLocation{},
+		Config: &recordingConfig{loadCfg: domain.Config{Profiles: []domain.Profile{
+			{Name: "default", IsDefault: true, WToken: "token", Location: domain.Location{Lat: 60.1, Lon: 24.9}},
+		}}},
+		Version: "1.1.1",
+	}
+}
@@ -0,0 +1,373 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mekedron/wolt-cli/internal/cli"
+	"github.com/mekedron/wolt-cli/internal/domain"
+	woltgateway "github.com/mekedron/wolt-cli/internal/gateway/wolt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func machineModeDeps() cli.Dependencies {
+	return cli.Dependencies{
+		Wolt: &mockWolt{},
+		Profiles: &mockProfiles{profile: domain.Profile{
+			Name: "default", IsDefault: true, WToken: "test-token", Location: domain.Location{Lat: 60.1, Lon: 24.9},
+		}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+}
+
+func leafCommandPaths(parent *cobra.Command, prefix []string) [][]string {
+	paths := [][]string{}
+	for _, cmd := range parent.Commands() {
+		if cmd.Hidden {
+			continue
+		}
+		path := append(append([]string{}, prefix...), cmd.Name())
+		if cmd.Runnable() && cmd.Flags().Lookup("machine") != nil {
+			paths = append(paths, path)
+		}
+		paths = append(paths, leafCommandPaths(cmd, path)...)
+	}
+	return paths
+}
+
+func placeholderArgs(cmd *cobra.Command) []string {
+	args := []string{}
+	for _, token := range strings.Fields(cmd.Use)[1:] {
+		if strings.HasPrefix(token, "<") {
+			args = append(args, "placeholder")
+		}
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		values, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]
+		if !ok || len(values) == 0 || values[0] != "true" {
+			return
+		}
+		value := "placeholder"
+		switch flag.Value.Type() {
+		case "float64", "int":
+			value = "1"
+		}
+		args = append(args, "--"+flag.Name, value)
+	})
+	return args
+}
+
+func TestMachineModeKeepsStdoutEnvelopeOnly(t *testing.T) {
+	t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	root := cli.NewRootCommand(machineModeDeps())
+	paths := leafCommandPaths(root, nil)
+	if len(paths) < 20 {
+		t.Fatalf("expected to discover every leaf command, got %d", len(paths))
+	}
+	for _, path := range paths {
+		leaf, _, err := root.Find(path)
+		if err != nil {
+			t.Fatalf("find %v: %v", path, err)
+		}
+		args := append(append([]string{}, path...), placeholderArgs(leaf)...)
+		args = append(args, "--machine")
+
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		cli.Execute(context.Background(), args, machineModeDeps(), &stdout, &stderr)
+
+		raw := strings.TrimSpace(stdout.String())
+		if raw == "" {
+			continue
+		}
+		var envelope map[string]any
+		if err := json.Unmarshal([]byte(raw), &envelope); err != nil {
+			t.Fatalf("%v: stdout is not a single JSON envelope: %v\nstdout:\n%s", args, err, raw)
+		}
+		if _, ok := envelope["meta"]; !ok {
+			t.Fatalf("%v: stdout JSON is missing envelope meta\nstdout:\n%s", args, raw)
+		}
+	}
+}
+
+func TestMachineModeRejectsTableFormat(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := cli.Execute(context.Background(), []string{"cart", "count", "--machine", "--format", "table"}, machineModeDeps(), &stdout, &stderr)
+	if exitCode == 0 {
+		t.Fatalf("expected failure for --machine with table format")
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected empty stdout, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "--machine") {
+		t.Fatalf("expected --machine hint on stderr, got %q", stderr.String())
+	}
+}
+
+func TestExpectSetsExitCodeFromResult(t *testing.T) {
+	count := 0
+	deps := machineModeDeps()
+	deps.Wolt = &mockWolt{
+		basketCountFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+			return map[string]any{"count": count}, nil
+		},
+	}
+	run := func(args ...string) (int, string, string) {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		exitCode := cli.Execute(context.Background(), append([]string{"cart", "count", "--machine"}, args...), deps, &stdout, &stderr)
+		return exitCode, stdout.String(), stderr.String()
+	}
+
+	exitCode, stdout, stderr := run("--expect", "data.count>=1")
+	if exitCode != 3 {
+		t.Fatalf("expected exit 3 for a failed expectation, got %d\nstderr:\n%s", exitCode, stderr)
+	}
+	if !strings.Contains(stderr, "expect failed: data.count>=1 (got 0)") {
+		t.Fatalf("expected the failed assertion on stderr, got %q", stderr)
+	}
+	if _, ok := asMapPayload(t, mustJSON(t, stdout))["data"]; !ok {
+		t.Fatalf("expected the envelope on stdout even when an expectation fails, got %q", stdout)
+	}
+
+	count = 2
+	if exitCode, _, stderr := run("--expect", "count >= 1", "--expect", "warnings.length==0", "--expect-nonempty", "count"); exitCode != 0 {
+		t.Fatalf("expected exit 0 when every expectation holds, got %d\nstderr:\n%s", exitCode, stderr)
+	}
+	if exitCode, _, _ := run("--expect", "count ~ 1"); exitCode != 1 {
+		t.Fatalf("expected an invalid expression to fail as an argument error, got %d", exitCode)
+	}
+
+	var stdoutBuf bytes.Buffer
+	var stderrBuf bytes.Buffer
+	if exitCode := cli.Execute(context.Background(), []string{"cart", "count", "--expect", "count>=1"}, deps, &stdoutBuf, &stderrBuf); exitCode == 0 || !strings.Contains(stderrBuf.String(), "--format json or yaml") {
+		t.Fatalf("expected --expect to require a machine format, got %d %q", exitCode, stderrBuf.String())
+	}
+}
+
+func TestEnvelopeCarriesRunIDAndMetaTags(t *testing.T) {
+	deps := machineModeDeps()
+	deps.Wolt = &mockWolt{
+		basketCountFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+			return map[string]any{"count": 1}, nil
+		},
+	}
+	run := func(args ...string) (int, string, string) {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		exitCode := cli.Execute(context.Background(), append([]string{"cart", "count", "--machine"}, args...), deps, &stdout, &stderr)
+		return exitCode, stdout.String(), stderr.String()
+	}
+
+	exitCode, stdout, stderr := run("--meta", "job=nightly", "--meta", "host=ci-1")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", exitCode, stderr)
+	}
+	meta := asMapPayload(t, mustJSON(t, stdout)["meta"])
+	if !strings.HasPrefix(asStringPayload(meta["run_id"]), "run_") || meta["generated_at"] == nil {
+		t.Fatalf("expected a generated run_id and generated_at, got %v", meta)
+	}
+	tags := asMapPayload(t, meta["tags"])
+	if tags["job"] != "nightly" || tags["host"] != "ci-1" {
+		t.Fatalf("expected --meta tags, got %v", tags)
+	}
+
+	t.Setenv("WOLT_RUN_ID", "pipeline-42")
+	_, stdout, _ = run()
+	meta = asMapPayload(t, mustJSON(t, stdout)["meta"])
+	if meta["run_id"] != "pipeline-42" {
+		t.Fatalf("expected WOLT_RUN_ID to set run_id, got %v", meta["run_id"])
+	}
+	if _, ok := meta["tags"]; ok {
+		t.Fatalf("expected no tags without --meta, got %v", meta["tags"])
+	}
+
+	if exitCode, stdout, _ := run("--meta", "nightly"); exitCode == 0 || !strings.Contains(stdout, "--meta expects key=value") {
+		t.Fatalf("expected a malformed --meta to fail, got %d %q", exitCode, stdout)
+	}
+}
+
+func TestRetryRerunsUntilExpectedExitCode(t *testing.T) {
+	calls := 0
+	deps := machineModeDeps()
+	deps.Wolt = &mockWolt{
+		basketCountFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+			calls++
+			return map[string]any{"count": calls - 1}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := cli.Execute(context.Background(), []string{"retry", "--attempts", "5", "--delay", "0s", "--", "cart", "count", "--machine", "--expect", "count>=2"}, deps, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0 once the expectation holds, got %d\nstderr:\n%s", exitCode, stderr.String())
+	}
+	if calls != 3 {
+		t.Fatalf("expected three attempts, got %d", calls)
+	}
+	if count := asIntPayload(asMapPayload(t, mustJSON(t, stdout.String())["data"])["count"]); count != 2 {
+		t.Fatalf("expected stdout to hold only the last attempt, got %q", stdout.String())
+	}
+	if strings.Count(stderr.String(), "[retry] attempt") != 2 {
+		t.Fatalf("expected one progress line per retried attempt, got %q", stderr.String())
+	}
+
+	calls = 0
+	stdout.Reset()
+	stderr.Reset()
+	exitCode = cli.Execute(context.Background(), []string{"retry", "--attempts", "2", "--delay", "0s", "cart", "count", "--machine", "--expect", "count>=5"}, deps, &stdout, &stderr)
+	if exitCode != 3 || calls != 2 {
+		t.Fatalf("expected the last exit code 3 after two attempts, got %d after %d", exitCode, calls)
+	}
+	if !strings.Contains(stderr.String(), "gave up after 2 attempts") {
+		t.Fatalf("expected a give-up message, got %q", stderr.String())
+	}
+}
+
+func TestDiffComparesSavedEnvelopesByRowKey(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, items string) string {
+		path := filepath.Join(dir, name)
+		envelope := `{"meta":{"request_id":"req_` + name + `","profile":"default"},"data":{"items":` + items + `},"warnings":[]}`
+		if err := os.WriteFile(path, []byte(envelope), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	oldPath := write("old.json", `[
+		{"id":"item-1","name":"Fries","price":{"amount":450},"tags":["vegan"]},
+		{"id":"item-2","name":"Cola","price":{"amount":300}},
+		{"name":"No id"}
+	]`)
+	newPath := write("new.json", `[
+		{"id":"item-1","name":"Fries","price":{"amount":490},"tags":["vegan"]},
+		{"id":"item-3","name":"Burger","price":{"amount":1290}}
+	]`)
+
+	exitCode, out := runCLIWithDeps(t, machineModeDeps(), "diff", oldPath, newPath, "--path", "data.items", "--key", "id", "--format", "json")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	payload := mustJSON(t, out)
+	data := asMapPayload(t, payload["data"])
+	if data["path"] != "data.items" || data["key"] != "id" {
+		t.Fatalf("expected path data.items keyed by id, got %v / %v", data["path"], data["key"])
+	}
+	added := asSlicePayload(t, data["added"])
+	removed := asSlicePayload(t, data["removed"])
+	changed := asSlicePayload(t, data["changed"])
+	if len(added) != 1 || asMapPayload(t, added[0])["key"] != "item-3" {
+		t.Fatalf("expected item-3 added, got %v", added)
+	}
+	if len(removed) != 1 || asMapPayload(t, removed[0])["key"] != "item-2" {
+		t.Fatalf("expected item-2 removed, got %v", removed)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected one changed row, got %v", changed)
+	}
+	changes := asSlicePayload(t, asMapPayload(t, changed[0])["changes"])
+	change := asMapPayload(t, changes[0])
+	if len(changes) != 1 || change["field"] != "price.amount" || asIntPayload(change["old"]) != 450 || asIntPayload(change["new"]) != 490 {
+		t.Fatalf("expected price.amount 450 -> 490, got %v", changes)
+	}
+	if asIntPayload(asMapPayload(t, data["old"])["count"]) != 3 || asMapPayload(t, data["old"])["request_id"] != "req_old.json" {
+		t.Fatalf("expected the old envelope summary, got %v", data["old"])
+	}
+	if !containsStringPayload(asSlicePayload(t, payload["warnings"]), "1 row(s) without id were skipped") {
+		t.Fatalf("expected a warning for the row without id, got %v", payload["warnings"])
+	}
+
+	exitCode, out = runCLIWithDeps(t, machineModeDeps(), "diff", oldPath, oldPath, "--path", "items", "--key", "id", "--machine", "--expect", "changed_count==0", "--expect", "added_count==0")
+	if exitCode != 0 {
+		t.Fatalf("expected identical files to pass the assertions, got %d\noutput:\n%s", exitCode, out)
+	}
+	if data := asMapPayload(t, mustJSON(t, out)["data"]); asIntPayload(data["unchanged_count"]) != 2 {
+		t.Fatalf("expected two unchanged rows, got %v", data)
+	}
+
+	exitCode, out = runCLIWithDeps(t, machineModeDeps(), "diff", oldPath, newPath, "--path", "data.items", "--key", "id", "--format", "markdown")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	for _, want := range []string{"# Wolt diff", "## Diff data.items: +1 -1 ~1 (0 unchanged)", "| Change | Key | Field | Old | New |", "| changed | item-1 | price.amount | 450 | 490 |", "- 1 row(s) without id were skipped"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in the markdown diff, got:\n%s", want, out)
+		}
+	}
+
+	exitCode, out = runCLIWithDeps(t, machineModeDeps(), "diff", oldPath, filepath.Join(dir, "missing.json"), "--format", "json")
+	if exitCode != 1 || asMapPayload(t, mustJSON(t, out)["error"])["code"] != "WOLT_INVALID_ARGUMENT" {
+		t.Fatalf("expected WOLT_INVALID_ARGUMENT for a missing file, got %d\n%s", exitCode, out)
+	}
+}
+
+func TestJSONNamingCamelRenamesEnvelopeAndDataKeys(t *testing.T) {
+	deps := machineModeDeps()
+	deps.Wolt = &mockWolt{
+		userMeFunc: func(context.Context, woltgateway.AuthContext) (map[string]any, error) {
+			return map[string]any{"user": map[string]any{"_id": map[string]any{"$oid": "user-1"}, "first_name": "Test"}}, nil
+		},
+	}
+	run := func(args ...string) (int, string) {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		exitCode := cli.Execute(context.Background(), append([]string{"profile", "show", "--format", "json"}, args...), deps, &stdout, &stderr)
+		return exitCode, stdout.String()
+	}
+
+	exitCode, stdout := run("--json-naming", "camel", "--meta", "build_id=7")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s", exitCode, stdout)
+	}
+	payload := mustJSON(t, stdout)
+	meta := asMapPayload(t, payload["meta"])
+	if meta["requestId"] == nil || meta["generatedAt"] == nil || meta["request_id"] != nil {
+		t.Fatalf("expected camelCase meta keys, got %v", meta)
+	}
+	if tags := asMapPayload(t, meta["tags"]); tags["build_id"] != "7" {
+		t.Fatalf("expected --meta tag keys to stay as given, got %v", tags)
+	}
+	data := asMapPayload(t, payload["data"])
+	if data["userId"] == nil || data["user_id"] != nil {
+		t.Fatalf("expected camelCase data keys, got %v", data)
+	}
+
+	_, stdout = run()
+	if asMapPayload(t, mustJSON(t, stdout)["meta"])["request_id"] == nil {
+		t.Fatalf("expected snake_case keys by default, got %s", stdout)
+	}
+
+	if exitCode, stdout := run("--json-naming", "kebab"); exitCode == 0 || !strings.Contains(stdout, "unsupported json naming") {
+		t.Fatalf("expected an unknown naming to fail, got %d %q", exitCode, stdout)
+	}
+
+	// Naming belongs to one run, so concurrent runs do not see each other's.
+	var wg sync.WaitGroup
+	for i := range 8 {
+		camel := i%2 == 0
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			naming := "snake"
+			if camel {
+				naming = "camel"
+			}
+			_, stdout := run("--json-naming", naming)
+			if meta := asMapPayload(t, mustJSON(t, stdout)["meta"]); (meta["requestId"] != nil) != camel {
+				t.Errorf("expected %s keys in a concurrent run, got %v", naming, meta)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -18,6 +21,7 @@ type mockWolt struct {
 	sectionsFunc            func(context.Context, domain.Location) ([]domain.Section, error)
 	itemsFunc               func(context.Context, domain.Location) ([]domain.Item, error)
 	restaurantByIDFunc      func(context.Context, string) (*domain.Restaurant, error)
+	citiesFunc              func(context.Context) (map[string]any, error)
 	searchFunc              func(context.Context, domain.Location, string) (map[string]any, error)
 	venuePageStaticFunc     func(context.Context, string) (map[string]any, error)
 	venuePageDynamicFunc    func(context.Context, string, woltgateway.VenuePageDynamicOptions) (map[string]any, error)
@@ -31,6 +35,8 @@ type mockWolt struct {
 	userMeFunc              func(context.Context, woltgateway.AuthContext) (map[string]any, error)
 	paymentMethodsFunc      func(context.Context, woltgateway.AuthContext) (map[string]any, error)
 	paymentProfileFunc      func(context.Context, woltgateway.AuthContext, woltgateway.PaymentMethodsProfileOptions) (map[string]any, error)
+	paymentBalancesFunc     func(context.Context, woltgateway.AuthContext, string) (map[string]any, error)
+	cardSetupFunc           func(context.Context, woltgateway.AuthContext, woltgateway.CardSetupOptions) (map[string]any, error)
 	addressFieldsFunc       func(context.Context, domain.Location, string, woltgateway.AuthContext) (map[string]any, error)
 	deliveryInfoListFunc    func(context.Context, woltgateway.AuthContext) (map[string]any, error)
 	deliveryInfoCreateFn    func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error)
@@ -46,6 +52,7 @@ type mockWolt struct {
 	deleteBasketsFunc       func(context.Context, []string, woltgateway.AuthContext) (map[string]any, error)
 	checkoutPreviewFunc     func(context.Context, map[string]any, woltgateway.AuthContext) (map[string]any, error)
 	refreshAccessTokenFn    func(context.Context, string, woltgateway.AuthContext) (woltgateway.TokenRefreshResult, error)
+	rawFunc                 func(context.Context, string, string, []byte, woltgateway.AuthContext) (json.RawMessage, error)
 }
 
 func (m *mockWolt) FrontPage(ctx context.Context, location domain.Location) (map[string]any, error) {
@@ -55,6 +62,13 @@ func (m *mockWolt) FrontPage(ctx context.Context, location domain.Location) (map
 	return m.frontPageFunc(ctx, location)
 }
 
+func (m *mockWolt) Cities(ctx context.Context) (map[string]any, error) {
+	if m.citiesFunc == nil {
+		return nil, errors.New("cities not mocked")
+	}
+	return m.citiesFunc(ctx)
+}
+
 func (m *mockWolt) Sections(ctx context.Context, location domain.Location) ([]domain.Section, error) {
 	if m.sectionsFunc == nil {
 		return nil, errors.New("sections not mocked")
@@ -193,6 +207,20 @@ func (m *mockWolt) PaymentMethodsProfile(
 	return m.paymentProfileFunc(ctx, auth, options)
 }
 
+func (m *mockWolt) PaymentBalances(ctx context.Context, auth woltgateway.AuthContext, country string) (map[string]any, error) {
+	if m.paymentBalancesFunc == nil {
+		return nil, errors.New("payment balances not mocked")
+	}
+	return m.paymentBalancesFunc(ctx, auth, country)
+}
+
+func (m *mockWolt) CardSetupSession(ctx context.Context, auth woltgateway.AuthContext, options woltgateway.CardSetupOptions) (map[string]any, error) {
+	if m.cardSetupFunc == nil {
+		return nil, errors.New("card setup not mocked")
+	}
+	return m.cardSetupFunc(ctx, auth, options)
+}
+
 func (m *mockWolt) AddressFields(
 	ctx context.Context,
 	location domain.Location,
@@ -315,6 +343,13 @@ func (m *mockWolt) RefreshAccessToken(
 	return m.refreshAccessTokenFn(ctx, refreshToken, auth)
 }
 
+func (m *mockWolt) Raw(ctx context.Context, method string, target string, body []byte, auth woltgateway.AuthContext) (json.RawMessage, error) {
+	if m.rawFunc == nil {
+		return nil, errors.New("raw not mocked")
+	}
+	return m.rawFunc(ctx, method, target, body, auth)
+}
+
 type mockProfiles struct {
 	profile domain.Profile
 	err     error
@@ -367,6 +402,11 @@ func runCLI(t *testing.T, args ...string) (int, string) {
 
 func runCLIWithDeps(t *testing.T, deps cli.Dependencies, args ...string) (int, string) {
 	t.Helper()
+	// Venue commands record what they see in the local venue map; keep each
+	// test's cache separate unless the test picked a directory itself.
+	if os.Getenv("WOLT_CACHE_DIR") == "" {
+		t.Setenv("WOLT_CACHE_DIR", t.TempDir())
+	}
 	ensureDefaultLocationLookupAuth(args, deps.Profiles)
 	if woltMock, ok := deps.Wolt.(*mockWolt); ok {
 		ensureDefaultDeliveryInfoList(woltMock, deps.Profiles)
@@ -382,7 +422,7 @@ func ensureDefaultLocationLookupAuth(args []string, profiles cli.ProfileResolver
 		return
 	}
 	switch strings.ToLower(strings.TrimSpace(args[0])) {
-	case "discover", "search", "venue", "item":
+	case "discover", "search", "venue", "item", "pick":
 	default:
 		return
 	}
@@ -500,7 +540,7 @@ func TestRootHelpIncludesCommandDescriptions(t *testing.T) {
 		}
 	}
 	for _, token := range []string{
-		"--format: Output format: table, json, or yaml.",
+		"--format: Output format: table, json, yaml, ha-sensor, beancount, ics, or markdown.",
 		"--profile: Profile name for saved local defaults.",
 		"--address: Temporary address override for this command. Geocoded to coordinates. Cannot be combined with --lat/--lon.",
 		"--locale: Response locale in BCP-47 format, for example en-FI.",
@@ -514,6 +554,85 @@ func TestRootHelpIncludesCommandDescriptions(t *testing.T) {
 	}
 }
 
+func TestPluginRunsAsSubcommandWithProfileEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixture is a shell script")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"echo \"args=$*\"\n" +
+		"echo \"profile=$WOLT_PROFILE format=$WOLT_FORMAT auth=$WOLT_PLUGIN_AUTH token=$WOLT_WTOKEN lat=$WOLT_LAT\"\n" +
+		"exit 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "wolt-hello"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("WOLT_WTOKEN", "")
+
+	profile := domain.Profile{Name: "work", IsDefault: true, WToken: "secret-token", Location: domain.Location{Lat: 60.17, Lon: 24.94}}
+	deps := cli.Dependencies{
+		Wolt:     &mockWolt{},
+		Profiles: &mockProfiles{profile: profile},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "hello", "world", "--format", "json")
+	if exitCode != 3 {
+		t.Fatalf("expected the plugin exit code 3, got %d\noutput:\n%s", exitCode, out)
+	}
+	if !strings.Contains(out, "args=world --format json") || !strings.Contains(out, "profile=work format=json auth=0 token= lat=60.17") {
+		t.Fatalf("expected forwarded args and env without credentials, got:\n%s", out)
+	}
+
+	profile.PluginAuth = []string{"hello"}
+	deps.Profiles = &mockProfiles{profile: profile}
+	_, out = runCLIWithDeps(t, deps, "hello")
+	if !strings.Contains(out, "auth=1 token=secret-token") {
+		t.Fatalf("expected credentials for a plugin listed in plugin_auth, got:\n%s", out)
+	}
+
+	_, out = runCLIWithDeps(t, deps, "--help")
+	if !strings.Contains(out, "plugins:\n  hello\n") {
+		t.Fatalf("expected root help to list the plugin, got:\n%s", out)
+	}
+	if exitCode, _ := runCLIWithDeps(t, deps, "no-such-plugin"); exitCode != 2 {
+		t.Fatalf("expected unknown command exit 2, got %d", exitCode)
+	}
+}
+
+func TestPluginOutsidePluginAuthDoesNotInheritEnvCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixture is a shell script")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"echo \"auth=$WOLT_PLUGIN_AUTH token=$WOLT_WTOKEN refresh=$WOLT_WRTOKEN\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "wolt-hello"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("WOLT_WTOKEN", "env-token")
+	t.Setenv("WOLT_WRTOKEN", "env-refresh")
+
+	deps := cli.Dependencies{
+		Wolt:     &mockWolt{},
+		Profiles: &mockProfiles{profile: domain.Profile{Name: "work", IsDefault: true, PluginAuth: []string{"other"}}},
+		Location: &mockLocation{},
+		Config:   &mockConfig{},
+		Version:  "1.1.1",
+	}
+
+	exitCode, out := runCLIWithDeps(t, deps, "hello")
+	if exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\noutput:\n%s", exitCode, out)
+	}
+	if strings.Contains(out, "env-token") || strings.Contains(out, "env-refresh") || !strings.Contains(out, "auth=0 token= refresh=") {
+		t.Fatalf("expected the untrusted plugin not to see WOLT_WTOKEN/WOLT_WRTOKEN, got:\n%s", out)
+	}
+}
+
 func TestDiscoverWithoutSubcommandShowsHelp(t *testing.T) {
 	deps := cli.Dependencies{
 		Wolt: &mockWolt{},
@@ -0,0 +1,24 @@
+package e2e_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain keeps profile locks out of the directory next to the mock config
+// path, which every test run would otherwise share.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "wolt-locks-*")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.Setenv("WOLT_LOCK_DIR", dir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}